@@ -1,11 +1,18 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"math"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
 
 	"github.com/alecthomas/chroma/v2/styles"
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,6 +20,7 @@ import (
 	"github.com/azizuysal/simtool/internal/config"
 	"github.com/azizuysal/simtool/internal/simulator"
 	"github.com/azizuysal/simtool/internal/tui"
+	"github.com/azizuysal/simtool/internal/ui"
 )
 
 const appName = "simtool"
@@ -25,6 +33,15 @@ var (
 	builtBy = "unknown"
 )
 
+// VersionInfo is the machine-readable form of the build variables,
+// printed by `--version --json`.
+type VersionInfo struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+	Date    string `json:"date"`
+	BuiltBy string `json:"built_by"`
+}
+
 func main() {
 	// Detect terminal theme before starting TUI
 	config.InitializeThemeDetection()
@@ -33,10 +50,23 @@ func main() {
 	var (
 		generateConfig bool
 		showConfigPath bool
+		validateConfig bool
+		migrateConfig  bool
 		listThemes     bool
+		previewThemes  bool
 		showHelp       bool
 		showVersion    bool
+		jsonOutput     bool
 		startWithApps  bool
+		reloadConfig   bool
+		profile        string
+		simUDID        string
+		printContainer string
+		appBundleID    string
+		catPath        string
+		treeBundleID   string
+		treeDepth      int
+		treeDirsOnly   bool
 	)
 
 	flag.BoolVar(&generateConfig, "generate-config", false, "Generate example configuration file")
@@ -45,18 +75,40 @@ func main() {
 	flag.BoolVar(&showConfigPath, "show-config-path", false, "Show configuration file path")
 	flag.BoolVar(&showConfigPath, "c", false, "Show configuration file path")
 
+	flag.BoolVar(&validateConfig, "validate-config", false, "Check the configuration file for errors")
+	flag.BoolVar(&validateConfig, "C", false, "Check the configuration file for errors")
+
+	flag.BoolVar(&migrateConfig, "migrate-config", false, "Rename deprecated keys in the configuration file")
+
+	flag.StringVar(&profile, "profile", "", "Use <name>.toml from the config directory instead of config.toml")
+	flag.StringVar(&profile, "p", "", "Use <name>.toml from the config directory instead of config.toml")
+
 	flag.BoolVar(&listThemes, "list-themes", false, "List available syntax highlighting themes")
 	flag.BoolVar(&listThemes, "l", false, "List available syntax highlighting themes")
 
+	flag.BoolVar(&previewThemes, "preview", false, "With --list-themes, render a code sample in each theme")
+
 	flag.BoolVar(&showHelp, "help", false, "Show help message")
 	flag.BoolVar(&showHelp, "h", false, "Show help message")
 
 	flag.BoolVar(&showVersion, "version", false, "Show version information")
 	flag.BoolVar(&showVersion, "v", false, "Show version information")
 
+	flag.BoolVar(&jsonOutput, "json", false, "With --version, print version information as JSON")
+
 	flag.BoolVar(&startWithApps, "apps", false, "Start with all apps view instead of simulator list")
 	flag.BoolVar(&startWithApps, "a", false, "Start with all apps view instead of simulator list")
 
+	flag.BoolVar(&reloadConfig, "reload-config", false, "Signal a running simtool to reload its configuration")
+
+	flag.StringVar(&simUDID, "sim", "", "Simulator UDID for --container, --cat and --tree")
+	flag.StringVar(&printContainer, "container", "", "With --sim, print the data container path of <bundleID> and exit")
+	flag.StringVar(&appBundleID, "app", "", "App bundle ID for --cat")
+	flag.StringVar(&catPath, "cat", "", "With --sim and --app, print a file from the app's data container and exit")
+	flag.StringVar(&treeBundleID, "tree", "", "With --sim, print the file tree of <bundleID>'s data container and exit")
+	flag.IntVar(&treeDepth, "depth", 0, "With --tree, descend at most <n> levels (0 for no limit)")
+	flag.BoolVar(&treeDirsOnly, "dirs-only", false, "With --tree, list directories only")
+
 	// Custom usage function
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\n", appName)
@@ -65,9 +117,22 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  -a, --apps                Start with all apps view instead of simulator list\n")
 		fmt.Fprintf(os.Stderr, "  -g, --generate-config     Generate example configuration file\n")
 		fmt.Fprintf(os.Stderr, "  -c, --show-config-path    Show configuration file path\n")
+		fmt.Fprintf(os.Stderr, "  -p, --profile <name>      Use <name>.toml from the config directory instead of config.toml\n")
+		fmt.Fprintf(os.Stderr, "  -C, --validate-config     Check the configuration file for errors\n")
+		fmt.Fprintf(os.Stderr, "      --migrate-config      Rename deprecated keys in the configuration file\n")
 		fmt.Fprintf(os.Stderr, "  -l, --list-themes         List available syntax highlighting themes\n")
+		fmt.Fprintf(os.Stderr, "      --preview             With --list-themes, render a code sample in each theme\n")
+		fmt.Fprintf(os.Stderr, "      --reload-config       Signal a running simtool to reload its configuration\n")
+		fmt.Fprintf(os.Stderr, "      --container <bundle>  With --sim <udid>, print the app's data container path\n")
+		fmt.Fprintf(os.Stderr, "      --cat <path>          With --sim <udid> --app <bundle>, print a file from the app's data container\n")
+		fmt.Fprintf(os.Stderr, "      --tree <bundle>       With --sim <udid>, print the app's data container as a tree\n")
+		fmt.Fprintf(os.Stderr, "      --depth <n>           With --tree, descend at most <n> levels\n")
+		fmt.Fprintf(os.Stderr, "      --dirs-only           With --tree, list directories only\n")
+		fmt.Fprintf(os.Stderr, "      --sim <udid>          Simulator to use with --container, --cat and --tree\n")
+		fmt.Fprintf(os.Stderr, "      --app <bundle>        App to use with --cat\n")
 		fmt.Fprintf(os.Stderr, "  -h, --help                Show help message\n")
 		fmt.Fprintf(os.Stderr, "  -v, --version             Show version information\n")
+		fmt.Fprintf(os.Stderr, "      --json                With --version, print version information as JSON\n")
 	}
 
 	flag.Parse()
@@ -79,6 +144,21 @@ func main() {
 	}
 
 	// Handle version flag
+	if showVersion && jsonOutput {
+		out, err := json.MarshalIndent(VersionInfo{
+			Version: version,
+			Commit:  commit,
+			Date:    date,
+			BuiltBy: builtBy,
+		}, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding version: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(out))
+		return
+	}
+
 	if showVersion {
 		fmt.Printf("%s version %s\n", appName, version)
 		if commit != "none" {
@@ -94,6 +174,33 @@ func main() {
 	}
 
 	// Handle config-related flags
+	if generateConfig && profile != "" {
+		path, err := config.SaveProfileExample(profile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating profile: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Profile created at: %s\n", path)
+		fmt.Printf("Customize it and run: %s --profile %s\n", appName, profile)
+		return
+	}
+
+	if profile != "" {
+		if err := config.SetProfile(profile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			if profiles, _ := config.ListProfiles(); len(profiles) > 0 {
+				fmt.Fprintf(os.Stderr, "Available profiles: %s\n", strings.Join(profiles, ", "))
+			}
+			os.Exit(1)
+		}
+		// Styles were generated from config.toml during package init;
+		// regenerate them from the selected profile.
+		if err := ui.ReloadStyles(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading profile: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	if generateConfig {
 		if err := config.SaveExample(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error generating config: %v\n", err)
@@ -113,16 +220,54 @@ func main() {
 	}
 
 	if showConfigPath {
-		configDir := os.Getenv("XDG_CONFIG_HOME")
-		if configDir == "" {
-			home, _ := os.UserHomeDir()
-			configDir = filepath.Join(home, ".config")
+		configPath, err := config.Path()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error resolving config path: %v\n", err)
+			os.Exit(1)
 		}
-		configPath := filepath.Join(configDir, "simtool", "config.toml")
 		fmt.Printf("Configuration file path: %s\n", configPath)
 		return
 	}
 
+	if validateConfig {
+		path, issues, err := config.Check()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error validating config: %v\n", err)
+			os.Exit(1)
+		}
+		if len(issues) == 0 {
+			fmt.Printf("%s: configuration is valid\n", path)
+			return
+		}
+		for _, issue := range issues {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", path, issue)
+		}
+		fmt.Fprintf(os.Stderr, "\n%d problem(s) found\n", len(issues))
+		os.Exit(1)
+	}
+
+	if migrateConfig {
+		path, renamed, err := config.Migrate()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error migrating config: %v\n", err)
+			os.Exit(1)
+		}
+		if len(renamed) == 0 {
+			fmt.Printf("%s: no deprecated keys found\n", path)
+			return
+		}
+		for _, key := range renamed {
+			fmt.Printf("%s: renamed %s to %s\n", path, key, config.RenamedKey(key))
+		}
+		fmt.Printf("Original saved as %s.bak\n", path)
+		return
+	}
+
+	if listThemes && previewThemes {
+		printThemePreviews()
+		return
+	}
+
 	if listThemes {
 		fmt.Println("Available syntax highlighting themes:")
 		fmt.Println()
@@ -178,6 +323,77 @@ func main() {
 		return
 	}
 
+	if reloadConfig {
+		if err := signalReload(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error reloading config: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("Sent reload signal to running simtool.")
+		return
+	}
+
+	if printContainer != "" {
+		if simUDID == "" {
+			fmt.Fprintf(os.Stderr, "Error: --container requires --sim <udid>\n")
+			os.Exit(1)
+		}
+		path, err := simulator.GetAppContainer(simUDID, printContainer)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		// Print only the path so the output can be used in cd $(...)
+		fmt.Println(path)
+		return
+	}
+
+	if catPath != "" {
+		if simUDID == "" || appBundleID == "" {
+			fmt.Fprintf(os.Stderr, "Error: --cat requires --sim <udid> and --app <bundleID>\n")
+			os.Exit(1)
+		}
+		path, err := simulator.ResolveContainerPath(simUDID, appBundleID, catPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := simulator.CatFile(os.Stdout, path); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if treeBundleID != "" {
+		if simUDID == "" {
+			fmt.Fprintf(os.Stderr, "Error: --tree requires --sim <udid>\n")
+			os.Exit(1)
+		}
+		if treeDepth < 0 {
+			fmt.Fprintf(os.Stderr, "Error: --depth must not be negative\n")
+			os.Exit(1)
+		}
+		container, err := simulator.GetAppContainer(simUDID, treeBundleID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		maxDepth := treeDepth
+		if maxDepth == 0 {
+			maxDepth = math.MaxInt
+		}
+		nodes, err := simulator.ReadContainerTree(container, maxDepth)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := simulator.WriteTree(os.Stdout, container, nodes, treeDirsOnly); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Set up debug logging. The file goes under the user cache directory
 	// (e.g. ~/Library/Caches/simtool/debug.log on macOS) rather than the
 	// process working directory, which would pollute wherever the user
@@ -198,7 +414,26 @@ func main() {
 	model := tui.New(fetcher, startWithApps)
 	p := tea.NewProgram(model, tea.WithAltScreen())
 
+	// Reload configuration on SIGHUP. The PID file lets
+	// `simtool --reload-config` find this process.
+	pidPath, err := writePIDFile()
+	if err != nil {
+		log.Printf("failed to write pid file: %s", err)
+	}
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			cfg, err := config.Load()
+			p.Send(tui.ReloadConfigMsg(cfg, err))
+		}
+	}()
+
 	_, runErr := p.Run()
+	signal.Stop(hup)
+	if pidPath != "" {
+		_ = os.Remove(pidPath)
+	}
 	_ = f.Close()
 	if runErr != nil {
 		log.Printf("Error running program: %s", runErr)
@@ -206,6 +441,87 @@ func main() {
 	}
 }
 
+// themePreviewSnippet is the one-line sample rendered by
+// `--list-themes --preview`. It is short enough to fit beside the theme name
+// on a standard 80-column terminal.
+const themePreviewSnippet = `func greet(name string) string { return "Hello, " + name } // 42`
+
+// printThemePreviews prints every available chroma theme followed by
+// themePreviewSnippet highlighted in that theme.
+func printThemePreviews() {
+	formatter := previewFormatter()
+	themes := styles.Names()
+
+	width := 0
+	for _, theme := range themes {
+		width = max(width, len(theme))
+	}
+
+	for _, theme := range themes {
+		line := simulator.HighlightWithTheme(themePreviewSnippet, ".go", theme, formatter)
+		fmt.Printf("%-*s  %s\x1b[0m\n", width, theme, line)
+	}
+}
+
+// previewFormatter picks the chroma terminal formatter for theme previews.
+// Truecolor output is only used when $COLORTERM advertises it; everything
+// else gets the 256-color formatter, which most terminals render correctly.
+func previewFormatter() string {
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return "terminal16m"
+	default:
+		return "terminal256"
+	}
+}
+
+// pidFilePath returns the path of the file recording the PID of the
+// running TUI. It lives next to the debug log.
+func pidFilePath() (string, error) {
+	logPath, err := debugLogPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(logPath), "simtool.pid"), nil
+}
+
+// writePIDFile records the current PID and returns the file path so the
+// caller can remove it on exit.
+func writePIDFile() (string, error) {
+	path, err := pidFilePath()
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(strconv.Itoa(os.Getpid())), 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// signalReload sends SIGHUP to the simtool process recorded in the PID
+// file, asking it to reload its configuration.
+func signalReload() error {
+	path, err := pidFilePath()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return errors.New("no running simtool found")
+		}
+		return err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return fmt.Errorf("invalid pid file %s: %w", path, err)
+	}
+	if err := syscall.Kill(pid, syscall.SIGHUP); err != nil {
+		return fmt.Errorf("signaling pid %d: %w", pid, err)
+	}
+	return nil
+}
+
 // debugLogPath returns the path for simtool's debug log file, ensuring
 // the parent directory exists with user-only permissions.
 func debugLogPath() (string, error) {
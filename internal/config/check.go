@@ -0,0 +1,341 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/alecthomas/chroma/v2/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Issue is a single problem found by Check. Unlike the error returned
+// by Load, which stops at the first failing stage, Check collects every
+// issue it can find so the user can fix them all in one pass.
+type Issue struct {
+	Key     string // Dotted TOML key, e.g. "theme.dark_theme"
+	Line    int    // 1-based line in the config file; 0 if unknown
+	Message string
+	Hint    string // Suggested fix; may be empty
+}
+
+// String formats the issue as "line N: key: message (hint)".
+func (i Issue) String() string {
+	var b strings.Builder
+	if i.Line > 0 {
+		fmt.Fprintf(&b, "line %d: ", i.Line)
+	}
+	if i.Key != "" {
+		fmt.Fprintf(&b, "%s: ", i.Key)
+	}
+	b.WriteString(i.Message)
+	if i.Hint != "" {
+		fmt.Fprintf(&b, "\n    hint: %s", i.Hint)
+	}
+	return b.String()
+}
+
+// Check validates the config file at the standard path and returns the
+// path together with any issues found. A missing file is valid (defaults
+// apply). The error is non-nil only if the file could not be read.
+func Check() (string, []Issue, error) {
+	path, err := getConfigPath()
+	if err != nil {
+		return "", nil, fmt.Errorf("getting config path: %w", err)
+	}
+	issues, err := checkPath(path)
+	return path, issues, err
+}
+
+// checkPath is the testable core of Check.
+func checkPath(path string) ([]Issue, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+
+	userCfg := &Config{}
+	md, legacy, err := decodeConfig(data, userCfg)
+	if err != nil {
+		var perr toml.ParseError
+		if errors.As(err, &perr) {
+			return []Issue{{
+				Line:    perr.Position.Line,
+				Message: perr.Message,
+				Hint:    "fix the TOML syntax (strings need double quotes, arrays use [\"a\", \"b\"])",
+			}}, nil
+		}
+		return []Issue{{Message: err.Error()}}, nil
+	}
+
+	var issues []Issue
+
+	for _, old := range legacy {
+		issues = append(issues, Issue{
+			Key:     old,
+			Line:    findKeyLine(data, old),
+			Message: fmt.Sprintf("deprecated key, renamed to %s", legacyKeyRenames[old]),
+			Hint:    "run 'simtool --migrate-config' to update the file",
+		})
+	}
+
+	known := knownConfigKeys()
+	for _, k := range md.Undecoded() {
+		key := k.String()
+		issue := Issue{
+			Key:     key,
+			Line:    findKeyLine(data, key),
+			Message: "unknown key",
+			Hint:    "remove it",
+		}
+		if match := closestMatch(key, known); match != "" {
+			issue.Hint = fmt.Sprintf("did you mean %q?", match)
+		}
+		issues = append(issues, issue)
+	}
+
+	if c := userCfg.Theme.Mode; c != "" && !stringInSlice(c, validThemeModes) {
+		issues = append(issues, Issue{
+			Key:     "theme.mode",
+			Line:    findKeyLine(data, "theme.mode"),
+			Message: fmt.Sprintf("%q is not a valid mode", c),
+			Hint:    "use one of " + quoteList(validThemeModes),
+		})
+	}
+
+	themes := styles.Names()
+	for _, field := range []struct{ key, name string }{
+		{"theme.dark_theme", userCfg.Theme.DarkTheme},
+		{"theme.light_theme", userCfg.Theme.LightTheme},
+	} {
+		key, name := field.key, field.name
+		if name == "" || stringInSlice(name, themes) {
+			continue
+		}
+		issue := Issue{
+			Key:     key,
+			Line:    findKeyLine(data, key),
+			Message: fmt.Sprintf("unknown theme %q", name),
+			Hint:    "run 'simtool --list-themes' to see available themes",
+		}
+		if match := closestMatch(name, themes); match != "" {
+			issue.Hint = fmt.Sprintf("did you mean %q? %s", match, issue.Hint)
+		}
+		issues = append(issues, issue)
+	}
+
+	if v := userCfg.Startup.InitialView; v != "" && !stringInSlice(v, validInitialViews) {
+		issues = append(issues, Issue{
+			Key:     "startup.initial_view",
+			Line:    findKeyLine(data, "startup.initial_view"),
+			Message: fmt.Sprintf("%q is not a valid view", v),
+			Hint:    "use one of " + quoteList(validInitialViews),
+		})
+	}
+
+	if v := userCfg.Display.DateFormat; v != "" && !stringInSlice(v, validDateFormats) {
+		issues = append(issues, Issue{
+			Key:     "display.date_format",
+			Line:    findKeyLine(data, "display.date_format"),
+			Message: fmt.Sprintf("%q is not a valid date format", v),
+			Hint:    "use one of " + quoteList(validDateFormats),
+		})
+	}
+
+	for _, v := range userCfg.Display.Platforms {
+		if !stringInSlice(v, validPlatforms) {
+			issues = append(issues, Issue{
+				Key:     "display.platforms",
+				Line:    findKeyLine(data, "display.platforms"),
+				Message: fmt.Sprintf("%q is not a platform", v),
+				Hint:    "use any of " + quoteList(validPlatforms),
+			})
+		}
+	}
+
+	if v := userCfg.Viewer.BinaryChunkSize; v < 0 || v%16 != 0 {
+		issues = append(issues, Issue{
+			Key:     "viewer.binary_chunk_size",
+			Line:    findKeyLine(data, "viewer.binary_chunk_size"),
+			Message: fmt.Sprintf("%d is not a valid chunk size", v),
+			Hint:    "use a positive multiple of 16, e.g. 8192",
+		})
+	}
+
+	if v := userCfg.Viewer.RowCountCacheSeconds; v < 0 {
+		issues = append(issues, Issue{
+			Key:     "viewer.row_count_cache_seconds",
+			Line:    findKeyLine(data, "viewer.row_count_cache_seconds"),
+			Message: fmt.Sprintf("%d is not a valid number of seconds", v),
+			Hint:    "use a positive number of seconds, e.g. 60",
+		})
+	}
+
+	issues = append(issues, checkKeyBindings(data, userCfg.Keys)...)
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Line < issues[j].Line
+	})
+	return issues, nil
+}
+
+// checkKeyBindings reports key strings that Bubble Tea would never
+// produce, which means the binding can never fire.
+func checkKeyBindings(data []byte, keys KeysConfig) []Issue {
+	var issues []Issue
+	v := reflect.ValueOf(keys)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("toml")
+		bindings, ok := v.Field(i).Interface().([]string)
+		if !ok {
+			continue
+		}
+		for _, b := range bindings {
+			if isKnownKey(b) {
+				continue
+			}
+			issue := Issue{
+				Key:     "keys." + tag,
+				Line:    findKeyLine(data, "keys."+tag),
+				Message: fmt.Sprintf("unrecognized key %q", b),
+				Hint:    `use a Bubble Tea key name such as "enter", "ctrl+a", "pgdown" or a single character`,
+			}
+			if b == "space" {
+				issue.Hint = `the space bar is written as " "`
+			}
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// bubbleTeaKeyNames lists every named key Bubble Tea can report, taken
+// from tea.KeyType's String method so it tracks the library version.
+var bubbleTeaKeyNames = func() map[string]bool {
+	names := make(map[string]bool)
+	for kt := tea.KeyType(-256); kt < 256; kt++ {
+		if s := kt.String(); s != "" {
+			names[s] = true
+		}
+	}
+	return names
+}()
+
+// isKnownKey reports whether s is a key string that tea.KeyMsg.String
+// can return: a named key or a single character, optionally with an
+// "alt+" prefix.
+func isKnownKey(s string) bool {
+	s = strings.TrimPrefix(s, "alt+")
+	if bubbleTeaKeyNames[s] {
+		return true
+	}
+	return len([]rune(s)) == 1
+}
+
+// knownConfigKeys returns every dotted key the Config struct accepts.
+func knownConfigKeys() []string {
+	var keys []string
+	ct := reflect.TypeFor[Config]()
+	for i := 0; i < ct.NumField(); i++ {
+		section := ct.Field(i)
+		name := section.Tag.Get("toml")
+		if name == "-" {
+			continue
+		}
+		keys = append(keys, name)
+		for j := 0; j < section.Type.NumField(); j++ {
+			keys = append(keys, name+"."+section.Type.Field(j).Tag.Get("toml"))
+		}
+	}
+	return keys
+}
+
+// findKeyLine returns the 1-based line on which the dotted key (or the
+// table header, for a single-component key) is defined, or 0 if it
+// cannot be located. It understands plain [table] headers and bare
+// keys, which covers everything the config schema uses.
+func findKeyLine(data []byte, dotted string) int {
+	table, key := "", dotted
+	if i := strings.LastIndex(dotted, "."); i >= 0 {
+		table, key = dotted[:i], dotted[i+1:]
+	}
+
+	current := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]") {
+			current = strings.TrimSpace(strings.Trim(text, "[]"))
+			if table == "" && current == key {
+				return line
+			}
+			continue
+		}
+		if current != table {
+			continue
+		}
+		name, _, found := strings.Cut(text, "=")
+		if found && strings.TrimSpace(name) == key {
+			return line
+		}
+	}
+	return 0
+}
+
+// closestMatch returns the candidate with the smallest edit distance to
+// s, provided the distance is small enough to be a plausible typo.
+func closestMatch(s string, candidates []string) string {
+	best, bestDist := "", len(s)/3+1
+	for _, c := range candidates {
+		if d := levenshtein(s, c); d > 0 && d <= bestDist {
+			if d < bestDist || best == "" {
+				best, bestDist = c, d
+			}
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
+
+// quoteList formats values as `"a", "b" or "c"`.
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	if len(quoted) == 1 {
+		return quoted[0]
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " or " + quoted[len(quoted)-1]
+}
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckPath_MissingFileIsValid(t *testing.T) {
+	issues, err := checkPath(filepath.Join(t.TempDir(), "nope.toml"))
+	if err != nil {
+		t.Fatalf("checkPath: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("issues = %v, want none", issues)
+	}
+}
+
+func TestCheckPath_ValidConfig(t *testing.T) {
+	path := writeTOML(t, `
+[theme]
+mode = "dark"
+dark_theme = "dracula"
+
+[keys]
+up = ["up", "k", "ctrl+p", "alt+k", " "]
+
+[startup]
+initial_view = "all_apps"
+`)
+	issues, err := checkPath(path)
+	if err != nil {
+		t.Fatalf("checkPath: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("issues = %v, want none", issues)
+	}
+}
+
+func TestCheckPath_ReportsLegacyKey(t *testing.T) {
+	path := writeTOML(t, `
+[syntax]
+theme = "dracula"
+`)
+	issues, err := checkPath(path)
+	if err != nil {
+		t.Fatalf("checkPath: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("issues = %v, want one", issues)
+	}
+	if issues[0].Key != "syntax.theme" || issues[0].Line != 3 {
+		t.Errorf("issue = %+v, want syntax.theme on line 3", issues[0])
+	}
+	if !strings.Contains(issues[0].Hint, "--migrate-config") {
+		t.Errorf("hint = %q, want to mention --migrate-config", issues[0].Hint)
+	}
+}
+
+func TestCheckPath_ReportsEachProblem(t *testing.T) {
+	path := writeTOML(t, `[theme]
+mode = "drak"
+dark_theme = "drakula"
+
+[keys]
+up = ["space"]
+down = ["ctrl+zz"]
+
+[startup]
+inital_view = "all_apps"
+`)
+	issues, err := checkPath(path)
+	if err != nil {
+		t.Fatalf("checkPath: %v", err)
+	}
+
+	want := []struct {
+		key  string
+		line int
+		hint string
+	}{
+		{"theme.mode", 2, `"auto", "dark" or "light"`},
+		{"theme.dark_theme", 3, `did you mean "dracula"`},
+		{"keys.up", 6, `" "`},
+		{"keys.down", 7, "Bubble Tea key name"},
+		{"startup.inital_view", 10, `did you mean "startup.initial_view"`},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("got %d issues, want %d: %v", len(issues), len(want), issues)
+	}
+	for i, w := range want {
+		got := issues[i]
+		if got.Key != w.key || got.Line != w.line {
+			t.Errorf("issue %d = %s@%d, want %s@%d", i, got.Key, got.Line, w.key, w.line)
+		}
+		if !strings.Contains(got.Hint, w.hint) {
+			t.Errorf("issue %d hint = %q, want it to contain %q", i, got.Hint, w.hint)
+		}
+	}
+}
+
+func TestCheckPath_SyntaxErrorHasLine(t *testing.T) {
+	path := writeTOML(t, "[theme]\nmode = \"dark\"\ndark_theme = dracula\n")
+	issues, err := checkPath(path)
+	if err != nil {
+		t.Fatalf("checkPath: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("issues = %v, want exactly one", issues)
+	}
+	if issues[0].Line != 3 {
+		t.Errorf("line = %d, want 3", issues[0].Line)
+	}
+}
+
+func TestIssueString(t *testing.T) {
+	got := Issue{Key: "theme.mode", Line: 4, Message: "bad", Hint: "fix it"}.String()
+	want := "line 4: theme.mode: bad\n    hint: fix it"
+	if got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestClosestMatch(t *testing.T) {
+	candidates := []string{"dracula", "monokai", "github-dark"}
+	if got := closestMatch("drakula", candidates); got != "dracula" {
+		t.Errorf("closestMatch(drakula) = %q, want dracula", got)
+	}
+	if got := closestMatch("zzzzzz", candidates); got != "" {
+		t.Errorf("closestMatch(zzzzzz) = %q, want no match", got)
+	}
+}
+
+func TestCheckPath_ReportsBinaryChunkSize(t *testing.T) {
+	path := writeTOML(t, `[viewer]
+binary_chunk_size = 1000
+`)
+	issues, err := checkPath(path)
+	if err != nil {
+		t.Fatalf("checkPath: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Key != "viewer.binary_chunk_size" || issues[0].Line != 2 {
+		t.Errorf("issues = %v, want viewer.binary_chunk_size on line 2", issues)
+	}
+}
+
+func TestCheckPath_ReportsDateFormat(t *testing.T) {
+	path := writeTOML(t, `[display]
+date_format = "short"
+`)
+	issues, err := checkPath(path)
+	if err != nil {
+		t.Fatalf("checkPath: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Key != "display.date_format" || !strings.Contains(issues[0].Hint, `"relative", "absolute" or "iso"`) {
+		t.Errorf("issues = %v, want display.date_format with the valid formats", issues)
+	}
+}
+
+func TestCheckPath_ReportsPlatform(t *testing.T) {
+	path := writeTOML(t, `[display]
+platforms = ["iOS", "macOS"]
+`)
+	issues, err := checkPath(path)
+	if err != nil {
+		t.Fatalf("checkPath: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Key != "display.platforms" || !strings.Contains(issues[0].Message, `"macOS"`) {
+		t.Errorf("issues = %v, want display.platforms for macOS", issues)
+	}
+}
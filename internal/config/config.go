@@ -1,9 +1,12 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/BurntSushi/toml"
@@ -15,12 +18,30 @@ var validThemeModes = []string{"auto", "dark", "light"}
 // validInitialViews is the set of accepted startup.initial_view values.
 var validInitialViews = []string{"simulator_list", "all_apps"}
 
+// validDateFormats is the set of accepted display.date_format values.
+var validDateFormats = []string{"relative", "absolute", "iso"}
+
+// validPlatforms is the set of accepted display.platforms entries.
+var validPlatforms = []string{"iOS", "watchOS", "tvOS", "visionOS"}
+
 // Config represents the application configuration
 type Config struct {
 	Theme   ThemeConfig   `toml:"theme"`
 	Keys    KeysConfig    `toml:"keys"`
 	Startup StartupConfig `toml:"startup"`
-	// Future: Display options, etc.
+	Display DisplayConfig `toml:"display"`
+	Viewer  ViewerConfig  `toml:"viewer"`
+
+	// LegacyKeys lists the renamed keys from an older config schema that
+	// the file still uses, by their old dotted names. Load accepts them
+	// under their new names; --migrate-config rewrites the file.
+	LegacyKeys []string `toml:"-"`
+}
+
+// legacyKeyRenames maps dotted keys from older config schemas to the
+// keys that replaced them.
+var legacyKeyRenames = map[string]string{
+	"syntax.theme": "theme.dark_theme",
 }
 
 // ThemeConfig defines theme configuration
@@ -39,6 +60,35 @@ type StartupConfig struct {
 	InitialView string `toml:"initial_view"`
 }
 
+// DisplayConfig defines how lists are drawn
+type DisplayConfig struct {
+	// Prefix file names with Nerd Font file type icons; needs a Nerd Font
+	UseNerdFontIcons bool `toml:"use_nerd_font_icons"`
+
+	// How file and app dates are shown: "relative" (default), "absolute"
+	// or "iso"
+	DateFormat string `toml:"date_format"`
+
+	// Count the apps that come with each simulator's runtime alongside
+	// the installed ones in the simulator list
+	ShowSystemAppCount bool `toml:"show_system_app_count"`
+
+	// Platforms whose simulators are listed: any of "iOS", "watchOS",
+	// "tvOS" and "visionOS"
+	Platforms []string `toml:"platforms"`
+}
+
+// ViewerConfig defines how the file viewer reads files
+type ViewerConfig struct {
+	// Bytes of a binary file read per fetch in the hex viewer; a multiple
+	// of 16, the bytes per hex dump row
+	BinaryChunkSize int `toml:"binary_chunk_size"`
+
+	// Seconds a database table's row count is reused before counting
+	// its rows again, unless the file changes
+	RowCountCacheSeconds int `toml:"row_count_cache_seconds"`
+}
+
 // Default returns the default configuration
 func Default() *Config {
 	return &Config{
@@ -51,6 +101,14 @@ func Default() *Config {
 		Startup: StartupConfig{
 			InitialView: "simulator_list",
 		},
+		Display: DisplayConfig{
+			DateFormat: "relative",
+			Platforms:  slices.Clone(validPlatforms),
+		},
+		Viewer: ViewerConfig{
+			BinaryChunkSize:      8192,
+			RowCountCacheSeconds: 60,
+		},
 	}
 }
 
@@ -76,8 +134,13 @@ func loadFromPath(path string) (*Config, error) {
 		return cfg, nil
 	}
 
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("reading config file: %w", err)
+	}
+
 	userCfg := &Config{}
-	md, err := toml.DecodeFile(path, userCfg)
+	md, legacy, err := decodeConfig(data, userCfg)
 	if err != nil {
 		return cfg, fmt.Errorf("decoding config file: %w", err)
 	}
@@ -99,9 +162,141 @@ func loadFromPath(path string) (*Config, error) {
 	}
 
 	cfg.merge(userCfg)
+	cfg.LegacyKeys = legacy
 	return cfg, nil
 }
 
+// RenamedKey returns the dotted key that replaced the legacy key old,
+// or "" if old wasn't renamed.
+func RenamedKey(old string) string {
+	return legacyKeyRenames[old]
+}
+
+// decodeConfig decodes the TOML in data into cfg. Keys renamed since an
+// older schema are moved to their new names before decoding, so they
+// aren't rejected as unknown; their old names are returned.
+func decodeConfig(data []byte, cfg *Config) (toml.MetaData, []string, error) {
+	var raw map[string]interface{}
+	if _, err := toml.Decode(string(data), &raw); err != nil {
+		return toml.MetaData{}, nil, err
+	}
+	legacy := detectLegacyKeys(raw)
+	if len(legacy) == 0 {
+		md, err := toml.Decode(string(data), cfg)
+		return md, nil, err
+	}
+
+	migrated, err := encodeMigrated(raw)
+	if err != nil {
+		return toml.MetaData{}, nil, err
+	}
+	md, err := toml.Decode(string(migrated), cfg)
+	return md, legacy, err
+}
+
+// detectLegacyKeys returns the dotted names of the renamed keys present
+// in the decoded config raw, sorted.
+func detectLegacyKeys(raw map[string]interface{}) []string {
+	var found []string
+	for old := range legacyKeyRenames {
+		table, key := splitDottedKey(old)
+		if section, ok := raw[table].(map[string]interface{}); ok {
+			if _, ok := section[key]; ok {
+				found = append(found, old)
+			}
+		}
+	}
+	sort.Strings(found)
+	return found
+}
+
+// renameLegacyKeys moves the renamed keys in raw to their new names. A
+// value already set under the new name wins over the old one. Tables
+// left empty by the move are removed.
+func renameLegacyKeys(raw map[string]interface{}) {
+	for _, old := range detectLegacyKeys(raw) {
+		oldTable, oldKey := splitDottedKey(old)
+		newTable, newKey := splitDottedKey(legacyKeyRenames[old])
+
+		section := raw[oldTable].(map[string]interface{})
+		value := section[oldKey]
+		delete(section, oldKey)
+		if len(section) == 0 {
+			delete(raw, oldTable)
+		}
+
+		target, ok := raw[newTable].(map[string]interface{})
+		if !ok {
+			target = make(map[string]interface{})
+			raw[newTable] = target
+		}
+		if _, exists := target[newKey]; !exists {
+			target[newKey] = value
+		}
+	}
+}
+
+// encodeMigrated renames the legacy keys in raw and encodes it as TOML
+func encodeMigrated(raw map[string]interface{}) ([]byte, error) {
+	renameLegacyKeys(raw)
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(raw); err != nil {
+		return nil, fmt.Errorf("encoding migrated config: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// splitDottedKey splits "table.key" into its table and key
+func splitDottedKey(dotted string) (string, string) {
+	table, key, _ := strings.Cut(dotted, ".")
+	return table, key
+}
+
+// Migrate rewrites the config file at the standard path with its legacy
+// keys renamed, keeping the original next to it as <file>.bak. It
+// returns the path and the old names of the keys it renamed; a file
+// without legacy keys is left untouched.
+func Migrate() (string, []string, error) {
+	path, err := getConfigPath()
+	if err != nil {
+		return "", nil, fmt.Errorf("getting config path: %w", err)
+	}
+	renamed, err := migratePath(path)
+	return path, renamed, err
+}
+
+// migratePath is the testable core of Migrate.
+func migratePath(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+
+	var raw map[string]interface{}
+	if _, err := toml.Decode(string(data), &raw); err != nil {
+		return nil, fmt.Errorf("decoding config file: %w", err)
+	}
+	legacy := detectLegacyKeys(raw)
+	if len(legacy) == 0 {
+		return nil, nil
+	}
+
+	migrated, err := encodeMigrated(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path+".bak", data, 0600); err != nil {
+		return nil, fmt.Errorf("writing backup: %w", err)
+	}
+	if err := os.WriteFile(path, migrated, 0600); err != nil {
+		return nil, fmt.Errorf("writing config file: %w", err)
+	}
+	return legacy, nil
+}
+
 // Validate checks that a Config's enum-valued fields hold only
 // accepted values. Empty strings are accepted since they fall back
 // to defaults via merge().
@@ -118,6 +313,25 @@ func (c *Config) Validate() error {
 			c.Startup.InitialView, validInitialViews))
 	}
 
+	if c.Display.DateFormat != "" && !stringInSlice(c.Display.DateFormat, validDateFormats) {
+		errs = append(errs, fmt.Sprintf("display.date_format: %q is not one of %v",
+			c.Display.DateFormat, validDateFormats))
+	}
+
+	for _, platform := range c.Display.Platforms {
+		if !stringInSlice(platform, validPlatforms) {
+			errs = append(errs, fmt.Sprintf("display.platforms: %q is not one of %v",
+				platform, validPlatforms))
+		}
+	}
+
+	if size := c.Viewer.BinaryChunkSize; size < 0 || size%16 != 0 {
+		errs = append(errs, fmt.Sprintf("viewer.binary_chunk_size: %d is not a positive multiple of 16", size))
+	}
+	if c.Viewer.RowCountCacheSeconds < 0 {
+		errs = append(errs, fmt.Sprintf("viewer.row_count_cache_seconds: %d is negative", c.Viewer.RowCountCacheSeconds))
+	}
+
 	if len(errs) > 0 {
 		return fmt.Errorf("%s", strings.Join(errs, "; "))
 	}
@@ -133,28 +347,9 @@ func stringInSlice(s string, set []string) bool {
 	return false
 }
 
-// SaveExample saves an example configuration file
-func SaveExample() error {
-	configDir, err := getConfigDir()
-	if err != nil {
-		return fmt.Errorf("getting config dir: %w", err)
-	}
-
-	// Create config directory if it doesn't exist
-	if err := os.MkdirAll(configDir, 0700); err != nil {
-		return fmt.Errorf("creating config dir: %w", err)
-	}
-
-	examplePath := filepath.Join(configDir, "config.example.toml")
-
-	file, err := os.Create(examplePath)
-	if err != nil {
-		return fmt.Errorf("creating example file: %w", err)
-	}
-	defer func() { _ = file.Close() }()
-
-	// Write example with comments
-	example := `# SimTool Configuration File
+// exampleConfig is the commented configuration written by SaveExample
+// and SaveProfileExample.
+const exampleConfig = `# SimTool Configuration File
 # Copy this file to config.toml and customize as needed
 
 [theme]
@@ -196,6 +391,28 @@ initial_view = "simulator_list"
 # Set to "all_apps" to start with all apps from all simulators
 # This is equivalent to using the --apps/-a command-line flag
 
+[display]
+# Show a file type icon before each name in the file list
+# Requires a Nerd Font (https://www.nerdfonts.com) in the terminal
+use_nerd_font_icons = false
+# How file and app dates are shown
+# Options: "relative" (default, e.g. "2 hours ago" or "Jan 2"),
+# "absolute" (2006-01-02 15:04) or "iso" (2006-01-02T15:04:05Z07:00)
+date_format = "relative"
+# Show "5 user + 42 system apps" in the simulator list rather than "5 apps"
+show_system_app_count = false
+# Platforms whose simulators are listed
+# Options: "iOS", "watchOS", "tvOS" and "visionOS"
+platforms = ["iOS", "watchOS", "tvOS", "visionOS"]
+
+[viewer]
+# Bytes of a binary file read per fetch in the hex viewer, a multiple of 16
+# Larger chunks fetch less often when scrolling quickly
+binary_chunk_size = 8192
+# Seconds a table's row count is reused before counting again, unless the
+# database changes; counting tables with millions of rows is slow
+row_count_cache_seconds = 60
+
 [keys]
 # Keyboard shortcuts configuration
 # Each action can have multiple keys assigned
@@ -217,6 +434,53 @@ filter = ["f"]             # Toggle filter (simulator list only)
 search = ["/"]             # Start search mode
 escape = ["esc"]           # Exit search mode / cancel
 enter = ["enter"]          # Select / confirm
+view_logs = ["L"]          # Stream simulator or app logs
+view_crash_logs = ["C"]    # List crash reports for the selected app
+inspect_notifications = ["N"] # Show notification status for the selected app
+toggle_tree = ["t"]        # Toggle tree view in the file list
+multi_select = ["m"]       # Toggle multi-select mode in the app list
+delete_selected = ["D"]    # Uninstall the selected apps
+cycle_sort = ["tab"]       # Cycle the sort order (all-apps view)
+sort = ["s"]               # Cycle the sort order of the simulator and app lists
+open_database = ["d"]      # Open the selected SQLite database in the file list
+view_url_cache = ["ctrl+u"] # Browse the selected app's URL cache
+view_keychain = ["K"]      # Show the selected app's keychain items
+view_app_groups = ["G"]    # Browse the selected app's app group containers
+clone_simulator = ["X"]    # Clone the selected simulator (simulator list)
+shutdown = ["x"]           # Shut down the selected booted simulator (simulator list)
+delete_simulator = []      # Delete the selected simulator, after confirming (unbound by default)
+create_simulator = ["n"]   # Create a new simulator (simulator list)
+export_container = ["Z"]   # Zip the selected app's data container to ~/Desktop
+editor = ["e"]             # Open the viewed file in $EDITOR (file viewer)
+export_table = ["E"]       # Write the open database table to ~/Desktop as CSV
+view_resources = ["R"]     # Show memory usage of a booted simulator
+open_simulator_app = ["ctrl+o"] # Open the simulator window in Simulator.app
+sort_next_column = ["ctrl+right"] # Sort table by the next column
+sort_prev_column = ["ctrl+left"] # Sort table by the previous column
+toggle_sort_direction = ["ctrl+s"] # Toggle ascending/descending table sort
+diff = ["="]               # Mark a file, then diff it with another
+show_metadata = ["i"]      # Show a file's metadata and extended attributes
+view_bundle_resources = ["ctrl+r"] # Browse the app bundle resources
+toggle_columns = ["ctrl+h"] # Choose which table columns are shown
+increase_size_filter = [">"] # Raise the minimum file size shown by 1 KB
+decrease_size_filter = ["<"] # Lower the minimum file size shown by 1 KB
+reset_size_filter = ["0"]  # Show files of every size
+vacuum_database = ["ctrl+v"] # Vacuum the open database
+query_database = [":"]     # Write a SQL query against the open database
+run_query = ["ctrl+e"]     # Run the query in the SQL query editor
+view_permissions = ["P"]   # Manage the app's privacy permissions
+test_deep_link = ["U"]     # Open a URL with the app's scheme in the simulator
+binary_chunks = ["B"]      # Cycle the hex viewer chunk size (4, 8, 16, 64 KB)
+capture_screen = ["S"]     # Save a screenshot of the booted simulator to ~/Desktop
+copy_file_content = ["ctrl+y"] # Copy the file content in view to the clipboard
+copy = ["y"]               # Copy the selected file or app container path to the clipboard
+send_push_notification = ["ctrl+p"] # Compose a push notification for the selected app; sends it from the editor
+spawn_command = ["!"]      # Run a command inside the selected simulator with simctl spawn
+duplicate_file = ["ctrl+d"] # Copy the selected file or folder next to it with a _copy suffix
+rename = ["r"]             # Rename the selected file or folder
+delete = ["delete"]        # Delete the selected file or folder, after confirming
+scroll_top = ["ctrl+home"] # Jump to the start of the viewed file
+scroll_bottom = ["ctrl+end"] # Jump to the end of the viewed file
 
 # Search mode keys
 backspace = ["backspace"]  # Delete character in search
@@ -228,7 +492,27 @@ backspace = ["backspace"]  # Delete character in search
 # - Disable a shortcut: filter = []
 `
 
-	if _, err := file.WriteString(example); err != nil {
+// SaveExample saves an example configuration file
+func SaveExample() error {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return fmt.Errorf("getting config dir: %w", err)
+	}
+
+	// Create config directory if it doesn't exist
+	if err := os.MkdirAll(configDir, 0700); err != nil {
+		return fmt.Errorf("creating config dir: %w", err)
+	}
+
+	examplePath := filepath.Join(configDir, "config.example.toml")
+
+	file, err := os.Create(examplePath)
+	if err != nil {
+		return fmt.Errorf("creating example file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if _, err := file.WriteString(exampleConfig); err != nil {
 		return fmt.Errorf("writing example file: %w", err)
 	}
 
@@ -271,6 +555,28 @@ func (c *Config) merge(user *Config) {
 		c.Startup.InitialView = user.Startup.InitialView
 	}
 
+	// Merge display settings
+	if user.Display.UseNerdFontIcons {
+		c.Display.UseNerdFontIcons = true
+	}
+	if user.Display.DateFormat != "" {
+		c.Display.DateFormat = user.Display.DateFormat
+	}
+	if user.Display.ShowSystemAppCount {
+		c.Display.ShowSystemAppCount = true
+	}
+	if len(user.Display.Platforms) > 0 {
+		c.Display.Platforms = user.Display.Platforms
+	}
+
+	// Merge viewer settings
+	if user.Viewer.BinaryChunkSize > 0 {
+		c.Viewer.BinaryChunkSize = user.Viewer.BinaryChunkSize
+	}
+	if user.Viewer.RowCountCacheSeconds > 0 {
+		c.Viewer.RowCountCacheSeconds = user.Viewer.RowCountCacheSeconds
+	}
+
 	// Merge key settings - only override if user has specified keys
 	if len(user.Keys.Up) > 0 {
 		c.Keys.Up = user.Keys.Up
@@ -311,6 +617,147 @@ func (c *Config) merge(user *Config) {
 	if len(user.Keys.Enter) > 0 {
 		c.Keys.Enter = user.Keys.Enter
 	}
+	if len(user.Keys.ViewLogs) > 0 {
+		c.Keys.ViewLogs = user.Keys.ViewLogs
+	}
+	if len(user.Keys.ViewCrashLogs) > 0 {
+		c.Keys.ViewCrashLogs = user.Keys.ViewCrashLogs
+	}
+	if len(user.Keys.InspectNotifications) > 0 {
+		c.Keys.InspectNotifications = user.Keys.InspectNotifications
+	}
+	if len(user.Keys.ToggleTree) > 0 {
+		c.Keys.ToggleTree = user.Keys.ToggleTree
+	}
+	if len(user.Keys.MultiSelect) > 0 {
+		c.Keys.MultiSelect = user.Keys.MultiSelect
+	}
+	if len(user.Keys.DeleteSelected) > 0 {
+		c.Keys.DeleteSelected = user.Keys.DeleteSelected
+	}
+	if len(user.Keys.CycleSort) > 0 {
+		c.Keys.CycleSort = user.Keys.CycleSort
+	}
+	if len(user.Keys.Sort) > 0 {
+		c.Keys.Sort = user.Keys.Sort
+	}
+	if len(user.Keys.OpenDatabase) > 0 {
+		c.Keys.OpenDatabase = user.Keys.OpenDatabase
+	}
+	if len(user.Keys.ViewURLCache) > 0 {
+		c.Keys.ViewURLCache = user.Keys.ViewURLCache
+	}
+	if len(user.Keys.ViewKeychain) > 0 {
+		c.Keys.ViewKeychain = user.Keys.ViewKeychain
+	}
+	if len(user.Keys.ViewAppGroups) > 0 {
+		c.Keys.ViewAppGroups = user.Keys.ViewAppGroups
+	}
+	if len(user.Keys.CloneSimulator) > 0 {
+		c.Keys.CloneSimulator = user.Keys.CloneSimulator
+	}
+	if len(user.Keys.Shutdown) > 0 {
+		c.Keys.Shutdown = user.Keys.Shutdown
+	}
+	if len(user.Keys.DeleteSimulator) > 0 {
+		c.Keys.DeleteSimulator = user.Keys.DeleteSimulator
+	}
+	if len(user.Keys.CreateSimulator) > 0 {
+		c.Keys.CreateSimulator = user.Keys.CreateSimulator
+	}
+	if len(user.Keys.ExportContainer) > 0 {
+		c.Keys.ExportContainer = user.Keys.ExportContainer
+	}
+	if len(user.Keys.OpenInEditor) > 0 {
+		c.Keys.OpenInEditor = user.Keys.OpenInEditor
+	}
+	if len(user.Keys.ExportTable) > 0 {
+		c.Keys.ExportTable = user.Keys.ExportTable
+	}
+	if len(user.Keys.ViewResources) > 0 {
+		c.Keys.ViewResources = user.Keys.ViewResources
+	}
+	if len(user.Keys.OpenSimulatorApp) > 0 {
+		c.Keys.OpenSimulatorApp = user.Keys.OpenSimulatorApp
+	}
+	if len(user.Keys.SortNextColumn) > 0 {
+		c.Keys.SortNextColumn = user.Keys.SortNextColumn
+	}
+	if len(user.Keys.SortPrevColumn) > 0 {
+		c.Keys.SortPrevColumn = user.Keys.SortPrevColumn
+	}
+	if len(user.Keys.ToggleSortDirection) > 0 {
+		c.Keys.ToggleSortDirection = user.Keys.ToggleSortDirection
+	}
+	if len(user.Keys.DiffFiles) > 0 {
+		c.Keys.DiffFiles = user.Keys.DiffFiles
+	}
+	if len(user.Keys.ShowMetadata) > 0 {
+		c.Keys.ShowMetadata = user.Keys.ShowMetadata
+	}
+	if len(user.Keys.ViewBundleResources) > 0 {
+		c.Keys.ViewBundleResources = user.Keys.ViewBundleResources
+	}
+	if len(user.Keys.ToggleColumns) > 0 {
+		c.Keys.ToggleColumns = user.Keys.ToggleColumns
+	}
+	if len(user.Keys.IncreaseSizeFilter) > 0 {
+		c.Keys.IncreaseSizeFilter = user.Keys.IncreaseSizeFilter
+	}
+	if len(user.Keys.DecreaseSizeFilter) > 0 {
+		c.Keys.DecreaseSizeFilter = user.Keys.DecreaseSizeFilter
+	}
+	if len(user.Keys.ResetSizeFilter) > 0 {
+		c.Keys.ResetSizeFilter = user.Keys.ResetSizeFilter
+	}
+	if len(user.Keys.VacuumDatabase) > 0 {
+		c.Keys.VacuumDatabase = user.Keys.VacuumDatabase
+	}
+	if len(user.Keys.QueryDatabase) > 0 {
+		c.Keys.QueryDatabase = user.Keys.QueryDatabase
+	}
+	if len(user.Keys.RunQuery) > 0 {
+		c.Keys.RunQuery = user.Keys.RunQuery
+	}
+	if len(user.Keys.ViewPermissions) > 0 {
+		c.Keys.ViewPermissions = user.Keys.ViewPermissions
+	}
+	if len(user.Keys.TestDeepLink) > 0 {
+		c.Keys.TestDeepLink = user.Keys.TestDeepLink
+	}
+	if len(user.Keys.BinaryChunks) > 0 {
+		c.Keys.BinaryChunks = user.Keys.BinaryChunks
+	}
+	if len(user.Keys.CaptureScreen) > 0 {
+		c.Keys.CaptureScreen = user.Keys.CaptureScreen
+	}
+	if len(user.Keys.CopyFileContent) > 0 {
+		c.Keys.CopyFileContent = user.Keys.CopyFileContent
+	}
+	if len(user.Keys.CopyPath) > 0 {
+		c.Keys.CopyPath = user.Keys.CopyPath
+	}
+	if len(user.Keys.SendPushNotification) > 0 {
+		c.Keys.SendPushNotification = user.Keys.SendPushNotification
+	}
+	if len(user.Keys.SpawnCommand) > 0 {
+		c.Keys.SpawnCommand = user.Keys.SpawnCommand
+	}
+	if len(user.Keys.DuplicateFile) > 0 {
+		c.Keys.DuplicateFile = user.Keys.DuplicateFile
+	}
+	if len(user.Keys.Rename) > 0 {
+		c.Keys.Rename = user.Keys.Rename
+	}
+	if len(user.Keys.Delete) > 0 {
+		c.Keys.Delete = user.Keys.Delete
+	}
+	if len(user.Keys.ScrollTop) > 0 {
+		c.Keys.ScrollTop = user.Keys.ScrollTop
+	}
+	if len(user.Keys.ScrollBottom) > 0 {
+		c.Keys.ScrollBottom = user.Keys.ScrollBottom
+	}
 	if len(user.Keys.Backspace) > 0 {
 		c.Keys.Backspace = user.Keys.Backspace
 	}
@@ -332,12 +779,21 @@ func getConfigDir() (string, error) {
 	return filepath.Join(home, ".config", "simtool"), nil
 }
 
-// getConfigPath returns the configuration file path
+// getConfigPath returns the configuration file path: config.toml, or
+// the active profile's file if one was selected with SetProfile.
 func getConfigPath() (string, error) {
 	configDir, err := getConfigDir()
 	if err != nil {
 		return "", err
 	}
 
+	if activeProfile != "" {
+		return filepath.Join(configDir, activeProfile+".toml"), nil
+	}
 	return filepath.Join(configDir, "config.toml"), nil
 }
+
+// Path returns the path of the configuration file Load reads.
+func Path() (string, error) {
+	return getConfigPath()
+}
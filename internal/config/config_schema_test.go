@@ -37,11 +37,35 @@ dark_theme = "dracula"
 
 [startup]
 initial_view = "all_apps"
+
+[display]
+use_nerd_font_icons = true
+date_format = "iso"
+show_system_app_count = true
+
+[viewer]
+binary_chunk_size = 16384
+row_count_cache_seconds = 300
 `)
 	cfg, err := loadFromPath(path)
 	if err != nil {
 		t.Fatalf("loadFromPath: %v", err)
 	}
+	if !cfg.Display.UseNerdFontIcons {
+		t.Error("display.use_nerd_font_icons = false, want true")
+	}
+	if !cfg.Display.ShowSystemAppCount {
+		t.Error("display.show_system_app_count = false, want true")
+	}
+	if cfg.Display.DateFormat != "iso" {
+		t.Errorf("display.date_format = %q, want 'iso'", cfg.Display.DateFormat)
+	}
+	if cfg.Viewer.BinaryChunkSize != 16384 {
+		t.Errorf("viewer.binary_chunk_size = %d, want 16384", cfg.Viewer.BinaryChunkSize)
+	}
+	if cfg.Viewer.RowCountCacheSeconds != 300 {
+		t.Errorf("viewer.row_count_cache_seconds = %d, want 300", cfg.Viewer.RowCountCacheSeconds)
+	}
 	if cfg.Theme.Mode != "dark" {
 		t.Errorf("theme.mode = %q, want 'dark'", cfg.Theme.Mode)
 	}
@@ -127,6 +151,35 @@ initial_view = "dashboard"
 	}
 }
 
+func TestLoadFromPath_InvalidDateFormat(t *testing.T) {
+	path := writeTOML(t, "[display]\ndate_format = \"short\"\n")
+	_, err := loadFromPath(path)
+	if err == nil || !strings.Contains(err.Error(), "display.date_format") {
+		t.Errorf("error = %v, want to mention 'display.date_format'", err)
+	}
+}
+
+func TestLoadFromPath_InvalidBinaryChunkSize(t *testing.T) {
+	for _, size := range []string{"-16", "1000"} {
+		path := writeTOML(t, "[viewer]\nbinary_chunk_size = "+size+"\n")
+		_, err := loadFromPath(path)
+		if err == nil {
+			t.Fatalf("expected error for viewer.binary_chunk_size = %s", size)
+		}
+		if !strings.Contains(err.Error(), "viewer.binary_chunk_size") {
+			t.Errorf("error = %q, want to mention 'viewer.binary_chunk_size'", err.Error())
+		}
+	}
+}
+
+func TestLoadFromPath_NegativeRowCountCache(t *testing.T) {
+	path := writeTOML(t, "[viewer]\nrow_count_cache_seconds = -1\n")
+	_, err := loadFromPath(path)
+	if err == nil || !strings.Contains(err.Error(), "viewer.row_count_cache_seconds") {
+		t.Errorf("error = %v, want to mention 'viewer.row_count_cache_seconds'", err)
+	}
+}
+
 func TestLoadFromPath_MultipleValidationErrors(t *testing.T) {
 	path := writeTOML(t, `
 [theme]
@@ -174,3 +227,115 @@ func TestValidate_EmptyFieldsAllowed(t *testing.T) {
 		t.Errorf("empty config failed validation: %v", err)
 	}
 }
+
+func TestDetectLegacyKeys(t *testing.T) {
+	raw := map[string]interface{}{
+		"syntax": map[string]interface{}{"theme": "dracula"},
+		"theme":  map[string]interface{}{"mode": "dark"},
+	}
+	got := detectLegacyKeys(raw)
+	if len(got) != 1 || got[0] != "syntax.theme" {
+		t.Errorf("detectLegacyKeys = %v, want [syntax.theme]", got)
+	}
+
+	if got := detectLegacyKeys(map[string]interface{}{"theme": map[string]interface{}{"dark_theme": "dracula"}}); len(got) != 0 {
+		t.Errorf("detectLegacyKeys on current schema = %v, want none", got)
+	}
+}
+
+func TestLoadFromPath_LegacyKeyRenamed(t *testing.T) {
+	path := writeTOML(t, `
+[syntax]
+theme = "dracula"
+
+[theme]
+mode = "dark"
+`)
+	cfg, err := loadFromPath(path)
+	if err != nil {
+		t.Fatalf("loadFromPath: %v", err)
+	}
+	if cfg.Theme.DarkTheme != "dracula" {
+		t.Errorf("theme.dark_theme = %q, want 'dracula' from syntax.theme", cfg.Theme.DarkTheme)
+	}
+	if cfg.Theme.Mode != "dark" {
+		t.Errorf("theme.mode = %q, want 'dark'", cfg.Theme.Mode)
+	}
+	if len(cfg.LegacyKeys) != 1 || cfg.LegacyKeys[0] != "syntax.theme" {
+		t.Errorf("LegacyKeys = %v, want [syntax.theme]", cfg.LegacyKeys)
+	}
+}
+
+func TestLoadFromPath_NewKeyWinsOverLegacy(t *testing.T) {
+	path := writeTOML(t, `
+[syntax]
+theme = "dracula"
+
+[theme]
+dark_theme = "monokai"
+`)
+	cfg, err := loadFromPath(path)
+	if err != nil {
+		t.Fatalf("loadFromPath: %v", err)
+	}
+	if cfg.Theme.DarkTheme != "monokai" {
+		t.Errorf("theme.dark_theme = %q, want 'monokai'", cfg.Theme.DarkTheme)
+	}
+}
+
+func TestMigratePath(t *testing.T) {
+	original := `
+[syntax]
+theme = "dracula"
+
+[keys]
+quit = ["q"]
+`
+	path := writeTOML(t, original)
+	renamed, err := migratePath(path)
+	if err != nil {
+		t.Fatalf("migratePath: %v", err)
+	}
+	if len(renamed) != 1 || renamed[0] != "syntax.theme" {
+		t.Errorf("renamed = %v, want [syntax.theme]", renamed)
+	}
+
+	backup, err := os.ReadFile(path + ".bak")
+	if err != nil {
+		t.Fatalf("reading backup: %v", err)
+	}
+	if string(backup) != original {
+		t.Errorf("backup = %q, want the original file", backup)
+	}
+
+	cfg, err := loadFromPath(path)
+	if err != nil {
+		t.Fatalf("loading migrated file: %v", err)
+	}
+	if cfg.Theme.DarkTheme != "dracula" {
+		t.Errorf("theme.dark_theme = %q, want 'dracula'", cfg.Theme.DarkTheme)
+	}
+	if len(cfg.Keys.Quit) != 1 || cfg.Keys.Quit[0] != "q" {
+		t.Errorf("keys.quit = %v, want [q]", cfg.Keys.Quit)
+	}
+	if len(cfg.LegacyKeys) != 0 {
+		t.Errorf("LegacyKeys after migration = %v, want none", cfg.LegacyKeys)
+	}
+}
+
+func TestMigratePath_NothingToMigrate(t *testing.T) {
+	path := writeTOML(t, `
+[theme]
+dark_theme = "dracula"
+`)
+	renamed, err := migratePath(path)
+	if err != nil {
+		t.Fatalf("migratePath: %v", err)
+	}
+	if len(renamed) != 0 {
+		t.Errorf("renamed = %v, want none", renamed)
+	}
+	if _, err := os.Stat(path + ".bak"); !os.IsNotExist(err) {
+		t.Error("no backup should be written when nothing changes")
+	}
+}
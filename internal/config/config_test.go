@@ -24,6 +24,18 @@ func TestDefault(t *testing.T) {
 	if cfg.Startup.InitialView != "simulator_list" {
 		t.Errorf("Default initial view should be 'simulator_list', got %q", cfg.Startup.InitialView)
 	}
+
+	if cfg.Display.DateFormat != "relative" {
+		t.Errorf("Default date format should be 'relative', got %q", cfg.Display.DateFormat)
+	}
+
+	if cfg.Viewer.BinaryChunkSize != 8192 {
+		t.Errorf("Default binary chunk size should be 8192, got %d", cfg.Viewer.BinaryChunkSize)
+	}
+
+	if cfg.Viewer.RowCountCacheSeconds != 60 {
+		t.Errorf("Default row count cache should be 60 seconds, got %d", cfg.Viewer.RowCountCacheSeconds)
+	}
 }
 
 func TestGetActiveTheme(t *testing.T) {
@@ -13,13 +13,60 @@ type KeysConfig struct {
 	End   []string `toml:"end"`
 
 	// Actions
-	Quit   []string `toml:"quit"`
-	Boot   []string `toml:"boot"`   // Boot simulator
-	Open   []string `toml:"open"`   // Open in Finder
-	Filter []string `toml:"filter"` // Toggle filter
-	Search []string `toml:"search"` // Start search
-	Escape []string `toml:"escape"` // Exit search/cancel
-	Enter  []string `toml:"enter"`  // Select/confirm
+	Quit                 []string `toml:"quit"`
+	Boot                 []string `toml:"boot"`                   // Boot simulator
+	Open                 []string `toml:"open"`                   // Open in Finder
+	Filter               []string `toml:"filter"`                 // Toggle filter
+	Search               []string `toml:"search"`                 // Start search
+	Escape               []string `toml:"escape"`                 // Exit search/cancel
+	Enter                []string `toml:"enter"`                  // Select/confirm
+	ViewLogs             []string `toml:"view_logs"`              // Stream simulator or app logs
+	ViewCrashLogs        []string `toml:"view_crash_logs"`        // List crash reports for the selected app
+	InspectNotifications []string `toml:"inspect_notifications"`  // Show notification status for the selected app
+	ToggleTree           []string `toml:"toggle_tree"`            // Toggle tree view in the file list
+	MultiSelect          []string `toml:"multi_select"`           // Toggle multi-select mode in the app list
+	DeleteSelected       []string `toml:"delete_selected"`        // Uninstall the selected apps
+	CycleSort            []string `toml:"cycle_sort"`             // Cycle the sort order (all-apps view)
+	Sort                 []string `toml:"sort"`                   // Cycle the sort order of the simulator and app lists
+	OpenDatabase         []string `toml:"open_database"`          // Open the selected SQLite database in the file list
+	ViewURLCache         []string `toml:"view_url_cache"`         // Browse the selected app's URL cache
+	ViewKeychain         []string `toml:"view_keychain"`          // Show the selected app's keychain items
+	ViewAppGroups        []string `toml:"view_app_groups"`        // Browse the selected app's app group containers
+	CloneSimulator       []string `toml:"clone_simulator"`        // Clone the selected simulator (simulator list)
+	Shutdown             []string `toml:"shutdown"`               // Shut down the selected booted simulator (simulator list)
+	DeleteSimulator      []string `toml:"delete_simulator"`       // Delete the selected simulator, after confirming (unbound by default)
+	CreateSimulator      []string `toml:"create_simulator"`       // Create a new simulator (simulator list)
+	ExportContainer      []string `toml:"export_container"`       // Zip the selected app's data container to ~/Desktop
+	OpenInEditor         []string `toml:"editor"`                 // Open the viewed file in $EDITOR
+	ExportTable          []string `toml:"export_table"`           // Write the open database table to ~/Desktop as CSV
+	ViewResources        []string `toml:"view_resources"`         // Show memory usage of a booted simulator
+	OpenSimulatorApp     []string `toml:"open_simulator_app"`     // Open the simulator window in Simulator.app
+	SortNextColumn       []string `toml:"sort_next_column"`       // Sort table by the next column
+	SortPrevColumn       []string `toml:"sort_prev_column"`       // Sort table by the previous column
+	ToggleSortDirection  []string `toml:"toggle_sort_direction"`  // Toggle ascending/descending table sort
+	DiffFiles            []string `toml:"diff"`                   // Mark a file, then diff it with another
+	ShowMetadata         []string `toml:"show_metadata"`          // Show a file's metadata and extended attributes
+	ViewBundleResources  []string `toml:"view_bundle_resources"`  // Browse the app bundle resources
+	ToggleColumns        []string `toml:"toggle_columns"`         // Choose which table columns are shown
+	IncreaseSizeFilter   []string `toml:"increase_size_filter"`   // Raise the minimum file size shown by 1 KB
+	DecreaseSizeFilter   []string `toml:"decrease_size_filter"`   // Lower the minimum file size shown by 1 KB
+	ResetSizeFilter      []string `toml:"reset_size_filter"`      // Show files of every size
+	VacuumDatabase       []string `toml:"vacuum_database"`        // Vacuum the open database
+	QueryDatabase        []string `toml:"query_database"`         // Write a SQL query against the open database
+	RunQuery             []string `toml:"run_query"`              // Run the query in the SQL query editor
+	ViewPermissions      []string `toml:"view_permissions"`       // Manage the app's privacy permissions
+	TestDeepLink         []string `toml:"test_deep_link"`         // Open a URL with the app's scheme in the simulator
+	BinaryChunks         []string `toml:"binary_chunks"`          // Cycle the hex viewer chunk size (4, 8, 16, 64 KB)
+	CaptureScreen        []string `toml:"capture_screen"`         // Save a screenshot of the booted simulator to ~/Desktop
+	CopyFileContent      []string `toml:"copy_file_content"`      // Copy the file content in view to the clipboard
+	CopyPath             []string `toml:"copy"`                   // Copy the selected file or app container path to the clipboard
+	SendPushNotification []string `toml:"send_push_notification"` // Compose a push notification for the selected app; sends it from the editor
+	SpawnCommand         []string `toml:"spawn_command"`          // Run a command inside the selected simulator with simctl spawn
+	DuplicateFile        []string `toml:"duplicate_file"`         // Copy the selected file or folder next to it with a _copy suffix
+	Rename               []string `toml:"rename"`                 // Rename the selected file or folder
+	Delete               []string `toml:"delete"`                 // Delete the selected file or folder, after confirming
+	ScrollTop            []string `toml:"scroll_top"`             // Jump to the start of the viewed file
+	ScrollBottom         []string `toml:"scroll_bottom"`          // Jump to the end of the viewed file
 
 	// Search mode
 	Backspace []string `toml:"backspace"` // Delete character in search
@@ -37,13 +84,60 @@ func DefaultKeys() KeysConfig {
 		End:   []string{"end"},
 
 		// Actions
-		Quit:   []string{"q", "ctrl+c"},
-		Boot:   []string{" "}, // space
-		Open:   []string{" "}, // space (context-dependent)
-		Filter: []string{"f"},
-		Search: []string{"/"},
-		Escape: []string{"esc"},
-		Enter:  []string{"enter"},
+		Quit:                 []string{"q", "ctrl+c"},
+		Boot:                 []string{" "}, // space
+		Open:                 []string{" "}, // space (context-dependent)
+		Filter:               []string{"f"},
+		Search:               []string{"/"},
+		Escape:               []string{"esc"},
+		Enter:                []string{"enter"},
+		ViewLogs:             []string{"L"},
+		ViewCrashLogs:        []string{"C"},
+		InspectNotifications: []string{"N"},
+		ToggleTree:           []string{"t"},
+		MultiSelect:          []string{"m"},
+		DeleteSelected:       []string{"D"},
+		CycleSort:            []string{"tab"},
+		Sort:                 []string{"s"},
+		OpenDatabase:         []string{"d"},
+		ViewURLCache:         []string{"ctrl+u"},
+		ViewKeychain:         []string{"K"},
+		ViewAppGroups:        []string{"G"},
+		CloneSimulator:       []string{"X"},
+		Shutdown:             []string{"x"},
+		DeleteSimulator:      []string{}, // unbound: deleting simulators is opt-in
+		CreateSimulator:      []string{"n"},
+		ExportContainer:      []string{"Z"},
+		OpenInEditor:         []string{"e"},
+		ExportTable:          []string{"E"},
+		ViewResources:        []string{"R"},
+		OpenSimulatorApp:     []string{"ctrl+o"},
+		SortNextColumn:       []string{"ctrl+right"},
+		SortPrevColumn:       []string{"ctrl+left"},
+		ToggleSortDirection:  []string{"ctrl+s"},
+		DiffFiles:            []string{"="},
+		ShowMetadata:         []string{"i"},
+		ViewBundleResources:  []string{"ctrl+r"},
+		ToggleColumns:        []string{"ctrl+h"},
+		IncreaseSizeFilter:   []string{">"},
+		DecreaseSizeFilter:   []string{"<"},
+		ResetSizeFilter:      []string{"0"},
+		VacuumDatabase:       []string{"ctrl+v"},
+		QueryDatabase:        []string{":"},
+		RunQuery:             []string{"ctrl+e"},
+		ViewPermissions:      []string{"P"},
+		TestDeepLink:         []string{"U"},
+		BinaryChunks:         []string{"B"},
+		CaptureScreen:        []string{"S"},
+		CopyFileContent:      []string{"ctrl+y"},
+		CopyPath:             []string{"y"},
+		SendPushNotification: []string{"ctrl+p"},
+		SpawnCommand:         []string{"!"},
+		DuplicateFile:        []string{"ctrl+d"},
+		Rename:               []string{"r"},
+		Delete:               []string{"delete"},
+		ScrollTop:            []string{"ctrl+home"},
+		ScrollBottom:         []string{"ctrl+end"},
 
 		// Search mode
 		Backspace: []string{"backspace"},
@@ -76,6 +170,53 @@ func NewKeyMap(keys KeysConfig) *KeyMap {
 	km.addBindings("search", keys.Search)
 	km.addBindings("escape", keys.Escape)
 	km.addBindings("enter", keys.Enter)
+	km.addBindings("view_logs", keys.ViewLogs)
+	km.addBindings("view_crash_logs", keys.ViewCrashLogs)
+	km.addBindings("inspect_notifications", keys.InspectNotifications)
+	km.addBindings("toggle_tree", keys.ToggleTree)
+	km.addBindings("multi_select", keys.MultiSelect)
+	km.addBindings("delete_selected", keys.DeleteSelected)
+	km.addBindings("cycle_sort", keys.CycleSort)
+	km.addBindings("sort", keys.Sort)
+	km.addBindings("open_database", keys.OpenDatabase)
+	km.addBindings("view_url_cache", keys.ViewURLCache)
+	km.addBindings("view_keychain", keys.ViewKeychain)
+	km.addBindings("view_app_groups", keys.ViewAppGroups)
+	km.addBindings("clone_simulator", keys.CloneSimulator)
+	km.addBindings("shutdown", keys.Shutdown)
+	km.addBindings("delete_simulator", keys.DeleteSimulator)
+	km.addBindings("create_simulator", keys.CreateSimulator)
+	km.addBindings("export_table", keys.ExportTable)
+	km.addBindings("export_container", keys.ExportContainer)
+	km.addBindings("editor", keys.OpenInEditor)
+	km.addBindings("view_resources", keys.ViewResources)
+	km.addBindings("open_simulator_app", keys.OpenSimulatorApp)
+	km.addBindings("sort_next_column", keys.SortNextColumn)
+	km.addBindings("sort_prev_column", keys.SortPrevColumn)
+	km.addBindings("toggle_sort_direction", keys.ToggleSortDirection)
+	km.addBindings("diff", keys.DiffFiles)
+	km.addBindings("show_metadata", keys.ShowMetadata)
+	km.addBindings("view_bundle_resources", keys.ViewBundleResources)
+	km.addBindings("toggle_columns", keys.ToggleColumns)
+	km.addBindings("increase_size_filter", keys.IncreaseSizeFilter)
+	km.addBindings("decrease_size_filter", keys.DecreaseSizeFilter)
+	km.addBindings("reset_size_filter", keys.ResetSizeFilter)
+	km.addBindings("vacuum_database", keys.VacuumDatabase)
+	km.addBindings("query_database", keys.QueryDatabase)
+	km.addBindings("run_query", keys.RunQuery)
+	km.addBindings("view_permissions", keys.ViewPermissions)
+	km.addBindings("test_deep_link", keys.TestDeepLink)
+	km.addBindings("binary_chunks", keys.BinaryChunks)
+	km.addBindings("capture_screen", keys.CaptureScreen)
+	km.addBindings("copy_file_content", keys.CopyFileContent)
+	km.addBindings("copy", keys.CopyPath)
+	km.addBindings("send_push_notification", keys.SendPushNotification)
+	km.addBindings("spawn_command", keys.SpawnCommand)
+	km.addBindings("duplicate_file", keys.DuplicateFile)
+	km.addBindings("rename", keys.Rename)
+	km.addBindings("delete", keys.Delete)
+	km.addBindings("scroll_top", keys.ScrollTop)
+	km.addBindings("scroll_bottom", keys.ScrollBottom)
 	km.addBindings("backspace", keys.Backspace)
 
 	return km
@@ -133,6 +274,8 @@ func FormatKeys(keys []string) string {
 			formatted = append(formatted, "Home")
 		case "end":
 			formatted = append(formatted, "End")
+		case "tab":
+			formatted = append(formatted, "Tab")
 		default:
 			formatted = append(formatted, key)
 		}
@@ -174,6 +317,100 @@ func (kc *KeysConfig) FormatKeyAction(action string, label string) string {
 		keys = kc.Escape
 	case "enter":
 		keys = kc.Enter
+	case "view_logs":
+		keys = kc.ViewLogs
+	case "view_crash_logs":
+		keys = kc.ViewCrashLogs
+	case "inspect_notifications":
+		keys = kc.InspectNotifications
+	case "toggle_tree":
+		keys = kc.ToggleTree
+	case "multi_select":
+		keys = kc.MultiSelect
+	case "delete_selected":
+		keys = kc.DeleteSelected
+	case "cycle_sort":
+		keys = kc.CycleSort
+	case "sort":
+		keys = kc.Sort
+	case "open_database":
+		keys = kc.OpenDatabase
+	case "view_url_cache":
+		keys = kc.ViewURLCache
+	case "view_keychain":
+		keys = kc.ViewKeychain
+	case "view_app_groups":
+		keys = kc.ViewAppGroups
+	case "clone_simulator":
+		keys = kc.CloneSimulator
+	case "shutdown":
+		keys = kc.Shutdown
+	case "delete_simulator":
+		keys = kc.DeleteSimulator
+	case "create_simulator":
+		keys = kc.CreateSimulator
+	case "export_container":
+		keys = kc.ExportContainer
+	case "editor":
+		keys = kc.OpenInEditor
+	case "export_table":
+		keys = kc.ExportTable
+	case "view_resources":
+		keys = kc.ViewResources
+	case "open_simulator_app":
+		keys = kc.OpenSimulatorApp
+	case "sort_next_column":
+		keys = kc.SortNextColumn
+	case "sort_prev_column":
+		keys = kc.SortPrevColumn
+	case "toggle_sort_direction":
+		keys = kc.ToggleSortDirection
+	case "diff":
+		keys = kc.DiffFiles
+	case "show_metadata":
+		keys = kc.ShowMetadata
+	case "view_bundle_resources":
+		keys = kc.ViewBundleResources
+	case "toggle_columns":
+		keys = kc.ToggleColumns
+	case "increase_size_filter":
+		keys = kc.IncreaseSizeFilter
+	case "decrease_size_filter":
+		keys = kc.DecreaseSizeFilter
+	case "reset_size_filter":
+		keys = kc.ResetSizeFilter
+	case "vacuum_database":
+		keys = kc.VacuumDatabase
+	case "query_database":
+		keys = kc.QueryDatabase
+	case "run_query":
+		keys = kc.RunQuery
+	case "view_permissions":
+		keys = kc.ViewPermissions
+	case "test_deep_link":
+		keys = kc.TestDeepLink
+	case "binary_chunks":
+		keys = kc.BinaryChunks
+	case "capture_screen":
+		keys = kc.CaptureScreen
+	case "copy_file_content":
+		keys = kc.CopyFileContent
+	case "copy":
+		keys = kc.CopyPath
+	case "send_push_notification":
+		keys = kc.SendPushNotification
+	case "spawn_command":
+		keys = kc.SpawnCommand
+	case "duplicate_file":
+		keys = kc.DuplicateFile
+	case "rename":
+		keys = kc.Rename
+	case "delete":
+		keys = kc.Delete
+	case "scroll_top":
+		keys = kc.ScrollTop
+	case "scroll_bottom":
+		keys = kc.ScrollBottom
 	case "backspace":
 		keys = kc.Backspace
 	}
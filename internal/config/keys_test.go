@@ -26,6 +26,53 @@ func TestDefaultKeys(t *testing.T) {
 		{"Search", d.Search, []string{"/"}, 0},
 		{"Escape", d.Escape, []string{"esc"}, 0},
 		{"Enter", d.Enter, []string{"enter"}, 0},
+		{"ViewLogs", d.ViewLogs, []string{"L"}, 0},
+		{"ViewCrashLogs", d.ViewCrashLogs, []string{"C"}, 0},
+		{"InspectNotifications", d.InspectNotifications, []string{"N"}, 0},
+		{"ToggleTree", d.ToggleTree, []string{"t"}, 0},
+		{"MultiSelect", d.MultiSelect, []string{"m"}, 0},
+		{"DeleteSelected", d.DeleteSelected, []string{"D"}, 0},
+		{"CycleSort", d.CycleSort, []string{"tab"}, 0},
+		{"Sort", d.Sort, []string{"s"}, 0},
+		{"OpenDatabase", d.OpenDatabase, []string{"d"}, 0},
+		{"ViewURLCache", d.ViewURLCache, []string{"ctrl+u"}, 0},
+		{"ViewKeychain", d.ViewKeychain, []string{"K"}, 0},
+		{"ViewAppGroups", d.ViewAppGroups, []string{"G"}, 0},
+		{"CloneSimulator", d.CloneSimulator, []string{"X"}, 0},
+		{"Shutdown", d.Shutdown, []string{"x"}, 0},
+		{"DeleteSimulator", d.DeleteSimulator, []string{}, 0},
+		{"CreateSimulator", d.CreateSimulator, []string{"n"}, 0},
+		{"ExportContainer", d.ExportContainer, []string{"Z"}, 0},
+		{"OpenInEditor", d.OpenInEditor, []string{"e"}, 0},
+		{"ExportTable", d.ExportTable, []string{"E"}, 0},
+		{"ViewResources", d.ViewResources, []string{"R"}, 0},
+		{"OpenSimulatorApp", d.OpenSimulatorApp, []string{"ctrl+o"}, 0},
+		{"SortNextColumn", d.SortNextColumn, []string{"ctrl+right"}, 0},
+		{"SortPrevColumn", d.SortPrevColumn, []string{"ctrl+left"}, 0},
+		{"ToggleSortDirection", d.ToggleSortDirection, []string{"ctrl+s"}, 0},
+		{"DiffFiles", d.DiffFiles, []string{"="}, 0},
+		{"ShowMetadata", d.ShowMetadata, []string{"i"}, 0},
+		{"ViewBundleResources", d.ViewBundleResources, []string{"ctrl+r"}, 0},
+		{"ToggleColumns", d.ToggleColumns, []string{"ctrl+h"}, 0},
+		{"IncreaseSizeFilter", d.IncreaseSizeFilter, []string{">"}, 0},
+		{"DecreaseSizeFilter", d.DecreaseSizeFilter, []string{"<"}, 0},
+		{"ResetSizeFilter", d.ResetSizeFilter, []string{"0"}, 0},
+		{"VacuumDatabase", d.VacuumDatabase, []string{"ctrl+v"}, 0},
+		{"QueryDatabase", d.QueryDatabase, []string{":"}, 0},
+		{"RunQuery", d.RunQuery, []string{"ctrl+e"}, 0},
+		{"ViewPermissions", d.ViewPermissions, []string{"P"}, 0},
+		{"TestDeepLink", d.TestDeepLink, []string{"U"}, 0},
+		{"BinaryChunks", d.BinaryChunks, []string{"B"}, 0},
+		{"CaptureScreen", d.CaptureScreen, []string{"S"}, 0},
+		{"CopyFileContent", d.CopyFileContent, []string{"ctrl+y"}, 0},
+		{"CopyPath", d.CopyPath, []string{"y"}, 0},
+		{"SendPushNotification", d.SendPushNotification, []string{"ctrl+p"}, 0},
+		{"SpawnCommand", d.SpawnCommand, []string{"!"}, 0},
+		{"DuplicateFile", d.DuplicateFile, []string{"ctrl+d"}, 0},
+		{"Rename", d.Rename, []string{"r"}, 0},
+		{"Delete", d.Delete, []string{"delete"}, 0},
+		{"ScrollTop", d.ScrollTop, []string{"ctrl+home"}, 0},
+		{"ScrollBottom", d.ScrollBottom, []string{"ctrl+end"}, 0},
 		{"Backspace", d.Backspace, []string{"backspace"}, 0},
 	}
 
@@ -61,6 +108,52 @@ func TestNewKeyMap_ResolvesAllActions(t *testing.T) {
 		{"/", "search"},
 		{"esc", "escape"},
 		{"enter", "enter"},
+		{"L", "view_logs"},
+		{"C", "view_crash_logs"},
+		{"N", "inspect_notifications"},
+		{"t", "toggle_tree"},
+		{"m", "multi_select"},
+		{"D", "delete_selected"},
+		{"tab", "cycle_sort"},
+		{"s", "sort"},
+		{"d", "open_database"},
+		{"ctrl+u", "view_url_cache"},
+		{"K", "view_keychain"},
+		{"G", "view_app_groups"},
+		{"X", "clone_simulator"},
+		{"x", "shutdown"},
+		{"n", "create_simulator"},
+		{"Z", "export_container"},
+		{"e", "editor"},
+		{"E", "export_table"},
+		{"R", "view_resources"},
+		{"ctrl+o", "open_simulator_app"},
+		{"ctrl+right", "sort_next_column"},
+		{"ctrl+left", "sort_prev_column"},
+		{"ctrl+s", "toggle_sort_direction"},
+		{"=", "diff"},
+		{"i", "show_metadata"},
+		{"ctrl+r", "view_bundle_resources"},
+		{"ctrl+h", "toggle_columns"},
+		{">", "increase_size_filter"},
+		{"<", "decrease_size_filter"},
+		{"0", "reset_size_filter"},
+		{"ctrl+v", "vacuum_database"},
+		{":", "query_database"},
+		{"ctrl+e", "run_query"},
+		{"P", "view_permissions"},
+		{"U", "test_deep_link"},
+		{"B", "binary_chunks"},
+		{"S", "capture_screen"},
+		{"ctrl+y", "copy_file_content"},
+		{"y", "copy"},
+		{"ctrl+p", "send_push_notification"},
+		{"!", "spawn_command"},
+		{"ctrl+d", "duplicate_file"},
+		{"r", "rename"},
+		{"delete", "delete"},
+		{"ctrl+home", "scroll_top"},
+		{"ctrl+end", "scroll_bottom"},
 		{"backspace", "backspace"},
 	}
 	for _, c := range cases {
@@ -119,6 +212,7 @@ func TestFormatKeys(t *testing.T) {
 		{"enter titlecased", []string{"enter"}, "Enter"},
 		{"backspace titlecased", []string{"backspace"}, "Backspace"},
 		{"home/end titlecased", []string{"home", "end"}, "Home/End"},
+		{"tab titlecased", []string{"tab"}, "Tab"},
 		{"default passthrough", []string{"f", "/"}, "f//"},
 		{"empty slice", []string{}, ""},
 	}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// activeProfile is the profile selected with SetProfile. Empty means
+// the default config.toml.
+var activeProfile string
+
+// SetProfile makes Load read <name>.toml from the config directory
+// instead of config.toml. The profile file must already exist.
+func SetProfile(name string) error {
+	path, err := profilePath(name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("profile %q not found (expected %s)", name, path)
+		}
+		return fmt.Errorf("checking profile: %w", err)
+	}
+	activeProfile = name
+	return nil
+}
+
+// ListProfiles returns the names of all profiles in the config
+// directory, i.e. every .toml file except config.example.toml, without
+// the extension. A missing config directory yields an empty list.
+func ListProfiles() ([]string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("getting config dir: %w", err)
+	}
+
+	entries, err := os.ReadDir(configDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading config dir: %w", err)
+	}
+
+	var profiles []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".toml" || name == "config.example.toml" {
+			continue
+		}
+		profiles = append(profiles, strings.TrimSuffix(name, ".toml"))
+	}
+	sort.Strings(profiles)
+	return profiles, nil
+}
+
+// SaveProfileExample writes the example configuration to <name>.toml in
+// the config directory and returns its path. An existing profile is
+// never overwritten.
+func SaveProfileExample(name string) (string, error) {
+	path, err := profilePath(name)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", fmt.Errorf("creating config dir: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return "", fmt.Errorf("profile %q already exists at %s", name, path)
+		}
+		return "", fmt.Errorf("creating profile file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if _, err := file.WriteString(exampleConfig); err != nil {
+		return "", fmt.Errorf("writing profile file: %w", err)
+	}
+	return path, nil
+}
+
+// profilePath returns the file path for the named profile. Names are
+// plain file stems; anything that could escape the config directory is
+// rejected.
+func profilePath(name string) (string, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid profile name %q", name)
+	}
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("getting config dir: %w", err)
+	}
+	return filepath.Join(configDir, name+".toml"), nil
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// useProfileDir points XDG_CONFIG_HOME at a fresh tempdir, creates the
+// simtool config directory and restores activeProfile after the test.
+func useProfileDir(t *testing.T) string {
+	t.Helper()
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	dir := filepath.Join(xdg, "simtool")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	prev := activeProfile
+	t.Cleanup(func() { activeProfile = prev })
+	return dir
+}
+
+func TestSetProfile_SwitchesLoadPath(t *testing.T) {
+	dir := useProfileDir(t)
+	body := "[theme]\nmode = \"dark\"\ndark_theme = \"monokai\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "present.toml"), []byte(body), 0600); err != nil {
+		t.Fatalf("write profile: %v", err)
+	}
+
+	if err := SetProfile("present"); err != nil {
+		t.Fatalf("SetProfile: %v", err)
+	}
+	path, err := Path()
+	if err != nil {
+		t.Fatalf("Path: %v", err)
+	}
+	if want := filepath.Join(dir, "present.toml"); path != want {
+		t.Errorf("Path = %q, want %q", path, want)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Theme.DarkTheme != "monokai" {
+		t.Errorf("dark_theme = %q, want monokai from profile", cfg.Theme.DarkTheme)
+	}
+}
+
+func TestSetProfile_Errors(t *testing.T) {
+	useProfileDir(t)
+
+	for _, name := range []string{"missing", "", "../escape", "a/b"} {
+		if err := SetProfile(name); err == nil {
+			t.Errorf("SetProfile(%q) = nil, want error", name)
+		}
+	}
+	if activeProfile != "" {
+		t.Errorf("activeProfile = %q after failed SetProfile, want empty", activeProfile)
+	}
+}
+
+func TestListProfiles(t *testing.T) {
+	dir := useProfileDir(t)
+	for _, name := range []string{"config.toml", "compact.toml", "config.example.toml", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "dir.toml"), 0700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	got, err := ListProfiles()
+	if err != nil {
+		t.Fatalf("ListProfiles: %v", err)
+	}
+	want := []string{"compact", "config"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListProfiles = %v, want %v", got, want)
+	}
+}
+
+func TestListProfiles_MissingDir(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	got, err := ListProfiles()
+	if err != nil || len(got) != 0 {
+		t.Errorf("ListProfiles = %v, %v; want empty, nil", got, err)
+	}
+}
+
+func TestSaveProfileExample(t *testing.T) {
+	dir := useProfileDir(t)
+
+	path, err := SaveProfileExample("compact")
+	if err != nil {
+		t.Fatalf("SaveProfileExample: %v", err)
+	}
+	if want := filepath.Join(dir, "compact.toml"); path != want {
+		t.Errorf("path = %q, want %q", path, want)
+	}
+	body, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read profile: %v", err)
+	}
+	if !strings.Contains(string(body), "[theme]") {
+		t.Error("profile does not contain the example config")
+	}
+
+	// A second call must not clobber the user's edits.
+	if _, err := SaveProfileExample("compact"); err == nil {
+		t.Error("SaveProfileExample overwrote an existing profile")
+	}
+}
@@ -16,6 +16,7 @@ type Styles struct {
 	Booted   lipgloss.Style
 	Shutdown lipgloss.Style
 	Error    lipgloss.Style
+	Warning  lipgloss.Style
 	Success  lipgloss.Style
 
 	// UI element styles
@@ -70,6 +71,9 @@ func (c *Config) GenerateStyles() *Styles {
 			Foreground(ConvertToLipglossColor(colors.Error)).
 			Bold(true),
 
+		Warning: lipgloss.NewStyle().
+			Foreground(ConvertToLipglossColor(colors.Warning)),
+
 		Success: lipgloss.NewStyle().
 			Foreground(ConvertToLipglossColor(colors.Success)),
 
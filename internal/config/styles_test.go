@@ -35,6 +35,7 @@ func TestGenerateStyles_HappyPath(t *testing.T) {
 	checkNonZero("Booted", s.Booted)
 	checkNonZero("Shutdown", s.Shutdown)
 	checkNonZero("Error", s.Error)
+	checkNonZero("Warning", s.Warning)
 	checkNonZero("Success", s.Success)
 	checkNonZero("Header", s.Header)
 	checkNonZero("Border", s.Border)
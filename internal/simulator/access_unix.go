@@ -0,0 +1,14 @@
+//go:build unix
+// +build unix
+
+package simulator
+
+import "syscall"
+
+// writeOK is access(2)'s W_OK mode
+const writeOK = 0x2
+
+// isWritable reports whether the current user may write to path
+func isWritable(path string) bool {
+	return syscall.Access(path, writeOK) == nil
+}
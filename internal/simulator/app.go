@@ -1,10 +1,15 @@
 package simulator
 
 import (
+	"bytes"
+	"debug/macho"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"strings"
 	"time"
@@ -26,14 +31,31 @@ type App struct {
 	Name          string
 	BundleID      string
 	Version       string
-	Size          int64
+	Size          int64 // Bytes on disk, AppSizeUnknown until measured with AppSize
 	Path          string
 	Container     string
 	SimulatorName string    // Name of the parent simulator
 	SimulatorUDID string    // UDID of the parent simulator
+	SimulatorOS   string    // Runtime of the parent simulator, e.g. "iOS 17.0"
 	ModTime       time.Time // Last modified time of the app
+	CrashCount    int       // Number of host crash reports naming the app
+	Extensions    []string  // Extension point identifiers of the app's extensions
+	URLSchemes    []string  // Custom URL schemes from CFBundleURLTypes
+	UIFramework   string    // UI framework the app is built with, one of the UIFramework constants
 }
 
+// UI frameworks detected by readAppInfo
+const (
+	UIFrameworkSwiftUI  = "SwiftUI"
+	UIFrameworkUIKit    = "UIKit"
+	UIFrameworkCatalyst = "Catalyst"
+	UIFrameworkUnknown  = "Unknown"
+)
+
+// executableScanSize is how much of an app's executable is searched for
+// linked frameworks. Load commands come right after the Mach-O header.
+const executableScanSize = 1 << 20
+
 // GetAppsForSimulator returns all apps installed on a simulator
 func GetAppsForSimulator(udid string, isRunning bool) ([]App, error) {
 	if isRunning {
@@ -85,9 +107,13 @@ func getAppsFromListApps(udid string) ([]App, error) {
 			case strings.HasPrefix(line, "DataContainer = "):
 				currentApp.Container = strings.Trim(strings.TrimPrefix(line, "DataContainer = "), `";`)
 			case line == "};" && currentApp.BundleID != "":
-				// Calculate app size from path
 				if currentApp.Path != "" {
-					currentApp.Size = calculateDirSize(currentApp.Path)
+					currentApp.Size = AppSizeUnknown
+					currentApp.Extensions = readAppExtensions(currentApp.Path)
+					if info := readAppInfo(currentApp.Path); info != nil {
+						currentApp.URLSchemes = info.URLSchemes
+						currentApp.UIFramework = info.UIFramework
+					}
 					// Get modification time
 					if info, err := os.Stat(currentApp.Path); err == nil {
 						currentApp.ModTime = info.ModTime()
@@ -146,6 +172,8 @@ func getAppsFromDataDir(udid string) ([]App, error) {
 						app.Name = info.DisplayName
 						app.BundleID = info.BundleID
 						app.Version = info.Version
+						app.URLSchemes = info.URLSchemes
+						app.UIFramework = info.UIFramework
 						if app.Name == "" {
 							app.Name = strings.TrimSuffix(appEntry.Name(), ".app")
 						}
@@ -154,7 +182,8 @@ func getAppsFromDataDir(udid string) ([]App, error) {
 						app.BundleID = "Unknown"
 					}
 
-					app.Size = calculateDirSize(app.Path)
+					app.Size = AppSizeUnknown
+					app.Extensions = readAppExtensions(app.Path)
 
 					// Get modification time
 					if info, err := os.Stat(app.Path); err == nil {
@@ -184,11 +213,33 @@ func getAppsFromDataDir(udid string) ([]App, error) {
 	return apps, nil
 }
 
+// UninstallApp removes the app with bundleID from a booted simulator
+func UninstallApp(udid, bundleID string) error {
+	output, err := defaultExecutor.Execute("xcrun", "simctl", "uninstall", udid, bundleID)
+	if err != nil {
+		return fmt.Errorf("failed to uninstall %s: %w (output: %s)", bundleID, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// GetAppContainer returns the path of the data container of the app with
+// bundleID on the simulator with udid, as reported by simctl
+// get_app_container.
+func GetAppContainer(udid, bundleID string) (string, error) {
+	output, err := defaultExecutor.Execute("xcrun", "simctl", "get_app_container", udid, bundleID, "data")
+	if err != nil {
+		return "", fmt.Errorf("failed to get container of %s: %w (output: %s)", bundleID, err, strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
 // AppInfo represents parsed Info.plist data
 type AppInfo struct {
 	DisplayName string
 	BundleID    string
 	Version     string
+	URLSchemes  []string
+	UIFramework string
 }
 
 // readAppInfo reads basic info from Info.plist
@@ -219,24 +270,163 @@ func readAppInfo(appPath string) *AppInfo {
 		info.Version = v
 	}
 
+	info.URLSchemes = urlSchemes(plist)
+	info.UIFramework = detectUIFramework(appPath, plist)
 	return info
 }
 
+// detectUIFramework guesses the UI framework of the app at appPath from
+// its Info.plist: Catalyst apps have NSApplication as their principal
+// class, SwiftUI apps link SwiftUI.framework, and UIKit apps have a main
+// storyboard or link UIKit.framework. SwiftUI apps link UIKit too, so
+// SwiftUI is checked first.
+func detectUIFramework(appPath string, plist map[string]interface{}) string {
+	if v, _ := plist["NSPrincipalClass"].(string); v == "NSApplication" {
+		return UIFrameworkCatalyst
+	}
+
+	var executable []byte
+	if name, _ := plist["CFBundleExecutable"].(string); name != "" {
+		executable = readFileHead(filepath.Join(appPath, name), executableScanSize)
+	}
+	if bytes.Contains(executable, []byte("SwiftUI.framework")) {
+		return UIFrameworkSwiftUI
+	}
+	if _, err := os.Stat(filepath.Join(appPath, "Frameworks", "SwiftUI.framework")); err == nil {
+		return UIFrameworkSwiftUI
+	}
+
+	if v, _ := plist["UIMainStoryboardFile"].(string); v != "" {
+		return UIFrameworkUIKit
+	}
+	if bytes.Contains(executable, []byte("UIKit.framework")) {
+		return UIFrameworkUIKit
+	}
+	return UIFrameworkUnknown
+}
+
+// readFileHead returns up to n bytes from the start of the file at path,
+// or nil if it can't be read
+func readFileHead(path string, n int) []byte {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = file.Close() }()
+
+	head := make([]byte, n)
+	read, _ := io.ReadFull(file, head)
+	return head[:read]
+}
+
+// urlSchemes returns the schemes of every URL type an Info.plist
+// declares in CFBundleURLTypes, in order and without duplicates
+func urlSchemes(plist map[string]interface{}) []string {
+	types, _ := plist["CFBundleURLTypes"].([]interface{})
+	var schemes []string
+	seen := make(map[string]bool)
+	for _, t := range types {
+		urlType, _ := t.(map[string]interface{})
+		list, _ := urlType["CFBundleURLSchemes"].([]interface{})
+		for _, v := range list {
+			if scheme, ok := v.(string); ok && scheme != "" && !seen[scheme] {
+				seen[scheme] = true
+				schemes = append(schemes, scheme)
+			}
+		}
+	}
+	return schemes
+}
+
+// OpenURL opens url in the booted simulator with udid, as if a link to
+// it had been tapped
+func OpenURL(udid, url string) error {
+	output, err := defaultExecutor.Execute("xcrun", "simctl", "openurl", udid, url)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w (output: %s)", url, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// AppSizeUnknown is the Size of an app whose bundle hasn't been measured
+// yet. Listing apps leaves sizes unknown, as walking every bundle can
+// take seconds; AppSize measures one.
+const AppSizeUnknown int64 = -1
+
+// AppSize returns the size on disk of the app bundle at path
+func AppSize(path string) int64 {
+	return calculateDirSize(path)
+}
+
+// SizeText returns the app's size for display, or "…" while it is
+// unknown
+func (a App) SizeText() string {
+	if a.Size < 0 {
+		return "…"
+	}
+	return FormatSize(a.Size)
+}
+
 // calculateDirSize calculates the size of a directory
 func calculateDirSize(path string) int64 {
 	var size int64
-	_ = filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+	_ = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		if !info.IsDir() {
-			size += info.Size()
+		if info.IsDir() {
+			return nil
+		}
+		// Universal binaries only take up their simulator slice's worth
+		if isBinaryCandidate(file, info) {
+			if thin, err := thinBinarySize(file); err == nil {
+				size += thin
+				return nil
+			}
 		}
+		size += info.Size()
 		return nil
 	})
 	return size
 }
 
+// simulatorCPU is the architecture simulators run on this Mac, and so
+// the only slice of a universal binary they use
+var simulatorCPU = map[string]macho.Cpu{
+	"arm64": macho.CpuArm64,
+	"amd64": macho.CpuAmd64,
+}[runtime.GOARCH]
+
+// errNoSimulatorSlice is returned by thinBinarySize for universal
+// binaries without a slice for simulatorCPU
+var errNoSimulatorSlice = errors.New("no slice for the simulator architecture")
+
+// isBinaryCandidate reports whether the file at path may be a Mach-O
+// binary: a dylib, or an executable regular file
+func isBinaryCandidate(path string, info os.FileInfo) bool {
+	if !info.Mode().IsRegular() {
+		return false
+	}
+	return filepath.Ext(path) == ".dylib" || info.Mode().Perm()&0o111 != 0
+}
+
+// thinBinarySize returns the size of the simulator's slice of the
+// universal (fat) Mach-O binary at path. Other files, including thin
+// Mach-O binaries, return an error.
+func thinBinarySize(path string) (int64, error) {
+	fat, err := macho.OpenFat(path)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = fat.Close() }()
+	for _, arch := range fat.Arches {
+		if arch.Cpu == simulatorCPU {
+			return int64(arch.Size), nil
+		}
+	}
+	return 0, errNoSimulatorSlice
+}
+
 // findDataContainer finds the data container for an app by its bundle ID
 func findDataContainer(dataPath string, bundleID string) string {
 	entries, err := os.ReadDir(dataPath)
@@ -247,22 +437,8 @@ func findDataContainer(dataPath string, bundleID string) string {
 	for _, entry := range entries {
 		if entry.IsDir() {
 			containerPath := filepath.Join(dataPath, entry.Name())
-			// Check if .com.apple.mobile_container_manager.metadata.plist exists
-			metadataPath := filepath.Join(containerPath, ".com.apple.mobile_container_manager.metadata.plist")
-
-			// Try to read the metadata to verify this is the right container
-			output, err := defaultExecutor.Execute("plutil", "-convert", "json", "-o", "-", metadataPath)
-			if err != nil {
-				continue
-			}
-
-			var metadata map[string]interface{}
-			if err := json.Unmarshal(output, &metadata); err != nil {
-				continue
-			}
-
 			// Check if this container belongs to our app
-			if identifier, ok := metadata["MCMMetadataIdentifier"].(string); ok && identifier == bundleID {
+			if containerIdentifier(containerPath) == bundleID {
 				return containerPath
 			}
 		}
@@ -271,6 +447,28 @@ func findDataContainer(dataPath string, bundleID string) string {
 	return ""
 }
 
+// containerIdentifier returns the identifier a container belongs to, as
+// recorded in its .com.apple.mobile_container_manager.metadata.plist:
+// a bundle ID for data containers, a group ID for app group containers.
+// It returns "" if the metadata can't be read.
+func containerIdentifier(containerPath string) string {
+	metadataPath := filepath.Join(containerPath, ".com.apple.mobile_container_manager.metadata.plist")
+
+	// Try to read the metadata to verify this is the right container
+	output, err := defaultExecutor.Execute("plutil", "-convert", "json", "-o", "-", metadataPath)
+	if err != nil {
+		return ""
+	}
+
+	var metadata map[string]interface{}
+	if err := json.Unmarshal(output, &metadata); err != nil {
+		return ""
+	}
+
+	identifier, _ := metadata["MCMMetadataIdentifier"].(string)
+	return identifier
+}
+
 // FormatSize formats bytes into human readable format
 func FormatSize(bytes int64) string {
 	const unit = 1024
@@ -285,11 +483,39 @@ func FormatSize(bytes int64) string {
 	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
 
-// FormatModTime formats modification time in a human-friendly way
-func FormatModTime(t time.Time) string {
+// DateFormat is how FormatModTime and FormatFileDate show dates
+type DateFormat string
+
+// Date formats, as named by the display.date_format setting. The zero
+// DateFormat is relative.
+const (
+	DateFormatRelative DateFormat = "relative"
+	DateFormatAbsolute DateFormat = "absolute"
+	DateFormatISO      DateFormat = "iso"
+)
+
+// formatFixedDate formats t in the absolute and ISO formats, which are
+// the same wherever dates are shown. It reports false for relative
+// formats, whose wording depends on the list.
+func formatFixedDate(t time.Time, format DateFormat) (string, bool) {
+	switch format {
+	case DateFormatAbsolute:
+		return t.Format("2006-01-02 15:04"), true
+	case DateFormatISO:
+		return t.Format(time.RFC3339), true
+	}
+	return "", false
+}
+
+// FormatModTime formats modification time in a human-friendly way, or
+// as format asks. The zero time formats as "".
+func FormatModTime(t time.Time, format DateFormat) string {
 	if t.IsZero() {
 		return ""
 	}
+	if text, ok := formatFixedDate(t, format); ok {
+		return text
+	}
 
 	now := time.Now()
 	diff := now.Sub(t)
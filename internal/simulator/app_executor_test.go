@@ -84,6 +84,89 @@ func TestReadAppInfo_Success(t *testing.T) {
 	}
 }
 
+func TestReadAppInfo_URLSchemes(t *testing.T) {
+	fake := &fakeExecutor{
+		responses: map[string]fakeResult{
+			"plutil -convert json -o - /apps/Links.app/Info.plist": {
+				out: []byte(`{
+					"CFBundleIdentifier": "com.example.links",
+					"CFBundleURLTypes": [
+						{"CFBundleURLName": "main", "CFBundleURLSchemes": ["links", "links-dev"]},
+						{"CFBundleURLSchemes": ["fb123", "links"]},
+						{"CFBundleURLName": "no schemes"}
+					]
+				}`),
+			},
+		},
+	}
+	withFakeExecutor(t, fake)
+
+	info := readAppInfo("/apps/Links.app")
+	if info == nil {
+		t.Fatal("readAppInfo returned nil, want info")
+	}
+	want := []string{"links", "links-dev", "fb123"}
+	if strings.Join(info.URLSchemes, ",") != strings.Join(want, ",") {
+		t.Errorf("URLSchemes = %v, want %v", info.URLSchemes, want)
+	}
+}
+
+func TestDetectUIFramework(t *testing.T) {
+	appPath := t.TempDir()
+	write := func(name, data string) {
+		t.Helper()
+		path := filepath.Join(appPath, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("SwiftApp", "\xcf\xfa\xed\xfe/System/Library/Frameworks/SwiftUI.framework/SwiftUI\x00/System/Library/Frameworks/UIKit.framework/UIKit")
+	write("KitApp", "\xcf\xfa\xed\xfe/System/Library/Frameworks/UIKit.framework/UIKit")
+	write("Tool", "\xcf\xfa\xed\xfe/usr/lib/libSystem.B.dylib")
+
+	tests := []struct {
+		name  string
+		plist map[string]interface{}
+		want  string
+	}{
+		{"Catalyst principal class", map[string]interface{}{"NSPrincipalClass": "NSApplication", "CFBundleExecutable": "SwiftApp"}, UIFrameworkCatalyst},
+		{"links SwiftUI", map[string]interface{}{"CFBundleExecutable": "SwiftApp", "UIMainStoryboardFile": "Main"}, UIFrameworkSwiftUI},
+		{"main storyboard", map[string]interface{}{"CFBundleExecutable": "Tool", "UIMainStoryboardFile": "Main"}, UIFrameworkUIKit},
+		{"links UIKit", map[string]interface{}{"CFBundleExecutable": "KitApp"}, UIFrameworkUIKit},
+		{"nothing to go on", map[string]interface{}{"CFBundleExecutable": "Tool"}, UIFrameworkUnknown},
+		{"missing executable", map[string]interface{}{"CFBundleExecutable": "Gone"}, UIFrameworkUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectUIFramework(appPath, tt.plist); got != tt.want {
+				t.Errorf("detectUIFramework() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	write("Frameworks/SwiftUI.framework/SwiftUI", "")
+	if got := detectUIFramework(appPath, map[string]interface{}{"CFBundleExecutable": "Tool"}); got != UIFrameworkSwiftUI {
+		t.Errorf("detectUIFramework() with an embedded SwiftUI.framework = %q, want SwiftUI", got)
+	}
+}
+
+func TestOpenURL(t *testing.T) {
+	fake := &fakeExecutor{responses: map[string]fakeResult{
+		"xcrun simctl openurl udid-1 links://item/42": {},
+	}}
+	withFakeExecutor(t, fake)
+
+	if err := OpenURL("udid-1", "links://item/42"); err != nil {
+		t.Errorf("OpenURL() error = %v", err)
+	}
+	if err := OpenURL("udid-1", "other://"); err == nil {
+		t.Error("OpenURL() should report simctl failures")
+	}
+}
+
 func TestReadAppInfo_FallsBackToCFBundleName(t *testing.T) {
 	// Only CFBundleName is present — DisplayName should get it.
 	fake := &fakeExecutor{
@@ -385,3 +468,38 @@ func TestGetAppsForSimulator_RunningUsesListApps(t *testing.T) {
 		t.Error("expected xcrun simctl listapps to be called for running simulators")
 	}
 }
+
+// ---------- UninstallApp ----------
+
+func TestUninstallApp(t *testing.T) {
+	fake := &fakeExecutor{responses: map[string]fakeResult{
+		"xcrun simctl uninstall UDID com.example.ok":  {},
+		"xcrun simctl uninstall UDID com.example.bad": {out: []byte("No such app\n"), err: errors.New("exit status 1")},
+	}}
+	withFakeExecutor(t, fake)
+
+	if err := UninstallApp("UDID", "com.example.ok"); err != nil {
+		t.Errorf("UninstallApp() error = %v", err)
+	}
+	err := UninstallApp("UDID", "com.example.bad")
+	if err == nil || !strings.Contains(err.Error(), "No such app") {
+		t.Errorf("UninstallApp() error = %v, want simctl output", err)
+	}
+}
+
+func TestGetAppContainer(t *testing.T) {
+	fake := &fakeExecutor{responses: map[string]fakeResult{
+		"xcrun simctl get_app_container UDID com.example.ok data":  {out: []byte("/data/Containers/Data/Application/ABC\n")},
+		"xcrun simctl get_app_container UDID com.example.bad data": {out: []byte("No such file or directory\n"), err: errors.New("exit status 2")},
+	}}
+	withFakeExecutor(t, fake)
+
+	path, err := GetAppContainer("UDID", "com.example.ok")
+	if err != nil || path != "/data/Containers/Data/Application/ABC" {
+		t.Errorf("GetAppContainer() = %q, %v; want the trimmed path", path, err)
+	}
+	_, err = GetAppContainer("UDID", "com.example.bad")
+	if err == nil || !strings.Contains(err.Error(), "No such file") {
+		t.Errorf("GetAppContainer() error = %v, want simctl output", err)
+	}
+}
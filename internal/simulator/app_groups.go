@@ -0,0 +1,137 @@
+package simulator
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// appGroupsEntitlement is the entitlement listing an app's app groups
+const appGroupsEntitlement = "com.apple.security.application-groups"
+
+// ErrNoAppGroups is returned when an app's entitlements name no app groups
+var ErrNoAppGroups = errors.New("no app groups")
+
+// AppGroup is an app group an app belongs to and its shared container
+type AppGroup struct {
+	Identifier string // e.g. "group.com.example.shared"
+	Path       string // Shared container; empty until the group is first used
+	Size       int64
+}
+
+// FileInfo returns the group's container as a directory FileInfo
+func (g AppGroup) FileInfo() FileInfo {
+	info := FileInfo{Name: g.Identifier, Path: g.Path, Size: g.Size, IsDirectory: true}
+	if stat, err := os.Stat(g.Path); err == nil {
+		info.CreatedAt = stat.ModTime()
+		info.ModifiedAt = stat.ModTime()
+	}
+	return info
+}
+
+// GetAppGroups returns the app groups in the entitlements of app and the
+// shared containers the simulator with udid keeps for them in
+// Containers/Shared/AppGroup, sorted by identifier. It returns
+// ErrNoAppGroups if the app doesn't use any.
+func GetAppGroups(udid string, app App) ([]AppGroup, error) {
+	identifiers, err := readAppGroupIdentifiers(app.Path)
+	if err != nil {
+		return nil, err
+	}
+	if len(identifiers) == 0 {
+		return nil, ErrNoAppGroups
+	}
+
+	sharedPath := filepath.Join(os.Getenv("HOME"), "Library", "Developer", "CoreSimulator", "Devices", udid, "data", "Containers", "Shared", "AppGroup")
+	containers := findGroupContainers(sharedPath, identifiers)
+
+	groups := make([]AppGroup, 0, len(identifiers))
+	for _, id := range identifiers {
+		group := AppGroup{Identifier: id, Path: containers[id]}
+		if group.Path != "" {
+			group.Size = calculateDirSize(group.Path)
+		}
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Identifier < groups[j].Identifier
+	})
+	return groups, nil
+}
+
+// findGroupContainers maps each of the given group identifiers to its
+// container below sharedPath, matching containers by their metadata the
+// same way findDataContainer does.
+func findGroupContainers(sharedPath string, identifiers []string) map[string]string {
+	wanted := make(map[string]bool, len(identifiers))
+	for _, id := range identifiers {
+		wanted[id] = true
+	}
+
+	containers := make(map[string]string)
+	entries, err := os.ReadDir(sharedPath)
+	if err != nil {
+		return containers
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		containerPath := filepath.Join(sharedPath, entry.Name())
+		if id := containerIdentifier(containerPath); wanted[id] {
+			containers[id] = containerPath
+		}
+	}
+	return containers
+}
+
+// readAppGroupIdentifiers reads the app group entitlement of the app
+// bundle at appPath. Simulator builds carry their entitlements in the
+// code signature, so they are read with codesign.
+func readAppGroupIdentifiers(appPath string) ([]string, error) {
+	output, err := defaultExecutor.Execute("codesign", "-d", "--entitlements", ":-", appPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading entitlements: %w", err)
+	}
+	return parseEntitlementArray(output, appGroupsEntitlement), nil
+}
+
+// parseEntitlementArray returns the strings of the array stored under key
+// in an XML entitlements plist. Malformed XML yields what was read
+// before the error.
+func parseEntitlementArray(plist []byte, key string) []string {
+	decoder := xml.NewDecoder(bytes.NewReader(plist))
+
+	var values []string
+	var element, lastKey string
+	inArray := false
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return values
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			element = t.Name.Local
+			if element == "array" && lastKey == key {
+				inArray = true
+			}
+		case xml.EndElement:
+			if t.Name.Local == "array" && inArray {
+				return values
+			}
+			element = ""
+		case xml.CharData:
+			switch {
+			case element == "key":
+				lastKey = string(bytes.TrimSpace(t))
+			case element == "string" && inArray:
+				values = append(values, string(bytes.TrimSpace(t)))
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const sampleEntitlements = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>application-identifier</key>
+	<string>ABCDE12345.com.example.app</string>
+	<key>com.apple.security.application-groups</key>
+	<array>
+		<string>group.com.example.widgets</string>
+		<string>group.com.example.shared</string>
+	</array>
+	<key>keychain-access-groups</key>
+	<array>
+		<string>ABCDE12345.com.example.app</string>
+	</array>
+</dict>
+</plist>
+`
+
+func TestParseEntitlementArray(t *testing.T) {
+	got := parseEntitlementArray([]byte(sampleEntitlements), appGroupsEntitlement)
+	want := []string{"group.com.example.widgets", "group.com.example.shared"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseEntitlementArray() = %v, want %v", got, want)
+	}
+
+	if got := parseEntitlementArray([]byte(sampleEntitlements), "missing-key"); len(got) != 0 {
+		t.Errorf("missing key: got %v, want none", got)
+	}
+	if got := parseEntitlementArray(nil, appGroupsEntitlement); len(got) != 0 {
+		t.Errorf("empty input: got %v, want none", got)
+	}
+}
+
+func TestGetAppGroups(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	sharedPath := filepath.Join(home, "Library", "Developer", "CoreSimulator", "Devices", "udid-1", "data", "Containers", "Shared", "AppGroup")
+
+	fake := &fakeExecutor{responses: map[string]fakeResult{
+		"codesign -d --entitlements :- /apps/Example.app": {out: []byte(sampleEntitlements)},
+	}}
+	// Only the shared group has a container; "other" belongs to another app
+	for name, identifier := range map[string]string{"1111": "group.com.example.shared", "2222": "group.com.other"} {
+		dir := filepath.Join(sharedPath, name)
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "data.bin"), make([]byte, 100), 0600); err != nil {
+			t.Fatal(err)
+		}
+		key := fmt.Sprintf("plutil -convert json -o - %s/.com.apple.mobile_container_manager.metadata.plist", dir)
+		fake.responses[key] = fakeResult{out: []byte(fmt.Sprintf(`{"MCMMetadataIdentifier": %q}`, identifier))}
+	}
+	withFakeExecutor(t, fake)
+
+	groups, err := GetAppGroups("udid-1", App{Path: "/apps/Example.app"})
+	if err != nil {
+		t.Fatalf("GetAppGroups() error = %v", err)
+	}
+	want := []AppGroup{
+		{Identifier: "group.com.example.shared", Path: filepath.Join(sharedPath, "1111"), Size: 100},
+		{Identifier: "group.com.example.widgets"},
+	}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("GetAppGroups() = %+v, want %+v", groups, want)
+	}
+}
+
+func TestGetAppGroups_Errors(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{
+		"codesign -d --entitlements :- /apps/NoGroups.app": {out: []byte(`<plist><dict></dict></plist>`)},
+	}})
+
+	if _, err := GetAppGroups("udid-1", App{Path: "/apps/NoGroups.app"}); !errors.Is(err, ErrNoAppGroups) {
+		t.Errorf("no groups: err = %v, want ErrNoAppGroups", err)
+	}
+	if _, err := GetAppGroups("udid-1", App{Path: "/apps/Unsigned.app"}); err == nil || errors.Is(err, ErrNoAppGroups) {
+		t.Errorf("codesign failure: err = %v, want the codesign error", err)
+	}
+}
@@ -0,0 +1,138 @@
+package simulator
+
+import (
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// requireIntegration skips t unless SIMTOOL_INTEGRATION_TESTS=1 and
+// xcrun is available. Integration tests run real simctl commands
+// against the simulators installed on the machine.
+func requireIntegration(t *testing.T) {
+	t.Helper()
+	if os.Getenv("SIMTOOL_INTEGRATION_TESTS") != "1" {
+		t.Skip("set SIMTOOL_INTEGRATION_TESTS=1 to run integration tests")
+	}
+	if _, err := exec.LookPath("xcrun"); err != nil {
+		t.Skip("xcrun not found in PATH")
+	}
+}
+
+// checkAppList fails t unless apps are sorted by name, have a name and
+// a bundle ID, and leave out Apple's own apps
+func checkAppList(t *testing.T, apps []App) {
+	t.Helper()
+	if !sort.SliceIsSorted(apps, func(i, j int) bool { return apps[i].Name < apps[j].Name }) {
+		t.Error("apps are not sorted by name")
+	}
+	for _, app := range apps {
+		if app.Name == "" || app.BundleID == "" {
+			t.Errorf("app %+v has no name or bundle ID", app)
+		}
+		if strings.HasPrefix(app.BundleID, "com.apple.") {
+			t.Errorf("system app %s should be left out", app.BundleID)
+		}
+	}
+}
+
+func TestGetAppsForSimulator_Integration(t *testing.T) {
+	requireIntegration(t)
+
+	sims, err := NewFetcher().FetchSimulators()
+	if err != nil {
+		t.Fatalf("FetchSimulators: %v", err)
+	}
+	var booted, shutdown *Simulator
+	for i := range sims {
+		if sims[i].IsRunning() && booted == nil {
+			booted = &sims[i]
+		} else if !sims[i].IsRunning() && shutdown == nil {
+			shutdown = &sims[i]
+		}
+	}
+
+	t.Run("listapps", func(t *testing.T) {
+		if booted == nil {
+			t.Skip("no booted simulator")
+		}
+		apps, err := GetAppsForSimulator(booted.UDID, true)
+		if err != nil {
+			t.Fatalf("GetAppsForSimulator(%s): %v", booted.Name, err)
+		}
+		checkAppList(t, apps)
+	})
+	t.Run("data directory", func(t *testing.T) {
+		if shutdown == nil {
+			t.Skip("no shut down simulator")
+		}
+		apps, err := GetAppsForSimulator(shutdown.UDID, false)
+		if err != nil {
+			t.Fatalf("GetAppsForSimulator(%s): %v", shutdown.Name, err)
+		}
+		checkAppList(t, apps)
+	})
+}
+
+// The tests below replay listapps output through the fake executor, so
+// they run everywhere
+
+func TestGetAppsForSimulator_MissingDataContainer(t *testing.T) {
+	// Apps that haven't been launched yet may have no data container,
+	// and apps without a display name fall back to their bundle ID
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{
+		"xcrun simctl listapps UDID": {out: []byte(`{
+    "com.example.fresh" =     {
+        CFBundleShortVersionString = "0.1";
+        Path = /tmp/fresh.app;
+    };
+}`)},
+	}})
+
+	apps, err := GetAppsForSimulator("UDID", true)
+	if err != nil {
+		t.Fatalf("GetAppsForSimulator: %v", err)
+	}
+	if len(apps) != 1 {
+		t.Fatalf("len(apps) = %d, want 1", len(apps))
+	}
+	if apps[0].Container != "" || apps[0].Name != "com.example.fresh" || apps[0].Version != "0.1" {
+		t.Errorf("apps[0] = %+v, want no container, named after its bundle ID", apps[0])
+	}
+}
+
+func TestGetAppsForSimulator_SortsByName(t *testing.T) {
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{
+		"xcrun simctl listapps UDID": {out: []byte(`{
+    "com.example.zebra" =     {
+        CFBundleDisplayName = "Zebra";
+        DataContainer = "/tmp/zebra";
+    };
+    "com.apple.mobilesafari" =     {
+        CFBundleDisplayName = "Safari";
+    };
+    "com.example.aardvark" =     {
+        DataContainer = "/tmp/aardvark";
+    };
+    "com.example.mango" =     {
+        CFBundleDisplayName = "Mango";
+    };
+}`)},
+	}})
+
+	apps, err := GetAppsForSimulator("UDID", true)
+	if err != nil {
+		t.Fatalf("GetAppsForSimulator: %v", err)
+	}
+	var names []string
+	for _, app := range apps {
+		names = append(names, app.Name)
+	}
+	want := []string{"Mango", "Zebra", "com.example.aardvark"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+	checkAppList(t, apps)
+}
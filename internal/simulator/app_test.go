@@ -1,9 +1,16 @@
 package simulator
 
 import (
+	"bytes"
+	"debug/macho"
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"testing"
 )
 
@@ -122,8 +129,11 @@ func TestGetAppsFromDataDir_WithAppUsesInfoPlist(t *testing.T) {
 	if got.Container != dataContainer {
 		t.Errorf("Container = %q, want %q", got.Container, dataContainer)
 	}
-	if got.Size < 10 {
-		t.Errorf("Size = %d, want >= 10 (bundle contains a 10-byte file)", got.Size)
+	if got.Size != AppSizeUnknown {
+		t.Errorf("Size = %d, want AppSizeUnknown until measured", got.Size)
+	}
+	if size := AppSize(got.Path); size < 10 {
+		t.Errorf("AppSize = %d, want >= 10 (bundle contains a 10-byte file)", size)
 	}
 	if got.ModTime.IsZero() {
 		t.Error("ModTime is zero, want non-zero")
@@ -243,3 +253,100 @@ func TestGetAppsFromDataDir_SortsByName(t *testing.T) {
 		t.Errorf("sort order = [%q, %q], want [Alpha, Zebra]", apps[0].Name, apps[1].Name)
 	}
 }
+
+// fatBinary returns a universal Mach-O binary with a slice of the given
+// size for each CPU. Each slice is a bare 64-bit Mach-O header padded
+// with zeros.
+func fatBinary(t *testing.T, sizes map[macho.Cpu]int) []byte {
+	t.Helper()
+	cpus := slices.Sorted(maps.Keys(sizes))
+	var header, body bytes.Buffer
+	write := func(buf *bytes.Buffer, order binary.ByteOrder, values ...uint32) {
+		for _, v := range values {
+			if err := binary.Write(buf, order, v); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	write(&header, binary.BigEndian, macho.MagicFat, uint32(len(cpus)))
+	offset := 8 + 20*len(cpus)
+	for _, cpu := range cpus {
+		size := sizes[cpu]
+		write(&header, binary.BigEndian, uint32(cpu), 0, uint32(offset), uint32(size), 0)
+
+		var slice bytes.Buffer
+		write(&slice, binary.LittleEndian, macho.Magic64, uint32(cpu), 0, uint32(macho.TypeExec), 0, 0, 0, 0)
+		slice.Write(make([]byte, size-slice.Len()))
+		body.Write(slice.Bytes())
+		offset += size
+	}
+	return append(header.Bytes(), body.Bytes()...)
+}
+
+func TestThinBinarySize(t *testing.T) {
+	if simulatorCPU == 0 {
+		t.Skipf("no simulator architecture on %s", runtime.GOARCH)
+	}
+	other := macho.CpuArm64
+	if simulatorCPU == macho.CpuArm64 {
+		other = macho.CpuAmd64
+	}
+	dir := t.TempDir()
+	write := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	universal := write("App", fatBinary(t, map[macho.Cpu]int{simulatorCPU: 300, other: 500}))
+	if size, err := thinBinarySize(universal); err != nil || size != 300 {
+		t.Errorf("thinBinarySize(universal) = %d, %v; want 300", size, err)
+	}
+
+	foreign := write("Foreign", fatBinary(t, map[macho.Cpu]int{other: 500}))
+	if _, err := thinBinarySize(foreign); !errors.Is(err, errNoSimulatorSlice) {
+		t.Errorf("thinBinarySize(foreign) error = %v, want errNoSimulatorSlice", err)
+	}
+
+	if _, err := thinBinarySize(write("run.sh", []byte("#!/bin/sh\necho hi\n"))); err == nil {
+		t.Error("thinBinarySize(script) should fail")
+	}
+}
+
+func TestCalculateDirSize_UniversalBinaries(t *testing.T) {
+	if simulatorCPU == 0 {
+		t.Skipf("no simulator architecture on %s", runtime.GOARCH)
+	}
+	other := macho.CpuArm64
+	if simulatorCPU == macho.CpuArm64 {
+		other = macho.CpuAmd64
+	}
+	fat := fatBinary(t, map[macho.Cpu]int{simulatorCPU: 300, other: 500})
+	app := filepath.Join(t.TempDir(), "Example.app")
+	if err := os.MkdirAll(filepath.Join(app, "Frameworks"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := []struct {
+		name string
+		data []byte
+		mode os.FileMode
+	}{
+		{"Example", fat, 0o755},                   // Executable: counts its 300 byte slice
+		{"Frameworks/libKit.dylib", fat, 0o644},   // Dylib: 300 too
+		{"Frameworks/blob.bin", fat, 0o644},       // Neither: counted whole
+		{"Info.plist", []byte("<plist/>"), 0o644}, // 8 bytes
+	}
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(app, f.name), f.data, f.mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := int64(300 + 300 + len(fat) + 8)
+	if got := calculateDirSize(app); got != want {
+		t.Errorf("calculateDirSize() = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,239 @@
+package simulator
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"unicode/utf16"
+)
+
+// bplistMaxDepth bounds nesting while decoding a binary plist, so a
+// malformed file whose objects reference each other can't recurse
+// forever.
+const bplistMaxDepth = 32
+
+// bplistMaxVisitsPerByte bounds how many objects decoding a binary
+// plist visits, relative to its size. Objects referenced from several
+// places are decoded once per reference, so without a budget a few
+// arrays that each reference the next twice would take exponential
+// time.
+const bplistMaxVisitsPerByte = 4
+
+// errBadBPlist is returned for data that isn't a well-formed bplist00
+// document.
+var errBadBPlist = errors.New("malformed binary plist")
+
+// bplistDecoder holds a binary plist and the layout read from its trailer
+type bplistDecoder struct {
+	data        []byte
+	offsets     []uint64
+	objectRefSz int
+	visitsLeft  int // Objects left to decode before giving up
+}
+
+// decodeBinaryPlist decodes a bplist00 document into Go values: maps,
+// slices, strings, int64, float64, bool, []byte, and nil. It covers the
+// object types found in cache blobs, not the whole format; unsupported
+// objects decode as nil.
+func decodeBinaryPlist(data []byte) (any, error) {
+	if len(data) < 40 || string(data[:8]) != "bplist00" {
+		return nil, errBadBPlist
+	}
+
+	trailer := data[len(data)-32:]
+	offsetIntSize := int(trailer[6])
+	objectRefSize := int(trailer[7])
+	numObjects := binary.BigEndian.Uint64(trailer[8:16])
+	topObject := binary.BigEndian.Uint64(trailer[16:24])
+	tableOffset := binary.BigEndian.Uint64(trailer[24:32])
+
+	if offsetIntSize < 1 || offsetIntSize > 8 || objectRefSize < 1 || objectRefSize > 8 {
+		return nil, errBadBPlist
+	}
+	// The offset table must fit between the header and the trailer;
+	// checking this first keeps the table size from overflowing
+	if numObjects == 0 || numObjects > uint64(len(data)-40)/uint64(offsetIntSize) || topObject >= numObjects {
+		return nil, errBadBPlist
+	}
+	tableEnd := tableOffset + numObjects*uint64(offsetIntSize)
+	if tableEnd > uint64(len(data)-32) || tableEnd < tableOffset {
+		return nil, errBadBPlist
+	}
+
+	d := &bplistDecoder{
+		data:        data,
+		objectRefSz: objectRefSize,
+		visitsLeft:  len(data) * bplistMaxVisitsPerByte,
+	}
+	d.offsets = make([]uint64, numObjects)
+	for i := range d.offsets {
+		start := tableOffset + uint64(i*offsetIntSize)
+		d.offsets[i] = readUint(data[start : start+uint64(offsetIntSize)])
+	}
+	return d.object(topObject, 0)
+}
+
+// object decodes the object with the given index
+func (d *bplistDecoder) object(ref uint64, depth int) (any, error) {
+	if depth > bplistMaxDepth || ref >= uint64(len(d.offsets)) || d.visitsLeft <= 0 {
+		return nil, errBadBPlist
+	}
+	d.visitsLeft--
+	pos := d.offsets[ref]
+	if pos >= uint64(len(d.data)) {
+		return nil, errBadBPlist
+	}
+
+	marker := d.data[pos]
+	kind, info := marker>>4, int(marker&0x0f)
+	switch kind {
+	case 0x0:
+		switch marker {
+		case 0x08:
+			return false, nil
+		case 0x09:
+			return true, nil
+		}
+		return nil, nil
+	case 0x1:
+		b, err := d.bytes(pos+1, 1<<info)
+		if err != nil {
+			return nil, err
+		}
+		return int64(readUint(b)), nil
+	case 0x2, 0x3:
+		size := 1 << info
+		if kind == 0x3 {
+			size = 8 // Dates are always 8-byte floats
+		}
+		if size != 4 && size != 8 {
+			return nil, errBadBPlist
+		}
+		b, err := d.bytes(pos+1, size)
+		if err != nil {
+			return nil, err
+		}
+		if size == 4 {
+			return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), nil
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+	case 0x4, 0x5:
+		count, start, err := d.count(pos, info)
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.bytes(start, count)
+		if err != nil {
+			return nil, err
+		}
+		if kind == 0x5 {
+			return string(b), nil
+		}
+		return b, nil
+	case 0x6:
+		count, start, err := d.count(pos, info)
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.bytes(start, count*2)
+		if err != nil {
+			return nil, err
+		}
+		units := make([]uint16, count)
+		for i := range units {
+			units[i] = binary.BigEndian.Uint16(b[i*2:])
+		}
+		return string(utf16.Decode(units)), nil
+	case 0x8:
+		b, err := d.bytes(pos+1, info+1)
+		if err != nil {
+			return nil, err
+		}
+		return int64(readUint(b)), nil
+	case 0xA:
+		count, start, err := d.count(pos, info)
+		if err != nil {
+			return nil, err
+		}
+		refs, err := d.bytes(start, count*d.objectRefSz)
+		if err != nil {
+			return nil, err
+		}
+		array := make([]any, count)
+		for i := range array {
+			if array[i], err = d.object(d.ref(refs, i), depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return array, nil
+	case 0xD:
+		count, start, err := d.count(pos, info)
+		if err != nil {
+			return nil, err
+		}
+		refs, err := d.bytes(start, 2*count*d.objectRefSz)
+		if err != nil {
+			return nil, err
+		}
+		dict := make(map[string]any, count)
+		for i := range count {
+			key, err := d.object(d.ref(refs, i), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			value, err := d.object(d.ref(refs, count+i), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			dict[fmt.Sprint(key)] = value
+		}
+		return dict, nil
+	}
+	return nil, nil
+}
+
+// count returns the element count of the object at pos and where its
+// contents start. Counts of 15 or more are stored as a following int.
+func (d *bplistDecoder) count(pos uint64, info int) (int, uint64, error) {
+	if info != 0x0f {
+		return info, pos + 1, nil
+	}
+	b, err := d.bytes(pos+1, 1)
+	if err != nil || b[0]>>4 != 0x1 {
+		return 0, 0, errBadBPlist
+	}
+	size := 1 << (b[0] & 0x0f)
+	n, err := d.bytes(pos+2, size)
+	if err != nil {
+		return 0, 0, err
+	}
+	count := readUint(n)
+	if count > uint64(len(d.data)) {
+		return 0, 0, errBadBPlist
+	}
+	return int(count), pos + 2 + uint64(size), nil
+}
+
+// bytes returns n bytes starting at pos
+func (d *bplistDecoder) bytes(pos uint64, n int) ([]byte, error) {
+	end := pos + uint64(n)
+	if n < 0 || end > uint64(len(d.data)) || end < pos {
+		return nil, errBadBPlist
+	}
+	return d.data[pos:end], nil
+}
+
+// ref returns the i-th object reference in refs
+func (d *bplistDecoder) ref(refs []byte, i int) uint64 {
+	return readUint(refs[i*d.objectRefSz : (i+1)*d.objectRefSz])
+}
+
+// readUint reads a big-endian unsigned integer of up to 8 bytes
+func readUint(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
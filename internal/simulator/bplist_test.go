@@ -0,0 +1,113 @@
+package simulator
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// cachedResponseBPlist is a response_object as NSURLCache stores it,
+// written with Python's plistlib:
+//
+//	{"Version": 1, "Array": [{"_CFURLString": "https://example.com/api.json",
+//	  "_CFURLStringType": 15}, 712345678.5, 0, 200,
+//	  {"Content-Type": "application/json", "Ünïcode": "değer"},
+//	  "application/json", b"\x00\x01"]}
+const cachedResponseBPlist = "62706c6973743030d2010203125541727261795756657273696f6ea704090a0b0c0f11d2050607085c5f434655524c537472696e675f10105f434655524c537472696e67547970655f101c68747470733a2f2f6578616d706c652e636f6d2f6170692e6a736f6e100f2341c53ac427400000100010c8d20d0e0f105c436f6e74656e742d547970656700dc006e00ef0063006f006400655f10106170706c69636174696f6e2f6a736f6e6500640065011f006500724200011001080d131b2328354867697274767b8897aab5b800000000000001010000000000000013000000000000000000000000000000ba"
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// buildBPlist assembles a bplist00 document from the bytes of its
+// objects and offset table and the fields of its trailer
+func buildBPlist(body []byte, offsetIntSize, objectRefSize byte, numObjects, topObject, tableOffset uint64) []byte {
+	data := append([]byte("bplist00"), body...)
+	trailer := make([]byte, 32)
+	trailer[6], trailer[7] = offsetIntSize, objectRefSize
+	binary.BigEndian.PutUint64(trailer[8:], numObjects)
+	binary.BigEndian.PutUint64(trailer[16:], topObject)
+	binary.BigEndian.PutUint64(trailer[24:], tableOffset)
+	return append(data, trailer...)
+}
+
+// overflowingTableBPlist claims so many 8-byte offsets that the size of
+// its offset table overflows uint64
+func overflowingTableBPlist() []byte {
+	return buildBPlist(make([]byte, 8), 8, 1, 1<<61+1, 0, 8)
+}
+
+// sharedSubtreesBPlist is a chain of 32 arrays that each reference the
+// next array twice, ending in an integer: tiny, and well within the
+// depth limit, but 2^33 objects deep when every reference is followed
+func sharedSubtreesBPlist() []byte {
+	const arrays = 32
+	var objects, offsets []byte
+	for i := range arrays {
+		offsets = append(offsets, byte(8+len(objects)))
+		objects = append(objects, 0xA2, byte(i+1), byte(i+1))
+	}
+	offsets = append(offsets, byte(8+len(objects)))
+	objects = append(objects, 0x10, 0x01)
+	return buildBPlist(append(objects, offsets...), 1, 1, arrays+1, 0, uint64(8+len(objects)))
+}
+
+func TestDecodeBinaryPlist(t *testing.T) {
+	got, err := decodeBinaryPlist(mustDecodeHex(t, cachedResponseBPlist))
+	if err != nil {
+		t.Fatalf("decodeBinaryPlist() error = %v", err)
+	}
+
+	want := map[string]any{
+		"Version": int64(1),
+		"Array": []any{
+			map[string]any{"_CFURLString": "https://example.com/api.json", "_CFURLStringType": int64(15)},
+			712345678.5,
+			int64(0),
+			int64(200),
+			map[string]any{"Content-Type": "application/json", "Ünïcode": "değer"},
+			"application/json",
+			[]byte{0x00, 0x01},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("decodeBinaryPlist() =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+func TestDecodeBinaryPlist_Malformed(t *testing.T) {
+	valid := mustDecodeHex(t, cachedResponseBPlist)
+
+	truncated := valid[:len(valid)-40]
+	badTopObject := append([]byte(nil), valid...)
+	badTopObject[len(badTopObject)-9] = 0xff
+
+	// An array holding itself twice
+	selfReference := buildBPlist([]byte{0xA2, 0x00, 0x00, 0x08}, 1, 1, 1, 0, 11)
+	// A real of 1 byte, where only 4 and 8 are valid
+	oneByteReal := buildBPlist([]byte{0x20, 0x00, 0x08}, 1, 1, 1, 0, 10)
+
+	for name, data := range map[string][]byte{
+		"empty":                    nil,
+		"not a bplist":             []byte("<?xml version=\"1.0\"?><plist></plist>                  "),
+		"truncated":                truncated,
+		"bad top object":           badTopObject,
+		"overflowing offset table": overflowingTableBPlist(),
+		"self reference":           selfReference,
+		"shared subtrees":          sharedSubtreesBPlist(),
+		"one-byte real":            oneByteReal,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := decodeBinaryPlist(data); !errors.Is(err, errBadBPlist) {
+				t.Errorf("decodeBinaryPlist() error = %v, want errBadBPlist", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package simulator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// bundleCodeDirs are the app bundle directories holding code and signing
+// data rather than resources
+var bundleCodeDirs = map[string]bool{
+	"Frameworks":     true,
+	"PlugIns":        true,
+	"Extensions":     true,
+	"Watch":          true,
+	"AppClips":       true,
+	"XPCServices":    true,
+	"_CodeSignature": true,
+	"SC_Info":        true,
+	"META-INF":       true,
+}
+
+// bundleGeneratedFiles are files the build and install steps add to an
+// app bundle
+var bundleGeneratedFiles = map[string]bool{
+	"embedded.mobileprovision": true,
+	"CodeResources":            true,
+	"PkgInfo":                  true,
+}
+
+// IsBundleResource reports whether file in an app bundle is a resource,
+// as opposed to code, signing data or an executable
+func IsBundleResource(file FileInfo) bool {
+	if file.IsDirectory {
+		return !bundleCodeDirs[file.Name]
+	}
+	if bundleGeneratedFiles[file.Name] {
+		return false
+	}
+	switch strings.ToLower(filepath.Ext(file.Name)) {
+	case ".dylib", ".so", ".a":
+		return false
+	case "":
+		// The main executable and other binaries have no extension
+		info, err := os.Stat(file.Path)
+		return err != nil || info.Mode()&0o111 == 0
+	}
+	return true
+}
+
+// FilterBundleResources returns the files that are bundle resources
+func FilterBundleResources(files []FileInfo) []FileInfo {
+	var resources []FileInfo
+	for _, file := range files {
+		if IsBundleResource(file) {
+			resources = append(resources, file)
+		}
+	}
+	return resources
+}
+
+// FilterBundleResourceTree returns nodes without the entries that aren't
+// bundle resources, at every level of the tree
+func FilterBundleResourceTree(nodes []TreeNode) []TreeNode {
+	var resources []TreeNode
+	for _, node := range nodes {
+		if !IsBundleResource(node.FileInfo) {
+			continue
+		}
+		node.Children = FilterBundleResourceTree(node.Children)
+		resources = append(resources, node)
+	}
+	return resources
+}
+
+// ExpandLocalizations marks the loaded .lproj directories in nodes, and
+// the directories containing them, as expanded. Other directories are
+// left as they are. It reports whether nodes contain a localization.
+func ExpandLocalizations(nodes []TreeNode, expanded map[string]bool) bool {
+	found := false
+	for _, node := range nodes {
+		if !node.IsDirectory || !node.Loaded {
+			continue
+		}
+		if strings.HasSuffix(node.Name, ".lproj") || ExpandLocalizations(node.Children, expanded) {
+			expanded[node.Path] = true
+			found = true
+		}
+	}
+	return found
+}
@@ -0,0 +1,90 @@
+package simulator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// makeBundle creates an app bundle with resources, localizations, code
+// and signing data.
+func makeBundle(t *testing.T) string {
+	t.Helper()
+	bundle := filepath.Join(t.TempDir(), "Demo.app")
+	for _, dir := range []string{"en.lproj", "Base.lproj", "Assets", "Frameworks/Lib.framework", "_CodeSignature"} {
+		if err := os.MkdirAll(filepath.Join(bundle, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := map[string]os.FileMode{
+		"Info.plist":                   0o644,
+		"Demo":                         0o755,
+		"LICENSE":                      0o644,
+		"embedded.mobileprovision":     0o644,
+		"libswift.dylib":               0o755,
+		"en.lproj/Localizable.strings": 0o644,
+		"Base.lproj/Main.storyboardc":  0o644,
+		"Assets/logo.png":              0o644,
+		"Frameworks/Lib.framework/Lib": 0o755,
+		"_CodeSignature/CodeResources": 0o644,
+	}
+	for name, mode := range files {
+		if err := os.WriteFile(filepath.Join(bundle, name), []byte("x"), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return bundle
+}
+
+func TestFilterBundleResources(t *testing.T) {
+	bundle := makeBundle(t)
+	files, err := GetFilesForContainer(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, file := range FilterBundleResources(files) {
+		names = append(names, file.Name)
+	}
+	got := strings.Join(names, ",")
+	for _, want := range []string{"Info.plist", "LICENSE", "en.lproj", "Base.lproj", "Assets"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("resources %s should include %s", got, want)
+		}
+	}
+	for _, hidden := range []string{"Frameworks", "_CodeSignature", "embedded.mobileprovision", "libswift.dylib", "Demo,"} {
+		if strings.Contains(got+",", hidden) {
+			t.Errorf("resources %s should not include %s", got, hidden)
+		}
+	}
+}
+
+func TestExpandLocalizations(t *testing.T) {
+	bundle := makeBundle(t)
+	nodes, err := ReadContainerTree(bundle, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes = FilterBundleResourceTree(nodes)
+
+	expanded := make(map[string]bool)
+	if !ExpandLocalizations(nodes, expanded) {
+		t.Fatal("ExpandLocalizations() found no localizations")
+	}
+	if !expanded[filepath.Join(bundle, "en.lproj")] || !expanded[filepath.Join(bundle, "Base.lproj")] {
+		t.Errorf("the .lproj directories should be expanded: %v", expanded)
+	}
+	if expanded[filepath.Join(bundle, "Assets")] {
+		t.Error("other directories should stay collapsed")
+	}
+
+	var visible []string
+	for _, node := range VisibleTreeNodes(nodes, expanded) {
+		visible = append(visible, node.Name)
+	}
+	if got := strings.Join(visible, ","); !strings.Contains(got, "Localizable.strings") || strings.Contains(got, "logo.png") {
+		t.Errorf("visible tree = %s, want localization files but not collapsed contents", got)
+	}
+}
@@ -0,0 +1,117 @@
+package simulator
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Hardware features reported by DeviceCapabilities
+const (
+	CapabilityFaceID        = "Face ID"
+	CapabilityTouchID       = "Touch ID"
+	CapabilityDynamicIsland = "Dynamic Island"
+	CapabilityHomeButton    = "Home Button"
+)
+
+// deviceTypePrefix starts every simctl device type identifier
+const deviceTypePrefix = "com.apple.CoreSimulator.SimDeviceType."
+
+var (
+	// leadingNumberPattern matches the model number at the start of an
+	// iPhone device type name, e.g. "15" in "15-Pro-Max"
+	leadingNumberPattern = regexp.MustCompile(`^(\d+)`)
+	// generationPattern matches an iPad generation, e.g. "10th-generation"
+	generationPattern = regexp.MustCompile(`(\d+)(?:st|nd|rd|th)-generation`)
+)
+
+// DeviceCapabilities returns the biometric and screen features of the
+// device type with identifier, e.g. Face ID and Dynamic Island for
+// com.apple.CoreSimulator.SimDeviceType.iPhone-15-Pro. simctl's device
+// type list doesn't describe them, so they are derived from the model
+// in the identifier. Devices other than iPhones and iPads, and unknown
+// identifiers, have none.
+func DeviceCapabilities(identifier string) []string {
+	name, ok := strings.CutPrefix(identifier, deviceTypePrefix)
+	if !ok {
+		return nil
+	}
+	if model, ok := strings.CutPrefix(name, "iPhone-"); ok {
+		return iPhoneCapabilities(model)
+	}
+	if model, ok := strings.CutPrefix(name, "iPad-"); ok {
+		return iPadCapabilities(model)
+	}
+	return nil
+}
+
+// iPhoneCapabilities returns the features of the iPhone model named as
+// in its device type, e.g. "SE-3rd-generation" or "16-Pro"
+func iPhoneCapabilities(model string) []string {
+	homeButton := []string{CapabilityTouchID, CapabilityHomeButton}
+	if strings.HasPrefix(model, "SE") {
+		return homeButton
+	}
+	if model == "Air" {
+		return []string{CapabilityFaceID, CapabilityDynamicIsland}
+	}
+
+	match := leadingNumberPattern.FindString(model)
+	if match == "" {
+		// iPhone X, XS and XR
+		return []string{CapabilityFaceID}
+	}
+	number, _ := strconv.Atoi(match)
+	rest := model[len(match):]
+	switch {
+	case number <= 8:
+		return homeButton
+	case rest == "e":
+		// The 16e has a notch rather than the Dynamic Island
+		return []string{CapabilityFaceID}
+	case number >= 15, number == 14 && strings.HasPrefix(rest, "-Pro"):
+		return []string{CapabilityFaceID, CapabilityDynamicIsland}
+	}
+	return []string{CapabilityFaceID}
+}
+
+// iPadCapabilities returns the features of the iPad model named as in
+// its device type, e.g. "Pro-11-inch-M4-8GB" or "mini-6th-generation".
+// iPads without a home button have Touch ID in the top button, except
+// the Face ID iPad Pros.
+func iPadCapabilities(model string) []string {
+	homeButton := []string{CapabilityTouchID, CapabilityHomeButton}
+	topButton := []string{CapabilityTouchID}
+	generation := 0
+	if match := generationPattern.FindStringSubmatch(model); match != nil {
+		generation, _ = strconv.Atoi(match[1])
+	}
+
+	switch {
+	case strings.HasPrefix(model, "Pro-11-inch"), strings.HasPrefix(model, "Pro-13-inch"):
+		return []string{CapabilityFaceID}
+	case strings.HasPrefix(model, "Pro-12-9-inch"):
+		if generation >= 3 {
+			return []string{CapabilityFaceID}
+		}
+		return homeButton
+	case strings.HasPrefix(model, "Pro-"):
+		// The 9.7 and 10.5 inch iPad Pros
+		return homeButton
+	case strings.HasPrefix(model, "Air"):
+		if model == "Air" || model == "Air-2" || generation >= 1 && generation <= 3 {
+			return homeButton
+		}
+		return topButton
+	case strings.HasPrefix(model, "mini"):
+		// The mini 2 to 4 are named by number, later ones by generation or chip
+		if model == "mini-2" || model == "mini-3" || model == "mini-4" || generation >= 1 && generation <= 5 {
+			return homeButton
+		}
+		return topButton
+	case generation >= 1 && generation <= 9:
+		return homeButton
+	}
+	// The 10th generation iPad and later, including the A16
+	return topButton
+}
@@ -0,0 +1,52 @@
+package simulator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDeviceCapabilities(t *testing.T) {
+	tests := []struct {
+		deviceType string
+		want       string
+	}{
+		{"iPhone-SE-3rd-generation", "Touch ID, Home Button"},
+		{"iPhone-8-Plus", "Touch ID, Home Button"},
+		{"iPhone-X", "Face ID"},
+		{"iPhone-XS-Max", "Face ID"},
+		{"iPhone-11-Pro", "Face ID"},
+		{"iPhone-14", "Face ID"},
+		{"iPhone-14-Pro-Max", "Face ID, Dynamic Island"},
+		{"iPhone-15", "Face ID, Dynamic Island"},
+		{"iPhone-16e", "Face ID"},
+		{"iPhone-17-Pro", "Face ID, Dynamic Island"},
+		{"iPhone-Air", "Face ID, Dynamic Island"},
+		{"iPad-Pro-11-inch-4th-generation-8GB", "Face ID"},
+		{"iPad-Pro-13-inch-M4-8GB", "Face ID"},
+		{"iPad-Pro-12-9-inch-6th-generation-16GB", "Face ID"},
+		{"iPad-Pro-12-9-inch-2nd-generation", "Touch ID, Home Button"},
+		{"iPad-Pro-9-7-inch", "Touch ID, Home Button"},
+		{"iPad-Air-3rd-generation", "Touch ID, Home Button"},
+		{"iPad-Air-5th-generation", "Touch ID"},
+		{"iPad-Air-11-inch-M2", "Touch ID"},
+		{"iPad-mini-5th-generation", "Touch ID, Home Button"},
+		{"iPad-mini-6th-generation", "Touch ID"},
+		{"iPad-mini-A17-Pro", "Touch ID"},
+		{"iPad-9th-generation", "Touch ID, Home Button"},
+		{"iPad-10th-generation", "Touch ID"},
+		{"iPad-A16", "Touch ID"},
+		{"Apple-Watch-Series-9-45mm", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.deviceType, func(t *testing.T) {
+			got := strings.Join(DeviceCapabilities("com.apple.CoreSimulator.SimDeviceType."+tt.deviceType), ", ")
+			if got != tt.want {
+				t.Errorf("DeviceCapabilities(%s) = %q, want %q", tt.deviceType, got, tt.want)
+			}
+		})
+	}
+
+	if got := DeviceCapabilities("iPhone-15"); got != nil {
+		t.Errorf("DeviceCapabilities without the identifier prefix = %v, want nil", got)
+	}
+}
@@ -0,0 +1,124 @@
+package simulator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ResolveContainerPath returns the absolute path of rel inside the data
+// container of the app with bundleID on the simulator with udid. Paths
+// leading out of the container are rejected.
+func ResolveContainerPath(udid, bundleID, rel string) (string, error) {
+	if !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("%s is not a path inside the app container", rel)
+	}
+	container, err := GetAppContainer(udid, bundleID)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(container, rel), nil
+}
+
+// CatFile writes the content of the file at path to w in a form suited
+// to shell pipelines: text files as they are, binary plists converted to
+// XML, SQLite databases as a schema dump, and other binary files as a
+// hex dump. Nothing is highlighted.
+func CatFile(w io.Writer, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory", path)
+	}
+
+	switch DetectFileType(path) {
+	case FileTypeText:
+		if isBinaryPlistFile(path) {
+			xml, err := convertPlist(path, "xml1")
+			if err != nil {
+				return fmt.Errorf("converting binary plist: %w", err)
+			}
+			_, err = w.Write(xml)
+			return err
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = file.Close() }()
+		_, err = io.Copy(w, file)
+		return err
+
+	case FileTypeDatabase:
+		dbInfo, err := ReadDatabaseContent(path)
+		if errors.Is(err, ErrNoRealmTooling) {
+			// Realm files without realm-cli are dumped as hex like in the viewer
+			return writeHexDump(w, path)
+		}
+		if err != nil {
+			return err
+		}
+		if dbInfo.Error != "" {
+			return errors.New(dbInfo.Error)
+		}
+		_, err = io.WriteString(w, dbInfo.Schema)
+		return err
+	}
+
+	return writeHexDump(w, path)
+}
+
+// writeHexDump writes a hex dump of the file at path to w, reading it
+// a chunk at a time
+func writeHexDump(w io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	chunk := make([]byte, BinaryChunkSize)
+	var offset int64
+	for {
+		n, err := io.ReadFull(file, chunk)
+		if n > 0 {
+			var out bytes.Buffer
+			for _, line := range FormatHexDump(chunk[:n], offset) {
+				out.WriteString(line)
+				out.WriteByte('\n')
+			}
+			if _, werr := w.Write(out.Bytes()); werr != nil {
+				return werr
+			}
+			offset += int64(n)
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// isBinaryPlistFile reports whether path is a .plist in binary format
+func isBinaryPlistFile(path string) bool {
+	if !strings.HasSuffix(strings.ToLower(path), ".plist") {
+		return false
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = file.Close() }()
+
+	magic := make([]byte, 6)
+	n, _ := io.ReadFull(file, magic)
+	return n == len(magic) && string(magic) == "bplist"
+}
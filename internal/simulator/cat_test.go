@@ -0,0 +1,101 @@
+package simulator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveContainerPath(t *testing.T) {
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{
+		"xcrun simctl get_app_container UDID com.example.app data": {out: []byte("/containers/ABC\n")},
+	}})
+
+	got, err := ResolveContainerPath("UDID", "com.example.app", "Library/Preferences/a.plist")
+	if err != nil || got != "/containers/ABC/Library/Preferences/a.plist" {
+		t.Errorf("ResolveContainerPath() = %q, %v", got, err)
+	}
+	for _, rel := range []string{"../../etc/passwd", "/etc/passwd"} {
+		if _, err := ResolveContainerPath("UDID", "com.example.app", rel); err == nil {
+			t.Errorf("ResolveContainerPath(%q) should be rejected", rel)
+		}
+	}
+}
+
+func TestCatFile_Text(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte("apiKey=123\nsecond line\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := CatFile(&out, path); err != nil {
+		t.Fatalf("CatFile() error = %v", err)
+	}
+	if out.String() != "apiKey=123\nsecond line\n" {
+		t.Errorf("CatFile() = %q, want the raw content", out.String())
+	}
+}
+
+func TestCatFile_BinaryPlist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prefs.plist")
+	if err := os.WriteFile(path, []byte("bplist00 not really"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{
+		"plutil -convert xml1 -o - " + path: {out: []byte("<plist><key>apiKey</key></plist>\n")},
+	}})
+
+	var out bytes.Buffer
+	if err := CatFile(&out, path); err != nil {
+		t.Fatalf("CatFile() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "<key>apiKey</key>") {
+		t.Errorf("CatFile() = %q, want the XML conversion", out.String())
+	}
+}
+
+func TestCatFile_Database(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.sqlite")
+	createTestDB(t, path, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)")
+
+	var out bytes.Buffer
+	if err := CatFile(&out, path); err != nil {
+		t.Fatalf("CatFile() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "CREATE TABLE users") {
+		t.Errorf("CatFile() = %q, want the schema dump", out.String())
+	}
+}
+
+func TestCatFile_Binary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blob.bin")
+	data := make([]byte, BinaryChunkSize+HexBytesPerLine)
+	data[0], data[1] = 0xde, 0xad
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := CatFile(&out, path); err != nil {
+		t.Fatalf("CatFile() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != len(data)/HexBytesPerLine {
+		t.Fatalf("CatFile() wrote %d lines, want %d", len(lines), len(data)/HexBytesPerLine)
+	}
+	if !strings.HasPrefix(lines[0], "00000000  de ad") {
+		t.Errorf("first line = %q", lines[0])
+	}
+	// Offsets continue across chunks
+	if !strings.HasPrefix(lines[len(lines)-1], "00002000") {
+		t.Errorf("last line = %q, want offset 00002000", lines[len(lines)-1])
+	}
+}
+
+func TestCatFile_Directory(t *testing.T) {
+	if err := CatFile(&bytes.Buffer{}, t.TempDir()); err == nil {
+		t.Error("CatFile() on a directory should fail")
+	}
+}
@@ -0,0 +1,259 @@
+package simulator
+
+import (
+	"bytes"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FindCoreDataModel returns the compiled Core Data model in the app
+// bundle at bundlePath that belongs to the SQLite store at storePath, or
+// "" if there is none. A model named like the store wins, e.g.
+// Model.momd for Model.sqlite; otherwise a bundle with a single model is
+// assumed to use it for every store.
+func FindCoreDataModel(bundlePath, storePath string) string {
+	if bundlePath == "" || !strings.EqualFold(filepath.Ext(storePath), ".sqlite") {
+		return ""
+	}
+	models, _ := filepath.Glob(filepath.Join(bundlePath, "*.momd"))
+	if len(models) == 0 {
+		return ""
+	}
+	storeName := strings.TrimSuffix(filepath.Base(storePath), filepath.Ext(storePath))
+	for _, model := range models {
+		if strings.TrimSuffix(filepath.Base(model), ".momd") == storeName {
+			return model
+		}
+	}
+	if len(models) == 1 {
+		return models[0]
+	}
+	return ""
+}
+
+// CoreDataEntityTables maps the entity table names of the store using
+// the model at momdPath to their entity names, e.g. ZCONTACT to
+// Contact. Entities from every model version in the bundle are
+// included. Subentities share their root entity's table, so only root
+// entities are named.
+func CoreDataEntityTables(momdPath string) (map[string]string, error) {
+	versions, err := filepath.Glob(filepath.Join(momdPath, "*.mom"))
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, os.ErrNotExist
+	}
+	sort.Strings(versions)
+
+	tables := make(map[string]string)
+	var firstErr error
+	for _, version := range versions {
+		entities, err := readModelEntities(version)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		for _, entity := range entities {
+			tables["Z"+strings.ToUpper(entity)] = entity
+		}
+	}
+	if len(tables) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+	return tables, nil
+}
+
+// readModelEntities returns the root entity names in the compiled model
+// at path. A .mom file is an NSKeyedArchiver archive; plutil normalizes
+// it to a binary plist, whose UIDs decode as integer indexes into
+// $objects.
+func readModelEntities(path string) ([]string, error) {
+	output, err := convertPlist(path, "binary1")
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := decodeBinaryPlist(output)
+	if err != nil {
+		return nil, err
+	}
+	archive, _ := decoded.(map[string]any)
+	objects, _ := archive["$objects"].([]any)
+	if objects == nil {
+		return nil, errBadBPlist
+	}
+
+	lookup := func(value any) any {
+		uid, ok := value.(int64)
+		if !ok || uid <= 0 || uid >= int64(len(objects)) {
+			// UID 0 is the archive's $null
+			return nil
+		}
+		return objects[uid]
+	}
+
+	var entities []string
+	for _, object := range objects {
+		entity, ok := object.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, ok := lookup(entity["NSEntityName"]).(string)
+		if !ok || name == "" {
+			continue
+		}
+		if lookup(entity["NSSuperentity"]) != nil {
+			continue
+		}
+		entities = append(entities, name)
+	}
+	return entities, nil
+}
+
+// ApplyCoreDataEntityNames sets CoreDataEntityName on the tables of the
+// Core Data store at storePath, from the model in the app bundle at
+// bundlePath. Stores without a model are left as they are.
+func ApplyCoreDataEntityNames(info *DatabaseInfo, bundlePath, storePath string) {
+	if info == nil || len(info.Tables) == 0 {
+		return
+	}
+	model := FindCoreDataModel(bundlePath, storePath)
+	if model == "" {
+		return
+	}
+	entities, err := CoreDataEntityTables(model)
+	if err != nil {
+		return
+	}
+	for i := range info.Tables {
+		info.Tables[i].CoreDataEntityName = entities[info.Tables[i].Name]
+	}
+}
+
+// readStoreModelHashes returns the entity version hashes Core Data keeps
+// in the Z_METADATA table of its SQLite stores, or nil if db with tables
+// isn't a Core Data store
+func readStoreModelHashes(db *sql.DB, tables []TableInfo) map[string][]byte {
+	found := false
+	for _, table := range tables {
+		found = found || table.Name == "Z_METADATA"
+	}
+	if !found {
+		return nil
+	}
+
+	var blob []byte
+	if err := db.QueryRow("SELECT Z_PLIST FROM Z_METADATA LIMIT 1").Scan(&blob); err != nil {
+		return nil
+	}
+	decoded, err := decodeBinaryPlist(blob)
+	if err != nil {
+		return nil
+	}
+	metadata, _ := decoded.(map[string]any)
+	return entityHashes(metadata["NSStoreModelVersionHashes"])
+}
+
+// entityHashes converts a decoded plist dictionary of entity names to
+// version hash data; other values yield nil
+func entityHashes(value any) map[string][]byte {
+	dict, ok := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+	hashes := make(map[string][]byte, len(dict))
+	for entity, hash := range dict {
+		if data, ok := hash.([]byte); ok {
+			hashes[entity] = data
+		}
+	}
+	return hashes
+}
+
+// sameEntityHashes reports whether a and b hash the same entities alike
+func sameEntityHashes(a, b map[string][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for entity, hash := range a {
+		other, ok := b[entity]
+		if !ok || !bytes.Equal(hash, other) {
+			return false
+		}
+	}
+	return true
+}
+
+// readModelVersionInfo returns the name of the current version of the
+// model at momdPath and the entity version hashes of each of its
+// versions, from the VersionInfo.plist Xcode compiles into the .momd
+func readModelVersionInfo(momdPath string) (string, map[string]map[string][]byte, error) {
+	output, err := convertPlist(filepath.Join(momdPath, "VersionInfo.plist"), "binary1")
+	if err != nil {
+		return "", nil, err
+	}
+	decoded, err := decodeBinaryPlist(output)
+	if err != nil {
+		return "", nil, err
+	}
+	info, _ := decoded.(map[string]any)
+	current, _ := info["NSManagedObjectModel_CurrentVersionName"].(string)
+	versionHashes, _ := info["NSManagedObjectModel_VersionHashes"].(map[string]any)
+	if current == "" || versionHashes == nil {
+		return "", nil, errBadBPlist
+	}
+
+	versions := make(map[string]map[string][]byte, len(versionHashes))
+	for name, hashes := range versionHashes {
+		versions[name] = entityHashes(hashes)
+	}
+	return current, versions, nil
+}
+
+// modelVersionNumber returns the number of the model version named as
+// Xcode names new versions, e.g. 3 for "Model 3"; the first version has
+// no number and is 1
+func modelVersionNumber(name string) int {
+	if i := strings.LastIndex(name, " "); i >= 0 {
+		if n, err := strconv.Atoi(name[i+1:]); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 1
+}
+
+// CheckCoreDataMigration sets CoreDataVersion and
+// CoreDataMigrationPending on the Core Data store at storePath, by
+// comparing the entity hashes in the store's metadata with those of the
+// versions of the model in the app bundle at bundlePath. Core Data
+// doesn't use SQLite's user_version, so the hashes are all there is to
+// go by. Stores without metadata or a model are left as they are.
+func CheckCoreDataMigration(info *DatabaseInfo, bundlePath, storePath string) {
+	if info == nil || info.coreDataHashes == nil {
+		return
+	}
+	model := FindCoreDataModel(bundlePath, storePath)
+	if model == "" {
+		return
+	}
+	current, versions, err := readModelVersionInfo(model)
+	if err != nil {
+		return
+	}
+
+	for name, hashes := range versions {
+		if sameEntityHashes(hashes, info.coreDataHashes) {
+			info.CoreDataVersion = modelVersionNumber(name)
+			break
+		}
+	}
+	if hashes, ok := versions[current]; ok {
+		info.CoreDataMigrationPending = !sameEntityHashes(hashes, info.coreDataHashes)
+	}
+}
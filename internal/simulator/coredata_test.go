@@ -0,0 +1,194 @@
+package simulator
+
+import (
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// keyedModelBPlist is an NSKeyedArchiver archive shaped like a compiled
+// .mom, written with Python's plistlib: root entities Contact and Tag,
+// and Customer, a subentity of Contact
+const keyedModelBPlist = "62706c6973743030d401020304050615185924617263686976657258246f626a656374735424746f70582476657273696f6e5f100f4e534b657965644172636869766572a707080d0e11121455246e756c6cd2090a0b0c5c4e53456e746974794e616d655d4e535375706572656e746974798002800057436f6e74616374d2090a0f108004800158437573746f6d6572d10913800653546167d1161754726f6f74800112000186a008111b242932444c5257647274767e838587909395999ca1a300000000000001010000000000000019000000000000000000000000000000a8"
+
+// versionInfoBPlist is a compiled model's VersionInfo.plist, written
+// with Python's plistlib: versions "Model", hashing Contact as 01, and
+// the current "Model 2", hashing Contact as 02 and Tag as 03
+const versionInfoBPlist = "62706c6973743030d2010203045f10274e534d616e616765644f626a6563744d6f64656c5f43757272656e7456657273696f6e4e616d655f10224e534d616e616765644f626a6563744d6f64656c5f56657273696f6e486173686573574d6f64656c2032d205030609554d6f64656cd1070857436f6e746163744101d2070a0b0c5354616741024103080d375c64696f727a7c8185870000000000000101000000000000000d00000000000000000000000000000089"
+
+// Store metadata plists recording the hashes of each model version
+const (
+	storeMetadataV1BPlist = "62706c6973743030d2010203065f10194e5353746f72654d6f64656c56657273696f6e4861736865735b4e5353746f726554797065d1040557436f6e7461637441015653514c697465080d29353840420000000000000101000000000000000700000000000000000000000000000049"
+	storeMetadataV2BPlist = "62706c6973743030d101025f10194e5353746f72654d6f64656c56657273696f6e486173686573d20304050657436f6e746163745354616741024103080b272c34383a000000000000010100000000000000070000000000000000000000000000003c"
+)
+
+// createModelBundle creates an app bundle holding a .momd for each name
+// in models, each with one model version
+func createModelBundle(t *testing.T, models ...string) string {
+	t.Helper()
+	bundle := filepath.Join(t.TempDir(), "Example.app")
+	for _, model := range models {
+		momd := filepath.Join(bundle, model+".momd")
+		if err := os.MkdirAll(momd, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(momd, model+".mom"), []byte("mom"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return bundle
+}
+
+func TestFindCoreDataModel(t *testing.T) {
+	single := createModelBundle(t, "Model")
+	several := createModelBundle(t, "Model", "Cache")
+
+	tests := []struct {
+		name   string
+		bundle string
+		store  string
+		want   string
+	}{
+		{"matching name", several, "/data/Library/Cache.sqlite", filepath.Join(several, "Cache.momd")},
+		{"only model", single, "/data/Library/Store.sqlite", filepath.Join(single, "Model.momd")},
+		{"ambiguous", several, "/data/Library/Store.sqlite", ""},
+		{"not a .sqlite store", single, "/data/Library/Model.db", ""},
+		{"no bundle", "", "/data/Library/Model.sqlite", ""},
+		{"no models", t.TempDir(), "/data/Library/Model.sqlite", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindCoreDataModel(tt.bundle, tt.store); got != tt.want {
+				t.Errorf("FindCoreDataModel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyCoreDataEntityNames(t *testing.T) {
+	bundle := createModelBundle(t, "Model")
+	mom := filepath.Join(bundle, "Model.momd", "Model.mom")
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{
+		"plutil -convert binary1 -o - " + mom: {out: mustDecodeHex(t, keyedModelBPlist)},
+	}})
+
+	info := &DatabaseInfo{Tables: []TableInfo{
+		{Name: "ZCONTACT"}, {Name: "ZCUSTOMER"}, {Name: "ZTAG"}, {Name: "Z_PRIMARYKEY"},
+	}}
+	ApplyCoreDataEntityNames(info, bundle, "/data/Library/Model.sqlite")
+
+	want := []string{"Contact (ZCONTACT)", "ZCUSTOMER", "Tag (ZTAG)", "Z_PRIMARYKEY"}
+	for i, table := range info.Tables {
+		if got := table.DisplayName(); got != want[i] {
+			t.Errorf("Tables[%d].DisplayName() = %q, want %q", i, got, want[i])
+		}
+	}
+}
+
+func TestCoreDataEntityTables_Errors(t *testing.T) {
+	if _, err := CoreDataEntityTables(t.TempDir()); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("CoreDataEntityTables(empty) error = %v, want os.ErrNotExist", err)
+	}
+
+	bundle := createModelBundle(t, "Model")
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{}})
+	if _, err := CoreDataEntityTables(filepath.Join(bundle, "Model.momd")); err == nil {
+		t.Error("CoreDataEntityTables() should fail when no version can be read")
+	}
+}
+
+func TestCheckCoreDataMigration(t *testing.T) {
+	bundle := createModelBundle(t, "Model")
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{
+		"plutil -convert binary1 -o - " + filepath.Join(bundle, "Model.momd", "VersionInfo.plist"): {out: mustDecodeHex(t, versionInfoBPlist)},
+	}})
+
+	tests := []struct {
+		name        string
+		metadata    string
+		wantVersion int
+		wantPending bool
+	}{
+		{"older version", storeMetadataV1BPlist, 1, true},
+		{"current version", storeMetadataV2BPlist, 2, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := filepath.Join(t.TempDir(), "Model.sqlite")
+			createTestDB(t, store,
+				"CREATE TABLE Z_METADATA (Z_VERSION INTEGER PRIMARY KEY, Z_UUID VARCHAR(255), Z_PLIST BLOB)",
+				"INSERT INTO Z_METADATA VALUES (1, 'uuid', X'"+tt.metadata+"')",
+			)
+			info, err := readDatabaseInfo(store)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			CheckCoreDataMigration(info, bundle, store)
+			if info.CoreDataVersion != tt.wantVersion || info.CoreDataMigrationPending != tt.wantPending {
+				t.Errorf("CoreDataVersion = %d, CoreDataMigrationPending = %v; want %d, %v",
+					info.CoreDataVersion, info.CoreDataMigrationPending, tt.wantVersion, tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestCheckCoreDataMigration_NotCoreData(t *testing.T) {
+	store := filepath.Join(t.TempDir(), "Model.sqlite")
+	createTestDB(t, store, "CREATE TABLE notes (id INTEGER)")
+	info, err := readDatabaseInfo(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	CheckCoreDataMigration(info, createModelBundle(t, "Model"), store)
+	if info.CoreDataVersion != 0 || info.CoreDataMigrationPending {
+		t.Errorf("CoreDataVersion = %d, CoreDataMigrationPending = %v; want a plain SQLite database left alone",
+			info.CoreDataVersion, info.CoreDataMigrationPending)
+	}
+}
+
+func TestCheckCoreDataMigration_MalformedMetadata(t *testing.T) {
+	bundle := createModelBundle(t, "Model")
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{
+		"plutil -convert binary1 -o - " + filepath.Join(bundle, "Model.momd", "VersionInfo.plist"): {out: mustDecodeHex(t, versionInfoBPlist)},
+	}})
+
+	for name, metadata := range map[string][]byte{
+		"not a plist":              []byte("not a plist"),
+		"overflowing offset table": overflowingTableBPlist(),
+		"shared subtrees":          sharedSubtreesBPlist(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			store := filepath.Join(t.TempDir(), "Model.sqlite")
+			createTestDB(t, store,
+				"CREATE TABLE ZNOTE (Z_PK INTEGER PRIMARY KEY, ZTITLE VARCHAR)",
+				"CREATE TABLE Z_METADATA (Z_VERSION INTEGER PRIMARY KEY, Z_UUID VARCHAR(255), Z_PLIST BLOB)",
+				"INSERT INTO Z_METADATA VALUES (1, 'uuid', X'"+hex.EncodeToString(metadata)+"')",
+			)
+			info, err := readDatabaseInfo(store)
+			if err != nil {
+				t.Fatalf("readDatabaseInfo() error = %v, want the plain table list", err)
+			}
+			if info.TableCount != 2 {
+				t.Errorf("TableCount = %d, want 2", info.TableCount)
+			}
+
+			CheckCoreDataMigration(info, bundle, store)
+			if info.CoreDataVersion != 0 || info.CoreDataMigrationPending {
+				t.Errorf("CoreDataVersion = %d, CoreDataMigrationPending = %v; want the store treated as plain SQLite",
+					info.CoreDataVersion, info.CoreDataMigrationPending)
+			}
+		})
+	}
+}
+
+func TestModelVersionNumber(t *testing.T) {
+	for name, want := range map[string]int{"Model": 1, "Model 2": 2, "My Model": 1, "My Model 12": 12} {
+		if got := modelVersionNumber(name); got != want {
+			t.Errorf("modelVersionNumber(%q) = %d, want %d", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package simulator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// crashLogHeaderSize is how much of a crash report is read to find the
+// bundle identifier. Both formats name the app near the top: .ips files
+// in their one-line JSON header, .crash files in the "Identifier:" line.
+const crashLogHeaderSize = 4096
+
+// CrashLog is a crash report written by the host for a simulator app
+type CrashLog struct {
+	Name    string
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
+
+// FileInfo returns the crash log as a FileInfo so it can be opened in
+// the file viewer.
+func (c CrashLog) FileInfo() FileInfo {
+	return FileInfo{
+		Name:       c.Name,
+		Path:       c.Path,
+		Size:       c.Size,
+		CreatedAt:  c.ModTime,
+		ModifiedAt: c.ModTime,
+	}
+}
+
+// crashLogDirs returns the directories macOS writes crash reports to.
+// Simulator app crashes are reported by the host, so they land in the
+// user's own DiagnosticReports rather than inside the device.
+func crashLogDirs() []string {
+	homeDir := os.Getenv("HOME")
+	return []string{
+		filepath.Join(homeDir, "Library", "Logs", "DiagnosticReports"),
+		filepath.Join(homeDir, "Library", "Logs", "CrashReporter", "MobileDevice"),
+	}
+}
+
+// GetCrashLogs returns the crash reports for bundleID, most recent first.
+// Missing or unreadable report directories are skipped.
+func GetCrashLogs(bundleID string) []CrashLog {
+	return scanCrashLogs(map[string]bool{bundleID: true})[bundleID]
+}
+
+// CountCrashLogs returns the number of crash reports for each of the
+// given bundle IDs, scanning the report directories once.
+func CountCrashLogs(bundleIDs []string) map[string]int {
+	wanted := make(map[string]bool, len(bundleIDs))
+	for _, id := range bundleIDs {
+		if id != "" && id != "Unknown" {
+			wanted[id] = true
+		}
+	}
+	counts := make(map[string]int)
+	if len(wanted) == 0 {
+		return counts
+	}
+	for id, logs := range scanCrashLogs(wanted) {
+		counts[id] = len(logs)
+	}
+	return counts
+}
+
+// scanCrashLogs walks the crash report directories and groups every
+// .ips and .crash file by the wanted bundle ID it mentions.
+func scanCrashLogs(wanted map[string]bool) map[string][]CrashLog {
+	found := make(map[string][]CrashLog)
+	for _, dir := range crashLogDirs() {
+		_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				// Unreadable or missing directory; skip it
+				if d != nil && d.IsDir() && path != dir {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() {
+				return nil
+			}
+			ext := strings.ToLower(filepath.Ext(path))
+			if ext != ".ips" && ext != ".crash" {
+				return nil
+			}
+			id := crashLogBundleID(path, wanted)
+			if id == "" {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			found[id] = append(found[id], CrashLog{
+				Name:    d.Name(),
+				Path:    path,
+				Size:    info.Size(),
+				ModTime: info.ModTime(),
+			})
+			return nil
+		})
+	}
+
+	for _, logs := range found {
+		sort.Slice(logs, func(i, j int) bool {
+			return logs[i].ModTime.After(logs[j].ModTime)
+		})
+	}
+	return found
+}
+
+// crashLogBundleID returns the wanted bundle ID named in the header of
+// the crash report at path, or "" if it names none of them.
+func crashLogBundleID(path string, wanted map[string]bool) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = f.Close() }()
+
+	buf := make([]byte, crashLogHeaderSize)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return ""
+	}
+	header := string(buf[:n])
+
+	for _, line := range strings.Split(header, "\n") {
+		// .crash: "Identifier:          com.example.app"
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "Identifier:"); ok {
+			if id := strings.TrimSpace(rest); wanted[id] {
+				return id
+			}
+		}
+	}
+	for id := range wanted {
+		// .ips: {"app_name":"Example","bundleID":"com.example.app",...}
+		if strings.Contains(header, `"bundleID":"`+id+`"`) ||
+			strings.Contains(header, `"bundleID" : "`+id+`"`) {
+			return id
+		}
+	}
+	return ""
+}
+
+// crashReportMaxFrames caps how many frames of the crashed thread a
+// CrashReport keeps.
+const crashReportMaxFrames = 5
+
+// CrashReport summarizes an .ips crash report
+type CrashReport struct {
+	AppName       string
+	BundleID      string
+	ExceptionType string   // e.g. "EXC_BAD_ACCESS (SIGSEGV)"
+	BuildUUID     string   // UUID of the crashed app's main binary
+	StackTrace    []string // Frames of the crashed thread, innermost first
+}
+
+// ipsHeader is the one-line JSON header of an .ips file
+type ipsHeader struct {
+	AppName   string `json:"app_name"`
+	BundleID  string `json:"bundleID"`
+	SliceUUID string `json:"slice_uuid"`
+}
+
+// ipsBody is the part of an .ips report body used by CrashReport
+type ipsBody struct {
+	Exception struct {
+		Type   string `json:"type"`
+		Signal string `json:"signal"`
+	} `json:"exception"`
+	FaultingThread int `json:"faultingThread"`
+	Threads        []struct {
+		Triggered bool `json:"triggered"`
+		Frames    []struct {
+			ImageIndex     int    `json:"imageIndex"`
+			ImageOffset    uint64 `json:"imageOffset"`
+			Symbol         string `json:"symbol"`
+			SymbolLocation uint64 `json:"symbolLocation"`
+		} `json:"frames"`
+	} `json:"threads"`
+	UsedImages []struct {
+		UUID string `json:"uuid"`
+		Name string `json:"name"`
+	} `json:"usedImages"`
+}
+
+// readIpsCrashReport parses the .ips crash report at path, returning nil
+// if it can't be read or isn't a JSON crash report.
+func readIpsCrashReport(path string) *CrashReport {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	report, err := parseIpsCrashReport(data)
+	if err != nil {
+		return nil
+	}
+	return report
+}
+
+// parseIpsCrashReport extracts the exception, the crashed thread's stack
+// trace and the build UUID from an .ips crash report: a one-line JSON
+// header followed by a JSON body.
+func parseIpsCrashReport(data []byte) (*CrashReport, error) {
+	headerLine, body, found := bytes.Cut(data, []byte("\n"))
+	if !found {
+		return nil, errors.New("crash report has no body")
+	}
+
+	var header ipsHeader
+	if err := json.Unmarshal(headerLine, &header); err != nil {
+		return nil, fmt.Errorf("parsing crash report header: %w", err)
+	}
+	var report ipsBody
+	if err := json.Unmarshal(body, &report); err != nil {
+		return nil, fmt.Errorf("parsing crash report: %w", err)
+	}
+
+	summary := &CrashReport{
+		AppName:       header.AppName,
+		BundleID:      header.BundleID,
+		ExceptionType: report.Exception.Type,
+		BuildUUID:     header.SliceUUID,
+	}
+	if report.Exception.Signal != "" {
+		summary.ExceptionType = fmt.Sprintf("%s (%s)", report.Exception.Type, report.Exception.Signal)
+	}
+	if summary.BuildUUID == "" && len(report.UsedImages) > 0 {
+		// The app's own binary is the first image
+		summary.BuildUUID = report.UsedImages[0].UUID
+	}
+
+	crashed := report.FaultingThread
+	for i, thread := range report.Threads {
+		if thread.Triggered {
+			crashed = i
+			break
+		}
+	}
+	if crashed >= 0 && crashed < len(report.Threads) {
+		for i, frame := range report.Threads[crashed].Frames {
+			if i == crashReportMaxFrames {
+				break
+			}
+			image := "???"
+			if frame.ImageIndex >= 0 && frame.ImageIndex < len(report.UsedImages) && report.UsedImages[frame.ImageIndex].Name != "" {
+				image = report.UsedImages[frame.ImageIndex].Name
+			}
+			location := fmt.Sprintf("0x%x", frame.ImageOffset)
+			if frame.Symbol != "" {
+				location = fmt.Sprintf("%s + %d", frame.Symbol, frame.SymbolLocation)
+			}
+			summary.StackTrace = append(summary.StackTrace, fmt.Sprintf("%-2d %s  %s", i, image, location))
+		}
+	}
+	return summary, nil
+}
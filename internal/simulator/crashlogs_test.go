@@ -0,0 +1,184 @@
+package simulator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCrashLog writes a crash report under home's DiagnosticReports
+// with the given modification time.
+func writeCrashLog(t *testing.T, home, name, body string, mod time.Time) string {
+	t.Helper()
+	dir := filepath.Join(home, "Library", "Logs", "DiagnosticReports")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, mod, mod); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestGetCrashLogs(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	now := time.Now()
+	older := writeCrashLog(t, home, "Example-2024-01-01-100000.ips",
+		`{"app_name":"Example","bundleID":"com.example.app","os_version":"iPhone OS 17.0"}`+"\n{}",
+		now.Add(-time.Hour))
+	newer := writeCrashLog(t, home, "Example_2024-01-02-100000_host.crash",
+		"Process:               Example [123]\nIdentifier:            com.example.app\n",
+		now)
+	writeCrashLog(t, home, "Other-2024-01-01-100000.ips",
+		`{"app_name":"Other","bundleID":"com.example.other"}`, now)
+	writeCrashLog(t, home, "notes.txt", "com.example.app", now)
+
+	logs := GetCrashLogs("com.example.app")
+	if len(logs) != 2 {
+		t.Fatalf("got %d crash logs, want 2: %+v", len(logs), logs)
+	}
+	if logs[0].Path != newer || logs[1].Path != older {
+		t.Errorf("crash logs not most-recent first: %s, %s", logs[0].Name, logs[1].Name)
+	}
+}
+
+func TestGetCrashLogs_NoReportDirectories(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if logs := GetCrashLogs("com.example.app"); len(logs) != 0 {
+		t.Errorf("got %d crash logs, want none", len(logs))
+	}
+}
+
+func TestCountCrashLogs(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	now := time.Now()
+	writeCrashLog(t, home, "A-1.ips", `{"bundleID":"com.example.a"}`, now)
+	writeCrashLog(t, home, "A-2.ips", `{"bundleID":"com.example.a"}`, now)
+	writeCrashLog(t, home, "B-1.crash", "Identifier: com.example.b\n", now)
+
+	counts := CountCrashLogs([]string{"com.example.a", "com.example.b", "com.example.c", "Unknown"})
+	if counts["com.example.a"] != 2 || counts["com.example.b"] != 1 || counts["com.example.c"] != 0 {
+		t.Errorf("counts = %v", counts)
+	}
+}
+
+func TestIpsUsesJSONHighlighting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.ips")
+	if err := os.WriteFile(path, []byte(`{"bundleID":"com.example.app"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if DetectFileType(path) != FileTypeText {
+		t.Error(".ips should be viewed as text")
+	}
+	if lexer := getLexerForExtension(".ips"); lexer == nil || lexer.Config().Name != "JSON" {
+		t.Errorf(".ips lexer = %v, want JSON", lexer)
+	}
+}
+
+// sampleIpsReport is a trimmed .ips crash report: a one-line header and
+// a JSON body whose second thread crashed.
+const sampleIpsReport = `{"app_name":"Example","bug_type":"309","bundleID":"com.example.app","slice_uuid":"1A2B3C4D-0000-1111-2222-333344445555"}
+{
+  "exception" : {"type" : "EXC_BAD_ACCESS", "signal" : "SIGSEGV"},
+  "faultingThread" : 1,
+  "threads" : [
+    {"frames" : [{"imageIndex" : 1, "imageOffset" : 4096, "symbol" : "mach_msg_trap", "symbolLocation" : 8}]},
+    {"triggered" : true, "frames" : [
+      {"imageIndex" : 0, "imageOffset" : 16400, "symbol" : "ViewController.crash()", "symbolLocation" : 52},
+      {"imageIndex" : 0, "imageOffset" : 16528},
+      {"imageIndex" : 7, "imageOffset" : 255}
+    ]}
+  ],
+  "usedImages" : [
+    {"uuid" : "9f8e7d6c-0000-1111-2222-333344445555", "name" : "Example"},
+    {"uuid" : "aaaaaaaa-0000-1111-2222-333344445555", "name" : "libsystem_kernel.dylib"}
+  ]
+}`
+
+func TestParseIpsCrashReport(t *testing.T) {
+	report, err := parseIpsCrashReport([]byte(sampleIpsReport))
+	if err != nil {
+		t.Fatalf("parseIpsCrashReport() error = %v", err)
+	}
+	if report.AppName != "Example" || report.BundleID != "com.example.app" {
+		t.Errorf("app = %q %q", report.AppName, report.BundleID)
+	}
+	if report.ExceptionType != "EXC_BAD_ACCESS (SIGSEGV)" {
+		t.Errorf("ExceptionType = %q", report.ExceptionType)
+	}
+	if report.BuildUUID != "1A2B3C4D-0000-1111-2222-333344445555" {
+		t.Errorf("BuildUUID = %q, want the header's slice_uuid", report.BuildUUID)
+	}
+	want := []string{
+		"0  Example  ViewController.crash() + 52",
+		"1  Example  0x4090",
+		"2  ???  0xff",
+	}
+	if len(report.StackTrace) != len(want) {
+		t.Fatalf("StackTrace = %q, want %q", report.StackTrace, want)
+	}
+	for i := range want {
+		if report.StackTrace[i] != want[i] {
+			t.Errorf("StackTrace[%d] = %q, want %q", i, report.StackTrace[i], want[i])
+		}
+	}
+}
+
+func TestParseIpsCrashReport_BuildUUIDFromImages(t *testing.T) {
+	data := `{"app_name":"Example"}
+{"exception":{"type":"EXC_CRASH"},"usedImages":[{"uuid":"from-images","name":"Example"}]}`
+	report, err := parseIpsCrashReport([]byte(data))
+	if err != nil {
+		t.Fatalf("parseIpsCrashReport() error = %v", err)
+	}
+	if report.BuildUUID != "from-images" || report.ExceptionType != "EXC_CRASH" {
+		t.Errorf("report = %+v", report)
+	}
+}
+
+func TestParseIpsCrashReport_Invalid(t *testing.T) {
+	for name, data := range map[string]string{
+		"no body":      `{"app_name":"Example"}`,
+		"bad header":   "Process: Example\n{}",
+		"plain .crash": "Incident Identifier: 1234\nCrashReporter Key: abc\n",
+	} {
+		if _, err := parseIpsCrashReport([]byte(data)); err == nil {
+			t.Errorf("%s: parseIpsCrashReport() error = nil, want error", name)
+		}
+	}
+}
+
+func TestReadFileContent_IpsCrashReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Example-2024-01-01-120000.ips")
+	if err := os.WriteFile(path, []byte(sampleIpsReport), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	content, err := ReadFileContent(path, 0, 100, 80)
+	if err != nil {
+		t.Fatalf("ReadFileContent() error = %v", err)
+	}
+	if content.Type != FileTypeText || content.CrashReport == nil {
+		t.Fatalf("content type %v, CrashReport %+v; want text with a crash report", content.Type, content.CrashReport)
+	}
+	if content.CrashReport.ExceptionType != "EXC_BAD_ACCESS (SIGSEGV)" {
+		t.Errorf("ExceptionType = %q", content.CrashReport.ExceptionType)
+	}
+}
+
+func TestDetectContentLanguage_IpsReport(t *testing.T) {
+	// Symbol names in the body must not be mistaken for markup
+	content := `{"app_name":"Example","bug_type":"309"}
+{"threads":[{"frames":[{"symbol":"std::vector<int>::push_back"}]}]}`
+	if got := detectContentLanguage(content); got != "json" {
+		t.Errorf("detectContentLanguage() = %q, want json", got)
+	}
+}
@@ -0,0 +1,108 @@
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DeviceType is a simulator hardware model as listed by simctl list
+// devicetypes, such as iPhone 15
+type DeviceType struct {
+	Identifier    string `json:"identifier"`
+	Name          string `json:"name"`
+	ProductFamily string `json:"productFamily"`
+
+	// Oldest and newest runtime the model runs, encoded like
+	// runtimeVersionNumber
+	MinRuntimeVersion uint32 `json:"minRuntimeVersion"`
+	MaxRuntimeVersion uint32 `json:"maxRuntimeVersion"`
+}
+
+// DeviceTypesOutput represents the JSON output from simctl list devicetypes
+type DeviceTypesOutput struct {
+	DeviceTypes []DeviceType `json:"devicetypes"`
+}
+
+// Supports reports whether a simulator of this type can run runtime.
+// Types that don't list a range support every runtime.
+func (d DeviceType) Supports(runtime Runtime) bool {
+	if d.MaxRuntimeVersion == 0 {
+		return true
+	}
+	version := runtimeVersionNumber(runtime.Version)
+	return version >= d.MinRuntimeVersion && version <= d.MaxRuntimeVersion
+}
+
+// runtimeVersionNumber encodes a runtime version the way simctl encodes
+// a device type's runtime range: major<<16 | minor<<8 | patch, so "17.0"
+// is 0x110000
+func runtimeVersionNumber(version string) uint32 {
+	var number uint32
+	parts := strings.SplitN(version, ".", 3)
+	for i := range 3 {
+		var part uint64
+		if i < len(parts) {
+			part, _ = strconv.ParseUint(parts[i], 10, 8)
+		}
+		number = number<<8 | uint32(part)
+	}
+	return number
+}
+
+// ListIOSRuntimes returns the installed iOS runtimes that simulators
+// can be created on, newest first
+func ListIOSRuntimes() ([]Runtime, error) {
+	output, err := defaultExecutor.Execute("xcrun", "simctl", "list", "runtimes", "--json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runtimes: %w", err)
+	}
+	var runtimesOutput RuntimesOutput
+	if err := json.Unmarshal(output, &runtimesOutput); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	var runtimes []Runtime
+	for _, runtime := range runtimesOutput.Runtimes {
+		if runtime.Platform == "iOS" && runtime.IsAvailable {
+			runtimes = append(runtimes, runtime)
+		}
+	}
+	sort.SliceStable(runtimes, func(i, j int) bool {
+		return runtimeVersionNumber(runtimes[i].Version) > runtimeVersionNumber(runtimes[j].Version)
+	})
+	return runtimes, nil
+}
+
+// ListDeviceTypes returns the iPhone and iPad device types, in the order
+// simctl lists them
+func ListDeviceTypes() ([]DeviceType, error) {
+	output, err := defaultExecutor.Execute("xcrun", "simctl", "list", "devicetypes", "--json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list device types: %w", err)
+	}
+	var deviceTypesOutput DeviceTypesOutput
+	if err := json.Unmarshal(output, &deviceTypesOutput); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	var deviceTypes []DeviceType
+	for _, deviceType := range deviceTypesOutput.DeviceTypes {
+		if deviceType.ProductFamily == "iPhone" || deviceType.ProductFamily == "iPad" {
+			deviceTypes = append(deviceTypes, deviceType)
+		}
+	}
+	return deviceTypes, nil
+}
+
+// CreateSimulator creates a simulator named name of deviceType on
+// runtime, both given by identifier, and returns its UDID
+func CreateSimulator(name, deviceType, runtime string) (string, error) {
+	output, err := defaultExecutor.Execute("xcrun", "simctl", "create", name, deviceType, runtime)
+	if err != nil {
+		return "", fmt.Errorf("failed to create simulator: %w (output: %s)", err, strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,89 @@
+package simulator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestListIOSRuntimes(t *testing.T) {
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{
+		"xcrun simctl list runtimes --json": {out: []byte(`{"runtimes": [
+			{"identifier": "com.apple.CoreSimulator.SimRuntime.iOS-16-4", "name": "iOS 16.4", "version": "16.4", "platform": "iOS", "isAvailable": true},
+			{"identifier": "com.apple.CoreSimulator.SimRuntime.watchOS-10-0", "name": "watchOS 10.0", "version": "10.0", "platform": "watchOS", "isAvailable": true},
+			{"identifier": "com.apple.CoreSimulator.SimRuntime.iOS-17-0", "name": "iOS 17.0", "version": "17.0", "platform": "iOS", "isAvailable": true},
+			{"identifier": "com.apple.CoreSimulator.SimRuntime.iOS-15-0", "name": "iOS 15.0", "version": "15.0", "platform": "iOS", "isAvailable": false}
+		]}`)},
+	}})
+
+	runtimes, err := ListIOSRuntimes()
+	if err != nil {
+		t.Fatalf("ListIOSRuntimes: %v", err)
+	}
+	var names []string
+	for _, runtime := range runtimes {
+		names = append(names, runtime.Name)
+	}
+	if got := strings.Join(names, ", "); got != "iOS 17.0, iOS 16.4" {
+		t.Errorf("runtimes = %s, want the available iOS runtimes newest first", got)
+	}
+}
+
+func TestListDeviceTypes(t *testing.T) {
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{
+		"xcrun simctl list devicetypes --json": {out: []byte(`{"devicetypes": [
+			{"identifier": "com.apple.CoreSimulator.SimDeviceType.iPhone-15", "name": "iPhone 15", "productFamily": "iPhone", "minRuntimeVersion": 1114112, "maxRuntimeVersion": 4294967295},
+			{"identifier": "com.apple.CoreSimulator.SimDeviceType.Apple-Watch-Series-9-45mm", "name": "Apple Watch Series 9 (45mm)", "productFamily": "Apple Watch"},
+			{"identifier": "com.apple.CoreSimulator.SimDeviceType.iPad-Pro-11-inch-4th-generation-8GB", "name": "iPad Pro (11-inch) (4th generation)", "productFamily": "iPad"}
+		]}`)},
+	}})
+
+	deviceTypes, err := ListDeviceTypes()
+	if err != nil {
+		t.Fatalf("ListDeviceTypes: %v", err)
+	}
+	if len(deviceTypes) != 2 || deviceTypes[0].Name != "iPhone 15" || deviceTypes[1].ProductFamily != "iPad" {
+		t.Errorf("device types = %+v, want the iPhone and the iPad", deviceTypes)
+	}
+	if deviceTypes[0].MinRuntimeVersion != 0x110000 {
+		t.Errorf("MinRuntimeVersion = %#x, want 0x110000", deviceTypes[0].MinRuntimeVersion)
+	}
+}
+
+func TestDeviceTypeSupports(t *testing.T) {
+	iPhone15 := DeviceType{MinRuntimeVersion: 0x110000, MaxRuntimeVersion: 0xffffffff}
+	iPhone8 := DeviceType{MinRuntimeVersion: 0x0b0000, MaxRuntimeVersion: 0x10ffff}
+
+	tests := []struct {
+		deviceType DeviceType
+		version    string
+		want       bool
+	}{
+		{iPhone15, "17.0", true},
+		{iPhone15, "16.4", false},
+		{iPhone8, "16.4", true},
+		{iPhone8, "17.0.1", false},
+		{DeviceType{}, "17.0", true}, // no range listed
+	}
+	for _, tt := range tests {
+		if got := tt.deviceType.Supports(Runtime{Version: tt.version}); got != tt.want {
+			t.Errorf("%+v.Supports(%s) = %v, want %v", tt.deviceType, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestCreateSimulator(t *testing.T) {
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{
+		"xcrun simctl create Test Phone com.apple.CoreSimulator.SimDeviceType.iPhone-15 com.apple.CoreSimulator.SimRuntime.iOS-17-0": {out: []byte("NEW-UDID\n")},
+		"xcrun simctl create Test Phone bad-type com.apple.CoreSimulator.SimRuntime.iOS-17-0":                                        {out: []byte("Invalid device type: bad-type\n"), err: errors.New("exit status 162")},
+	}})
+
+	udid, err := CreateSimulator("Test Phone", "com.apple.CoreSimulator.SimDeviceType.iPhone-15", "com.apple.CoreSimulator.SimRuntime.iOS-17-0")
+	if err != nil || udid != "NEW-UDID" {
+		t.Errorf("CreateSimulator() = %q, %v; want NEW-UDID", udid, err)
+	}
+	_, err = CreateSimulator("Test Phone", "bad-type", "com.apple.CoreSimulator.SimRuntime.iOS-17-0")
+	if err == nil || !strings.Contains(err.Error(), "Invalid device type") {
+		t.Errorf("CreateSimulator() error = %v, want simctl output", err)
+	}
+}
@@ -0,0 +1,18 @@
+package simulator
+
+import "os"
+
+// DeleteFile removes the file at path, or the directory at path with
+// everything in it
+func DeleteFile(path string) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return withHint(err)
+	}
+	if info.IsDir() {
+		err = os.RemoveAll(path)
+	} else {
+		err = os.Remove(path)
+	}
+	return withHint(err)
+}
@@ -0,0 +1,54 @@
+package simulator
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDeleteFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "notes.txt")
+	folder := filepath.Join(dir, "Caches")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(folder, "images"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{file, folder} {
+		if err := DeleteFile(path); err != nil {
+			t.Fatalf("DeleteFile(%q) error = %v", path, err)
+		}
+		if _, err := os.Lstat(path); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("%s still exists after DeleteFile()", path)
+		}
+	}
+
+	if err := DeleteFile(file); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("DeleteFile(missing) error = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestDeleteFile_PermissionHint(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root can delete anything")
+	}
+	dir := t.TempDir()
+	file := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(dir, 0o555); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chmod(dir, 0o755) })
+
+	err := DeleteFile(file)
+	if !errors.Is(err, fs.ErrPermission) || err.Error() != errHint(err) {
+		t.Errorf("DeleteFile() error = %v, want the permission hint", err)
+	}
+}
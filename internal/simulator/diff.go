@@ -0,0 +1,217 @@
+package simulator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// maxDiffFileSize is the largest file ComputeDiff compares. The diff
+// keeps a trace of its search that grows with the edit distance, so
+// large, very different files would use a lot of memory.
+const maxDiffFileSize = 1024 * 1024 // 1MB
+
+// diffContextRows is how many unchanged hex rows a binary diff keeps on
+// each side of a change. Longer unchanged runs collapse into one line.
+const diffContextRows = 2
+
+// DiffOp is the kind of change a DiffLine records
+type DiffOp int
+
+const (
+	DiffContext DiffOp = iota // Line present in both files
+	DiffAdded                 // Line only in the second file
+	DiffRemoved               // Line only in the first file
+)
+
+// DiffLine is one line of a diff between two files. OldLine and NewLine
+// are 1-based line numbers in the first and second file, 0 where the
+// line doesn't appear.
+type DiffLine struct {
+	Op      DiffOp
+	Text    string
+	OldLine int
+	NewLine int
+}
+
+// ComputeDiff returns the line diff that turns the text file at path1
+// into the one at path2, computed with the Myers algorithm. If either
+// file is binary, it compares their hex dump rows instead (see
+// binaryDiff).
+func ComputeDiff(path1, path2 string) ([]DiffLine, error) {
+	a, err := readDiffFile(path1)
+	if err != nil {
+		return nil, err
+	}
+	b, err := readDiffFile(path2)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.IndexByte(a, 0) >= 0 || bytes.IndexByte(b, 0) >= 0 {
+		return binaryDiff(a, b), nil
+	}
+	return myersDiff(splitDiffLines(a), splitDiffLines(b)), nil
+}
+
+// readDiffFile reads the file at path, refusing directories and files
+// over maxDiffFileSize
+func readDiffFile(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s is a directory", info.Name())
+	}
+	if info.Size() > maxDiffFileSize {
+		return nil, fmt.Errorf("%s is too large to diff (%s)", info.Name(), FormatSize(info.Size()))
+	}
+	return os.ReadFile(path)
+}
+
+// splitDiffLines splits text file content into lines
+func splitDiffLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	text := strings.TrimSuffix(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	return strings.Split(text, "\n")
+}
+
+// binaryDiff compares a and b a hex dump row at a time, at the same
+// offsets, so inserted bytes show as every later row changing. Each run
+// of changed rows lists its old rows, then its new ones. Unchanged rows
+// further than diffContextRows from a change collapse into one line
+// counting their bytes. OldLine and NewLine hold row numbers.
+func binaryDiff(a, b []byte) []DiffLine {
+	rowCount := (max(len(a), len(b)) + HexBytesPerLine - 1) / HexBytesPerLine
+	changed := make([]bool, rowCount)
+	for row := range changed {
+		changed[row] = !bytes.Equal(hexRow(a, row), hexRow(b, row))
+	}
+	nearChange := func(row int) bool {
+		for r := max(row-diffContextRows, 0); r <= min(row+diffContextRows, rowCount-1); r++ {
+			if changed[r] {
+				return true
+			}
+		}
+		return false
+	}
+
+	var lines []DiffLine
+	for row := 0; row < rowCount; {
+		switch {
+		case changed[row]:
+			end := row
+			for end < rowCount && changed[end] {
+				end++
+			}
+			for r := row; r < end; r++ {
+				if data := hexRow(a, r); len(data) > 0 {
+					lines = append(lines, DiffLine{Op: DiffRemoved, Text: formatHexRow(data, r), OldLine: r + 1})
+				}
+			}
+			for r := row; r < end; r++ {
+				if data := hexRow(b, r); len(data) > 0 {
+					lines = append(lines, DiffLine{Op: DiffAdded, Text: formatHexRow(data, r), NewLine: r + 1})
+				}
+			}
+			row = end
+		case nearChange(row):
+			lines = append(lines, DiffLine{Op: DiffContext, Text: formatHexRow(hexRow(a, row), row), OldLine: row + 1, NewLine: row + 1})
+			row++
+		default:
+			size := 0
+			for ; row < rowCount && !changed[row] && !nearChange(row); row++ {
+				size += len(hexRow(a, row))
+			}
+			lines = append(lines, DiffLine{Op: DiffContext, Text: fmt.Sprintf("⋯ %d identical bytes", size)})
+		}
+	}
+	return lines
+}
+
+// hexRow returns the bytes of data shown on the given hex dump row, or
+// none past the end of data
+func hexRow(data []byte, row int) []byte {
+	start := min(row*HexBytesPerLine, len(data))
+	return data[start:min(start+HexBytesPerLine, len(data))]
+}
+
+// formatHexRow formats the bytes of a hex dump row as the hex viewer
+// shows them, at the row's offset
+func formatHexRow(data []byte, row int) string {
+	return FormatHexDump(data, int64(row*HexBytesPerLine))[0]
+}
+
+// myersDiff computes a shortest edit script from a to b with the greedy
+// algorithm from Myers' "An O(ND) Difference Algorithm and Its
+// Variations", then walks it back into diff lines. Deletions are placed
+// before insertions where both are possible.
+func myersDiff(a, b []string) []DiffLine {
+	n, m := len(a), len(b)
+	maxD := n + m
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+	// trace[d] is v as it was before step d, for the backtrack
+	var trace [][]int
+
+search:
+	for d := 0; d <= maxD; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1] // Step down: insertion
+			} else {
+				x = v[offset+k-1] + 1 // Step right: deletion
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	// Walk the trace back from the end, collecting lines in reverse
+	var lines []DiffLine
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			lines = append(lines, DiffLine{Op: DiffContext, Text: a[x], OldLine: x + 1, NewLine: y + 1})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			y--
+			lines = append(lines, DiffLine{Op: DiffAdded, Text: b[y], NewLine: y + 1})
+		} else {
+			x--
+			lines = append(lines, DiffLine{Op: DiffRemoved, Text: a[x], OldLine: x + 1})
+		}
+	}
+
+	for i, j := 0, len(lines)-1; i < j; i, j = i+1, j-1 {
+		lines[i], lines[j] = lines[j], lines[i]
+	}
+	return lines
+}
@@ -0,0 +1,137 @@
+package simulator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// formatDiff renders diff lines as unified-diff-style text for comparison
+func formatDiff(lines []DiffLine) string {
+	var s strings.Builder
+	for _, line := range lines {
+		switch line.Op {
+		case DiffAdded:
+			s.WriteString("+")
+		case DiffRemoved:
+			s.WriteString("-")
+		default:
+			s.WriteString(" ")
+		}
+		s.WriteString(line.Text)
+		s.WriteString("\n")
+	}
+	return s.String()
+}
+
+func TestMyersDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want string
+	}{
+		{"identical", []string{"a", "b"}, []string{"a", "b"}, " a\n b\n"},
+		{"both empty", nil, nil, ""},
+		{"all added", nil, []string{"a", "b"}, "+a\n+b\n"},
+		{"all removed", []string{"a", "b"}, nil, "-a\n-b\n"},
+		{"changed line", []string{"a", "b", "c"}, []string{"a", "x", "c"}, " a\n-b\n+x\n c\n"},
+		{
+			"Myers paper example",
+			strings.Split("ABCABBA", ""), strings.Split("CBABAC", ""),
+			"-A\n-B\n C\n+B\n A\n B\n-B\n A\n+C\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatDiff(myersDiff(tt.a, tt.b)); got != tt.want {
+				t.Errorf("myersDiff() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyersDiff_LineNumbers(t *testing.T) {
+	lines := myersDiff([]string{"a", "b", "c"}, []string{"a", "c", "d"})
+	want := []DiffLine{
+		{Op: DiffContext, Text: "a", OldLine: 1, NewLine: 1},
+		{Op: DiffRemoved, Text: "b", OldLine: 2},
+		{Op: DiffContext, Text: "c", OldLine: 3, NewLine: 2},
+		{Op: DiffAdded, Text: "d", NewLine: 3},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("myersDiff() = %+v, want %+v", lines, want)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %+v, want %+v", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestComputeDiff(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	old := write("old.txt", "one\r\ntwo\r\n")
+	updated := write("new.txt", "one\nthree\n")
+	binary := write("data.bin", "a\x00b")
+
+	lines, err := ComputeDiff(old, updated)
+	if err != nil {
+		t.Fatalf("ComputeDiff() error = %v", err)
+	}
+	if got := formatDiff(lines); got != " one\n-two\n+three\n" {
+		t.Errorf("ComputeDiff() =\n%s", got)
+	}
+
+	// A binary file on either side compares hex dump rows
+	lines, err = ComputeDiff(old, binary)
+	if err != nil {
+		t.Fatalf("ComputeDiff() with a binary file error = %v", err)
+	}
+	if len(lines) != 2 || lines[0].Op != DiffRemoved || !strings.HasPrefix(lines[1].Text, "00000000  61 00 62") {
+		t.Errorf("ComputeDiff() with a binary file =\n%s", formatDiff(lines))
+	}
+	if _, err := ComputeDiff(old, dir); err == nil {
+		t.Error("ComputeDiff() with a directory should fail")
+	}
+}
+
+func TestBinaryDiff(t *testing.T) {
+	a := make([]byte, 10*HexBytesPerLine)
+	b := make([]byte, 10*HexBytesPerLine+4)
+	a[0], b[0] = 0x01, 0x02
+
+	lines := binaryDiff(a, b)
+	var got []string
+	for _, line := range lines {
+		// The marker and the row's offset
+		got = append(got, formatDiff([]DiffLine{line})[:1]+strings.Fields(line.Text)[0])
+	}
+	// Row 0 changed, rows 1-2 are context after it, rows 3-7 collapse,
+	// rows 8-9 are context before the row only b has
+	want := []string{
+		"-00000000", "+00000000",
+		" 00000010", " 00000020",
+		" ⋯",
+		" 00000080", " 00000090",
+		"+000000a0",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("binaryDiff() rows = %v, want %v", got, want)
+	}
+	if collapsed := lines[4].Text; collapsed != "⋯ 80 identical bytes" {
+		t.Errorf("collapsed line = %q, want the 80 bytes of rows 3-7", collapsed)
+	}
+
+	// Identical data collapses to a single line
+	if lines := binaryDiff(a, a); len(lines) != 1 || lines[0].Op != DiffContext {
+		t.Errorf("binaryDiff() of identical data = %v", lines)
+	}
+}
@@ -0,0 +1,134 @@
+package simulator
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DuplicateFile copies the file or directory at path next to it, named
+// with a _copy suffix before the extension, e.g. notes_copy.txt. Later
+// copies are numbered, e.g. notes_copy2.txt. Directories are copied
+// with everything in them. It returns the path of the copy.
+func DuplicateFile(path string) (string, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return "", err
+	}
+	dest, err := duplicateName(path, info.IsDir())
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		err = copyDir(path, dest)
+	} else {
+		err = copyEntry(path, dest, info)
+	}
+	if err != nil {
+		// Don't leave half a copy behind
+		_ = os.RemoveAll(dest)
+		return "", withHint(err)
+	}
+	return dest, nil
+}
+
+// CanDuplicate reports whether the file at path can be duplicated: the
+// copy goes next to it, so its directory must be writable
+func CanDuplicate(path string) bool {
+	return isWritable(filepath.Dir(path))
+}
+
+// duplicateName returns the first unused name for a copy of path
+func duplicateName(path string, isDir bool) (string, error) {
+	dir, name := filepath.Split(path)
+	ext := filepath.Ext(name)
+	if isDir || ext == name {
+		// Directories and dotfiles like .env have no extension to keep
+		ext = ""
+	}
+	stem := strings.TrimSuffix(name, ext)
+	for n := 1; n < 1000; n++ {
+		suffix := "_copy"
+		if n > 1 {
+			suffix = fmt.Sprintf("_copy%d", n)
+		}
+		candidate := filepath.Join(dir, stem+suffix+ext)
+		if _, err := os.Lstat(candidate); os.IsNotExist(err) {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("too many copies of %s", name)
+}
+
+// copyDir copies the directory tree at src to dest, which must not exist
+func copyDir(src, dest string) error {
+	// Directories are created writable so their contents can be copied
+	// in, and get their own modes back once everything is copied
+	modes := make(map[string]fs.FileMode)
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+		if d.IsDir() {
+			if err := os.Mkdir(target, info.Mode().Perm()|0o700); err != nil {
+				return err
+			}
+			modes[target] = info.Mode().Perm()
+			return nil
+		}
+		return copyEntry(path, target, info)
+	})
+	if err != nil {
+		return err
+	}
+	for dir, mode := range modes {
+		if err := os.Chmod(dir, mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyEntry copies a single file or symlink at src to dest, keeping its
+// permissions. Symlinks are recreated rather than followed.
+func copyEntry(src, dest string, info fs.FileInfo) error {
+	if info.Mode()&fs.ModeSymlink != 0 {
+		link, err := os.Readlink(src)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(link, dest)
+	}
+	if !info.Mode().IsRegular() {
+		// Sockets and pipes have no contents to copy
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
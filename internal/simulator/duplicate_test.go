@@ -0,0 +1,113 @@
+package simulator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDuplicateFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(src, []byte("hello"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"notes_copy.txt", "notes_copy2.txt"} {
+		got, err := DuplicateFile(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != filepath.Join(dir, want) {
+			t.Errorf("DuplicateFile() = %q, want %q", got, filepath.Join(dir, want))
+		}
+		data, err := os.ReadFile(got)
+		if err != nil || string(data) != "hello" {
+			t.Errorf("copy contains %q (%v), want %q", data, err, "hello")
+		}
+		if info, err := os.Stat(got); err != nil || info.Mode().Perm() != 0o640 {
+			t.Errorf("copy mode = %v (%v), want 0640", info.Mode().Perm(), err)
+		}
+	}
+}
+
+func TestDuplicateFile_Directory(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "Caches.v2")
+	if err := os.MkdirAll(filepath.Join(src, "images"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "images", "a.png"), []byte("png"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("images/a.png", filepath.Join(src, "latest")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(filepath.Join(src, "images"), 0o555); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		// Let t.TempDir remove the read-only directories
+		_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+			if err == nil && d.IsDir() {
+				_ = os.Chmod(path, 0o755)
+			}
+			return nil
+		})
+	})
+
+	got, err := DuplicateFile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Directory names keep their dots
+	if want := filepath.Join(dir, "Caches.v2_copy"); got != want {
+		t.Errorf("DuplicateFile() = %q, want %q", got, want)
+	}
+	if data, err := os.ReadFile(filepath.Join(got, "images", "a.png")); err != nil || string(data) != "png" {
+		t.Errorf("copied file contains %q (%v), want %q", data, err, "png")
+	}
+	if link, err := os.Readlink(filepath.Join(got, "latest")); err != nil || link != "images/a.png" {
+		t.Errorf("copied symlink = %q (%v), want %q", link, err, "images/a.png")
+	}
+	if info, err := os.Stat(filepath.Join(got, "images")); err != nil || info.Mode().Perm() != 0o555 {
+		t.Errorf("copied directory mode = %v (%v), want 0555", info.Mode().Perm(), err)
+	}
+}
+
+func TestDuplicateName(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name  string
+		isDir bool
+		want  string
+	}{
+		{"photo.jpeg", false, "photo_copy.jpeg"},
+		{".env", false, ".env_copy"},
+		{"Makefile", false, "Makefile_copy"},
+		{"Model.momd", true, "Model.momd_copy"},
+	}
+	for _, tt := range tests {
+		got, err := duplicateName(filepath.Join(dir, tt.name), tt.isDir)
+		if err != nil || got != filepath.Join(dir, tt.want) {
+			t.Errorf("duplicateName(%q) = %q, %v; want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestCanDuplicate(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root can write anywhere")
+	}
+	dir := t.TempDir()
+	if !CanDuplicate(filepath.Join(dir, "notes.txt")) {
+		t.Error("CanDuplicate() = false in a writable directory")
+	}
+	if err := os.Chmod(dir, 0o555); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chmod(dir, 0o755) })
+	if CanDuplicate(filepath.Join(dir, "notes.txt")) {
+		t.Error("CanDuplicate() = true in a read-only directory")
+	}
+}
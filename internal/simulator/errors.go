@@ -0,0 +1,56 @@
+package simulator
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// errHint returns a suggestion for getting past err, for errors with a
+// known remedy, or "" for the rest
+func errHint(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, fs.ErrPermission):
+		return "Permission denied — try running simtool with the same user as the Simulator process"
+	case isBusy(err):
+		return "Database is locked — close the app on the simulator first"
+	}
+	return ""
+}
+
+// hintError replaces the message of an error that has a hint with the
+// hint, keeping the error itself for errors.Is and errors.As
+type hintError struct {
+	err  error
+	hint string
+}
+
+func (e *hintError) Error() string { return e.hint }
+
+func (e *hintError) Unwrap() error { return e.err }
+
+// withHint returns err with its message replaced by its hint, if it has
+// one, and err unchanged otherwise
+func withHint(err error) error {
+	if hint := errHint(err); hint != "" {
+		return &hintError{err: err, hint: hint}
+	}
+	return err
+}
+
+// openFailureHint returns a hint for SQLite failing to open the database
+// at path with err. SQLite reports an unreadable file only as "unable to
+// open database file", so the file is opened directly to find out why.
+func openFailureHint(path string, err error) string {
+	if hint := errHint(err); hint != "" {
+		return hint
+	}
+	f, openErr := os.Open(path)
+	if openErr != nil {
+		return errHint(openErr)
+	}
+	_ = f.Close()
+	return ""
+}
@@ -0,0 +1,75 @@
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+func TestErrHint(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"nil", nil, ""},
+		{"permission", fmt.Errorf("failed to open archive: %w", &fs.PathError{Op: "open", Path: "a.zip", Err: fs.ErrPermission}), "Permission denied"},
+		{"busy", sqlite3.Error{Code: sqlite3.ErrBusy}, "close the app on the simulator"},
+		{"locked", fmt.Errorf("query: %w", sqlite3.Error{Code: sqlite3.ErrLocked}), "close the app on the simulator"},
+		{"other", errors.New("not a valid image"), ""},
+	}
+	for _, tt := range tests {
+		got := errHint(tt.err)
+		if tt.want == "" && got != "" || !strings.Contains(got, tt.want) {
+			t.Errorf("errHint(%s) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWithHint(t *testing.T) {
+	err := withHint(fmt.Errorf("read: %w", fs.ErrPermission))
+	if !errors.Is(err, fs.ErrPermission) || !strings.HasPrefix(err.Error(), "Permission denied — ") {
+		t.Errorf("withHint() = %v, want the hint wrapping the permission error", err)
+	}
+	plain := errors.New("boom")
+	if withHint(plain) != plain {
+		t.Error("withHint() should leave errors without a hint alone")
+	}
+}
+
+func TestReadFileContent_PermissionHint(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root can read any file")
+	}
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte("secret"), 0o000); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := ReadFileContent(path, 0, 10, 80)
+	if err == nil || errHint(err) == "" || err.Error() != errHint(err) {
+		t.Errorf("ReadFileContent() error = %v, want the permission hint", err)
+	}
+}
+
+func TestReadDatabaseInfo_PermissionHint(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root can read any file")
+	}
+	path := filepath.Join(t.TempDir(), "app.db")
+	createTestDB(t, path, "CREATE TABLE notes (id INTEGER)")
+	if err := os.Chmod(path, 0o000); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := readDatabaseInfo(path)
+	if err != nil || !strings.HasPrefix(info.Error, "Permission denied") {
+		t.Errorf("readDatabaseInfo() = %+v, %v; want the permission hint", info, err)
+	}
+}
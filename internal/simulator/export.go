@@ -0,0 +1,168 @@
+package simulator
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// exportTableChunkRows is how many rows ExportTableCSV writes between
+// flushes to disk and progress updates
+const exportTableChunkRows = 500
+
+// zipCommand builds the process that archives everything in dir into
+// the zip file dest, keeping symlinks as links. Tests swap it to check
+// the arguments.
+var zipCommand = func(dir, dest string) *exec.Cmd {
+	cmd := exec.Command("zip", "-r", "-q", "-y", dest, ".")
+	cmd.Dir = dir
+	return cmd
+}
+
+// ContainerArchiveName returns the file name for an archive of the data
+// container of the app with bundleID made at t, e.g.
+// "com.example.app-2026-01-02-150405.zip"
+func ContainerArchiveName(bundleID string, t time.Time) string {
+	name := strings.NewReplacer("/", "-", ":", "-").Replace(bundleID)
+	if name == "" {
+		name = "Container"
+	}
+	return fmt.Sprintf("%s-%s.zip", name, t.Format("2006-01-02-150405"))
+}
+
+// ExportContainer archives the data container at container with zip
+// into a file in dir named by ContainerArchiveName, and returns its
+// path. Paths in the archive are relative to the container.
+func ExportContainer(container, bundleID, dir string, t time.Time) (string, error) {
+	if container == "" {
+		return "", errors.New("app has no data container")
+	}
+	path := filepath.Join(dir, ContainerArchiveName(bundleID, t))
+	output, err := zipCommand(container, path).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to export container: %w (output: %s)", err, strings.TrimSpace(string(output)))
+	}
+	return path, nil
+}
+
+// TableCSVName returns the file name for a CSV export of the table
+// named table, e.g. "users.csv"
+func TableCSVName(table string) string {
+	name := strings.NewReplacer("/", "-", ":", "-").Replace(table)
+	if name == "" {
+		name = "Table"
+	}
+	return name + ".csv"
+}
+
+// ExportTableCSV writes every row of table in the SQLite database at
+// dbPath to a CSV file in dir named by TableCSVName, below a header of
+// the column names, and returns its path and the number of rows written.
+// Rows are read through a cursor and flushed in chunks, so large tables
+// are never held in memory; progress, when not nil, is set to the rows
+// written after each chunk. A failed export leaves no file behind.
+func ExportTableCSV(dbPath, table, dir string, progress *atomic.Int64) (string, int, error) {
+	if isRealmFile(dbPath) {
+		return "", 0, errors.New("CSV export is not supported for Realm databases")
+	}
+	db, err := openReadOnlyDB(dbPath)
+	if err != nil {
+		return "", 0, err
+	}
+	defer func() { _ = db.Close() }()
+
+	rows, err := db.Query("SELECT * FROM " + quoteSQLiteIdentifier(table))
+	if err != nil {
+		return "", 0, withHint(err)
+	}
+	defer func() { _ = rows.Close() }()
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", 0, err
+	}
+
+	path := filepath.Join(dir, TableCSVName(table))
+	f, err := os.Create(path)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create CSV file: %w", err)
+	}
+	written, err := writeTableCSV(f, rows, columns, progress)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return "", written, fmt.Errorf("failed to export table: %w", err)
+	}
+	return path, written, nil
+}
+
+// writeTableCSV writes the header and every row to f, flushing each
+// chunk of exportTableChunkRows rows
+func writeTableCSV(f *os.File, rows *sql.Rows, columns []string, progress *atomic.Int64) (int, error) {
+	w := csv.NewWriter(f)
+	if err := w.Write(columns); err != nil {
+		return 0, err
+	}
+
+	values := make([]any, len(columns))
+	valuePtrs := make([]any, len(columns))
+	for i := range values {
+		valuePtrs[i] = &values[i]
+	}
+	record := make([]string, len(columns))
+	written := 0
+	for rows.Next() {
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return written, err
+		}
+		for i, v := range values {
+			record[i] = csvValue(v)
+		}
+		if err := w.Write(record); err != nil {
+			return written, err
+		}
+		written++
+		if written%exportTableChunkRows == 0 {
+			w.Flush()
+			if err := w.Error(); err != nil {
+				return written, err
+			}
+			if progress != nil {
+				progress.Store(int64(written))
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return written, err
+	}
+	w.Flush()
+	if progress != nil {
+		progress.Store(int64(written))
+	}
+	return written, w.Error()
+}
+
+// csvValue formats a SQLite value for a CSV cell. NULL is an empty cell.
+func csvValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,148 @@
+package simulator
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestContainerArchiveName(t *testing.T) {
+	at := time.Date(2026, 1, 2, 15, 4, 5, 0, time.Local)
+	if got := ContainerArchiveName("com.example.app", at); got != "com.example.app-2026-01-02-150405.zip" {
+		t.Errorf("ContainerArchiveName() = %q", got)
+	}
+	if got := ContainerArchiveName("", at); got != "Container-2026-01-02-150405.zip" {
+		t.Errorf("ContainerArchiveName(\"\") = %q", got)
+	}
+}
+
+func TestExportContainer(t *testing.T) {
+	if _, err := exec.LookPath("zip"); err != nil {
+		t.Skip("zip not found in PATH")
+	}
+	container := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(container, "Documents"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(container, "Documents", "notes.txt"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	desktop := t.TempDir()
+
+	path, err := ExportContainer(container, "com.example.app", desktop, time.Date(2026, 1, 2, 15, 4, 5, 0, time.Local))
+	if err != nil {
+		t.Fatalf("ExportContainer: %v", err)
+	}
+	if want := filepath.Join(desktop, "com.example.app-2026-01-02-150405.zip"); path != want {
+		t.Errorf("path = %s, want %s", path, want)
+	}
+
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	defer archive.Close()
+	var names []string
+	for _, f := range archive.File {
+		names = append(names, f.Name)
+	}
+	sort.Strings(names)
+	if got := strings.Join(names, ", "); got != "Documents/, Documents/notes.txt" {
+		t.Errorf("archive holds %s, want paths relative to the container", got)
+	}
+}
+
+func TestExportContainer_Errors(t *testing.T) {
+	if _, err := ExportContainer("", "com.example.app", t.TempDir(), time.Now()); err == nil {
+		t.Error("expected an error for an app without a data container")
+	}
+
+	prev := zipCommand
+	zipCommand = func(dir, dest string) *exec.Cmd {
+		return exec.Command("sh", "-c", "echo 'zip error: Nothing to do!' >&2; exit 12")
+	}
+	t.Cleanup(func() { zipCommand = prev })
+
+	_, err := ExportContainer(t.TempDir(), "com.example.app", t.TempDir(), time.Now())
+	if err == nil || !strings.Contains(err.Error(), "Nothing to do") {
+		t.Errorf("error = %v, want zip's output", err)
+	}
+}
+
+func TestExportTableCSV(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "app.db")
+	statements := []string{
+		`CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, score REAL, note TEXT)`,
+		`INSERT INTO users VALUES (1, 'Ann', 1.5, NULL), (2, 'Bo, Jr.', 2000000, 'says "hi"')`,
+	}
+	// Enough rows to span several chunks
+	for i := 3; i <= 2*exportTableChunkRows+3; i++ {
+		statements = append(statements, fmt.Sprintf("INSERT INTO users VALUES (%d, 'user%d', 0, '')", i, i))
+	}
+	createTestDB(t, dbPath, statements...)
+	desktop := t.TempDir()
+
+	var progress atomic.Int64
+	path, written, err := ExportTableCSV(dbPath, "users", desktop, &progress)
+	if err != nil {
+		t.Fatalf("ExportTableCSV: %v", err)
+	}
+	if want := 2*exportTableChunkRows + 3; written != want || progress.Load() != int64(want) {
+		t.Errorf("rows written = %d, progress = %d, want %d", written, progress.Load(), want)
+	}
+	if path != filepath.Join(desktop, "users.csv") {
+		t.Errorf("path = %q", path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("reading the CSV: %v", err)
+	}
+	want := [][]string{
+		{"id", "name", "score", "note"},
+		{"1", "Ann", "1.5", ""},
+		{"2", "Bo, Jr.", "2000000", `says "hi"`},
+	}
+	if len(records) != written+1 || !reflect.DeepEqual(records[:3], want) {
+		t.Errorf("CSV starts %q with %d records, want %q with %d", records[:3], len(records), want, written+1)
+	}
+}
+
+func TestExportTableCSV_Errors(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "app.db")
+	createTestDB(t, dbPath, `CREATE TABLE users (id INTEGER)`)
+	desktop := t.TempDir()
+
+	if _, _, err := ExportTableCSV(dbPath, "missing", desktop, nil); err == nil {
+		t.Error("ExportTableCSV of a missing table: want error")
+	}
+	if _, _, err := ExportTableCSV(dbPath, "users", filepath.Join(desktop, "nope"), nil); err == nil {
+		t.Error("ExportTableCSV into a missing folder: want error")
+	}
+	if entries, _ := os.ReadDir(desktop); len(entries) != 0 {
+		t.Errorf("failed exports left %d files behind", len(entries))
+	}
+}
+
+func TestTableCSVName(t *testing.T) {
+	if got := TableCSVName("ZUSER"); got != "ZUSER.csv" {
+		t.Errorf("TableCSVName() = %q", got)
+	}
+	if got := TableCSVName("a/b:c"); got != "a-b-c.csv" {
+		t.Errorf("TableCSVName() with separators = %q", got)
+	}
+}
@@ -0,0 +1,90 @@
+package simulator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// extensionDirs are the app bundle directories holding extensions:
+// PlugIns for NSExtension-based ones, Extensions for ExtensionKit ones
+var extensionDirs = []string{"PlugIns", "Extensions"}
+
+// extensionLabels names the common extension points, keyed by extension
+// point identifier
+var extensionLabels = map[string]string{
+	"com.apple.widgetkit-extension":                   "Widgets",
+	"com.apple.widget-extension":                      "Widgets",
+	"com.apple.share-services":                        "Share ext",
+	"com.apple.ui-services":                           "Action ext",
+	"com.apple.services":                              "Action ext",
+	"com.apple.usernotifications.service":             "Notification service",
+	"com.apple.usernotifications.content-extension":   "Notification content",
+	"com.apple.intents-service":                       "Intents",
+	"com.apple.intents-ui-service":                    "Intents UI",
+	"com.apple.keyboard-service":                      "Keyboard",
+	"com.apple.message-payload-provider":              "iMessage",
+	"com.apple.fileprovider-nonui":                    "File provider",
+	"com.apple.background-asset-downloader-extension": "Background assets",
+}
+
+// ExtensionLabel returns a short label for the extension point with
+// identifier, e.g. "Widgets" for com.apple.widgetkit-extension.
+// Extension points without a label are reported as "Other ext".
+func ExtensionLabel(identifier string) string {
+	if label, ok := extensionLabels[identifier]; ok {
+		return label
+	}
+	return "Other ext"
+}
+
+// readAppExtensions returns the extension point identifiers of the
+// .appex bundles inside the app bundle at appPath, sorted, one entry
+// per extension. Extensions whose Info.plist can't be read are skipped.
+func readAppExtensions(appPath string) []string {
+	var extensions []string
+	for _, dir := range extensionDirs {
+		entries, err := os.ReadDir(filepath.Join(appPath, dir))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !strings.HasSuffix(entry.Name(), ".appex") {
+				continue
+			}
+			if id := readExtensionPoint(filepath.Join(appPath, dir, entry.Name())); id != "" {
+				extensions = append(extensions, id)
+			}
+		}
+	}
+	sort.Strings(extensions)
+	return extensions
+}
+
+// readExtensionPoint reads the extension point identifier from the
+// Info.plist of the extension bundle at appexPath, or "" if there is
+// none
+func readExtensionPoint(appexPath string) string {
+	output, err := defaultExecutor.Execute("plutil", "-convert", "json", "-o", "-", filepath.Join(appexPath, "Info.plist"))
+	if err != nil {
+		return ""
+	}
+
+	var plist struct {
+		NSExtension struct {
+			PointIdentifier string `json:"NSExtensionPointIdentifier"`
+		} `json:"NSExtension"`
+		EXAppExtensionAttributes struct {
+			PointIdentifier string `json:"EXExtensionPointIdentifier"`
+		} `json:"EXAppExtensionAttributes"`
+	}
+	if err := json.Unmarshal(output, &plist); err != nil {
+		return ""
+	}
+	if plist.NSExtension.PointIdentifier != "" {
+		return plist.NSExtension.PointIdentifier
+	}
+	return plist.EXAppExtensionAttributes.PointIdentifier
+}
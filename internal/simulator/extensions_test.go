@@ -0,0 +1,49 @@
+package simulator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadAppExtensions(t *testing.T) {
+	appPath := filepath.Join(t.TempDir(), "MyApp.app")
+	for _, dir := range []string{"PlugIns/Widget.appex", "PlugIns/Share.appex", "PlugIns/Broken.appex", "Extensions/Kit.appex", "PlugIns/Resources"} {
+		if err := os.MkdirAll(filepath.Join(appPath, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	plist := func(name string) string {
+		return "plutil -convert json -o - " + filepath.Join(appPath, name, "Info.plist")
+	}
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{
+		plist("PlugIns/Widget.appex"): {out: []byte(`{"NSExtension": {"NSExtensionPointIdentifier": "com.apple.widgetkit-extension"}}`)},
+		plist("PlugIns/Share.appex"):  {out: []byte(`{"NSExtension": {"NSExtensionPointIdentifier": "com.apple.share-services"}}`)},
+		plist("PlugIns/Broken.appex"): {out: []byte(`not json`)},
+		plist("Extensions/Kit.appex"): {out: []byte(`{"EXAppExtensionAttributes": {"EXExtensionPointIdentifier": "com.example.point"}}`)},
+	}})
+
+	got := readAppExtensions(appPath)
+	want := []string{"com.apple.share-services", "com.apple.widgetkit-extension", "com.example.point"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readAppExtensions() = %v, want %v", got, want)
+	}
+
+	if got := readAppExtensions(filepath.Join(t.TempDir(), "Plain.app")); got != nil {
+		t.Errorf("readAppExtensions() without extensions = %v, want nil", got)
+	}
+}
+
+func TestExtensionLabel(t *testing.T) {
+	tests := map[string]string{
+		"com.apple.widgetkit-extension": "Widgets",
+		"com.apple.share-services":      "Share ext",
+		"com.example.point":             "Other ext",
+	}
+	for id, want := range tests {
+		if got := ExtensionLabel(id); got != want {
+			t.Errorf("ExtensionLabel(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
@@ -2,11 +2,16 @@ package simulator
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 )
 
 // Fetcher is responsible for fetching simulator information
@@ -14,6 +19,11 @@ type Fetcher interface {
 	Fetch() ([]Item, error)
 	FetchSimulators() ([]Simulator, error)
 	Boot(udid string) error
+	Shutdown(udid string) error
+
+	// SetRuntimeFilter limits later fetches to simulators of the
+	// platforms in filter
+	SetRuntimeFilter(filter RuntimeFilter)
 }
 
 // CommandExecutor handles execution of external commands
@@ -38,12 +48,21 @@ func (e *RealCommandExecutor) Run(name string, args ...string) error {
 // SimctlFetcher fetches simulators using xcrun simctl
 type SimctlFetcher struct {
 	executor CommandExecutor
+
+	// The Xcode version is read once, on the first Fetch
+	xcodeOnce    sync.Once
+	xcodeVersion string
+
+	// The filter is set from the UI while fetches run in the background
+	filterMu sync.Mutex
+	filter   RuntimeFilter
 }
 
 // NewFetcher creates a new simulator fetcher
 func NewFetcher() Fetcher {
 	return &SimctlFetcher{
 		executor: &RealCommandExecutor{},
+		filter:   RuntimeAll,
 	}
 }
 
@@ -51,9 +70,25 @@ func NewFetcher() Fetcher {
 func NewFetcherWithExecutor(executor CommandExecutor) Fetcher {
 	return &SimctlFetcher{
 		executor: executor,
+		filter:   RuntimeAll,
 	}
 }
 
+// SetRuntimeFilter limits later fetches to simulators of the platforms
+// in filter
+func (f *SimctlFetcher) SetRuntimeFilter(filter RuntimeFilter) {
+	f.filterMu.Lock()
+	defer f.filterMu.Unlock()
+	f.filter = filter
+}
+
+// runtimeFilter returns the current filter
+func (f *SimctlFetcher) runtimeFilter() RuntimeFilter {
+	f.filterMu.Lock()
+	defer f.filterMu.Unlock()
+	return f.filter
+}
+
 // FetchSimulators retrieves all available simulators without app counts
 func (f *SimctlFetcher) FetchSimulators() ([]Simulator, error) {
 	output, err := f.executor.Execute("xcrun", "simctl", "list", "devices", "--json")
@@ -66,8 +101,12 @@ func (f *SimctlFetcher) FetchSimulators() ([]Simulator, error) {
 		return nil, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
+	filter := f.runtimeFilter()
 	var simulators []Simulator
-	for _, sims := range simctlOutput.Devices {
+	for runtime, sims := range simctlOutput.Devices {
+		if !filter.Includes(PlatformFromRuntime(runtime)) {
+			continue
+		}
 		for _, sim := range sims {
 			if sim.IsAvailable {
 				simulators = append(simulators, sim)
@@ -78,7 +117,8 @@ func (f *SimctlFetcher) FetchSimulators() ([]Simulator, error) {
 	return simulators, nil
 }
 
-// Fetch retrieves all available iOS simulators
+// Fetch retrieves all available simulators of the platforms in the
+// runtime filter
 func (f *SimctlFetcher) Fetch() ([]Item, error) {
 	output, err := f.executor.Execute("xcrun", "simctl", "list", "devices", "--json")
 	if err != nil {
@@ -90,24 +130,54 @@ func (f *SimctlFetcher) Fetch() ([]Item, error) {
 		return nil, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
+	f.xcodeOnce.Do(func() {
+		// Without a version there is nothing to compare runtimes against
+		f.xcodeVersion, _ = f.getXcodeVersion()
+	})
+
+	// Build numbers and system apps are only details; without them the
+	// runtimes show their version alone
+	runtimes, _ := f.getRuntimes()
+
+	filter := f.runtimeFilter()
 	var items []Item
 	for runtime, sims := range simctlOutput.Devices {
+		platform := PlatformFromRuntime(runtime)
+		if !filter.Includes(platform) {
+			continue
+		}
 		runtimeName := formatRuntime(runtime)
+		unsupported := runtimeNewerThanXcode(runtimeName, f.xcodeVersion)
+		systemApps := countSystemApps(runtimes[runtime].BundlePath)
 		for _, sim := range sims {
 			if sim.IsAvailable {
 				appCount := f.getAppCount(sim.UDID)
 				items = append(items, Item{
-					Simulator: sim,
-					Runtime:   runtimeName,
-					AppCount:  appCount,
+					Simulator:          sim,
+					Runtime:            runtimeName,
+					Platform:           platform,
+					BuildVersion:       runtimes[runtime].BuildVersion,
+					AppCount:           appCount,
+					SystemAppCount:     systemApps,
+					RuntimeUnsupported: unsupported,
+					DeviceCapabilities: DeviceCapabilities(sim.DeviceTypeIdentifier),
 				})
 			}
 		}
 	}
 
-	// Sort simulators by name
-	sort.Slice(items, func(i, j int) bool {
-		return items[i].Name < items[j].Name
+	// Sort simulators by name. Devices sharing a name fall back to the
+	// runtime order, newest first, then UDID, so they keep their places
+	// across refreshes.
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Runtime != b.Runtime {
+			return runtimeLess(a.Runtime, b.Runtime)
+		}
+		return a.UDID < b.UDID
 	})
 
 	return items, nil
@@ -130,6 +200,19 @@ func (f *SimctlFetcher) Boot(udid string) error {
 	return f.openSimulatorApp()
 }
 
+// Shutdown shuts down the simulator with the given UDID
+func (f *SimctlFetcher) Shutdown(udid string) error {
+	output, err := f.executor.Execute("xcrun", "simctl", "shutdown", udid)
+	if err != nil {
+		// Already shut down, e.g. from Simulator.app since the last refresh
+		if strings.Contains(string(output), "Unable to shutdown device in current state: Shutdown") {
+			return nil
+		}
+		return fmt.Errorf("failed to shut down simulator: %w (output: %s)", err, string(output))
+	}
+	return nil
+}
+
 // openSimulatorApp opens the Simulator application
 func (f *SimctlFetcher) openSimulatorApp() error {
 	if err := f.executor.Run("open", "-a", "Simulator"); err != nil {
@@ -138,6 +221,102 @@ func (f *SimctlFetcher) openSimulatorApp() error {
 	return nil
 }
 
+// getRuntimes maps runtime identifiers to the installed runtimes, e.g.
+// com.apple.CoreSimulator.SimRuntime.iOS-17-0 to iOS 17.0 (21A329)
+func (f *SimctlFetcher) getRuntimes() (map[string]Runtime, error) {
+	output, err := f.executor.Execute("xcrun", "simctl", "list", "runtimes", "--json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runtimes: %w", err)
+	}
+
+	var runtimesOutput RuntimesOutput
+	if err := json.Unmarshal(output, &runtimesOutput); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	runtimes := make(map[string]Runtime, len(runtimesOutput.Runtimes))
+	for _, runtime := range runtimesOutput.Runtimes {
+		runtimes[runtime.Identifier] = runtime
+	}
+	return runtimes, nil
+}
+
+// runtimeAppsDir is where a runtime bundle keeps the apps that come with
+// the OS, such as Safari and Settings
+const runtimeAppsDir = "Contents/Resources/RuntimeRoot/Applications"
+
+// countSystemApps returns the number of apps the runtime bundle at
+// bundlePath comes with, or 0 if they can't be listed. Every simulator
+// on the runtime has the same ones.
+func countSystemApps(bundlePath string) int {
+	if bundlePath == "" {
+		return 0
+	}
+	apps, _ := filepath.Glob(filepath.Join(bundlePath, runtimeAppsDir, "*.app"))
+	return len(apps)
+}
+
+// getXcodeVersion returns the version of the Xcode selected with
+// xcode-select, e.g. "15.4". It is read from the version.plist next to
+// the Developer directory xcode-select -p prints; the command line tools
+// have none and yield an error.
+func (f *SimctlFetcher) getXcodeVersion() (string, error) {
+	output, err := f.executor.Execute("xcode-select", "-p")
+	if err != nil {
+		return "", fmt.Errorf("failed to run xcode-select: %w", err)
+	}
+	developerDir := strings.TrimSpace(string(output))
+	if developerDir == "" {
+		return "", errors.New("no developer directory selected")
+	}
+
+	versionPlist := filepath.Join(filepath.Dir(developerDir), "version.plist")
+	output, err = f.executor.Execute("plutil", "-extract", "CFBundleShortVersionString", "raw", "-o", "-", versionPlist)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Xcode version: %w", err)
+	}
+	version := strings.TrimSpace(string(output))
+	if version == "" {
+		return "", errors.New("no Xcode version found")
+	}
+	return version, nil
+}
+
+// runtimeVersionPattern matches the platform and major version of a
+// runtime name as returned by formatRuntime, e.g. "iOS 17.0" or
+// "watchOS 10.0".
+var runtimeVersionPattern = regexp.MustCompile(`^(iOS|tvOS|watchOS|visionOS|xrOS)[ .](\d+)`)
+
+// runtimeNewerThanXcode reports whether runtime is a newer major version
+// than Xcode xcodeVersion ships with, so Xcode can't build for it. Since
+// Xcode 26 every platform shares Xcode's version; before that each
+// platform's versions were offset from Xcode's. Unknown runtimes and
+// versions never count as newer.
+func runtimeNewerThanXcode(runtime, xcodeVersion string) bool {
+	match := runtimeVersionPattern.FindStringSubmatch(runtime)
+	if match == nil {
+		return false
+	}
+	runtimeMajor, _ := strconv.Atoi(match[2])
+	xcodeMajor, err := strconv.Atoi(strings.SplitN(xcodeVersion, ".", 2)[0])
+	if err != nil {
+		return false
+	}
+
+	supported := xcodeMajor
+	if xcodeMajor < 26 {
+		switch match[1] {
+		case "iOS", "tvOS":
+			supported = xcodeMajor + 2 // Xcode 15 ships iOS 17
+		case "watchOS":
+			supported = xcodeMajor - 5 // Xcode 15 ships watchOS 10
+		case "visionOS", "xrOS":
+			supported = xcodeMajor - 14 // Xcode 15 ships visionOS 1
+		}
+	}
+	return runtimeMajor > supported
+}
+
 // getAppCount returns the number of installed apps on a simulator
 func (f *SimctlFetcher) getAppCount(udid string) int {
 	// First try to get apps using listapps (works for booted simulators)
@@ -195,10 +374,15 @@ func (f *SimctlFetcher) getAppCountFromDataDir(udid string) int {
 func formatRuntime(runtime string) string {
 	// Remove prefix
 	runtimeName := strings.Replace(runtime, "com.apple.CoreSimulator.SimRuntime.", "", 1)
-	// Format iOS versions
-	runtimeName = strings.Replace(runtimeName, "iOS-", "iOS ", 1)
-	runtimeName = strings.ReplaceAll(runtimeName, "-", ".")
-	return runtimeName
+	platform, version, ok := strings.Cut(runtimeName, "-")
+	if !ok {
+		return runtimeName
+	}
+	if platform == "xrOS" {
+		platform = "visionOS"
+	}
+	// Format versions, e.g. watchOS-10-0 as watchOS 10.0
+	return platform + " " + strings.ReplaceAll(version, "-", ".")
 }
 
 // parseRuntimeVersion extracts version from runtime string
@@ -227,9 +411,10 @@ func parseSimulatorJSON(data []byte) ([]Item, error) {
 		for _, device := range devices {
 			if device.IsAvailable {
 				items = append(items, Item{
-					Simulator: device,
-					Runtime:   runtime,
-					AppCount:  0, // This is calculated separately
+					Simulator:          device,
+					Runtime:            runtime,
+					AppCount:           0, // This is calculated separately
+					DeviceCapabilities: DeviceCapabilities(device.DeviceTypeIdentifier),
 				})
 			}
 		}
@@ -167,6 +167,37 @@ func TestFetcher_Boot_OpenFails(t *testing.T) {
 	}
 }
 
+func TestFetcher_Shutdown(t *testing.T) {
+	var shutdownUDID string
+	mock := &MockCommandExecutor{
+		ExecuteFunc: func(name string, args ...string) ([]byte, error) {
+			if name == "xcrun" && len(args) == 3 && args[0] == "simctl" && args[1] == "shutdown" {
+				switch args[2] {
+				case "ALREADY-SHUTDOWN":
+					return []byte("Unable to shutdown device in current state: Shutdown"),
+						errors.New("exit status 149")
+				case "BROKEN":
+					return []byte("some other failure"), errors.New("exit status 1")
+				}
+				shutdownUDID = args[2]
+				return nil, nil
+			}
+			return nil, fmt.Errorf("unexpected Execute: %s %v", name, args)
+		},
+	}
+	f := NewFetcherWithExecutor(mock)
+
+	if err := f.Shutdown("UDID"); err != nil || shutdownUDID != "UDID" {
+		t.Errorf("Shutdown(UDID) = %v, shut down %q", err, shutdownUDID)
+	}
+	if err := f.Shutdown("ALREADY-SHUTDOWN"); err != nil {
+		t.Errorf("Shutdown of a shut down simulator = %v, want nil", err)
+	}
+	if err := f.Shutdown("BROKEN"); err == nil || !strings.Contains(err.Error(), "some other failure") {
+		t.Errorf("Shutdown(BROKEN) = %v, want error with simctl output", err)
+	}
+}
+
 func TestGetAppCountFromDataDir_CountsDirEntries(t *testing.T) {
 	// Redirect HOME to a tempdir, build the exact directory layout
 	// getAppCountFromDataDir expects, then count.
@@ -239,7 +270,9 @@ func TestFormatRuntime(t *testing.T) {
 	}{
 		{"com.apple.CoreSimulator.SimRuntime.iOS-17-0", "iOS 17.0"},
 		{"com.apple.CoreSimulator.SimRuntime.iOS-16-4-1", "iOS 16.4.1"},
-		{"com.apple.CoreSimulator.SimRuntime.watchOS-10-0", "watchOS.10.0"},
+		{"com.apple.CoreSimulator.SimRuntime.watchOS-10-0", "watchOS 10.0"},
+		{"com.apple.CoreSimulator.SimRuntime.tvOS-17-0", "tvOS 17.0"},
+		{"com.apple.CoreSimulator.SimRuntime.xrOS-1-0", "visionOS 1.0"},
 		{"unexpected", "unexpected"},
 	}
 	for _, tt := range tests {
@@ -3,6 +3,9 @@ package simulator
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -69,6 +72,41 @@ func TestSimctlFetcher_Fetch(t *testing.T) {
 	}
 }
 
+func TestSimctlFetcher_Fetch_SameNameOrder(t *testing.T) {
+	mockExecutor := &MockCommandExecutor{}
+	fetcher := NewFetcherWithExecutor(mockExecutor)
+
+	mockExecutor.ExecuteFunc = func(name string, args ...string) ([]byte, error) {
+		if name == "xcrun" && args[1] == "list" && args[2] == "devices" {
+			return json.Marshal(SimctlOutput{Devices: map[string][]Simulator{
+				"com.apple.CoreSimulator.SimRuntime.iOS-17-0": {
+					{UDID: "b", Name: "iPhone 15", IsAvailable: true},
+					{UDID: "a", Name: "iPhone 15", IsAvailable: true},
+				},
+				"com.apple.CoreSimulator.SimRuntime.iOS-17-2": {{UDID: "c", Name: "iPhone 15", IsAvailable: true}},
+				"com.apple.CoreSimulator.SimRuntime.iOS-16-4": {{UDID: "d", Name: "iPhone 14", IsAvailable: true}},
+			}})
+		}
+		return nil, fmt.Errorf("unexpected command: %s %v", name, args)
+	}
+
+	// Map iteration varies between fetches, so repeat to catch an
+	// unstable order
+	for range 10 {
+		items, err := fetcher.Fetch()
+		if err != nil {
+			t.Fatalf("Fetch() error = %v", err)
+		}
+		udids := make([]string, len(items))
+		for i, item := range items {
+			udids[i] = item.UDID
+		}
+		if got := strings.Join(udids, ","); got != "d,c,a,b" {
+			t.Fatalf("Fetch() order = %s, want d,c,a,b: by name, newest runtime, then UDID", got)
+		}
+	}
+}
+
 func TestSimctlFetcher_Boot(t *testing.T) {
 	mockExecutor := &MockCommandExecutor{}
 	fetcher := NewFetcherWithExecutor(mockExecutor)
@@ -148,6 +186,9 @@ func TestParseSimulatorJSON(t *testing.T) {
 						if item.Runtime != "iOS 17.0" {
 							t.Errorf("Expected runtime for UDID 12345 to be iOS 17.0, got %s", item.Runtime)
 						}
+						if got := strings.Join(item.DeviceCapabilities, ", "); got != "Face ID, Dynamic Island" {
+							t.Errorf("Expected iPhone 15 capabilities to be Face ID, Dynamic Island, got %s", got)
+						}
 					}
 					if item.UDID == "67890" {
 						found67890 = true
@@ -343,3 +384,299 @@ func TestJSONMarshaling(t *testing.T) {
 		t.Errorf("Expected UDID 12345, got %s", devices[0].UDID)
 	}
 }
+
+func TestSimctlFetcher_GetXcodeVersion(t *testing.T) {
+	mockExecutor := &MockCommandExecutor{}
+	fetcher := &SimctlFetcher{executor: mockExecutor}
+
+	mockExecutor.ExecuteFunc = func(name string, args ...string) ([]byte, error) {
+		switch {
+		case name == "xcode-select":
+			return []byte("/Applications/Xcode.app/Contents/Developer\n"), nil
+		case name == "plutil" && args[len(args)-1] == "/Applications/Xcode.app/Contents/version.plist":
+			return []byte("15.4\n"), nil
+		}
+		return nil, fmt.Errorf("unexpected command: %s %v", name, args)
+	}
+	version, err := fetcher.getXcodeVersion()
+	if err != nil || version != "15.4" {
+		t.Errorf("getXcodeVersion() = %q, %v; want 15.4", version, err)
+	}
+
+	// The command line tools have no version.plist
+	mockExecutor.ExecuteFunc = func(name string, args ...string) ([]byte, error) {
+		if name == "xcode-select" {
+			return []byte("/Library/Developer/CommandLineTools\n"), nil
+		}
+		return nil, fmt.Errorf("file not found")
+	}
+	if _, err := fetcher.getXcodeVersion(); err == nil {
+		t.Error("getXcodeVersion() without Xcode should fail")
+	}
+}
+
+func TestRuntimeNewerThanXcode(t *testing.T) {
+	tests := []struct {
+		runtime string
+		xcode   string
+		want    bool
+	}{
+		{"iOS 17.5", "15.4", false},
+		{"iOS 18.0", "15.4", true},
+		{"tvOS.17.0", "15.0", false},
+		{"watchOS.11.0", "15.2", true},
+		{"watchOS.10.0", "15.2", false},
+		{"visionOS.2.0", "15.4", true},
+		{"iOS 26.0", "16.4", true},
+		{"iOS 26.0", "26.0", false},
+		{"watchOS.26.0", "26.1", false},
+		{"iOS 27.0", "26.1", true},
+		{"iOS 18.0", "", false},
+		{"Unknown 99", "15.0", false},
+	}
+	for _, tt := range tests {
+		if got := runtimeNewerThanXcode(tt.runtime, tt.xcode); got != tt.want {
+			t.Errorf("runtimeNewerThanXcode(%q, %q) = %v, want %v", tt.runtime, tt.xcode, got, tt.want)
+		}
+	}
+}
+
+func TestSimctlFetcher_Fetch_RuntimeUnsupported(t *testing.T) {
+	mockExecutor := &MockCommandExecutor{}
+	fetcher := NewFetcherWithExecutor(mockExecutor)
+
+	xcodeChecks := 0
+	mockExecutor.ExecuteFunc = func(name string, args ...string) ([]byte, error) {
+		switch {
+		case name == "xcrun" && args[1] == "list":
+			return json.Marshal(SimctlOutput{Devices: map[string][]Simulator{
+				"com.apple.CoreSimulator.SimRuntime.iOS-17-5": {{UDID: "1", Name: "iPhone 15", IsAvailable: true}},
+				"com.apple.CoreSimulator.SimRuntime.iOS-18-0": {{UDID: "2", Name: "iPhone 16", IsAvailable: true}},
+			}})
+		case name == "xcode-select":
+			xcodeChecks++
+			return []byte("/Applications/Xcode.app/Contents/Developer\n"), nil
+		case name == "plutil":
+			return []byte("15.4\n"), nil
+		}
+		return nil, fmt.Errorf("unexpected command: %s %v", name, args)
+	}
+
+	for range 2 {
+		items, err := fetcher.Fetch()
+		if err != nil {
+			t.Fatalf("Fetch() error = %v", err)
+		}
+		for _, item := range items {
+			if want := item.UDID == "2"; item.RuntimeUnsupported != want {
+				t.Errorf("%s (%s) RuntimeUnsupported = %v, want %v", item.Name, item.Runtime, item.RuntimeUnsupported, want)
+			}
+		}
+	}
+	if xcodeChecks != 1 {
+		t.Errorf("Xcode version read %d times, want once", xcodeChecks)
+	}
+}
+
+func TestSimctlFetcher_Fetch_BuildVersion(t *testing.T) {
+	mockExecutor := &MockCommandExecutor{}
+	fetcher := NewFetcherWithExecutor(mockExecutor)
+
+	mockExecutor.ExecuteFunc = func(name string, args ...string) ([]byte, error) {
+		switch {
+		case name == "xcrun" && args[1] == "list" && args[2] == "devices":
+			return json.Marshal(SimctlOutput{Devices: map[string][]Simulator{
+				"com.apple.CoreSimulator.SimRuntime.iOS-17-0": {{UDID: "1", Name: "iPhone 15", IsAvailable: true}},
+				"com.apple.CoreSimulator.SimRuntime.iOS-16-4": {{UDID: "2", Name: "iPhone 14", IsAvailable: true}},
+			}})
+		case name == "xcrun" && args[1] == "list" && args[2] == "runtimes":
+			return []byte(`{"runtimes": [{
+				"identifier": "com.apple.CoreSimulator.SimRuntime.iOS-17-0",
+				"name": "iOS 17.0",
+				"version": "17.0",
+				"buildversion": "21A329"
+			}]}`), nil
+		}
+		return nil, fmt.Errorf("unexpected command: %s %v", name, args)
+	}
+
+	items, err := fetcher.Fetch()
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	want := map[string]string{"1": "21A329", "2": ""}
+	for _, item := range items {
+		if item.BuildVersion != want[item.UDID] {
+			t.Errorf("%s BuildVersion = %q, want %q", item.Name, item.BuildVersion, want[item.UDID])
+		}
+	}
+}
+
+func TestSimctlFetcher_Fetch_RuntimeFilter(t *testing.T) {
+	mockExecutor := &MockCommandExecutor{}
+	fetcher := NewFetcherWithExecutor(mockExecutor)
+
+	mockExecutor.ExecuteFunc = func(name string, args ...string) ([]byte, error) {
+		if name == "xcrun" && args[1] == "list" && args[2] == "devices" {
+			return json.Marshal(SimctlOutput{Devices: map[string][]Simulator{
+				"com.apple.CoreSimulator.SimRuntime.iOS-17-0":     {{UDID: "1", Name: "iPhone 15", IsAvailable: true}},
+				"com.apple.CoreSimulator.SimRuntime.watchOS-10-0": {{UDID: "2", Name: "Apple Watch", IsAvailable: true}},
+				"com.apple.CoreSimulator.SimRuntime.tvOS-17-0":    {{UDID: "3", Name: "Apple TV", IsAvailable: true}},
+				"com.apple.CoreSimulator.SimRuntime.xrOS-1-0":     {{UDID: "4", Name: "Apple Vision Pro", IsAvailable: true}},
+			}})
+		}
+		return nil, fmt.Errorf("unexpected command: %s %v", name, args)
+	}
+
+	items, err := fetcher.Fetch()
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	want := map[string]RuntimeFilter{"1": RuntimeIOS, "2": RuntimeWatchOS, "3": RuntimeTVOS, "4": RuntimeVisionOS}
+	if len(items) != len(want) {
+		t.Fatalf("Fetch() returned %d simulators, want %d", len(items), len(want))
+	}
+	for _, item := range items {
+		if item.Platform != want[item.UDID] {
+			t.Errorf("%s Platform = %v, want %v", item.Name, item.Platform, want[item.UDID])
+		}
+	}
+
+	fetcher.SetRuntimeFilter(RuntimeWatchOS | RuntimeTVOS)
+	items, err = fetcher.Fetch()
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(items) != 2 || items[0].Name != "Apple TV" || items[1].Name != "Apple Watch" {
+		t.Errorf("Fetch() with watchOS and tvOS = %v", items)
+	}
+	sims, err := fetcher.FetchSimulators()
+	if err != nil {
+		t.Fatalf("FetchSimulators() error = %v", err)
+	}
+	if len(sims) != 2 {
+		t.Errorf("FetchSimulators() with watchOS and tvOS returned %d simulators, want 2", len(sims))
+	}
+}
+
+func TestSimctlFetcher_Fetch_SystemAppCount(t *testing.T) {
+	bundle := filepath.Join(t.TempDir(), "iOS 17.0.simruntime")
+	for _, app := range []string{"MobileSafari.app", "Preferences.app", "Frameworks"} {
+		if err := os.MkdirAll(filepath.Join(bundle, runtimeAppsDir, app), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mockExecutor := &MockCommandExecutor{}
+	fetcher := NewFetcherWithExecutor(mockExecutor)
+	mockExecutor.ExecuteFunc = func(name string, args ...string) ([]byte, error) {
+		switch {
+		case name == "xcrun" && args[1] == "list" && args[2] == "devices":
+			return json.Marshal(SimctlOutput{Devices: map[string][]Simulator{
+				"com.apple.CoreSimulator.SimRuntime.iOS-17-0": {{UDID: "1", Name: "iPhone 15", IsAvailable: true}},
+				"com.apple.CoreSimulator.SimRuntime.iOS-16-4": {{UDID: "2", Name: "iPhone 14", IsAvailable: true}},
+			}})
+		case name == "xcrun" && args[1] == "list" && args[2] == "runtimes":
+			return json.Marshal(RuntimesOutput{Runtimes: []Runtime{
+				{Identifier: "com.apple.CoreSimulator.SimRuntime.iOS-17-0", BundlePath: bundle},
+				{Identifier: "com.apple.CoreSimulator.SimRuntime.iOS-16-4", BundlePath: filepath.Join(bundle, "missing")},
+			}})
+		}
+		return nil, fmt.Errorf("unexpected command: %s %v", name, args)
+	}
+
+	items, err := fetcher.Fetch()
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	want := map[string]int{"1": 2, "2": 0}
+	for _, item := range items {
+		if item.SystemAppCount != want[item.UDID] {
+			t.Errorf("%s SystemAppCount = %d, want %d", item.Name, item.SystemAppCount, want[item.UDID])
+		}
+	}
+}
+
+// benchmarkDevices returns simctl's device list with n simulators spread
+// over 10 iOS runtimes and 5 others, every tenth one booted
+func benchmarkDevices(n int) SimctlOutput {
+	var runtimes []string
+	for v := range 10 {
+		runtimes = append(runtimes, fmt.Sprintf("com.apple.CoreSimulator.SimRuntime.iOS-%d-0", 9+v))
+	}
+	for _, platform := range []string{"watchOS-10-0", "tvOS-17-0", "xrOS-1-0", "watchOS-9-4", "tvOS-16-4"} {
+		runtimes = append(runtimes, "com.apple.CoreSimulator.SimRuntime."+platform)
+	}
+	devices := SimctlOutput{Devices: map[string][]Simulator{}}
+	for i := range n {
+		runtime := runtimes[i%len(runtimes)]
+		state := "Shutdown"
+		if i%10 == 0 {
+			state = "Booted"
+		}
+		devices.Devices[runtime] = append(devices.Devices[runtime], Simulator{
+			UDID:        fmt.Sprintf("UDID-%03d", i),
+			Name:        fmt.Sprintf("Device %03d", i),
+			State:       state,
+			IsAvailable: true,
+		})
+	}
+	return devices
+}
+
+// BenchmarkFetchSimulators measures Fetch for 200 simulators: parsing
+// simctl's JSON, then counting the apps of each simulator. Booted ones
+// are counted from listapps output and shut down ones from their app
+// containers on disk, with apps containers per simulator.
+func BenchmarkFetchSimulators(b *testing.B) {
+	const simulators = 200
+	devicesJSON, err := json.Marshal(benchmarkDevices(simulators))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, apps := range []int{0, 10, 50} {
+		b.Run(fmt.Sprintf("apps=%d", apps), func(b *testing.B) {
+			home := b.TempDir()
+			b.Setenv("HOME", home)
+			var listapps strings.Builder
+			listapps.WriteString("{\n")
+			for a := range apps {
+				fmt.Fprintf(&listapps, "    \"com.example.app%d\" = {\n        CFBundleIdentifier = \"com.example.app%d\";\n    };\n", a, a)
+			}
+			listapps.WriteString("}\n")
+			for i := range simulators {
+				for a := range apps {
+					dir := filepath.Join(home, "Library/Developer/CoreSimulator/Devices", fmt.Sprintf("UDID-%03d", i), "data/Containers/Bundle/Application", fmt.Sprintf("APP-%d", a))
+					if err := os.MkdirAll(dir, 0755); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+
+			fetcher := NewFetcherWithExecutor(&MockCommandExecutor{
+				ExecuteFunc: func(name string, args ...string) ([]byte, error) {
+					switch {
+					case name != "xcrun" || len(args) < 3:
+						// No Xcode version, so no runtime is flagged
+					case args[1] == "list" && args[2] == "devices":
+						return devicesJSON, nil
+					case args[1] == "list" && args[2] == "runtimes":
+						return []byte(`{"runtimes": []}`), nil
+					case args[1] == "listapps" && strings.HasSuffix(args[2], "0"):
+						return []byte(listapps.String()), nil
+					}
+					return nil, fmt.Errorf("unexpected command: %s %v", name, args)
+				},
+			})
+
+			b.ReportAllocs()
+			for b.Loop() {
+				items, err := fetcher.Fetch()
+				if err != nil || len(items) != simulators {
+					b.Fatalf("Fetch() = %d items, %v", len(items), err)
+				}
+			}
+		})
+	}
+}
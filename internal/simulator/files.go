@@ -17,6 +17,26 @@ type FileInfo struct {
 	IsDirectory bool
 	CreatedAt   time.Time
 	ModifiedAt  time.Time
+	Xattrs      map[string]string // Extended attributes; nil until read with ReadXattrs
+
+	// A .plist file in the binary format, which the viewer shows as XML
+	IsBinaryPlist bool
+}
+
+// containerDirAnnotations describes the well-known directories at the
+// root of an app's data container, keyed by directory name.
+var containerDirAnnotations = map[string]string{
+	"Documents":  "User documents (iCloud-synced)",
+	"Library":    "App settings and caches",
+	"tmp":        "Temporary files (may be deleted)",
+	"SystemData": "System-managed",
+}
+
+// ContainerDirAnnotation returns a short description of a directory at
+// the root of a data container, or "" if the name has no well-known
+// meaning.
+func ContainerDirAnnotation(name string) string {
+	return containerDirAnnotations[name]
 }
 
 // GetFilesForContainer returns all files and directories in the app's data container
@@ -61,6 +81,9 @@ func GetFilesForContainer(containerPath string) ([]FileInfo, error) {
 
 		if !entry.IsDir() {
 			fileInfo.Size = info.Size()
+			if strings.EqualFold(filepath.Ext(entry.Name()), ".plist") {
+				fileInfo.IsBinaryPlist = IsBinaryPlist(fileInfo.Path)
+			}
 		} else {
 			// Calculate directory size
 			fileInfo.Size = calculateDirSize(fileInfo.Path)
@@ -80,8 +103,13 @@ func GetFilesForContainer(containerPath string) ([]FileInfo, error) {
 	return files, nil
 }
 
-// FormatFileDate formats a date for display in the file list
-func FormatFileDate(t time.Time) string {
+// FormatFileDate formats a date for display in the file list, as
+// format asks
+func FormatFileDate(t time.Time, format DateFormat) string {
+	if text, ok := formatFixedDate(t, format); ok {
+		return text
+	}
+
 	now := time.Now()
 	diff := now.Sub(t)
 
@@ -196,10 +196,50 @@ func TestFormatFileDateEdgeCases(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := FormatFileDate(tt.time)
+			result := FormatFileDate(tt.time, DateFormatRelative)
 			if !tt.validate(result) {
 				t.Errorf("FormatFileDate() = %v, validation failed", result)
 			}
 		})
 	}
 }
+
+func TestContainerDirAnnotation(t *testing.T) {
+	tests := map[string]string{
+		"Documents":  "User documents (iCloud-synced)",
+		"Library":    "App settings and caches",
+		"tmp":        "Temporary files (may be deleted)",
+		"SystemData": "System-managed",
+		"documents":  "",
+		"Caches":     "",
+	}
+	for name, want := range tests {
+		if got := ContainerDirAnnotation(name); got != want {
+			t.Errorf("ContainerDirAnnotation(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestGetFilesForContainer_BinaryPlist(t *testing.T) {
+	tmpDir := t.TempDir()
+	for name, data := range map[string]string{
+		"binary.plist": "bplist00\x00",
+		"xml.plist":    `<?xml version="1.0"?><plist/>`,
+		"data.bin":     "bplist00\x00",
+	} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := GetFilesForContainer(tmpDir)
+	if err != nil {
+		t.Fatalf("GetFilesForContainer: %v", err)
+	}
+	want := map[string]bool{"binary.plist": true, "xml.plist": false, "data.bin": false}
+	for _, file := range files {
+		if file.IsBinaryPlist != want[file.Name] {
+			t.Errorf("%s: IsBinaryPlist = %v, want %v", file.Name, file.IsBinaryPlist, want[file.Name])
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package simulator
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrNoKeychain is returned when a simulator has no keychain files
+var ErrNoKeychain = errors.New("no keychain found")
+
+// KeychainItem is an item read from a simulator's keychain
+type KeychainItem struct {
+	Class       string // "generic password", "internet password", ...
+	Service     string // Service for generic passwords, server for internet passwords
+	Account     string
+	AccessGroup string
+	Created     time.Time
+}
+
+// keychainClassNames maps the class codes printed by security to names
+var keychainClassNames = map[string]string{
+	"genp":       "generic password",
+	"inet":       "internet password",
+	"cert":       "certificate",
+	"0x0000000F": "public key",
+	"0x00000010": "private key",
+	"0x00000011": "symmetric key",
+	"0x80001000": "certificate",
+}
+
+// keychainAttrPattern matches an attribute line of security dump-keychain:
+// a quoted four-character name or a hex id, the value type, and the value.
+var keychainAttrPattern = regexp.MustCompile(`^\s+("[^"]*"|0x[0-9A-Fa-f]+)\s*<(\w+)>=(.*)$`)
+
+// keychainDir returns the directory holding a simulator's keychains
+func keychainDir(udid string) string {
+	return filepath.Join(os.Getenv("HOME"), "Library", "Developer", "CoreSimulator", "Devices", udid, "data", "Library", "Keychains")
+}
+
+// GetKeychainItems dumps the keychains of the simulator with udid using
+// security dump-keychain and returns the items whose access group names
+// bundleID. It returns ErrNoKeychain if the simulator has no keychain.
+func GetKeychainItems(udid, bundleID string) ([]KeychainItem, error) {
+	entries, err := os.ReadDir(keychainDir(udid))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNoKeychain
+		}
+		return nil, fmt.Errorf("reading keychain directory: %w", err)
+	}
+
+	var items []KeychainItem
+	var lastErr error
+	dumped := false
+	for _, entry := range entries {
+		name := entry.Name()
+		// SQLite journal files are read along with their database
+		if entry.IsDir() || strings.HasSuffix(name, "-wal") || strings.HasSuffix(name, "-shm") {
+			continue
+		}
+		output, err := defaultExecutor.Execute("security", "dump-keychain", filepath.Join(keychainDir(udid), name))
+		if err != nil {
+			lastErr = fmt.Errorf("dumping %s: %w", name, err)
+			continue
+		}
+		dumped = true
+		for _, item := range parseKeychainDump(output) {
+			if bundleID == "" || strings.Contains(item.AccessGroup, bundleID) {
+				items = append(items, item)
+			}
+		}
+	}
+	if !dumped {
+		if lastErr != nil {
+			return nil, lastErr
+		}
+		return nil, ErrNoKeychain
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Class != items[j].Class {
+			return items[i].Class < items[j].Class
+		}
+		if items[i].Service != items[j].Service {
+			return items[i].Service < items[j].Service
+		}
+		return items[i].Account < items[j].Account
+	})
+	return items, nil
+}
+
+// parseKeychainDump parses the output of security dump-keychain. Each
+// item starts with a "class:" line followed by its attributes.
+func parseKeychainDump(output []byte) []KeychainItem {
+	var items []KeychainItem
+	var current *KeychainItem
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if class, ok := strings.CutPrefix(line, "class: "); ok {
+			items = append(items, KeychainItem{Class: keychainClassName(class)})
+			current = &items[len(items)-1]
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		match := keychainAttrPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		value := keychainValue(match[3])
+		switch strings.Trim(match[1], `"`) {
+		case "svce", "srvr":
+			// Internet passwords name a server instead of a service
+			if value != "" {
+				current.Service = value
+			}
+		case "acct":
+			current.Account = value
+		case "agrp":
+			current.AccessGroup = value
+		case "cdat":
+			if t, err := time.Parse("20060102150405Z", value); err == nil {
+				current.Created = t
+			}
+		}
+	}
+	return items
+}
+
+// keychainClassName converts a class as printed by security to a name,
+// keeping unknown classes as printed.
+func keychainClassName(class string) string {
+	class = strings.Trim(strings.TrimSpace(class), `"`)
+	if name, ok := keychainClassNames[class]; ok {
+		return name
+	}
+	return class
+}
+
+// keychainValue extracts the text of an attribute value. Values are
+// printed as <NULL>, a quoted string, or hex optionally followed by the
+// quoted string when the value isn't plain text.
+func keychainValue(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "<NULL>" {
+		return ""
+	}
+	start := strings.Index(raw, `"`)
+	end := strings.LastIndex(raw, `"`)
+	if start < 0 || end <= start {
+		return raw
+	}
+	return strings.TrimSuffix(raw[start+1:end], `\000`)
+}
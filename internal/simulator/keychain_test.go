@@ -0,0 +1,126 @@
+package simulator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const sampleKeychainDump = `keychain: "/sim/data/Library/Keychains/keychain-2-debug.db"
+version: 512
+class: "genp"
+attributes:
+    0x00000007 <blob>="com.example.a.auth"
+    "acct"<blob>="user@example.com"
+    "agrp"<blob>="ABCDE12345.com.example.a"
+    "cdat"<timedate>=0x32303234303330343035303630305A00  "20240304050600Z\000"
+    "svce"<blob>="com.example.a.auth"
+class: "inet"
+attributes:
+    "acct"<blob>="admin"
+    "agrp"<blob>="com.example.a"
+    "cdat"<timedate>=<NULL>
+    "srvr"<blob>="api.example.com"
+    "svce"<blob>=<NULL>
+class: 0x00000010 
+attributes:
+    "agrp"<blob>="com.other.app"
+    "labl"<blob>=0x6B657900  "key\000"
+`
+
+func TestParseKeychainDump(t *testing.T) {
+	items := parseKeychainDump([]byte(sampleKeychainDump))
+	if len(items) != 3 {
+		t.Fatalf("got %d items, want 3", len(items))
+	}
+
+	want := KeychainItem{
+		Class:       "generic password",
+		Service:     "com.example.a.auth",
+		Account:     "user@example.com",
+		AccessGroup: "ABCDE12345.com.example.a",
+		Created:     time.Date(2024, 3, 4, 5, 6, 0, 0, time.UTC),
+	}
+	if items[0] != want {
+		t.Errorf("items[0] = %+v, want %+v", items[0], want)
+	}
+
+	if items[1].Class != "internet password" || items[1].Service != "api.example.com" || !items[1].Created.IsZero() {
+		t.Errorf("items[1] = %+v, want internet password for api.example.com", items[1])
+	}
+	if items[2].Class != "private key" || items[2].AccessGroup != "com.other.app" {
+		t.Errorf("items[2] = %+v, want private key in com.other.app", items[2])
+	}
+}
+
+func TestKeychainValue(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{`<NULL>`, ""},
+		{`"plain"`, "plain"},
+		{`0x6B657900  "key\000"`, "key"},
+		{`0x00000001`, "0x00000001"},
+	}
+	for _, tt := range tests {
+		if got := keychainValue(tt.raw); got != tt.want {
+			t.Errorf("keychainValue(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestGetKeychainItems(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := keychainDir("udid-1")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"keychain-2-debug.db", "keychain-2-debug.db-wal", "keychain-2-debug.db-shm"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fake := &fakeExecutor{responses: map[string]fakeResult{
+		"security dump-keychain " + filepath.Join(dir, "keychain-2-debug.db"): {out: []byte(sampleKeychainDump)},
+	}}
+	withFakeExecutor(t, fake)
+
+	items, err := GetKeychainItems("udid-1", "com.example.a")
+	if err != nil {
+		t.Fatalf("GetKeychainItems() error = %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("got %d items, want the 2 in com.example.a's access groups", len(items))
+	}
+	if items[0].Class != "generic password" || items[1].Class != "internet password" {
+		t.Errorf("items not sorted by class: %+v", items)
+	}
+	if len(fake.calls) != 1 {
+		t.Errorf("security ran %d times, want once (journal files skipped): %v", len(fake.calls), fake.calls)
+	}
+}
+
+func TestGetKeychainItems_Errors(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if _, err := GetKeychainItems("missing", "com.example.a"); !errors.Is(err, ErrNoKeychain) {
+		t.Errorf("missing keychain dir: err = %v, want ErrNoKeychain", err)
+	}
+
+	dir := keychainDir("udid-2")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "keychain-2-debug.db"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{}})
+	if _, err := GetKeychainItems("udid-2", "com.example.a"); err == nil || errors.Is(err, ErrNoKeychain) {
+		t.Errorf("failed dump: err = %v, want the security error", err)
+	}
+}
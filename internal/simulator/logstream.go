@@ -0,0 +1,168 @@
+package simulator
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// LogLevel classifies a unified-log line for display.
+type LogLevel int
+
+const (
+	LogLevelDefault LogLevel = iota
+	LogLevelDebug
+	LogLevelWarning
+	LogLevelError
+)
+
+// logStreamCommand builds the process that produces log output. An
+// empty process streams every process in the simulator, debug messages
+// included. Tests swap it for a command that prints canned lines.
+var logStreamCommand = func(udid, process string) *exec.Cmd {
+	args := []string{"simctl", "spawn", udid, "log", "stream"}
+	if process != "" {
+		args = append(args, "--process", process)
+	} else {
+		args = append(args, "--level", "debug")
+	}
+	return exec.Command("xcrun", append(args, "--style", "compact")...)
+}
+
+// LogStream is a running `log stream` process inside a simulator. Lines
+// are delivered on Lines until the process exits or Stop is called, at
+// which point the channel is closed.
+type LogStream struct {
+	Lines <-chan string
+	cmd   *exec.Cmd
+	done  chan struct{}
+	once  sync.Once
+}
+
+// StartLogStream starts streaming unified-log output for app on the
+// simulator with the given UDID. The simulator must be booted.
+func StartLogStream(udid string, app App) (*LogStream, error) {
+	return startLogStream(logStreamCommand(udid, appProcessName(app)))
+}
+
+// StartSimulatorLogStream starts streaming the unified-log output of
+// every process on the booted simulator with the given UDID
+func StartSimulatorLogStream(udid string) (*LogStream, error) {
+	return startLogStream(logStreamCommand(udid, ""))
+}
+
+// startLogStream starts cmd and delivers its output line by line
+func startLogStream(cmd *exec.Cmd) (*LogStream, error) {
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("creating log pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting log stream: %w", err)
+	}
+
+	lines := make(chan string, 256)
+	stream := &LogStream{Lines: lines, cmd: cmd, done: make(chan struct{})}
+	go func() {
+		defer close(lines)
+		defer func() { _ = cmd.Wait() }()
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-stream.done:
+				// Nobody is reading any more; don't block on a full channel.
+				return
+			}
+		}
+	}()
+
+	return stream, nil
+}
+
+// Stop terminates the log stream process. It is safe to call more than
+// once and on a stream whose process has already exited.
+func (s *LogStream) Stop() {
+	if s == nil || s.cmd == nil || s.cmd.Process == nil {
+		return
+	}
+	s.once.Do(func() {
+		close(s.done)
+		_ = s.cmd.Process.Kill()
+	})
+}
+
+// appProcessName returns the process name `log stream --process`
+// matches for app. The executable inside a .app bundle is named after
+// the bundle, which is not the same as the bundle identifier; the
+// bundle ID is only used when the bundle path is unknown.
+func appProcessName(app App) string {
+	if app.Path != "" {
+		return strings.TrimSuffix(filepath.Base(app.Path), ".app")
+	}
+	return app.BundleID
+}
+
+// ParseLogLevel classifies a line of `log stream --style compact`
+// output. Compact lines carry a type column after the timestamp
+// ("E" error, "F" fault, "Db" debug, ...); apps that log their own
+// level names ("ERROR", "WARNING") are recognized too.
+func ParseLogLevel(line string) LogLevel {
+	fields := strings.Fields(line)
+	if len(fields) >= 3 {
+		switch fields[2] {
+		case "E", "F":
+			return LogLevelError
+		case "Db":
+			return LogLevelDebug
+		}
+	}
+
+	upper := strings.ToUpper(line)
+	switch {
+	case strings.Contains(upper, "ERROR") || strings.Contains(upper, "FAULT"):
+		return LogLevelError
+	case strings.Contains(upper, "WARN"):
+		return LogLevelWarning
+	case strings.Contains(upper, "DEBUG"):
+		return LogLevelDebug
+	}
+	return LogLevelDefault
+}
+
+// maxLogJSONCandidates caps the opening brackets FindLogJSON tries per
+// line, so lines full of brackets that aren't JSON stay cheap
+const maxLogJSONCandidates = 8
+
+// FindLogJSON returns the byte range of the JSON object or array a log
+// line's message carries, such as a logged API response, or -1, -1 when
+// it has none. The JSON runs from an opening bracket to the last
+// matching closing one; brackets before it, like the process column's
+// "[pid:tid]", are skipped.
+func FindLogJSON(line string) (start, end int) {
+	tries := 0
+	for i := 0; i < len(line) && tries < maxLogJSONCandidates; i++ {
+		var closing int
+		switch line[i] {
+		case '{':
+			closing = strings.LastIndexByte(line, '}')
+		case '[':
+			closing = strings.LastIndexByte(line, ']')
+		default:
+			continue
+		}
+		if closing < i {
+			continue
+		}
+		tries++
+		if json.Valid([]byte(line[i : closing+1])) {
+			return i, closing + 1
+		}
+	}
+	return -1, -1
+}
@@ -0,0 +1,161 @@
+package simulator
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+// withLogStreamCommand swaps logStreamCommand for the duration of a test.
+func withLogStreamCommand(t *testing.T, fn func(udid, process string) *exec.Cmd) {
+	t.Helper()
+	prev := logStreamCommand
+	logStreamCommand = fn
+	t.Cleanup(func() { logStreamCommand = prev })
+}
+
+func TestStartLogStream_DeliversLinesThenCloses(t *testing.T) {
+	var gotUDID, gotProcess string
+	withLogStreamCommand(t, func(udid, process string) *exec.Cmd {
+		gotUDID, gotProcess = udid, process
+		return exec.Command("printf", "first\\nsecond\\n")
+	})
+
+	stream, err := StartLogStream("UDID-1", App{BundleID: "com.example.demo", Path: "/x/Demo.app"})
+	if err != nil {
+		t.Fatalf("StartLogStream: %v", err)
+	}
+	if gotUDID != "UDID-1" || gotProcess != "Demo" {
+		t.Errorf("command built with (%q, %q), want (UDID-1, Demo)", gotUDID, gotProcess)
+	}
+
+	var lines []string
+	timeout := time.After(5 * time.Second)
+	for done := false; !done; {
+		select {
+		case line, ok := <-stream.Lines:
+			if !ok {
+				done = true
+				break
+			}
+			lines = append(lines, line)
+		case <-timeout:
+			t.Fatal("timed out waiting for log lines")
+		}
+	}
+	if len(lines) != 2 || lines[0] != "first" || lines[1] != "second" {
+		t.Errorf("lines = %v, want [first second]", lines)
+	}
+}
+
+func TestStartLogStream_StopKillsProcess(t *testing.T) {
+	withLogStreamCommand(t, func(string, string) *exec.Cmd {
+		return exec.Command("sleep", "30")
+	})
+
+	stream, err := StartLogStream("UDID", App{BundleID: "com.example.demo"})
+	if err != nil {
+		t.Fatalf("StartLogStream: %v", err)
+	}
+	stream.Stop()
+	stream.Stop() // second call must be harmless
+
+	select {
+	case _, ok := <-stream.Lines:
+		if ok {
+			t.Error("unexpected line from killed process")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Lines not closed after Stop")
+	}
+}
+
+func TestStartLogStream_StartError(t *testing.T) {
+	withLogStreamCommand(t, func(string, string) *exec.Cmd {
+		return exec.Command("/nonexistent/simtool-test-binary")
+	})
+	if _, err := StartLogStream("UDID", App{}); err == nil {
+		t.Error("StartLogStream with missing binary: want error")
+	}
+}
+
+func TestStartSimulatorLogStream(t *testing.T) {
+	gotProcess := "unset"
+	withLogStreamCommand(t, func(udid, process string) *exec.Cmd {
+		gotProcess = process
+		return exec.Command("printf", "line\\n")
+	})
+
+	stream, err := StartSimulatorLogStream("UDID-1")
+	if err != nil {
+		t.Fatalf("StartSimulatorLogStream: %v", err)
+	}
+	defer stream.Stop()
+	if gotProcess != "" {
+		t.Errorf("process = %q, want every process", gotProcess)
+	}
+}
+
+func TestLogStreamCommand(t *testing.T) {
+	app := logStreamCommand("UDID", "Demo").Args
+	if got := strings.Join(app, " "); got != "xcrun simctl spawn UDID log stream --process Demo --style compact" {
+		t.Errorf("app command = %q", got)
+	}
+	sim := logStreamCommand("UDID", "").Args
+	if got := strings.Join(sim, " "); got != "xcrun simctl spawn UDID log stream --level debug --style compact" {
+		t.Errorf("simulator command = %q", got)
+	}
+}
+
+func TestAppProcessName(t *testing.T) {
+	if got := appProcessName(App{BundleID: "com.a.b", Path: "/apps/My App.app"}); got != "My App" {
+		t.Errorf("with path = %q, want %q", got, "My App")
+	}
+	if got := appProcessName(App{BundleID: "com.a.b"}); got != "com.a.b" {
+		t.Errorf("without path = %q, want bundle ID", got)
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		line string
+		want LogLevel
+	}{
+		{"2024-05-01 10:00:00.123 E  Demo[1:2] boom", LogLevelError},
+		{"2024-05-01 10:00:00.123 F  Demo[1:2] fault", LogLevelError},
+		{"2024-05-01 10:00:00.123 Db Demo[1:2] details", LogLevelDebug},
+		{"2024-05-01 10:00:00.123 Df Demo[1:2] WARNING: low disk", LogLevelWarning},
+		{"2024-05-01 10:00:00.123 Df Demo[1:2] [ERROR] request failed", LogLevelError},
+		{"2024-05-01 10:00:00.123 I  Demo[1:2] DEBUG cache hit", LogLevelDebug},
+		{"2024-05-01 10:00:00.123 Df Demo[1:2] launched", LogLevelDefault},
+		{"", LogLevelDefault},
+	}
+	for _, tt := range tests {
+		if got := ParseLogLevel(tt.line); got != tt.want {
+			t.Errorf("ParseLogLevel(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestFindLogJSON(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{`10:00:00.123 Df Demo[1:2] response {"id": 1, "tags": ["a"]}`, `{"id": 1, "tags": ["a"]}`},
+		{`10:00:00.123 Df Demo[1:2] ids [1, 2, 3]`, `[1, 2, 3]`},
+		{`10:00:00.123 Df Demo[1:2] launched`, ""},
+		{`10:00:00.123 Df Demo[1:2] {not json}`, ""},
+	}
+	for _, tt := range tests {
+		start, end := FindLogJSON(tt.line)
+		got := ""
+		if start >= 0 {
+			got = tt.line[start:end]
+		}
+		if got != tt.want {
+			t.Errorf("FindLogJSON(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package simulator
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// vmStatPageSizePattern matches the page size in vm_stat's header line
+var vmStatPageSizePattern = regexp.MustCompile(`page size of (\d+) bytes`)
+
+// MemoryStats is a memory usage estimate read with vm_stat, in MB.
+// Simulators share the host's kernel, so the figures describe the
+// memory of the Mac the simulator runs on.
+type MemoryStats struct {
+	UsedMB       float64 // Active, wired and compressed memory
+	WiredMB      float64
+	CompressedMB float64
+	FreeMB       float64
+}
+
+// GetMemoryStats runs vm_stat in the booted simulator with udid and
+// estimates its memory usage from the page counts.
+func GetMemoryStats(udid string) (*MemoryStats, error) {
+	output, err := defaultExecutor.Execute("xcrun", "simctl", "spawn", udid, "vm_stat")
+	if err != nil {
+		return nil, fmt.Errorf("failed to run vm_stat: %w", err)
+	}
+	return parseVMStat(output)
+}
+
+// parseVMStat converts vm_stat output to MemoryStats. The header names
+// the page size; each following line is "Pages <kind>: <count>.".
+func parseVMStat(output []byte) (*MemoryStats, error) {
+	pageSize := 0.0
+	pages := make(map[string]float64)
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if match := vmStatPageSizePattern.FindStringSubmatch(line); match != nil {
+			pageSize, _ = strconv.ParseFloat(match[1], 64)
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		count, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(value), "."), 64)
+		if err != nil {
+			continue
+		}
+		pages[strings.TrimSpace(name)] = count
+	}
+	if pageSize == 0 {
+		return nil, errors.New("vm_stat output has no page size")
+	}
+
+	toMB := func(count float64) float64 {
+		return count * pageSize / (1024 * 1024)
+	}
+	stats := &MemoryStats{
+		WiredMB:      toMB(pages["Pages wired down"]),
+		CompressedMB: toMB(pages["Pages occupied by compressor"]),
+		FreeMB:       toMB(pages["Pages free"]),
+	}
+	stats.UsedMB = toMB(pages["Pages active"]) + stats.WiredMB + stats.CompressedMB
+	return stats, nil
+}
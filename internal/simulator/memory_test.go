@@ -0,0 +1,52 @@
+package simulator
+
+import (
+	"errors"
+	"testing"
+)
+
+const sampleVMStat = `Mach Virtual Memory Statistics: (page size of 16384 bytes)
+Pages free:                               64000.
+Pages active:                            256000.
+Pages inactive:                          250000.
+Pages speculative:                         3000.
+Pages throttled:                              0.
+Pages wired down:                        128000.
+Pages purgeable:                           1200.
+"Translation faults":                 912345678.
+Pages copy-on-write:                   12345678.
+Pages occupied by compressor:             64000.
+`
+
+func TestParseVMStat(t *testing.T) {
+	stats, err := parseVMStat([]byte(sampleVMStat))
+	if err != nil {
+		t.Fatalf("parseVMStat() error = %v", err)
+	}
+	// 16 KB pages: 64 pages per MB
+	want := MemoryStats{UsedMB: 7000, WiredMB: 2000, CompressedMB: 1000, FreeMB: 1000}
+	if *stats != want {
+		t.Errorf("parseVMStat() = %+v, want %+v", *stats, want)
+	}
+}
+
+func TestParseVMStat_NoPageSize(t *testing.T) {
+	if _, err := parseVMStat([]byte("Pages free: 10.\n")); err == nil {
+		t.Error("parseVMStat() without a header should fail")
+	}
+}
+
+func TestGetMemoryStats(t *testing.T) {
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{
+		"xcrun simctl spawn UDID vm_stat": {out: []byte(sampleVMStat)},
+		"xcrun simctl spawn OFF vm_stat":  {err: errors.New("exit status 149")},
+	}})
+
+	stats, err := GetMemoryStats("UDID")
+	if err != nil || stats.UsedMB != 7000 {
+		t.Errorf("GetMemoryStats() = %+v, %v", stats, err)
+	}
+	if _, err := GetMemoryStats("OFF"); err == nil {
+		t.Error("GetMemoryStats() should fail when vm_stat can't run")
+	}
+}
@@ -0,0 +1,132 @@
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// notificationSettingsFile is where usernotificationsd keeps an app's
+// notification settings, relative to its data container.
+var notificationSettingsFile = filepath.Join("Library", "Preferences", "com.apple.usernotificationsd.plist")
+
+// NotificationInfo summarizes an app's notification state
+type NotificationInfo struct {
+	AuthorizationStatus string // "authorized", "denied", ...; "unknown" if absent
+	Delivered           int    // Notifications shown and still in Notification Center
+	Scheduled           int    // Pending notification requests
+}
+
+// authorizationStatusNames maps UNAuthorizationStatus raw values to names.
+var authorizationStatusNames = map[int]string{
+	0: "not determined",
+	1: "denied",
+	2: "authorized",
+	3: "provisional",
+	4: "ephemeral",
+}
+
+// GetNotificationInfo reads the notification settings plist in the app's
+// data container. The returned error wraps os.ErrNotExist when the app
+// has never touched the notification APIs.
+func GetNotificationInfo(container string) (*NotificationInfo, error) {
+	container = strings.TrimPrefix(container, "file://")
+	path := filepath.Join(container, notificationSettingsFile)
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("notification settings: %w", err)
+	}
+
+	output, err := convertPlist(path, "json")
+	if err != nil {
+		return nil, fmt.Errorf("converting notification settings: %w", err)
+	}
+	var plist map[string]any
+	if err := json.Unmarshal(output, &plist); err != nil {
+		return nil, fmt.Errorf("parsing notification settings: %w", err)
+	}
+
+	info := &NotificationInfo{AuthorizationStatus: "unknown"}
+	if v, ok := findPlistKey(plist, "authorizationStatus"); ok {
+		info.AuthorizationStatus = authorizationStatusName(v)
+	}
+	if v, ok := findPlistKey(plist, "deliveredNotifications"); ok {
+		info.Delivered = plistCount(v)
+	}
+	for _, key := range []string{"pendingNotificationRequests", "pendingNotifications", "scheduledNotifications"} {
+		if v, ok := findPlistKey(plist, key); ok {
+			info.Scheduled = plistCount(v)
+			break
+		}
+	}
+	return info, nil
+}
+
+// findPlistKey searches v depth-first for a dictionary key matching key
+// case-insensitively. The layout of usernotificationsd's plist differs
+// between iOS versions, so the fields are located by name rather than
+// by path.
+func findPlistKey(v any, key string) (any, bool) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if strings.EqualFold(k, key) {
+				return child, true
+			}
+		}
+		// Descend in key order so the result doesn't depend on map order
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if found, ok := findPlistKey(v[k], key); ok {
+				return found, true
+			}
+		}
+	case []any:
+		for _, child := range v {
+			if found, ok := findPlistKey(child, key); ok {
+				return found, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// authorizationStatusName converts a stored authorization status, which
+// is either a UNAuthorizationStatus number or a string, to a name.
+func authorizationStatusName(v any) string {
+	switch v := v.(type) {
+	case float64:
+		if name, ok := authorizationStatusNames[int(v)]; ok {
+			return name
+		}
+		return fmt.Sprintf("unknown (%d)", int(v))
+	case bool:
+		if v {
+			return "authorized"
+		}
+		return "denied"
+	case string:
+		return v
+	}
+	return "unknown"
+}
+
+// plistCount returns the number of entries in a collection value, or the
+// value itself when it is stored as a count.
+func plistCount(v any) int {
+	switch v := v.(type) {
+	case []any:
+		return len(v)
+	case map[string]any:
+		return len(v)
+	case float64:
+		return int(v)
+	}
+	return 0
+}
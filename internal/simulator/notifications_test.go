@@ -0,0 +1,79 @@
+package simulator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeNotificationSettings creates an (empty) settings plist in a temp
+// container and returns the container and plist paths. The content is
+// supplied through a fakeExecutor since plutil is macOS-only.
+func writeNotificationSettings(t *testing.T) (string, string) {
+	t.Helper()
+	container := t.TempDir()
+	path := filepath.Join(container, notificationSettingsFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("bplist00"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return container, path
+}
+
+func TestGetNotificationInfo(t *testing.T) {
+	container, path := writeNotificationSettings(t)
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{
+		"plutil -convert json -o - " + path: {out: []byte(`{
+			"settings": {"authorizationStatus": 2},
+			"deliveredNotifications": [{"id": "a"}, {"id": "b"}],
+			"pendingNotificationRequests": [{"id": "c"}]
+		}`)},
+	}})
+
+	info, err := GetNotificationInfo("file://" + container)
+	if err != nil {
+		t.Fatalf("GetNotificationInfo() error = %v", err)
+	}
+	want := NotificationInfo{AuthorizationStatus: "authorized", Delivered: 2, Scheduled: 1}
+	if *info != want {
+		t.Errorf("GetNotificationInfo() = %+v, want %+v", *info, want)
+	}
+}
+
+func TestGetNotificationInfo_Missing(t *testing.T) {
+	_, err := GetNotificationInfo(t.TempDir())
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("error = %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestGetNotificationInfo_ConversionError(t *testing.T) {
+	container, _ := writeNotificationSettings(t)
+	withFakeExecutor(t, &fakeExecutor{})
+	if _, err := GetNotificationInfo(container); err == nil {
+		t.Error("expected an error when plutil fails")
+	}
+}
+
+func TestAuthorizationStatusName(t *testing.T) {
+	tests := []struct {
+		in   any
+		want string
+	}{
+		{float64(0), "not determined"},
+		{float64(1), "denied"},
+		{float64(3), "provisional"},
+		{float64(9), "unknown (9)"},
+		{true, "authorized"},
+		{"custom", "custom"},
+		{nil, "unknown"},
+	}
+	for _, tt := range tests {
+		if got := authorizationStatusName(tt.in); got != tt.want {
+			t.Errorf("authorizationStatusName(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
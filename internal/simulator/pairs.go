@@ -0,0 +1,70 @@
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Pairing describes a simulator's pairing with a watch or phone
+// simulator
+type Pairing struct {
+	PairedDevice string // Name of the simulator on the other side of the pair
+	Active       bool   // The pair is the active one for its phone
+	Connected    bool   // The paired simulators are connected
+}
+
+// simctlPairs is the JSON output of simctl listpairs
+type simctlPairs struct {
+	Pairs map[string]struct {
+		Watch simctlPairDevice `json:"watch"`
+		Phone simctlPairDevice `json:"phone"`
+		State string           `json:"state"`
+	} `json:"pairs"`
+}
+
+// simctlPairDevice is one side of a simctl pair
+type simctlPairDevice struct {
+	Name string `json:"name"`
+	UDID string `json:"udid"`
+}
+
+// GetPairings returns the pairing of each paired simulator, by UDID.
+// A phone paired with several watches reports its active pair.
+func GetPairings() (map[string]Pairing, error) {
+	output, err := defaultExecutor.Execute("xcrun", "simctl", "list", "pairs", "--json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pairs: %w", err)
+	}
+	return parsePairings(output)
+}
+
+// parsePairings parses simctl's pair list. Pair states read like
+// "(active, connected)" or "(inactive, disconnected)".
+func parsePairings(output []byte) (map[string]Pairing, error) {
+	var list simctlPairs
+	if err := json.Unmarshal(output, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse pairs: %w", err)
+	}
+
+	pairings := make(map[string]Pairing)
+	for _, pair := range list.Pairs {
+		state := strings.Trim(pair.State, "()")
+		var active, connected bool
+		for field := range strings.SplitSeq(state, ",") {
+			switch strings.TrimSpace(field) {
+			case "active":
+				active = true
+			case "connected":
+				connected = true
+			}
+		}
+
+		pairings[pair.Watch.UDID] = Pairing{PairedDevice: pair.Phone.Name, Active: active, Connected: connected}
+		// Keep a phone's active pair over its other watches
+		if existing, ok := pairings[pair.Phone.UDID]; !ok || active && !existing.Active {
+			pairings[pair.Phone.UDID] = Pairing{PairedDevice: pair.Watch.Name, Active: active, Connected: connected}
+		}
+	}
+	return pairings, nil
+}
@@ -0,0 +1,63 @@
+package simulator
+
+import "testing"
+
+const pairsJSON = `{
+  "pairs" : {
+    "PAIR-1" : {
+      "watch" : {"name" : "Apple Watch Series 9 (45mm)", "udid" : "WATCH-1", "state" : "Booted"},
+      "phone" : {"name" : "iPhone 15 Pro", "udid" : "PHONE-1", "state" : "Booted"},
+      "state" : "(active, connected)"
+    },
+    "PAIR-2" : {
+      "watch" : {"name" : "Apple Watch SE (40mm)", "udid" : "WATCH-2", "state" : "Shutdown"},
+      "phone" : {"name" : "iPhone 15 Pro", "udid" : "PHONE-1", "state" : "Booted"},
+      "state" : "(inactive, disconnected)"
+    },
+    "PAIR-3" : {
+      "watch" : {"name" : "Apple Watch Ultra 2 (49mm)", "udid" : "WATCH-3", "state" : "Shutdown"},
+      "phone" : {"name" : "iPhone 16", "udid" : "PHONE-2", "state" : "Shutdown"},
+      "state" : "(active, disconnected)"
+    }
+  }
+}`
+
+func TestParsePairings(t *testing.T) {
+	pairings, err := parsePairings([]byte(pairsJSON))
+	if err != nil {
+		t.Fatalf("parsePairings() error = %v", err)
+	}
+
+	tests := []struct {
+		udid string
+		want Pairing
+	}{
+		{"WATCH-1", Pairing{PairedDevice: "iPhone 15 Pro", Active: true, Connected: true}},
+		{"WATCH-2", Pairing{PairedDevice: "iPhone 15 Pro"}},
+		{"PHONE-1", Pairing{PairedDevice: "Apple Watch Series 9 (45mm)", Active: true, Connected: true}},
+		{"PHONE-2", Pairing{PairedDevice: "Apple Watch Ultra 2 (49mm)", Active: true}},
+	}
+	for _, tt := range tests {
+		if got := pairings[tt.udid]; got != tt.want {
+			t.Errorf("pairings[%s] = %+v, want %+v", tt.udid, got, tt.want)
+		}
+	}
+	if len(pairings) != 5 {
+		t.Errorf("len(pairings) = %d, want 5", len(pairings))
+	}
+
+	if _, err := parsePairings([]byte("not json")); err == nil {
+		t.Error("parsePairings() should fail on invalid JSON")
+	}
+}
+
+func TestGetPairings(t *testing.T) {
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{
+		"xcrun simctl list pairs --json": {out: []byte(pairsJSON)},
+	}})
+
+	pairings, err := GetPairings()
+	if err != nil || !pairings["WATCH-1"].Connected {
+		t.Errorf("GetPairings() = %v, %v", pairings, err)
+	}
+}
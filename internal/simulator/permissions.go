@@ -0,0 +1,137 @@
+package simulator
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Permission statuses reported by GetAppPermissions
+const (
+	PermissionGranted = "granted"
+	PermissionDenied  = "denied"
+	PermissionLimited = "limited"
+	PermissionNotSet  = "not set"
+	PermissionUnknown = "unknown" // Not recorded where simtool can read it
+)
+
+// Permission is an app's access to one privacy-protected service
+type Permission struct {
+	Service string // simctl privacy service name, e.g. "camera"
+	Label   string
+	Status  string
+}
+
+// privacyService is a service simctl privacy can grant, with the TCC
+// service recording it; location isn't kept in TCC
+type privacyService struct {
+	name, label, tcc string
+}
+
+// privacyServices lists the services in the order they are shown
+var privacyServices = []privacyService{
+	{"camera", "Camera", "kTCCServiceCamera"},
+	{"microphone", "Microphone", "kTCCServiceMicrophone"},
+	{"location", "Location (while in use)", ""},
+	{"location-always", "Location (always)", ""},
+	{"photos", "Photos", "kTCCServicePhotos"},
+	{"photos-add", "Photos (add only)", "kTCCServicePhotosAdd"},
+	{"contacts", "Contacts", "kTCCServiceAddressBook"},
+	{"calendar", "Calendar", "kTCCServiceCalendar"},
+	{"reminders", "Reminders", "kTCCServiceReminders"},
+	{"media-library", "Media Library", "kTCCServiceMediaLibrary"},
+	{"motion", "Motion & Fitness", "kTCCServiceMotion"},
+	{"siri", "Siri", "kTCCServiceSiri"},
+}
+
+// tccDatabasePath returns the privacy database of simulator udid
+func tccDatabasePath(udid string) string {
+	return filepath.Join(os.Getenv("HOME"), "Library/Developer/CoreSimulator/Devices", udid, "data/Library/TCC/TCC.db")
+}
+
+// GetAppPermissions returns the app's status for each service simctl
+// privacy manages, read from the simulator's TCC database. simctl
+// privacy can change permissions but not list them. Services the app
+// has no record for are PermissionNotSet; location permissions live
+// outside TCC and are PermissionUnknown.
+func GetAppPermissions(udid, bundleID string) ([]Permission, error) {
+	statuses, err := readTCCStatuses(tccDatabasePath(udid), bundleID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make([]Permission, 0, len(privacyServices))
+	for _, service := range privacyServices {
+		status := PermissionUnknown
+		if service.tcc != "" {
+			status = PermissionNotSet
+			if s, ok := statuses[service.tcc]; ok {
+				status = s
+			}
+		}
+		permissions = append(permissions, Permission{Service: service.name, Label: service.label, Status: status})
+	}
+	return permissions, nil
+}
+
+// readTCCStatuses returns the status of each TCC service the app with
+// bundleID has a record for. A simulator that has never recorded a
+// permission has no database, which means nothing is set.
+func readTCCStatuses(path, bundleID string) (map[string]string, error) {
+	statuses := make(map[string]string)
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return statuses, nil
+	}
+
+	db, err := openReadOnlyDB(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = db.Close() }()
+
+	// iOS 14 added auth_value, replacing the allowed flag
+	query := "SELECT service, auth_value FROM access WHERE client = ?"
+	rows, err := db.Query(query, bundleID)
+	if err != nil && strings.Contains(err.Error(), "no such column") {
+		rows, err = db.Query("SELECT service, allowed * 2 FROM access WHERE client = ?", bundleID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading privacy database: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var service string
+		var value sql.NullInt64
+		if err := rows.Scan(&service, &value); err != nil {
+			return nil, fmt.Errorf("reading privacy database: %w", err)
+		}
+		statuses[service] = tccStatusName(value.Int64)
+	}
+	return statuses, rows.Err()
+}
+
+// tccStatusName names a TCC auth_value
+func tccStatusName(value int64) string {
+	switch value {
+	case 2:
+		return PermissionGranted
+	case 3:
+		return PermissionLimited
+	default:
+		return PermissionDenied
+	}
+}
+
+// SetAppPermission grants or revokes the app's access to service with
+// simctl privacy. action is "grant", "revoke" or "reset".
+func SetAppPermission(udid, bundleID, service, action string) error {
+	output, err := defaultExecutor.Execute("xcrun", "simctl", "privacy", udid, action, service, bundleID)
+	if err != nil {
+		return fmt.Errorf("failed to %s %s: %w (output: %s)", action, service, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
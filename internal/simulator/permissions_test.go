@@ -0,0 +1,89 @@
+package simulator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetAppPermissions(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := tccDatabasePath("udid-1")
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	createTestDB(t, path,
+		"CREATE TABLE access (service TEXT, client TEXT, client_type INTEGER, auth_value INTEGER)",
+		"INSERT INTO access VALUES ('kTCCServiceCamera', 'com.example.app', 0, 2)",
+		"INSERT INTO access VALUES ('kTCCServiceMicrophone', 'com.example.app', 0, 0)",
+		"INSERT INTO access VALUES ('kTCCServicePhotos', 'com.example.app', 0, 3)",
+		"INSERT INTO access VALUES ('kTCCServiceCamera', 'com.example.other', 0, 0)",
+	)
+
+	permissions, err := GetAppPermissions("udid-1", "com.example.app")
+	if err != nil {
+		t.Fatalf("GetAppPermissions() error = %v", err)
+	}
+	want := map[string]string{
+		"camera":     PermissionGranted,
+		"microphone": PermissionDenied,
+		"photos":     PermissionLimited,
+		"contacts":   PermissionNotSet,
+		"location":   PermissionUnknown,
+	}
+	for _, p := range permissions {
+		if status, ok := want[p.Service]; ok && p.Status != status {
+			t.Errorf("%s status = %q, want %q", p.Service, p.Status, status)
+		}
+	}
+	if len(permissions) != len(privacyServices) {
+		t.Errorf("len(permissions) = %d, want %d", len(permissions), len(privacyServices))
+	}
+}
+
+func TestGetAppPermissions_LegacySchema(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := tccDatabasePath("udid-1")
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	createTestDB(t, path,
+		"CREATE TABLE access (service TEXT, client TEXT, client_type INTEGER, allowed INTEGER)",
+		"INSERT INTO access VALUES ('kTCCServiceAddressBook', 'com.example.app', 0, 1)",
+	)
+
+	permissions, err := GetAppPermissions("udid-1", "com.example.app")
+	if err != nil {
+		t.Fatalf("GetAppPermissions() error = %v", err)
+	}
+	for _, p := range permissions {
+		if p.Service == "contacts" && p.Status != PermissionGranted {
+			t.Errorf("contacts status = %q, want granted", p.Status)
+		}
+	}
+}
+
+func TestGetAppPermissions_NoDatabase(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	permissions, err := GetAppPermissions("udid-1", "com.example.app")
+	if err != nil {
+		t.Fatalf("GetAppPermissions() error = %v", err)
+	}
+	if permissions[0].Status != PermissionNotSet {
+		t.Errorf("status without a database = %q, want not set", permissions[0].Status)
+	}
+}
+
+func TestSetAppPermission(t *testing.T) {
+	fake := &fakeExecutor{responses: map[string]fakeResult{
+		"xcrun simctl privacy udid-1 grant camera com.example.app": {},
+	}}
+	withFakeExecutor(t, fake)
+
+	if err := SetAppPermission("udid-1", "com.example.app", "camera", "grant"); err != nil {
+		t.Errorf("SetAppPermission() error = %v", err)
+	}
+	if err := SetAppPermission("udid-1", "com.example.app", "camera", "revoke"); err == nil {
+		t.Error("SetAppPermission() should report simctl failures")
+	}
+}
@@ -0,0 +1,94 @@
+package simulator
+
+import "strings"
+
+// RuntimeFilter is a set of simulator platforms, one bit per platform.
+// An Item's Platform holds exactly one bit, or none when the runtime's
+// platform is unknown.
+type RuntimeFilter uint8
+
+// Platforms simctl has runtimes for
+const (
+	RuntimeIOS RuntimeFilter = 1 << iota
+	RuntimeWatchOS
+	RuntimeTVOS
+	RuntimeVisionOS
+
+	// RuntimeAll includes every platform
+	RuntimeAll = RuntimeIOS | RuntimeWatchOS | RuntimeTVOS | RuntimeVisionOS
+)
+
+// Platforms lists the single platforms in display order
+var Platforms = []RuntimeFilter{RuntimeIOS, RuntimeWatchOS, RuntimeTVOS, RuntimeVisionOS}
+
+// platformNames maps each platform to its name in simctl runtime
+// identifiers, e.g. com.apple.CoreSimulator.SimRuntime.watchOS-10-0
+var platformNames = map[RuntimeFilter]string{
+	RuntimeIOS:      "iOS",
+	RuntimeWatchOS:  "watchOS",
+	RuntimeTVOS:     "tvOS",
+	RuntimeVisionOS: "visionOS",
+}
+
+// platformIcons prefixes runtimes in the simulator list
+var platformIcons = map[RuntimeFilter]string{
+	RuntimeIOS:      "🍎",
+	RuntimeWatchOS:  "⌚",
+	RuntimeTVOS:     "📺",
+	RuntimeVisionOS: "🥽",
+}
+
+// String returns the platform's name, e.g. "watchOS", or "" for sets of
+// several platforms
+func (f RuntimeFilter) String() string {
+	return platformNames[f]
+}
+
+// Icon returns the platform's icon, or "" for sets of several platforms
+func (f RuntimeFilter) Icon() string {
+	return platformIcons[f]
+}
+
+// Includes reports whether platform is in the set. Simulators of an
+// unknown platform are only hidden when the set is empty.
+func (f RuntimeFilter) Includes(platform RuntimeFilter) bool {
+	if platform == 0 {
+		return f != 0
+	}
+	return f&platform != 0
+}
+
+// ParseRuntimeFilter builds a filter from platform names such as "iOS"
+// and "tvOS". Unknown names are skipped; no names at all include every
+// platform.
+func ParseRuntimeFilter(names []string) RuntimeFilter {
+	if len(names) == 0 {
+		return RuntimeAll
+	}
+	var filter RuntimeFilter
+	for _, name := range names {
+		for _, platform := range Platforms {
+			if name == platformNames[platform] {
+				filter |= platform
+			}
+		}
+	}
+	return filter
+}
+
+// PlatformFromRuntime returns the platform of a simctl runtime
+// identifier, or 0 when it isn't one of Platforms
+func PlatformFromRuntime(runtime string) RuntimeFilter {
+	name := strings.TrimPrefix(runtime, "com.apple.CoreSimulator.SimRuntime.")
+	name, _, _ = strings.Cut(name, "-")
+	if name == "xrOS" {
+		// visionOS runtimes kept their pre-release name
+		return RuntimeVisionOS
+	}
+	for _, platform := range Platforms {
+		if name == platformNames[platform] {
+			return platform
+		}
+	}
+	return 0
+}
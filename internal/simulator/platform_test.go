@@ -0,0 +1,57 @@
+package simulator
+
+import "testing"
+
+func TestPlatformFromRuntime(t *testing.T) {
+	tests := []struct {
+		runtime string
+		want    RuntimeFilter
+	}{
+		{"com.apple.CoreSimulator.SimRuntime.iOS-17-0", RuntimeIOS},
+		{"com.apple.CoreSimulator.SimRuntime.watchOS-10-0", RuntimeWatchOS},
+		{"com.apple.CoreSimulator.SimRuntime.tvOS-17-0", RuntimeTVOS},
+		{"com.apple.CoreSimulator.SimRuntime.xrOS-1-0", RuntimeVisionOS},
+		{"com.apple.CoreSimulator.SimRuntime.visionOS-2-0", RuntimeVisionOS},
+		{"com.apple.CoreSimulator.SimRuntime.macOS-14-0", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.runtime, func(t *testing.T) {
+			if got := PlatformFromRuntime(tt.runtime); got != tt.want {
+				t.Errorf("PlatformFromRuntime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRuntimeFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		want  RuntimeFilter
+	}{
+		{"unset", nil, RuntimeAll},
+		{"one", []string{"tvOS"}, RuntimeTVOS},
+		{"several", []string{"iOS", "watchOS"}, RuntimeIOS | RuntimeWatchOS},
+		{"unknown skipped", []string{"macOS", "visionOS"}, RuntimeVisionOS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseRuntimeFilter(tt.names); got != tt.want {
+				t.Errorf("ParseRuntimeFilter(%v) = %v, want %v", tt.names, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuntimeFilterIncludes(t *testing.T) {
+	filter := RuntimeIOS | RuntimeTVOS
+	if !filter.Includes(RuntimeTVOS) || filter.Includes(RuntimeWatchOS) {
+		t.Errorf("%b.Includes() is wrong", filter)
+	}
+	if !filter.Includes(0) || RuntimeFilter(0).Includes(0) {
+		t.Error("unknown platforms should only be hidden by an empty filter")
+	}
+	if RuntimeWatchOS.String() != "watchOS" || RuntimeAll.String() != "" {
+		t.Errorf("String() = %q, %q", RuntimeWatchOS, RuntimeAll)
+	}
+}
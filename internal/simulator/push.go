@@ -0,0 +1,49 @@
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// PushPayloadTemplate is a minimal APNs payload showing an alert with a
+// sound, a starting point for composing test notifications
+const PushPayloadTemplate = `{
+  "aps": {
+    "alert": {
+      "title": "Test notification",
+      "body": "Sent from simtool"
+    },
+    "sound": "default"
+  }
+}`
+
+// SendPushNotification delivers payload, an APNs JSON payload, to the
+// app with bundleID on the booted simulator with udid. simctl reads the
+// payload from a file, so it is written to a temporary one first.
+func SendPushNotification(udid, bundleID, payload string) error {
+	var object map[string]any
+	if err := json.Unmarshal([]byte(payload), &object); err != nil {
+		return fmt.Errorf("invalid payload: %w", err)
+	}
+
+	file, err := os.CreateTemp("", "simtool-push-*.apns")
+	if err != nil {
+		return fmt.Errorf("failed to write payload: %w", err)
+	}
+	defer func() { _ = os.Remove(file.Name()) }()
+	if _, err := file.WriteString(payload); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write payload: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write payload: %w", err)
+	}
+
+	output, err := defaultExecutor.Execute("xcrun", "simctl", "push", udid, bundleID, file.Name())
+	if err != nil {
+		return fmt.Errorf("failed to send push notification: %w (output: %s)", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
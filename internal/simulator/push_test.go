@@ -0,0 +1,65 @@
+package simulator
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+// payloadRecorder is a CommandExecutor that keeps the payload file simctl
+// push is given, which is removed once SendPushNotification returns
+type payloadRecorder struct {
+	args    []string
+	payload string
+	err     error
+}
+
+func (r *payloadRecorder) Execute(name string, args ...string) ([]byte, error) {
+	r.args = append([]string{name}, args...)
+	data, _ := os.ReadFile(args[len(args)-1])
+	r.payload = string(data)
+	if r.err != nil {
+		return []byte("Invalid device: udid-1"), r.err
+	}
+	return nil, nil
+}
+
+func (r *payloadRecorder) Run(name string, args ...string) error {
+	_, err := r.Execute(name, args...)
+	return err
+}
+
+func TestSendPushNotification(t *testing.T) {
+	recorder := &payloadRecorder{}
+	original := defaultExecutor
+	defaultExecutor = recorder
+	t.Cleanup(func() { defaultExecutor = original })
+
+	if err := SendPushNotification("udid-1", "com.example.app", PushPayloadTemplate); err != nil {
+		t.Fatalf("SendPushNotification() error = %v", err)
+	}
+	if got := strings.Join(recorder.args[:5], " "); got != "xcrun simctl push udid-1 com.example.app" {
+		t.Errorf("command = %q", got)
+	}
+	if recorder.payload != PushPayloadTemplate {
+		t.Errorf("payload file = %q, want the template", recorder.payload)
+	}
+	if _, err := os.Stat(recorder.args[5]); !os.IsNotExist(err) {
+		t.Error("the payload file should be removed")
+	}
+
+	recorder.err = errors.New("exit status 1")
+	if err := SendPushNotification("udid-1", "com.example.app", PushPayloadTemplate); err == nil || !strings.Contains(err.Error(), "Invalid device") {
+		t.Errorf("SendPushNotification() error = %v, want simctl's output", err)
+	}
+}
+
+func TestSendPushNotification_InvalidPayload(t *testing.T) {
+	withFakeExecutor(t, &fakeExecutor{})
+	for _, payload := range []string{"", `{"aps": `, `["aps"]`} {
+		if err := SendPushNotification("udid-1", "com.example.app", payload); err == nil || !strings.Contains(err.Error(), "invalid payload") {
+			t.Errorf("SendPushNotification(%q) error = %v, want invalid payload", payload, err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// QueryMaxRows caps how many rows of a query's results are read
+const QueryMaxRows = 1000
+
+// QueryResult holds the rows returned by a SQL query
+type QueryResult struct {
+	Columns []string         // Result column names, in query order
+	Rows    []map[string]any // Rows keyed by Columns
+	// The query returned more rows than were read
+	Truncated bool
+}
+
+// RunQuery runs a SQL query against the database at dbPath and reads up
+// to limit rows of its results. The database is opened read-only, so
+// statements that write fail.
+func RunQuery(dbPath, query string, limit int) (*QueryResult, error) {
+	if isRealmFile(dbPath) {
+		return nil, errors.New("SQL queries are not supported for Realm databases")
+	}
+	if strings.TrimSpace(query) == "" {
+		return nil, errors.New("query is empty")
+	}
+
+	db, err := openReadOnlyDB(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = db.Close() }()
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, withHint(err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	result := &QueryResult{Columns: uniqueColumnNames(columns)}
+	for rows.Next() {
+		if len(result.Rows) == limit {
+			result.Truncated = true
+			break
+		}
+		row, err := scanRow(rows, result.Columns)
+		if err != nil {
+			return nil, err
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, withHint(err)
+	}
+	return result, nil
+}
+
+// uniqueColumnNames numbers repeated column names, as in a join
+// selecting id from two tables, so each keeps its own value:
+// id, id (2)
+func uniqueColumnNames(columns []string) []string {
+	seen := make(map[string]bool, len(columns))
+	unique := make([]string, len(columns))
+	for i, name := range columns {
+		candidate := name
+		for n := 2; seen[candidate]; n++ {
+			candidate = fmt.Sprintf("%s (%d)", name, n)
+		}
+		seen[candidate] = true
+		unique[i] = candidate
+	}
+	return unique
+}
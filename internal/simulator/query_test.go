@@ -0,0 +1,102 @@
+package simulator
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRunQuery(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "app.sqlite")
+	createTestDB(t, dbPath,
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)",
+		"CREATE TABLE posts (id INTEGER PRIMARY KEY, user_id INTEGER, title TEXT)",
+		"INSERT INTO users (name) VALUES ('alice'), ('bob'), ('carol')",
+		"INSERT INTO posts (user_id, title) VALUES (2, 'hello')",
+	)
+
+	result, err := RunQuery(dbPath, "SELECT name, id FROM users\nWHERE id > 1\nORDER BY id", QueryMaxRows)
+	if err != nil {
+		t.Fatalf("RunQuery: %v", err)
+	}
+	if want := []string{"name", "id"}; !reflect.DeepEqual(result.Columns, want) {
+		t.Errorf("Columns = %v, want %v", result.Columns, want)
+	}
+	want := []map[string]any{
+		{"name": "bob", "id": int64(2)},
+		{"name": "carol", "id": int64(3)},
+	}
+	if !reflect.DeepEqual(result.Rows, want) {
+		t.Errorf("Rows = %v, want %v", result.Rows, want)
+	}
+	if result.Truncated {
+		t.Error("Truncated = true for a query within the limit")
+	}
+
+	t.Run("limit", func(t *testing.T) {
+		result, err := RunQuery(dbPath, "SELECT * FROM users", 2)
+		if err != nil {
+			t.Fatalf("RunQuery: %v", err)
+		}
+		if len(result.Rows) != 2 || !result.Truncated {
+			t.Errorf("read %d rows, truncated %v; want 2 rows, truncated", len(result.Rows), result.Truncated)
+		}
+	})
+
+	t.Run("repeated column names", func(t *testing.T) {
+		result, err := RunQuery(dbPath, "SELECT users.id, posts.id FROM users JOIN posts ON posts.user_id = users.id", QueryMaxRows)
+		if err != nil {
+			t.Fatalf("RunQuery: %v", err)
+		}
+		if want := []string{"id", "id (2)"}; !reflect.DeepEqual(result.Columns, want) {
+			t.Errorf("Columns = %v, want %v", result.Columns, want)
+		}
+		if want := []map[string]any{{"id": int64(2), "id (2)": int64(1)}}; !reflect.DeepEqual(result.Rows, want) {
+			t.Errorf("Rows = %v, want %v", result.Rows, want)
+		}
+	})
+
+	t.Run("no rows", func(t *testing.T) {
+		result, err := RunQuery(dbPath, "SELECT * FROM users WHERE 0", QueryMaxRows)
+		if err != nil {
+			t.Fatalf("RunQuery: %v", err)
+		}
+		if len(result.Rows) != 0 || len(result.Columns) != 2 {
+			t.Errorf("got %d rows of %v, want the columns and no rows", len(result.Rows), result.Columns)
+		}
+	})
+}
+
+func TestRunQuery_Errors(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "app.sqlite")
+	createTestDB(t, dbPath, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)")
+
+	tests := []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{"empty", " \n ", "query is empty"},
+		{"syntax", "SELEC 1", "syntax error"},
+		{"write", "INSERT INTO users (name) VALUES ('mallory')", "readonly"},
+		{"missing table", "SELECT * FROM nope", "no such table"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := RunQuery(dbPath, tt.query, QueryMaxRows)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("RunQuery(%q) error = %v, want one containing %q", tt.query, err, tt.wantErr)
+			}
+		})
+	}
+
+	// The write was refused, not just reported
+	result, err := RunQuery(dbPath, "SELECT COUNT(*) AS n FROM users", QueryMaxRows)
+	if err != nil {
+		t.Fatalf("RunQuery: %v", err)
+	}
+	if n := result.Rows[0]["n"]; n != int64(0) {
+		t.Errorf("users has %v rows after a read-only insert, want 0", n)
+	}
+}
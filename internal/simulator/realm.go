@@ -0,0 +1,204 @@
+package simulator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// A Realm file starts with a 24-byte header: two 8-byte top refs, the
+// "T-DB" mnemonic, two file format bytes, a reserved byte and flags.
+const (
+	realmMnemonic       = "T-DB"
+	realmMnemonicOffset = 16
+	realmHeaderSize     = 24
+)
+
+// RealmToolingNotice is shown when a Realm file falls back to the hex view
+const RealmToolingNotice = "Realm database — install realm-cli for full inspection"
+
+// ErrNoRealmTooling is returned when realm-cli isn't available to read a
+// Realm database
+var ErrNoRealmTooling = errors.New("realm-cli not available")
+
+// realmObjectSchema is one object type as realm-cli prints it: the
+// schema in the form Realm's JavaScript SDK reports it, along with the
+// objects stored for the type.
+type realmObjectSchema struct {
+	Name       string                   `json:"name"`
+	PrimaryKey string                   `json:"primaryKey"`
+	Properties map[string]realmProperty `json:"properties"`
+	Objects    []map[string]any         `json:"objects"`
+}
+
+// realmProperty is a property of a realmObjectSchema
+type realmProperty struct {
+	Type       string `json:"type"`
+	ObjectType string `json:"objectType"`
+	Optional   bool   `json:"optional"`
+}
+
+// isRealmFile reports whether path has the .realm extension
+func isRealmFile(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".realm")
+}
+
+// readRealmInfo reads the object types of a Realm database as tables.
+// The file header is checked here; the schema and objects are read with
+// realm-cli through npx, since Realm's storage format has no Go reader.
+// It returns ErrNoRealmTooling when realm-cli can't be run so callers
+// can fall back to the hex view.
+func readRealmInfo(path string) (*DatabaseInfo, error) {
+	version, err := readRealmHeader(path)
+	if err != nil {
+		return &DatabaseInfo{Error: "Not a valid Realm database: " + err.Error()}, nil
+	}
+
+	output, err := defaultExecutor.Execute("npx", "--no-install", "realm-cli", "schema", "--json", path)
+	if err != nil {
+		return nil, ErrNoRealmTooling
+	}
+	info, err := parseRealmSchema(output)
+	if err != nil {
+		return &DatabaseInfo{Error: err.Error()}, nil
+	}
+	info.Version = version
+	if stat, err := os.Stat(path); err == nil {
+		info.FileSize = stat.Size()
+	}
+	return info, nil
+}
+
+// readRealmHeader checks the Realm mnemonic at the start of path and
+// returns the file format version recorded in the header. The header
+// keeps two format bytes; the low bit of its flags byte selects the one
+// in use.
+func readRealmHeader(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = file.Close() }()
+
+	header := make([]byte, realmHeaderSize)
+	if _, err := io.ReadFull(file, header); err != nil {
+		return "", err
+	}
+	if string(header[realmMnemonicOffset:realmMnemonicOffset+len(realmMnemonic)]) != realmMnemonic {
+		return "", errors.New("missing Realm file header")
+	}
+	format := header[20]
+	if header[23]&1 != 0 {
+		format = header[21]
+	}
+	return fmt.Sprintf("file format %d", format), nil
+}
+
+// parseRealmSchema converts realm-cli's JSON schema output to a
+// DatabaseInfo with a table per object type
+func parseRealmSchema(output []byte) (*DatabaseInfo, error) {
+	var schemas []realmObjectSchema
+	if err := json.Unmarshal(output, &schemas); err != nil {
+		return nil, fmt.Errorf("reading realm-cli output: %w", err)
+	}
+
+	info := &DatabaseInfo{Format: "Realm"}
+	for _, schema := range schemas {
+		table := TableInfo{
+			Name:     schema.Name,
+			RowCount: int64(len(schema.Objects)),
+			Sample:   schema.Objects,
+		}
+		names := make([]string, 0, len(schema.Properties))
+		for name := range schema.Properties {
+			names = append(names, name)
+		}
+		// Keep the primary key first, as SQLite tables usually list it
+		sort.Slice(names, func(i, j int) bool {
+			if (names[i] == schema.PrimaryKey) != (names[j] == schema.PrimaryKey) {
+				return names[i] == schema.PrimaryKey
+			}
+			return names[i] < names[j]
+		})
+		for _, name := range names {
+			prop := schema.Properties[name]
+			typ := prop.Type
+			if prop.ObjectType != "" {
+				typ += "<" + prop.ObjectType + ">"
+			}
+			table.Columns = append(table.Columns, ColumnInfo{
+				Name:    name,
+				Type:    typ,
+				NotNull: !prop.Optional,
+				PK:      name == schema.PrimaryKey,
+			})
+		}
+		info.Tables = append(info.Tables, table)
+	}
+	sort.Slice(info.Tables, func(i, j int) bool {
+		return info.Tables[i].Name < info.Tables[j].Name
+	})
+	info.TableCount = len(info.Tables)
+	return info, nil
+}
+
+// readRealmTableData returns a page of the objects of one object type,
+// sorted by sortColumn unless it is empty
+func readRealmTableData(path, tableName, sortColumn string, ascending bool, offset, limit int) ([]map[string]any, error) {
+	info, err := readRealmInfo(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Error != "" {
+		return nil, errors.New(info.Error)
+	}
+	for _, table := range info.Tables {
+		if table.Name != tableName {
+			continue
+		}
+		if offset >= len(table.Sample) {
+			return []map[string]any{}, nil
+		}
+		if sortColumn != "" {
+			sortRealmObjects(table.Sample, sortColumn, ascending)
+		}
+		end := min(offset+limit, len(table.Sample))
+		return table.Sample[offset:end], nil
+	}
+	return nil, fmt.Errorf("no object type %q", tableName)
+}
+
+// sortRealmObjects sorts objects by the property column the way SQLite
+// orders values: nulls first, then numbers, then everything else as text
+func sortRealmObjects(objects []map[string]any, column string, ascending bool) {
+	rank := func(v any) int {
+		switch v.(type) {
+		case nil:
+			return 0
+		case float64:
+			return 1
+		default:
+			return 2
+		}
+	}
+	less := func(a, b any) bool {
+		if rank(a) != rank(b) {
+			return rank(a) < rank(b)
+		}
+		if x, ok := a.(float64); ok {
+			return x < b.(float64)
+		}
+		return fmt.Sprint(a) < fmt.Sprint(b)
+	}
+	sort.SliceStable(objects, func(i, j int) bool {
+		if ascending {
+			return less(objects[i][column], objects[j][column])
+		}
+		return less(objects[j][column], objects[i][column])
+	})
+}
@@ -0,0 +1,154 @@
+package simulator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeRealmFile writes a minimal file with a Realm header using file
+// format 23 and returns its path.
+func writeRealmFile(t *testing.T) string {
+	t.Helper()
+	header := make([]byte, realmHeaderSize+8)
+	copy(header[realmMnemonicOffset:], realmMnemonic)
+	header[20] = 23
+	path := filepath.Join(t.TempDir(), "default.realm")
+	if err := os.WriteFile(path, header, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const sampleRealmSchema = `[
+  {"name": "Owner", "primaryKey": "id", "properties": {
+    "name": {"type": "string", "optional": true},
+    "id": {"type": "int"},
+    "dogs": {"type": "list", "objectType": "Dog"}
+  }, "objects": [{"id": 1, "name": "Ann"}]},
+  {"name": "Dog", "properties": {"name": {"type": "string"}}, "objects": [
+    {"name": "Rex"}, {"name": "Fido"}, {"name": "Spot"}
+  ]}
+]`
+
+func realmSchemaKey(path string) string {
+	return "npx --no-install realm-cli schema --json " + path
+}
+
+func TestReadRealmInfo(t *testing.T) {
+	path := writeRealmFile(t)
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{
+		realmSchemaKey(path): {out: []byte(sampleRealmSchema)},
+	}})
+
+	info, err := ReadDatabaseContent(path)
+	if err != nil {
+		t.Fatalf("ReadDatabaseContent() error = %v", err)
+	}
+	if info.Error != "" {
+		t.Fatalf("info.Error = %q", info.Error)
+	}
+	if info.Format != "Realm" || info.Version != "file format 23" || info.TableCount != 2 {
+		t.Errorf("info = %s %q with %d tables", info.Format, info.Version, info.TableCount)
+	}
+	if info.Tables[0].Name != "Dog" || info.Tables[0].RowCount != 3 {
+		t.Errorf("Tables[0] = %s with %d rows, want Dog with 3", info.Tables[0].Name, info.Tables[0].RowCount)
+	}
+
+	owner := info.Tables[1]
+	wantColumns := []ColumnInfo{
+		{Name: "id", Type: "int", NotNull: true, PK: true},
+		{Name: "dogs", Type: "list<Dog>", NotNull: true},
+		{Name: "name", Type: "string"},
+	}
+	if len(owner.Columns) != len(wantColumns) {
+		t.Fatalf("Owner columns = %+v, want %+v", owner.Columns, wantColumns)
+	}
+	for i, want := range wantColumns {
+		if owner.Columns[i] != want {
+			t.Errorf("Owner column %d = %+v, want %+v", i, owner.Columns[i], want)
+		}
+	}
+}
+
+func TestReadRealmInfo_NotRealm(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken.realm")
+	if err := os.WriteFile(path, []byte("definitely not a realm file"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	fake := &fakeExecutor{}
+	withFakeExecutor(t, fake)
+
+	info, err := readRealmInfo(path)
+	if err != nil {
+		t.Fatalf("readRealmInfo() error = %v", err)
+	}
+	if info.Error == "" {
+		t.Error("info.Error is empty for a file without a Realm header")
+	}
+	if len(fake.calls) != 0 {
+		t.Errorf("realm-cli was run for an invalid file: %v", fake.calls)
+	}
+}
+
+func TestReadFileContent_RealmWithoutTooling(t *testing.T) {
+	path := writeRealmFile(t)
+	withFakeExecutor(t, &fakeExecutor{})
+
+	if _, err := ReadDatabaseContent(path); err != ErrNoRealmTooling {
+		t.Errorf("ReadDatabaseContent() error = %v, want ErrNoRealmTooling", err)
+	}
+
+	content, err := ReadFileContent(path, 0, 100, 80)
+	if err != nil {
+		t.Fatalf("ReadFileContent() error = %v", err)
+	}
+	if content.Type != FileTypeBinary {
+		t.Errorf("Type = %v, want FileTypeBinary", content.Type)
+	}
+	if content.Notice != RealmToolingNotice {
+		t.Errorf("Notice = %q, want %q", content.Notice, RealmToolingNotice)
+	}
+	if len(content.BinaryData) != realmHeaderSize+8 {
+		t.Errorf("BinaryData has %d bytes, want the whole file", len(content.BinaryData))
+	}
+}
+
+func TestReadTableData_Realm(t *testing.T) {
+	path := writeRealmFile(t)
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{
+		realmSchemaKey(path): {out: []byte(sampleRealmSchema)},
+	}})
+
+	rows, err := ReadTableData(path, "Dog", 1, 5)
+	if err != nil {
+		t.Fatalf("ReadTableData() error = %v", err)
+	}
+	if len(rows) != 2 || rows[0]["name"] != "Fido" {
+		t.Errorf("rows = %v, want Fido and Spot", rows)
+	}
+	if _, err := ReadTableData(path, "Cat", 0, 5); err == nil {
+		t.Error("ReadTableData() for an unknown object type should fail")
+	}
+}
+
+func TestSortRealmObjects(t *testing.T) {
+	objects := []map[string]any{
+		{"v": "b"}, {"v": float64(10)}, {"v": nil}, {"v": float64(2)}, {"v": "a"},
+	}
+	sortRealmObjects(objects, "v", true)
+	if got := fmt.Sprint(objects); got != "[map[v:<nil>] map[v:2] map[v:10] map[v:a] map[v:b]]" {
+		t.Errorf("ascending = %s", got)
+	}
+	sortRealmObjects(objects, "v", false)
+	if got := fmt.Sprint(objects); got != "[map[v:b] map[v:a] map[v:10] map[v:2] map[v:<nil>]]" {
+		t.Errorf("descending = %s", got)
+	}
+}
+
+func TestDetectFileType_Realm(t *testing.T) {
+	if got := DetectFileType("/data/default.realm"); got != FileTypeDatabase {
+		t.Errorf("DetectFileType(.realm) = %v, want FileTypeDatabase", got)
+	}
+}
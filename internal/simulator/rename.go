@@ -0,0 +1,42 @@
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CheckRename returns an error if the file at path can't be renamed to
+// name: the name must be a plain file name, and no file may have it in
+// the same directory already
+func CheckRename(path, name string) error {
+	switch {
+	case name == "":
+		return errors.New("name can't be empty")
+	case strings.ContainsRune(name, '/') || strings.ContainsRune(name, filepath.Separator):
+		return errors.New("name can't contain path separators")
+	case name == "." || name == "..":
+		return fmt.Errorf("%s isn't a file name", name)
+	case name == filepath.Base(path):
+		return nil
+	}
+	if _, err := os.Lstat(filepath.Join(filepath.Dir(path), name)); err == nil {
+		return fmt.Errorf("%s already exists", name)
+	}
+	return nil
+}
+
+// RenameFile renames the file or directory at path to name, in the same
+// directory, and returns its new path
+func RenameFile(path, name string) (string, error) {
+	if err := CheckRename(path, name); err != nil {
+		return "", err
+	}
+	newPath := filepath.Join(filepath.Dir(path), name)
+	if err := os.Rename(path, newPath); err != nil {
+		return "", withHint(err)
+	}
+	return newPath, nil
+}
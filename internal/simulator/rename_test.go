@@ -0,0 +1,57 @@
+package simulator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckRename(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "notes.txt")
+	for _, name := range []string{"notes.txt", "other.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"todo.txt", false},
+		{"notes.txt", false}, // Unchanged
+		{"", true},
+		{"sub/todo.txt", true},
+		{"..", true},
+		{"other.txt", true},
+	}
+	for _, tt := range tests {
+		if err := CheckRename(path, tt.name); (err != nil) != tt.wantErr {
+			t.Errorf("CheckRename(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRenameFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Caches")
+	if err := os.Mkdir(path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := RenameFile(path, "OldCaches")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "OldCaches"); got != want {
+		t.Errorf("RenameFile() = %q, want %q", got, want)
+	}
+	if _, err := os.Stat(got); err != nil {
+		t.Errorf("renamed directory missing: %v", err)
+	}
+
+	if _, err := RenameFile(got, "a/b"); err == nil {
+		t.Error("RenameFile() should refuse a name with a separator")
+	}
+}
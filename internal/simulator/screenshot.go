@@ -0,0 +1,31 @@
+package simulator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ScreenshotName returns the file name for a screenshot of the simulator
+// named name taken at t, e.g. "iPhone-15-Pro-2026-01-02-150405.png"
+func ScreenshotName(name string, t time.Time) string {
+	name = strings.Join(strings.Fields(name), "-")
+	name = strings.NewReplacer("/", "-", ":", "-").Replace(name)
+	if name == "" {
+		name = "Simulator"
+	}
+	return fmt.Sprintf("%s-%s.png", name, t.Format("2006-01-02-150405"))
+}
+
+// CaptureScreenshot saves a PNG of the screen of the booted simulator
+// with udid to a file in dir named by ScreenshotName, and returns its
+// path
+func CaptureScreenshot(udid, name, dir string, t time.Time) (string, error) {
+	path := filepath.Join(dir, ScreenshotName(name, t))
+	output, err := defaultExecutor.Execute("xcrun", "simctl", "io", udid, "screenshot", path)
+	if err != nil {
+		return "", fmt.Errorf("failed to capture screenshot: %w (output: %s)", err, strings.TrimSpace(string(output)))
+	}
+	return path, nil
+}
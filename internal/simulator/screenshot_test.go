@@ -0,0 +1,44 @@
+package simulator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScreenshotName(t *testing.T) {
+	at := time.Date(2026, 1, 2, 15, 4, 5, 0, time.Local)
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"iPhone 15 Pro", "iPhone-15-Pro-2026-01-02-150405.png"},
+		{"iPad Pro (11-inch)", "iPad-Pro-(11-inch)-2026-01-02-150405.png"},
+		{"Test/Device: A", "Test-Device--A-2026-01-02-150405.png"},
+		{"", "Simulator-2026-01-02-150405.png"},
+	}
+	for _, tt := range tests {
+		if got := ScreenshotName(tt.name, at); got != tt.want {
+			t.Errorf("ScreenshotName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCaptureScreenshot(t *testing.T) {
+	at := time.Date(2026, 1, 2, 15, 4, 5, 0, time.Local)
+	fake := &fakeExecutor{responses: map[string]fakeResult{
+		"xcrun simctl io udid-1 screenshot /out/iPhone-15-2026-01-02-150405.png": {},
+	}}
+	withFakeExecutor(t, fake)
+
+	path, err := CaptureScreenshot("udid-1", "iPhone 15", "/out", at)
+	if err != nil {
+		t.Fatalf("CaptureScreenshot() error = %v", err)
+	}
+	if path != "/out/iPhone-15-2026-01-02-150405.png" {
+		t.Errorf("CaptureScreenshot() = %q", path)
+	}
+
+	if _, err := CaptureScreenshot("udid-2", "iPhone 15", "/out", at); err == nil {
+		t.Error("CaptureScreenshot() should report simctl failures")
+	}
+}
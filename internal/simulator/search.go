@@ -0,0 +1,57 @@
+package simulator
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// MaxSearchMatches caps the lines SearchFile returns
+const MaxSearchMatches = 1000
+
+// SearchMatch is a line of a file that contains a search query
+type SearchMatch struct {
+	LineNum int    // 1-based
+	Snippet string // The line, without surrounding whitespace
+}
+
+// SearchFile finds the lines of the text file at path that contain
+// query, ignoring case, with grep -n. The query is matched as plain
+// text, not a pattern, and at most MaxSearchMatches lines are returned.
+func SearchFile(path, query string) ([]SearchMatch, error) {
+	path = strings.TrimPrefix(path, "file://")
+	output, err := defaultExecutor.Execute("grep", "-n", "-i", "-F",
+		"-m", strconv.Itoa(MaxSearchMatches), "-e", query, "--", path)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		// grep exits 1 when no line matches
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to search file: %w", err)
+	}
+	return parseGrepOutput(output), nil
+}
+
+// parseGrepOutput reads grep -n output, one "line:text" per match
+func parseGrepOutput(output []byte) []SearchMatch {
+	var matches []SearchMatch
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		num, text, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		lineNum, err := strconv.Atoi(num)
+		if err != nil {
+			continue
+		}
+		matches = append(matches, SearchMatch{LineNum: lineNum, Snippet: strings.TrimSpace(text)})
+	}
+	return matches
+}
@@ -0,0 +1,63 @@
+package simulator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSearchFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	text := "first line\n  Second LINE (indented)\nthird\n-dash line\n"
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query string
+		want  []SearchMatch
+	}{
+		{"line", []SearchMatch{{1, "first line"}, {2, "Second LINE (indented)"}, {4, "-dash line"}}},
+		// Plain text, not a pattern, even when it looks like a flag
+		{"(indented", []SearchMatch{{2, "Second LINE (indented)"}}},
+		{"-dash", []SearchMatch{{4, "-dash line"}}},
+		{"missing", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := SearchFile("file://"+path, tt.query)
+			if err != nil {
+				t.Fatalf("SearchFile() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := SearchFile(filepath.Join(t.TempDir(), "missing.txt"), "line"); err == nil {
+		t.Error("SearchFile() on a missing file should fail")
+	}
+}
+
+func TestSearchFile_Command(t *testing.T) {
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{
+		"grep -n -i -F -m 1000 -e foo -- /c/a.txt": {out: []byte("3:  foo = 1\n10:call(foo)\nnot a match line\n")},
+		"grep -n -i -F -m 1000 -e bad -- /c/a.txt": {err: errors.New("grep: /c/a.txt: Permission denied")},
+	}})
+
+	got, err := SearchFile("/c/a.txt", "foo")
+	if err != nil {
+		t.Fatalf("SearchFile() error = %v", err)
+	}
+	want := []SearchMatch{{3, "foo = 1"}, {10, "call(foo)"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SearchFile() = %v, want %v", got, want)
+	}
+
+	if _, err := SearchFile("/c/a.txt", "bad"); err == nil {
+		t.Error("SearchFile() should report grep errors")
+	}
+}
@@ -3,6 +3,7 @@ package simulator
 import (
 	"fmt"
 	"sort"
+	"strings"
 )
 
 // Simulator represents an iOS simulator device
@@ -17,8 +18,28 @@ type Simulator struct {
 // Item represents a simulator with its runtime information
 type Item struct {
 	Simulator
-	Runtime  string
-	AppCount int
+	Runtime          string
+	BuildVersion     string // Runtime build, e.g. "21A329"; "" if unknown
+	AppCount         int
+	SystemAppCount   int    // Apps that come with the runtime; see countSystemApps
+	NetworkCondition string // Status bar network override, e.g. "WiFi: Excellent"; booted only
+
+	// DeviceCapabilities lists the device's hardware features, such as
+	// Face ID or a home button; see DeviceCapabilities
+	DeviceCapabilities []string
+
+	// Pairing with a watch or phone simulator; PairedDevice is "" for
+	// simulators that aren't paired
+	PairedDevice string
+	IsConnected  bool
+
+	// RuntimeUnsupported is set when the runtime is newer than the
+	// selected Xcode supports
+	RuntimeUnsupported bool
+
+	// Platform is the runtime's platform, a single RuntimeFilter bit; 0
+	// for runtimes of other platforms
+	Platform RuntimeFilter
 }
 
 // DevicesByRuntime maps runtime identifiers to simulators
@@ -29,6 +50,22 @@ type SimctlOutput struct {
 	Devices DevicesByRuntime `json:"devices"`
 }
 
+// Runtime is a simulator runtime as listed by simctl list runtimes
+type Runtime struct {
+	Identifier   string `json:"identifier"`
+	Name         string `json:"name"`
+	Version      string `json:"version"`
+	BuildVersion string `json:"buildversion"`
+	BundlePath   string `json:"bundlePath"`
+	Platform     string `json:"platform"`
+	IsAvailable  bool   `json:"isAvailable"`
+}
+
+// RuntimesOutput represents the JSON output from simctl list runtimes
+type RuntimesOutput struct {
+	Runtimes []Runtime `json:"runtimes"`
+}
+
 // IsRunning returns true if the simulator is booted
 func (s *Simulator) IsRunning() bool {
 	return s.State == "Booted"
@@ -46,6 +83,35 @@ func (s *Simulator) StateDisplay() string {
 	}
 }
 
+// CloneSimulator creates a copy of the shut down simulator with udid
+// named name and returns the UDID of the copy
+func CloneSimulator(udid, name string) (string, error) {
+	output, err := defaultExecutor.Execute("xcrun", "simctl", "clone", udid, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to clone simulator: %w (output: %s)", err, strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// DeleteSimulator removes the simulator with udid along with its apps
+// and data
+func DeleteSimulator(udid string) error {
+	output, err := defaultExecutor.Execute("xcrun", "simctl", "delete", udid)
+	if err != nil {
+		return fmt.Errorf("failed to delete simulator: %w (output: %s)", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// OpenSimulatorApp brings Simulator.app to the front showing the window
+// of the booted simulator with udid
+func OpenSimulatorApp(udid string) error {
+	if err := defaultExecutor.Run("open", "-a", "Simulator", "--args", "-CurrentDeviceUDID", udid); err != nil {
+		return fmt.Errorf("failed to open Simulator app: %w", err)
+	}
+	return nil
+}
+
 // Common errors
 var (
 	ErrSimulatorNotFound = fmt.Errorf("simulator not found")
@@ -71,6 +137,7 @@ func GetAllApps(fetcher Fetcher) ([]App, error) {
 		for i := range apps {
 			apps[i].SimulatorName = item.Name
 			apps[i].SimulatorUDID = item.UDID
+			apps[i].SimulatorOS = item.Runtime
 		}
 
 		allApps = append(allApps, apps...)
@@ -1,9 +1,14 @@
 package simulator
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"math/rand/v2"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -138,6 +143,18 @@ func TestFormatSize(t *testing.T) {
 	}
 }
 
+func TestAppSizeText(t *testing.T) {
+	if got := (App{Size: AppSizeUnknown}).SizeText(); got != "…" {
+		t.Errorf("unknown size = %q, want …", got)
+	}
+	if got := (App{Size: 0}).SizeText(); got != "0 B" {
+		t.Errorf("empty bundle = %q, want 0 B", got)
+	}
+	if got := (App{Size: 1536}).SizeText(); got != "1.5 KB" {
+		t.Errorf("1536 bytes = %q, want 1.5 KB", got)
+	}
+}
+
 func TestFormatFileDate(t *testing.T) {
 	now := time.Now()
 	tests := []struct {
@@ -178,7 +195,7 @@ func TestFormatFileDate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := FormatFileDate(tt.time)
+			result := FormatFileDate(tt.time, DateFormatRelative)
 			if !tt.validate(result) {
 				t.Errorf("FormatFileDate() = %v, validation failed", result)
 			}
@@ -257,6 +274,12 @@ func (m *MockFetcher) Boot(udid string) error {
 	return nil
 }
 
+func (m *MockFetcher) Shutdown(udid string) error {
+	return nil
+}
+
+func (m *MockFetcher) SetRuntimeFilter(filter RuntimeFilter) {}
+
 func TestGetAllApps(t *testing.T) {
 	// Create a mock fetcher with test data
 	mockFetcher := &MockFetcher{
@@ -359,10 +382,229 @@ func TestFormatModTime(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := FormatModTime(tt.time)
+			result := FormatModTime(tt.time, DateFormatRelative)
 			if result != tt.expected {
 				t.Errorf("FormatModTime() = %v, want %v", result, tt.expected)
 			}
 		})
 	}
 }
+
+func TestFormatDates_FixedFormats(t *testing.T) {
+	date := time.Date(2026, 1, 2, 14, 5, 0, 0, time.FixedZone("CET", 3600))
+	tests := []struct {
+		format DateFormat
+		want   string
+	}{
+		{DateFormatAbsolute, "2026-01-02 14:05"},
+		{DateFormatISO, "2026-01-02T14:05:00+01:00"},
+	}
+	for _, tt := range tests {
+		if got := FormatModTime(date, tt.format); got != tt.want {
+			t.Errorf("FormatModTime(%s) = %q, want %q", tt.format, got, tt.want)
+		}
+		if got := FormatFileDate(date, tt.format); got != tt.want {
+			t.Errorf("FormatFileDate(%s) = %q, want %q", tt.format, got, tt.want)
+		}
+		if got := FormatModTime(time.Time{}, tt.format); got != "" {
+			t.Errorf("FormatModTime(zero, %s) = %q, want \"\"", tt.format, got)
+		}
+	}
+
+	// Anything else is relative
+	recent := time.Now().Add(-5 * time.Minute)
+	if got := FormatModTime(recent, ""); got != "5 minutes ago" {
+		t.Errorf("FormatModTime(\"\") = %q, want %q", got, "5 minutes ago")
+	}
+}
+
+// propertyRuns is how many random inputs each property test tries
+const propertyRuns = 2000
+
+// propertyRand returns a random source for a property test, logging its
+// seed so a failure can be reproduced
+func propertyRand(t *testing.T) *rand.Rand {
+	t.Helper()
+	seed := uint64(time.Now().UnixNano())
+	t.Logf("seed %d", seed)
+	return rand.New(rand.NewPCG(seed, seed))
+}
+
+// randomSize returns a byte count spread over every unit FormatSize
+// uses, from single bytes to exabytes
+func randomSize(r *rand.Rand) int64 {
+	switch r.IntN(10) {
+	case 0:
+		return r.Int64N(1024)
+	case 1:
+		return math.MaxInt64 - r.Int64N(1024)
+	}
+	return r.Int64N(1 << r.IntN(63))
+}
+
+func TestFormatSizeProperties(t *testing.T) {
+	units := map[string]float64{"B": 1}
+	for exp, prefix := range "KMGTPE" {
+		units[string(prefix)+"B"] = math.Pow(1024, float64(exp+1))
+	}
+
+	r := propertyRand(t)
+	for range propertyRuns {
+		n := randomSize(r)
+		got := FormatSize(n)
+
+		number, unit, ok := strings.Cut(got, " ")
+		scale, known := units[unit]
+		if !ok || !known {
+			t.Fatalf("FormatSize(%d) = %q, want a number and a unit", n, got)
+		}
+		// Dividing by 1024 saves three digits, which the decimal place
+		// and the K of KB spend again, so kilobytes are at most as long
+		// as the plain count. Each larger unit saves three more.
+		plain := fmt.Sprintf("%d B", n)
+		if n >= 1024 && len(got) > len(plain) {
+			t.Errorf("FormatSize(%d) = %q, longer than %q", n, got, plain)
+		}
+		if n >= 1024*1024 && len(got) >= len(plain) {
+			t.Errorf("FormatSize(%d) = %q, not shorter than %q", n, got, plain)
+		}
+		// One decimal place is shown, so the value is within half a
+		// tenth of the unit
+		value, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			t.Fatalf("FormatSize(%d) = %q, number doesn't parse: %v", n, got, err)
+		}
+		if diff := math.Abs(value*scale - float64(n)); diff > 0.05*scale+1 {
+			t.Errorf("FormatSize(%d) = %q, off by %.0f bytes", n, got, diff)
+		}
+	}
+
+	// Negative sizes never come from the file system, but mustn't panic
+	for range 100 {
+		_ = FormatSize(-r.Int64())
+	}
+}
+
+func TestFormatModTimeProperties(t *testing.T) {
+	relative := regexp.MustCompile(`^(\d+) (minutes|hours|days) ago$`)
+	r := propertyRand(t)
+	for range propertyRuns {
+		var tm time.Time
+		if r.IntN(2) == 0 {
+			// Recent times hit every relative wording
+			tm = time.Now().Add(-time.Duration(r.Int64N(int64(10 * 24 * time.Hour))))
+		} else {
+			tm = time.Unix(r.Int64N(4102444800), 0) // 1970 to 2100
+		}
+
+		if got := FormatModTime(tm, DateFormatAbsolute); !sameTime(got, "2006-01-02 15:04", tm, time.Minute) {
+			t.Errorf("FormatModTime(%v, absolute) = %q, doesn't parse back", tm, got)
+		}
+		if got := FormatModTime(tm, DateFormatISO); !sameTime(got, time.RFC3339, tm, time.Second) {
+			t.Errorf("FormatModTime(%v, iso) = %q, doesn't parse back", tm, got)
+		}
+
+		// Time passes while formatting, so the wording may match the age
+		// before or after the call
+		before := time.Since(tm)
+		got := FormatModTime(tm, DateFormatRelative)
+		after := time.Since(tm)
+		if !relativeMatches(got, before, relative) && !relativeMatches(got, after, relative) {
+			if !sameTime(got, "Jan 2", tm, 24*time.Hour) && !sameTime(got, "Jan 2, 2006", tm, 24*time.Hour) {
+				t.Errorf("FormatModTime(%v) = %q, %v ago", tm, got, before)
+			} else if before < 7*24*time.Hour {
+				t.Errorf("FormatModTime(%v) = %q, a date for a time %v ago", tm, got, before)
+			}
+		}
+	}
+	if got := FormatModTime(time.Time{}, DateFormatRelative); got != "" {
+		t.Errorf("FormatModTime(zero) = %q, want empty", got)
+	}
+}
+
+// sameTime reports whether text, formatted with layout in the local
+// time zone, shows tm to the given precision. Layouts without a year
+// are taken to be in tm's year.
+func sameTime(text, layout string, tm time.Time, precision time.Duration) bool {
+	parsed, err := time.ParseInLocation(layout, text, time.Local)
+	if err != nil {
+		return false
+	}
+	tm = tm.Local()
+	if !strings.Contains(layout, "2006") {
+		parsed = parsed.AddDate(tm.Year(), 0, 0)
+	}
+	if precision == 24*time.Hour {
+		return parsed.Year() == tm.Year() && parsed.YearDay() == tm.YearDay()
+	}
+	return parsed.Equal(tm.Truncate(precision))
+}
+
+// relativeMatches reports whether got is the relative wording for an age
+// of diff
+func relativeMatches(got string, diff time.Duration, relative *regexp.Regexp) bool {
+	switch {
+	case diff < time.Minute:
+		return got == "just now"
+	case diff < 2*time.Minute:
+		return got == "1 minute ago"
+	case diff >= time.Hour && diff < 2*time.Hour:
+		return got == "1 hour ago"
+	case diff >= 24*time.Hour && diff < 48*time.Hour:
+		return got == "yesterday"
+	case diff >= 7*24*time.Hour:
+		return false
+	}
+	m := relative.FindStringSubmatch(got)
+	if m == nil {
+		return false
+	}
+	n, _ := strconv.Atoi(m[1])
+	unit := map[string]time.Duration{"minutes": time.Minute, "hours": time.Hour, "days": 24 * time.Hour}[m[2]]
+	return time.Duration(n)*unit <= diff && diff < time.Duration(n+1)*unit
+}
+
+func TestCloneSimulator(t *testing.T) {
+	fake := &fakeExecutor{responses: map[string]fakeResult{
+		"xcrun simctl clone UDID My Copy": {out: []byte("NEW-UDID\n")},
+		"xcrun simctl clone BOOTED Copy":  {out: []byte("Unable to clone device in current state: Booted\n"), err: errors.New("exit status 149")},
+	}}
+	withFakeExecutor(t, fake)
+
+	udid, err := CloneSimulator("UDID", "My Copy")
+	if err != nil || udid != "NEW-UDID" {
+		t.Errorf("CloneSimulator() = %q, %v; want NEW-UDID", udid, err)
+	}
+	_, err = CloneSimulator("BOOTED", "Copy")
+	if err == nil || !strings.Contains(err.Error(), "current state: Booted") {
+		t.Errorf("CloneSimulator() error = %v, want simctl output", err)
+	}
+}
+
+func TestDeleteSimulator(t *testing.T) {
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{
+		"xcrun simctl delete UDID":    {},
+		"xcrun simctl delete MISSING": {out: []byte("Invalid device: MISSING\n"), err: errors.New("exit status 164")},
+	}})
+
+	if err := DeleteSimulator("UDID"); err != nil {
+		t.Errorf("DeleteSimulator() = %v", err)
+	}
+	if err := DeleteSimulator("MISSING"); err == nil || !strings.Contains(err.Error(), "Invalid device") {
+		t.Errorf("DeleteSimulator() error = %v, want simctl output", err)
+	}
+}
+
+func TestOpenSimulatorApp(t *testing.T) {
+	fake := &fakeExecutor{responses: map[string]fakeResult{
+		"open -a Simulator --args -CurrentDeviceUDID UDID": {},
+	}}
+	withFakeExecutor(t, fake)
+
+	if err := OpenSimulatorApp("UDID"); err != nil {
+		t.Errorf("OpenSimulatorApp() error = %v", err)
+	}
+	if err := OpenSimulatorApp("MISSING"); err == nil || !strings.Contains(err.Error(), "failed to open Simulator app") {
+		t.Errorf("OpenSimulatorApp() error = %v, want wrapped failure", err)
+	}
+}
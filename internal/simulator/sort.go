@@ -0,0 +1,139 @@
+package simulator
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SimSortOrder is an order of the simulator list. The sort key steps
+// through the orders in declaration order.
+type SimSortOrder int
+
+const (
+	SimSortByName SimSortOrder = iota
+	SimSortByRuntime
+	SimSortByState
+	SimSortByAppCount
+	SimSortOrderCount
+)
+
+// String returns the label shown in the footer
+func (o SimSortOrder) String() string {
+	switch o {
+	case SimSortByRuntime:
+		return "runtime"
+	case SimSortByState:
+		return "state"
+	case SimSortByAppCount:
+		return "apps"
+	default:
+		return "name"
+	}
+}
+
+// Next returns the sort order after o, wrapping around
+func (o SimSortOrder) Next() SimSortOrder {
+	return (o + 1) % SimSortOrderCount
+}
+
+// SortSimulators returns items in the given order. Simulators are
+// fetched sorted by name, so the stable sort keeps that as the
+// tie-break. The input slice is left untouched.
+func SortSimulators(items []Item, order SimSortOrder) []Item {
+	if order == SimSortByName || len(items) < 2 {
+		return items
+	}
+	sorted := slices.Clone(items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		switch order {
+		case SimSortByRuntime:
+			return runtimeLess(sorted[i].Runtime, sorted[j].Runtime)
+		case SimSortByState:
+			// Running simulators first
+			return sorted[i].IsRunning() && !sorted[j].IsRunning()
+		case SimSortByAppCount:
+			return sorted[i].AppCount > sorted[j].AppCount
+		}
+		return false
+	})
+	return sorted
+}
+
+// runtimeLess reports whether runtime a, such as "iOS 17.2", sorts
+// before b: platforms alphabetically, then the newest version first
+func runtimeLess(a, b string) bool {
+	aPlatform, aVersion, _ := strings.Cut(a, " ")
+	bPlatform, bVersion, _ := strings.Cut(b, " ")
+	if aPlatform != bPlatform {
+		return aPlatform < bPlatform
+	}
+	aParts, bParts := strings.Split(aVersion, "."), strings.Split(bVersion, ".")
+	for i := range max(len(aParts), len(bParts)) {
+		var aNum, bNum int
+		if i < len(aParts) {
+			aNum, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bNum, _ = strconv.Atoi(bParts[i])
+		}
+		if aNum != bNum {
+			return aNum > bNum
+		}
+	}
+	return false
+}
+
+// AppSortOrder is an order of an app list. Each view picks the orders
+// its sort key steps through.
+type AppSortOrder int
+
+const (
+	AppSortByName AppSortOrder = iota
+	AppSortBySize
+	AppSortByBundleID
+	AppSortBySimulator
+	AppSortByModTime
+)
+
+// String returns the label shown in the footer
+func (o AppSortOrder) String() string {
+	switch o {
+	case AppSortBySize:
+		return "size"
+	case AppSortByBundleID:
+		return "bundle ID"
+	case AppSortBySimulator:
+		return "simulator"
+	case AppSortByModTime:
+		return "modified"
+	default:
+		return "name"
+	}
+}
+
+// SortApps returns apps in the given order. Apps are fetched sorted by
+// name, so the stable sort keeps that as the tie-break. The input slice
+// is left untouched.
+func SortApps(apps []App, order AppSortOrder) []App {
+	if order == AppSortByName || len(apps) < 2 {
+		return apps
+	}
+	sorted := slices.Clone(apps)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		switch order {
+		case AppSortBySize:
+			// Largest first; sizes still being measured go last
+			return sorted[i].Size > sorted[j].Size
+		case AppSortByBundleID:
+			return sorted[i].BundleID < sorted[j].BundleID
+		case AppSortBySimulator:
+			return sorted[i].SimulatorName < sorted[j].SimulatorName
+		case AppSortByModTime:
+			return sorted[i].ModTime.After(sorted[j].ModTime)
+		}
+		return false
+	})
+	return sorted
+}
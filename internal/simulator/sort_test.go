@@ -0,0 +1,118 @@
+package simulator
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// sortTestSims returns simulators sorted by name, as the fetcher
+// returns them
+func sortTestSims() []Item {
+	return []Item{
+		{Simulator: Simulator{Name: "Apple Watch", UDID: "w", State: "Shutdown"}, Runtime: "watchOS 10.0", AppCount: 0},
+		{Simulator: Simulator{Name: "iPad Air", UDID: "a", State: "Booted"}, Runtime: "iOS 9.3", AppCount: 2},
+		{Simulator: Simulator{Name: "iPhone 14", UDID: "14", State: "Shutdown"}, Runtime: "iOS 17.0", AppCount: 5},
+		{Simulator: Simulator{Name: "iPhone 15", UDID: "15", State: "Booted"}, Runtime: "iOS 17.2", AppCount: 2},
+	}
+}
+
+// simNames joins the names of sims with commas
+func simNames(sims []Item) string {
+	names := make([]string, len(sims))
+	for i, sim := range sims {
+		names[i] = sim.Name
+	}
+	return strings.Join(names, ",")
+}
+
+func TestSortSimulators(t *testing.T) {
+	tests := []struct {
+		order SimSortOrder
+		want  string
+	}{
+		{SimSortByName, "Apple Watch,iPad Air,iPhone 14,iPhone 15"},
+		// Platforms alphabetically, newest first; 17.2 is newer than 9.3
+		{SimSortByRuntime, "iPhone 15,iPhone 14,iPad Air,Apple Watch"},
+		// Running first, by name within each state
+		{SimSortByState, "iPad Air,iPhone 15,Apple Watch,iPhone 14"},
+		// Most apps first, ties kept in name order
+		{SimSortByAppCount, "iPhone 14,iPad Air,iPhone 15,Apple Watch"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.order.String(), func(t *testing.T) {
+			sims := sortTestSims()
+			if got := simNames(SortSimulators(sims, tt.order)); got != tt.want {
+				t.Errorf("SortSimulators() = %s, want %s", got, tt.want)
+			}
+			if got := simNames(sims); got != "Apple Watch,iPad Air,iPhone 14,iPhone 15" {
+				t.Errorf("SortSimulators() modified its input: %s", got)
+			}
+		})
+	}
+}
+
+func TestRuntimeLess(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"iOS 17.2", "iOS 17.0", true},
+		{"iOS 17.0", "iOS 17.0.1", false},
+		{"iOS 10.0", "iOS 9.3", true},
+		{"iOS 9.3", "tvOS 18.0", true},
+		{"iOS 17.0", "iOS 17.0", false},
+	}
+	for _, tt := range tests {
+		if got := runtimeLess(tt.a, tt.b); got != tt.want {
+			t.Errorf("runtimeLess(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// sortTestApps returns apps from two simulators sorted by name, as the
+// app fetchers return them
+func sortTestApps() []App {
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []App{
+		{Name: "Alpha", BundleID: "com.z.alpha", Size: 100, ModTime: day, SimulatorName: "iPhone 15"},
+		{Name: "Beta", BundleID: "com.a.beta", Size: AppSizeUnknown, ModTime: day.Add(48 * time.Hour), SimulatorName: "iPhone 14"},
+		{Name: "Delta", BundleID: "com.a.delta", Size: 100, ModTime: day.Add(24 * time.Hour), SimulatorName: "iPhone 15"},
+		{Name: "Gamma", BundleID: "com.m.gamma", Size: 300, ModTime: day, SimulatorName: "iPhone 14"},
+	}
+}
+
+// appNames joins the names of apps with commas
+func appNames(apps []App) string {
+	names := make([]string, len(apps))
+	for i, app := range apps {
+		names[i] = app.Name
+	}
+	return strings.Join(names, ",")
+}
+
+func TestSortApps(t *testing.T) {
+	tests := []struct {
+		order AppSortOrder
+		want  string
+	}{
+		{AppSortByName, "Alpha,Beta,Delta,Gamma"},
+		// Largest first, unmeasured last, ties kept in name order
+		{AppSortBySize, "Gamma,Alpha,Delta,Beta"},
+		{AppSortByBundleID, "Beta,Delta,Gamma,Alpha"},
+		{AppSortBySimulator, "Beta,Gamma,Alpha,Delta"},
+		// Newest first, ties kept in name order
+		{AppSortByModTime, "Beta,Delta,Alpha,Gamma"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.order.String(), func(t *testing.T) {
+			apps := sortTestApps()
+			if got := appNames(SortApps(apps, tt.order)); got != tt.want {
+				t.Errorf("SortApps() = %s, want %s", got, tt.want)
+			}
+			if got := appNames(apps); got != "Alpha,Beta,Delta,Gamma" {
+				t.Errorf("SortApps() modified its input: %s", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// SpawnResult is the outcome of a command run inside a simulator
+type SpawnResult struct {
+	Command  string
+	Output   string // stdout and stderr, as the command wrote them
+	ExitCode int
+}
+
+// spawnCommand builds the process that runs args inside the simulator.
+// Tests swap it for a local command.
+var spawnCommand = func(udid string, args []string) *exec.Cmd {
+	return exec.Command("xcrun", append([]string{"simctl", "spawn", udid}, args...)...)
+}
+
+// SpawnCommand runs command in the environment of the booted simulator
+// with udid, using xcrun simctl spawn. The command line is split into
+// arguments on spaces, keeping quoted strings together; there is no
+// shell, so pipes and globs aren't expanded. A command that runs and
+// exits with a nonzero status is not an error: its status is in the
+// result's ExitCode.
+func SpawnCommand(udid, command string) (SpawnResult, error) {
+	args, err := splitCommandLine(command)
+	if err != nil {
+		return SpawnResult{}, err
+	}
+	if len(args) == 0 {
+		return SpawnResult{}, errors.New("no command to run")
+	}
+
+	output, err := spawnCommand(udid, args).CombinedOutput()
+	result := SpawnResult{Command: command, Output: string(output)}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		result.ExitCode = exitErr.ExitCode()
+		return result, nil
+	}
+	if err != nil {
+		return result, fmt.Errorf("failed to run command: %w", err)
+	}
+	return result, nil
+}
+
+// splitCommandLine splits line into arguments on whitespace. Single or
+// double quotes group words into one argument and are removed.
+func splitCommandLine(line string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	inArg := false
+	var quote rune
+	for _, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
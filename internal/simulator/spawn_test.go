@@ -0,0 +1,68 @@
+package simulator
+
+import (
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// withSpawnCommand swaps spawnCommand for the duration of a test.
+func withSpawnCommand(t *testing.T, fn func(udid string, args []string) *exec.Cmd) {
+	t.Helper()
+	prev := spawnCommand
+	spawnCommand = fn
+	t.Cleanup(func() { spawnCommand = prev })
+}
+
+func TestSpawnCommand(t *testing.T) {
+	var gotUDID string
+	var gotArgs []string
+	withSpawnCommand(t, func(udid string, args []string) *exec.Cmd {
+		gotUDID, gotArgs = udid, args
+		return exec.Command("sh", "-c", "echo out; echo err >&2; exit 3")
+	})
+
+	result, err := SpawnCommand("udid-1", `defaults read "com.example app"`)
+	if err != nil {
+		t.Fatalf("SpawnCommand() error = %v", err)
+	}
+	if want := []string{"defaults", "read", "com.example app"}; gotUDID != "udid-1" || !reflect.DeepEqual(gotArgs, want) {
+		t.Errorf("spawned %s %q, want udid-1 %q", gotUDID, gotArgs, want)
+	}
+	if result.ExitCode != 3 || !strings.Contains(result.Output, "out") || !strings.Contains(result.Output, "err") {
+		t.Errorf("result = %+v, want exit status 3 with stdout and stderr", result)
+	}
+}
+
+func TestSpawnCommand_Errors(t *testing.T) {
+	withSpawnCommand(t, func(udid string, args []string) *exec.Cmd {
+		return exec.Command("/nonexistent/simctl")
+	})
+
+	for _, command := range []string{"", "   ", `cat "unterminated`, "ls"} {
+		if _, err := SpawnCommand("udid-1", command); err == nil {
+			t.Errorf("SpawnCommand(%q) should fail", command)
+		}
+	}
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"ls -la /tmp", []string{"ls", "-la", "/tmp"}},
+		{"  ls\t /tmp  ", []string{"ls", "/tmp"}},
+		{`defaults read 'com.example' "Some Key"`, []string{"defaults", "read", "com.example", "Some Key"}},
+		{`echo ""`, []string{"echo", ""}},
+		{`echo it"'"s`, []string{"echo", "it's"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		got, err := splitCommandLine(tt.line)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitCommandLine(%q) = %q, %v; want %q", tt.line, got, err, tt.want)
+		}
+	}
+}
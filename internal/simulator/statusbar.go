@@ -0,0 +1,81 @@
+package simulator
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// wifiQuality names the signal strength of each number of WiFi bars
+var wifiQuality = []string{"Poor", "Fair", "Good", "Excellent"}
+
+// statusBarFieldPattern matches one "Name: value" field of simctl
+// status_bar list output. Several fields can share a line, separated by
+// commas.
+var statusBarFieldPattern = regexp.MustCompile(`([A-Za-z][A-Za-z ]*?):\s*([^,]*)`)
+
+// GetNetworkCondition returns the network shown in the status bar of the
+// booted simulator with udid, as overridden with simctl status_bar. It
+// returns "" when the network isn't overridden.
+func GetNetworkCondition(udid string) (string, error) {
+	output, err := defaultExecutor.Execute("xcrun", "simctl", "status_bar", udid, "list")
+	if err != nil {
+		return "", fmt.Errorf("failed to read status bar: %w", err)
+	}
+	return parseNetworkCondition(output), nil
+}
+
+// parseNetworkCondition summarizes the data network override in simctl
+// status_bar list output: "WiFi: <quality>" for WiFi, the network name
+// (e.g. "LTE") for cellular networks, "" when there is no override.
+func parseNetworkCondition(output []byte) string {
+	var network string
+	wifiBars := -1
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		for _, match := range statusBarFieldPattern.FindAllStringSubmatch(scanner.Text(), -1) {
+			name := strings.TrimSpace(match[1])
+			value := statusBarValue(match[2])
+			switch name {
+			case "DataNetworkType":
+				network = value
+			case "WiFi Bars":
+				if bars, err := strconv.Atoi(value); err == nil {
+					wifiBars = bars
+				}
+			}
+		}
+	}
+
+	if _, err := strconv.Atoi(network); err == nil {
+		// A bare enum value can't be named reliably
+		return ""
+	}
+	switch strings.ToLower(network) {
+	case "", "hide":
+		return ""
+	case "wifi":
+		if wifiBars >= 0 && wifiBars < len(wifiQuality) {
+			return "WiFi: " + wifiQuality[wifiBars]
+		}
+		return "WiFi"
+	}
+	return strings.ToUpper(network)
+}
+
+// statusBarValue returns the name of a status bar value. simctl prints
+// enumerated values as a number followed by the name in parentheses,
+// e.g. "2 (wifi)"; other values are printed as is.
+func statusBarValue(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if start := strings.Index(raw, "("); start >= 0 {
+		if end := strings.Index(raw[start:], ")"); end > 0 {
+			return raw[start+1 : start+end]
+		}
+	}
+	return raw
+}
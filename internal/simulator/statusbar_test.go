@@ -0,0 +1,44 @@
+package simulator
+
+import "testing"
+
+func TestParseNetworkCondition(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{"no overrides", "Current Status Bar Overrides:\n=============================\n", ""},
+		{
+			"wifi",
+			"Current Status Bar Overrides:\n=============================\nTime: 9:41\nDataNetworkType: 1 (wifi)\nWiFi Mode: 3 (Active), WiFi Bars: 3\n",
+			"WiFi: Excellent",
+		},
+		{"weak wifi", "DataNetworkType: wifi\nWiFi Mode: active, WiFi Bars: 1\n", "WiFi: Fair"},
+		{"wifi without bars", "DataNetworkType: wifi\n", "WiFi"},
+		{"cellular", "DataNetworkType: 6 (lte)\nCellular Mode: 3 (Active), Cellular Bars: 4\n", "LTE"},
+		{"hidden", "DataNetworkType: hide\n", ""},
+		{"bare enum value", "DataNetworkType: 2\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseNetworkCondition([]byte(tt.output)); got != tt.want {
+				t.Errorf("parseNetworkCondition() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetNetworkCondition(t *testing.T) {
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{
+		"xcrun simctl status_bar udid-1 list": {out: []byte("DataNetworkType: 5g\n")},
+	}})
+
+	got, err := GetNetworkCondition("udid-1")
+	if err != nil || got != "5G" {
+		t.Errorf("GetNetworkCondition() = %q, %v; want 5G", got, err)
+	}
+	if _, err := GetNetworkCondition("udid-2"); err == nil {
+		t.Error("GetNetworkCondition() with failing simctl: want error")
+	}
+}
@@ -0,0 +1,165 @@
+package simulator
+
+import (
+	"fmt"
+	"io"
+)
+
+// TreeNode is an entry in a directory tree read by ReadContainerTree
+type TreeNode struct {
+	FileInfo
+	Depth    int        // 0 for entries directly under the tree root
+	Children []TreeNode // Directory contents; empty until Loaded
+	Loaded   bool       // Children have been read (directories only)
+}
+
+// ReadContainerTree reads the directory tree below path. Directories up
+// to maxDepth levels deep have their children read; deeper directories
+// are returned unloaded so a caller can read them on demand with
+// ReadTreeChildren.
+func ReadContainerTree(path string, maxDepth int) ([]TreeNode, error) {
+	return readTree(path, 0, maxDepth)
+}
+
+// readTree reads the entries of path as nodes at depth, descending while
+// the children would still be within maxDepth levels.
+func readTree(path string, depth, maxDepth int) ([]TreeNode, error) {
+	files, err := GetFilesForContainer(path)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]TreeNode, len(files))
+	for i, file := range files {
+		nodes[i] = TreeNode{FileInfo: file, Depth: depth}
+		if !file.IsDirectory || depth+1 >= maxDepth {
+			continue
+		}
+		children, err := readTree(file.Path, depth+1, maxDepth)
+		if err != nil {
+			// Leave unreadable directories unloaded rather than failing the tree
+			continue
+		}
+		nodes[i].Children = children
+		nodes[i].Loaded = true
+	}
+	return nodes, nil
+}
+
+// ReadTreeChildren reads up to maxDepth levels below the directory
+// node dir, at the depths they occupy in dir's tree.
+func ReadTreeChildren(dir TreeNode, maxDepth int) ([]TreeNode, error) {
+	return readTree(dir.Path, dir.Depth+1, dir.Depth+1+maxDepth)
+}
+
+// AttachTreeChildren sets the children of the directory at path in
+// nodes and marks it loaded. It reports whether the directory was found.
+func AttachTreeChildren(nodes []TreeNode, path string, children []TreeNode) bool {
+	node := findTreeNode(nodes, path)
+	if node == nil {
+		return false
+	}
+	node.Children = children
+	node.Loaded = true
+	return true
+}
+
+// findTreeNode returns a pointer to the node for path, or nil.
+func findTreeNode(nodes []TreeNode, path string) *TreeNode {
+	for i := range nodes {
+		if nodes[i].Path == path {
+			return &nodes[i]
+		}
+		if found := findTreeNode(nodes[i].Children, path); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// VisibleTreeNodes flattens nodes into display order, descending only
+// into directories marked in expanded.
+func VisibleTreeNodes(nodes []TreeNode, expanded map[string]bool) []TreeNode {
+	var visible []TreeNode
+	for _, node := range nodes {
+		visible = append(visible, node)
+		if node.IsDirectory && expanded[node.Path] {
+			visible = append(visible, VisibleTreeNodes(node.Children, expanded)...)
+		}
+	}
+	return visible
+}
+
+// ExpandLoadedDirs marks every loaded directory in nodes as expanded.
+func ExpandLoadedDirs(nodes []TreeNode, expanded map[string]bool) {
+	for _, node := range nodes {
+		if node.IsDirectory && node.Loaded {
+			expanded[node.Path] = true
+			ExpandLoadedDirs(node.Children, expanded)
+		}
+	}
+}
+
+// WriteTree writes nodes to w in the layout of the tree utility: the
+// root line, one line per entry drawn with box characters, directories
+// suffixed with "/", and a closing count of directories and files. With
+// dirsOnly, files are left out.
+func WriteTree(w io.Writer, root string, nodes []TreeNode, dirsOnly bool) error {
+	if _, err := fmt.Fprintln(w, root); err != nil {
+		return err
+	}
+	var dirs, files int
+	if err := writeTreeNodes(w, nodes, "", dirsOnly, &dirs, &files); err != nil {
+		return err
+	}
+	if dirsOnly {
+		_, err := fmt.Fprintf(w, "\n%d %s\n", dirs, pluralCount(dirs, "directory", "directories"))
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%d %s, %d %s\n", dirs, pluralCount(dirs, "directory", "directories"),
+		files, pluralCount(files, "file", "files"))
+	return err
+}
+
+// writeTreeNodes writes one level of the tree below prefix, counting the
+// directories and files it writes
+func writeTreeNodes(w io.Writer, nodes []TreeNode, prefix string, dirsOnly bool, dirs, files *int) error {
+	if dirsOnly {
+		var kept []TreeNode
+		for _, node := range nodes {
+			if node.IsDirectory {
+				kept = append(kept, node)
+			}
+		}
+		nodes = kept
+	}
+
+	for i, node := range nodes {
+		branch, childPrefix := "├── ", prefix+"│   "
+		if i == len(nodes)-1 {
+			branch, childPrefix = "└── ", prefix+"    "
+		}
+		name := node.Name
+		if node.IsDirectory {
+			name += "/"
+			*dirs++
+		} else {
+			*files++
+		}
+		if _, err := fmt.Fprintln(w, prefix+branch+name); err != nil {
+			return err
+		}
+		if err := writeTreeNodes(w, node.Children, childPrefix, dirsOnly, dirs, files); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pluralCount returns singular when n is 1 and plural otherwise
+func pluralCount(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
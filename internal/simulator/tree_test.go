@@ -0,0 +1,131 @@
+package simulator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// makeTree creates a/b/c/d/deep.txt plus top.txt under a temp dir.
+func makeTree(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	deep := filepath.Join(root, "a", "b", "c", "d")
+	if err := os.MkdirAll(deep, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{filepath.Join(root, "top.txt"), filepath.Join(deep, "deep.txt")} {
+		if err := os.WriteFile(p, []byte("x"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestReadContainerTree_DepthLimit(t *testing.T) {
+	root := makeTree(t)
+	nodes, err := ReadContainerTree(root, 3)
+	if err != nil {
+		t.Fatalf("ReadContainerTree() error = %v", err)
+	}
+	if len(nodes) != 2 || nodes[0].Name != "a" || nodes[1].Name != "top.txt" {
+		t.Fatalf("root nodes = %+v", nodes)
+	}
+
+	a := nodes[0]
+	if !a.Loaded || len(a.Children) != 1 {
+		t.Fatalf("a should be loaded with one child: %+v", a)
+	}
+	b := a.Children[0]
+	if !b.Loaded || b.Depth != 1 {
+		t.Fatalf("b = %+v", b)
+	}
+	c := b.Children[0]
+	if c.Depth != 2 || c.Loaded || len(c.Children) != 0 {
+		t.Errorf("c is past the depth limit and should be unloaded: %+v", c)
+	}
+}
+
+func TestReadTreeChildren_AttachAndFlatten(t *testing.T) {
+	root := makeTree(t)
+	nodes, err := ReadContainerTree(root, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := nodes[0].Children[0].Children[0]
+
+	children, err := ReadTreeChildren(c, 3)
+	if err != nil {
+		t.Fatalf("ReadTreeChildren() error = %v", err)
+	}
+	if len(children) != 1 || children[0].Name != "d" || children[0].Depth != 3 {
+		t.Fatalf("children = %+v", children)
+	}
+	if !AttachTreeChildren(nodes, c.Path, children) {
+		t.Fatal("AttachTreeChildren() did not find c")
+	}
+	if AttachTreeChildren(nodes, filepath.Join(root, "missing"), nil) {
+		t.Error("AttachTreeChildren() found a missing path")
+	}
+
+	expanded := make(map[string]bool)
+	ExpandLoadedDirs(nodes, expanded)
+	var names []string
+	for _, n := range VisibleTreeNodes(nodes, expanded) {
+		names = append(names, n.Name)
+	}
+	want := []string{"a", "b", "c", "d", "deep.txt", "top.txt"}
+	if len(names) != len(want) {
+		t.Fatalf("visible = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("visible = %v, want %v", names, want)
+		}
+	}
+
+	expanded[nodes[0].Path] = false
+	if got := VisibleTreeNodes(nodes, expanded); len(got) != 2 {
+		t.Errorf("collapsing a should hide its subtree, got %d nodes", len(got))
+	}
+}
+
+func TestWriteTree(t *testing.T) {
+	root := makeTree(t)
+	nodes, err := ReadContainerTree(root, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	if err := WriteTree(&out, root, nodes, false); err != nil {
+		t.Fatalf("WriteTree() error = %v", err)
+	}
+	want := root + `
+├── a/
+│   └── b/
+│       └── c/
+└── top.txt
+
+3 directories, 1 file
+`
+	if out.String() != want {
+		t.Errorf("WriteTree() =\n%s\nwant\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	if err := WriteTree(&out, root, nodes, true); err != nil {
+		t.Fatalf("WriteTree(dirsOnly) error = %v", err)
+	}
+	want = root + `
+└── a/
+    └── b/
+        └── c/
+
+3 directories
+`
+	if out.String() != want {
+		t.Errorf("WriteTree(dirsOnly) =\n%s\nwant\n%s", out.String(), want)
+	}
+}
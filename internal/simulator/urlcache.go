@@ -0,0 +1,180 @@
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// urlCacheDBName is the SQLite database NSURLCache keeps its index in.
+// Bodies too large to store inline live in an fsCachedData directory
+// next to it.
+const urlCacheDBName = "Cache.db"
+
+// ErrNoURLCache is returned when an app has no NSURLCache database
+var ErrNoURLCache = errors.New("no URL cache found")
+
+// URLCacheEntry is a response stored in an app's NSURLCache
+type URLCacheEntry struct {
+	ID         int64
+	URL        string
+	StatusCode int    // 0 if the stored response couldn't be decoded
+	Size       int64  // Size of the response body
+	Timestamp  string // When the response was cached, as stored
+	BodyPath   string // File holding the body, if stored on disk
+	body       []byte // Body stored inline in the database
+}
+
+// urlCacheDirs returns the directories NSURLCache may use for an app:
+// the shared com.apple.URLCache directory and the per-bundle directory
+// used by URLSession's default cache.
+func urlCacheDirs(container, bundleID string) []string {
+	caches := filepath.Join(strings.TrimPrefix(container, "file://"), "Library", "Caches")
+	dirs := []string{filepath.Join(caches, "com.apple.URLCache")}
+	if bundleID != "" {
+		dirs = append(dirs, filepath.Join(caches, bundleID))
+	}
+	return dirs
+}
+
+// GetURLCacheEntries reads the cached responses of the app whose data
+// container is container, most recent first. It returns ErrNoURLCache
+// if the app has no cache database.
+func GetURLCacheEntries(container, bundleID string) ([]URLCacheEntry, error) {
+	var entries []URLCacheEntry
+	found := false
+	for _, dir := range urlCacheDirs(container, bundleID) {
+		path := filepath.Join(dir, urlCacheDBName)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		found = true
+		dirEntries, err := readURLCacheDB(path)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, dirEntries...)
+	}
+	if !found {
+		return nil, ErrNoURLCache
+	}
+	return entries, nil
+}
+
+// readURLCacheDB reads the entries of one Cache.db
+func readURLCacheDB(path string) ([]URLCacheEntry, error) {
+	db, err := openReadOnlyDB(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening URL cache: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	rows, err := db.Query(`
+		SELECT r.entry_ID, r.request_key, COALESCE(r.time_stamp, ''),
+		       b.response_object, COALESCE(d.isDataOnFS, 0), d.receiver_data
+		FROM cfurl_cache_response r
+		LEFT JOIN cfurl_cache_blob_data b ON b.entry_ID = r.entry_ID
+		LEFT JOIN cfurl_cache_receiver_data d ON d.entry_ID = r.entry_ID
+		ORDER BY r.time_stamp DESC, r.entry_ID DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("reading URL cache: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	fsDataDir := filepath.Join(filepath.Dir(path), "fsCachedData")
+	var entries []URLCacheEntry
+	for rows.Next() {
+		var (
+			entry    URLCacheEntry
+			response []byte
+			onFS     bool
+			receiver []byte
+		)
+		if err := rows.Scan(&entry.ID, &entry.URL, &entry.Timestamp, &response, &onFS, &receiver); err != nil {
+			continue
+		}
+		entry.StatusCode = responseStatusCode(response)
+		if onFS {
+			// receiver_data holds the name of the body file
+			entry.BodyPath = filepath.Join(fsDataDir, filepath.Base(strings.TrimSpace(string(receiver))))
+			if info, err := os.Stat(entry.BodyPath); err == nil {
+				entry.Size = info.Size()
+			}
+		} else {
+			entry.body = receiver
+			entry.Size = int64(len(receiver))
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading URL cache: %w", err)
+	}
+	return entries, nil
+}
+
+// responseStatusCode returns the HTTP status code stored in a cached
+// response_object. The response is archived as a binary plist whose
+// "Array" holds the response fields; the status code is the first
+// integer in the HTTP status range.
+func responseStatusCode(blob []byte) int {
+	if len(blob) == 0 {
+		return 0
+	}
+	plist, err := decodeBinaryPlist(blob)
+	if err != nil {
+		return 0
+	}
+	dict, ok := plist.(map[string]any)
+	if !ok {
+		return 0
+	}
+	fields, ok := dict["Array"].([]any)
+	if !ok {
+		return 0
+	}
+	for _, field := range fields {
+		if code, ok := field.(int64); ok && code >= 100 && code <= 599 {
+			return int(code)
+		}
+	}
+	return 0
+}
+
+// BodyFile returns a file holding the entry's response body so it can
+// be opened in the file viewer. Inline bodies are written to the
+// system temp directory, named after the URL's extension so the viewer
+// can detect their type.
+func (e URLCacheEntry) BodyFile() (string, error) {
+	if e.BodyPath != "" {
+		return e.BodyPath, nil
+	}
+
+	ext := ""
+	if u, err := url.Parse(e.URL); err == nil {
+		ext = filepath.Ext(u.Path)
+	}
+	dir := filepath.Join(os.TempDir(), "simtool-urlcache")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("creating body directory: %w", err)
+	}
+	path := filepath.Join(dir, strconv.FormatInt(e.ID, 10)+ext)
+	if err := os.WriteFile(path, e.body, 0o600); err != nil {
+		return "", fmt.Errorf("writing response body: %w", err)
+	}
+	return path, nil
+}
+
+// FileInfo returns the body file as a FileInfo for the file viewer
+func (e URLCacheEntry) FileInfo(path string) FileInfo {
+	info := FileInfo{Name: e.URL, Path: path, Size: e.Size}
+	if stat, err := os.Stat(path); err == nil {
+		info.CreatedAt = stat.ModTime()
+		info.ModifiedAt = stat.ModTime()
+	}
+	return info
+}
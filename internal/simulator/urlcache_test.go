@@ -0,0 +1,92 @@
+package simulator
+
+import (
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// createURLCache writes a Cache.db with NSURLCache's schema to dir: an
+// inline JSON response and a response whose body is in fsCachedData.
+func createURLCache(t *testing.T, dir string, response []byte) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, "fsCachedData"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "fsCachedData", "BODY-UUID"), []byte("large body"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("sqlite3", filepath.Join(dir, urlCacheDBName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.Close() }()
+
+	for _, stmt := range []string{
+		`CREATE TABLE cfurl_cache_response(entry_ID INTEGER PRIMARY KEY AUTOINCREMENT UNIQUE, version INTEGER, hash_value INTEGER, storage_policy INTEGER, request_key TEXT UNIQUE, time_stamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP, partition TEXT)`,
+		`CREATE TABLE cfurl_cache_blob_data(entry_ID INTEGER PRIMARY KEY, response_object BLOB, request_object BLOB, proto_props BLOB, user_info BLOB)`,
+		`CREATE TABLE cfurl_cache_receiver_data(entry_ID INTEGER PRIMARY KEY, isDataOnFS INTEGER, receiver_data BLOB)`,
+		`INSERT INTO cfurl_cache_response(entry_ID, request_key, time_stamp) VALUES (1, 'https://example.com/api.json', '2026-01-01 10:00:00'), (2, 'https://example.com/big.bin', '2026-01-02 10:00:00')`,
+		`INSERT INTO cfurl_cache_receiver_data VALUES (1, 0, '{"ok":true}'), (2, 1, 'BODY-UUID')`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	if _, err := db.Exec(`INSERT INTO cfurl_cache_blob_data(entry_ID, response_object) VALUES (1, ?)`, response); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetURLCacheEntries(t *testing.T) {
+	container := t.TempDir()
+	createURLCache(t, filepath.Join(container, "Library", "Caches", "com.example.app"), mustDecodeHex(t, cachedResponseBPlist))
+
+	entries, err := GetURLCacheEntries("file://"+container, "com.example.app")
+	if err != nil {
+		t.Fatalf("GetURLCacheEntries() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+
+	// Most recent first
+	onDisk, inline := entries[0], entries[1]
+	if onDisk.URL != "https://example.com/big.bin" || inline.URL != "https://example.com/api.json" {
+		t.Errorf("URLs = %q, %q", onDisk.URL, inline.URL)
+	}
+	if inline.StatusCode != 200 || onDisk.StatusCode != 0 {
+		t.Errorf("status codes = %d, %d; want 200 and unknown", inline.StatusCode, onDisk.StatusCode)
+	}
+	if onDisk.Size != int64(len("large body")) || inline.Size != int64(len(`{"ok":true}`)) {
+		t.Errorf("sizes = %d, %d", onDisk.Size, inline.Size)
+	}
+
+	path, err := onDisk.BodyFile()
+	if err != nil || filepath.Base(path) != "BODY-UUID" {
+		t.Errorf("on-disk BodyFile() = %q, %v", path, err)
+	}
+
+	t.Setenv("TMPDIR", t.TempDir())
+	path, err = inline.BodyFile()
+	if err != nil {
+		t.Fatalf("inline BodyFile() error = %v", err)
+	}
+	if filepath.Ext(path) != ".json" {
+		t.Errorf("inline body file %q should keep the URL's extension", path)
+	}
+	if body, _ := os.ReadFile(path); string(body) != `{"ok":true}` {
+		t.Errorf("inline body = %q", body)
+	}
+}
+
+func TestGetURLCacheEntries_NoCache(t *testing.T) {
+	if _, err := GetURLCacheEntries(t.TempDir(), "com.example.app"); !errors.Is(err, ErrNoURLCache) {
+		t.Errorf("GetURLCacheEntries() error = %v, want ErrNoURLCache", err)
+	}
+}
@@ -0,0 +1,72 @@
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// ErrDatabaseInUse is returned by VacuumDatabase when another process,
+// such as an app in the simulator, has the database open
+var ErrDatabaseInUse = errors.New("database is in use; quit the app using it and try again")
+
+// VacuumDatabase rebuilds the SQLite database at path with VACUUM to
+// reclaim free pages, returning the file size before and after. It
+// refuses with ErrDatabaseInUse if the database has a write-ahead log,
+// which exists while a connection is open, or if another connection
+// holds a lock on it.
+func VacuumDatabase(path string) (before, after int64, err error) {
+	if isRealmFile(path) {
+		return 0, 0, errors.New("vacuum is not supported for Realm databases")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	before = info.Size()
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if _, err := os.Stat(path + suffix); err == nil {
+			return 0, 0, ErrDatabaseInUse
+		}
+	}
+
+	// Without a busy timeout, taking an exclusive lock fails at once if
+	// anyone else holds a lock
+	db, err := openExclusiveDB(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() { _ = db.Close() }()
+	db.SetMaxOpenConns(1)
+
+	tx, err := db.Begin()
+	if err != nil {
+		if isBusy(err) {
+			return 0, 0, ErrDatabaseInUse
+		}
+		return 0, 0, err
+	}
+	if err := tx.Rollback(); err != nil {
+		return 0, 0, err
+	}
+	if _, err := db.Exec("VACUUM"); err != nil {
+		if isBusy(err) {
+			return 0, 0, ErrDatabaseInUse
+		}
+		return 0, 0, fmt.Errorf("vacuum failed: %w", err)
+	}
+
+	info, err = os.Stat(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	return before, info.Size(), nil
+}
+
+// isBusy reports whether err is SQLite failing to get a lock
+func isBusy(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && (sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked)
+}
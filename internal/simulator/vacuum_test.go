@@ -0,0 +1,61 @@
+package simulator
+
+import (
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVacuumDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.sqlite")
+	createTestDB(t, path,
+		"CREATE TABLE blobs (data BLOB)",
+		"WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 200) INSERT INTO blobs SELECT randomblob(4096) FROM n",
+		"DELETE FROM blobs",
+	)
+
+	before, after, err := VacuumDatabase(path)
+	if err != nil {
+		t.Fatalf("VacuumDatabase() error = %v", err)
+	}
+	if after >= before {
+		t.Errorf("VacuumDatabase() sizes = %d → %d, want the file to shrink", before, after)
+	}
+}
+
+func TestVacuumDatabase_InUse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.sqlite")
+	createTestDB(t, path, "CREATE TABLE t (id INTEGER)")
+
+	// Another connection in the middle of a write holds a lock
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.Close() }()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.Exec("INSERT INTO t VALUES (1)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := VacuumDatabase(path); !errors.Is(err, ErrDatabaseInUse) {
+		t.Errorf("VacuumDatabase() with a lock held error = %v, want ErrDatabaseInUse", err)
+	}
+	_ = tx.Rollback()
+
+	// A write-ahead log means a connection has the database open
+	if err := os.WriteFile(path+"-wal", nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := VacuumDatabase(path); !errors.Is(err, ErrDatabaseInUse) {
+		t.Errorf("VacuumDatabase() with a WAL file error = %v, want ErrDatabaseInUse", err)
+	}
+
+	if _, _, err := VacuumDatabase(filepath.Join(t.TempDir(), "missing.sqlite")); err == nil {
+		t.Error("VacuumDatabase() on a missing file should fail")
+	}
+}
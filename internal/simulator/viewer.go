@@ -2,10 +2,13 @@ package simulator
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
@@ -21,13 +24,16 @@ const (
 	FileTypeBinary
 	FileTypeArchive
 	FileTypeDatabase
+	FileTypeBinaryCookies
+	FileTypeAssetCatalog
 )
 
 // Constants shared across the viewer subsystem. Exported ones are
 // referenced from the tui package (e.g. for converting byte offsets
 // to hex-dump line offsets when paginating).
 const (
-	// BinaryChunkSize is the number of bytes read per binary file fetch.
+	// BinaryChunkSize is the default number of bytes read per binary
+	// file fetch; the viewer.binary_chunk_size setting overrides it.
 	BinaryChunkSize = 8192
 	// HexBytesPerLine is the number of bytes shown per hex dump row.
 	HexBytesPerLine = 16
@@ -38,14 +44,20 @@ type FileContent struct {
 	Type          FileType
 	Lines         []string // For text files
 	TotalLines    int      // Total number of lines in the file
+	LineOffset    int      // Line of the file the loaded Lines start at
 	ImageInfo     *ImageInfo
-	BinaryData    []byte        // For hex view (current chunk)
-	BinaryOffset  int64         // Offset of the current chunk in the file
-	TotalSize     int64         // Total size of the file (for binary files)
-	ArchiveInfo   *ArchiveInfo  // For archive files
-	DatabaseInfo  *DatabaseInfo // For database files
-	IsBinaryPlist bool          // Whether this was converted from binary plist
-	DetectedLang  string        // Detected language for syntax highlighting (e.g., "html")
+	BinaryData    []byte            // For hex view (current chunk)
+	BinaryOffset  int64             // Offset of the current chunk in the file
+	TotalSize     int64             // Total size of the file (for binary files)
+	ChunkSize     int               // Bytes read per binary file fetch
+	ArchiveInfo   *ArchiveInfo      // For archive files
+	DatabaseInfo  *DatabaseInfo     // For database files
+	CookiesInfo   *CookiesInfo      // For binary cookie files
+	AssetCatalog  *AssetCatalogInfo // For .xcassets directories
+	IsBinaryPlist bool              // Whether this was converted from binary plist
+	DetectedLang  string            // Detected language for syntax highlighting (e.g., "html")
+	CrashReport   *CrashReport      // Summary of an .ips crash report
+	Notice        string            // Shown in the status bar, e.g. why a fallback view is used
 	Error         error
 }
 
@@ -93,6 +105,15 @@ type DatabaseInfo struct {
 	Tables     []TableInfo `json:"tables"`
 	Schema     string      `json:"schema"` // Full schema dump
 	Error      string      `json:"error,omitempty"`
+
+	// For Core Data stores, the number of the model version the store
+	// was created with, e.g. 2 for "Model 2", or 0 if unknown; and
+	// whether the store is out of date with the model's current version
+	CoreDataVersion          int  `json:"core_data_version,omitempty"`
+	CoreDataMigrationPending bool `json:"core_data_migration_pending,omitempty"`
+
+	// Entity version hashes from the store's Core Data metadata
+	coreDataHashes map[string][]byte
 }
 
 // TableInfo represents information about a database table
@@ -102,6 +123,62 @@ type TableInfo struct {
 	Schema   string           `json:"schema"`
 	Columns  []ColumnInfo     `json:"columns"`
 	Sample   []map[string]any `json:"sample,omitempty"` // First few rows
+
+	// RowCountEstimated is set for virtual tables, whose RowCount is
+	// their largest rowid rather than a COUNT(*)
+	RowCountEstimated bool `json:"row_count_estimated,omitempty"`
+
+	// Entity stored in the table when the database is a Core Data store
+	CoreDataEntityName string `json:"core_data_entity_name,omitempty"`
+}
+
+// Table sizes that limit how much of a table is read at once
+const (
+	// MaxSampleRows is the number of rows sampled for each table when a
+	// database is opened
+	MaxSampleRows = 5
+	// MaxDisplayRows is the number of rows fetched per page of table
+	// content
+	MaxDisplayRows = 50
+	// LargeTableRows is the row count above which a table is flagged as
+	// large
+	LargeTableRows = 100_000
+)
+
+// IsLarge reports whether the table has more than LargeTableRows rows
+func (t TableInfo) IsLarge() bool {
+	return t.RowCount > LargeTableRows
+}
+
+// RowCountText returns the table's row count for display, marked with
+// a ~ when it is an estimate
+func (t TableInfo) RowCountText() string {
+	if t.RowCountEstimated {
+		return "~" + strconv.FormatInt(t.RowCount, 10)
+	}
+	return strconv.FormatInt(t.RowCount, 10)
+}
+
+// FormatRowCount formats a row count compactly, e.g. 950, 12.5K or
+// 1.2M
+func FormatRowCount(count int64) string {
+	switch {
+	case count >= 999_950:
+		// Rounds to at least 1.0M rather than 1000.0K
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", float64(count)/1_000_000), ".0") + "M"
+	case count >= 1_000:
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", float64(count)/1_000), ".0") + "K"
+	}
+	return strconv.FormatInt(count, 10)
+}
+
+// DisplayName returns the table's name, led by its Core Data entity
+// name when it has one, e.g. "Contact (ZCONTACT)"
+func (t TableInfo) DisplayName() string {
+	if t.CoreDataEntityName == "" {
+		return t.Name
+	}
+	return t.CoreDataEntityName + " (" + t.Name + ")"
 }
 
 // ColumnInfo represents information about a table column
@@ -112,11 +189,66 @@ type ColumnInfo struct {
 	PK      bool   `json:"primary_key"`
 }
 
+// AssetCatalogInfo summarizes an .xcassets asset catalog
+type AssetCatalogInfo struct {
+	Assets []CatalogAsset // Assets and the folders grouping them, in walk order
+	Counts map[string]int // Number of assets of each type
+}
+
+// CatalogAsset is an asset or folder in an asset catalog
+type CatalogAsset struct {
+	Path     string // Relative to the catalog, "/"-separated
+	Type     string // e.g. "image set"; "folder" for groups
+	Variants int    // Images, colors or data items listed in Contents.json
+}
+
+// assetTypes names the asset kinds by their directory extension
+var assetTypes = map[string]string{
+	".imageset":        "image set",
+	".appiconset":      "app icon",
+	".colorset":        "color set",
+	".dataset":         "data set",
+	".symbolset":       "symbol set",
+	".launchimage":     "launch image",
+	".imagestack":      "image stack",
+	".brandassets":     "brand assets",
+	".textureset":      "texture set",
+	".cubetextureset":  "cube texture set",
+	".arresourcegroup": "AR resource group",
+	".complicationset": "complication set",
+	".stickersequence": "sticker sequence",
+	".sticker":         "sticker",
+	".stickerpack":     "sticker pack",
+}
+
+// CookiesInfo contains the cookies stored in a binary cookies file
+type CookiesInfo struct {
+	FileSize int64
+	Cookies  []Cookie
+}
+
+// Cookie is a single cookie from a binary cookies file
+type Cookie struct {
+	Domain   string
+	Path     string
+	Name     string
+	Value    string
+	Expires  time.Time
+	Created  time.Time
+	Secure   bool
+	HTTPOnly bool
+}
+
 // DetectFileType determines the type of file based on content and extension
 func DetectFileType(path string) FileType {
 	// First check by extension
 	ext := strings.ToLower(filepath.Ext(path))
 
+	// Asset catalogs are directories viewed as a whole
+	if ext == ".xcassets" {
+		return FileTypeAssetCatalog
+	}
+
 	// Image file extensions
 	imageExts := map[string]bool{
 		".jpg": true, ".jpeg": true, ".png": true, ".gif": true,
@@ -140,12 +272,18 @@ func DetectFileType(path string) FileType {
 	// Database file extensions
 	databaseExts := map[string]bool{
 		".db": true, ".sqlite": true, ".sqlite3": true, ".db3": true,
+		".realm": true,
 	}
 
 	if databaseExts[ext] {
 		return FileTypeDatabase
 	}
 
+	// Crash reports in Apple's JSON-based .ips format are always text
+	if ext == ".ips" {
+		return FileTypeText
+	}
+
 	// Check for known binary extensions first
 	binaryExts := map[string]bool{
 		".exe": true, ".dll": true, ".so": true, ".dylib": true,
@@ -184,6 +322,12 @@ func DetectFileType(path string) FileType {
 		return FileTypeDatabase
 	}
 
+	// Check for the binary cookies magic "cook" used by WKWebView and
+	// NSHTTPCookieStorage
+	if n >= 4 && string(buffer[:4]) == binaryCookiesMagic {
+		return FileTypeBinaryCookies
+	}
+
 	// Check for image file signatures
 	imageSignatures := [][]byte{
 		[]byte("\x89PNG\r\n\x1a\n"),        // PNG
@@ -244,6 +388,7 @@ func DetectFileType(path string) FileType {
 		".gitignore": true, ".env": true, ".conf": true, ".ini": true,
 		".podspec": true, ".gemspec": true, ".rake": true, ".gemfile": true,
 		".podfile": true, ".brewfile": true, ".rakefile": true,
+		".ips": true, ".crash": true,
 	}
 
 	if textExts[ext] {
@@ -343,8 +488,18 @@ func isTextContent(data []byte) bool {
 	return float64(printable)/float64(total) > 0.9
 }
 
-// ReadFileContent reads file content based on its type
+// ReadFileContent reads file content based on its type, reading binary
+// files BinaryChunkSize bytes at a time
 func ReadFileContent(path string, startLine, maxLines, maxWidth int) (*FileContent, error) {
+	return ReadFileContentChunk(path, startLine, maxLines, maxWidth, BinaryChunkSize)
+}
+
+// ReadFileContentChunk is ReadFileContent with the number of bytes read
+// per binary file fetch given by chunkSize
+func ReadFileContentChunk(path string, startLine, maxLines, maxWidth, chunkSize int) (*FileContent, error) {
+	if chunkSize <= 0 {
+		chunkSize = BinaryChunkSize
+	}
 	fileType := DetectFileType(path)
 
 	content := &FileContent{
@@ -356,9 +511,14 @@ func ReadFileContent(path string, startLine, maxLines, maxWidth int) (*FileConte
 		lines, totalLines, isBinaryPlist, err := readTextFile(path, startLine, maxLines)
 		content.Lines = lines
 		content.TotalLines = totalLines
+		content.LineOffset = startLine
 		content.IsBinaryPlist = isBinaryPlist
 		content.Error = err
 
+		if strings.EqualFold(filepath.Ext(path), ".ips") {
+			content.CrashReport = readIpsCrashReport(path)
+		}
+
 		// Detect language for files without extensions
 		if filepath.Ext(path) == "" && len(lines) > 0 {
 			// Check first few lines for content type
@@ -390,81 +550,135 @@ func ReadFileContent(path string, startLine, maxLines, maxWidth int) (*FileConte
 		if err != nil && strings.Contains(err.Error(), "not a valid image") {
 			// Fall back to binary view if image decoding fails
 			content.Type = FileTypeBinary
+			readBinaryChunk(content, path, startLine, chunkSize)
+			content.Error = withHint(content.Error)
+			return content, content.Error
+		}
+		content.ImageInfo = info
+		content.Error = err
 
-			// Get file info
-			fileInfo, err := os.Stat(path)
-			if err != nil {
-				content.Error = err
-				return content, err
-			}
+	case FileTypeBinary:
+		readBinaryChunk(content, path, startLine, chunkSize)
 
-			content.TotalSize = fileInfo.Size()
+	case FileTypeArchive:
+		info, err := readArchiveInfo(path)
+		content.ArchiveInfo = info
+		content.Error = err
 
-			// Offset is expressed in hex-dump lines on entry.
-			offset := int64(startLine * HexBytesPerLine)
+	case FileTypeDatabase:
+		info, err := ReadDatabaseContent(path)
+		if errors.Is(err, ErrNoRealmTooling) {
+			// Without realm-cli a Realm file can still be inspected as hex
+			content.Type = FileTypeBinary
+			content.Notice = RealmToolingNotice
+			readBinaryChunk(content, path, startLine, chunkSize)
+			break
+		}
+		content.DatabaseInfo = info
+		content.Error = err
 
-			readSize := BinaryChunkSize
+	case FileTypeBinaryCookies:
+		info, err := readBinaryCookiesInfo(path)
+		content.CookiesInfo = info
+		content.Error = err
 
-			// Don't read past the end of the file
-			if offset+int64(readSize) > fileInfo.Size() {
-				readSize = int(fileInfo.Size() - offset)
-			}
+	case FileTypeAssetCatalog:
+		info, err := readAssetCatalogInfo(path)
+		content.AssetCatalog = info
+		content.Error = err
+	}
 
-			if readSize > 0 {
-				data, err := readBinaryFile(path, offset, readSize)
-				content.BinaryData = data
-				content.BinaryOffset = offset
-				content.Error = err
-			} else {
-				content.BinaryData = []byte{}
-				content.BinaryOffset = offset
-			}
+	content.Error = withHint(content.Error)
+	return content, content.Error
+}
 
-			return content, content.Error
+// readAssetCatalogInfo walks the asset catalog at path. Each directory
+// with a known asset extension is an asset; its Contents.json lists its
+// variants. Other directories are folders grouping assets and are
+// walked in turn.
+func readAssetCatalogInfo(path string) (*AssetCatalogInfo, error) {
+	info := &AssetCatalogInfo{Counts: make(map[string]int)}
+	err := filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
 		}
-		content.ImageInfo = info
-		content.Error = err
-
-	case FileTypeBinary:
-		// For binary files, implement lazy loading
-		fileInfo, err := os.Stat(path)
+		if !d.IsDir() || p == path {
+			return nil
+		}
+		rel, err := filepath.Rel(path, p)
 		if err != nil {
-			content.Error = err
-			return content, err
+			return err
+		}
+		asset := CatalogAsset{Path: filepath.ToSlash(rel), Type: "folder"}
+		kind, isAsset := assetTypes[strings.ToLower(filepath.Ext(p))]
+		if isAsset {
+			asset.Type = kind
+			asset.Variants = countAssetVariants(filepath.Join(p, "Contents.json"))
+			info.Counts[kind]++
 		}
+		info.Assets = append(info.Assets, asset)
+		if isAsset {
+			// Asset contents are its variants, not further assets
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
 
-		content.TotalSize = fileInfo.Size()
+// countAssetVariants counts the images, colors, data items and symbols
+// listed in an asset's Contents.json. Missing or malformed files count
+// as none.
+func countAssetVariants(contentsPath string) int {
+	data, err := os.ReadFile(contentsPath)
+	if err != nil {
+		return 0
+	}
+	var contents struct {
+		Images  []json.RawMessage `json:"images"`
+		Colors  []json.RawMessage `json:"colors"`
+		Data    []json.RawMessage `json:"data"`
+		Symbols []json.RawMessage `json:"symbols"`
+		Layers  []json.RawMessage `json:"layers"`
+	}
+	if err := json.Unmarshal(data, &contents); err != nil {
+		return 0
+	}
+	return len(contents.Images) + len(contents.Colors) + len(contents.Data) + len(contents.Symbols) + len(contents.Layers)
+}
 
-		// Offset is expressed in hex-dump lines on entry.
-		offset := int64(startLine * HexBytesPerLine)
+// readBinaryChunk loads the chunkSize-byte hex-view chunk of path
+// starting startLine hex-dump lines into the file into content.
+func readBinaryChunk(content *FileContent, path string, startLine, chunkSize int) {
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		content.Error = err
+		return
+	}
 
-		readSize := BinaryChunkSize
+	content.TotalSize = fileInfo.Size()
+	content.ChunkSize = chunkSize
 
-		// Don't read past the end of the file
-		if offset+int64(readSize) > fileInfo.Size() {
-			readSize = int(fileInfo.Size() - offset)
-		}
+	// Offset is expressed in hex-dump lines on entry.
+	offset := int64(startLine * HexBytesPerLine)
 
-		if readSize > 0 {
-			data, err := readBinaryFile(path, offset, readSize)
-			content.BinaryData = data
-			content.BinaryOffset = offset
-			content.Error = err
-		} else {
-			content.BinaryData = []byte{}
-			content.BinaryOffset = offset
-		}
+	readSize := chunkSize
 
-	case FileTypeArchive:
-		info, err := readArchiveInfo(path)
-		content.ArchiveInfo = info
-		content.Error = err
+	// Don't read past the end of the file
+	if offset+int64(readSize) > fileInfo.Size() {
+		readSize = int(fileInfo.Size() - offset)
+	}
 
-	case FileTypeDatabase:
-		info, err := readDatabaseInfo(path)
-		content.DatabaseInfo = info
+	if readSize > 0 {
+		data, err := readBinaryFile(path, offset, readSize)
+		content.BinaryData = data
+		content.BinaryOffset = offset
 		content.Error = err
+	} else {
+		content.BinaryData = []byte{}
+		content.BinaryOffset = offset
 	}
-
-	return content, content.Error
 }
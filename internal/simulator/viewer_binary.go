@@ -32,7 +32,8 @@ func readBinaryFile(path string, offset int64, size int) ([]byte, error) {
 	return data[:n], nil
 }
 
-// FormatHexDump formats binary data as hex dump
+// FormatHexDump formats binary data as hex dump. A short last row has
+// its hex column padded so the ASCII column lines up with the rows above.
 func FormatHexDump(data []byte, offset int64) []string {
 	var lines []string
 
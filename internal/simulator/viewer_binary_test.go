@@ -96,3 +96,36 @@ func TestReadBinaryFile_EmptyFile(t *testing.T) {
 		t.Errorf("len(got) = %d, want 0", len(got))
 	}
 }
+
+func TestReadFileContentChunk_ChunkSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.bin")
+	payload := make([]byte, 3*BinaryChunkSize)
+	if err := os.WriteFile(path, payload, 0600); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	for _, tt := range []struct {
+		chunkSize, want int
+	}{
+		{4096, 4096},
+		{16384, 16384},
+		{0, BinaryChunkSize},
+	} {
+		content, err := ReadFileContentChunk(path, 0, 0, 0, tt.chunkSize)
+		if err != nil {
+			t.Fatalf("ReadFileContentChunk(%d): %v", tt.chunkSize, err)
+		}
+		if len(content.BinaryData) != tt.want || content.ChunkSize != tt.want {
+			t.Errorf("ReadFileContentChunk(%d) read %d bytes with ChunkSize %d, want %d", tt.chunkSize, len(content.BinaryData), content.ChunkSize, tt.want)
+		}
+	}
+
+	// Offsets stay in hex dump rows whatever the chunk size
+	content, err := ReadFileContentChunk(path, 2*BinaryChunkSize/HexBytesPerLine, 0, 0, 65536)
+	if err != nil {
+		t.Fatalf("ReadFileContentChunk: %v", err)
+	}
+	if content.BinaryOffset != 2*BinaryChunkSize || len(content.BinaryData) != BinaryChunkSize {
+		t.Errorf("BinaryOffset = %d with %d bytes, want the last %d bytes", content.BinaryOffset, len(content.BinaryData), BinaryChunkSize)
+	}
+}
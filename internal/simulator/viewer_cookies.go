@@ -0,0 +1,145 @@
+package simulator
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"time"
+)
+
+// binaryCookiesMagic starts every Cookies.binarycookies file
+const binaryCookiesMagic = "cook"
+
+// macEpochOffset is the number of seconds between the Unix epoch and
+// the Mac absolute time reference date, 2001-01-01 UTC.
+const macEpochOffset = 978307200
+
+// Cookie flag bits stored in each cookie record
+const (
+	cookieFlagSecure   = 0x1
+	cookieFlagHTTPOnly = 0x4
+)
+
+// cookieHeaderSize is the fixed part of a cookie record that precedes
+// its strings
+const cookieHeaderSize = 56
+
+// errBadCookies is returned for data that isn't a well-formed binary
+// cookies file.
+var errBadCookies = errors.New("malformed binary cookies file")
+
+// readBinaryCookiesInfo reads the cookies in a binary cookies file
+func readBinaryCookiesInfo(path string) (*CookiesInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cookies: %w", err)
+	}
+	cookies, err := parseBinaryCookies(data)
+	if err != nil {
+		return nil, err
+	}
+	return &CookiesInfo{FileSize: int64(len(data)), Cookies: cookies}, nil
+}
+
+// parseBinaryCookies decodes Apple's binary cookies format: a big-endian
+// header with the page count and page sizes, followed by pages whose
+// cookie records are little-endian.
+func parseBinaryCookies(data []byte) ([]Cookie, error) {
+	if len(data) < 8 || string(data[:4]) != binaryCookiesMagic {
+		return nil, errBadCookies
+	}
+	numPages := int(binary.BigEndian.Uint32(data[4:8]))
+	pos := 8
+	if numPages > (len(data)-pos)/4 {
+		return nil, errBadCookies
+	}
+
+	pageSizes := make([]int, numPages)
+	for i := range pageSizes {
+		pageSizes[i] = int(binary.BigEndian.Uint32(data[pos:]))
+		pos += 4
+	}
+
+	var cookies []Cookie
+	for _, size := range pageSizes {
+		if size < 0 || size > len(data)-pos {
+			return nil, errBadCookies
+		}
+		pageCookies, err := parseCookiePage(data[pos : pos+size])
+		if err != nil {
+			return nil, err
+		}
+		cookies = append(cookies, pageCookies...)
+		pos += size
+	}
+	return cookies, nil
+}
+
+// parseCookiePage decodes the cookies of one page. Cookie offsets are
+// relative to the start of the page.
+func parseCookiePage(page []byte) ([]Cookie, error) {
+	if len(page) < 8 || binary.BigEndian.Uint32(page) != 0x00000100 {
+		return nil, errBadCookies
+	}
+	count := int(binary.LittleEndian.Uint32(page[4:]))
+	if count > (len(page)-8)/4 {
+		return nil, errBadCookies
+	}
+
+	cookies := make([]Cookie, 0, count)
+	for i := range count {
+		offset := int(binary.LittleEndian.Uint32(page[8+i*4:]))
+		if offset+4 > len(page) {
+			return nil, errBadCookies
+		}
+		size := int(binary.LittleEndian.Uint32(page[offset:]))
+		if size < cookieHeaderSize || size > len(page)-offset {
+			return nil, errBadCookies
+		}
+		cookies = append(cookies, parseCookie(page[offset:offset+size]))
+	}
+	return cookies, nil
+}
+
+// parseCookie decodes a single cookie record. String offsets are
+// relative to the start of the record; strings are NUL-terminated.
+func parseCookie(record []byte) Cookie {
+	flags := binary.LittleEndian.Uint32(record[8:])
+	return Cookie{
+		Domain:   cookieString(record, binary.LittleEndian.Uint32(record[16:])),
+		Name:     cookieString(record, binary.LittleEndian.Uint32(record[20:])),
+		Path:     cookieString(record, binary.LittleEndian.Uint32(record[24:])),
+		Value:    cookieString(record, binary.LittleEndian.Uint32(record[28:])),
+		Expires:  macAbsoluteTime(record[40:48]),
+		Created:  macAbsoluteTime(record[48:56]),
+		Secure:   flags&cookieFlagSecure != 0,
+		HTTPOnly: flags&cookieFlagHTTPOnly != 0,
+	}
+}
+
+// cookieString reads the NUL-terminated string at offset in record
+func cookieString(record []byte, offset uint32) string {
+	if offset == 0 || int(offset) >= len(record) {
+		return ""
+	}
+	s := record[offset:]
+	for i, c := range s {
+		if c == 0 {
+			return string(s[:i])
+		}
+	}
+	return string(s)
+}
+
+// macAbsoluteTime converts a little-endian float64 of seconds since
+// 2001-01-01 UTC to a time. A zero value decodes as the zero time.
+func macAbsoluteTime(b []byte) time.Time {
+	secs := math.Float64frombits(binary.LittleEndian.Uint64(b))
+	if secs == 0 || math.IsNaN(secs) || math.IsInf(secs, 0) {
+		return time.Time{}
+	}
+	whole, frac := math.Modf(secs)
+	return time.Unix(int64(whole)+macEpochOffset, int64(frac*1e9)).UTC()
+}
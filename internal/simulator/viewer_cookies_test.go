@@ -0,0 +1,160 @@
+package simulator
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCookie describes a cookie record for buildBinaryCookies
+type testCookie struct {
+	Domain, Name, Path, Value string
+	Flags                     uint32
+	Expires, Created          float64 // Mac absolute time
+}
+
+// buildBinaryCookies encodes pages of cookies in Apple's binary cookies
+// format.
+func buildBinaryCookies(pages ...[]testCookie) []byte {
+	var encoded [][]byte
+	for _, cookies := range pages {
+		var records [][]byte
+		for _, c := range cookies {
+			var strs bytes.Buffer
+			var offsets [4]uint32
+			for i, s := range []string{c.Domain, c.Name, c.Path, c.Value} {
+				offsets[i] = uint32(cookieHeaderSize + strs.Len())
+				strs.WriteString(s)
+				strs.WriteByte(0)
+			}
+			rec := make([]byte, cookieHeaderSize)
+			binary.LittleEndian.PutUint32(rec[0:], uint32(cookieHeaderSize+strs.Len()))
+			binary.LittleEndian.PutUint32(rec[8:], c.Flags)
+			for i, off := range offsets {
+				binary.LittleEndian.PutUint32(rec[16+i*4:], off)
+			}
+			binary.LittleEndian.PutUint64(rec[40:], math.Float64bits(c.Expires))
+			binary.LittleEndian.PutUint64(rec[48:], math.Float64bits(c.Created))
+			records = append(records, append(rec, strs.Bytes()...))
+		}
+
+		page := binary.BigEndian.AppendUint32(nil, 0x00000100)
+		page = binary.LittleEndian.AppendUint32(page, uint32(len(records)))
+		offset := 8 + 4*len(records) + 4
+		for _, rec := range records {
+			page = binary.LittleEndian.AppendUint32(page, uint32(offset))
+			offset += len(rec)
+		}
+		page = append(page, 0, 0, 0, 0)
+		for _, rec := range records {
+			page = append(page, rec...)
+		}
+		encoded = append(encoded, page)
+	}
+
+	data := []byte(binaryCookiesMagic)
+	data = binary.BigEndian.AppendUint32(data, uint32(len(encoded)))
+	for _, page := range encoded {
+		data = binary.BigEndian.AppendUint32(data, uint32(len(page)))
+	}
+	for _, page := range encoded {
+		data = append(data, page...)
+	}
+	return data
+}
+
+func TestParseBinaryCookies(t *testing.T) {
+	data := buildBinaryCookies(
+		[]testCookie{
+			{Domain: ".example.com", Name: "session", Path: "/", Value: "abc123", Flags: cookieFlagSecure | cookieFlagHTTPOnly, Expires: 700000000, Created: 690000000},
+			{Domain: "api.example.com", Name: "theme", Path: "/app", Value: "dark"},
+		},
+		[]testCookie{
+			{Domain: "other.org", Name: "id", Path: "/", Value: "42", Flags: cookieFlagSecure, Expires: 700000000.5},
+		},
+	)
+
+	cookies, err := parseBinaryCookies(data)
+	if err != nil {
+		t.Fatalf("parseBinaryCookies() error = %v", err)
+	}
+	if len(cookies) != 3 {
+		t.Fatalf("got %d cookies, want 3", len(cookies))
+	}
+
+	first := cookies[0]
+	if first.Domain != ".example.com" || first.Name != "session" || first.Path != "/" || first.Value != "abc123" {
+		t.Errorf("first cookie = %+v", first)
+	}
+	if !first.Secure || !first.HTTPOnly {
+		t.Errorf("first cookie flags: secure=%v httpOnly=%v, want both", first.Secure, first.HTTPOnly)
+	}
+	wantExpires := time.Unix(700000000+macEpochOffset, 0).UTC()
+	if !first.Expires.Equal(wantExpires) {
+		t.Errorf("Expires = %v, want %v", first.Expires, wantExpires)
+	}
+	if !first.Created.Equal(time.Unix(690000000+macEpochOffset, 0)) {
+		t.Errorf("Created = %v", first.Created)
+	}
+
+	if second := cookies[1]; !second.Expires.IsZero() || second.Secure || second.HTTPOnly {
+		t.Errorf("second cookie = %+v, want session cookie without flags", second)
+	}
+	if third := cookies[2]; third.Domain != "other.org" || !third.Secure || third.HTTPOnly {
+		t.Errorf("third cookie = %+v", third)
+	}
+}
+
+func TestParseBinaryCookies_Malformed(t *testing.T) {
+	valid := buildBinaryCookies([]testCookie{{Domain: "a.com", Name: "n", Path: "/", Value: "v"}})
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"wrong magic", append([]byte("kooc"), valid[4:]...)},
+		{"truncated", valid[:len(valid)-10]},
+		{"huge page count", []byte("cook\xff\xff\xff\xff")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseBinaryCookies(tt.data); err == nil {
+				t.Error("parseBinaryCookies() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestReadBinaryCookiesInfo(t *testing.T) {
+	data := buildBinaryCookies([]testCookie{{Domain: "a.com", Name: "n", Path: "/", Value: "v"}})
+	path := filepath.Join(t.TempDir(), "Cookies.binarycookies")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("write cookies: %v", err)
+	}
+
+	if got := DetectFileType(path); got != FileTypeBinaryCookies {
+		t.Errorf("DetectFileType() = %v, want FileTypeBinaryCookies", got)
+	}
+
+	content, err := ReadFileContent(path, 0, 100, 80)
+	if err != nil {
+		t.Fatalf("ReadFileContent() error = %v", err)
+	}
+	if content.CookiesInfo == nil || len(content.CookiesInfo.Cookies) != 1 {
+		t.Fatalf("CookiesInfo = %+v, want 1 cookie", content.CookiesInfo)
+	}
+	if content.CookiesInfo.FileSize != int64(len(data)) {
+		t.Errorf("FileSize = %d, want %d", content.CookiesInfo.FileSize, len(data))
+	}
+}
+
+func TestReadBinaryCookiesInfo_NonexistentFile(t *testing.T) {
+	if _, err := readBinaryCookiesInfo(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatal("readBinaryCookiesInfo on missing file returned nil error, want error")
+	}
+}
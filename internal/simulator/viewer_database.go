@@ -2,18 +2,68 @@ package simulator
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
 
 // ReadDatabaseContent reads information from a database file
 func ReadDatabaseContent(path string) (*DatabaseInfo, error) {
+	if isRealmFile(path) {
+		return readRealmInfo(path)
+	}
 	return readDatabaseInfo(path)
 }
 
+// FindDatabases returns the SQLite databases below root, named by their
+// path relative to root and sorted by that name. Unreadable directories
+// are skipped.
+func FindDatabases(root string) []FileInfo {
+	root = strings.TrimPrefix(root, "file://")
+
+	var databases []FileInfo
+	_ = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if d != nil && d.IsDir() && path != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || DetectFileType(path) != FileTypeDatabase {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		name, err := filepath.Rel(root, path)
+		if err != nil {
+			name = d.Name()
+		}
+		databases = append(databases, FileInfo{
+			Name:       name,
+			Path:       path,
+			Size:       info.Size(),
+			CreatedAt:  info.ModTime(),
+			ModifiedAt: info.ModTime(),
+		})
+		return nil
+	})
+
+	sort.Slice(databases, func(i, j int) bool {
+		return databases[i].Name < databases[j].Name
+	})
+	return databases
+}
+
 // openReadOnlyDB opens a SQLite database in read-only mode. The "file:"
 // URI prefix is required: without it, go-sqlite3 treats "?mode=ro" as
 // part of the filename rather than as a URI parameter, and a missing
@@ -23,6 +73,12 @@ func openReadOnlyDB(path string) (*sql.DB, error) {
 	return sql.Open("sqlite3", "file:"+path+"?mode=ro")
 }
 
+// openExclusiveDB opens the database at path for writing, with
+// transactions taking an exclusive lock and no wait for other locks
+func openExclusiveDB(path string) (*sql.DB, error) {
+	return sql.Open("sqlite3", "file:"+path+"?mode=rw&_txlock=exclusive&_busy_timeout=0")
+}
+
 // readDatabaseInfo reads information from a database file
 func readDatabaseInfo(path string) (*DatabaseInfo, error) {
 	// Try to open as SQLite database
@@ -34,6 +90,9 @@ func readDatabaseInfo(path string) (*DatabaseInfo, error) {
 
 	// Test connection
 	if err := db.Ping(); err != nil {
+		if hint := openFailureHint(path, err); hint != "" {
+			return &DatabaseInfo{Error: hint}, nil
+		}
 		return &DatabaseInfo{Error: "Not a valid SQLite database: " + err.Error()}, nil
 	}
 
@@ -52,14 +111,15 @@ func readDatabaseInfo(path string) (*DatabaseInfo, error) {
 	}
 
 	// Get all tables
-	tables, err := getAllTables(db)
+	tables, err := getAllTables(db, path)
 	if err != nil {
-		dbInfo.Error = err.Error()
+		dbInfo.Error = withHint(err).Error()
 		return dbInfo, nil
 	}
 
 	dbInfo.Tables = tables
 	dbInfo.TableCount = len(tables)
+	dbInfo.coreDataHashes = readStoreModelHashes(db, tables)
 
 	// Generate schema dump
 	if schema, err := generateSchema(tables); err == nil {
@@ -76,8 +136,9 @@ func getSQLiteVersion(db *sql.DB) (string, error) {
 	return version, err
 }
 
-// getAllTables gets information about all tables in the database
-func getAllTables(db *sql.DB) ([]TableInfo, error) {
+// getAllTables gets information about all tables in the database at
+// path, open as db
+func getAllTables(db *sql.DB, path string) ([]TableInfo, error) {
 	// Query sqlite_master for all tables
 	rows, err := db.Query(`
 		SELECT name, sql FROM sqlite_master
@@ -102,8 +163,10 @@ func getAllTables(db *sql.DB) ([]TableInfo, error) {
 		}
 
 		// Get row count
-		if count, err := getTableRowCount(db, tableName); err == nil {
+		virtual := isVirtualTable(tableSQL)
+		if count, err := cachedRowCount(db, path, tableName, virtual); err == nil {
 			table.RowCount = count
+			table.RowCountEstimated = virtual
 		}
 
 		// Get column info
@@ -111,8 +174,8 @@ func getAllTables(db *sql.DB) ([]TableInfo, error) {
 			table.Columns = columns
 		}
 
-		// Get sample data (first 5 rows)
-		if sample, err := getTableSample(db, tableName, 5); err == nil {
+		// Get sample data
+		if sample, err := getTableSample(db, tableName, MaxSampleRows); err == nil {
 			table.Sample = sample
 		}
 
@@ -130,14 +193,90 @@ func quoteSQLiteIdentifier(name string) string {
 	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
 }
 
-// getTableRowCount gets the number of rows in a table
-func getTableRowCount(db *sql.DB, tableName string) (int64, error) {
+// isVirtualTable reports whether tableSQL, a table's sqlite_master
+// entry, creates a virtual table such as an FTS index
+func isVirtualTable(tableSQL string) bool {
+	fields := strings.Fields(strings.ToUpper(tableSQL))
+	return len(fields) >= 3 && fields[0] == "CREATE" && fields[1] == "VIRTUAL" && fields[2] == "TABLE"
+}
+
+// getTableRowCount gets the number of rows in a table. COUNT(*) on a
+// virtual table makes its module produce every row, which for an FTS
+// index means decoding the whole index, so for those the largest rowid
+// is used as an estimate. Asking for it with ORDER BY lets the module
+// read it from the end of the table rather than scanning.
+func getTableRowCount(db *sql.DB, tableName string, virtual bool) (int64, error) {
 	var count int64
+	if virtual {
+		query := "SELECT rowid FROM " + quoteSQLiteIdentifier(tableName) + " ORDER BY rowid DESC LIMIT 1"
+		err := db.QueryRow(query).Scan(&count)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return count, err
+	}
 	query := "SELECT COUNT(*) FROM " + quoteSQLiteIdentifier(tableName)
 	err := db.QueryRow(query).Scan(&count)
 	return count, err
 }
 
+// rowCountKey identifies a table in a database file
+type rowCountKey struct {
+	path  string
+	table string
+}
+
+// rowCountEntry is a cached row count, with the database file's
+// modification time when it was counted
+type rowCountEntry struct {
+	count   int64
+	modTime time.Time
+	counted time.Time
+}
+
+var (
+	rowCountMu       sync.Mutex
+	rowCountCache    = make(map[rowCountKey]rowCountEntry)
+	rowCountCacheTTL = time.Minute
+)
+
+// SetRowCountCacheTTL sets how long table row counts are reused
+func SetRowCountCacheTTL(ttl time.Duration) {
+	rowCountMu.Lock()
+	defer rowCountMu.Unlock()
+	rowCountCacheTTL = ttl
+}
+
+// cachedRowCount returns the number of rows in tableName of the database
+// at path, open as db. COUNT(*) scans the whole table, which takes
+// seconds for millions of rows, so a count is reused for the cache TTL
+// as long as the file hasn't been modified since. virtual is whether
+// the table is a virtual table, whose count is estimated.
+func cachedRowCount(db *sql.DB, path, tableName string, virtual bool) (int64, error) {
+	key := rowCountKey{path: path, table: tableName}
+	var modTime time.Time
+	if info, err := os.Stat(path); err == nil {
+		modTime = info.ModTime()
+	}
+
+	rowCountMu.Lock()
+	entry, ok := rowCountCache[key]
+	ttl := rowCountCacheTTL
+	rowCountMu.Unlock()
+	if ok && entry.modTime.Equal(modTime) && time.Since(entry.counted) < ttl {
+		return entry.count, nil
+	}
+
+	count, err := getTableRowCount(db, tableName, virtual)
+	if err != nil {
+		return 0, err
+	}
+	rowCountMu.Lock()
+	rowCountCache[key] = rowCountEntry{count: count, modTime: modTime, counted: time.Now()}
+	rowCountMu.Unlock()
+	return count, nil
+}
+
 // getTableColumns gets information about table columns
 func getTableColumns(db *sql.DB, tableName string) ([]ColumnInfo, error) {
 	query := "PRAGMA table_info(" + quoteSQLiteIdentifier(tableName) + ")"
@@ -179,6 +318,86 @@ func getTableSample(db *sql.DB, tableName string, limit int) ([]map[string]any,
 	}
 	defer func() { _ = rows.Close() }()
 
+	return scanTableRows(rows)
+}
+
+// SearchTableData returns up to limit rows of table whose text columns
+// contain query, in the order of sortColumn (table order if ""), along
+// with the total number of matching rows. LIKE matching makes the search
+// case-insensitive for ASCII letters.
+func SearchTableData(dbPath string, table *TableInfo, query, sortColumn string, ascending bool, limit int) ([]map[string]any, int, error) {
+	if isRealmFile(dbPath) {
+		return nil, 0, errors.New("search is not supported for Realm databases")
+	}
+	where, args := buildSearchQuery(table, query)
+	if where == "" {
+		return nil, 0, fmt.Errorf("table %s has no text columns to search", table.Name)
+	}
+
+	db, err := openReadOnlyDB(dbPath)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = db.Close() }()
+
+	from := " FROM " + quoteSQLiteIdentifier(table.Name) + " " + where
+	var total int
+	if err := db.QueryRow("SELECT COUNT(*)"+from, args...).Scan(&total); err != nil {
+		return nil, 0, withHint(err)
+	}
+
+	selectQuery := "SELECT *" + from
+	if sortColumn != "" {
+		direction := " ASC"
+		if !ascending {
+			direction = " DESC"
+		}
+		selectQuery += " ORDER BY " + quoteSQLiteIdentifier(sortColumn) + direction
+	}
+	selectQuery += " LIMIT " + strconv.Itoa(limit)
+	rows, err := db.Query(selectQuery, args...)
+	if err != nil {
+		return nil, 0, withHint(err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	data, err := scanTableRows(rows)
+	return data, total, err
+}
+
+// buildSearchQuery returns a WHERE clause matching rows of table with
+// query in any of its text columns, OR-joining one LIKE per column, and
+// the arguments for its placeholders. LIKE wildcards in query match
+// literally. It returns "" if the table has no text columns.
+func buildSearchQuery(table *TableInfo, query string) (string, []any) {
+	escaper := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	pattern := "%" + escaper.Replace(query) + "%"
+
+	var clauses []string
+	var args []any
+	for _, col := range table.Columns {
+		if !isTextColumn(col) {
+			continue
+		}
+		clauses = append(clauses, quoteSQLiteIdentifier(col.Name)+` LIKE ? ESCAPE '\'`)
+		args = append(args, pattern)
+	}
+	if len(clauses) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(clauses, " OR "), args
+}
+
+// isTextColumn reports whether col has SQLite's TEXT affinity, i.e. its
+// declared type contains CHAR, CLOB or TEXT (VARCHAR, NVARCHAR, ...)
+func isTextColumn(col ColumnInfo) bool {
+	colType := strings.ToUpper(col.Type)
+	return strings.Contains(colType, "CHAR") || strings.Contains(colType, "CLOB") || strings.Contains(colType, "TEXT")
+}
+
+// scanTableRows reads all rows into maps from column name to value,
+// converting byte slices to strings for display
+func scanTableRows(rows *sql.Rows) ([]map[string]any, error) {
 	// Get column names
 	columns, err := rows.Columns()
 	if err != nil {
@@ -187,35 +406,44 @@ func getTableSample(db *sql.DB, tableName string, limit int) ([]map[string]any,
 
 	var result []map[string]any
 	for rows.Next() {
-		// Create slice to hold values
-		values := make([]any, len(columns))
-		valuePtrs := make([]any, len(columns))
-		for i := range values {
-			valuePtrs[i] = &values[i]
-		}
-
-		if err := rows.Scan(valuePtrs...); err != nil {
+		row, err := scanRow(rows, columns)
+		if err != nil {
 			continue
 		}
-
-		// Create map from column names to values
-		row := make(map[string]any)
-		for i, col := range columns {
-			val := values[i]
-			if b, ok := val.([]byte); ok {
-				// Convert byte slices to strings for display
-				row[col] = string(b)
-			} else {
-				row[col] = val
-			}
-		}
-
 		result = append(result, row)
 	}
 
 	return result, nil
 }
 
+// scanRow reads the current row into a map from column name to value,
+// converting byte slices to strings for display
+func scanRow(rows *sql.Rows, columns []string) (map[string]any, error) {
+	// Create slice to hold values
+	values := make([]any, len(columns))
+	valuePtrs := make([]any, len(columns))
+	for i := range values {
+		valuePtrs[i] = &values[i]
+	}
+
+	if err := rows.Scan(valuePtrs...); err != nil {
+		return nil, err
+	}
+
+	// Create map from column names to values
+	row := make(map[string]any)
+	for i, col := range columns {
+		val := values[i]
+		if b, ok := val.([]byte); ok {
+			// Convert byte slices to strings for display
+			row[col] = string(b)
+		} else {
+			row[col] = val
+		}
+	}
+	return row, nil
+}
+
 // generateSchema generates a schema dump for the database. It operates
 // entirely on the prepared TableInfo slice and does not need a live DB
 // connection.
@@ -236,6 +464,16 @@ func generateSchema(tables []TableInfo) (string, error) {
 
 // ReadTableData reads paginated data from a specific table
 func ReadTableData(dbPath, tableName string, offset, limit int) ([]map[string]any, error) {
+	return ReadSortedTableData(dbPath, tableName, "", true, offset, limit)
+}
+
+// ReadSortedTableData reads paginated data from a specific table ordered
+// by sortColumn, ascending or descending. An empty sortColumn keeps the
+// table's own order.
+func ReadSortedTableData(dbPath, tableName, sortColumn string, ascending bool, offset, limit int) ([]map[string]any, error) {
+	if isRealmFile(dbPath) {
+		return readRealmTableData(dbPath, tableName, sortColumn, ascending, offset, limit)
+	}
 	db, err := openReadOnlyDB(dbPath)
 	if err != nil {
 		return nil, err
@@ -243,16 +481,26 @@ func ReadTableData(dbPath, tableName string, offset, limit int) ([]map[string]an
 	defer func() { _ = db.Close() }()
 
 	if err := db.Ping(); err != nil {
+		if hint := openFailureHint(dbPath, err); hint != "" {
+			return nil, &hintError{err: err, hint: hint}
+		}
 		return nil, err
 	}
 
-	// Build query with pagination
-	query := "SELECT * FROM " + quoteSQLiteIdentifier(tableName) +
-		" LIMIT " + strconv.Itoa(limit) +
+	// Build query with sorting and pagination
+	query := "SELECT * FROM " + quoteSQLiteIdentifier(tableName)
+	if sortColumn != "" {
+		direction := " ASC"
+		if !ascending {
+			direction = " DESC"
+		}
+		query += " ORDER BY " + quoteSQLiteIdentifier(sortColumn) + direction
+	}
+	query += " LIMIT " + strconv.Itoa(limit) +
 		" OFFSET " + strconv.Itoa(offset)
 	rows, err := db.Query(query)
 	if err != nil {
-		return nil, err
+		return nil, withHint(err)
 	}
 	defer func() { _ = rows.Close() }()
 
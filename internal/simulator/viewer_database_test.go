@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -221,6 +222,86 @@ func TestReadTableData_Pagination(t *testing.T) {
 	}
 }
 
+func TestReadSortedTableData(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "sorted.db")
+	createTestDB(t, dbPath,
+		`CREATE TABLE "my items" ("sort key" TEXT, n INTEGER)`,
+		`INSERT INTO "my items" VALUES ('b', 2), ('c', 1), ('a', 3)`,
+	)
+
+	rows, err := ReadSortedTableData(dbPath, "my items", "sort key", true, 0, 10)
+	if err != nil {
+		t.Fatalf("ReadSortedTableData() error = %v", err)
+	}
+	if got := fmt.Sprint(rows[0]["sort key"], rows[1]["sort key"], rows[2]["sort key"]); got != "abc" {
+		t.Errorf("ascending order = %s, want abc", got)
+	}
+
+	rows, err = ReadSortedTableData(dbPath, "my items", "n", false, 1, 10)
+	if err != nil {
+		t.Fatalf("ReadSortedTableData() error = %v", err)
+	}
+	if len(rows) != 2 || rows[0]["n"] != int64(2) {
+		t.Errorf("descending page from offset 1 = %v, want n = 2, 1", rows)
+	}
+}
+
+func TestSearchTableData(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "search.db")
+	createTestDB(t, dbPath,
+		`CREATE TABLE users (id INTEGER, name TEXT, email VARCHAR(64))`,
+		`INSERT INTO users VALUES (1, 'Alice', 'alice@example.com'), (2, 'Bob', 'bob@test.org'), (3, 'Carol', 'carol_100%@example.com')`,
+	)
+	table := &TableInfo{Name: "users", Columns: []ColumnInfo{
+		{Name: "id", Type: "INTEGER"},
+		{Name: "name", Type: "TEXT"},
+		{Name: "email", Type: "VARCHAR(64)"},
+	}}
+
+	rows, total, err := SearchTableData(dbPath, table, "EXAMPLE", "id", false, 10)
+	if err != nil {
+		t.Fatalf("SearchTableData() error = %v", err)
+	}
+	if total != 2 || len(rows) != 2 || rows[0]["name"] != "Carol" {
+		t.Errorf("search for EXAMPLE = %d matches %v, want Carol and Alice", total, rows)
+	}
+
+	rows, total, err = SearchTableData(dbPath, table, "example", "", true, 1)
+	if err != nil {
+		t.Fatalf("SearchTableData() error = %v", err)
+	}
+	if total != 2 || len(rows) != 1 {
+		t.Errorf("limited search = %d matches, %d rows, want 2 matches, 1 row", total, len(rows))
+	}
+
+	// Wildcards and quotes are matched literally
+	for query, want := range map[string]int{"0%": 1, "_": 1, "%": 1, "' OR 1=1 --": 0} {
+		if _, total, err := SearchTableData(dbPath, table, query, "", true, 10); err != nil || total != want {
+			t.Errorf("search for %q = %d matches (err %v), want %d", query, total, err, want)
+		}
+	}
+}
+
+func TestBuildSearchQuery(t *testing.T) {
+	table := &TableInfo{Name: "t", Columns: []ColumnInfo{
+		{Name: "id", Type: "INTEGER"},
+		{Name: "title", Type: "NVARCHAR(20)"},
+		{Name: "body", Type: "text"},
+	}}
+	where, args := buildSearchQuery(table, "a%b")
+	want := `WHERE "title" LIKE ? ESCAPE '\' OR "body" LIKE ? ESCAPE '\'`
+	if where != want {
+		t.Errorf("where = %s, want %s", where, want)
+	}
+	if len(args) != 2 || args[0] != `%a\%b%` {
+		t.Errorf("args = %v, want two escaped patterns", args)
+	}
+
+	if where, _ := buildSearchQuery(&TableInfo{Columns: []ColumnInfo{{Name: "n", Type: "INTEGER"}}}, "x"); where != "" {
+		t.Errorf("where without text columns = %q, want empty", where)
+	}
+}
+
 func TestReadTableData_InvalidDBPath(t *testing.T) {
 	// Regression test paired with TestReadDatabaseInfo_MissingFile:
 	// missing file should surface as an error via openReadOnlyDB's
@@ -332,3 +413,175 @@ func TestGetSQLiteVersion(t *testing.T) {
 		t.Errorf("version %q does not look like a semver", version)
 	}
 }
+
+func TestFindDatabases(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "Library", "Application Support")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{
+		filepath.Join(root, "cache.db"),
+		filepath.Join(nested, "Model.sqlite"),
+		filepath.Join(root, "notes.txt"),
+	} {
+		if err := os.WriteFile(path, []byte("x"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := FindDatabases("file://" + root)
+	var names []string
+	for _, db := range got {
+		names = append(names, db.Name)
+	}
+	want := "Library/Application Support/Model.sqlite,cache.db"
+	if strings.Join(names, ",") != want {
+		t.Errorf("FindDatabases() names = %v, want %s", names, want)
+	}
+	if len(got) > 0 && got[0].Path != filepath.Join(nested, "Model.sqlite") {
+		t.Errorf("Path = %q, want absolute path", got[0].Path)
+	}
+}
+
+func TestFindDatabases_MissingRoot(t *testing.T) {
+	if got := FindDatabases(filepath.Join(t.TempDir(), "missing")); len(got) != 0 {
+		t.Errorf("FindDatabases() = %v, want none", got)
+	}
+}
+
+func TestCachedRowCount(t *testing.T) {
+	t.Cleanup(func() { SetRowCountCacheTTL(time.Minute) })
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	createTestDB(t, dbPath,
+		`CREATE TABLE notes (id INTEGER)`,
+		`INSERT INTO notes VALUES (1), (2)`,
+	)
+	countNotes := func() int64 {
+		t.Helper()
+		info, err := readDatabaseInfo(dbPath)
+		if err != nil || len(info.Tables) != 1 {
+			t.Fatalf("readDatabaseInfo() = %+v, %v", info, err)
+		}
+		return info.Tables[0].RowCount
+	}
+	// addRow inserts a row, keeping the file's modification time if
+	// keepModTime is set
+	addRow := func(keepModTime bool) {
+		t.Helper()
+		stat, err := os.Stat(dbPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		createTestDB(t, dbPath, `INSERT INTO notes VALUES (3)`)
+		modTime := stat.ModTime()
+		if !keepModTime {
+			modTime = modTime.Add(time.Second)
+		}
+		if err := os.Chtimes(dbPath, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := countNotes(); got != 2 {
+		t.Fatalf("RowCount = %d, want 2", got)
+	}
+	addRow(true)
+	if got := countNotes(); got != 2 {
+		t.Errorf("RowCount = %d, want the cached 2", got)
+	}
+	addRow(false)
+	if got := countNotes(); got != 4 {
+		t.Errorf("RowCount = %d, want 4 after the file changed", got)
+	}
+
+	SetRowCountCacheTTL(0)
+	addRow(true)
+	if got := countNotes(); got != 5 {
+		t.Errorf("RowCount = %d, want 5 without caching", got)
+	}
+}
+
+func TestFormatRowCount(t *testing.T) {
+	tests := map[int64]string{
+		0:         "0",
+		950:       "950",
+		1_000:     "1K",
+		12_500:    "12.5K",
+		999_949:   "999.9K",
+		999_950:   "1M",
+		1_234_567: "1.2M",
+	}
+	for count, want := range tests {
+		if got := FormatRowCount(count); got != want {
+			t.Errorf("FormatRowCount(%d) = %q, want %q", count, got, want)
+		}
+	}
+}
+
+func TestTableInfo_IsLarge(t *testing.T) {
+	if (TableInfo{RowCount: LargeTableRows}).IsLarge() {
+		t.Error("a table of exactly LargeTableRows rows is not large")
+	}
+	if !(TableInfo{RowCount: LargeTableRows + 1}).IsLarge() {
+		t.Error("a table of more than LargeTableRows rows is large")
+	}
+}
+
+func TestIsVirtualTable(t *testing.T) {
+	tests := map[string]bool{
+		`CREATE VIRTUAL TABLE docs USING fts5(body)`:   true,
+		"create  virtual\ntable docs using fts4(body)": true,
+		`CREATE TABLE docs (body TEXT)`:                false,
+		`CREATE TABLE "virtual" (body TEXT)`:           false,
+		``:                                             false,
+	}
+	for tableSQL, want := range tests {
+		if got := isVirtualTable(tableSQL); got != want {
+			t.Errorf("isVirtualTable(%q) = %v, want %v", tableSQL, got, want)
+		}
+	}
+}
+
+func TestVirtualTableRowCount(t *testing.T) {
+	for _, module := range []string{"fts4", "fts5"} {
+		t.Run(module, func(t *testing.T) {
+			dbPath := filepath.Join(t.TempDir(), "search.db")
+			db, err := sql.Open("sqlite3", dbPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = db.Exec(`CREATE VIRTUAL TABLE docs USING ` + module + `(body)`)
+			_ = db.Close()
+			if err != nil && strings.Contains(err.Error(), "no such module") {
+				t.Skipf("SQLite built without %s", module)
+			}
+			createTestDB(t, dbPath,
+				`INSERT INTO docs(rowid, body) VALUES (1, 'one'), (2, 'two'), (3, 'three')`,
+				`DELETE FROM docs WHERE rowid = 2`,
+			)
+
+			info, err := readDatabaseInfo(dbPath)
+			if err != nil || info.Error != "" {
+				t.Fatalf("readDatabaseInfo() = %+v, %v", info, err)
+			}
+			var docs *TableInfo
+			for i := range info.Tables {
+				if info.Tables[i].Name == "docs" {
+					docs = &info.Tables[i]
+				} else if info.Tables[i].RowCountEstimated {
+					t.Errorf("shadow table %s should be counted exactly", info.Tables[i].Name)
+				}
+			}
+			if docs == nil {
+				t.Fatalf("no docs table in %+v", info.Tables)
+			}
+			if docs.RowCount != 3 || !docs.RowCountEstimated {
+				t.Errorf("docs RowCount = %d (estimated %v), want the largest rowid 3, estimated", docs.RowCount, docs.RowCountEstimated)
+			}
+			if got := docs.RowCountText(); got != "~3" {
+				t.Errorf("RowCountText() = %q, want ~3", got)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package simulator
 
 import (
 	"bytes"
+	"container/list"
 	"log"
 	"strings"
 	"sync"
@@ -19,14 +20,93 @@ var (
 	lexerCache = make(map[string]chroma.Lexer)
 	lexerMutex sync.RWMutex
 
-	// Terminal formatter and style
-	termFormatter chroma.Formatter
-	chromaStyle   *chroma.Style
+	// Terminal formatter and style, guarded by styleMutex. The style is
+	// rebuilt when chromaStyleVersion has moved past the version it was
+	// loaded for; ResetSyntaxStyle bumps it.
+	termFormatter      chroma.Formatter
+	chromaStyle        *chroma.Style
+	styleMutex         sync.Mutex
+	chromaStyleVersion int
+	loadedStyleVersion int
+
+	// Lines already highlighted with the current style, so redrawing
+	// the same screen doesn't tokenize them again
+	syntaxCache = newHighlightCache(syntaxCacheSize)
+)
 
-	// Initialize once
-	initOnce sync.Once
+// Limits of the highlighted line cache
+const (
+	// syntaxCacheSize is the number of highlighted lines kept
+	syntaxCacheSize = 10_000
+	// syntaxCacheMaxLine is the longest line, in bytes, that is cached.
+	// Highlighting adds escape codes around every token, so a few
+	// minified lines could otherwise fill memory.
+	syntaxCacheMaxLine = 1000
 )
 
+// highlightCache is a least recently used cache of highlighted lines,
+// safe for concurrent use
+type highlightCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List // Of highlightEntry, most recently used first
+}
+
+// highlightEntry is a line in a highlightCache
+type highlightEntry struct {
+	key         string
+	highlighted string
+}
+
+// newHighlightCache returns an empty cache that keeps up to capacity
+// lines
+func newHighlightCache(capacity int) *highlightCache {
+	return &highlightCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// get returns the highlighted line stored under key
+func (c *highlightCache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(highlightEntry).highlighted, true
+}
+
+// add stores highlighted under key, evicting the least recently used
+// line when the cache is full
+func (c *highlightCache) add(key, highlighted string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = highlightEntry{key: key, highlighted: highlighted}
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(highlightEntry{key: key, highlighted: highlighted})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(highlightEntry).key)
+	}
+}
+
+// clear empties the cache
+func (c *highlightCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+	c.order.Init()
+}
+
 // initChromaStyle initializes the chroma style from config.
 //
 // Failures here are surfaced via the tea debug log rather than silently
@@ -35,26 +115,47 @@ var (
 // keeps rendering. The `github-dark` fallback below is the documented
 // recovery path for an unknown theme name — the user can inspect
 // `--list-themes` to see valid names.
-func initChromaStyle() {
-	initOnce.Do(func() {
-		termFormatter = formatters.Get("terminal16m")
-
-		cfg, err := config.Load()
-		if err != nil {
-			// config.Load returns a valid defaults Config alongside the
-			// error, so cfg is safe to use. Surface the error explicitly.
-			log.Printf("initChromaStyle: config load failed, using defaults: %v", err)
-		}
+//
+// It returns the formatter and style to highlight with, so callers
+// don't read the package variables while another goroutine resets them.
+func initChromaStyle() (chroma.Formatter, *chroma.Style) {
+	styleMutex.Lock()
+	defer styleMutex.Unlock()
+	if chromaStyle != nil && loadedStyleVersion == chromaStyleVersion {
+		return termFormatter, chromaStyle
+	}
 
-		themeName := cfg.GetActiveTheme()
-		style := styles.Get(themeName)
-		if style == nil || style == styles.Fallback {
-			log.Printf("initChromaStyle: theme %q not found, falling back to github-dark", themeName)
-			style = styles.Get("github-dark")
-		}
+	termFormatter = formatters.Get("terminal16m")
 
-		chromaStyle = style
-	})
+	cfg, err := config.Load()
+	if err != nil {
+		// config.Load returns a valid defaults Config alongside the
+		// error, so cfg is safe to use. Surface the error explicitly.
+		log.Printf("initChromaStyle: config load failed, using defaults: %v", err)
+	}
+
+	themeName := cfg.GetActiveTheme()
+	style := styles.Get(themeName)
+	if style == nil || style == styles.Fallback {
+		log.Printf("initChromaStyle: theme %q not found, falling back to github-dark", themeName)
+		style = styles.Get("github-dark")
+	}
+
+	chromaStyle = style
+	loadedStyleVersion = chromaStyleVersion
+	// Lines highlighted with the previous style are out of date
+	syntaxCache.clear()
+	return termFormatter, chromaStyle
+}
+
+// ResetSyntaxStyle discards the cached chroma style so the next highlight
+// call re-reads the theme from config. Call it after the config file has
+// been reloaded or the terminal has switched between light and dark, as
+// either can change the active theme.
+func ResetSyntaxStyle() {
+	styleMutex.Lock()
+	defer styleMutex.Unlock()
+	chromaStyleVersion++
 }
 
 // GetSyntaxHighlightedLine returns a syntax highlighted version of a line
@@ -67,13 +168,18 @@ func GetSyntaxHighlightedLine(line string, fileExt string) string {
 // with support for detected language override
 func GetSyntaxHighlightedLineWithLang(line string, fileExt string, detectedLang string) string {
 	// Initialize style if needed
-	initChromaStyle()
+	formatter, style := initChromaStyle()
 
 	// Quick return for empty lines
 	if strings.TrimSpace(line) == "" {
 		return line
 	}
 
+	cacheKey := fileExt + "|" + detectedLang + "|" + line
+	if highlighted, ok := syntaxCache.get(cacheKey); ok {
+		return highlighted
+	}
+
 	// Get or create lexer for this file extension
 	var lexer chroma.Lexer
 
@@ -100,12 +206,12 @@ func GetSyntaxHighlightedLineWithLang(line string, fileExt string, detectedLang
 
 	// Format the tokens
 	var buf bytes.Buffer
-	if termFormatter == nil || chromaStyle == nil {
+	if formatter == nil || style == nil {
 		// Formatter or style not initialized properly
 		return line
 	}
 
-	err = termFormatter.Format(&buf, chromaStyle, iterator)
+	err = formatter.Format(&buf, style, iterator)
 	if err != nil {
 		return line
 	}
@@ -116,13 +222,53 @@ func GetSyntaxHighlightedLineWithLang(line string, fileExt string, detectedLang
 		return line
 	}
 
-	return strings.TrimRight(result, "\n")
+	result = strings.TrimRight(result, "\n")
+	if len(line) <= syntaxCacheMaxLine {
+		syntaxCache.add(cacheKey, result)
+	}
+	return result
+}
+
+// HighlightWithTheme highlights line with the named chroma theme and
+// formatter instead of the configured ones. It is used by the
+// `--list-themes --preview` CLI path, which renders the same snippet once
+// per theme. Unknown themes and formatters fall back to chroma's defaults;
+// an extension with no lexer returns the line unchanged.
+func HighlightWithTheme(line, fileExt, themeName, formatterName string) string {
+	if strings.TrimSpace(line) == "" {
+		return line
+	}
+
+	lexer := getLexerForExtension(fileExt)
+	formatter := formatters.Get(formatterName)
+	if lexer == nil {
+		return line
+	}
+
+	iterator, err := lexer.Tokenise(nil, line)
+	if err != nil {
+		return line
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.Format(&buf, styles.Get(themeName), iterator); err != nil {
+		return line
+	}
+
+	return strings.TrimRight(buf.String(), "\n")
 }
 
 // detectContentLanguage detects the programming/markup language based on content
 func detectContentLanguage(content string) string {
 	trimmed := strings.TrimSpace(strings.ToLower(content))
 
+	// .ips crash reports are a JSON header line followed by a JSON
+	// body. Check them first: symbol names in the body can look like
+	// markup.
+	if strings.HasPrefix(trimmed, "{") && strings.Contains(trimmed, `"bug_type"`) {
+		return "json"
+	}
+
 	// Check for HTML patterns
 	htmlPatterns := []string{
 		"<!doctype html",
@@ -223,6 +369,9 @@ func getLexerForExtension(fileExt string) chroma.Lexer {
 			lexer = lexers.Get("react")
 		case ".plist":
 			lexer = lexers.Get("xml")
+		case ".ips":
+			// Crash reports in Apple's newer format are JSON
+			lexer = lexers.Get("json")
 		case ".htm", ".html":
 			lexer = lexers.Get("html")
 		case ".podspec":
@@ -5,33 +5,27 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"sync"
 	"testing"
 
 	"github.com/alecthomas/chroma/v2/styles"
 )
 
 // resetChromaInit clears the cached chroma state so initChromaStyle
-// runs its body again on the next call. Only valid in tests that do
-// not run in parallel.
-//
-// sync.Once embeds sync.noCopy, so we can't snapshot it by value;
-// instead we zero it and let the cleanup also zero it after the test,
-// which is sound because no other goroutine touches these package
-// variables in the test binary.
+// runs its body again on the next call, and puts it back after the
+// test. Only valid in tests that do not run in parallel.
 func resetChromaInit(t *testing.T) {
 	t.Helper()
 	prevStyle := chromaStyle
 	prevFormatter := termFormatter
+	prevLoaded := loadedStyleVersion
 
-	initOnce = sync.Once{}
 	chromaStyle = nil
 	termFormatter = nil
 
 	t.Cleanup(func() {
-		initOnce = sync.Once{}
 		chromaStyle = prevStyle
 		termFormatter = prevFormatter
+		loadedStyleVersion = prevLoaded
 	})
 }
 
@@ -137,3 +131,60 @@ dark_theme = "monokai"
 		t.Error("termFormatter is nil after init")
 	}
 }
+
+func TestResetSyntaxStyle_PicksUpNewTheme(t *testing.T) {
+	path := writeConfig(t, "[theme]\nmode = \"dark\"\ndark_theme = \"monokai\"\n")
+	resetChromaInit(t)
+
+	initChromaStyle()
+	if chromaStyle != styles.Get("monokai") {
+		t.Fatalf("chromaStyle = %v, want monokai", chromaStyle)
+	}
+
+	if err := os.WriteFile(path, []byte("[theme]\nmode = \"dark\"\ndark_theme = \"dracula\"\n"), 0600); err != nil {
+		t.Fatalf("rewrite config: %v", err)
+	}
+
+	// Without a reset the cached style sticks.
+	initChromaStyle()
+	if chromaStyle != styles.Get("monokai") {
+		t.Fatalf("chromaStyle changed before reset: %v", chromaStyle)
+	}
+
+	ResetSyntaxStyle()
+	initChromaStyle()
+	if chromaStyle != styles.Get("dracula") {
+		t.Errorf("chromaStyle = %v after reset, want dracula", chromaStyle)
+	}
+}
+
+func TestThemeReload(t *testing.T) {
+	path := writeConfig(t, "[theme]\nmode = \"dark\"\ndark_theme = \"monokai\"\nlight_theme = \"dracula\"\n")
+	resetChromaInit(t)
+
+	highlight := func() string { return GetSyntaxHighlightedLine(`x := "hello"`, ".go") }
+	dark := highlight()
+	if chromaStyle != styles.Get("monokai") {
+		t.Fatalf("chromaStyle = %v, want monokai", chromaStyle)
+	}
+
+	// The terminal switched to light; the theme is picked up once the
+	// style is reset, without restarting
+	if err := os.WriteFile(path, []byte("[theme]\nmode = \"light\"\ndark_theme = \"monokai\"\nlight_theme = \"dracula\"\n"), 0600); err != nil {
+		t.Fatalf("rewrite config: %v", err)
+	}
+	if highlight() != dark {
+		t.Fatal("style changed before ResetSyntaxStyle")
+	}
+	ResetSyntaxStyle()
+	if light := highlight(); light == dark || chromaStyle != styles.Get("dracula") {
+		t.Errorf("chromaStyle = %v after reset, want dracula with different colors", chromaStyle.Name)
+	}
+
+	// Resetting again goes back to the config's theme each time
+	ResetSyntaxStyle()
+	ResetSyntaxStyle()
+	if highlight(); chromaStyle != styles.Get("dracula") || loadedStyleVersion != chromaStyleVersion {
+		t.Errorf("chromaStyle = %v, loaded version %d of %d", chromaStyle.Name, loadedStyleVersion, chromaStyleVersion)
+	}
+}
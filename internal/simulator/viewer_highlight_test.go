@@ -139,3 +139,95 @@ func TestGetSyntaxHighlightedLineWithLang_NoLexerReturnsInput(t *testing.T) {
 		t.Errorf("expected plain passthrough, got %q", out)
 	}
 }
+
+func TestHighlightCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := newHighlightCache(2)
+	c.add("a", "A")
+	c.add("b", "B")
+	if _, ok := c.get("a"); !ok {
+		t.Fatal("a should be cached")
+	}
+	// b is now the least recently used, so adding c evicts it
+	c.add("c", "C")
+	if _, ok := c.get("b"); ok {
+		t.Error("b should have been evicted")
+	}
+	for key, want := range map[string]string{"a": "A", "c": "C"} {
+		if got, ok := c.get(key); !ok || got != want {
+			t.Errorf("get(%q) = %q, %v, want %q", key, got, ok, want)
+		}
+	}
+
+	c.clear()
+	if _, ok := c.get("a"); ok {
+		t.Error("clear should empty the cache")
+	}
+}
+
+func TestGetSyntaxHighlightedLine_Cached(t *testing.T) {
+	line := "func cached() {}"
+	first := GetSyntaxHighlightedLine(line, ".go")
+	if cached, ok := syntaxCache.get(".go||" + line); !ok || cached != first {
+		t.Fatalf("highlighted line should be cached, got %q, %v", cached, ok)
+	}
+	if again := GetSyntaxHighlightedLine(line, ".go"); again != first {
+		t.Errorf("cached highlight = %q, want %q", again, first)
+	}
+	// The same line in another language is highlighted separately
+	if html := GetSyntaxHighlightedLineWithLang(line, ".go", "html"); html == first {
+		t.Error("a detected language should not share the extension's cached line")
+	}
+
+	long := "var x = " + strings.Repeat("1", syntaxCacheMaxLine)
+	GetSyntaxHighlightedLine(long, ".go")
+	if _, ok := syntaxCache.get(".go||" + long); ok {
+		t.Errorf("lines over %d bytes should not be cached", syntaxCacheMaxLine)
+	}
+
+	// Reloading the style drops lines highlighted with the old one
+	ResetSyntaxStyle()
+	GetSyntaxHighlightedLine("package main", ".go")
+	if _, ok := syntaxCache.get(".go||" + line); ok {
+		t.Error("a style reload should clear the cache")
+	}
+}
+
+func TestHighlightWithTheme(t *testing.T) {
+	const snippet = `func main() { fmt.Println("hi") }`
+
+	t.Run("known theme emits ANSI escapes", func(t *testing.T) {
+		got := HighlightWithTheme(snippet, ".go", "monokai", "terminal256")
+		if !strings.Contains(got, "\x1b[") {
+			t.Errorf("expected ANSI escapes in %q", got)
+		}
+		if strings.HasSuffix(got, "\n") {
+			t.Errorf("trailing newline not trimmed: %q", got)
+		}
+	})
+
+	t.Run("different themes render differently", func(t *testing.T) {
+		a := HighlightWithTheme(snippet, ".go", "monokai", "terminal16m")
+		b := HighlightWithTheme(snippet, ".go", "github", "terminal16m")
+		if a == b {
+			t.Error("expected monokai and github to produce different output")
+		}
+	})
+
+	t.Run("blank line passes through", func(t *testing.T) {
+		if got := HighlightWithTheme("   ", ".go", "monokai", "terminal256"); got != "   " {
+			t.Errorf("got %q, want unchanged", got)
+		}
+	})
+
+	t.Run("unknown formatter returns plain line", func(t *testing.T) {
+		if got := HighlightWithTheme(snippet, ".go", "monokai", "no-such-formatter"); got != snippet {
+			t.Errorf("got %q, want unchanged", got)
+		}
+	})
+
+	t.Run("unknown extension returns plain line", func(t *testing.T) {
+		if got := HighlightWithTheme(snippet, ".zzz-unknown", "monokai", "terminal256"); got != snippet {
+			t.Errorf("got %q, want unchanged", got)
+		}
+	})
+}
@@ -268,53 +268,96 @@ func readSVGInfo(path string, fileSize int64, maxPreviewHeight, maxPreviewWidth
 	return info, nil
 }
 
-// extractSVGDimensions tries to extract width and height from SVG content
+// extractSVGDimensions tries to extract width and height from SVG content.
+// Absolute width and height attributes win. A missing one is taken from
+// the viewBox, and when either is a percentage, both come from the
+// viewBox, as the document then has no size of its own.
 func extractSVGDimensions(svgContent string) (int, int) {
-	width, height := defaultSVGDimension, defaultSVGDimension
-
-	// Try to extract width
-	if widthMatch := strings.Index(svgContent, `width="`); widthMatch != -1 {
-		widthStart := widthMatch + 7
-		widthEnd := strings.Index(svgContent[widthStart:], `"`)
-		if widthEnd != -1 {
-			if w, err := strconv.Atoi(svgContent[widthStart : widthStart+widthEnd]); err == nil {
-				width = w
-			}
+	width, widthPercent, widthOK := parseSVGLength(svgAttribute(svgContent, "width"))
+	height, heightPercent, heightOK := parseSVGLength(svgAttribute(svgContent, "height"))
+
+	// Try viewBox if width/height not found
+	viewBoxWidth, viewBoxHeight, viewBoxOK := 0, 0, false
+	if parts := strings.Fields(svgAttribute(svgContent, "viewBox")); len(parts) >= 4 {
+		w, errW := strconv.ParseFloat(parts[2], 64)
+		h, errH := strconv.ParseFloat(parts[3], 64)
+		if errW == nil && errH == nil {
+			viewBoxWidth, viewBoxHeight, viewBoxOK = int(w), int(h), true
 		}
 	}
+	if viewBoxOK && (widthPercent || heightPercent) {
+		return viewBoxWidth, viewBoxHeight
+	}
 
-	// Try to extract height
-	if heightMatch := strings.Index(svgContent, `height="`); heightMatch != -1 {
-		heightStart := heightMatch + 8
-		heightEnd := strings.Index(svgContent[heightStart:], `"`)
-		if heightEnd != -1 {
-			if h, err := strconv.Atoi(svgContent[heightStart : heightStart+heightEnd]); err == nil {
-				height = h
-			}
+	if !widthOK {
+		width = defaultSVGDimension
+		if viewBoxOK {
+			width = viewBoxWidth
 		}
 	}
-
-	// Try viewBox if width/height not found
-	if viewBoxMatch := strings.Index(svgContent, `viewBox="`); viewBoxMatch != -1 {
-		viewBoxStart := viewBoxMatch + 9
-		viewBoxEnd := strings.Index(svgContent[viewBoxStart:], `"`)
-		if viewBoxEnd != -1 {
-			viewBox := svgContent[viewBoxStart : viewBoxStart+viewBoxEnd]
-			parts := strings.Fields(viewBox)
-			if len(parts) >= 4 {
-				if w, err := strconv.ParseFloat(parts[2], 64); err == nil {
-					width = int(w)
-				}
-				if h, err := strconv.ParseFloat(parts[3], 64); err == nil {
-					height = int(h)
-				}
-			}
+	if !heightOK {
+		height = defaultSVGDimension
+		if viewBoxOK {
+			height = viewBoxHeight
 		}
 	}
-
 	return width, height
 }
 
+// svgAttribute returns the value of the first name="..." attribute in
+// svgContent, or "" if there is none
+func svgAttribute(svgContent, name string) string {
+	prefix := name + `="`
+	start := strings.Index(svgContent, prefix)
+	if start == -1 {
+		return ""
+	}
+	start += len(prefix)
+	end := strings.Index(svgContent[start:], `"`)
+	if end == -1 {
+		return ""
+	}
+	return svgContent[start : start+end]
+}
+
+// svgLengthUnits are the pixels in one of each CSS length unit. em and
+// ex assume the default 16px font.
+var svgLengthUnits = map[string]float64{
+	"":   1,
+	"px": 1,
+	"pt": 4.0 / 3,
+	"pc": 16,
+	"in": 96,
+	"cm": 96 / 2.54,
+	"mm": 96 / 25.4,
+	"em": 16,
+	"ex": 8,
+}
+
+// parseSVGLength parses an SVG width or height into pixels. percent is
+// set for percentages, which are relative to a container and so have
+// no size here; ok is false for them and for values that don't parse.
+func parseSVGLength(value string) (px int, percent, ok bool) {
+	value = strings.TrimSpace(value)
+	if number, found := strings.CutSuffix(value, "%"); found {
+		_, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+		return 0, err == nil, false
+	}
+	unitStart := len(value)
+	for unitStart > 0 && value[unitStart-1] >= 'a' && value[unitStart-1] <= 'z' {
+		unitStart--
+	}
+	scale, known := svgLengthUnits[value[unitStart:]]
+	if !known {
+		return 0, false, false
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(value[:unitStart]), 64)
+	if err != nil || n <= 0 {
+		return 0, false, false
+	}
+	return int(n*scale + 0.5), false, true
+}
+
 // rasterizeSVG converts an SVG icon to a raster image using oksvg.
 // A recovered panic from the rasterizer is returned as an error via
 // the named return rather than printed to stdout — printing would
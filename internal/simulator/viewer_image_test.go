@@ -318,6 +318,54 @@ func TestExtractSVGDimensions(t *testing.T) {
 			wantWidth:  defaultSVGDimension,
 			wantHeight: 200,
 		},
+		{
+			name:       "percentages use the viewBox",
+			svg:        `<svg width="100%" height="100%" viewBox="0 0 48 32"></svg>`,
+			wantWidth:  48,
+			wantHeight: 32,
+		},
+		{
+			name:       "one percentage uses the whole viewBox",
+			svg:        `<svg width="50%" height="200" viewBox="0 0 48 32"></svg>`,
+			wantWidth:  48,
+			wantHeight: 32,
+		},
+		{
+			name:       "width and height win over the viewBox",
+			svg:        `<svg width="120" height="80" viewBox="0 0 24 16"></svg>`,
+			wantWidth:  120,
+			wantHeight: 80,
+		},
+		{
+			name:       "missing height comes from the viewBox",
+			svg:        `<svg width="120" viewBox="0 0 24 16"></svg>`,
+			wantWidth:  120,
+			wantHeight: 16,
+		},
+		{
+			name:       "em units at 16px",
+			svg:        `<svg width="2em" height="1.5em"></svg>`,
+			wantWidth:  32,
+			wantHeight: 24,
+		},
+		{
+			name:       "px and in units",
+			svg:        `<svg width="64px" height="1in"></svg>`,
+			wantWidth:  64,
+			wantHeight: 96,
+		},
+		{
+			name:       "unknown unit falls back to the viewBox",
+			svg:        `<svg width="3furlongs" height="2furlongs" viewBox="0 0 30 20"></svg>`,
+			wantWidth:  30,
+			wantHeight: 20,
+		},
+		{
+			name:       "fractional viewBox only",
+			svg:        `<svg viewBox="0 0 99.5 49.9"></svg>`,
+			wantWidth:  99,
+			wantHeight: 49,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
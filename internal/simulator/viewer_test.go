@@ -1,11 +1,14 @@
 package simulator
 
 import (
+	"encoding/hex"
+	"errors"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func TestFormatHexDump(t *testing.T) {
@@ -92,6 +95,28 @@ func TestFormatHexDump(t *testing.T) {
 			offset:    0,
 			wantLines: 0,
 		},
+		{
+			name:      "partial last row",
+			data:      []byte("0123456789abcdef\x00\x01AB"),
+			offset:    0,
+			wantLines: 2,
+			check: func(t *testing.T, lines []string) {
+				if len(lines) != 2 {
+					return
+				}
+				if !strings.HasPrefix(lines[1], "00000010  00 01 41 42 ") {
+					t.Errorf("second line should hold the last 4 bytes, got: %s", lines[1])
+				}
+				// The short row is padded so its ASCII column lines up
+				// with the full row above it
+				if a, b := strings.Index(lines[0], "|"), strings.Index(lines[1], "|"); a != b {
+					t.Errorf("ASCII column starts at %d in the full row and %d in the partial one", a, b)
+				}
+				if !strings.HasSuffix(lines[1], "|..AB|") {
+					t.Errorf("ASCII column should show only the 4 bytes, got: %s", lines[1])
+				}
+			},
+		},
 		{
 			name:      "exact 16 bytes",
 			data:      []byte("1234567890123456"),
@@ -461,3 +486,181 @@ func TestDetectFileTypeWithContent(t *testing.T) {
 		})
 	}
 }
+
+func TestReadFileContent_AssetCatalog(t *testing.T) {
+	catalog := filepath.Join(t.TempDir(), "Assets.xcassets")
+	files := map[string]string{
+		"Contents.json":                          `{"info":{"version":1}}`,
+		"AppIcon.appiconset/Contents.json":       `{"images":[{"filename":"icon.png"},{"size":"1024x1024"}]}`,
+		"AppIcon.appiconset/icon.png":            "png",
+		"Accent.colorset/Contents.json":          `{"colors":[{"idiom":"universal"}]}`,
+		"Icons/Contents.json":                    `{"info":{"version":1}}`,
+		"Icons/star.imageset/Contents.json":      `{"images":[{"scale":"1x"},{"scale":"2x"},{"scale":"3x"}]}`,
+		"Icons/heart.imageset/Contents.json":     `not json`,
+		"Icons/Nested/moon.imageset/placeholder": "",
+	}
+	for name, data := range files {
+		path := filepath.Join(catalog, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := DetectFileType(catalog); got != FileTypeAssetCatalog {
+		t.Fatalf("DetectFileType() = %v, want FileTypeAssetCatalog", got)
+	}
+	content, err := ReadFileContent(catalog, 0, 100, 80)
+	if err != nil {
+		t.Fatalf("ReadFileContent() error = %v", err)
+	}
+	info := content.AssetCatalog
+	if info == nil {
+		t.Fatal("AssetCatalog is nil")
+	}
+
+	wantCounts := map[string]int{"app icon": 1, "color set": 1, "image set": 3}
+	for kind, n := range wantCounts {
+		if info.Counts[kind] != n {
+			t.Errorf("Counts[%q] = %d, want %d", kind, info.Counts[kind], n)
+		}
+	}
+
+	variants := make(map[string]CatalogAsset)
+	for _, asset := range info.Assets {
+		variants[asset.Path] = asset
+	}
+	if len(info.Assets) != 7 {
+		t.Errorf("got %d assets and folders, want 7: %+v", len(info.Assets), info.Assets)
+	}
+	for path, want := range map[string]int{
+		"AppIcon.appiconset":         2,
+		"Accent.colorset":            1,
+		"Icons/star.imageset":        3,
+		"Icons/heart.imageset":       0, // Malformed Contents.json
+		"Icons/Nested/moon.imageset": 0,
+	} {
+		if got := variants[path].Variants; got != want {
+			t.Errorf("%s has %d variants, want %d", path, got, want)
+		}
+	}
+	if variants["Icons/Nested"].Type != "folder" {
+		t.Errorf("Icons/Nested type = %q, want folder", variants["Icons/Nested"].Type)
+	}
+}
+
+func BenchmarkFormatHexDump(b *testing.B) {
+	data := make([]byte, BinaryChunkSize)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	for b.Loop() {
+		FormatHexDump(data, 0)
+	}
+}
+
+func BenchmarkIsTextContent(b *testing.B) {
+	// The 512 bytes DetectFileType sniffs, all printable so every byte
+	// is checked
+	data := []byte(strings.Repeat("The quick brown fox jumps over the lazy dog.\n", 12)[:512])
+	for b.Loop() {
+		isTextContent(data)
+	}
+}
+
+// benchmarkHighlightLine is a 500-character Go line of keywords,
+// identifiers, strings, numbers, operators and a comment
+func benchmarkHighlightLine() string {
+	line := `	if err := fetch(ctx, "https://example.com/api/v1/items", 42, 3.14, []string{"a", "b"}); err != nil && !errors.Is(err, io.EOF) { return fmt.Errorf("fetch %d: %w", 0x1F, err) } // retry`
+	return strings.Repeat(line, 500/len(line)+1)[:500]
+}
+
+// BenchmarkGetSyntaxHighlightedLineCached highlights a line already on
+// screen, as each redraw while scrolling does
+func BenchmarkGetSyntaxHighlightedLineCached(b *testing.B) {
+	line := benchmarkHighlightLine()
+	GetSyntaxHighlightedLine(line, ".go")
+	for b.Loop() {
+		GetSyntaxHighlightedLine(line, ".go")
+	}
+}
+
+// BenchmarkGetSyntaxHighlightedLineUncached highlights a line for the
+// first time
+func BenchmarkGetSyntaxHighlightedLineUncached(b *testing.B) {
+	line := benchmarkHighlightLine()
+	for b.Loop() {
+		syntaxCache.clear()
+		GetSyntaxHighlightedLine(line, ".go")
+	}
+}
+
+func FuzzIsTextContent(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"hello\x00world",
+		"\x00\x00\x00\x00",
+		"\xEF\xBB\xBFutf-8 with a BOM",
+		"\xFF\xFEu\x00t\x00f\x00",
+		"bplist00",
+		"\x89PNG\r\n\x1a\n",
+		"\xCF\xFA\xED\xFE",
+		"SQLite format 3\x00",
+		"PK\x03\x04",
+		"%PDF-1.7",
+		"\x1F\x8B\x08",
+	} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if isTextContent(data) && !utf8.Valid(data) {
+			t.Errorf("isTextContent(%q) = true for invalid UTF-8", data)
+		}
+	})
+}
+
+func FuzzDecodeBinaryPlist(f *testing.F) {
+	valid, err := hex.DecodeString(cachedResponseBPlist)
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, seed := range [][]byte{
+		nil,
+		[]byte("bplist00"),
+		valid,
+		overflowingTableBPlist(),
+		sharedSubtreesBPlist(),
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if _, err := decodeBinaryPlist(data); err != nil && !errors.Is(err, errBadBPlist) {
+			t.Errorf("decodeBinaryPlist() error = %v, want errBadBPlist", err)
+		}
+	})
+}
+
+func FuzzDetectContentLanguage(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"   \n\t",
+		"<!DOCTYPE html><html></html>",
+		"<svg xmlns=\"http://www.w3.org/2000/svg\"/>",
+		"<?xml version=\"1.0\"?><plist/>",
+		`{"bug_type":"309"}`,
+		"[1, 2, 3]",
+		"\xEF\xBB\xBF{\"a\": 1}",
+		"a\x00<html",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, content string) {
+		switch got := detectContentLanguage(content); got {
+		case "", "html", "svg", "xml", "json":
+		default:
+			t.Errorf("detectContentLanguage(%q) = %q, want a known language or \"\"", content, got)
+		}
+	})
+}
@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 )
 
@@ -13,34 +12,36 @@ const (
 	// will accept (1 MiB). Minified sources can legitimately have very
 	// long lines that would otherwise cause a "token too long" error.
 	textScanBufferSize = 1024 * 1024
-	// maxDisplayLineLength truncates displayed lines to keep rendering
-	// responsive on pathologically long lines (e.g. minified JS).
+	// maxDisplayLineLength truncates displayed lines, counted in runes,
+	// to keep rendering responsive on pathologically long lines (e.g.
+	// minified JS).
 	maxDisplayLineLength = 2000
 )
 
+// IsBinaryPlist reports whether the file at path starts with the
+// "bplist" magic of a binary property list
+func IsBinaryPlist(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = file.Close() }()
+
+	magic := make([]byte, 6)
+	n, err := file.Read(magic)
+	return err == nil && n == len(magic) && string(magic) == "bplist"
+}
+
 // readTextFile reads a text file with pagination support
 // Returns lines, totalLines, isBinaryPlist, error
 func readTextFile(path string, startLine, maxLines int) ([]string, int, bool, error) {
 	isBinaryPlist := false
 
-	// Check if it's a plist file
-	if strings.HasSuffix(strings.ToLower(path), ".plist") {
-		// Check if it's a binary plist by reading first few bytes
-		file, err := os.Open(path)
-		if err != nil {
-			return nil, 0, false, err
-		}
-
-		magic := make([]byte, 6)
-		n, err := file.Read(magic)
-		_ = file.Close()
-
-		if err == nil && n >= 6 && string(magic) == "bplist" {
-			// It's a binary plist, convert it to XML for viewing
-			lines, total, err := readBinaryPlist(path, startLine, maxLines)
-			return lines, total, true, err
-		}
-		// Otherwise, fall through to read as normal text file
+	// Binary plists are converted to XML for viewing; other plists
+	// are read as normal text files
+	if strings.HasSuffix(strings.ToLower(path), ".plist") && IsBinaryPlist(path) {
+		lines, total, err := readBinaryPlist(path, startLine, maxLines)
+		return lines, total, true, err
 	}
 
 	file, err := os.Open(path)
@@ -63,9 +64,7 @@ func readTextFile(path string, startLine, maxLines int) ([]string, int, bool, er
 		if currentLine >= startLine && len(lines) < maxLines {
 			line := scanner.Text()
 			// Truncate very long lines for display
-			if len(line) > maxDisplayLineLength {
-				line = line[:maxDisplayLineLength] + "..."
-			}
+			line = truncateDisplayLine(line)
 			lines = append(lines, line)
 		}
 		currentLine++
@@ -78,11 +77,26 @@ func readTextFile(path string, startLine, maxLines int) ([]string, int, bool, er
 	return lines, totalLines, isBinaryPlist, nil
 }
 
+// truncateDisplayLine cuts line to maxDisplayLineLength runes followed
+// by "...". It cuts at a rune boundary so multi-byte characters aren't
+// split.
+func truncateDisplayLine(line string) string {
+	if len(line) <= maxDisplayLineLength {
+		return line // No more runes than bytes
+	}
+	runes := 0
+	for i := range line {
+		if runes == maxDisplayLineLength {
+			return line[:i] + "..."
+		}
+		runes++
+	}
+	return line
+}
+
 // readBinaryPlist converts a binary plist to XML and reads it
 func readBinaryPlist(path string, startLine, maxLines int) ([]string, int, error) {
-	// Use plutil to convert binary plist to XML
-	cmd := exec.Command("plutil", "-convert", "xml1", "-o", "-", path)
-	output, err := cmd.Output()
+	output, err := convertPlist(path, "xml1")
 	if err != nil {
 		// If conversion fails, return an error message
 		return []string{fmt.Sprintf("Error converting binary plist: %v", err)}, 1, nil
@@ -107,11 +121,15 @@ func readBinaryPlist(path string, startLine, maxLines int) ([]string, int, error
 	for i := startLine; i < endLine; i++ {
 		line := allLines[i]
 		// Truncate very long lines for display
-		if len(line) > maxDisplayLineLength {
-			line = line[:maxDisplayLineLength] + "..."
-		}
+		line = truncateDisplayLine(line)
 		lines = append(lines, line)
 	}
 
 	return lines, totalLines, nil
 }
+
+// convertPlist converts the plist at path to the given plutil format
+// ("xml1", "json") and returns the converted document.
+func convertPlist(path, format string) ([]byte, error) {
+	return defaultExecutor.Execute("plutil", "-convert", format, "-o", "-", path)
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func TestReadTextFile_FullFile(t *testing.T) {
@@ -89,6 +90,37 @@ func TestReadTextFile_LongLineTruncation(t *testing.T) {
 	}
 }
 
+func TestReadTextFile_UTF8Truncation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chinese.txt")
+	// 1000 3-byte characters are 3000 bytes, but only 1000 runes, so
+	// the first line is kept whole. The second is cut at 2000 runes.
+	short := strings.Repeat("汉", 1000)
+	long := strings.Repeat("字", 3000)
+	if err := os.WriteFile(path, []byte(short+"\n"+long+"\n"), 0600); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	lines, _, _, err := readTextFile(path, 0, 10)
+	if err != nil {
+		t.Fatalf("readTextFile: %v", err)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("len(lines) = %d, want 2", len(lines))
+	}
+	for i, line := range lines {
+		if !utf8.ValidString(line) {
+			t.Errorf("lines[%d] is not valid UTF-8", i)
+		}
+	}
+	if lines[0] != short {
+		t.Errorf("lines[0] has %d runes, want the whole 1000", utf8.RuneCountInString(lines[0]))
+	}
+	want := strings.Repeat("字", maxDisplayLineLength) + "..."
+	if lines[1] != want {
+		t.Errorf("lines[1] has %d runes, want %d", utf8.RuneCountInString(lines[1]), utf8.RuneCountInString(want))
+	}
+}
+
 func TestReadTextFile_NonexistentFile(t *testing.T) {
 	_, _, _, err := readTextFile(filepath.Join(t.TempDir(), "missing.txt"), 0, 10)
 	if err == nil {
@@ -0,0 +1,74 @@
+package simulator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// xattrHexLinePattern matches the hex dump lines xattr -l prints for
+// binary attribute values, e.g. "00000000  62 70 6C 69 ...  |bpli...|"
+var xattrHexLinePattern = regexp.MustCompile(`^[0-9A-Fa-f]{8}  `)
+
+// xattrDescriptions describes the extended attributes commonly found on
+// files in a simulator, keyed by attribute name
+var xattrDescriptions = map[string]string{
+	"com.apple.quarantine":                            "Quarantine flag set on downloaded files until Gatekeeper checks them",
+	"com.apple.provenance":                            "Provenance tag identifying the app that created the file",
+	"com.apple.metadata:com_apple_backup_excludeItem": "Excluded from iCloud and device backups (isExcludedFromBackup)",
+	"com.apple.metadata:kMDItemWhereFroms":            "URLs the file was downloaded from",
+	"com.apple.FinderInfo":                            "Finder type, creator and flags",
+	"com.apple.ResourceFork":                          "Classic Mac OS resource fork",
+	"com.apple.TextEncoding":                          "Text encoding the file was saved with",
+	"com.apple.lastuseddate#PS":                       "Date the file was last opened",
+	"com.apple.macl":                                  "Sandbox access control list",
+	"com.apple.metadata:_kMDItemUserTags":             "Finder tags",
+	"com.apple.metadata:kMDItemDownloadedDate":        "Date the file was downloaded",
+}
+
+// XattrDescription returns a human-readable description of the extended
+// attribute name, or "" for attributes without one
+func XattrDescription(name string) string {
+	return xattrDescriptions[name]
+}
+
+// ReadXattrs returns the extended attributes of the file at path, keyed
+// by name. Binary values are kept as the hex dump xattr prints.
+func ReadXattrs(path string) (map[string]string, error) {
+	output, err := defaultExecutor.Execute("xattr", "-l", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read extended attributes: %w", err)
+	}
+	return parseXattrList(string(output)), nil
+}
+
+// parseXattrList parses xattr -l output. Text values are printed as
+// "name: value"; binary values as "name:" followed by hex dump lines.
+// Names may themselves contain colons, e.g.
+// "com.apple.metadata:kMDItemWhereFroms".
+func parseXattrList(output string) map[string]string {
+	xattrs := make(map[string]string)
+	current := ""
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case line == "":
+			continue
+		case current != "" && xattrHexLinePattern.MatchString(line):
+			if xattrs[current] != "" {
+				xattrs[current] += "\n"
+			}
+			xattrs[current] += line
+		case strings.Contains(line, ": "):
+			name, value, _ := strings.Cut(line, ": ")
+			current = name
+			xattrs[name] = value
+		case strings.HasSuffix(line, ":"):
+			current = strings.TrimSuffix(line, ":")
+			xattrs[current] = ""
+		case current != "":
+			// A multi-line text value
+			xattrs[current] += "\n" + line
+		}
+	}
+	return xattrs
+}
@@ -0,0 +1,55 @@
+package simulator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const sampleXattrList = `com.apple.metadata:com_apple_backup_excludeItem: com.apple.backupd
+com.apple.metadata:kMDItemWhereFroms:
+00000000  62 70 6C 69 73 74 30 30 A1 01 5F 10 13 68 74 74  |bplist00.._..htt|
+00000010  70 73 3A 2F 2F 65 78 61 6D 70 6C 65 2E 63 6F 6D  |ps://example.com|
+com.apple.quarantine: 0083;65a1b2c3;Safari;
+`
+
+func TestParseXattrList(t *testing.T) {
+	got := parseXattrList(sampleXattrList)
+	want := map[string]string{
+		"com.apple.metadata:com_apple_backup_excludeItem": "com.apple.backupd",
+		"com.apple.metadata:kMDItemWhereFroms": "00000000  62 70 6C 69 73 74 30 30 A1 01 5F 10 13 68 74 74  |bplist00.._..htt|\n" +
+			"00000010  70 73 3A 2F 2F 65 78 61 6D 70 6C 65 2E 63 6F 6D  |ps://example.com|",
+		"com.apple.quarantine": "0083;65a1b2c3;Safari;",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseXattrList() = %#v, want %#v", got, want)
+	}
+
+	if got := parseXattrList(""); len(got) != 0 {
+		t.Errorf("parseXattrList() of no output = %v, want empty", got)
+	}
+}
+
+func TestReadXattrs(t *testing.T) {
+	withFakeExecutor(t, &fakeExecutor{responses: map[string]fakeResult{
+		"xattr -l /data/file.txt": {out: []byte("com.apple.quarantine: 0083;65a1b2c3;Safari;\n")},
+		"xattr -l /data/gone":     {err: errors.New("exit status 1")},
+	}})
+
+	xattrs, err := ReadXattrs("/data/file.txt")
+	if err != nil || xattrs["com.apple.quarantine"] != "0083;65a1b2c3;Safari;" {
+		t.Errorf("ReadXattrs() = %v, %v", xattrs, err)
+	}
+	if _, err := ReadXattrs("/data/gone"); err == nil {
+		t.Error("ReadXattrs() should fail when xattr fails")
+	}
+}
+
+func TestXattrDescription(t *testing.T) {
+	if XattrDescription("com.apple.quarantine") == "" || XattrDescription("com.apple.provenance") == "" {
+		t.Error("quarantine and provenance attributes should be described")
+	}
+	if got := XattrDescription("com.example.custom"); got != "" {
+		t.Errorf("XattrDescription() of an unknown attribute = %q, want empty", got)
+	}
+}
@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+// fakeAllApps returns apps from two simulators, in GetAllApps order
+func fakeAllApps() []simulator.App {
+	now := time.Now()
+	return []simulator.App{
+		{Name: "AppA", BundleID: "com.example.a", Size: 100, ModTime: now.Add(-2 * time.Hour), SimulatorName: "iPhone 15", SimulatorUDID: "udid-15", SimulatorOS: "iOS 17.0"},
+		{Name: "AppB", BundleID: "com.example.b", Size: 300, ModTime: now.Add(-1 * time.Hour), SimulatorName: "iPhone 14", SimulatorUDID: "udid-14", SimulatorOS: "iOS 16.0"},
+		{Name: "AppC", BundleID: "com.example.c", Size: 200, ModTime: now.Add(-3 * time.Hour), SimulatorName: "iPhone 15", SimulatorUDID: "udid-15", SimulatorOS: "iOS 17.0"},
+	}
+}
+
+func appNames(apps []simulator.App) string {
+	names := make([]string, len(apps))
+	for i, app := range apps {
+		names[i] = app.Name
+	}
+	return strings.Join(names, ",")
+}
+
+func TestGetFilteredAndSearchedAllApps_KeepsSortOrder(t *testing.T) {
+	m := Model{allApps: allAppsState{apps: fakeAllApps(), sortOrder: simulator.AppSortBySize}}
+	if got := appNames(m.getFilteredAndSearchedAllApps()); got != "AppB,AppC,AppA" {
+		t.Errorf("without a query = %s, want size order", got)
+	}
+
+	// Search results stay in the order the footer shows
+	m.allApps.searchQuery = "app"
+	if got := appNames(m.getFilteredAndSearchedAllApps()); got != "AppB,AppC,AppA" {
+		t.Errorf("searching %q = %s, want size order", m.allApps.searchQuery, got)
+	}
+	m.allApps.searchQuery = "iphone 15"
+	if got := appNames(m.getFilteredAndSearchedAllApps()); got != "AppC,AppA" {
+		t.Errorf("searching %q = %s, want size order", m.allApps.searchQuery, got)
+	}
+}
+
+func TestHandleAllAppsKey_CycleSort(t *testing.T) {
+	m := Model{
+		viewState: AllAppsView,
+		allApps:   allAppsState{apps: fakeAllApps(), cursor: 2, viewport: 1},
+		height:    30,
+	}
+
+	var orders []string
+	for range len(allAppsSortOrders) {
+		got, _ := m.handleAllAppsKey("cycle_sort")
+		m = asModel(t, got)
+		orders = append(orders, m.allApps.sortOrder.String())
+	}
+	if got := strings.Join(orders, ","); got != "size,simulator,modified,name" {
+		t.Errorf("sort cycle = %s", got)
+	}
+	if m.allApps.cursor != 0 || m.allApps.viewport != 0 {
+		t.Errorf("cursor/viewport = %d/%d, want 0/0", m.allApps.cursor, m.allApps.viewport)
+	}
+}
+
+func TestHandleAllAppsKey_ViewLogs(t *testing.T) {
+	t.Run("booted simulator starts stream", func(t *testing.T) {
+		m := Model{
+			viewState: AllAppsView,
+			simList:   simListState{simulators: fakeSims()},
+			allApps:   allAppsState{apps: fakeAllApps()},
+			height:    30,
+		}
+		got, cmd := m.handleAllAppsKey("view_logs")
+		gm := asModel(t, got)
+		if gm.viewState != LogStreamView {
+			t.Errorf("viewState = %v, want LogStreamView", gm.viewState)
+		}
+		if cmd == nil {
+			t.Error("expected startLogStreamCmd")
+		}
+
+		// Leaving the stream returns to the all-apps list
+		got, _ = gm.handleLogStreamKey("left")
+		if gm = asModel(t, got); gm.viewState != AllAppsView {
+			t.Errorf("after left, viewState = %v, want AllAppsView", gm.viewState)
+		}
+	})
+
+	t.Run("shutdown simulator flashes", func(t *testing.T) {
+		m := Model{
+			viewState: AllAppsView,
+			simList:   simListState{simulators: fakeSims()},
+			allApps:   allAppsState{apps: fakeAllApps(), cursor: 1},
+			height:    30,
+		}
+		got, _ := m.handleAllAppsKey("view_logs")
+		gm := asModel(t, got)
+		if gm.viewState != AllAppsView {
+			t.Errorf("viewState = %v, want AllAppsView", gm.viewState)
+		}
+		if !strings.Contains(gm.statusMessage, "Boot iPhone 14") {
+			t.Errorf("statusMessage = %q, want boot hint", gm.statusMessage)
+		}
+	})
+}
+
+func TestRenderAllAppsView(t *testing.T) {
+	base := Model{
+		viewState: AllAppsView,
+		allApps:   allAppsState{apps: fakeAllApps()},
+		height:    30,
+		width:     120,
+		config:    config.Default(),
+	}
+
+	t.Run("lists apps with their simulator", func(t *testing.T) {
+		view := base.View()
+		for _, want := range []string{"All Apps (3)", "iPhone 15 (iOS 17.0)", "sort (name)"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("View() should contain %q", want)
+			}
+		}
+	})
+
+	t.Run("search by simulator name", func(t *testing.T) {
+		m := base
+		m.allApps.searchMode = true
+		m.allApps.searchQuery = "iPhone 14"
+		view := m.View()
+		if !strings.Contains(view, "All Apps (1 of 3)") || !strings.Contains(view, "AppB") || strings.Contains(view, "AppA") {
+			t.Errorf("View() should list only AppB:\n%s", view)
+		}
+	})
+
+	t.Run("error state", func(t *testing.T) {
+		m := base
+		m.err = simulator.ErrSimulatorNotFound
+		if view := m.View(); !strings.Contains(view, "Error loading apps:") {
+			t.Errorf("View() should show the load error:\n%s", view)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestHandleAppListKey_ViewAppGroups(t *testing.T) {
+	sim := fakeSims()[0]
+	m := Model{
+		viewState: AppListView,
+		appList:   appListState{selectedSim: &sim, apps: fakeApps(), cursor: 1},
+		height:    30,
+	}
+	got, cmd := m.handleAppListKey("view_app_groups")
+	gm := asModel(t, got)
+
+	if gm.viewState != AppGroupsView {
+		t.Errorf("viewState = %v, want AppGroupsView", gm.viewState)
+	}
+	if gm.appGroups.app == nil || gm.appGroups.app.BundleID != "com.example.b" {
+		t.Errorf("appGroups.app = %+v, want com.example.b", gm.appGroups.app)
+	}
+	if !gm.appGroups.loading || cmd == nil {
+		t.Error("expected loading state and fetchAppGroupsCmd")
+	}
+}
+
+func TestHandleFetchAppGroups(t *testing.T) {
+	app := fakeApps()[0]
+
+	tests := []struct {
+		name       string
+		msg        fetchAppGroupsMsg
+		wantStatus string
+	}{
+		{"no groups", fetchAppGroupsMsg{err: simulator.ErrNoAppGroups}, "AppA doesn't use any app groups"},
+		{"read error", fetchAppGroupsMsg{err: errors.New("boom")}, "Error reading app groups: boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{viewState: AppGroupsView, appGroups: appGroupsState{app: &app, loading: true}}
+			got, _ := m.Update(tt.msg)
+			gm := asModel(t, got)
+			if gm.viewState != AppListView {
+				t.Errorf("viewState = %v, want AppListView", gm.viewState)
+			}
+			if !strings.Contains(gm.statusMessage, tt.wantStatus) {
+				t.Errorf("statusMessage = %q, want %q", gm.statusMessage, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestAppGroupsView_BrowseAndReturn(t *testing.T) {
+	app := fakeApps()[0]
+	m := Model{
+		viewState: AppGroupsView,
+		appGroups: appGroupsState{app: &app, groups: []simulator.AppGroup{
+			{Identifier: "group.com.example.shared", Path: "/shared/1111"},
+			{Identifier: "group.com.example.widgets"},
+		}},
+		height: 30,
+	}
+
+	got, cmd := m.handleAppGroupsKey("right")
+	gm := asModel(t, got)
+	if gm.viewState != FileListView || gm.fileList.basePath != "/shared/1111" || !gm.fileList.fromAppGroups {
+		t.Fatalf("expected file list of the group container, got view %v fileList %+v", gm.viewState, gm.fileList)
+	}
+	if cmd == nil {
+		t.Error("expected fetchFilesCmd")
+	}
+
+	got, _ = gm.handleFileListKey("left")
+	gm = asModel(t, got)
+	if gm.viewState != AppGroupsView || len(gm.appGroups.groups) != 2 {
+		t.Errorf("left from group root: view %v, want AppGroupsView with groups kept", gm.viewState)
+	}
+
+	// A group the app hasn't used yet has no container to browse
+	gm.appGroups.cursor = 1
+	got, _ = gm.handleAppGroupsKey("right")
+	gm = asModel(t, got)
+	if gm.viewState != AppGroupsView || !strings.Contains(gm.statusMessage, "has no container yet") {
+		t.Errorf("right on group without container: view %v status %q", gm.viewState, gm.statusMessage)
+	}
+
+	got, _ = gm.handleAppGroupsKey("left")
+	if gm = asModel(t, got); gm.viewState != AppListView {
+		t.Errorf("left from app groups: viewState = %v, want AppListView", gm.viewState)
+	}
+}
@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestHandleSimulatorListKey_CloneSimulator(t *testing.T) {
+	m := testModelWithKeyMap()
+	m.viewState = SimulatorListView
+	m.simList.simulators = fakeSims()
+
+	// Booted simulators can't be cloned
+	m.simList.cursor = 1
+	got, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}})
+	gm := asModel(t, got)
+	if gm.simList.cloneMode || !strings.Contains(gm.statusMessage, "Shut down") {
+		t.Errorf("cloneMode = %v, status = %q; want the shut down hint", gm.simList.cloneMode, gm.statusMessage)
+	}
+
+	m.simList.cursor = 0
+	got, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}})
+	gm = asModel(t, got)
+	if !gm.simList.cloneMode || gm.simList.cloneName != "iPhone 14 Copy" {
+		t.Fatalf("cloneMode = %v, cloneName = %q; want the prompt with a default name", gm.simList.cloneMode, gm.simList.cloneName)
+	}
+	if !strings.Contains(gm.View(), "Clone as: iPhone 14 Copy") {
+		t.Error("the view should show the clone prompt")
+	}
+}
+
+func TestHandleCloneNameInput(t *testing.T) {
+	m := testModelWithKeyMap()
+	m.viewState = SimulatorListView
+	m.simList.simulators = fakeSims()
+	m.simList.cloneMode = true
+	m.simList.cloneName = "iPhone 14 Copy"
+
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyBackspace},
+		{Type: tea.KeyBackspace},
+		{Type: tea.KeyBackspace},
+		{Type: tea.KeyBackspace},
+		{Type: tea.KeyRunes, Runes: []rune("qa")}, // Action keys are typed as text
+		{Type: tea.KeySpace, Runes: []rune(" ")},
+		{Type: tea.KeyRunes, Runes: []rune("2")},
+	} {
+		got, _ := m.handleKeyPress(key)
+		m = asModel(t, got)
+	}
+	if m.simList.cloneName != "iPhone 14 qa 2" {
+		t.Fatalf("cloneName = %q, want %q", m.simList.cloneName, "iPhone 14 qa 2")
+	}
+
+	got, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	gm := asModel(t, got)
+	if cmd == nil || !gm.simList.cloning || gm.simList.cloneMode {
+		t.Errorf("enter should start cloning, got cloning=%v cloneMode=%v", gm.simList.cloning, gm.simList.cloneMode)
+	}
+
+	m.simList.cloneMode = true
+	got, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEscape})
+	gm = asModel(t, got)
+	if cmd != nil || gm.simList.cloneMode || gm.simList.cloneName != "" {
+		t.Errorf("escape should cancel the prompt, got cloneMode=%v cloneName=%q", gm.simList.cloneMode, gm.simList.cloneName)
+	}
+}
+
+func TestHandleCloneSimulator(t *testing.T) {
+	m := Model{viewState: SimulatorListView, height: 30, fetcher: &mockFetcher{}}
+	m.simList.simulators = fakeSims()
+	m.simList.cloning = true
+
+	gm, _ := m.handleCloneSimulator(cloneSimulatorMsg{name: "Copy", err: errors.New("boom")})
+	if gm.simList.cloning || !strings.Contains(gm.statusMessage, "Error") {
+		t.Errorf("cloning = %v, status = %q; want an error", gm.simList.cloning, gm.statusMessage)
+	}
+
+	gm, cmd := m.handleCloneSimulator(cloneSimulatorMsg{name: "Copy", udid: "udid-copy"})
+	if cmd == nil || !strings.Contains(gm.statusMessage, "successfully") {
+		t.Fatalf("status = %q; want success and a refresh", gm.statusMessage)
+	}
+
+	// The refreshed list puts the cursor on the clone
+	sims := append(fakeSims(), fakeSims()[0])
+	sims[3].Name, sims[3].UDID = "Copy", "udid-copy"
+	gm, _ = gm.handleFetchSimulators(fetchSimulatorsMsg{simulators: sims})
+	if gm.simList.cursor != 3 || gm.simList.selectUDID != "" {
+		t.Errorf("cursor = %d, selectUDID = %q; want the clone selected", gm.simList.cursor, gm.simList.selectUDID)
+	}
+}
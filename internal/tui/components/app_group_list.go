@@ -0,0 +1,124 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/simulator"
+	"github.com/azizuysal/simtool/internal/ui"
+)
+
+// AppGroupList renders the app groups an app belongs to
+type AppGroupList struct {
+	Width    int
+	Height   int
+	App      *simulator.App
+	Groups   []simulator.AppGroup
+	Cursor   int
+	Viewport int
+	Keys     *config.KeysConfig
+}
+
+// NewAppGroupList creates a new app group list renderer
+func NewAppGroupList(width, height int) *AppGroupList {
+	return &AppGroupList{
+		Width:  width,
+		Height: height,
+	}
+}
+
+// Update updates the list data
+func (gl *AppGroupList) Update(app *simulator.App, groups []simulator.AppGroup, cursor, viewport int, keys *config.KeysConfig) {
+	gl.App = app
+	gl.Groups = groups
+	gl.Cursor = cursor
+	gl.Viewport = viewport
+	gl.Keys = keys
+}
+
+// Render renders the app group list content
+func (gl *AppGroupList) Render() string {
+	if len(gl.Groups) == 0 {
+		return ui.DetailStyle().Render("No app groups found")
+	}
+
+	var s strings.Builder
+	innerWidth := gl.Width - 4 // Account for padding
+
+	startIdx := gl.Viewport
+	endIdx := min(gl.Viewport+gl.calculateItemsPerScreen(), len(gl.Groups))
+	for i := startIdx; i < endIdx; i++ {
+		group := gl.Groups[i]
+
+		detailText := "No container yet"
+		if group.Path != "" {
+			detailText = simulator.FormatSize(group.Size)
+		}
+
+		if i == gl.Cursor {
+			line1 := ui.PadLine(fmt.Sprintf("▶ %s", group.Identifier), innerWidth)
+			line2 := ui.PadLine(fmt.Sprintf("  %s", detailText), innerWidth)
+			s.WriteString(ui.SelectedStyle().Render(line1))
+			s.WriteString("\n")
+			s.WriteString(ui.SelectedStyle().Render(line2))
+		} else {
+			s.WriteString(ui.ListItemStyle().Inherit(ui.NameStyle()).Render(group.Identifier))
+			s.WriteString("\n")
+			s.WriteString(ui.ListItemStyle().Inherit(ui.DetailStyle()).Render(detailText))
+		}
+
+		if i < endIdx-1 {
+			s.WriteString("\n\n")
+		}
+	}
+
+	return s.String()
+}
+
+// GetTitle returns the title for the app group list
+func (gl *AppGroupList) GetTitle() string {
+	if gl.App != nil {
+		return fmt.Sprintf("%s App Groups (%d)", gl.App.Name, len(gl.Groups))
+	}
+	return fmt.Sprintf("App Groups (%d)", len(gl.Groups))
+}
+
+// GetFooter returns the footer for the app group list
+func (gl *AppGroupList) GetFooter() string {
+	itemsPerScreen := gl.calculateItemsPerScreen()
+	scrollInfo := ui.FormatScrollInfo(gl.Viewport, itemsPerScreen, len(gl.Groups))
+
+	if gl.Keys == nil {
+		return "↑/k: up • ↓/j: down • →/l: files • space: open in Finder • ←/h: back • q: quit" + scrollInfo
+	}
+
+	var parts []string
+	if up := gl.Keys.FormatKeyAction("up", "up"); up != "" {
+		parts = append(parts, up)
+	}
+	if down := gl.Keys.FormatKeyAction("down", "down"); down != "" {
+		parts = append(parts, down)
+	}
+	if right := gl.Keys.FormatKeyAction("right", "files"); right != "" {
+		parts = append(parts, right)
+	}
+	if open := gl.Keys.FormatKeyAction("open", "open in Finder"); open != "" {
+		parts = append(parts, open)
+	}
+	if left := gl.Keys.FormatKeyAction("left", "back"); left != "" {
+		parts = append(parts, left)
+	}
+	if quit := gl.Keys.FormatKeyAction("quit", "quit"); quit != "" {
+		parts = append(parts, quit)
+	}
+
+	return strings.Join(parts, " • ") + scrollInfo
+}
+
+// calculateItemsPerScreen calculates how many items fit on screen
+func (gl *AppGroupList) calculateItemsPerScreen() int {
+	// Each item takes 3 lines (name + details + blank line)
+	availableHeight := gl.Height - 2 // Border takes 2 lines
+	return max(availableHeight/3, 1)
+}
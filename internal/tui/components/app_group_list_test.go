@@ -0,0 +1,46 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestAppGroupListRender(t *testing.T) {
+	gl := NewAppGroupList(100, 24)
+	app := &simulator.App{Name: "Example"}
+
+	gl.Update(app, nil, 0, 0, nil)
+	if got := gl.Render(); !strings.Contains(got, "No app groups found") {
+		t.Errorf("empty Render() = %q", got)
+	}
+
+	groups := []simulator.AppGroup{
+		{Identifier: "group.com.example.shared", Path: "/shared/1111", Size: 2048},
+		{Identifier: "group.com.example.widgets"},
+	}
+	gl.Update(app, groups, 1, 0, nil)
+	got := gl.Render()
+	for _, want := range []string{"group.com.example.shared", "2.0 KB", "▶ group.com.example.widgets", "No container yet"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() missing %q:\n%s", want, got)
+		}
+	}
+	if title := gl.GetTitle(); title != "Example App Groups (2)" {
+		t.Errorf("GetTitle() = %q", title)
+	}
+}
+
+func TestAppGroupListGetFooter(t *testing.T) {
+	gl := NewAppGroupList(80, 24)
+	keys := config.DefaultKeys()
+	gl.Update(nil, nil, 0, 0, &keys)
+	got := gl.GetFooter()
+	for _, want := range []string{"→/l: files", "←/h: back"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("GetFooter() = %q, missing %q", got, want)
+		}
+	}
+}
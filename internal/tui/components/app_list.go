@@ -2,6 +2,7 @@ package components
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/azizuysal/simtool/internal/config"
@@ -20,6 +21,16 @@ type AppList struct {
 	SearchQuery   string
 	SimulatorName string
 	Keys          *config.KeysConfig
+
+	// Multi-select mode
+	MultiSelect bool
+	Selected    map[string]bool // Paths of the selected apps
+
+	// All-apps mode lists apps from every simulator
+	ShowAllSims bool
+	SortLabel   string // Current sort order, shown in the footer
+
+	DateFormat simulator.DateFormat // How modification times are shown
 }
 
 // NewAppList creates a new app list renderer
@@ -41,12 +52,40 @@ func (al *AppList) Update(apps []simulator.App, cursor, viewport int, searchMode
 	al.Keys = keys
 }
 
+// SetSelection sets the multi-select state. selected is keyed by app
+// path, so the selection survives the list being reordered.
+func (al *AppList) SetSelection(multiSelect bool, selected map[string]bool) {
+	al.MultiSelect = multiSelect
+	al.Selected = selected
+}
+
+// SetShowAllSims switches the list to all-apps mode, where each app
+// names the simulator it is installed on and sortLabel describes the
+// current order.
+func (al *AppList) SetShowAllSims(showAllSims bool, sortLabel string) {
+	al.ShowAllSims = showAllSims
+	al.SortLabel = sortLabel
+}
+
+// SetSortLabel describes the current order of a single simulator's list
+func (al *AppList) SetSortLabel(label string) {
+	al.SortLabel = label
+}
+
+// SetDateFormat sets how modification times are shown
+func (al *AppList) SetDateFormat(format simulator.DateFormat) {
+	al.DateFormat = format
+}
+
 // Render renders the app list content
 func (al *AppList) Render() string {
 	if len(al.Apps) == 0 {
 		if al.SearchQuery != "" {
 			return ui.DetailStyle().Render("No apps match your search")
 		}
+		if al.ShowAllSims {
+			return ui.DetailStyle().Render("No apps installed on any simulator")
+		}
 		return ui.DetailStyle().Render("No apps installed")
 	}
 
@@ -65,23 +104,35 @@ func (al *AppList) Render() string {
 // GetTitle returns the title for the app list
 func (al *AppList) GetTitle(totalCount int) string {
 	title := fmt.Sprintf("%s Apps (%d", al.SimulatorName, len(al.Apps))
+	if al.ShowAllSims {
+		title = fmt.Sprintf("All Apps (%d", len(al.Apps))
+	}
 	if al.SearchQuery != "" {
 		title += fmt.Sprintf(" of %d)", totalCount)
 	} else {
 		title += ")"
 	}
+	if al.MultiSelect {
+		title += fmt.Sprintf(" • %d selected", len(al.Selected))
+	}
 	return title
 }
 
 // GetFooter returns the footer for the app list
 func (al *AppList) GetFooter() string {
+	if al.MultiSelect {
+		return al.getMultiSelectFooter()
+	}
 	if al.Keys == nil {
 		// Fallback to default if keys not set
 		footer := ""
-		if al.SearchMode {
+		switch {
+		case al.SearchMode:
 			footer = "ESC: exit search • ↑/↓: navigate • →/Enter: select"
-		} else {
-			footer = "↑/k: up • ↓/j: down • →/l: files • space: open in Finder • /: search • ←/h: back • q: quit"
+		case al.ShowAllSims:
+			footer = "↑/k: up • ↓/j: down • →/l: files • space: open in Finder • L: logs • Tab: sort (" + al.SortLabel + ") • /: search • q: quit"
+		default:
+			footer = "↑/k: up • ↓/j: down • →/l: files • space: open in Finder • L: logs • C: crashes • N: notifications • m: select • /: search • ←/h: back • q: quit"
 		}
 		// Add scroll info
 		itemsPerScreen := al.calculateItemsPerScreen()
@@ -107,6 +158,8 @@ func (al *AppList) GetFooter() string {
 				parts = append(parts, right)
 			}
 		}
+	} else if al.ShowAllSims {
+		parts = al.allSimsFooterParts()
 	} else {
 		if up := al.Keys.FormatKeyAction("up", "up"); up != "" {
 			parts = append(parts, up)
@@ -120,6 +173,35 @@ func (al *AppList) GetFooter() string {
 		if open := al.Keys.FormatKeyAction("open", "open in Finder"); open != "" {
 			parts = append(parts, open)
 		}
+		if cp := al.Keys.FormatKeyAction("copy", "copy path"); cp != "" {
+			parts = append(parts, cp)
+		}
+		if logs := al.Keys.FormatKeyAction("view_logs", "logs"); logs != "" {
+			parts = append(parts, logs)
+		}
+		if crashes := al.Keys.FormatKeyAction("view_crash_logs", "crashes"); crashes != "" {
+			parts = append(parts, crashes)
+		}
+		if notifications := al.Keys.FormatKeyAction("inspect_notifications", "notifications"); notifications != "" {
+			parts = append(parts, notifications)
+		}
+		if permissions := al.Keys.FormatKeyAction("view_permissions", "permissions"); permissions != "" {
+			parts = append(parts, permissions)
+		}
+		if deepLink := al.Keys.FormatKeyAction("test_deep_link", "open URL"); deepLink != "" {
+			parts = append(parts, deepLink)
+		}
+		if push := al.Keys.FormatKeyAction("send_push_notification", "push"); push != "" {
+			parts = append(parts, push)
+		}
+		if multi := al.Keys.FormatKeyAction("multi_select", "select"); multi != "" {
+			parts = append(parts, multi)
+		}
+		if al.SortLabel != "" {
+			if sortOrder := al.Keys.FormatKeyAction("sort", fmt.Sprintf("sort (%s)", al.SortLabel)); sortOrder != "" {
+				parts = append(parts, sortOrder)
+			}
+		}
 		if search := al.Keys.FormatKeyAction("search", "search"); search != "" {
 			parts = append(parts, search)
 		}
@@ -139,6 +221,61 @@ func (al *AppList) GetFooter() string {
 	return footer + scrollInfo
 }
 
+// allSimsFooterParts returns the footer actions available in all-apps
+// mode. Actions that need a single simulator context are left out, and
+// there is no view to go back to.
+func (al *AppList) allSimsFooterParts() []string {
+	var parts []string
+	if up := al.Keys.FormatKeyAction("up", "up"); up != "" {
+		parts = append(parts, up)
+	}
+	if down := al.Keys.FormatKeyAction("down", "down"); down != "" {
+		parts = append(parts, down)
+	}
+	if right := al.Keys.FormatKeyAction("right", "files"); right != "" {
+		parts = append(parts, right)
+	}
+	if open := al.Keys.FormatKeyAction("open", "open in Finder"); open != "" {
+		parts = append(parts, open)
+	}
+	if cp := al.Keys.FormatKeyAction("copy", "copy path"); cp != "" {
+		parts = append(parts, cp)
+	}
+	if logs := al.Keys.FormatKeyAction("view_logs", "logs"); logs != "" {
+		parts = append(parts, logs)
+	}
+	if sortOrder := al.Keys.FormatKeyAction("cycle_sort", fmt.Sprintf("sort (%s)", al.SortLabel)); sortOrder != "" {
+		parts = append(parts, sortOrder)
+	}
+	if search := al.Keys.FormatKeyAction("search", "search"); search != "" {
+		parts = append(parts, search)
+	}
+	if quit := al.Keys.FormatKeyAction("quit", "quit"); quit != "" {
+		parts = append(parts, quit)
+	}
+	return parts
+}
+
+// getMultiSelectFooter returns the footer shown in multi-select mode
+func (al *AppList) getMultiSelectFooter() string {
+	scrollInfo := ui.FormatScrollInfo(al.Viewport, al.calculateItemsPerScreen(), len(al.Apps))
+	if al.Keys == nil {
+		return "space: toggle • D: delete selected • ESC: cancel" + scrollInfo
+	}
+
+	var parts []string
+	if toggle := al.Keys.FormatKeyAction("open", "toggle"); toggle != "" {
+		parts = append(parts, toggle)
+	}
+	if del := al.Keys.FormatKeyAction("delete_selected", "delete selected"); del != "" {
+		parts = append(parts, del)
+	}
+	if esc := al.Keys.FormatKeyAction("escape", "cancel"); esc != "" {
+		parts = append(parts, esc)
+	}
+	return strings.Join(parts, " • ") + scrollInfo
+}
+
 // GetStatus returns the status message for the app list
 func (al *AppList) GetStatus() string {
 	if al.SearchMode {
@@ -172,19 +309,43 @@ func (al *AppList) renderList(startIdx, endIdx int) string {
 		app := al.Apps[i]
 
 		// Format app details
-		sizeText := simulator.FormatSize(app.Size)
-		modTimeText := simulator.FormatModTime(app.ModTime)
+		sizeText := app.SizeText()
+		modTimeText := simulator.FormatModTime(app.ModTime, al.DateFormat)
 		detailText := fmt.Sprintf("%s • %s", app.BundleID, sizeText)
 		if app.Version != "" {
 			detailText = fmt.Sprintf("%s • v%s • %s", app.BundleID, app.Version, sizeText)
 		}
+		if badge := formatUIFramework(app.UIFramework); badge != "" {
+			detailText = fmt.Sprintf("%s • %s", detailText, badge)
+		}
+		if al.ShowAllSims {
+			detailText = fmt.Sprintf("%s • %s", detailText, formatSimulator(app))
+		}
 		if modTimeText != "" {
 			detailText = fmt.Sprintf("%s • %s", detailText, modTimeText)
 		}
+		if app.CrashCount > 0 {
+			detailText = fmt.Sprintf("%s • %s", detailText, formatCrashCount(app.CrashCount))
+		}
+		if len(app.URLSchemes) > 0 {
+			detailText = fmt.Sprintf("%s • %s", detailText, formatURLSchemeCount(len(app.URLSchemes)))
+		}
+
+		name := app.Name
+		if al.MultiSelect {
+			mark := "  "
+			if al.Selected[app.Path] {
+				mark = "✓ "
+			}
+			name = mark + name
+		}
 
 		if i == al.Cursor {
-			// Selected item
-			line1 := fmt.Sprintf("▶ %s", app.Name)
+			// Selected item, with its extensions in the detail line
+			if extensions := formatExtensionCounts(app.Extensions); extensions != "" {
+				detailText = fmt.Sprintf("%s • %s", detailText, extensions)
+			}
+			line1 := fmt.Sprintf("▶ %s", name)
 			line2 := fmt.Sprintf("  %s", detailText)
 
 			// Pad to full width
@@ -196,7 +357,7 @@ func (al *AppList) renderList(startIdx, endIdx int) string {
 			s.WriteString(ui.SelectedStyle().Render(line2))
 		} else {
 			// Non-selected item
-			s.WriteString(ui.ListItemStyle().Inherit(ui.NameStyle()).Render(app.Name))
+			s.WriteString(ui.ListItemStyle().Inherit(ui.NameStyle()).Render(name))
 			s.WriteString("\n")
 			s.WriteString(ui.ListItemStyle().Inherit(ui.DetailStyle()).Render(detailText))
 		}
@@ -208,3 +369,56 @@ func (al *AppList) renderList(startIdx, endIdx int) string {
 
 	return s.String()
 }
+
+// formatCrashCount formats a crash report count for the detail line
+func formatCrashCount(n int) string {
+	if n == 1 {
+		return "1 crash"
+	}
+	return fmt.Sprintf("%d crashes", n)
+}
+
+// formatUIFramework formats an app's UI framework as a badge, e.g.
+// "[SwiftUI]"; undetected frameworks have none
+func formatUIFramework(framework string) string {
+	if framework == "" || framework == simulator.UIFrameworkUnknown {
+		return ""
+	}
+	return "[" + framework + "]"
+}
+
+// formatURLSchemeCount formats a URL scheme count for the detail line
+func formatURLSchemeCount(n int) string {
+	if n == 1 {
+		return "1 URL scheme"
+	}
+	return fmt.Sprintf("%d URL schemes", n)
+}
+
+// formatExtensionCounts counts an app's extensions by kind, e.g.
+// "Widgets: 1 • Share ext: 1", in order of the kind labels
+func formatExtensionCounts(extensions []string) string {
+	counts := make(map[string]int)
+	for _, id := range extensions {
+		counts[simulator.ExtensionLabel(id)]++
+	}
+	labels := make([]string, 0, len(counts))
+	for label := range counts {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	parts := make([]string, len(labels))
+	for i, label := range labels {
+		parts[i] = fmt.Sprintf("%s: %d", label, counts[label])
+	}
+	return strings.Join(parts, " • ")
+}
+
+// formatSimulator names the simulator an app is installed on, with its
+// OS version when known
+func formatSimulator(app simulator.App) string {
+	if app.SimulatorOS == "" {
+		return app.SimulatorName
+	}
+	return fmt.Sprintf("%s (%s)", app.SimulatorName, app.SimulatorOS)
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/azizuysal/simtool/internal/config"
 	"github.com/azizuysal/simtool/internal/simulator"
 )
 
@@ -161,7 +162,7 @@ func TestAppListGetFooter(t *testing.T) {
 		{
 			name:       "normal mode",
 			searchMode: false,
-			expected:   "↑/k: up • ↓/j: down • →/l: files • space: open in Finder • /: search • ←/h: back • q: quit",
+			expected:   "↑/k: up • ↓/j: down • →/l: files • space: open in Finder • L: logs • C: crashes • N: notifications • m: select • /: search • ←/h: back • q: quit",
 		},
 		{
 			name:       "search mode",
@@ -222,3 +223,115 @@ func TestAppListGetStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestAppListMultiSelect(t *testing.T) {
+	al := NewAppList(80, 24)
+	apps := []simulator.App{
+		{Name: "Alpha", BundleID: "com.example.alpha", Path: "/bundles/Alpha.app"},
+		{Name: "Beta", BundleID: "com.example.beta", Path: "/bundles/Beta.app"},
+	}
+	al.Update(apps, 0, 0, false, "", "iPhone 15", nil)
+	al.SetSelection(true, map[string]bool{"/bundles/Beta.app": true})
+
+	if got := al.Render(); !strings.Contains(got, "✓ Beta") || strings.Contains(got, "✓ Alpha") {
+		t.Errorf("Render() should mark only Beta:\n%s", got)
+	}
+	if got := al.GetTitle(2); !strings.Contains(got, "1 selected") {
+		t.Errorf("GetTitle() = %q", got)
+	}
+	if got := al.GetFooter(); !strings.Contains(got, "space: toggle • D: delete selected • ESC: cancel") {
+		t.Errorf("GetFooter() = %q", got)
+	}
+}
+
+func TestAppListShowAllSims(t *testing.T) {
+	al := NewAppList(120, 24)
+	apps := []simulator.App{
+		{Name: "Alpha", BundleID: "com.example.alpha", SimulatorName: "iPhone 15", SimulatorOS: "iOS 17.0"},
+		{Name: "Beta", BundleID: "com.example.beta", SimulatorName: "iPad Air"},
+	}
+	al.Update(apps, 0, 0, false, "", "", nil)
+	al.SetShowAllSims(true, "size")
+
+	got := al.Render()
+	for _, want := range []string{"iPhone 15 (iOS 17.0)", "iPad Air"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() should contain %q:\n%s", want, got)
+		}
+	}
+	if got := al.GetTitle(3); got != "All Apps (2)" {
+		t.Errorf("GetTitle() = %q, want %q", got, "All Apps (2)")
+	}
+
+	keys := config.DefaultKeys()
+	al.Update(apps, 0, 0, false, "", "", &keys)
+	footer := al.GetFooter()
+	if !strings.Contains(footer, "Tab: sort (size)") {
+		t.Errorf("GetFooter() should show the sort order, got %q", footer)
+	}
+	if strings.Contains(footer, "back") {
+		t.Errorf("GetFooter() has no view to go back to, got %q", footer)
+	}
+
+	al.Update(nil, 0, 0, false, "", "", nil)
+	if got := al.Render(); !strings.Contains(got, "No apps installed on any simulator") {
+		t.Errorf("Render() empty = %q", got)
+	}
+}
+
+func TestAppListRender_ExtensionCounts(t *testing.T) {
+	al := NewAppList(100, 24)
+	apps := []simulator.App{
+		{Name: "Alpha", BundleID: "com.example.alpha", Extensions: []string{
+			"com.apple.share-services", "com.apple.widgetkit-extension", "com.apple.widgetkit-extension",
+		}},
+		{Name: "Beta", BundleID: "com.example.beta", Extensions: []string{"com.apple.keyboard-service"}},
+	}
+	al.Update(apps, 0, 0, false, "", "iPhone 15", nil)
+
+	got := al.Render()
+	if !strings.Contains(got, "Share ext: 1 • Widgets: 2") {
+		t.Errorf("Render() should count the selected app's extensions:\n%s", got)
+	}
+	if strings.Contains(got, "Keyboard") {
+		t.Errorf("Render() should only count extensions of the selected app:\n%s", got)
+	}
+}
+
+func TestAppListRender_URLSchemes(t *testing.T) {
+	al := NewAppList(100, 24)
+	apps := []simulator.App{
+		{Name: "Alpha", BundleID: "com.example.alpha", URLSchemes: []string{"alpha", "fb123"}},
+		{Name: "Beta", BundleID: "com.example.beta", URLSchemes: []string{"beta"}},
+		{Name: "Gamma", BundleID: "com.example.gamma"},
+	}
+	al.Update(apps, 0, 0, false, "", "iPhone 15", nil)
+
+	got := al.Render()
+	if !strings.Contains(got, "2 URL schemes") || !strings.Contains(got, "1 URL scheme") {
+		t.Errorf("Render() should count each app's URL schemes:\n%s", got)
+	}
+	if strings.Count(got, "URL scheme") != 2 {
+		t.Errorf("Render() should leave out apps without URL schemes:\n%s", got)
+	}
+}
+
+func TestAppListRender_UIFramework(t *testing.T) {
+	al := NewAppList(120, 24)
+	apps := []simulator.App{
+		{Name: "Alpha", BundleID: "com.example.alpha", UIFramework: simulator.UIFrameworkSwiftUI},
+		{Name: "Beta", BundleID: "com.example.beta", UIFramework: simulator.UIFrameworkCatalyst},
+		{Name: "Gamma", BundleID: "com.example.gamma", UIFramework: simulator.UIFrameworkUnknown},
+	}
+	al.Update(apps, 0, 0, false, "", "iPhone 15", nil)
+
+	got := al.Render()
+	for _, badge := range []string{"[SwiftUI]", "[Catalyst]"} {
+		if !strings.Contains(got, badge) {
+			t.Errorf("Render() missing %s badge:\n%s", badge, got)
+		}
+	}
+	if strings.Contains(got, "[Unknown]") {
+		t.Errorf("Render() should leave out undetected frameworks:\n%s", got)
+	}
+}
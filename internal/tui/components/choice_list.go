@@ -0,0 +1,119 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/ui"
+)
+
+// Choice is one entry of a ChoiceList
+type Choice struct {
+	Name   string
+	Detail string
+}
+
+// ChoiceList renders a list to pick one entry from, such as a step of
+// the new simulator form
+type ChoiceList struct {
+	Width    int
+	Height   int
+	Title    string
+	Choices  []Choice
+	Cursor   int
+	Viewport int
+	Keys     *config.KeysConfig
+}
+
+// NewChoiceList creates a new choice list renderer
+func NewChoiceList(width, height int) *ChoiceList {
+	return &ChoiceList{
+		Width:  width,
+		Height: height,
+	}
+}
+
+// Update updates the list data
+func (cl *ChoiceList) Update(title string, choices []Choice, cursor, viewport int, keys *config.KeysConfig) {
+	cl.Title = title
+	cl.Choices = choices
+	cl.Cursor = cursor
+	cl.Viewport = viewport
+	cl.Keys = keys
+}
+
+// Render renders the visible choices
+func (cl *ChoiceList) Render() string {
+	if len(cl.Choices) == 0 {
+		return ui.DetailStyle().Render("Nothing to choose from")
+	}
+
+	var s strings.Builder
+	innerWidth := cl.Width - 4 // Account for padding
+
+	startIdx := cl.Viewport
+	endIdx := min(cl.Viewport+cl.calculateItemsPerScreen(), len(cl.Choices))
+	for i := startIdx; i < endIdx; i++ {
+		choice := cl.Choices[i]
+
+		if i == cl.Cursor {
+			line1 := ui.PadLine(fmt.Sprintf("▶ %s", choice.Name), innerWidth)
+			line2 := ui.PadLine(fmt.Sprintf("  %s", choice.Detail), innerWidth)
+			s.WriteString(ui.SelectedStyle().Render(line1))
+			s.WriteString("\n")
+			s.WriteString(ui.SelectedStyle().Render(line2))
+		} else {
+			s.WriteString(ui.ListItemStyle().Inherit(ui.NameStyle()).Render(choice.Name))
+			s.WriteString("\n")
+			s.WriteString(ui.ListItemStyle().Inherit(ui.DetailStyle()).Render(choice.Detail))
+		}
+
+		if i < endIdx-1 {
+			s.WriteString("\n\n")
+		}
+	}
+
+	return s.String()
+}
+
+// GetTitle returns the title for the choice list
+func (cl *ChoiceList) GetTitle() string {
+	return fmt.Sprintf("%s (%d)", cl.Title, len(cl.Choices))
+}
+
+// GetFooter returns the footer for the choice list
+func (cl *ChoiceList) GetFooter() string {
+	itemsPerScreen := cl.calculateItemsPerScreen()
+	scrollInfo := ui.FormatScrollInfo(cl.Viewport, itemsPerScreen, len(cl.Choices))
+
+	if cl.Keys == nil {
+		return "↑/k: up • ↓/j: down • →/l: select • ←/h: back • q: quit" + scrollInfo
+	}
+
+	var parts []string
+	if up := cl.Keys.FormatKeyAction("up", "up"); up != "" {
+		parts = append(parts, up)
+	}
+	if down := cl.Keys.FormatKeyAction("down", "down"); down != "" {
+		parts = append(parts, down)
+	}
+	if right := cl.Keys.FormatKeyAction("right", "select"); right != "" {
+		parts = append(parts, right)
+	}
+	if left := cl.Keys.FormatKeyAction("left", "back"); left != "" {
+		parts = append(parts, left)
+	}
+	if quit := cl.Keys.FormatKeyAction("quit", "quit"); quit != "" {
+		parts = append(parts, quit)
+	}
+
+	return strings.Join(parts, " • ") + scrollInfo
+}
+
+// calculateItemsPerScreen calculates how many items fit on screen
+func (cl *ChoiceList) calculateItemsPerScreen() int {
+	// Each item takes 3 lines (name + details + blank line)
+	availableHeight := cl.Height - 2 // Border takes 2 lines
+	return max(availableHeight/3, 1)
+}
@@ -0,0 +1,35 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/config"
+)
+
+func TestChoiceList(t *testing.T) {
+	cl := NewChoiceList(80, 20)
+	keys := config.DefaultKeys()
+	cl.Update("New Simulator: Runtime", []Choice{
+		{Name: "iOS 17.0", Detail: "Build 21A329"},
+		{Name: "iOS 16.4", Detail: "Build 20E247"},
+	}, 1, 0, &keys)
+
+	got := cl.Render()
+	for _, want := range []string{"iOS 17.0", "Build 21A329", "▶ iOS 16.4"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() missing %q:\n%s", want, got)
+		}
+	}
+	if title := cl.GetTitle(); title != "New Simulator: Runtime (2)" {
+		t.Errorf("GetTitle() = %q", title)
+	}
+	if footer := cl.GetFooter(); !strings.Contains(footer, "→/l: select") || !strings.Contains(footer, "←/h: back") {
+		t.Errorf("GetFooter() = %q", footer)
+	}
+
+	cl.Update("Empty", nil, 0, 0, &keys)
+	if got := cl.Render(); !strings.Contains(got, "Nothing to choose from") {
+		t.Errorf("Render() with no choices = %q", got)
+	}
+}
@@ -0,0 +1,108 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/simulator"
+	"github.com/azizuysal/simtool/internal/ui"
+)
+
+// ColumnPicker renders a table's columns with checkboxes for choosing
+// which are shown, as a panel centered over the table content
+type ColumnPicker struct {
+	Width  int
+	Height int
+	Table  *simulator.TableInfo
+	Hidden map[string]bool
+	Cursor int
+	Keys   *config.KeysConfig
+}
+
+// NewColumnPicker creates a new column picker renderer
+func NewColumnPicker(width, height int) *ColumnPicker {
+	return &ColumnPicker{
+		Width:  width,
+		Height: height,
+	}
+}
+
+// Update updates the picker data
+func (cp *ColumnPicker) Update(table *simulator.TableInfo, hidden map[string]bool, cursor int, keys *config.KeysConfig) {
+	cp.Table = table
+	cp.Hidden = hidden
+	cp.Cursor = cursor
+	cp.Keys = keys
+}
+
+// Render renders the panel centered in the content area
+func (cp *ColumnPicker) Render() string {
+	if cp.Table == nil {
+		return ""
+	}
+
+	var s strings.Builder
+	s.WriteString(ui.NameStyle().Render(cp.GetTitle()))
+	s.WriteString("\n")
+
+	// Title, blank line, panel border and padding, content box border
+	rows := max(cp.Height-8, 1)
+	start := 0
+	if cp.Cursor >= rows {
+		start = cp.Cursor - rows + 1
+	}
+	end := min(start+rows, len(cp.Table.Columns))
+	for i := start; i < end; i++ {
+		col := cp.Table.Columns[i]
+		check := "[x]"
+		if cp.Hidden[col.Name] {
+			check = "[ ]"
+		}
+		line := fmt.Sprintf("%s %s", check, col.Name)
+		if col.Type != "" {
+			line += " " + ui.DetailStyle().Render(col.Type)
+		}
+		s.WriteString("\n")
+		if i == cp.Cursor {
+			s.WriteString(ui.SelectedStyle().Render("▶ " + line))
+		} else {
+			s.WriteString(ui.NormalStyle().Render("  " + line))
+		}
+	}
+
+	panel := ui.BorderStyle().Padding(1, 2).Render(s.String())
+	// The content box border takes 2 lines
+	return lipgloss.Place(max(cp.Width-4, 0), max(cp.Height-2, 0), lipgloss.Center, lipgloss.Center, panel)
+}
+
+// GetTitle returns the title for the panel
+func (cp *ColumnPicker) GetTitle() string {
+	if cp.Table == nil {
+		return "Columns"
+	}
+	shown := 0
+	for _, col := range cp.Table.Columns {
+		if !cp.Hidden[col.Name] {
+			shown++
+		}
+	}
+	return fmt.Sprintf("Columns (%d of %d shown)", shown, len(cp.Table.Columns))
+}
+
+// GetFooter returns the footer shown while the panel is open
+func (cp *ColumnPicker) GetFooter() string {
+	if cp.Keys == nil {
+		return "↑/↓: move • space: show/hide • ESC: close"
+	}
+	parts := []string{config.FormatKeys(cp.Keys.Up) + "/" + config.FormatKeys(cp.Keys.Down) + ": move"}
+	if toggle := cp.Keys.FormatKeyAction("open", "show/hide"); toggle != "" {
+		parts = append(parts, toggle)
+	}
+	if esc := cp.Keys.FormatKeyAction("escape", "close"); esc != "" {
+		parts = append(parts, esc)
+	}
+	return strings.Join(parts, " • ")
+}
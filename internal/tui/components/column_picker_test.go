@@ -0,0 +1,26 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestColumnPickerRender(t *testing.T) {
+	table := &simulator.TableInfo{Name: "users", Columns: []simulator.ColumnInfo{{Name: "id", Type: "INTEGER"}, {Name: "name"}, {Name: "email"}}}
+	keys := config.DefaultKeys()
+	cp := NewColumnPicker(80, 24)
+	cp.Update(table, map[string]bool{"email": true}, 1, &keys)
+
+	got := cp.Render()
+	for _, want := range []string{"Columns (2 of 3 shown)", "[x] id", "INTEGER", "▶ [x] name", "[ ] email"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() missing %q:\n%s", want, got)
+		}
+	}
+	if footer := cp.GetFooter(); !strings.Contains(footer, "show/hide") || !strings.Contains(footer, "close") {
+		t.Errorf("GetFooter() = %q", footer)
+	}
+}
@@ -28,6 +28,18 @@ var (
 	_ Component = (*FileList)(nil)
 	_ Component = (*DatabaseTableList)(nil)
 	_ Component = (*DatabaseTableContent)(nil)
+	_ Component = (*LogStream)(nil)
+	_ Component = (*DiffView)(nil)
+	_ Component = (*CrashLogList)(nil)
+	_ Component = (*NotificationPanel)(nil)
+	_ Component = (*ResourcesPanel)(nil)
+	_ Component = (*MetadataPanel)(nil)
+	_ Component = (*ColumnPicker)(nil)
+	_ Component = (*PermissionsPanel)(nil)
+	_ Component = (*PushEditor)(nil)
+	_ Component = (*URLCacheList)(nil)
+	_ Component = (*AppGroupList)(nil)
+	_ Component = (*ChoiceList)(nil)
 )
 
 // renderHeaderPrefix returns a rendered header block followed by a
@@ -0,0 +1,56 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/azizuysal/simtool/internal/ui"
+)
+
+// ConfirmPanel renders a yes/no question as a panel centered over the
+// list it is about
+type ConfirmPanel struct {
+	Width    int
+	Height   int
+	Question string
+	Details  []string // Lines shown under the question
+}
+
+// NewConfirmPanel creates a new confirmation panel renderer
+func NewConfirmPanel(width, height int) *ConfirmPanel {
+	return &ConfirmPanel{
+		Width:  width,
+		Height: height,
+	}
+}
+
+// Update updates the panel data
+func (cp *ConfirmPanel) Update(question string, details []string) {
+	cp.Question = question
+	cp.Details = details
+}
+
+// Render renders the panel centered in the content area
+func (cp *ConfirmPanel) Render() string {
+	var s strings.Builder
+	s.WriteString(ui.WarningStyle().Render(cp.Question))
+	if len(cp.Details) > 0 {
+		s.WriteString("\n\n")
+		s.WriteString(ui.DetailStyle().Render(strings.Join(cp.Details, "\n")))
+	}
+
+	panel := ui.BorderStyle().Padding(1, 2).Render(s.String())
+	// The content box border takes 2 lines
+	return lipgloss.Place(max(cp.Width-4, 0), max(cp.Height-2, 0), lipgloss.Center, lipgloss.Center, panel)
+}
+
+// GetTitle returns the title for the panel
+func (cp *ConfirmPanel) GetTitle() string {
+	return cp.Question
+}
+
+// GetFooter returns the footer shown while the panel is open
+func (cp *ConfirmPanel) GetFooter() string {
+	return "y: yes • n: no"
+}
@@ -0,0 +1,21 @@
+package components
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfirmPanelRender(t *testing.T) {
+	cp := NewConfirmPanel(80, 20)
+
+	cp.Update("Delete iPhone 14?", []string{"iOS 16.0 • udid-14", "Its apps and data are removed too."})
+	got := cp.Render()
+	for _, want := range []string{"Delete iPhone 14?", "iOS 16.0 • udid-14", "Its apps and data are removed too.", "╭"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() missing %q:\n%s", want, got)
+		}
+	}
+	if footer := cp.GetFooter(); footer != "y: yes • n: no" {
+		t.Errorf("GetFooter() = %q", footer)
+	}
+}
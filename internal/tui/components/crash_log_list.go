@@ -0,0 +1,131 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/simulator"
+	"github.com/azizuysal/simtool/internal/ui"
+)
+
+// CrashLogList renders the crash reports found for an app
+type CrashLogList struct {
+	Width    int
+	Height   int
+	App      *simulator.App
+	Logs     []simulator.CrashLog
+	Cursor   int
+	Viewport int
+	Keys     *config.KeysConfig
+
+	DateFormat simulator.DateFormat // How modification times are shown
+}
+
+// NewCrashLogList creates a new crash log list renderer
+func NewCrashLogList(width, height int) *CrashLogList {
+	return &CrashLogList{
+		Width:  width,
+		Height: height,
+	}
+}
+
+// Update updates the list data
+func (cl *CrashLogList) Update(app *simulator.App, logs []simulator.CrashLog, cursor, viewport int, keys *config.KeysConfig) {
+	cl.App = app
+	cl.Logs = logs
+	cl.Cursor = cursor
+	cl.Viewport = viewport
+	cl.Keys = keys
+}
+
+// SetDateFormat sets how modification times are shown
+func (cl *CrashLogList) SetDateFormat(format simulator.DateFormat) {
+	cl.DateFormat = format
+}
+
+// Render renders the crash log list content
+func (cl *CrashLogList) Render() string {
+	if len(cl.Logs) == 0 {
+		return ui.DetailStyle().Render("No crash logs found")
+	}
+
+	var s strings.Builder
+	innerWidth := cl.Width - 4 // Account for padding
+
+	startIdx := cl.Viewport
+	endIdx := min(cl.Viewport+cl.calculateItemsPerScreen(), len(cl.Logs))
+	for i := startIdx; i < endIdx; i++ {
+		log := cl.Logs[i]
+
+		detailText := simulator.FormatSize(log.Size)
+		if modTimeText := simulator.FormatModTime(log.ModTime, cl.DateFormat); modTimeText != "" {
+			detailText = fmt.Sprintf("%s • %s", detailText, modTimeText)
+		}
+
+		if i == cl.Cursor {
+			line1 := ui.PadLine(fmt.Sprintf("▶ %s", log.Name), innerWidth)
+			line2 := ui.PadLine(fmt.Sprintf("  %s", detailText), innerWidth)
+			s.WriteString(ui.SelectedStyle().Render(line1))
+			s.WriteString("\n")
+			s.WriteString(ui.SelectedStyle().Render(line2))
+		} else {
+			s.WriteString(ui.ListItemStyle().Inherit(ui.NameStyle()).Render(log.Name))
+			s.WriteString("\n")
+			s.WriteString(ui.ListItemStyle().Inherit(ui.DetailStyle()).Render(detailText))
+		}
+
+		if i < endIdx-1 {
+			s.WriteString("\n\n")
+		}
+	}
+
+	return s.String()
+}
+
+// GetTitle returns the title for the crash log list
+func (cl *CrashLogList) GetTitle() string {
+	if cl.App != nil {
+		return fmt.Sprintf("%s Crash Logs (%d)", cl.App.Name, len(cl.Logs))
+	}
+	return fmt.Sprintf("Crash Logs (%d)", len(cl.Logs))
+}
+
+// GetFooter returns the footer for the crash log list
+func (cl *CrashLogList) GetFooter() string {
+	itemsPerScreen := cl.calculateItemsPerScreen()
+	scrollInfo := ui.FormatScrollInfo(cl.Viewport, itemsPerScreen, len(cl.Logs))
+
+	if cl.Keys == nil {
+		return "↑/k: up • ↓/j: down • →/l: view • space: open in Finder • ←/h: back • q: quit" + scrollInfo
+	}
+
+	var parts []string
+	if up := cl.Keys.FormatKeyAction("up", "up"); up != "" {
+		parts = append(parts, up)
+	}
+	if down := cl.Keys.FormatKeyAction("down", "down"); down != "" {
+		parts = append(parts, down)
+	}
+	if right := cl.Keys.FormatKeyAction("right", "view"); right != "" {
+		parts = append(parts, right)
+	}
+	if open := cl.Keys.FormatKeyAction("open", "open in Finder"); open != "" {
+		parts = append(parts, open)
+	}
+	if left := cl.Keys.FormatKeyAction("left", "back"); left != "" {
+		parts = append(parts, left)
+	}
+	if quit := cl.Keys.FormatKeyAction("quit", "quit"); quit != "" {
+		parts = append(parts, quit)
+	}
+
+	return strings.Join(parts, " • ") + scrollInfo
+}
+
+// calculateItemsPerScreen calculates how many items fit on screen
+func (cl *CrashLogList) calculateItemsPerScreen() int {
+	// Each item takes 3 lines (name + details + blank line)
+	availableHeight := cl.Height - 2 // Border takes 2 lines
+	return max(availableHeight/3, 1)
+}
@@ -0,0 +1,47 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestCrashLogListRender(t *testing.T) {
+	cl := NewCrashLogList(80, 24)
+	app := &simulator.App{Name: "Example"}
+
+	cl.Update(app, nil, 0, 0, nil)
+	if got := cl.Render(); !strings.Contains(got, "No crash logs found") {
+		t.Errorf("empty Render() = %q", got)
+	}
+
+	logs := []simulator.CrashLog{
+		{Name: "Example-2.ips", Size: 2048, ModTime: time.Now()},
+		{Name: "Example-1.crash", Size: 100},
+	}
+	cl.Update(app, logs, 1, 0, nil)
+	got := cl.Render()
+	for _, want := range []string{"Example-2.ips", "▶ Example-1.crash", "2.0 KB", "just now"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() missing %q:\n%s", want, got)
+		}
+	}
+	if title := cl.GetTitle(); title != "Example Crash Logs (2)" {
+		t.Errorf("GetTitle() = %q", title)
+	}
+}
+
+func TestCrashLogListGetFooter(t *testing.T) {
+	cl := NewCrashLogList(80, 24)
+	keys := config.DefaultKeys()
+	cl.Update(nil, nil, 0, 0, &keys)
+	got := cl.GetFooter()
+	for _, want := range []string{"→/l: view", "←/h: back", "space: open in Finder"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("GetFooter() = %q, missing %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/simulator"
+	"github.com/azizuysal/simtool/internal/ui"
+)
+
+// DatabaseQuery renders the SQL query editor of a database above the
+// results of the last query run from it
+type DatabaseQuery struct {
+	Width        int
+	Height       int
+	DatabaseFile *simulator.FileInfo
+	Input        string
+	Cursor       int // Rune index of the cursor in Input
+	Result       *simulator.QueryResult
+	Viewport     int // First result row shown
+	HScroll      int // Result columns scrolled off the left edge
+	Keys         *config.KeysConfig
+}
+
+// NewDatabaseQuery creates a new query editor renderer
+func NewDatabaseQuery(width, height int) *DatabaseQuery {
+	return &DatabaseQuery{
+		Width:  width,
+		Height: height,
+	}
+}
+
+// Update updates the query being edited
+func (dq *DatabaseQuery) Update(dbFile *simulator.FileInfo, input string, cursor int, keys *config.KeysConfig) {
+	dq.DatabaseFile = dbFile
+	dq.Input = input
+	dq.Cursor = cursor
+	dq.Keys = keys
+}
+
+// SetResult sets the results of the last query and how far they are
+// scrolled
+func (dq *DatabaseQuery) SetResult(result *simulator.QueryResult, viewport, hScroll int) {
+	dq.Result = result
+	dq.Viewport = viewport
+	dq.HScroll = hScroll
+}
+
+// Render renders the editor, then the results in the same aligned
+// columns as the table content view
+func (dq *DatabaseQuery) Render() string {
+	innerWidth := dq.Width - 4 // Account for padding
+	editor := dq.editorLines()
+
+	var s strings.Builder
+	s.WriteString(strings.Join(editor, "\n"))
+	s.WriteString("\n\n")
+	s.WriteString(ui.DetailStyle().Render(strings.Repeat("─", innerWidth)))
+	s.WriteString("\n\n")
+
+	if dq.Result == nil {
+		s.WriteString(ui.DetailStyle().Render("No results yet"))
+		return s.String()
+	}
+	table, rows := queryResultTable(dq.Result)
+	results := NewDatabaseTableContent(dq.Width, dq.Height-len(editor)-4)
+	results.Update(table, rows, dq.DatabaseFile, dq.Viewport, 0, dq.Keys)
+	results.SetHScroll(dq.HScroll)
+	s.WriteString(results.Render())
+	return s.String()
+}
+
+// editorLines returns the query's lines behind sqlite3-style prompts,
+// with the cursor drawn in. Queries taller than a third of the view are
+// scrolled to keep the cursor's line in view.
+func (dq *DatabaseQuery) editorLines() []string {
+	var b strings.Builder
+	cursorLine := 0
+	for i, r := range []rune(dq.Input) {
+		if i == dq.Cursor {
+			b.WriteString("▏")
+		}
+		if r == '\n' && i < dq.Cursor {
+			cursorLine++
+		}
+		b.WriteRune(r)
+	}
+	if dq.Cursor >= len([]rune(dq.Input)) {
+		b.WriteString("▏")
+	}
+
+	lines := strings.Split(b.String(), "\n")
+	for i, line := range lines {
+		prompt := "sqlite> "
+		if i > 0 {
+			prompt = "   ...> "
+		}
+		lines[i] = ui.DetailStyle().Render(prompt) + ui.NormalStyle().Render(line)
+	}
+
+	visible := max(dq.Height/3, 1)
+	if len(lines) <= visible {
+		return lines
+	}
+	start := min(max(cursorLine-visible+1, 0), len(lines)-visible)
+	return lines[start : start+visible]
+}
+
+// queryResultTable converts query results into a table and its rows for
+// the database table renderer
+func queryResultTable(result *simulator.QueryResult) (*simulator.TableInfo, []map[string]any) {
+	columns := make([]simulator.ColumnInfo, len(result.Columns))
+	for i, name := range result.Columns {
+		columns[i] = simulator.ColumnInfo{Name: name}
+	}
+	table := &simulator.TableInfo{
+		Name:     "Results",
+		RowCount: int64(len(result.Rows)),
+		Columns:  columns,
+	}
+	return table, result.Rows
+}
+
+// GetTitle returns the title for the query editor
+func (dq *DatabaseQuery) GetTitle() string {
+	if dq.DatabaseFile != nil {
+		return fmt.Sprintf("Query: %s", dq.DatabaseFile.Name)
+	}
+	return "Query"
+}
+
+// GetFooter returns the footer for the query editor
+func (dq *DatabaseQuery) GetFooter() string {
+	if dq.Keys == nil {
+		return "ctrl+e: run • enter: new line • ↑/↓: history • PgUp/PgDn: scroll results • ESC: back"
+	}
+	var parts []string
+	if run := dq.Keys.FormatKeyAction("run_query", "run"); run != "" {
+		parts = append(parts, run)
+	}
+	parts = append(parts, "enter: new line", "↑/↓: history")
+	if dq.Result != nil && len(dq.Result.Rows) > 0 {
+		parts = append(parts, "PgUp/PgDn: scroll results")
+	}
+	if dq.Result != nil && len(dq.Result.Columns) > 1 {
+		parts = append(parts, "shift+←/→: scroll columns")
+	}
+	if esc := dq.Keys.FormatKeyAction("escape", "back"); esc != "" {
+		parts = append(parts, esc)
+	}
+	return strings.Join(parts, " • ") + dq.rowInfo()
+}
+
+// rowInfo describes the result rows shown for the footer
+func (dq *DatabaseQuery) rowInfo() string {
+	if dq.Result == nil || len(dq.Result.Rows) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (from row %d of %d)", dq.Viewport+1, len(dq.Result.Rows))
+}
+
+// GetStatus warns when the results were cut short
+func (dq *DatabaseQuery) GetStatus() string {
+	if dq.Result == nil || !dq.Result.Truncated {
+		return ""
+	}
+	return ui.WarningStyle().Render(fmt.Sprintf("⚠ Showing the first %d rows", len(dq.Result.Rows)))
+}
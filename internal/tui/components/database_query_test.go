@@ -0,0 +1,100 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestDatabaseQueryRender(t *testing.T) {
+	keys := config.DefaultKeys()
+	dq := NewDatabaseQuery(100, 30)
+	dq.Update(&simulator.FileInfo{Name: "app.db"}, "SELECT name\nFROM users", 6, &keys)
+
+	got := dq.Render()
+	for _, want := range []string{"sqlite> SELECT▏ name", "   ...> FROM users", "No results yet"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() missing %q:\n%s", want, got)
+		}
+	}
+	if title := dq.GetTitle(); title != "Query: app.db" {
+		t.Errorf("GetTitle() = %q", title)
+	}
+
+	dq.SetResult(&simulator.QueryResult{
+		Columns: []string{"name", "age"},
+		Rows: []map[string]any{
+			{"name": "alice", "age": int64(30)},
+			{"name": "bob", "age": int64(25)},
+		},
+	}, 1, 0)
+	got = dq.Render()
+	for _, want := range []string{"2 rows • 2 columns", "name", "age", "bob"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "alice") {
+		t.Errorf("Render() should start the results at the viewport:\n%s", got)
+	}
+}
+
+func TestDatabaseQueryRender_CursorAtEnd(t *testing.T) {
+	dq := NewDatabaseQuery(100, 30)
+	dq.Update(nil, "SELECT 1\n", 9, nil)
+
+	lines := dq.editorLines()
+	if len(lines) != 2 || !strings.HasSuffix(lines[1], "▏") {
+		t.Errorf("editorLines() = %q, want the cursor on an empty second line", lines)
+	}
+}
+
+func TestDatabaseQueryRender_ScrollsToCursor(t *testing.T) {
+	dq := NewDatabaseQuery(100, 9) // Three editor lines
+	dq.Update(nil, "one\ntwo\nthree\nfour\nfive", 0, nil)
+	if got := strings.Join(dq.editorLines(), "\n"); !strings.Contains(got, "▏one") || strings.Contains(got, "four") {
+		t.Errorf("editorLines() should show the top of the query:\n%s", got)
+	}
+
+	dq.Update(nil, "one\ntwo\nthree\nfour\nfive", 23, nil)
+	if got := strings.Join(dq.editorLines(), "\n"); !strings.Contains(got, "five▏") || strings.Contains(got, "two") {
+		t.Errorf("editorLines() should scroll to the cursor's line:\n%s", got)
+	}
+}
+
+func TestDatabaseQueryGetFooter(t *testing.T) {
+	keys := config.DefaultKeys()
+	dq := NewDatabaseQuery(100, 30)
+	dq.Update(nil, "", 0, &keys)
+	if got := dq.GetFooter(); got != "ctrl+e: run • enter: new line • ↑/↓: history • ESC: back" {
+		t.Errorf("GetFooter() = %q", got)
+	}
+
+	dq.SetResult(&simulator.QueryResult{
+		Columns: []string{"a", "b"},
+		Rows:    []map[string]any{{"a": 1, "b": 2}, {"a": 3, "b": 4}},
+	}, 1, 0)
+	got := dq.GetFooter()
+	for _, want := range []string{"PgUp/PgDn: scroll results", "shift+←/→: scroll columns", "(from row 2 of 2)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("GetFooter() = %q, missing %q", got, want)
+		}
+	}
+}
+
+func TestDatabaseQueryGetStatus(t *testing.T) {
+	dq := NewDatabaseQuery(100, 30)
+	if got := dq.GetStatus(); got != "" {
+		t.Errorf("GetStatus() = %q with no results", got)
+	}
+	dq.SetResult(&simulator.QueryResult{
+		Columns:   []string{"a"},
+		Rows:      []map[string]any{{"a": 1}},
+		Truncated: true,
+	}, 0, 0)
+	if got := dq.GetStatus(); !strings.Contains(got, "Showing the first 1 rows") {
+		t.Errorf("GetStatus() = %q, want a truncation warning", got)
+	}
+}
@@ -19,7 +19,15 @@ type DatabaseTableContent struct {
 	DatabaseFile *simulator.FileInfo
 	Viewport     int
 	DataOffset   int
-	Keys         *config.KeysConfig
+	HScroll      int    // Index of the first column shown
+	SortColumn   string // Column the rows are sorted by; "" for table order
+	SortDesc     bool
+	SearchMode   bool   // The query bar is taking input
+	SearchQuery  string // Rows are limited to matches of this query
+	MatchCount   int    // Number of rows matching SearchQuery
+	// Columns left out of the table, keyed by name
+	HiddenColumns map[string]bool
+	Keys          *config.KeysConfig
 }
 
 // NewDatabaseTableContent creates a new database table content renderer
@@ -40,6 +48,30 @@ func (dtc *DatabaseTableContent) Update(table *simulator.TableInfo, data []map[s
 	dtc.Keys = keys
 }
 
+// SetHScroll sets how many columns are scrolled off the left edge
+func (dtc *DatabaseTableContent) SetHScroll(hScroll int) {
+	dtc.HScroll = hScroll
+}
+
+// SetSort sets the column the rows are sorted by and its direction
+func (dtc *DatabaseTableContent) SetSort(column string, desc bool) {
+	dtc.SortColumn = column
+	dtc.SortDesc = desc
+}
+
+// SetSearch sets the row search state: whether the query bar is open,
+// the query, and how many rows match it
+func (dtc *DatabaseTableContent) SetSearch(searchMode bool, query string, matchCount int) {
+	dtc.SearchMode = searchMode
+	dtc.SearchQuery = query
+	dtc.MatchCount = matchCount
+}
+
+// SetHiddenColumns sets the columns left out of the table
+func (dtc *DatabaseTableContent) SetHiddenColumns(hidden map[string]bool) {
+	dtc.HiddenColumns = hidden
+}
+
 // Render renders the table content
 func (dtc *DatabaseTableContent) Render() string {
 	if dtc.Table == nil {
@@ -62,7 +94,7 @@ func (dtc *DatabaseTableContent) Render() string {
 // GetTitle returns the title for the table content view
 func (dtc *DatabaseTableContent) GetTitle() string {
 	if dtc.Table != nil {
-		return fmt.Sprintf("Table: %s", dtc.Table.Name)
+		return fmt.Sprintf("Table: %s", dtc.Table.DisplayName())
 	}
 	return "Table Content"
 }
@@ -72,22 +104,26 @@ func (dtc *DatabaseTableContent) GetFooter() string {
 	if dtc.Keys == nil {
 		// Fallback to default if keys not set
 		footer := "↑/k: scroll up • ↓/j: scroll down • ←/h: back • q: quit"
+		if dtc.firstColumn() > 0 {
+			footer = "↑/k: scroll up • ↓/j: scroll down • ←/h: scroll left • q: quit"
+		}
+		_, visibleColumns := dtc.columnLayout()
+		if indicator := dtc.columnIndicator(visibleColumns); indicator != "" {
+			footer += " • " + indicator
+		}
 
-		// Add scroll info for data rows
-		if dtc.Table != nil && dtc.Table.RowCount > 0 {
-			startRow := dtc.DataOffset + 1
-			endRow := dtc.DataOffset + len(dtc.TableData)
-			totalRows := int(dtc.Table.RowCount)
-
-			if endRow > totalRows {
-				endRow = totalRows
-			}
+		return footer + dtc.rowInfo()
+	}
 
-			scrollInfo := fmt.Sprintf(" (%d-%d of %d)", startRow, endRow, totalRows)
-			footer += scrollInfo
+	if dtc.SearchMode {
+		var parts []string
+		if esc := dtc.Keys.FormatKeyAction("escape", "clear search"); esc != "" {
+			parts = append(parts, esc)
 		}
-
-		return footer
+		if enter := dtc.Keys.FormatKeyAction("enter", "done"); enter != "" {
+			parts = append(parts, enter)
+		}
+		return strings.Join(parts, " • ") + dtc.rowInfo()
 	}
 
 	// Build footer from configured keys
@@ -99,30 +135,82 @@ func (dtc *DatabaseTableContent) GetFooter() string {
 	if down := dtc.Keys.FormatKeyAction("down", "scroll down"); down != "" {
 		parts = append(parts, down)
 	}
-	if left := dtc.Keys.FormatKeyAction("left", "back"); left != "" {
+	_, visibleColumns := dtc.columnLayout()
+	hasMoreRight := dtc.Table != nil && dtc.firstColumn()+visibleColumns < len(dtc.shownColumns())
+	if hasMoreRight {
+		if right := dtc.Keys.FormatKeyAction("right", "scroll right"); right != "" {
+			parts = append(parts, right)
+		}
+	}
+	leftLabel := "back"
+	if dtc.firstColumn() > 0 {
+		leftLabel = "scroll left"
+	}
+	if left := dtc.Keys.FormatKeyAction("left", leftLabel); left != "" {
 		parts = append(parts, left)
 	}
+	if sortKey := dtc.Keys.FormatKeyAction("sort_next_column", "sort"); sortKey != "" {
+		parts = append(parts, sortKey)
+	}
+	if search := dtc.Keys.FormatKeyAction("search", "search"); search != "" {
+		parts = append(parts, search)
+	}
+	if columns := dtc.Keys.FormatKeyAction("toggle_columns", "columns"); columns != "" {
+		parts = append(parts, columns)
+	}
+	if export := dtc.Keys.FormatKeyAction("export_table", "export CSV"); export != "" {
+		parts = append(parts, export)
+	}
+	if dtc.SearchQuery != "" {
+		if esc := dtc.Keys.FormatKeyAction("escape", "clear search"); esc != "" {
+			parts = append(parts, esc)
+		}
+	}
 	if quit := dtc.Keys.FormatKeyAction("quit", "quit"); quit != "" {
 		parts = append(parts, quit)
 	}
+	if indicator := dtc.columnIndicator(visibleColumns); indicator != "" {
+		parts = append(parts, indicator)
+	}
 
-	footer := strings.Join(parts, " • ")
-
-	// Add scroll info for data rows
-	if dtc.Table != nil && dtc.Table.RowCount > 0 {
-		startRow := dtc.DataOffset + 1
-		endRow := dtc.DataOffset + len(dtc.TableData)
-		totalRows := int(dtc.Table.RowCount)
+	return strings.Join(parts, " • ") + dtc.rowInfo()
+}
 
-		if endRow > totalRows {
-			endRow = totalRows
+// rowInfo describes the loaded rows for the footer: the range shown out
+// of the table's rows, or the number of search matches
+func (dtc *DatabaseTableContent) rowInfo() string {
+	if dtc.SearchQuery != "" {
+		if dtc.MatchCount > len(dtc.TableData) {
+			return fmt.Sprintf(" (first %d of %d matches)", len(dtc.TableData), dtc.MatchCount)
+		}
+		if dtc.MatchCount == 1 {
+			return " (1 match)"
 		}
+		return fmt.Sprintf(" (%d matches)", dtc.MatchCount)
+	}
+	if dtc.Table == nil || dtc.Table.RowCount <= 0 {
+		return ""
+	}
+	startRow := dtc.DataOffset + 1
+	endRow := dtc.DataOffset + len(dtc.TableData)
+	totalRows := int(dtc.Table.RowCount)
 
-		scrollInfo := fmt.Sprintf(" (%d-%d of %d)", startRow, endRow, totalRows)
-		footer += scrollInfo
+	if endRow > totalRows {
+		endRow = totalRows
 	}
 
-	return footer
+	return fmt.Sprintf(" (%d-%d of %d)", startRow, endRow, totalRows)
+}
+
+// GetStatus returns the query bar while a search is being typed
+func (dtc *DatabaseTableContent) GetStatus() string {
+	if !dtc.SearchMode {
+		return ""
+	}
+	if dtc.SearchQuery == "" {
+		return ui.SearchStyle().Render("Search: (type to filter rows)")
+	}
+	return ui.SearchStyle().Render("Search: " + dtc.SearchQuery)
 }
 
 // buildHeader builds the header content for the table
@@ -134,15 +222,35 @@ func (dtc *DatabaseTableContent) buildHeader() string {
 	var s strings.Builder
 
 	// Table info
-	s.WriteString(ui.NameStyle().Render(dtc.Table.Name))
+	s.WriteString(ui.NameStyle().Render(dtc.Table.DisplayName()))
 	s.WriteString("\n")
 
-	tableDetails := fmt.Sprintf("%d rows • %d columns", dtc.Table.RowCount, len(dtc.Table.Columns))
+	tableDetails := fmt.Sprintf("%s rows • %d columns", dtc.Table.RowCountText(), len(dtc.Table.Columns))
+	if hidden := len(dtc.Table.Columns) - len(dtc.shownColumns()); hidden > 0 {
+		tableDetails += fmt.Sprintf(" (%d hidden)", hidden)
+	}
 	s.WriteString(ui.DetailStyle().Render(tableDetails))
+	if warning := dtc.largeTableWarning(); warning != "" {
+		s.WriteString("\n")
+		s.WriteString(ui.WarningStyle().Render(warning))
+	}
 
 	return s.String()
 }
 
+// largeTableWarning returns the notice shown over a large table's rows,
+// which are read a page at a time, or "" for other tables
+func (dtc *DatabaseTableContent) largeTableWarning() string {
+	if !dtc.Table.IsLarge() || dtc.SearchQuery != "" || len(dtc.TableData) == 0 {
+		return ""
+	}
+	total := simulator.FormatRowCount(dtc.Table.RowCount)
+	if dtc.DataOffset == 0 {
+		return fmt.Sprintf("⚠ Large table: showing first %d of %s rows", len(dtc.TableData), total)
+	}
+	return fmt.Sprintf("⚠ Large table: showing rows %d-%d of %s", dtc.DataOffset+1, dtc.DataOffset+len(dtc.TableData), total)
+}
+
 // renderWithHeader renders the table content with header
 func (dtc *DatabaseTableContent) renderWithHeader(header string, availableHeight int) string {
 	var s strings.Builder
@@ -151,83 +259,15 @@ func (dtc *DatabaseTableContent) renderWithHeader(header string, availableHeight
 	s.WriteString(renderHeaderPrefix(header, innerWidth))
 
 	// Calculate column widths first to align delimiters
-	var columnWidths []int
-	visibleColumns := 0
-	totalUsedWidth := 0
-
-	if len(dtc.Table.Columns) > 0 {
-		// Reserve space for " | ..." if we won't show all columns
-		reservedSpace := 0
-		if len(dtc.Table.Columns) > 1 {
-			reservedSpace = 6 // " | ..."
-		}
-
-		// First pass: calculate minimum column widths based on headers and sample data
-		for i, col := range dtc.Table.Columns {
-			colHeader := col.Name
-			if col.PK {
-				colHeader += "*"
-			}
-
-			// Start with header width (rune count)
-			minWidth := len([]rune(colHeader))
-
-			// Check ALL loaded rows to get accurate data width
-			// This ensures we calculate based on the actual data we'll display
-			for j := 0; j < len(dtc.TableData); j++ {
-				row := dtc.TableData[j]
-				var valStr string
-				if val, ok := row[col.Name]; ok {
-					valStr = fmt.Sprintf("%v", val)
-					// Sanitize the value for display
-					valStr = sanitizeForDisplay(valStr)
-				} else {
-					valStr = "NULL"
-				}
-				// Use rune count for width calculation to handle multi-byte chars
-				runeCount := len([]rune(valStr))
-				if runeCount > minWidth {
-					minWidth = runeCount
-				}
-			}
-
-			// Check if we can fit this column
-			separatorWidth := 0
-			if i > 0 {
-				separatorWidth = 3 // " | "
-			}
-
-			// Check if we need to reserve space for "..."
-			effectiveWidth := innerWidth
-			if i < len(dtc.Table.Columns)-1 {
-				// Not the last column, so we might need "..."
-				effectiveWidth = innerWidth - reservedSpace
-			}
-
-			if totalUsedWidth+separatorWidth+minWidth <= effectiveWidth {
-				// Column fits entirely
-				columnWidths = append(columnWidths, minWidth)
-				totalUsedWidth += separatorWidth + minWidth
-				visibleColumns++
-			} else {
-				// Column doesn't fit entirely, but add it partially if there's enough space
-				remainingSpace := effectiveWidth - totalUsedWidth - separatorWidth
-				if remainingSpace >= 10 { // Only add if we have at least 10 chars for readability
-					columnWidths = append(columnWidths, remainingSpace)
-					visibleColumns++
-				}
-				break
-			}
-		}
+	columnWidths, visibleColumns := dtc.columnLayout()
+	columns := dtc.shownColumns()[dtc.firstColumn():]
+	hasMoreColumns := visibleColumns < len(columns)
 
+	if len(columns) > 0 {
 		// Render column headers with calculated widths
 		var headerParts []string
 		for i := 0; i < visibleColumns; i++ {
-			col := dtc.Table.Columns[i]
-			colHeader := col.Name
-			if col.PK {
-				colHeader += "*"
-			}
+			colHeader := dtc.columnHeader(columns[i])
 
 			// Pad header to column width based on rune count
 			padded := colHeader
@@ -239,7 +279,7 @@ func (dtc *DatabaseTableContent) renderWithHeader(header string, availableHeight
 		}
 
 		headerStr := strings.Join(headerParts, " | ")
-		if visibleColumns < len(dtc.Table.Columns) {
+		if hasMoreColumns {
 			headerStr += " | ..."
 		}
 
@@ -251,7 +291,9 @@ func (dtc *DatabaseTableContent) renderWithHeader(header string, availableHeight
 	}
 
 	// Show table data
-	if len(dtc.TableData) == 0 {
+	if len(dtc.TableData) == 0 && dtc.SearchQuery != "" {
+		s.WriteString(ui.DetailStyle().Render("No rows match your search"))
+	} else if len(dtc.TableData) == 0 {
 		s.WriteString(ui.DetailStyle().Render("No data"))
 	} else {
 		linesUsed := 0
@@ -271,7 +313,7 @@ func (dtc *DatabaseTableContent) renderWithHeader(header string, availableHeight
 			// Build row data with aligned columns
 			var rowParts []string
 			for j := 0; j < visibleColumns; j++ {
-				col := dtc.Table.Columns[j]
+				col := columns[j]
 				var valStr string
 				if val, ok := row[col.Name]; ok {
 					valStr = fmt.Sprintf("%v", val)
@@ -303,7 +345,7 @@ func (dtc *DatabaseTableContent) renderWithHeader(header string, availableHeight
 
 			rowStr := strings.Join(rowParts, " | ")
 			// Add ... if we have more columns
-			if visibleColumns < len(dtc.Table.Columns) {
+			if hasMoreColumns {
 				rowStr += " | ..."
 			}
 
@@ -315,6 +357,145 @@ func (dtc *DatabaseTableContent) renderWithHeader(header string, availableHeight
 	return s.String()
 }
 
+// columnHeader returns the header label of col: its name, a "*" for
+// primary key columns and an arrow for the sort column
+func (dtc *DatabaseTableContent) columnHeader(col simulator.ColumnInfo) string {
+	header := col.Name
+	if col.PK {
+		header += "*"
+	}
+	if dtc.SortColumn != "" && col.Name == dtc.SortColumn {
+		if dtc.SortDesc {
+			header += " ↓"
+		} else {
+			header += " ↑"
+		}
+	}
+	return header
+}
+
+// shownColumns returns the table's columns without the hidden ones
+func (dtc *DatabaseTableContent) shownColumns() []simulator.ColumnInfo {
+	if dtc.Table == nil {
+		return nil
+	}
+	if len(dtc.HiddenColumns) == 0 {
+		return dtc.Table.Columns
+	}
+	shown := make([]simulator.ColumnInfo, 0, len(dtc.Table.Columns))
+	for _, col := range dtc.Table.Columns {
+		if !dtc.HiddenColumns[col.Name] {
+			shown = append(shown, col)
+		}
+	}
+	return shown
+}
+
+// firstColumn returns the index of the first column shown, clamped to
+// the shown columns
+func (dtc *DatabaseTableContent) firstColumn() int {
+	columns := dtc.shownColumns()
+	if len(columns) == 0 {
+		return 0
+	}
+	return max(min(dtc.HScroll, len(columns)-1), 0)
+}
+
+// columnLayout calculates the widths of the columns that fit on screen,
+// starting from the first scrolled-to column, and how many fit
+func (dtc *DatabaseTableContent) columnLayout() (columnWidths []int, visibleColumns int) {
+	if dtc.Table == nil {
+		return nil, 0
+	}
+	innerWidth := dtc.Width - 4 // Account for padding
+	columns := dtc.shownColumns()[dtc.firstColumn():]
+	totalUsedWidth := 0
+
+	// Reserve space for " | ..." if we won't show all columns
+	reservedSpace := 0
+	if len(columns) > 1 {
+		reservedSpace = 6 // " | ..."
+	}
+
+	// Calculate minimum column widths based on headers and sample data
+	for i, col := range columns {
+		// Start with header width (rune count)
+		minWidth := len([]rune(dtc.columnHeader(col)))
+
+		// Check ALL loaded rows to get accurate data width
+		// This ensures we calculate based on the actual data we'll display
+		for j := 0; j < len(dtc.TableData); j++ {
+			row := dtc.TableData[j]
+			var valStr string
+			if val, ok := row[col.Name]; ok {
+				valStr = fmt.Sprintf("%v", val)
+				// Sanitize the value for display
+				valStr = sanitizeForDisplay(valStr)
+			} else {
+				valStr = "NULL"
+			}
+			// Use rune count for width calculation to handle multi-byte chars
+			runeCount := len([]rune(valStr))
+			if runeCount > minWidth {
+				minWidth = runeCount
+			}
+		}
+
+		// Check if we can fit this column
+		separatorWidth := 0
+		if i > 0 {
+			separatorWidth = 3 // " | "
+		}
+
+		// Check if we need to reserve space for "..."
+		effectiveWidth := innerWidth
+		if i < len(columns)-1 {
+			// Not the last column, so we might need "..."
+			effectiveWidth = innerWidth - reservedSpace
+		}
+
+		if totalUsedWidth+separatorWidth+minWidth <= effectiveWidth {
+			// Column fits entirely
+			columnWidths = append(columnWidths, minWidth)
+			totalUsedWidth += separatorWidth + minWidth
+			visibleColumns++
+		} else {
+			// Column doesn't fit entirely, but add it partially if there's enough space
+			remainingSpace := effectiveWidth - totalUsedWidth - separatorWidth
+			if remainingSpace >= 10 { // Only add if we have at least 10 chars for readability
+				columnWidths = append(columnWidths, remainingSpace)
+				visibleColumns++
+			}
+			break
+		}
+	}
+
+	return columnWidths, visibleColumns
+}
+
+// columnIndicator describes which columns are on screen, e.g.
+// "◀ columns 3-7 ▶", with arrows for the directions that can scroll.
+// It returns "" when every column fits.
+func (dtc *DatabaseTableContent) columnIndicator(visibleColumns int) string {
+	if dtc.Table == nil {
+		return ""
+	}
+	first := dtc.firstColumn()
+	total := len(dtc.shownColumns())
+	if first == 0 && visibleColumns >= total {
+		return ""
+	}
+
+	indicator := fmt.Sprintf("columns %d-%d", first+1, first+max(visibleColumns, 1))
+	if first > 0 {
+		indicator = "◀ " + indicator
+	}
+	if first+visibleColumns < total {
+		indicator += " ▶"
+	}
+	return indicator
+}
+
 // sanitizeForDisplay cleans string values for terminal display
 func sanitizeForDisplay(s string) string {
 	// Remove newlines and carriage returns
@@ -1,9 +1,11 @@
 package components
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/azizuysal/simtool/internal/config"
 	"github.com/azizuysal/simtool/internal/simulator"
 )
 
@@ -52,6 +54,11 @@ func TestDatabaseTableContentGetFooter(t *testing.T) {
 			t.Errorf("GetFooter() = %q, missing %q", got, sub)
 		}
 	}
+	keys := config.DefaultKeys()
+	dtc.Update(table, data, nil, 0, 0, &keys)
+	if got := dtc.GetFooter(); !strings.Contains(got, "E: export CSV") {
+		t.Errorf("GetFooter() = %q, missing the CSV export", got)
+	}
 }
 
 func TestDatabaseTableContentRender(t *testing.T) {
@@ -123,6 +130,116 @@ func TestDatabaseTableContentRender(t *testing.T) {
 	}
 }
 
+func TestDatabaseTableContentHScroll(t *testing.T) {
+	// Ten 15-character columns can't fit in 80 columns
+	table := &simulator.TableInfo{Name: "wide", RowCount: 1}
+	row := map[string]any{}
+	for i := range 10 {
+		name := fmt.Sprintf("column_%02d_xxxxx", i+1)
+		table.Columns = append(table.Columns, simulator.ColumnInfo{Name: name})
+		row[name] = "v"
+	}
+	data := []map[string]any{row}
+	keys := config.DefaultKeys()
+
+	dtc := NewDatabaseTableContent(80, 24)
+	dtc.Update(table, data, nil, 0, 0, &keys)
+	if got := dtc.Render(); !strings.Contains(got, "column_01") || strings.Contains(got, "column_10") {
+		t.Errorf("Render() at hScroll 0 should start with the first column:\n%s", got)
+	}
+	footer := dtc.GetFooter()
+	if !strings.Contains(footer, "columns 1-") || !strings.Contains(footer, "▶") || strings.Contains(footer, "◀") {
+		t.Errorf("GetFooter() = %q, want a right-only column indicator", footer)
+	}
+	if !strings.Contains(footer, "←/h: back") {
+		t.Errorf("GetFooter() = %q, want left to go back", footer)
+	}
+
+	dtc.SetHScroll(9)
+	if got := dtc.Render(); strings.Contains(got, "column_01") || !strings.Contains(got, "column_10") {
+		t.Errorf("Render() at hScroll 9 should show only the last column:\n%s", got)
+	}
+	footer = dtc.GetFooter()
+	if !strings.Contains(footer, "◀ columns 10-10") || strings.Contains(footer, "▶") {
+		t.Errorf("GetFooter() = %q, want a left-only column indicator", footer)
+	}
+	if !strings.Contains(footer, "scroll left") {
+		t.Errorf("GetFooter() = %q, want left to scroll", footer)
+	}
+}
+
+func TestDatabaseTableContentHiddenColumns(t *testing.T) {
+	table := &simulator.TableInfo{Name: "wide", RowCount: 1}
+	row := map[string]any{}
+	for i := range 10 {
+		name := fmt.Sprintf("column_%02d_xxxxx", i+1)
+		table.Columns = append(table.Columns, simulator.ColumnInfo{Name: name})
+		row[name] = "v"
+	}
+	keys := config.DefaultKeys()
+
+	dtc := NewDatabaseTableContent(80, 24)
+	dtc.Update(table, []map[string]any{row}, nil, 0, 0, &keys)
+	hidden := map[string]bool{}
+	for _, col := range table.Columns[2:] {
+		hidden[col.Name] = true
+	}
+	dtc.SetHiddenColumns(hidden)
+
+	got := dtc.Render()
+	if !strings.Contains(got, "column_01") || !strings.Contains(got, "column_02") || strings.Contains(got, "column_03") {
+		t.Errorf("Render() should show only the shown columns:\n%s", got)
+	}
+	if !strings.Contains(got, "(8 hidden)") {
+		t.Errorf("Render() should count the hidden columns:\n%s", got)
+	}
+	// Both shown columns fit, so there's nothing to scroll to
+	if footer := dtc.GetFooter(); strings.Contains(footer, "▶") || strings.Contains(footer, "columns 1-") {
+		t.Errorf("GetFooter() = %q, want no column indicator", footer)
+	}
+}
+
+func TestDatabaseTableContentSortIndicator(t *testing.T) {
+	table := &simulator.TableInfo{Name: "users", RowCount: 1, Columns: []simulator.ColumnInfo{{Name: "id", PK: true}, {Name: "name"}}}
+	dtc := NewDatabaseTableContent(80, 24)
+	dtc.Update(table, []map[string]any{{"id": 1, "name": "Ann"}}, nil, 0, 0, nil)
+
+	dtc.SetSort("name", false)
+	if got := dtc.Render(); !strings.Contains(got, "name ↑") || strings.Contains(got, "id* ↑") {
+		t.Errorf("Render() should mark only the sort column ascending:\n%s", got)
+	}
+	dtc.SetSort("id", true)
+	if got := dtc.Render(); !strings.Contains(got, "id* ↓") {
+		t.Errorf("Render() should mark the sort column descending:\n%s", got)
+	}
+}
+
+func TestDatabaseTableContentSearch(t *testing.T) {
+	table := &simulator.TableInfo{Name: "users", RowCount: 500, Columns: []simulator.ColumnInfo{{Name: "name"}}}
+	keys := config.DefaultKeys()
+	dtc := NewDatabaseTableContent(80, 24)
+	dtc.Update(table, []map[string]any{{"name": "Ann"}, {"name": "Anna"}}, nil, 0, 0, &keys)
+
+	dtc.SetSearch(true, "", 0)
+	if got := dtc.GetStatus(); !strings.Contains(got, "type to filter rows") {
+		t.Errorf("GetStatus() with an empty query = %q", got)
+	}
+
+	dtc.SetSearch(false, "ann", 120)
+	if got := dtc.GetFooter(); !strings.Contains(got, "first 2 of 120 matches") || !strings.Contains(got, "clear search") {
+		t.Errorf("GetFooter() = %q, want the match count and a clear hint", got)
+	}
+	if got := dtc.GetStatus(); got != "" {
+		t.Errorf("GetStatus() with the query bar closed = %q, want empty", got)
+	}
+
+	dtc.Update(table, nil, nil, 0, 0, &keys)
+	dtc.SetSearch(false, "zed", 0)
+	if got := dtc.Render(); !strings.Contains(got, "No rows match your search") {
+		t.Errorf("Render() without matches:\n%s", got)
+	}
+}
+
 func TestSanitizeForDisplay(t *testing.T) {
 	tests := []struct {
 		name string
@@ -155,3 +272,27 @@ func TestSanitizeForDisplay(t *testing.T) {
 		})
 	}
 }
+
+func TestDatabaseTableContentLargeTableWarning(t *testing.T) {
+	table := &simulator.TableInfo{Name: "events", RowCount: 1_234_567, Columns: []simulator.ColumnInfo{{Name: "id"}}}
+	data := make([]map[string]any, simulator.MaxDisplayRows)
+	for i := range data {
+		data[i] = map[string]any{"id": i}
+	}
+
+	dtc := NewDatabaseTableContent(80, 24)
+	dtc.Update(table, data, nil, 0, 0, nil)
+	if got := dtc.Render(); !strings.Contains(got, "⚠ Large table: showing first 50 of 1.2M rows") {
+		t.Errorf("Render() missing the large table warning:\n%s", got)
+	}
+
+	dtc.Update(table, data, nil, 0, 50, nil)
+	if got := dtc.Render(); !strings.Contains(got, "showing rows 51-100 of 1.2M") {
+		t.Errorf("Render() missing the row range of a later page:\n%s", got)
+	}
+
+	dtc.Update(&simulator.TableInfo{Name: "small", RowCount: 50, Columns: table.Columns}, data, nil, 0, 0, nil)
+	if got := dtc.Render(); strings.Contains(got, "Large table") {
+		t.Errorf("Render() warned about a small table:\n%s", got)
+	}
+}
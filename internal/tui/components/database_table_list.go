@@ -117,6 +117,12 @@ func (dtl *DatabaseTableList) GetFooter() string {
 		}
 	}
 
+	if query := dtl.Keys.FormatKeyAction("query_database", "query"); query != "" {
+		parts = append(parts, query)
+	}
+	if vacuum := dtl.Keys.FormatKeyAction("vacuum_database", "vacuum"); vacuum != "" {
+		parts = append(parts, vacuum)
+	}
 	if left := dtl.Keys.FormatKeyAction("left", "back"); left != "" {
 		parts = append(parts, left)
 	}
@@ -169,6 +175,18 @@ func (dtl *DatabaseTableList) buildHeader() string {
 			simulator.FormatSize(dtl.DatabaseInfo.FileSize))
 	}
 	s.WriteString(ui.DetailStyle().Render(dbDetails))
+	if dtl.DatabaseInfo.Error != "" {
+		s.WriteString("\n")
+		s.WriteString(ui.ErrorStyle().Render("Error: " + dtl.DatabaseInfo.Error))
+	}
+	if dtl.DatabaseInfo.CoreDataMigrationPending {
+		warning := "⚠ Migration pending"
+		if dtl.DatabaseInfo.CoreDataVersion > 0 {
+			warning += fmt.Sprintf(": store is at model version %d", dtl.DatabaseInfo.CoreDataVersion)
+		}
+		s.WriteString("\n")
+		s.WriteString(ui.WarningStyle().Render(warning))
+	}
 
 	return s.String()
 }
@@ -193,7 +211,10 @@ func (dtl *DatabaseTableList) renderWithHeader(header string, startIdx, endIdx i
 			}
 
 			// Format table name (no icon)
-			tableName := table.Name
+			tableName := table.DisplayName()
+			if table.IsLarge() {
+				tableName += " [LARGE]"
+			}
 
 			// Format column details (no row count)
 			var colNames []string
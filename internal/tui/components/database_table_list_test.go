@@ -118,6 +118,50 @@ func TestDatabaseTableListRender(t *testing.T) {
 			cursor:  0,
 			wantSub: []string{"app.db", "SQLite", "users", "posts", "▶", "Columns"},
 		},
+		{
+			name: "Core Data entity names",
+			info: &simulator.DatabaseInfo{
+				Format:     "SQLite",
+				TableCount: 2,
+				Tables: []simulator.TableInfo{
+					{Name: "ZCONTACT", CoreDataEntityName: "Contact"},
+					{Name: "Z_PRIMARYKEY"},
+				},
+			},
+			wantSub: []string{"Contact (ZCONTACT)", "Z_PRIMARYKEY"},
+		},
+		{
+			name: "large table",
+			info: &simulator.DatabaseInfo{
+				Format:     "SQLite",
+				TableCount: 2,
+				Tables: []simulator.TableInfo{
+					{Name: "events", RowCount: 2_000_000},
+					{Name: "users", RowCount: 10},
+				},
+			},
+			wantSub:  []string{"events [LARGE]"},
+			dontWant: []string{"users [LARGE]"},
+		},
+		{
+			name:    "database error",
+			info:    &simulator.DatabaseInfo{Error: "Database is locked — close the app on the simulator first"},
+			wantSub: []string{"Error: Database is locked", "No tables found"},
+		},
+		{
+			name: "Core Data migration pending",
+			info: &simulator.DatabaseInfo{
+				Format:                   "SQLite",
+				CoreDataVersion:          1,
+				CoreDataMigrationPending: true,
+			},
+			wantSub: []string{"⚠ Migration pending: store is at model version 1"},
+		},
+		{
+			name:     "Core Data store up to date",
+			info:     &simulator.DatabaseInfo{Format: "SQLite", CoreDataVersion: 2},
+			dontWant: []string{"Migration pending"},
+		},
 	}
 
 	for _, tt := range tests {
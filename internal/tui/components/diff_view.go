@@ -0,0 +1,154 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/simulator"
+	"github.com/azizuysal/simtool/internal/ui"
+)
+
+// DiffViewHeaderLines is the number of lines DiffView spends on its info
+// header and separator above the diff lines.
+const DiffViewHeaderLines = 4
+
+// DiffView renders the line diff between two files
+type DiffView struct {
+	Width    int
+	Height   int
+	OldName  string
+	NewName  string
+	Lines    []simulator.DiffLine
+	Viewport int
+	Keys     *config.KeysConfig
+}
+
+// NewDiffView creates a new diff view renderer
+func NewDiffView(width, height int) *DiffView {
+	return &DiffView{
+		Width:  width,
+		Height: height,
+	}
+}
+
+// Update updates the diff data
+func (dv *DiffView) Update(oldName, newName string, lines []simulator.DiffLine, viewport int, keys *config.KeysConfig) {
+	dv.OldName = oldName
+	dv.NewName = newName
+	dv.Lines = lines
+	dv.Viewport = viewport
+	dv.Keys = keys
+}
+
+// VisibleLines returns how many diff lines fit below the header
+func (dv *DiffView) VisibleLines() int {
+	return max(dv.Height-DiffViewHeaderLines, 1)
+}
+
+// Render renders the visible window of diff lines: additions in green,
+// deletions in red and unchanged lines in the normal color
+func (dv *DiffView) Render() string {
+	var s strings.Builder
+	innerWidth := dv.Width - 4 // Account for content box padding
+
+	added, removed := 0, 0
+	for _, line := range dv.Lines {
+		switch line.Op {
+		case simulator.DiffAdded:
+			added++
+		case simulator.DiffRemoved:
+			removed++
+		}
+	}
+	s.WriteString(ui.DetailStyle().Render(fmt.Sprintf("%d added • %d removed", added, removed)))
+	s.WriteString("\n")
+	s.WriteString(ui.DetailStyle().Render(strings.Repeat("─", innerWidth)))
+	s.WriteString("\n\n")
+
+	if len(dv.Lines) == 0 {
+		s.WriteString(ui.DetailStyle().Render("Both files are empty"))
+		return s.String()
+	}
+	if added == 0 && removed == 0 {
+		s.WriteString(ui.DetailStyle().Render("The files are identical"))
+		return s.String()
+	}
+
+	start := dv.Viewport
+	end := min(start+dv.VisibleLines(), len(dv.Lines))
+	for i := start; i < end; i++ {
+		if i > start {
+			s.WriteString("\n")
+		}
+		line := dv.Lines[i]
+		text := diffMarker(line.Op) + " " + strings.ReplaceAll(line.Text, "\t", "    ")
+		if innerWidth > 3 && lipgloss.Width(text) > innerWidth {
+			runes := []rune(text)
+			if len(runes) > innerWidth-3 {
+				text = string(runes[:innerWidth-3]) + "..."
+			}
+		}
+		s.WriteString(diffLineStyle(line.Op).Render(text))
+	}
+
+	return s.String()
+}
+
+// diffMarker returns the unified diff prefix for a line of the given op
+func diffMarker(op simulator.DiffOp) string {
+	switch op {
+	case simulator.DiffAdded:
+		return "+"
+	case simulator.DiffRemoved:
+		return "-"
+	default:
+		return " "
+	}
+}
+
+// diffLineStyle returns the style used for a line of the given op
+func diffLineStyle(op simulator.DiffOp) lipgloss.Style {
+	switch op {
+	case simulator.DiffAdded:
+		return ui.SuccessStyle()
+	case simulator.DiffRemoved:
+		return ui.ErrorStyle()
+	default:
+		return ui.NormalStyle()
+	}
+}
+
+// GetTitle returns the title for the diff view
+func (dv *DiffView) GetTitle() string {
+	return fmt.Sprintf("Diff: %s → %s", dv.OldName, dv.NewName)
+}
+
+// GetFooter returns the footer for the diff view
+func (dv *DiffView) GetFooter() string {
+	if dv.Keys == nil {
+		return "↑/k: up • ↓/j: down • ←/h: back • q: quit"
+	}
+
+	var parts []string
+	if up := dv.Keys.FormatKeyAction("up", "up"); up != "" {
+		parts = append(parts, up)
+	}
+	if down := dv.Keys.FormatKeyAction("down", "down"); down != "" {
+		parts = append(parts, down)
+	}
+	if left := dv.Keys.FormatKeyAction("left", "back"); left != "" {
+		parts = append(parts, left)
+	}
+	if quit := dv.Keys.FormatKeyAction("quit", "quit"); quit != "" {
+		parts = append(parts, quit)
+	}
+
+	footer := strings.Join(parts, " • ")
+	if len(dv.Lines) > 0 {
+		footer += ui.FormatScrollInfo(dv.Viewport, dv.VisibleLines(), len(dv.Lines))
+	}
+	return footer
+}
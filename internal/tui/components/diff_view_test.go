@@ -0,0 +1,46 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestDiffViewRender(t *testing.T) {
+	dv := NewDiffView(80, 20)
+	lines := []simulator.DiffLine{
+		{Op: simulator.DiffContext, Text: "same"},
+		{Op: simulator.DiffRemoved, Text: "before"},
+		{Op: simulator.DiffAdded, Text: "after"},
+	}
+	dv.Update("a.json", "b.json", lines, 0, nil)
+
+	got := dv.Render()
+	for _, want := range []string{"1 added • 1 removed", "  same", "- before", "+ after"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() missing %q:\n%s", want, got)
+		}
+	}
+	if title := dv.GetTitle(); title != "Diff: a.json → b.json" {
+		t.Errorf("GetTitle() = %q", title)
+	}
+
+	dv.Update("a.json", "b.json", lines[:1], 0, nil)
+	if got := dv.Render(); !strings.Contains(got, "The files are identical") {
+		t.Errorf("Render() for identical files = %q", got)
+	}
+}
+
+func TestDiffViewGetFooter(t *testing.T) {
+	keys := config.DefaultKeys()
+	dv := NewDiffView(80, 20)
+	dv.Update("a", "b", make([]simulator.DiffLine, 50), 0, &keys)
+	footer := dv.GetFooter()
+	for _, want := range []string{"up", "down", "back", "quit"} {
+		if !strings.Contains(footer, want) {
+			t.Errorf("GetFooter() = %q, missing %q", footer, want)
+		}
+	}
+}
@@ -19,6 +19,20 @@ type FileList struct {
 	App         *simulator.App
 	Breadcrumbs []string
 	Keys        *config.KeysConfig
+
+	// NerdFontIcons prefixes names with a Nerd Font file type icon
+	NerdFontIcons bool
+
+	// DateFormat is how created and modified dates are shown
+	DateFormat simulator.DateFormat
+
+	// MinSize is the size filter's threshold in bytes; 0 when it's off
+	MinSize int64
+
+	// Tree mode: one line per node instead of the two-line list
+	TreeView     bool
+	TreeNodes    []simulator.TreeNode // Visible nodes in display order
+	TreeExpanded map[string]bool
 }
 
 // NewFileList creates a new file list renderer
@@ -39,11 +53,56 @@ func (fl *FileList) Update(files []simulator.FileInfo, cursor, viewport int, app
 	fl.Keys = keys
 }
 
+// UpdateTree switches the list into tree mode with the given visible
+// nodes. Cursor and viewport index into nodes.
+func (fl *FileList) UpdateTree(nodes []simulator.TreeNode, expanded map[string]bool, cursor, viewport int) {
+	fl.TreeView = true
+	fl.TreeNodes = nodes
+	fl.TreeExpanded = expanded
+	fl.Cursor = cursor
+	fl.Viewport = viewport
+}
+
+// SetNerdFontIcons turns the file type icons on or off
+func (fl *FileList) SetNerdFontIcons(enabled bool) {
+	fl.NerdFontIcons = enabled
+}
+
+// SetDateFormat sets how dates are shown
+func (fl *FileList) SetDateFormat(format simulator.DateFormat) {
+	fl.DateFormat = format
+}
+
+// SetMinSizeFilter sets the size below which files are hidden, for the
+// footer to show
+func (fl *FileList) SetMinSizeFilter(minSize int64) {
+	fl.MinSize = minSize
+}
+
+// iconPrefix returns the icon and a space to put before a file name, or
+// "" without icons
+func (fl *FileList) iconPrefix(name string, isDir bool) string {
+	if !fl.NerdFontIcons {
+		return ""
+	}
+	return ui.FileIcon(name, isDir) + " "
+}
+
+// TreeRowsPerScreen returns how many tree lines fit below the header
+func (fl *FileList) TreeRowsPerScreen() int {
+	headerLines := strings.Count(fl.buildHeader(), "\n") + 4 // header + separator + padding
+	return max(fl.Height-headerLines-2, 1)                   // Border takes 2 lines
+}
+
 // Render renders the file list content
 func (fl *FileList) Render() string {
 	// Build header content
 	header := fl.buildHeader()
 
+	if fl.TreeView {
+		return renderHeaderPrefix(header, fl.Width-4) + fl.renderTree()
+	}
+
 	// Calculate available space for file list
 	headerLines := strings.Count(header, "\n") + 4 // header + separator + padding
 	availableHeight := fl.Height - headerLines
@@ -79,6 +138,9 @@ func (fl *FileList) GetTitle() string {
 
 // GetFooter returns the footer for the file list
 func (fl *FileList) GetFooter() string {
+	if fl.TreeView {
+		return fl.getTreeFooter()
+	}
 	if fl.Keys == nil {
 		// Fallback to default if keys not set
 		footer := "↑/k: up • ↓/j: down"
@@ -90,7 +152,10 @@ func (fl *FileList) GetFooter() string {
 			}
 			footer += " • space: open in Finder"
 		}
-		footer += " • ←/h: back • q: quit"
+		footer += " • t: tree • ←/h: back • q: quit"
+		if fl.MinSize > 0 {
+			footer += fmt.Sprintf(" • size ≥ %s (0: all sizes)", simulator.FormatSize(fl.MinSize))
+		}
 
 		// Add scroll info
 		// Calculate actual header lines for this specific render
@@ -124,18 +189,37 @@ func (fl *FileList) GetFooter() string {
 			if right := fl.Keys.FormatKeyAction("right", "view file"); right != "" {
 				parts = append(parts, right)
 			}
+			if diff := fl.Keys.FormatKeyAction("diff", "diff"); diff != "" {
+				parts = append(parts, diff)
+			}
 		}
 		if open := fl.Keys.FormatKeyAction("open", "open in Finder"); open != "" {
 			parts = append(parts, open)
 		}
+		if cp := fl.Keys.FormatKeyAction("copy", "copy path"); cp != "" {
+			parts = append(parts, cp)
+		}
+		if info := fl.Keys.FormatKeyAction("show_metadata", "info"); info != "" {
+			parts = append(parts, info)
+		}
 	}
 
+	if tree := fl.Keys.FormatKeyAction("toggle_tree", "tree"); tree != "" {
+		parts = append(parts, tree)
+	}
 	if left := fl.Keys.FormatKeyAction("left", "back"); left != "" {
 		parts = append(parts, left)
 	}
 	if quit := fl.Keys.FormatKeyAction("quit", "quit"); quit != "" {
 		parts = append(parts, quit)
 	}
+	if fl.MinSize > 0 {
+		filter := "size ≥ " + simulator.FormatSize(fl.MinSize)
+		if reset := fl.Keys.FormatKeyAction("reset_size_filter", "all sizes"); reset != "" {
+			filter += " (" + reset + ")"
+		}
+		parts = append(parts, filter)
+	}
 
 	footer := strings.Join(parts, " • ")
 
@@ -164,9 +248,9 @@ func (fl *FileList) buildHeader() string {
 	s.WriteString(ui.NameStyle().Render(fl.App.Name))
 	s.WriteString("\n")
 
-	appDetails := fmt.Sprintf("%s • v%s • %s", fl.App.BundleID, fl.App.Version, simulator.FormatSize(fl.App.Size))
+	appDetails := fmt.Sprintf("%s • v%s • %s", fl.App.BundleID, fl.App.Version, fl.App.SizeText())
 	if fl.App.Version == "" {
-		appDetails = fmt.Sprintf("%s • %s", fl.App.BundleID, simulator.FormatSize(fl.App.Size))
+		appDetails = fmt.Sprintf("%s • %s", fl.App.BundleID, fl.App.SizeText())
 	}
 	s.WriteString(ui.DetailStyle().Render(appDetails))
 
@@ -191,7 +275,9 @@ func (fl *FileList) renderWithHeader(header string, startIdx, endIdx int, availa
 	linesUsed := 0
 
 	// Render file list
-	if len(fl.Files) == 0 {
+	if len(fl.Files) == 0 && fl.MinSize > 0 {
+		s.WriteString(ui.DetailStyle().Render(fmt.Sprintf("No files of %s or more in folder", simulator.FormatSize(fl.MinSize))))
+	} else if len(fl.Files) == 0 {
 		s.WriteString(ui.DetailStyle().Render("No files in folder"))
 	} else {
 		for i := startIdx; i < endIdx && linesUsed < availableHeight; i++ {
@@ -203,19 +289,32 @@ func (fl *FileList) renderWithHeader(header string, startIdx, endIdx int, availa
 				linesUsed++ // Empty line between items
 			}
 
-			// Format file name with directory indicator
-			fileName := file.Name
+			// Format file name with icon and directory indicator
+			fileName := fl.iconPrefix(file.Name, file.IsDirectory) + file.Name
 			if file.IsDirectory {
 				fileName += "/"
 			}
 
 			// Format file details
 			sizeText := simulator.FormatSize(file.Size)
-			createdText := simulator.FormatFileDate(file.CreatedAt)
-			modifiedText := simulator.FormatFileDate(file.ModifiedAt)
+			createdText := simulator.FormatFileDate(file.CreatedAt, fl.DateFormat)
+			modifiedText := simulator.FormatFileDate(file.ModifiedAt, fl.DateFormat)
 			detailText := fmt.Sprintf("%s • Created %s • Modified %s", sizeText, createdText, modifiedText)
+			if file.IsBinaryPlist {
+				detailText = "[BPLIST] " + detailText
+			}
 
 			if i == fl.Cursor {
+				// Well-known container directories are explained at the root
+				if file.IsDirectory && len(fl.Breadcrumbs) == 0 {
+					if annotation := simulator.ContainerDirAnnotation(file.Name); annotation != "" {
+						detailText = fmt.Sprintf("%s • %s", detailText, annotation)
+					}
+				}
+				if file.IsBinaryPlist {
+					detailText += " • Shown as XML"
+				}
+
 				// Selected item
 				line1 := fmt.Sprintf("▶ %s", fileName)
 				line2 := fmt.Sprintf("  %s", detailText)
@@ -243,3 +342,83 @@ func (fl *FileList) renderWithHeader(header string, startIdx, endIdx int, availa
 
 	return s.String()
 }
+
+// renderTree renders the visible tree nodes, one per line, indented by
+// depth with an expand/collapse marker on directories
+func (fl *FileList) renderTree() string {
+	if len(fl.TreeNodes) == 0 {
+		return ui.DetailStyle().Render("No files in folder")
+	}
+
+	var s strings.Builder
+	innerWidth := fl.Width - 4 // Account for padding
+
+	startIdx := fl.Viewport
+	endIdx := min(startIdx+fl.TreeRowsPerScreen(), len(fl.TreeNodes))
+	for i := startIdx; i < endIdx; i++ {
+		node := fl.TreeNodes[i]
+		if i > startIdx {
+			s.WriteString("\n")
+		}
+
+		marker := "  "
+		name := fl.iconPrefix(node.Name, node.IsDirectory) + node.Name
+		if node.IsDirectory {
+			marker = "▸ "
+			if fl.TreeExpanded[node.Path] {
+				marker = "▾ "
+			}
+			name += "/"
+		}
+		line := strings.Repeat("  ", node.Depth) + marker + name
+		size := simulator.FormatSize(node.Size)
+
+		if i == fl.Cursor {
+			s.WriteString(ui.SelectedStyle().Render(ui.PadLine(line+"  "+size, innerWidth)))
+			continue
+		}
+		if node.IsDirectory {
+			s.WriteString(ui.FolderStyle().Render(line))
+		} else {
+			s.WriteString(ui.NameStyle().Render(line))
+		}
+		s.WriteString(ui.DetailStyle().Render("  " + size))
+	}
+
+	return s.String()
+}
+
+// getTreeFooter returns the footer for tree mode
+func (fl *FileList) getTreeFooter() string {
+	scrollInfo := ui.FormatScrollInfo(fl.Viewport, fl.TreeRowsPerScreen(), len(fl.TreeNodes))
+	if fl.Keys == nil {
+		return "↑/k: up • ↓/j: down • →/l: expand/view • ←/h: collapse • t: list • q: quit" + scrollInfo
+	}
+
+	var parts []string
+	if up := fl.Keys.FormatKeyAction("up", "up"); up != "" {
+		parts = append(parts, up)
+	}
+	if down := fl.Keys.FormatKeyAction("down", "down"); down != "" {
+		parts = append(parts, down)
+	}
+	if right := fl.Keys.FormatKeyAction("right", "expand/view"); right != "" {
+		parts = append(parts, right)
+	}
+	if left := fl.Keys.FormatKeyAction("left", "collapse"); left != "" {
+		parts = append(parts, left)
+	}
+	if open := fl.Keys.FormatKeyAction("open", "open in Finder"); open != "" {
+		parts = append(parts, open)
+	}
+	if cp := fl.Keys.FormatKeyAction("copy", "copy path"); cp != "" {
+		parts = append(parts, cp)
+	}
+	if tree := fl.Keys.FormatKeyAction("toggle_tree", "list"); tree != "" {
+		parts = append(parts, tree)
+	}
+	if quit := fl.Keys.FormatKeyAction("quit", "quit"); quit != "" {
+		parts = append(parts, quit)
+	}
+	return strings.Join(parts, " • ") + scrollInfo
+}
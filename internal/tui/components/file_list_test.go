@@ -5,7 +5,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/azizuysal/simtool/internal/config"
 	"github.com/azizuysal/simtool/internal/simulator"
+	"github.com/azizuysal/simtool/internal/ui"
 )
 
 func TestNewFileList(t *testing.T) {
@@ -123,6 +125,25 @@ func TestFileListRender(t *testing.T) {
 			breadcrumbs: []string{"Documents", "Inner"},
 			wantSub:     []string{"Documents/Inner/", "sub"},
 		},
+		{
+			name: "container directory annotated when selected",
+			files: []simulator.FileInfo{
+				{Name: "SystemData", IsDirectory: true, CreatedAt: now, ModifiedAt: now},
+				{Name: "tmp", IsDirectory: true, CreatedAt: now, ModifiedAt: now},
+			},
+			cursor:   0,
+			wantSub:  []string{"System-managed"},
+			dontWant: []string{"Temporary files"},
+		},
+		{
+			name: "no annotation below the container root",
+			files: []simulator.FileInfo{
+				{Name: "SystemData", IsDirectory: true, CreatedAt: now, ModifiedAt: now},
+			},
+			cursor:      0,
+			breadcrumbs: []string{"Library"},
+			dontWant:    []string{"System-managed"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -158,3 +179,77 @@ func TestFileListRenderWithoutApp(t *testing.T) {
 		t.Errorf("Render() unexpectedly contains separator rule without a header")
 	}
 }
+
+func TestFileListNerdFontIcons(t *testing.T) {
+	files := []simulator.FileInfo{
+		{Name: "Documents", IsDirectory: true},
+		{Name: "store.sqlite"},
+	}
+	fl := NewFileList(80, 24)
+	fl.Update(files, 0, 0, nil, nil, nil)
+	folder, database := ui.FileIcon("Documents", true), ui.FileIcon("store.sqlite", false)
+
+	if got := fl.Render(); strings.Contains(got, folder) || strings.Contains(got, database) {
+		t.Errorf("Render() without icons enabled shows icons:\n%s", got)
+	}
+
+	fl.SetNerdFontIcons(true)
+	got := fl.Render()
+	if !strings.Contains(got, folder+" Documents/") || !strings.Contains(got, database+" store.sqlite") {
+		t.Errorf("Render() missing file type icons:\n%s", got)
+	}
+	// Each file still takes two lines plus the blank line between them
+	if lines := strings.Count(got, "\n"); lines != 4 {
+		t.Errorf("Render() has %d line breaks, want 4:\n%s", lines, got)
+	}
+}
+
+func TestFileListDateFormat(t *testing.T) {
+	date := time.Date(2024, 3, 9, 8, 30, 0, 0, time.UTC)
+	files := []simulator.FileInfo{{Name: "notes.txt", CreatedAt: date, ModifiedAt: date}}
+	fl := NewFileList(120, 24)
+	fl.Update(files, 0, 0, nil, nil, nil)
+
+	if got := fl.Render(); !strings.Contains(got, "Mar 9, 2024") {
+		t.Errorf("Render() with the default format missing the relative date:\n%s", got)
+	}
+	fl.SetDateFormat(simulator.DateFormatISO)
+	if got := fl.Render(); !strings.Contains(got, "2024-03-09T08:30:00Z") {
+		t.Errorf("Render() with the ISO format missing the ISO date:\n%s", got)
+	}
+}
+
+func TestFileListSizeFilter(t *testing.T) {
+	keys := config.DefaultKeys()
+	fl := NewFileList(80, 24)
+	fl.Update(nil, 0, 0, nil, nil, &keys)
+	if got := fl.GetFooter(); strings.Contains(got, "size ≥") {
+		t.Errorf("GetFooter() without a filter = %q", got)
+	}
+
+	fl.SetMinSizeFilter(4096)
+	if got := fl.GetFooter(); !strings.Contains(got, "size ≥ 4.0 KB (0: all sizes)") {
+		t.Errorf("GetFooter() = %q, want the size threshold", got)
+	}
+	if got := fl.Render(); !strings.Contains(got, "No files of 4.0 KB or more") {
+		t.Errorf("Render() with every file filtered = %q", got)
+	}
+}
+
+func TestFileListBinaryPlist(t *testing.T) {
+	files := []simulator.FileInfo{
+		{Name: "Info.plist", Size: 512, IsBinaryPlist: true},
+		{Name: "Settings.plist", Size: 512},
+		{Name: "Prefs.plist", Size: 512, IsBinaryPlist: true},
+	}
+	fl := NewFileList(120, 24)
+	fl.Update(files, 0, 0, nil, nil, nil)
+
+	got := fl.Render()
+	if n := strings.Count(got, "[BPLIST]"); n != 2 {
+		t.Errorf("Render() shows [BPLIST] %d times, want 2:\n%s", n, got)
+	}
+	if n := strings.Count(got, "Shown as XML"); n != 1 {
+		t.Errorf("Render() should explain the conversion on the selected row only:\n%s", got)
+	}
+}
@@ -0,0 +1,181 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/simulator"
+	"github.com/azizuysal/simtool/internal/ui"
+)
+
+// FileSearchList renders the lines of a file that match a search, with
+// the query bar in the status line
+type FileSearchList struct {
+	Width    int
+	Height   int
+	File     *simulator.FileInfo
+	Query    string
+	Matches  []simulator.SearchMatch
+	Cursor   int
+	Viewport int
+	Loading  bool
+	Keys     *config.KeysConfig
+}
+
+// NewFileSearchList creates a new file search list renderer
+func NewFileSearchList(width, height int) *FileSearchList {
+	return &FileSearchList{
+		Width:  width,
+		Height: height,
+	}
+}
+
+// Update updates the list data
+func (fs *FileSearchList) Update(file *simulator.FileInfo, query string, matches []simulator.SearchMatch, cursor, viewport int, loading bool, keys *config.KeysConfig) {
+	fs.File = file
+	fs.Query = query
+	fs.Matches = matches
+	fs.Cursor = cursor
+	fs.Viewport = viewport
+	fs.Loading = loading
+	fs.Keys = keys
+}
+
+// RowsPerScreen returns how many matches fit in the list, one per line
+func (fs *FileSearchList) RowsPerScreen() int {
+	return max(fs.Height-2, 1) // Border takes 2 lines
+}
+
+// Render renders the visible matches, line number first, with the query
+// highlighted in each
+func (fs *FileSearchList) Render() string {
+	switch {
+	case fs.Query == "":
+		return ui.DetailStyle().Render("Type to search the file")
+	case len(fs.Matches) == 0 && fs.Loading:
+		return ui.DetailStyle().Render("Searching...")
+	case len(fs.Matches) == 0:
+		return ui.DetailStyle().Render("No lines match your search")
+	}
+
+	innerWidth := fs.Width - 4 // Account for padding
+	numWidth := len(fmt.Sprint(fs.Matches[len(fs.Matches)-1].LineNum))
+
+	var s strings.Builder
+	start := fs.Viewport
+	end := min(start+fs.RowsPerScreen(), len(fs.Matches))
+	for i := start; i < end; i++ {
+		match := fs.Matches[i]
+		base := ui.NormalStyle()
+		marker := "  "
+		if i == fs.Cursor {
+			base = ui.SelectedStyle()
+			marker = "▶ "
+		}
+		prefix := fmt.Sprintf("%s%*d  ", marker, numWidth, match.LineNum)
+		snippet := fitSnippet(match.Snippet, fs.Query, innerWidth-lipgloss.Width(prefix))
+		line := base.Render(prefix) + highlightQuery(snippet, fs.Query, base)
+		if pad := innerWidth - lipgloss.Width(line); pad > 0 && i == fs.Cursor {
+			line += base.Render(strings.Repeat(" ", pad))
+		}
+		s.WriteString(line)
+		if i < end-1 {
+			s.WriteString("\n")
+		}
+	}
+	return s.String()
+}
+
+// fitSnippet shortens snippet to width, centering the window on the
+// first match of query when it would be cut off
+func fitSnippet(snippet, query string, width int) string {
+	runes := []rune(snippet)
+	if width <= 6 || len(runes) <= width {
+		return snippet
+	}
+	if idx := strings.Index(strings.ToLower(snippet), strings.ToLower(query)); idx >= 0 {
+		matchStart := len([]rune(snippet[:idx]))
+		queryLen := len([]rune(query))
+		if matchStart+queryLen > width-3 {
+			start := matchStart - (width-6-queryLen)/2
+			start = max(min(start, len(runes)-(width-3)), 0)
+			runes = append([]rune("..."), runes[start:]...)
+		}
+	}
+	if len(runes) > width {
+		runes = append(runes[:width-3], []rune("...")...)
+	}
+	return string(runes)
+}
+
+// highlightQuery renders text in base style, with each case-insensitive
+// occurrence of query in the search style
+func highlightQuery(text, query string, base lipgloss.Style) string {
+	lower, lowerQuery := strings.ToLower(text), strings.ToLower(query)
+	if query == "" || len(lower) != len(text) {
+		// Lowercasing changed byte offsets; leave it unhighlighted
+		return base.Render(text)
+	}
+	var s strings.Builder
+	for {
+		idx := strings.Index(lower, lowerQuery)
+		if idx < 0 {
+			break
+		}
+		if idx > 0 {
+			s.WriteString(base.Render(text[:idx]))
+		}
+		s.WriteString(ui.SearchStyle().Render(text[idx : idx+len(query)]))
+		text, lower = text[idx+len(query):], lower[idx+len(query):]
+	}
+	if text != "" {
+		s.WriteString(base.Render(text))
+	}
+	return s.String()
+}
+
+// GetTitle returns the title for the search results
+func (fs *FileSearchList) GetTitle() string {
+	name := "file"
+	if fs.File != nil {
+		name = fs.File.Name
+	}
+	if fs.Query == "" {
+		return "Search " + name
+	}
+	count := fmt.Sprintf("%d", len(fs.Matches))
+	if len(fs.Matches) >= simulator.MaxSearchMatches {
+		count += "+"
+	}
+	return fmt.Sprintf("Search %s (%s matches)", name, count)
+}
+
+// GetFooter returns the footer for the search results. Letter keys go
+// to the query, so only the arrow keys move the cursor.
+func (fs *FileSearchList) GetFooter() string {
+	scrollInfo := ui.FormatScrollInfo(fs.Viewport, fs.RowsPerScreen(), len(fs.Matches))
+
+	if fs.Keys == nil {
+		return "↑/↓: navigate • Enter: go to line • ESC: back" + scrollInfo
+	}
+
+	parts := []string{"↑/↓: navigate"}
+	if enter := fs.Keys.FormatKeyAction("enter", "go to line"); enter != "" {
+		parts = append(parts, enter)
+	}
+	if esc := fs.Keys.FormatKeyAction("escape", "back"); esc != "" {
+		parts = append(parts, esc)
+	}
+	return strings.Join(parts, " • ") + scrollInfo
+}
+
+// GetStatus returns the query bar
+func (fs *FileSearchList) GetStatus() string {
+	if fs.Query == "" {
+		return ui.SearchStyle().Render("Search: (type to search the file)")
+	}
+	return ui.SearchStyle().Render("Search: " + fs.Query)
+}
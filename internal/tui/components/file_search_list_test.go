@@ -0,0 +1,76 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestFileSearchListRender(t *testing.T) {
+	fs := NewFileSearchList(60, 10)
+	file := &simulator.FileInfo{Name: "notes.txt"}
+
+	fs.Update(file, "", nil, 0, 0, false, nil)
+	if got := fs.Render(); !strings.Contains(got, "Type to search") {
+		t.Errorf("Render() without a query = %q", got)
+	}
+	fs.Update(file, "todo", nil, 0, 0, true, nil)
+	if got := fs.Render(); !strings.Contains(got, "Searching...") {
+		t.Errorf("Render() while searching = %q", got)
+	}
+	fs.Update(file, "todo", nil, 0, 0, false, nil)
+	if got := fs.Render(); !strings.Contains(got, "No lines match") {
+		t.Errorf("Render() without matches = %q", got)
+	}
+
+	matches := []simulator.SearchMatch{{LineNum: 7, Snippet: "// TODO: fix"}, {LineNum: 120, Snippet: "todo list"}}
+	fs.Update(file, "todo", matches, 1, 0, false, nil)
+	got := fs.Render()
+	for _, want := range []string{"    7  // TODO: fix", "▶ 120  todo list"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() missing %q:\n%s", want, got)
+		}
+	}
+	if title := fs.GetTitle(); title != "Search notes.txt (2 matches)" {
+		t.Errorf("GetTitle() = %q", title)
+	}
+	if status := fs.GetStatus(); !strings.Contains(status, "Search: todo") {
+		t.Errorf("GetStatus() = %q", status)
+	}
+}
+
+func TestFitSnippet(t *testing.T) {
+	long := strings.Repeat("a", 40) + "needle" + strings.Repeat("b", 40)
+	tests := []struct {
+		name, snippet, want string
+	}{
+		{"fits", "short needle", "short needle"},
+		{"match at the start", "needle" + strings.Repeat("b", 40), "needle" + strings.Repeat("b", 11) + "..."},
+		{"match cut off", long, "..." + strings.Repeat("a", 4) + "needle" + strings.Repeat("b", 4) + "..."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fitSnippet(tt.snippet, "NEEDLE", 20)
+			if got != tt.want {
+				t.Errorf("fitSnippet() = %q, want %q", got, tt.want)
+			}
+			if len([]rune(got)) > 20 {
+				t.Errorf("fitSnippet() = %q is wider than 20", got)
+			}
+		})
+	}
+}
+
+func TestFileSearchListGetFooter(t *testing.T) {
+	fs := NewFileSearchList(80, 24)
+	keys := config.DefaultKeys()
+	fs.Update(nil, "x", nil, 0, 0, false, &keys)
+	got := fs.GetFooter()
+	for _, want := range []string{"↑/↓: navigate", "Enter: go to line", "ESC: back"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("GetFooter() = %q, missing %q", got, want)
+		}
+	}
+}
@@ -42,21 +42,7 @@ func (fv *FileViewer) renderArchive() string {
 	s.WriteString(ui.DetailStyle().Render(strings.Repeat("─", innerWidth)))
 	s.WriteString("\n\n")
 
-	// Build and render tree
-	tree := buildTreeFromPaths(archInfo.Entries)
-	var treeLines []string
-
-	// Render root's children
-	childNames := make([]string, 0, len(tree.children))
-	for name := range tree.children {
-		childNames = append(childNames, name)
-	}
-	sort.Strings(childNames)
-
-	for i, childName := range childNames {
-		child := tree.children[childName]
-		renderTree(child, "", i == len(childNames)-1, &treeLines)
-	}
+	treeLines := ArchiveTreeLines(archInfo)
 
 	// Calculate visible range
 	headerLines := 4 // Info + separator + padding
@@ -83,6 +69,29 @@ func (fv *FileViewer) renderArchive() string {
 	return s.String()
 }
 
+// ArchiveTreeLines returns the archive's entries as the lines of a file
+// tree drawn with box drawing characters, unstyled
+func ArchiveTreeLines(info *simulator.ArchiveInfo) []string {
+	if info == nil {
+		return nil
+	}
+	tree := buildTreeFromPaths(info.Entries)
+	var treeLines []string
+
+	// Render root's children
+	childNames := make([]string, 0, len(tree.children))
+	for name := range tree.children {
+		childNames = append(childNames, name)
+	}
+	sort.Strings(childNames)
+
+	for i, childName := range childNames {
+		child := tree.children[childName]
+		renderTree(child, "", i == len(childNames)-1, &treeLines)
+	}
+	return treeLines
+}
+
 // countArchiveTreeLines counts how many lines the tree view will
 // produce for a given archive without actually building the tree.
 // Each unique path component (a/, a/b, a/b/c.txt) becomes one
@@ -0,0 +1,103 @@
+package file_viewer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+	"github.com/azizuysal/simtool/internal/ui"
+)
+
+// renderAssetCatalog renders an asset catalog as a tree of its folders
+// and assets below a summary of asset counts by type
+func (fv *FileViewer) renderAssetCatalog() string {
+	info := fv.Content.AssetCatalog
+	if info == nil {
+		return ui.ErrorStyle().Render("Error loading asset catalog")
+	}
+
+	var s strings.Builder
+	innerWidth := fv.Width - 4 // Account for padding
+
+	s.WriteString(ui.DetailStyle().Render("Asset catalog • " + assetCountSummary(info.Counts)))
+	s.WriteString("\n")
+	s.WriteString(ui.DetailStyle().Render(strings.Repeat("─", innerWidth)))
+	s.WriteString("\n\n")
+
+	if len(info.Assets) == 0 {
+		s.WriteString(ui.DetailStyle().Render("No assets"))
+		return s.String()
+	}
+
+	tree := buildTreeFromPaths(assetCatalogEntries(info))
+	var treeLines []string
+	childNames := make([]string, 0, len(tree.children))
+	for name := range tree.children {
+		childNames = append(childNames, name)
+	}
+	sort.Strings(childNames)
+	for i, childName := range childNames {
+		renderTree(tree.children[childName], "", i == len(childNames)-1, &treeLines)
+	}
+
+	headerLines := 4 // Info + separator + padding
+	startIdx := fv.ContentViewport
+	endIdx := min(startIdx+fv.Height-headerLines, len(treeLines))
+	for i := startIdx; i < endIdx; i++ {
+		if i > startIdx {
+			s.WriteString("\n")
+		}
+		s.WriteString(treeLines[i])
+	}
+
+	return s.String()
+}
+
+// assetCatalogEntries converts the catalog's folders and assets to tree
+// entries. Assets are leaves labeled with their type and variant count;
+// folders stay directories so renderTree marks them as such.
+func assetCatalogEntries(info *simulator.AssetCatalogInfo) []simulator.ArchiveEntry {
+	entries := make([]simulator.ArchiveEntry, 0, len(info.Assets))
+	for _, asset := range info.Assets {
+		if asset.Type == "folder" {
+			entries = append(entries, simulator.ArchiveEntry{Name: asset.Path, IsDir: true})
+			continue
+		}
+		label := fmt.Sprintf("%s (%s, %d %s)", asset.Path, asset.Type, asset.Variants, pluralize("variant", asset.Variants))
+		entries = append(entries, simulator.ArchiveEntry{Name: label})
+	}
+	return entries
+}
+
+// assetCountSummary lists the number of assets of each type, most
+// common first, e.g. "12 image sets, 3 color sets, 1 app icon"
+func assetCountSummary(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "0 assets"
+	}
+	kinds := make([]string, 0, len(counts))
+	for kind := range counts {
+		kinds = append(kinds, kind)
+	}
+	sort.Slice(kinds, func(i, j int) bool {
+		if counts[kinds[i]] != counts[kinds[j]] {
+			return counts[kinds[i]] > counts[kinds[j]]
+		}
+		return kinds[i] < kinds[j]
+	})
+	parts := make([]string, len(kinds))
+	for i, kind := range kinds {
+		parts[i] = fmt.Sprintf("%d %s", counts[kind], pluralize(kind, counts[kind]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// pluralize returns noun in the plural unless n is 1. Nouns already
+// ending in "s" (e.g. "brand assets") are left as they are.
+func pluralize(noun string, n int) string {
+	if n == 1 || strings.HasSuffix(noun, "s") {
+		return noun
+	}
+	return noun + "s"
+}
@@ -15,6 +15,9 @@ func (fv *FileViewer) renderBinary() string {
 
 	// File info header
 	info := fmt.Sprintf("Binary file • %s", simulator.FormatSize(fv.File.Size))
+	if fv.Content.ChunkSize > 0 {
+		info += fmt.Sprintf(" • %s chunks", simulator.FormatSize(int64(fv.Content.ChunkSize)))
+	}
 	s.WriteString(ui.DetailStyle().Render(info))
 	s.WriteString("\n")
 	s.WriteString(ui.DetailStyle().Render(strings.Repeat("─", innerWidth)))
@@ -0,0 +1,53 @@
+package file_viewer
+
+import (
+	"github.com/azizuysal/simtool/internal/simulator"
+	"github.com/azizuysal/simtool/internal/tui/components"
+	"github.com/azizuysal/simtool/internal/ui"
+)
+
+// cookieColumns are the columns of the cookie table
+var cookieColumns = []simulator.ColumnInfo{
+	{Name: "Domain"},
+	{Name: "Path"},
+	{Name: "Name"},
+	{Name: "Value"},
+	{Name: "Expires"},
+}
+
+// renderCookies renders a binary cookies file as a table, reusing the
+// database table renderer
+func (fv *FileViewer) renderCookies() string {
+	if fv.Content.CookiesInfo == nil {
+		return ui.ErrorStyle().Render("Error loading cookies")
+	}
+
+	table, rows := cookiesTable(fv.Content.CookiesInfo)
+	dtc := components.NewDatabaseTableContent(fv.Width, fv.Height)
+	dtc.Update(table, rows, fv.File, fv.ContentViewport, 0, fv.Keys)
+	return dtc.Render()
+}
+
+// cookiesTable converts cookies into a table and its rows
+func cookiesTable(info *simulator.CookiesInfo) (*simulator.TableInfo, []map[string]any) {
+	table := &simulator.TableInfo{
+		Name:     "Cookies",
+		RowCount: int64(len(info.Cookies)),
+		Columns:  cookieColumns,
+	}
+	rows := make([]map[string]any, len(info.Cookies))
+	for i, cookie := range info.Cookies {
+		expires := "Session"
+		if !cookie.Expires.IsZero() {
+			expires = cookie.Expires.Format("2006-01-02 15:04")
+		}
+		rows[i] = map[string]any{
+			"Domain":  cookie.Domain,
+			"Path":    cookie.Path,
+			"Name":    cookie.Name,
+			"Value":   cookie.Value,
+			"Expires": expires,
+		}
+	}
+	return table, rows
+}
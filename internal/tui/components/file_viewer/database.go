@@ -105,7 +105,7 @@ func (fv *FileViewer) renderDatabaseTables(dbInfo *simulator.DatabaseInfo) strin
 		}
 
 		// Table header with icon
-		tableHeader := fmt.Sprintf("🗃️  %s (%d rows)", table.Name, table.RowCount)
+		tableHeader := fmt.Sprintf("🗃️  %s (%s rows)", table.Name, table.RowCountText())
 		s.WriteString(ui.NameStyle().Render(tableHeader))
 		s.WriteString("\n")
 		linesUsed++
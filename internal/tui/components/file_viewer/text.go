@@ -16,7 +16,9 @@ func (fv *FileViewer) renderText() string {
 
 	// File info header
 	fileType := "Text file"
-	if fv.Content.IsBinaryPlist {
+	if fv.Content.CrashReport != nil {
+		fileType = "Crash report"
+	} else if fv.Content.IsBinaryPlist {
 		fileType = "Binary plist (converted to XML)"
 	} else if strings.HasSuffix(strings.ToLower(fv.File.Path), ".plist") {
 		fileType = "Property list (XML)"
@@ -28,12 +30,20 @@ func (fv *FileViewer) renderText() string {
 		simulator.FormatSize(fv.File.Size))
 	s.WriteString(ui.DetailStyle().Render(info))
 	s.WriteString("\n")
-	s.WriteString(ui.DetailStyle().Render(strings.Repeat("─", innerWidth)))
-	s.WriteString("\n\n")
 
 	// Calculate visible lines
 	// Don't subtract border height as we're already in content dimensions
 	headerLines := 4 // Info + separator + padding
+
+	if fv.Content.CrashReport != nil {
+		crashHeader := renderCrashReportHeader(fv.Content.CrashReport)
+		s.WriteString(crashHeader)
+		s.WriteString("\n")
+		headerLines += strings.Count(crashHeader, "\n") + 1
+	}
+
+	s.WriteString(ui.DetailStyle().Render(strings.Repeat("─", innerWidth)))
+	s.WriteString("\n\n")
 	visibleLines := fv.Height - headerLines
 
 	startLine := fv.ContentViewport
@@ -81,3 +91,24 @@ func (fv *FileViewer) renderText() string {
 
 	return s.String()
 }
+
+// renderCrashReportHeader renders the exception, build UUID and top of
+// the crashed thread's stack shown above an .ips crash report
+func renderCrashReportHeader(report *simulator.CrashReport) string {
+	var lines []string
+	summary := "Exception: " + report.ExceptionType
+	if report.ExceptionType == "" {
+		summary = "Exception: unknown"
+	}
+	if report.BuildUUID != "" {
+		summary += " • Build UUID: " + report.BuildUUID
+	}
+	lines = append(lines, ui.ErrorStyle().Render(summary))
+	if len(report.StackTrace) > 0 {
+		lines = append(lines, ui.NameStyle().Render("Crashed thread:"))
+		for _, frame := range report.StackTrace {
+			lines = append(lines, ui.DetailStyle().Render("  "+frame))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -48,7 +48,12 @@ func (fv *FileViewer) Update(file *simulator.FileInfo, content *simulator.FileCo
 	fv.Keys = keys
 }
 
-// Render renders the file content based on type
+// scrollbarWidth is the columns the scrollbar takes from the content:
+// a space and the bar
+const scrollbarWidth = 2
+
+// Render renders the file content based on type, with a scrollbar on
+// the right when it doesn't all fit
 func (fv *FileViewer) Render() string {
 	if fv.File == nil || fv.Content == nil {
 		return ui.DetailStyle().Render("No file selected")
@@ -59,6 +64,19 @@ func (fv *FileViewer) Render() string {
 		return ui.ErrorStyle().Render(fmt.Sprintf("Error loading file: %v", fv.Content.Error))
 	}
 
+	if startLine, _, totalLines, ok := fv.scrollRange(); ok {
+		// The bar runs the height of the content box, inside its padding
+		if bar := renderScrollbar(fv.Height-2, totalLines, startLine-1); bar != "" {
+			narrow := *fv
+			narrow.Width -= scrollbarWidth
+			return appendScrollbar(narrow.renderContent(), bar, narrow.Width-4)
+		}
+	}
+	return fv.renderContent()
+}
+
+// renderContent renders the file content for its type
+func (fv *FileViewer) renderContent() string {
 	switch fv.Content.Type {
 	case simulator.FileTypeText:
 		return fv.renderText()
@@ -70,6 +88,10 @@ func (fv *FileViewer) Render() string {
 		return fv.renderArchive()
 	case simulator.FileTypeDatabase:
 		return fv.renderDatabase()
+	case simulator.FileTypeBinaryCookies:
+		return fv.renderCookies()
+	case simulator.FileTypeAssetCatalog:
+		return fv.renderAssetCatalog()
 	default:
 		return ui.ErrorStyle().Render("Unknown file type")
 	}
@@ -107,6 +129,29 @@ func (fv *FileViewer) GetFooter() string {
 	if down := fv.Keys.FormatKeyAction("down", "scroll down"); down != "" {
 		parts = append(parts, down)
 	}
+	if fv.Content != nil && fv.Content.Type == simulator.FileTypeBinary {
+		if chunks := fv.Keys.FormatKeyAction("binary_chunks", "chunk size"); chunks != "" {
+			parts = append(parts, chunks)
+		}
+	}
+	if fv.Content != nil {
+		switch fv.Content.Type {
+		case simulator.FileTypeText, simulator.FileTypeBinary, simulator.FileTypeArchive:
+			if cp := fv.Keys.FormatKeyAction("copy_file_content", "copy"); cp != "" {
+				parts = append(parts, cp)
+			}
+		}
+	}
+	if fv.File != nil && fv.File.Path != "" {
+		if fv.Content != nil && fv.Content.Type == simulator.FileTypeText {
+			if search := fv.Keys.FormatKeyAction("search", "search"); search != "" {
+				parts = append(parts, search)
+			}
+		}
+		if edit := fv.Keys.FormatKeyAction("editor", "edit"); edit != "" {
+			parts = append(parts, edit)
+		}
+	}
 	if left := fv.Keys.FormatKeyAction("left", "back"); left != "" {
 		parts = append(parts, left)
 	}
@@ -136,17 +181,43 @@ func (fv *FileViewer) GetStatus() string {
 	if fv.SVGWarning != "" {
 		return ui.StatusStyle().Render(fv.SVGWarning)
 	}
+	if fv.Content != nil && fv.Content.Notice != "" {
+		return ui.StatusStyle().Render(fv.Content.Notice)
+	}
 	return ""
 }
 
 // getScrollInfo returns scroll information based on file type
 func (fv *FileViewer) getScrollInfo() string {
-	if fv.Content == nil {
-		return ""
+	startLine, endLine, totalLines, hasContent := fv.scrollRange()
+	if hasContent {
+		// Add scroll indicators with arrows
+		canScrollUp := fv.ContentViewport > 0 || fv.ContentOffset > 0
+		canScrollDown := endLine < totalLines
+
+		switch {
+		case canScrollUp && canScrollDown:
+			return fmt.Sprintf("(%d-%d of %d) ↑↓", startLine, endLine, totalLines)
+		case canScrollUp:
+			return fmt.Sprintf("(%d-%d of %d) ↑", startLine, endLine, totalLines)
+		case canScrollDown:
+			return fmt.Sprintf("(%d-%d of %d) ↓", startLine, endLine, totalLines)
+		default:
+			return fmt.Sprintf("(%d-%d of %d)", startLine, endLine, totalLines)
+		}
 	}
 
-	var startLine, endLine, totalLines int
-	var hasContent bool
+	return ""
+}
+
+// scrollRange returns the 1-based first and last lines on screen and the
+// total lines of the content, which for text and binary files counts the
+// whole file rather than the loaded chunk. hasContent is false for
+// content that doesn't scroll.
+func (fv *FileViewer) scrollRange() (startLine, endLine, totalLines int, hasContent bool) {
+	if fv.Content == nil {
+		return 0, 0, 0, false
+	}
 
 	contentHeight := fv.Height // We're already in content dimensions
 
@@ -213,29 +284,80 @@ func (fv *FileViewer) getScrollInfo() string {
 				endLine = totalLines
 			}
 		}
+	case simulator.FileTypeAssetCatalog:
+		if fv.Content.AssetCatalog != nil {
+			hasContent = true
+			// Every folder and asset is one tree line
+			totalLines = len(fv.Content.AssetCatalog.Assets)
+			startLine = fv.ContentViewport + 1
+			endLine = startLine + contentHeight - 4
+			if endLine > totalLines {
+				endLine = totalLines
+			}
+		}
+	case simulator.FileTypeBinaryCookies:
+		if fv.Content.CookiesInfo != nil && len(fv.Content.CookiesInfo.Cookies) > 0 {
+			hasContent = true
+			// One row per cookie below the table and column headers
+			totalLines = len(fv.Content.CookiesInfo.Cookies)
+			startLine = fv.ContentViewport + 1
+			endLine = startLine + contentHeight - 9
+			if endLine > totalLines {
+				endLine = totalLines
+			}
+		}
 	}
 
-	if hasContent {
-		// Add scroll indicators with arrows
-		canScrollUp := fv.ContentViewport > 0 || fv.ContentOffset > 0
-		canScrollDown := endLine < totalLines
+	return startLine, endLine, totalLines, hasContent
+}
+
+// renderScrollbar returns a vertical scrollbar contentHeight rows high,
+// one row per line, for a view of contentHeight lines starting at the
+// 0-based viewportLine of totalLines. The thumb is ▓, with ░ where it
+// only partly covers a row, on a │ track. It returns "" when all the
+// lines fit.
+func renderScrollbar(contentHeight, totalLines, viewportLine int) string {
+	if contentHeight <= 0 || totalLines <= contentHeight {
+		return ""
+	}
+	viewportLine = max(min(viewportLine, totalLines-contentHeight), 0)
 
+	height := float64(contentHeight)
+	thumbSize := max(height*height/float64(totalLines), 1)
+	thumbStart := float64(viewportLine) / float64(totalLines-contentHeight) * (height - thumbSize)
+	thumbEnd := thumbStart + thumbSize
+
+	rows := make([]string, contentHeight)
+	for i := range rows {
+		covered := min(float64(i+1), thumbEnd) - max(float64(i), thumbStart)
 		switch {
-		case canScrollUp && canScrollDown:
-			return fmt.Sprintf("(%d-%d of %d) ↑↓", startLine, endLine, totalLines)
-		case canScrollUp:
-			return fmt.Sprintf("(%d-%d of %d) ↑", startLine, endLine, totalLines)
-		case canScrollDown:
-			return fmt.Sprintf("(%d-%d of %d) ↓", startLine, endLine, totalLines)
+		case covered > 0.999:
+			rows[i] = "▓"
+		case covered > 0:
+			rows[i] = "░"
 		default:
-			return fmt.Sprintf("(%d-%d of %d)", startLine, endLine, totalLines)
+			rows[i] = "│"
 		}
 	}
+	return strings.Join(rows, "\n")
+}
 
-	return ""
+// appendScrollbar places bar to the right of content, whose lines are
+// padded to width
+func appendScrollbar(content, bar string, width int) string {
+	lines := strings.Split(content, "\n")
+	barRows := strings.Split(bar, "\n")
+	for len(lines) < len(barRows) {
+		lines = append(lines, "")
+	}
+	for i, row := range barRows {
+		lines[i] = ui.PadLine(lines[i], width) + " " + ui.DetailStyle().Render(row)
+	}
+	return strings.Join(lines, "\n")
 }
 
 // renderText is implemented in text.go
 // renderImage is implemented in image.go
 // renderBinary is implemented in binary.go
 // renderArchive is implemented in archive.go
+// renderCookies is implemented in cookies.go
@@ -3,6 +3,7 @@ package file_viewer
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/azizuysal/simtool/internal/config"
 	"github.com/azizuysal/simtool/internal/simulator"
@@ -64,6 +65,15 @@ func TestFileViewer_GetStatus(t *testing.T) {
 	}
 }
 
+func TestFileViewer_GetStatus_ContentNotice(t *testing.T) {
+	fv := NewFileViewer(80, 24)
+	content := &simulator.FileContent{Type: simulator.FileTypeBinary, Notice: simulator.RealmToolingNotice}
+	fv.Update(&simulator.FileInfo{Path: "/default.realm"}, content, 0, 0, "", nil)
+	if got := fv.GetStatus(); !strings.Contains(got, "install realm-cli") {
+		t.Errorf("GetStatus() = %q, want the content notice", got)
+	}
+}
+
 // ---------- Render dispatcher error paths ----------
 
 func TestFileViewer_Render_NoFile(t *testing.T) {
@@ -181,6 +191,36 @@ func TestRenderText_ViewportOffset(t *testing.T) {
 	}
 }
 
+func TestRenderText_CrashReport(t *testing.T) {
+	file := simulator.FileInfo{Path: "/Example-2024-01-01-120000.ips", Size: 4000}
+	content := &simulator.FileContent{
+		Type:       simulator.FileTypeText,
+		Lines:      []string{`{"app_name":"Example","bug_type":"309"}`, "{", "}"},
+		TotalLines: 3,
+		CrashReport: &simulator.CrashReport{
+			ExceptionType: "EXC_BAD_ACCESS (SIGSEGV)",
+			BuildUUID:     "1A2B3C4D-0000-1111-2222-333344445555",
+			StackTrace:    []string{"0  Example  ViewController.crash() + 52"},
+		},
+	}
+	fv := NewFileViewer(100, 24)
+	fv.Update(&file, content, 0, 0, "", nil)
+
+	got := fv.Render()
+	for _, sub := range []string{
+		"Crash report",
+		"Exception: EXC_BAD_ACCESS (SIGSEGV)",
+		"Build UUID: 1A2B3C4D-0000-1111-2222-333344445555",
+		"Crashed thread:",
+		"ViewController.crash() + 52",
+		"app_name",
+	} {
+		if !strings.Contains(got, sub) {
+			t.Errorf("renderText() missing %q\n----\n%s", sub, got)
+		}
+	}
+}
+
 // ---------- renderBinary ----------
 
 func TestRenderBinary(t *testing.T) {
@@ -202,6 +242,26 @@ func TestRenderBinary(t *testing.T) {
 	}
 }
 
+func TestRenderBinary_ChunkSize(t *testing.T) {
+	file := simulator.FileInfo{Path: "/x.bin", Size: 16}
+	content := &simulator.FileContent{
+		Type:       simulator.FileTypeBinary,
+		BinaryData: []byte{0x00},
+		TotalSize:  16,
+		ChunkSize:  16384,
+	}
+	fv := NewFileViewer(80, 24)
+	keys := config.DefaultKeys()
+	fv.Update(&file, content, 0, 0, "", &keys)
+
+	if got := fv.Render(); !strings.Contains(got, "16.0 KB chunks") {
+		t.Errorf("renderBinary() should show the chunk size, got:\n%s", got)
+	}
+	if got := fv.GetFooter(); !strings.Contains(got, "B: chunk size") || !strings.Contains(got, "ctrl+y: copy") {
+		t.Errorf("GetFooter() = %q, want the chunk size and copy keys", got)
+	}
+}
+
 func TestRenderBinary_NilData(t *testing.T) {
 	file := simulator.FileInfo{Path: "/x.bin", Size: 0}
 	content := &simulator.FileContent{
@@ -453,6 +513,40 @@ func TestRenderDatabase_ErrorInfo(t *testing.T) {
 
 // ---------- Render dispatcher: unknown type ----------
 
+func TestRenderCookies_NoCookiesInfo(t *testing.T) {
+	file := simulator.FileInfo{Path: "/Cookies.binarycookies"}
+	content := &simulator.FileContent{Type: simulator.FileTypeBinaryCookies}
+	fv := NewFileViewer(80, 24)
+	fv.Update(&file, content, 0, 0, "", nil)
+
+	got := fv.Render()
+	if !strings.Contains(got, "Error loading cookies") {
+		t.Errorf("renderCookies() with nil CookiesInfo = %q, want error", got)
+	}
+}
+
+func TestRenderCookies_Table(t *testing.T) {
+	file := simulator.FileInfo{Path: "/Cookies.binarycookies"}
+	content := &simulator.FileContent{
+		Type: simulator.FileTypeBinaryCookies,
+		CookiesInfo: &simulator.CookiesInfo{
+			Cookies: []simulator.Cookie{
+				{Domain: ".example.com", Path: "/", Name: "session", Value: "abc", Expires: time.Date(2030, 1, 2, 3, 4, 0, 0, time.UTC)},
+				{Domain: "api.example.com", Path: "/v1", Name: "theme", Value: "dark"},
+			},
+		},
+	}
+	fv := NewFileViewer(160, 24)
+	fv.Update(&file, content, 0, 0, "", nil)
+
+	got := fv.Render()
+	for _, want := range []string{"Cookies", "2 rows", "Domain", "Expires", ".example.com", "session", "2030-01-02 03:04", "Session"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("renderCookies() missing %q in:\n%s", want, got)
+		}
+	}
+}
+
 func TestFileViewer_Render_UnknownType(t *testing.T) {
 	file := simulator.FileInfo{Path: "/x"}
 	// Use an out-of-range FileType value to exercise the default branch.
@@ -480,13 +574,25 @@ func TestFileViewer_GetFooter_ConfiguredKeys(t *testing.T) {
 	fv.Update(&file, content, 0, 0, "", &keys)
 
 	got := fv.GetFooter()
-	for _, sub := range []string{"scroll up", "scroll down", "back", "quit"} {
+	for _, sub := range []string{"scroll up", "scroll down", "/: search", "e: edit", "back", "quit"} {
 		if !strings.Contains(got, sub) {
 			t.Errorf("GetFooter() = %q, missing %q", got, sub)
 		}
 	}
 }
 
+func TestFileViewer_GetFooter_NoEditWithoutPath(t *testing.T) {
+	// Command output shown in the viewer has no file to edit
+	keys := config.DefaultKeys()
+	content := &simulator.FileContent{Type: simulator.FileTypeText, Lines: []string{"a"}, TotalLines: 1}
+	fv := NewFileViewer(80, 24)
+	fv.Update(&simulator.FileInfo{Name: "ls"}, content, 0, 0, "", &keys)
+
+	if got := fv.GetFooter(); strings.Contains(got, "edit") {
+		t.Errorf("GetFooter() = %q, want no edit key", got)
+	}
+}
+
 // ---------- scroll info: each file type ----------
 
 func TestFileViewer_ScrollInfo_Text(t *testing.T) {
@@ -596,6 +702,52 @@ func TestFileViewer_ScrollInfo_Database(t *testing.T) {
 
 // ---------- count helpers ----------
 
+func TestRenderScrollbar(t *testing.T) {
+	tests := []struct {
+		name                               string
+		contentHeight, total, viewportLine int
+		want                               string
+	}{
+		{"fits", 4, 4, 0, ""},
+		{"top", 4, 8, 0, "▓\n▓\n│\n│"},
+		{"bottom", 4, 8, 4, "│\n│\n▓\n▓"},
+		{"past the bottom", 4, 8, 9, "│\n│\n▓\n▓"},
+		{"partly covered rows", 4, 8, 1, "░\n▓\n░\n│"},
+		{"tiny thumb", 4, 1000, 500, "│\n░\n░\n│"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderScrollbar(tt.contentHeight, tt.total, tt.viewportLine); got != tt.want {
+				t.Errorf("renderScrollbar(%d, %d, %d) = %q, want %q",
+					tt.contentHeight, tt.total, tt.viewportLine, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileViewer_Render_Scrollbar(t *testing.T) {
+	file := simulator.FileInfo{Path: "/x.txt"}
+	content := &simulator.FileContent{
+		Type:       simulator.FileTypeText,
+		Lines:      []string{"one", "two", "three"},
+		TotalLines: 3,
+	}
+	fv := NewFileViewer(40, 12)
+	fv.Update(&file, content, 0, 0, "", nil)
+	if got := fv.Render(); strings.Contains(got, "▓") {
+		t.Errorf("Render() of content that fits shows a scrollbar:\n%s", got)
+	}
+
+	content.Lines = make([]string, 100)
+	content.TotalLines = 100
+	got := fv.Render()
+	// The bar fills the content box's 10 inner rows
+	lines := strings.Split(got, "\n")
+	if !strings.HasSuffix(lines[0], "▓") || !strings.HasSuffix(lines[9], "│") {
+		t.Errorf("Render() should end its lines with the scrollbar, thumb at the top:\n%s", got)
+	}
+}
+
 func TestCountArchiveTreeLines(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -686,3 +838,48 @@ func TestCountDatabaseLines(t *testing.T) {
 		})
 	}
 }
+
+// ---------- renderAssetCatalog ----------
+
+func TestRenderAssetCatalog(t *testing.T) {
+	file := simulator.FileInfo{Path: "/Assets.xcassets"}
+	content := &simulator.FileContent{
+		Type: simulator.FileTypeAssetCatalog,
+		AssetCatalog: &simulator.AssetCatalogInfo{
+			Assets: []simulator.CatalogAsset{
+				{Path: "AppIcon.appiconset", Type: "app icon", Variants: 1},
+				{Path: "Icons", Type: "folder"},
+				{Path: "Icons/star.imageset", Type: "image set", Variants: 3},
+				{Path: "Icons/moon.imageset", Type: "image set", Variants: 2},
+			},
+			Counts: map[string]int{"app icon": 1, "image set": 2},
+		},
+	}
+	fv := NewFileViewer(80, 24)
+	fv.Update(&file, content, 0, 0, "", nil)
+
+	got := fv.Render()
+	for _, sub := range []string{
+		"Asset catalog • 2 image sets, 1 app icon",
+		"AppIcon.appiconset (app icon, 1 variant)",
+		"Icons/",
+		"└── star.imageset (image set, 3 variants)",
+	} {
+		if !strings.Contains(got, sub) {
+			t.Errorf("renderAssetCatalog() missing %q\n----\n%s", sub, got)
+		}
+	}
+}
+
+func TestRenderAssetCatalog_Empty(t *testing.T) {
+	fv := NewFileViewer(80, 24)
+	fv.Update(&simulator.FileInfo{Path: "/Empty.xcassets"}, &simulator.FileContent{
+		Type:         simulator.FileTypeAssetCatalog,
+		AssetCatalog: &simulator.AssetCatalogInfo{Counts: map[string]int{}},
+	}, 0, 0, "", nil)
+
+	got := fv.Render()
+	if !strings.Contains(got, "0 assets") || !strings.Contains(got, "No assets") {
+		t.Errorf("renderAssetCatalog() for an empty catalog:\n%s", got)
+	}
+}
@@ -0,0 +1,171 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/simulator"
+	"github.com/azizuysal/simtool/internal/ui"
+)
+
+// LogStreamHeaderLines is the number of lines LogStream spends on its
+// info header and separator above the log lines.
+const LogStreamHeaderLines = 4
+
+// LogStream renders the live log view for an app, or for a whole
+// simulator
+type LogStream struct {
+	Width     int
+	Height    int
+	App       *simulator.App
+	Simulator *simulator.Item
+	Lines     []string
+	Viewport  int
+	Following bool
+	Ended     bool
+	Keys      *config.KeysConfig
+}
+
+// NewLogStream creates a new log stream renderer
+func NewLogStream(width, height int) *LogStream {
+	return &LogStream{
+		Width:  width,
+		Height: height,
+	}
+}
+
+// Update updates the log data
+func (ls *LogStream) Update(app *simulator.App, lines []string, viewport int, following, ended bool, keys *config.KeysConfig) {
+	ls.App = app
+	ls.Lines = lines
+	ls.Viewport = viewport
+	ls.Following = following
+	ls.Ended = ended
+	ls.Keys = keys
+}
+
+// SetSimulator sets the simulator whose every process is streamed, for
+// streams that aren't limited to one app
+func (ls *LogStream) SetSimulator(sim *simulator.Item) {
+	ls.Simulator = sim
+}
+
+// VisibleLines returns how many log lines fit below the header
+func (ls *LogStream) VisibleLines() int {
+	return max(ls.Height-LogStreamHeaderLines, 1)
+}
+
+// Render renders the visible window of log lines
+func (ls *LogStream) Render() string {
+	var s strings.Builder
+	innerWidth := ls.Width - 4 // Account for content box padding
+
+	state := "streaming"
+	switch {
+	case ls.Ended:
+		state = "stream ended"
+	case !ls.Following:
+		state = "paused (scrolling)"
+	}
+	info := fmt.Sprintf("%d lines • %s", len(ls.Lines), state)
+	s.WriteString(ui.DetailStyle().Render(info))
+	s.WriteString("\n")
+	s.WriteString(ui.DetailStyle().Render(strings.Repeat("─", innerWidth)))
+	s.WriteString("\n\n")
+
+	if len(ls.Lines) == 0 {
+		s.WriteString(ui.DetailStyle().Render("Waiting for log output..."))
+		return s.String()
+	}
+
+	start := ls.Viewport
+	end := min(start+ls.VisibleLines(), len(ls.Lines))
+	for i := start; i < end; i++ {
+		if i > start {
+			s.WriteString("\n")
+		}
+		s.WriteString(renderLogLine(ls.Lines[i], innerWidth))
+	}
+
+	return s.String()
+}
+
+// renderLogLine renders a log line in the style of its level, cut to
+// width. JSON in the message is syntax highlighted instead.
+func renderLogLine(line string, width int) string {
+	style := logLineStyle(simulator.ParseLogLevel(line))
+	jsonStart, _ := simulator.FindLogJSON(line)
+	if width > 3 && lipgloss.Width(line) > width {
+		runes := []rune(line)
+		if len(runes) > width-3 {
+			line = string(runes[:width-3]) + "..."
+		}
+	}
+	if jsonStart < 0 || jsonStart >= len(line) {
+		return style.Render(line)
+	}
+	// A cut-off object still lexes; only the bracket balance is lost
+	return style.Render(line[:jsonStart]) + simulator.GetSyntaxHighlightedLine(line[jsonStart:], ".json")
+}
+
+// logLineStyle returns the style used for a log line of the given level
+func logLineStyle(level simulator.LogLevel) lipgloss.Style {
+	switch level {
+	case simulator.LogLevelError:
+		return ui.ErrorStyle()
+	case simulator.LogLevelWarning:
+		return ui.WarningStyle()
+	case simulator.LogLevelDebug:
+		return ui.SuccessStyle()
+	default:
+		return ui.NormalStyle()
+	}
+}
+
+// GetTitle returns the title for the log view
+func (ls *LogStream) GetTitle() string {
+	if ls.App != nil {
+		return fmt.Sprintf("%s Logs", ls.App.Name)
+	}
+	if ls.Simulator != nil {
+		return fmt.Sprintf("%s Logs", ls.Simulator.Name)
+	}
+	return "Logs"
+}
+
+// GetFooter returns the footer for the log view
+func (ls *LogStream) GetFooter() string {
+	if ls.Keys == nil {
+		return "↑/k: up • ↓/j: down • End: follow • q/←/h: stop"
+	}
+
+	var parts []string
+	if up := ls.Keys.FormatKeyAction("up", "up"); up != "" {
+		parts = append(parts, up)
+	}
+	if down := ls.Keys.FormatKeyAction("down", "down"); down != "" {
+		parts = append(parts, down)
+	}
+	if end := ls.Keys.FormatKeyAction("end", "follow"); end != "" {
+		parts = append(parts, end)
+	}
+	// Quit keys stop the stream instead of exiting; ctrl+c still exits.
+	var stopKeys []string
+	for _, k := range append(append([]string{}, ls.Keys.Quit...), ls.Keys.Left...) {
+		if k != "ctrl+c" {
+			stopKeys = append(stopKeys, k)
+		}
+	}
+	if len(stopKeys) > 0 {
+		parts = append(parts, config.FormatKeys(stopKeys)+": stop")
+	}
+
+	footer := strings.Join(parts, " • ")
+	if len(ls.Lines) > 0 {
+		footer += ui.FormatScrollInfo(ls.Viewport, ls.VisibleLines(), len(ls.Lines))
+	}
+	return footer
+}
@@ -0,0 +1,94 @@
+package components
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestLogStreamGetTitle(t *testing.T) {
+	ls := NewLogStream(80, 24)
+	if got := ls.GetTitle(); got != "Logs" {
+		t.Errorf("GetTitle() without app = %q, want Logs", got)
+	}
+	ls.Update(&simulator.App{Name: "Demo"}, nil, 0, true, false, nil)
+	if got := ls.GetTitle(); got != "Demo Logs" {
+		t.Errorf("GetTitle() = %q, want %q", got, "Demo Logs")
+	}
+
+	sim := NewLogStream(80, 24)
+	sim.SetSimulator(&simulator.Item{Simulator: simulator.Simulator{Name: "iPhone 15"}})
+	if got := sim.GetTitle(); got != "iPhone 15 Logs" {
+		t.Errorf("GetTitle() for a simulator = %q, want %q", got, "iPhone 15 Logs")
+	}
+}
+
+func TestLogStreamRender(t *testing.T) {
+	t.Run("waiting for output", func(t *testing.T) {
+		ls := NewLogStream(80, 24)
+		ls.Update(nil, nil, 0, true, false, nil)
+		out := ls.Render()
+		if !strings.Contains(out, "Waiting for log output") || !strings.Contains(out, "streaming") {
+			t.Errorf("Render() = %q", out)
+		}
+	})
+
+	t.Run("shows only the viewport window", func(t *testing.T) {
+		lines := []string{"line0", "line1", "line2", "line3", "line4", "line5"}
+		ls := NewLogStream(80, 4+3) // 3 visible lines
+		ls.Update(nil, lines, 2, false, false, nil)
+		out := ls.Render()
+		for _, want := range []string{"line2", "line3", "line4", "paused"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("Render() missing %q", want)
+			}
+		}
+		for _, unwanted := range []string{"line1", "line5"} {
+			if strings.Contains(out, unwanted) {
+				t.Errorf("Render() contains off-screen %q", unwanted)
+			}
+		}
+	})
+
+	t.Run("truncates long lines", func(t *testing.T) {
+		ls := NewLogStream(24, 10)
+		ls.Update(nil, []string{strings.Repeat("x", 100)}, 0, true, true, nil)
+		out := ls.Render()
+		if strings.Contains(out, strings.Repeat("x", 21)) || !strings.Contains(out, "...") {
+			t.Errorf("long line not truncated: %q", out)
+		}
+		if !strings.Contains(out, "stream ended") {
+			t.Error("ended state not shown")
+		}
+	})
+}
+
+func TestRenderLogLine_JSON(t *testing.T) {
+	ansi := regexp.MustCompile(`\x1b\[[0-9;]*m`)
+	line := `10:00:00.123 Df Demo[1:2] response {"id": 1}`
+	got := renderLogLine(line, 80)
+	if plain := ansi.ReplaceAllString(got, ""); !strings.Contains(plain, line) {
+		t.Errorf("renderLogLine() = %q, want the whole line", plain)
+	}
+	if !strings.Contains(got, "\x1b[") {
+		t.Errorf("renderLogLine() = %q, want the JSON highlighted", got)
+	}
+}
+
+func TestLogStreamGetFooter(t *testing.T) {
+	keys := config.DefaultKeys()
+	ls := NewLogStream(80, 24)
+	ls.Update(nil, []string{"a"}, 0, true, false, &keys)
+	footer := ls.GetFooter()
+	for _, want := range []string{"follow", "q/←/h: stop"} {
+		if !strings.Contains(footer, want) {
+			t.Errorf("GetFooter() = %q, want it to contain %q", footer, want)
+		}
+	}
+	if strings.Contains(footer, "Ctrl+C") {
+		t.Errorf("GetFooter() = %q, ctrl+c should not be listed as stop", footer)
+	}
+}
@@ -0,0 +1,113 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+	"github.com/azizuysal/simtool/internal/ui"
+)
+
+// maxXattrValueLines caps how many lines of an attribute value the panel
+// shows, since binary values are printed as long hex dumps
+const maxXattrValueLines = 3
+
+// MetadataPanel renders a file's metadata and extended attributes as a
+// panel centered over the file list
+type MetadataPanel struct {
+	Width  int
+	Height int
+	File   simulator.FileInfo
+}
+
+// NewMetadataPanel creates a new metadata panel renderer
+func NewMetadataPanel(width, height int) *MetadataPanel {
+	return &MetadataPanel{
+		Width:  width,
+		Height: height,
+	}
+}
+
+// Update updates the panel data
+func (mp *MetadataPanel) Update(file simulator.FileInfo) {
+	mp.File = file
+}
+
+// Render renders the panel centered in the content area
+func (mp *MetadataPanel) Render() string {
+	// Leave room for the panel border and padding
+	valueWidth := max(mp.Width-16, 20)
+
+	var s strings.Builder
+	s.WriteString(ui.NameStyle().Render(mp.GetTitle()))
+	s.WriteString("\n\n")
+
+	rows := []struct{ label, value string }{
+		{"Size", simulator.FormatSize(mp.File.Size)},
+		{"Created", mp.File.CreatedAt.Format("2006-01-02 15:04:05")},
+		{"Modified", mp.File.ModifiedAt.Format("2006-01-02 15:04:05")},
+	}
+	for _, row := range rows {
+		s.WriteString(ui.DetailStyle().Render(fmt.Sprintf("%-10s", row.label)))
+		s.WriteString(ui.NormalStyle().Render(row.value))
+		s.WriteString("\n")
+	}
+	s.WriteString("\n")
+
+	if len(mp.File.Xattrs) == 0 {
+		s.WriteString(ui.DetailStyle().Render("No extended attributes"))
+	} else {
+		names := make([]string, 0, len(mp.File.Xattrs))
+		for name := range mp.File.Xattrs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for i, name := range names {
+			if i > 0 {
+				s.WriteString("\n\n")
+			}
+			s.WriteString(ui.NameStyle().Render(name))
+			if description := simulator.XattrDescription(name); description != "" {
+				s.WriteString("\n")
+				s.WriteString(ui.DetailStyle().Render(description))
+			}
+			s.WriteString("\n")
+			s.WriteString(ui.NormalStyle().Render(formatXattrValue(mp.File.Xattrs[name], valueWidth)))
+		}
+	}
+
+	panel := ui.BorderStyle().Padding(1, 2).Render(s.String())
+	// The content box border takes 2 lines
+	return lipgloss.Place(max(mp.Width-4, 0), max(mp.Height-2, 0), lipgloss.Center, lipgloss.Center, panel)
+}
+
+// formatXattrValue shortens an attribute value to a few lines of at
+// most width characters
+func formatXattrValue(value string, width int) string {
+	if value == "" {
+		return "(empty)"
+	}
+	lines := strings.Split(value, "\n")
+	if len(lines) > maxXattrValueLines {
+		lines = append(lines[:maxXattrValueLines], fmt.Sprintf("… %d more lines", len(lines)-maxXattrValueLines))
+	}
+	for i, line := range lines {
+		if runes := []rune(line); len(runes) > width {
+			lines[i] = string(runes[:width-3]) + "..."
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// GetTitle returns the title for the panel
+func (mp *MetadataPanel) GetTitle() string {
+	return fmt.Sprintf("%s Metadata", mp.File.Name)
+}
+
+// GetFooter returns the footer shown while the panel is open
+func (mp *MetadataPanel) GetFooter() string {
+	return "any key: close"
+}
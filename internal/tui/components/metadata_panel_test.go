@@ -0,0 +1,38 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestMetadataPanelRender(t *testing.T) {
+	mp := NewMetadataPanel(100, 30)
+
+	mp.Update(simulator.FileInfo{Name: "cache.db", Size: 2048, Xattrs: map[string]string{
+		"com.apple.quarantine": "0083;65a1b2c3;Safari;",
+		"com.example.custom":   "",
+	}})
+	got := mp.Render()
+	for _, want := range []string{"cache.db Metadata", "2.0 KB", "com.apple.quarantine", "Quarantine flag", "0083;65a1b2c3;Safari;", "com.example.custom", "(empty)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() missing %q:\n%s", want, got)
+		}
+	}
+
+	mp.Update(simulator.FileInfo{Name: "plain.txt", Xattrs: map[string]string{}})
+	if got := mp.Render(); !strings.Contains(got, "No extended attributes") {
+		t.Errorf("Render() without attributes = %q", got)
+	}
+}
+
+func TestFormatXattrValue(t *testing.T) {
+	value := "line 1\nline 2\nline 3\nline 4\nline 5"
+	if got := formatXattrValue(value, 40); !strings.HasSuffix(got, "… 2 more lines") || strings.Contains(got, "line 4") {
+		t.Errorf("formatXattrValue() = %q, want three lines and a count", got)
+	}
+	if got := formatXattrValue(strings.Repeat("x", 50), 20); got != strings.Repeat("x", 17)+"..." {
+		t.Errorf("formatXattrValue() = %q, want a truncated line", got)
+	}
+}
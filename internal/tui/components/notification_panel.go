@@ -0,0 +1,88 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+	"github.com/azizuysal/simtool/internal/ui"
+)
+
+// NotificationPanel renders an app's notification status as a panel
+// centered over the app list
+type NotificationPanel struct {
+	Width   int
+	Height  int
+	AppName string
+	Info    *simulator.NotificationInfo // nil if the app has no settings file
+}
+
+// NewNotificationPanel creates a new notification panel renderer
+func NewNotificationPanel(width, height int) *NotificationPanel {
+	return &NotificationPanel{
+		Width:  width,
+		Height: height,
+	}
+}
+
+// Update updates the panel data
+func (np *NotificationPanel) Update(appName string, info *simulator.NotificationInfo) {
+	np.AppName = appName
+	np.Info = info
+}
+
+// Render renders the panel centered in the content area
+func (np *NotificationPanel) Render() string {
+	var s strings.Builder
+	s.WriteString(ui.NameStyle().Render(fmt.Sprintf("%s Notifications", np.AppName)))
+	s.WriteString("\n\n")
+
+	if np.Info == nil {
+		s.WriteString(ui.DetailStyle().Render("No notification settings found.\nThe app has not requested notification permission."))
+	} else {
+		rows := []struct{ label, value string }{
+			{"Authorization", np.Info.AuthorizationStatus},
+			{"Delivered", fmt.Sprintf("%d", np.Info.Delivered)},
+			{"Scheduled", fmt.Sprintf("%d", np.Info.Scheduled)},
+		}
+		for i, row := range rows {
+			if i > 0 {
+				s.WriteString("\n")
+			}
+			s.WriteString(ui.DetailStyle().Render(fmt.Sprintf("%-15s", row.label)))
+			s.WriteString(authorizationStyle(row.label, row.value).Render(row.value))
+		}
+	}
+
+	panel := ui.BorderStyle().Padding(1, 2).Render(s.String())
+	// The content box border takes 2 lines
+	return lipgloss.Place(max(np.Width-4, 0), max(np.Height-2, 0), lipgloss.Center, lipgloss.Center, panel)
+}
+
+// authorizationStyle colors the authorization value by outcome; other
+// rows use the normal style.
+func authorizationStyle(label, value string) lipgloss.Style {
+	if label != "Authorization" {
+		return ui.NormalStyle()
+	}
+	switch value {
+	case "authorized", "provisional", "ephemeral":
+		return ui.SuccessStyle()
+	case "denied":
+		return ui.ErrorStyle()
+	default:
+		return ui.WarningStyle()
+	}
+}
+
+// GetTitle returns the title for the panel
+func (np *NotificationPanel) GetTitle() string {
+	return fmt.Sprintf("%s Notifications", np.AppName)
+}
+
+// GetFooter returns the footer shown while the panel is open
+func (np *NotificationPanel) GetFooter() string {
+	return "any key: close"
+}
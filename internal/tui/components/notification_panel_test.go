@@ -0,0 +1,25 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestNotificationPanelRender(t *testing.T) {
+	np := NewNotificationPanel(80, 20)
+
+	np.Update("Example", &simulator.NotificationInfo{AuthorizationStatus: "denied", Delivered: 4, Scheduled: 2})
+	got := np.Render()
+	for _, want := range []string{"Example Notifications", "Authorization", "denied", "Delivered", "4", "Scheduled", "2"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() missing %q:\n%s", want, got)
+		}
+	}
+
+	np.Update("Example", nil)
+	if got := np.Render(); !strings.Contains(got, "No notification settings found") {
+		t.Errorf("Render() without settings = %q", got)
+	}
+}
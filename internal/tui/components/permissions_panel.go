@@ -0,0 +1,101 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/simulator"
+	"github.com/azizuysal/simtool/internal/ui"
+)
+
+// PermissionsPanel renders an app's privacy permissions as a checklist
+// centered over the app list
+type PermissionsPanel struct {
+	Width       int
+	Height      int
+	AppName     string
+	Permissions []simulator.Permission
+	Cursor      int
+	Keys        *config.KeysConfig
+}
+
+// NewPermissionsPanel creates a new permissions panel renderer
+func NewPermissionsPanel(width, height int) *PermissionsPanel {
+	return &PermissionsPanel{
+		Width:  width,
+		Height: height,
+	}
+}
+
+// Update updates the panel data
+func (pp *PermissionsPanel) Update(appName string, permissions []simulator.Permission, cursor int, keys *config.KeysConfig) {
+	pp.AppName = appName
+	pp.Permissions = permissions
+	pp.Cursor = cursor
+	pp.Keys = keys
+}
+
+// Render renders the panel centered in the content area
+func (pp *PermissionsPanel) Render() string {
+	var s strings.Builder
+	s.WriteString(ui.NameStyle().Render(pp.GetTitle()))
+	s.WriteString("\n")
+
+	labelWidth := 0
+	for _, p := range pp.Permissions {
+		labelWidth = max(labelWidth, len(p.Label))
+	}
+	for i, p := range pp.Permissions {
+		check := "[ ]"
+		if p.Status == simulator.PermissionGranted || p.Status == simulator.PermissionLimited {
+			check = "[x]"
+		}
+		line := fmt.Sprintf("%s %-*s  ", check, labelWidth, p.Label)
+		s.WriteString("\n")
+		if i == pp.Cursor {
+			s.WriteString(ui.SelectedStyle().Render("▶ " + line + p.Status))
+			continue
+		}
+		s.WriteString(ui.NormalStyle().Render("  " + line))
+		s.WriteString(permissionStatusStyle(p.Status).Render(p.Status))
+	}
+
+	panel := ui.BorderStyle().Padding(1, 2).Render(s.String())
+	// The content box border takes 2 lines
+	return lipgloss.Place(max(pp.Width-4, 0), max(pp.Height-2, 0), lipgloss.Center, lipgloss.Center, panel)
+}
+
+// permissionStatusStyle colors a permission status by outcome
+func permissionStatusStyle(status string) lipgloss.Style {
+	switch status {
+	case simulator.PermissionGranted, simulator.PermissionLimited:
+		return ui.SuccessStyle()
+	case simulator.PermissionDenied:
+		return ui.ErrorStyle()
+	default:
+		return ui.DetailStyle()
+	}
+}
+
+// GetTitle returns the title for the panel
+func (pp *PermissionsPanel) GetTitle() string {
+	return fmt.Sprintf("%s Permissions", pp.AppName)
+}
+
+// GetFooter returns the footer shown while the panel is open
+func (pp *PermissionsPanel) GetFooter() string {
+	if pp.Keys == nil {
+		return "↑/↓: move • space: grant/revoke • ESC: close"
+	}
+	parts := []string{config.FormatKeys(pp.Keys.Up) + "/" + config.FormatKeys(pp.Keys.Down) + ": move"}
+	if toggle := pp.Keys.FormatKeyAction("open", "grant/revoke"); toggle != "" {
+		parts = append(parts, toggle)
+	}
+	if esc := pp.Keys.FormatKeyAction("escape", "close"); esc != "" {
+		parts = append(parts, esc)
+	}
+	return strings.Join(parts, " • ")
+}
@@ -0,0 +1,29 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestPermissionsPanelRender(t *testing.T) {
+	keys := config.DefaultKeys()
+	pp := NewPermissionsPanel(100, 30)
+	pp.Update("AppA", []simulator.Permission{
+		{Service: "camera", Label: "Camera", Status: simulator.PermissionGranted},
+		{Service: "contacts", Label: "Contacts", Status: simulator.PermissionDenied},
+		{Service: "photos", Label: "Photos", Status: simulator.PermissionNotSet},
+	}, 1, &keys)
+
+	got := pp.Render()
+	for _, want := range []string{"AppA Permissions", "[x] Camera", "granted", "▶ [ ] Contacts", "denied", "[ ] Photos", "not set"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() missing %q:\n%s", want, got)
+		}
+	}
+	if footer := pp.GetFooter(); !strings.Contains(footer, "grant/revoke") || !strings.Contains(footer, "close") {
+		t.Errorf("GetFooter() = %q", footer)
+	}
+}
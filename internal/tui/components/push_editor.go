@@ -0,0 +1,82 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/ui"
+)
+
+// PushEditor renders the APNs payload being composed for an app,
+// centered over the app list
+type PushEditor struct {
+	Width   int
+	Height  int
+	AppName string
+	Payload string
+	Sending bool
+	Keys    *config.KeysConfig
+}
+
+// NewPushEditor creates a new push payload editor renderer
+func NewPushEditor(width, height int) *PushEditor {
+	return &PushEditor{
+		Width:  width,
+		Height: height,
+	}
+}
+
+// Update updates the editor data
+func (pe *PushEditor) Update(appName, payload string, sending bool, keys *config.KeysConfig) {
+	pe.AppName = appName
+	pe.Payload = payload
+	pe.Sending = sending
+	pe.Keys = keys
+}
+
+// Render renders the editor centered in the content area. Payloads
+// taller than the panel are scrolled to keep the cursor, at the end of
+// the payload, in view.
+func (pe *PushEditor) Render() string {
+	lines := strings.Split(pe.Payload, "\n")
+	if !pe.Sending {
+		lines[len(lines)-1] += "▏"
+	}
+	// Border, padding, title and blank line take 6 lines, the content
+	// box border 2 more
+	if visible := max(pe.Height-8, 1); len(lines) > visible {
+		lines = lines[len(lines)-visible:]
+	}
+
+	var s strings.Builder
+	s.WriteString(ui.NameStyle().Render(pe.GetTitle()))
+	s.WriteString("\n\n")
+	s.WriteString(ui.NormalStyle().Render(strings.Join(lines, "\n")))
+
+	panel := ui.BorderStyle().Padding(1, 2).Render(s.String())
+	return lipgloss.Place(max(pe.Width-4, 0), max(pe.Height-2, 0), lipgloss.Center, lipgloss.Center, panel)
+}
+
+// GetTitle returns the title for the editor
+func (pe *PushEditor) GetTitle() string {
+	return fmt.Sprintf("Push Notification to %s", pe.AppName)
+}
+
+// GetFooter returns the footer shown while the editor is open
+func (pe *PushEditor) GetFooter() string {
+	if pe.Keys == nil {
+		return "ctrl+p: send • enter: new line • ESC: cancel"
+	}
+	var parts []string
+	if send := pe.Keys.FormatKeyAction("send_push_notification", "send"); send != "" {
+		parts = append(parts, send)
+	}
+	parts = append(parts, "enter: new line")
+	if esc := pe.Keys.FormatKeyAction("escape", "cancel"); esc != "" {
+		parts = append(parts, esc)
+	}
+	return strings.Join(parts, " • ")
+}
@@ -0,0 +1,39 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/config"
+)
+
+func TestPushEditorRender(t *testing.T) {
+	keys := config.DefaultKeys()
+	pe := NewPushEditor(100, 30)
+	pe.Update("AppA", "{\n  \"aps\": {}\n}", false, &keys)
+
+	got := pe.Render()
+	for _, want := range []string{"Push Notification to AppA", `"aps": {}`, "}▏"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() missing %q:\n%s", want, got)
+		}
+	}
+	if footer := pe.GetFooter(); footer != "ctrl+p: send • enter: new line • ESC: cancel" {
+		t.Errorf("GetFooter() = %q", footer)
+	}
+
+	pe.Update("AppA", "{}", true, &keys)
+	if got := pe.Render(); strings.Contains(got, "▏") {
+		t.Error("Render() should hide the cursor while sending")
+	}
+}
+
+func TestPushEditorRender_ScrollsToEnd(t *testing.T) {
+	pe := NewPushEditor(100, 12)
+	pe.Update("AppA", "first\nsecond\nthird\nfourth\nlast", false, nil)
+
+	got := pe.Render()
+	if strings.Contains(got, "first") || !strings.Contains(got, "last▏") {
+		t.Errorf("Render() should show the end of a tall payload:\n%s", got)
+	}
+}
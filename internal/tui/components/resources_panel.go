@@ -0,0 +1,83 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+	"github.com/azizuysal/simtool/internal/ui"
+)
+
+// ResourcesPanel renders a booted simulator's memory usage as a panel
+// centered over the app list
+type ResourcesPanel struct {
+	Width   int
+	Height  int
+	SimName string
+	Stats   *simulator.MemoryStats // nil until the first reading arrives
+	Err     error
+}
+
+// NewResourcesPanel creates a new resources panel renderer
+func NewResourcesPanel(width, height int) *ResourcesPanel {
+	return &ResourcesPanel{
+		Width:  width,
+		Height: height,
+	}
+}
+
+// Update updates the panel data
+func (rp *ResourcesPanel) Update(simName string, stats *simulator.MemoryStats, err error) {
+	rp.SimName = simName
+	rp.Stats = stats
+	rp.Err = err
+}
+
+// Render renders the panel centered in the content area
+func (rp *ResourcesPanel) Render() string {
+	var s strings.Builder
+	s.WriteString(ui.NameStyle().Render(rp.GetTitle()))
+	s.WriteString("\n\n")
+
+	switch {
+	case rp.Err != nil:
+		s.WriteString(ui.ErrorStyle().Render(fmt.Sprintf("Error reading memory usage: %v", rp.Err)))
+	case rp.Stats == nil:
+		s.WriteString(ui.LoadingStyle().Render("Reading memory usage..."))
+	default:
+		rows := []struct {
+			label string
+			mb    float64
+		}{
+			{"Used", rp.Stats.UsedMB},
+			{"Wired", rp.Stats.WiredMB},
+			{"Compressed", rp.Stats.CompressedMB},
+			{"Free", rp.Stats.FreeMB},
+		}
+		for i, row := range rows {
+			if i > 0 {
+				s.WriteString("\n")
+			}
+			s.WriteString(ui.DetailStyle().Render(fmt.Sprintf("%-15s", row.label)))
+			s.WriteString(ui.NormalStyle().Render(fmt.Sprintf("%.0f MB", row.mb)))
+		}
+		s.WriteString("\n\n")
+		s.WriteString(ui.DetailStyle().Render("Estimated with vm_stat, refreshed every 5s"))
+	}
+
+	panel := ui.BorderStyle().Padding(1, 2).Render(s.String())
+	// The content box border takes 2 lines
+	return lipgloss.Place(max(rp.Width-4, 0), max(rp.Height-2, 0), lipgloss.Center, lipgloss.Center, panel)
+}
+
+// GetTitle returns the title for the panel
+func (rp *ResourcesPanel) GetTitle() string {
+	return fmt.Sprintf("%s Resources", rp.SimName)
+}
+
+// GetFooter returns the footer shown while the panel is open
+func (rp *ResourcesPanel) GetFooter() string {
+	return "any key: close"
+}
@@ -0,0 +1,31 @@
+package components
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestResourcesPanelRender(t *testing.T) {
+	rp := NewResourcesPanel(80, 20)
+
+	rp.Update("iPhone 15", &simulator.MemoryStats{UsedMB: 7000, WiredMB: 2000, CompressedMB: 1000, FreeMB: 512}, nil)
+	got := rp.Render()
+	for _, want := range []string{"iPhone 15 Resources", "Used", "7000 MB", "Wired", "2000 MB", "Compressed", "1000 MB", "Free", "512 MB"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() missing %q:\n%s", want, got)
+		}
+	}
+
+	rp.Update("iPhone 15", nil, nil)
+	if got := rp.Render(); !strings.Contains(got, "Reading memory usage") {
+		t.Errorf("Render() before the first reading = %q", got)
+	}
+
+	rp.Update("iPhone 15", nil, errors.New("vm_stat failed"))
+	if got := rp.Render(); !strings.Contains(got, "vm_stat failed") {
+		t.Errorf("Render() with an error = %q", got)
+	}
+}
@@ -22,6 +22,17 @@ type SimulatorList struct {
 	SearchMode   bool
 	SearchQuery  string
 	Keys         *config.KeysConfig
+
+	// ShowSystemAppCount counts the runtime's apps next to the installed
+	// ones, e.g. "5 user + 42 system apps"
+	ShowSystemAppCount bool
+
+	// SortLabel names the order of the list, shown in the footer
+	SortLabel string
+
+	// Platform is the one platform the list is filtered to; 0 when it
+	// shows every platform
+	Platform simulator.RuntimeFilter
 }
 
 // NewSimulatorList creates a new simulator list renderer
@@ -43,6 +54,21 @@ func (sl *SimulatorList) Update(simulators []simulator.Item, cursor, viewport in
 	sl.Keys = keys
 }
 
+// SetShowSystemAppCount turns the system app count on or off
+func (sl *SimulatorList) SetShowSystemAppCount(enabled bool) {
+	sl.ShowSystemAppCount = enabled
+}
+
+// SetSortLabel sets the name of the list's sort order
+func (sl *SimulatorList) SetSortLabel(label string) {
+	sl.SortLabel = label
+}
+
+// SetPlatform sets the platform the list is filtered to
+func (sl *SimulatorList) SetPlatform(platform simulator.RuntimeFilter) {
+	sl.Platform = platform
+}
+
 // Render renders the simulator list content
 func (sl *SimulatorList) Render() string {
 	if len(sl.Simulators) == 0 {
@@ -61,15 +87,24 @@ func (sl *SimulatorList) Render() string {
 	return sl.renderList(startIdx, endIdx)
 }
 
-// GetTitle returns the title for the simulator list
-func (sl *SimulatorList) GetTitle(totalCount int) string {
-	title := fmt.Sprintf("iOS Simulators (%d", len(sl.Simulators))
-	if sl.FilterActive || sl.SearchQuery != "" {
-		title += fmt.Sprintf(" of %d)", totalCount)
-	} else {
-		title += ")"
+// GetTitle returns the title for the simulator list. totalCount and
+// runningCount count every simulator, filtered out or not; the running
+// count is only shown when some are running.
+func (sl *SimulatorList) GetTitle(totalCount, runningCount int) string {
+	title := fmt.Sprintf("Simulators (%d", len(sl.Simulators))
+	if sl.Platform != 0 {
+		title = sl.Platform.String() + " " + title
 	}
-	return title
+	switch {
+	case sl.FilterActive || sl.Platform != 0 || sl.SearchQuery != "":
+		title += fmt.Sprintf(" of %d", totalCount)
+	case runningCount > 0:
+		title += " total"
+	}
+	if runningCount > 0 {
+		title += fmt.Sprintf(", %d running", runningCount)
+	}
+	return title + ")"
 }
 
 // GetFooter returns the footer for the simulator list
@@ -119,9 +154,22 @@ func (sl *SimulatorList) GetFooter() string {
 		if boot := sl.Keys.FormatKeyAction("boot", "run"); boot != "" {
 			parts = append(parts, boot)
 		}
+		if sl.Cursor < len(sl.Simulators) && sl.Simulators[sl.Cursor].IsRunning() {
+			if shutdown := sl.Keys.FormatKeyAction("shutdown", "shut down"); shutdown != "" {
+				parts = append(parts, shutdown)
+			}
+			if logs := sl.Keys.FormatKeyAction("view_logs", "logs"); logs != "" {
+				parts = append(parts, logs)
+			}
+		}
 		if filter := sl.Keys.FormatKeyAction("filter", "filter"); filter != "" {
 			parts = append(parts, filter)
 		}
+		if sl.SortLabel != "" {
+			if sortOrder := sl.Keys.FormatKeyAction("sort", fmt.Sprintf("sort (%s)", sl.SortLabel)); sortOrder != "" {
+				parts = append(parts, sortOrder)
+			}
+		}
 		if search := sl.Keys.FormatKeyAction("search", "search"); search != "" {
 			parts = append(parts, search)
 		}
@@ -148,6 +196,8 @@ func (sl *SimulatorList) GetStatus() string {
 		return ui.SearchStyle().Render(searchStatus)
 	} else if sl.FilterActive {
 		return ui.SearchStyle().Render("Filter: Showing only simulators with apps")
+	} else if sl.Platform != 0 {
+		return ui.SearchStyle().Render(fmt.Sprintf("Filter: Showing only %s simulators", sl.Platform))
 	}
 	return ""
 }
@@ -174,19 +224,45 @@ func (sl *SimulatorList) renderList(startIdx, endIdx int) string {
 
 		// Format app count text
 		appCountText := ""
-		if sim.AppCount > 0 {
+		switch {
+		case sl.ShowSystemAppCount && sim.SystemAppCount > 0:
+			appCountText = fmt.Sprintf(" • %d user + %d system apps", sim.AppCount, sim.SystemAppCount)
+		case sim.AppCount > 0:
 			appCountText = fmt.Sprintf(" • %d app", sim.AppCount)
 			if sim.AppCount > 1 {
 				appCountText += "s"
 			}
-		} else {
+		default:
 			appCountText = " • 0 apps"
 		}
+		if len(sim.DeviceCapabilities) > 0 {
+			appCountText += " • " + strings.Join(sim.DeviceCapabilities, " • ")
+		}
+		if sim.NetworkCondition != "" {
+			appCountText += " • " + sim.NetworkCondition
+		}
+		if sim.PairedDevice != "" {
+			icon := "○"
+			if sim.IsConnected {
+				icon = "⚡"
+			}
+			appCountText += fmt.Sprintf(" • %s %s", icon, sim.PairedDevice)
+		}
+		runtime := sim.Runtime
+		if icon := sim.Platform.Icon(); icon != "" {
+			runtime = icon + " " + runtime
+		}
+		if sim.BuildVersion != "" {
+			runtime += " (" + sim.BuildVersion + ")"
+		}
+		if sim.RuntimeUnsupported {
+			runtime = "⚠ " + runtime
+		}
 
 		if i == sl.Cursor {
 			// Selected item
 			line1 := fmt.Sprintf("▶ %s", sim.Name)
-			line2 := fmt.Sprintf("  %s • %s%s", sim.Runtime, sim.StateDisplay(), appCountText)
+			line2 := fmt.Sprintf("  %s • %s%s", runtime, sim.StateDisplay(), appCountText)
 
 			// Pad to full width
 			line1 = ui.PadLine(line1, innerWidth)
@@ -208,7 +284,7 @@ func (sl *SimulatorList) renderList(startIdx, endIdx int) string {
 
 			s.WriteString(nameStyle.Render(sim.Name))
 			s.WriteString("\n")
-			s.WriteString(detailStyle.Render(sim.Runtime + " • " + sim.StateDisplay() + appCountText))
+			s.WriteString(detailStyle.Render(runtime + " • " + sim.StateDisplay() + appCountText))
 		}
 
 		if i < endIdx-1 {
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/azizuysal/simtool/internal/config"
 	"github.com/azizuysal/simtool/internal/simulator"
 )
 
@@ -90,6 +91,94 @@ func TestSimulatorListRender(t *testing.T) {
 			expected:    []string{"iPad Pro", "1 app"}, // Should be "1 app" not "1 apps"
 			notExpected: []string{"1 apps"},
 		},
+		{
+			name: "booted simulator with network override",
+			simulators: []simulator.Item{
+				{
+					Simulator: simulator.Simulator{
+						Name:  "iPhone 15",
+						State: "Booted",
+					},
+					Runtime:          "iOS 17.0",
+					AppCount:         2,
+					NetworkCondition: "WiFi: Excellent",
+				},
+			},
+			cursor:   0,
+			viewport: 0,
+			expected: []string{"2 apps • WiFi: Excellent"},
+		},
+		{
+			name: "hardware features",
+			simulators: []simulator.Item{
+				{
+					Simulator: simulator.Simulator{
+						Name:  "iPhone SE",
+						State: "Booted",
+					},
+					Runtime:            "iOS 17.0",
+					DeviceCapabilities: []string{"Touch ID", "Home Button"},
+					NetworkCondition:   "LTE",
+				},
+			},
+			cursor:   0,
+			viewport: 0,
+			expected: []string{"0 apps • Touch ID • Home Button • LTE"},
+		},
+		{
+			name: "paired simulators",
+			simulators: []simulator.Item{
+				{
+					Simulator:    simulator.Simulator{Name: "iPhone 15", State: "Booted"},
+					Runtime:      "iOS 17.0",
+					PairedDevice: "Apple Watch",
+					IsConnected:  true,
+				},
+				{
+					Simulator:    simulator.Simulator{Name: "Apple Watch", State: "Shutdown"},
+					Runtime:      "watchOS 10.0",
+					PairedDevice: "iPhone 15",
+				},
+			},
+			cursor:   0,
+			viewport: 0,
+			expected: []string{"0 apps • ⚡ Apple Watch", "0 apps • ○ iPhone 15"},
+		},
+		{
+			name: "runtime newer than Xcode",
+			simulators: []simulator.Item{
+				{
+					Simulator: simulator.Simulator{
+						UDID:  "1",
+						Name:  "iPhone 17",
+						State: "Shutdown",
+					},
+					Runtime:            "iOS 26.0",
+					RuntimeUnsupported: true,
+				},
+			},
+			cursor:   0,
+			viewport: 0,
+			expected: []string{"⚠ iOS 26.0"},
+		},
+		{
+			name: "runtime build version",
+			simulators: []simulator.Item{
+				{
+					Simulator:    simulator.Simulator{UDID: "1", Name: "iPhone 15", State: "Shutdown"},
+					Runtime:      "iOS 17.0",
+					BuildVersion: "21A329",
+				},
+				{
+					Simulator: simulator.Simulator{UDID: "2", Name: "iPhone 14", State: "Shutdown"},
+					Runtime:   "iOS 16.4",
+				},
+			},
+			cursor:      0,
+			viewport:    0,
+			expected:    []string{"iOS 17.0 (21A329) • Not Running", "iOS 16.4 • Not Running"},
+			notExpected: []string{"iOS 16.4 ("},
+		},
 	}
 
 	for _, tt := range tests {
@@ -114,6 +203,41 @@ func TestSimulatorListRender(t *testing.T) {
 	}
 }
 
+func TestSimulatorListSystemAppCount(t *testing.T) {
+	sims := []simulator.Item{
+		{Simulator: simulator.Simulator{UDID: "1", Name: "iPhone 15"}, AppCount: 5, SystemAppCount: 42},
+	}
+	sl := NewSimulatorList(100, 24)
+	sl.Update(sims, 0, 0, false, false, "", nil)
+	if got := sl.Render(); !strings.Contains(got, "• 5 apps") || strings.Contains(got, "system") {
+		t.Errorf("Render() by default should count installed apps only:\n%s", got)
+	}
+
+	sl.SetShowSystemAppCount(true)
+	if got := sl.Render(); !strings.Contains(got, "• 5 user + 42 system apps") {
+		t.Errorf("Render() missing the system app count:\n%s", got)
+	}
+}
+
+func TestSimulatorListPlatform(t *testing.T) {
+	sims := []simulator.Item{
+		{Simulator: simulator.Simulator{UDID: "1", Name: "Apple TV"}, Runtime: "tvOS 17.0", Platform: simulator.RuntimeTVOS},
+	}
+	sl := NewSimulatorList(100, 24)
+	sl.Update(sims, 0, 0, false, false, "", nil)
+	if got := sl.Render(); !strings.Contains(got, "📺 tvOS 17.0") {
+		t.Errorf("Render() missing the platform icon:\n%s", got)
+	}
+
+	sl.SetPlatform(simulator.RuntimeTVOS)
+	if got := sl.GetTitle(4, 0); got != "tvOS Simulators (1 of 4)" {
+		t.Errorf("GetTitle() = %q", got)
+	}
+	if got := sl.GetStatus(); !strings.Contains(got, "Filter: Showing only tvOS simulators") {
+		t.Errorf("GetStatus() = %q", got)
+	}
+}
+
 func TestSimulatorListGetTitle(t *testing.T) {
 	sl := NewSimulatorList(80, 24)
 
@@ -123,6 +247,7 @@ func TestSimulatorListGetTitle(t *testing.T) {
 		filterActive bool
 		searchQuery  string
 		totalCount   int
+		runningCount int
 		expected     string
 	}{
 		{
@@ -134,7 +259,7 @@ func TestSimulatorListGetTitle(t *testing.T) {
 			filterActive: false,
 			searchQuery:  "",
 			totalCount:   2,
-			expected:     "iOS Simulators (2)",
+			expected:     "Simulators (2)",
 		},
 		{
 			name: "with filter active",
@@ -144,7 +269,7 @@ func TestSimulatorListGetTitle(t *testing.T) {
 			filterActive: true,
 			searchQuery:  "",
 			totalCount:   3,
-			expected:     "iOS Simulators (1 of 3)",
+			expected:     "Simulators (1 of 3)",
 		},
 		{
 			name: "with search query",
@@ -154,14 +279,34 @@ func TestSimulatorListGetTitle(t *testing.T) {
 			filterActive: false,
 			searchQuery:  "iPhone",
 			totalCount:   5,
-			expected:     "iOS Simulators (1 of 5)",
+			expected:     "Simulators (1 of 5)",
+		},
+		{
+			name: "some running",
+			simulators: []simulator.Item{
+				{Simulator: simulator.Simulator{Name: "iPhone 15"}},
+				{Simulator: simulator.Simulator{Name: "iPhone 14"}},
+			},
+			totalCount:   2,
+			runningCount: 1,
+			expected:     "Simulators (2 total, 1 running)",
+		},
+		{
+			name: "some running with filter active",
+			simulators: []simulator.Item{
+				{Simulator: simulator.Simulator{Name: "iPhone 15"}, AppCount: 1},
+			},
+			filterActive: true,
+			totalCount:   3,
+			runningCount: 2,
+			expected:     "Simulators (1 of 3, 2 running)",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			sl.Update(tt.simulators, 0, 0, tt.filterActive, false, tt.searchQuery, nil)
-			result := sl.GetTitle(tt.totalCount)
+			result := sl.GetTitle(tt.totalCount, tt.runningCount)
 			if result != tt.expected {
 				t.Errorf("Expected title %q, got %q", tt.expected, result)
 			}
@@ -200,6 +345,35 @@ func TestSimulatorListGetFooter(t *testing.T) {
 	}
 }
 
+func TestSimulatorListGetFooter_Shutdown(t *testing.T) {
+	sl := NewSimulatorList(80, 24)
+	sims := []simulator.Item{
+		{Simulator: simulator.Simulator{Name: "iPhone 14", State: "Shutdown"}},
+		{Simulator: simulator.Simulator{Name: "iPhone 15", State: "Booted"}},
+	}
+
+	keys := config.DefaultKeys()
+
+	sl.Update(sims, 0, 0, false, false, "", &keys)
+	if footer := sl.GetFooter(); strings.Contains(footer, "shut down") {
+		t.Errorf("footer for a shut down simulator offers shut down: %q", footer)
+	}
+	sl.Update(sims, 1, 0, false, false, "", &keys)
+	if footer := sl.GetFooter(); !strings.Contains(footer, "x: shut down") || !strings.Contains(footer, "L: logs") {
+		t.Errorf("footer for a booted simulator = %q, want x: shut down and L: logs", footer)
+	}
+}
+
+func TestSimulatorListGetFooter_Sort(t *testing.T) {
+	sl := NewSimulatorList(80, 24)
+	keys := config.DefaultKeys()
+	sl.Update(nil, 0, 0, false, false, "", &keys)
+	sl.SetSortLabel("runtime")
+	if footer := sl.GetFooter(); !strings.Contains(footer, "s: sort (runtime)") {
+		t.Errorf("footer = %q, want s: sort (runtime)", footer)
+	}
+}
+
 func TestSimulatorListGetStatus(t *testing.T) {
 	sl := NewSimulatorList(80, 24)
 
@@ -0,0 +1,124 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/simulator"
+	"github.com/azizuysal/simtool/internal/ui"
+)
+
+// URLCacheList renders the responses in an app's NSURLCache
+type URLCacheList struct {
+	Width    int
+	Height   int
+	App      *simulator.App
+	Entries  []simulator.URLCacheEntry
+	Cursor   int
+	Viewport int
+	Keys     *config.KeysConfig
+}
+
+// NewURLCacheList creates a new URL cache list renderer
+func NewURLCacheList(width, height int) *URLCacheList {
+	return &URLCacheList{
+		Width:  width,
+		Height: height,
+	}
+}
+
+// Update updates the list data
+func (ul *URLCacheList) Update(app *simulator.App, entries []simulator.URLCacheEntry, cursor, viewport int, keys *config.KeysConfig) {
+	ul.App = app
+	ul.Entries = entries
+	ul.Cursor = cursor
+	ul.Viewport = viewport
+	ul.Keys = keys
+}
+
+// Render renders the URL cache list content
+func (ul *URLCacheList) Render() string {
+	if len(ul.Entries) == 0 {
+		return ui.DetailStyle().Render("No cached responses")
+	}
+
+	var s strings.Builder
+	innerWidth := ul.Width - 4 // Account for padding
+
+	startIdx := ul.Viewport
+	endIdx := min(ul.Viewport+ul.calculateItemsPerScreen(), len(ul.Entries))
+	for i := startIdx; i < endIdx; i++ {
+		entry := ul.Entries[i]
+
+		status := "status unknown"
+		if entry.StatusCode != 0 {
+			status = fmt.Sprintf("HTTP %d", entry.StatusCode)
+		}
+		detailText := fmt.Sprintf("%s • %s", status, simulator.FormatSize(entry.Size))
+		if entry.Timestamp != "" {
+			detailText = fmt.Sprintf("%s • %s", detailText, entry.Timestamp)
+		}
+
+		if i == ul.Cursor {
+			line1 := ui.PadLine(fmt.Sprintf("▶ %s", entry.URL), innerWidth)
+			line2 := ui.PadLine(fmt.Sprintf("  %s", detailText), innerWidth)
+			s.WriteString(ui.SelectedStyle().Render(line1))
+			s.WriteString("\n")
+			s.WriteString(ui.SelectedStyle().Render(line2))
+		} else {
+			s.WriteString(ui.ListItemStyle().Inherit(ui.NameStyle()).Render(entry.URL))
+			s.WriteString("\n")
+			s.WriteString(ui.ListItemStyle().Inherit(ui.DetailStyle()).Render(detailText))
+		}
+
+		if i < endIdx-1 {
+			s.WriteString("\n\n")
+		}
+	}
+
+	return s.String()
+}
+
+// GetTitle returns the title for the URL cache list
+func (ul *URLCacheList) GetTitle() string {
+	if ul.App != nil {
+		return fmt.Sprintf("%s URL Cache (%d)", ul.App.Name, len(ul.Entries))
+	}
+	return fmt.Sprintf("URL Cache (%d)", len(ul.Entries))
+}
+
+// GetFooter returns the footer for the URL cache list
+func (ul *URLCacheList) GetFooter() string {
+	scrollInfo := ui.FormatScrollInfo(ul.Viewport, ul.calculateItemsPerScreen(), len(ul.Entries))
+
+	if ul.Keys == nil {
+		return "↑/k: up • ↓/j: down • →/l: view body • ←/h: back • q: quit" + scrollInfo
+	}
+
+	var parts []string
+	if up := ul.Keys.FormatKeyAction("up", "up"); up != "" {
+		parts = append(parts, up)
+	}
+	if down := ul.Keys.FormatKeyAction("down", "down"); down != "" {
+		parts = append(parts, down)
+	}
+	if right := ul.Keys.FormatKeyAction("right", "view body"); right != "" {
+		parts = append(parts, right)
+	}
+	if left := ul.Keys.FormatKeyAction("left", "back"); left != "" {
+		parts = append(parts, left)
+	}
+	if quit := ul.Keys.FormatKeyAction("quit", "quit"); quit != "" {
+		parts = append(parts, quit)
+	}
+
+	return strings.Join(parts, " • ") + scrollInfo
+}
+
+// calculateItemsPerScreen calculates how many items fit on screen
+func (ul *URLCacheList) calculateItemsPerScreen() int {
+	// Each item takes 3 lines (URL + details + blank line)
+	availableHeight := ul.Height - 2 // Border takes 2 lines
+	return max(availableHeight/3, 1)
+}
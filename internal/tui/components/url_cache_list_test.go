@@ -0,0 +1,46 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestURLCacheListRender(t *testing.T) {
+	ul := NewURLCacheList(100, 24)
+	app := &simulator.App{Name: "Example"}
+
+	ul.Update(app, nil, 0, 0, nil)
+	if got := ul.Render(); !strings.Contains(got, "No cached responses") {
+		t.Errorf("empty Render() = %q", got)
+	}
+
+	entries := []simulator.URLCacheEntry{
+		{URL: "https://example.com/api.json", StatusCode: 200, Size: 2048, Timestamp: "2026-01-01 10:00:00"},
+		{URL: "https://example.com/big.bin", Size: 100},
+	}
+	ul.Update(app, entries, 1, 0, nil)
+	got := ul.Render()
+	for _, want := range []string{"https://example.com/api.json", "HTTP 200 • 2.0 KB • 2026-01-01 10:00:00", "▶ https://example.com/big.bin", "status unknown"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() missing %q:\n%s", want, got)
+		}
+	}
+	if title := ul.GetTitle(); title != "Example URL Cache (2)" {
+		t.Errorf("GetTitle() = %q", title)
+	}
+}
+
+func TestURLCacheListGetFooter(t *testing.T) {
+	ul := NewURLCacheList(80, 24)
+	keys := config.DefaultKeys()
+	ul.Update(nil, nil, 0, 0, &keys)
+	got := ul.GetFooter()
+	for _, want := range []string{"→/l: view body", "←/h: back"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("GetFooter() = %q, missing %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestHandleAppListKey_ViewCrashLogs(t *testing.T) {
+	sim := fakeSims()[0]
+	m := Model{
+		viewState: AppListView,
+		appList:   appListState{selectedSim: &sim, apps: fakeApps(), cursor: 1},
+		height:    30,
+	}
+	got, cmd := m.handleAppListKey("view_crash_logs")
+	gm := asModel(t, got)
+
+	if gm.viewState != CrashLogsView {
+		t.Errorf("viewState = %v, want CrashLogsView", gm.viewState)
+	}
+	if gm.crashLogs.app == nil || gm.crashLogs.app.BundleID != "com.example.b" {
+		t.Errorf("crashLogs.app = %+v, want com.example.b", gm.crashLogs.app)
+	}
+	if !gm.crashLogs.loading || cmd == nil {
+		t.Error("expected loading state and fetchCrashLogsCmd")
+	}
+}
+
+func TestHandleFetchCrashLogs(t *testing.T) {
+	app := fakeApps()[0]
+
+	t.Run("none found returns to app list", func(t *testing.T) {
+		m := Model{viewState: CrashLogsView, crashLogs: crashLogsState{app: &app, loading: true}}
+		got, _ := m.Update(fetchCrashLogsMsg{})
+		gm := asModel(t, got)
+		if gm.viewState != AppListView {
+			t.Errorf("viewState = %v, want AppListView", gm.viewState)
+		}
+		if !strings.Contains(gm.statusMessage, "No crash logs found for AppA") {
+			t.Errorf("statusMessage = %q", gm.statusMessage)
+		}
+	})
+
+	t.Run("logs are listed", func(t *testing.T) {
+		m := Model{viewState: CrashLogsView, crashLogs: crashLogsState{app: &app, loading: true}, height: 30}
+		logs := []simulator.CrashLog{{Name: "a.ips", Path: "/logs/a.ips"}}
+		got, _ := m.Update(fetchCrashLogsMsg{logs: logs})
+		gm := asModel(t, got)
+		if gm.crashLogs.loading || len(gm.crashLogs.logs) != 1 {
+			t.Errorf("crashLogs = %+v", gm.crashLogs)
+		}
+	})
+}
+
+func TestCrashLogsView_OpenAndReturn(t *testing.T) {
+	app := fakeApps()[0]
+	m := Model{
+		viewState: CrashLogsView,
+		crashLogs: crashLogsState{app: &app, logs: []simulator.CrashLog{{Name: "a.ips", Path: "/logs/a.ips"}}},
+		height:    30,
+		width:     100,
+	}
+	got, cmd := m.handleCrashLogsKey("right")
+	gm := asModel(t, got)
+	if gm.viewState != FileViewerView || gm.fileViewer.file == nil || gm.fileViewer.file.Path != "/logs/a.ips" {
+		t.Fatalf("expected file viewer on the crash log, got view %v file %+v", gm.viewState, gm.fileViewer.file)
+	}
+	if cmd == nil {
+		t.Error("expected fetchFileContentCmd")
+	}
+
+	got, _ = gm.handleFileViewerKey("left")
+	gm = asModel(t, got)
+	if gm.viewState != CrashLogsView {
+		t.Errorf("left from crash log viewer: viewState = %v, want CrashLogsView", gm.viewState)
+	}
+	if len(gm.crashLogs.logs) != 1 {
+		t.Error("crash log list should be preserved")
+	}
+}
+
+func TestCrashCountsMsg(t *testing.T) {
+	m := Model{viewState: AppListView, appList: appListState{apps: fakeApps()}}
+	got, _ := m.Update(crashCountsMsg{counts: map[string]int{"com.example.b": 3}})
+	gm := asModel(t, got)
+	if gm.appList.apps[0].CrashCount != 0 || gm.appList.apps[1].CrashCount != 3 {
+		t.Errorf("crash counts = %d, %d; want 0, 3", gm.appList.apps[0].CrashCount, gm.appList.apps[1].CrashCount)
+	}
+}
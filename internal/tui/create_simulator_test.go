@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func fakeRuntimes() []simulator.Runtime {
+	return []simulator.Runtime{
+		{Identifier: "com.apple.CoreSimulator.SimRuntime.iOS-17-0", Name: "iOS 17.0", Version: "17.0", BuildVersion: "21A329"},
+		{Identifier: "com.apple.CoreSimulator.SimRuntime.iOS-16-4", Name: "iOS 16.4", Version: "16.4", BuildVersion: "20E247"},
+	}
+}
+
+func fakeDeviceTypes() []simulator.DeviceType {
+	return []simulator.DeviceType{
+		{Identifier: "com.apple.CoreSimulator.SimDeviceType.iPhone-8", Name: "iPhone 8", ProductFamily: "iPhone", MinRuntimeVersion: 0x0b0000, MaxRuntimeVersion: 0x10ffff},
+		{Identifier: "com.apple.CoreSimulator.SimDeviceType.iPhone-15", Name: "iPhone 15", ProductFamily: "iPhone", MinRuntimeVersion: 0x110000, MaxRuntimeVersion: 0xffffffff},
+		{Identifier: "com.apple.CoreSimulator.SimDeviceType.iPad-Air-5th-generation", Name: "iPad Air (5th generation)", ProductFamily: "iPad"},
+	}
+}
+
+func TestCreateSimulatorForm(t *testing.T) {
+	m := testModelWithKeyMap()
+	m.viewState = SimulatorListView
+	m.simList.simulators = fakeSims()
+
+	press := func(key tea.KeyMsg) tea.Cmd {
+		t.Helper()
+		got, cmd := m.handleKeyPress(key)
+		m = asModel(t, got)
+		return cmd
+	}
+
+	if cmd := press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}}); cmd == nil {
+		t.Fatal("n should list the runtimes")
+	}
+	if m.viewState != CreateSimulatorView || m.createSim.step != createStepRuntime || !m.createSim.loading {
+		t.Fatalf("viewState = %v, step = %v, loading = %v; want the runtime step loading", m.viewState, m.createSim.step, m.createSim.loading)
+	}
+	m, _ = m.handleFetchRuntimes(fetchRuntimesMsg{runtimes: fakeRuntimes()})
+	if screen := m.View(); !strings.Contains(screen, "New Simulator: Runtime (2)") || !strings.Contains(screen, "Build 21A329") {
+		t.Errorf("runtime step screen:\n%s", screen)
+	}
+
+	// Only the device types that run iOS 17.0 are offered
+	if cmd := press(tea.KeyMsg{Type: tea.KeyRight}); cmd == nil {
+		t.Fatal("choosing a runtime should list the device types")
+	}
+	if m.createSim.step != createStepDeviceType || m.createSim.runtime.Name != "iOS 17.0" {
+		t.Fatalf("step = %v, runtime = %q; want the device type step for iOS 17.0", m.createSim.step, m.createSim.runtime.Name)
+	}
+	m, _ = m.handleFetchDeviceTypes(fetchDeviceTypesMsg{deviceTypes: fakeDeviceTypes()})
+	if len(m.createSim.deviceTypes) != 2 || m.createSim.deviceTypes[0].Name != "iPhone 15" {
+		t.Fatalf("device types = %+v, want iPhone 15 and the iPad", m.createSim.deviceTypes)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyDown})
+	press(tea.KeyMsg{Type: tea.KeyRight})
+	if m.createSim.step != createStepName || m.createSim.name != "iPad Air (5th generation)" {
+		t.Fatalf("step = %v, name = %q; want the name step prefilled with the device name", m.createSim.step, m.createSim.name)
+	}
+
+	// Going back keeps the device type under the cursor
+	press(tea.KeyMsg{Type: tea.KeyEscape})
+	if m.createSim.step != createStepDeviceType || m.createSim.cursor != 1 {
+		t.Fatalf("step = %v, cursor = %d; want the iPad selected in the device type step", m.createSim.step, m.createSim.cursor)
+	}
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+
+	for range len("(5th generation)") {
+		press(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("qa")}) // Action keys are typed as text
+	if !strings.Contains(m.View(), "Name: iPad Air qa▏") {
+		t.Errorf("name step screen:\n%s", m.View())
+	}
+
+	cmd := press(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || m.viewState != SimulatorListView || !m.simList.creating {
+		t.Fatalf("enter should create the simulator, got viewState %v, creating %v", m.viewState, m.simList.creating)
+	}
+	if m.statusMessage != "Creating iPad Air qa..." {
+		t.Errorf("statusMessage = %q", m.statusMessage)
+	}
+}
+
+func TestCreateSimulatorForm_Back(t *testing.T) {
+	m := testModelWithKeyMap()
+	m.viewState = CreateSimulatorView
+	m.createSim = createSimState{runtimes: fakeRuntimes(), cursor: 1}
+
+	got, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyLeft})
+	gm := asModel(t, got)
+	if gm.viewState != SimulatorListView || gm.createSim.runtimes != nil {
+		t.Errorf("left from the runtime step: viewState = %v, form = %+v; want the list and a cleared form", gm.viewState, gm.createSim)
+	}
+}
+
+func TestHandleFetchRuntimes_Empty(t *testing.T) {
+	m := Model{viewState: CreateSimulatorView, createSim: createSimState{loading: true}}
+
+	gm, _ := m.handleFetchRuntimes(fetchRuntimesMsg{})
+	if gm.viewState != SimulatorListView || gm.statusMessage != "No iOS runtimes installed" {
+		t.Errorf("viewState = %v, status = %q; want the list with a hint", gm.viewState, gm.statusMessage)
+	}
+
+	gm, _ = m.handleFetchRuntimes(fetchRuntimesMsg{err: errors.New("boom")})
+	if gm.viewState != SimulatorListView || !strings.Contains(gm.statusMessage, "boom") {
+		t.Errorf("viewState = %v, status = %q; want the list with the error", gm.viewState, gm.statusMessage)
+	}
+}
+
+func TestHandleFetchDeviceTypes_NoneSupported(t *testing.T) {
+	runtime := simulator.Runtime{Identifier: "old", Name: "iOS 9.0", Version: "9.0"}
+	m := Model{viewState: CreateSimulatorView, createSim: createSimState{
+		step:     createStepDeviceType,
+		runtimes: []simulator.Runtime{fakeRuntimes()[0], runtime},
+		runtime:  runtime,
+		loading:  true,
+	}}
+
+	gm, _ := m.handleFetchDeviceTypes(fetchDeviceTypesMsg{deviceTypes: fakeDeviceTypes()[:2]})
+	if gm.createSim.step != createStepRuntime || gm.createSim.cursor != 1 {
+		t.Errorf("step = %v, cursor = %d; want the runtime step on iOS 9.0", gm.createSim.step, gm.createSim.cursor)
+	}
+	if gm.statusMessage != "No device types run iOS 9.0" {
+		t.Errorf("statusMessage = %q", gm.statusMessage)
+	}
+}
+
+func TestHandleCreateSimulator(t *testing.T) {
+	m := Model{fetcher: &mockFetcher{}}
+	m.simList.creating = true
+
+	gm, _ := m.handleCreateSimulator(createSimulatorMsg{name: "Test", err: errors.New("boom")})
+	if gm.simList.creating || !strings.Contains(gm.statusMessage, "boom") {
+		t.Errorf("creating = %v, status = %q; want the error", gm.simList.creating, gm.statusMessage)
+	}
+
+	gm, cmd := m.handleCreateSimulator(createSimulatorMsg{name: "Test", udid: "NEW-UDID"})
+	if gm.simList.creating || gm.statusMessage != "Created Test (NEW-UDID) successfully!" {
+		t.Errorf("creating = %v, status = %q; want the new UDID", gm.simList.creating, gm.statusMessage)
+	}
+	if gm.simList.selectUDID != "NEW-UDID" || cmd == nil {
+		t.Error("expected a refresh selecting the new simulator")
+	}
+}
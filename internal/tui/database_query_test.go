@@ -0,0 +1,211 @@
+package tui
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+// queryEditorModel returns a model with the query editor open on the
+// database at path
+func queryEditorModel(t *testing.T, path string) Model {
+	t.Helper()
+	m := testModelWithKeyMap()
+	m.viewState = DatabaseTableListView
+	m.dbTables.file = &simulator.FileInfo{Name: filepath.Base(path), Path: path}
+	got, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{':'}})
+	gm := asModel(t, got)
+	if gm.viewState != DatabaseQueryView {
+		t.Fatalf("viewState = %v, want DatabaseQueryView", gm.viewState)
+	}
+	return gm
+}
+
+// typeQuery types text into the query editor, with newlines as enter
+func typeQuery(t *testing.T, m Model, text string) Model {
+	t.Helper()
+	for _, r := range text {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+		switch r {
+		case '\n':
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case ' ':
+			msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+		}
+		got, _ := m.handleKeyPress(msg)
+		m = asModel(t, got)
+	}
+	return m
+}
+
+func TestDatabaseQueryView_RunsQuery(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "app.db")
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`CREATE TABLE users (id INTEGER, name TEXT); INSERT INTO users VALUES (1, 'Ann'), (2, 'Bo')`); err != nil {
+		t.Fatal(err)
+	}
+	_ = db.Close()
+
+	// Letters bound to actions, like q and e, are typed into the query
+	m := typeQuery(t, queryEditorModel(t, dbPath), "select name\nfrom users where name = 'Bo'")
+	if want := "select name\nfrom users where name = 'Bo'"; m.dbQuery.input != want {
+		t.Fatalf("input = %q, want %q", m.dbQuery.input, want)
+	}
+
+	got, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlE})
+	m = asModel(t, got)
+	if !m.dbQuery.running || cmd == nil {
+		t.Fatalf("running = %v; want the query to run", m.dbQuery.running)
+	}
+	if _, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlE}); cmd != nil {
+		t.Error("run_query should wait for the running query")
+	}
+
+	got, _ = m.Update(cmd())
+	m = asModel(t, got)
+	if m.dbQuery.running || m.dbQuery.result == nil {
+		t.Fatalf("running = %v, result = %v; want the results", m.dbQuery.running, m.dbQuery.result)
+	}
+	if want := []map[string]any{{"name": "Bo"}}; !reflect.DeepEqual(m.dbQuery.result.Rows, want) {
+		t.Errorf("rows = %v, want %v", m.dbQuery.result.Rows, want)
+	}
+	if got := m.dbQuery.history; len(got) != 1 || got[0] != m.dbQuery.input {
+		t.Errorf("history = %q, want the query run", got)
+	}
+}
+
+func TestHandleRunQuery_Error(t *testing.T) {
+	m := Model{viewState: DatabaseQueryView}
+	m.dbQuery.running = true
+	m.dbQuery.result = &simulator.QueryResult{Columns: []string{"a"}}
+
+	gm, _ := m.handleRunQuery(runQueryMsg{err: errors.New("no such table: nope")})
+	if gm.dbQuery.running || !strings.HasPrefix(gm.statusMessage, "Error") {
+		t.Errorf("running = %v, status = %q; want an error", gm.dbQuery.running, gm.statusMessage)
+	}
+	if gm.dbQuery.result == nil {
+		t.Error("a failed query should keep the previous results")
+	}
+
+	// Results arriving after the editor closed are dropped
+	m.viewState = DatabaseTableListView
+	gm, _ = m.handleRunQuery(runQueryMsg{result: &simulator.QueryResult{}})
+	if gm.dbQuery.result != m.dbQuery.result {
+		t.Error("results should be dropped outside the query editor")
+	}
+}
+
+func TestDatabaseQueryView_Editing(t *testing.T) {
+	m := typeQuery(t, queryEditorModel(t, "/c/app.db"), "select 1\nfrom t")
+
+	press := func(keyType tea.KeyType) {
+		t.Helper()
+		got, _ := m.handleKeyPress(tea.KeyMsg{Type: keyType})
+		m = asModel(t, got)
+	}
+	press(tea.KeyHome)
+	if m.dbQuery.cursor != 9 {
+		t.Errorf("home: cursor = %d, want the start of the second line", m.dbQuery.cursor)
+	}
+	press(tea.KeyLeft)
+	press(tea.KeyBackspace)
+	if m.dbQuery.input != "select \nfrom t" || m.dbQuery.cursor != 7 {
+		t.Errorf("backspace: input = %q, cursor = %d", m.dbQuery.input, m.dbQuery.cursor)
+	}
+	m = typeQuery(t, m, "2")
+	if m.dbQuery.input != "select 2\nfrom t" {
+		t.Errorf("typing mid-query: input = %q", m.dbQuery.input)
+	}
+	press(tea.KeyEnd)
+	press(tea.KeyRight)
+	press(tea.KeyRight)
+	if m.dbQuery.cursor != 10 {
+		t.Errorf("cursor = %d, want 10", m.dbQuery.cursor)
+	}
+
+	press(tea.KeyEsc)
+	if m.viewState != DatabaseTableListView {
+		t.Errorf("escape: viewState = %v, want DatabaseTableListView", m.viewState)
+	}
+}
+
+func TestDatabaseQueryView_History(t *testing.T) {
+	m := testModelWithKeyMap()
+	for i := range queryHistorySize + 2 {
+		m.dbQuery.history = appendQueryHistory(m.dbQuery.history, fmt.Sprintf("select %d", i))
+	}
+	m.dbQuery.history = appendQueryHistory(m.dbQuery.history, "select 5")
+	if len(m.dbQuery.history) != queryHistorySize {
+		t.Fatalf("history holds %d queries, want %d", len(m.dbQuery.history), queryHistorySize)
+	}
+	if first, last := m.dbQuery.history[0], m.dbQuery.history[queryHistorySize-1]; first != "select 2" || last != "select 5" {
+		t.Errorf("history runs %q to %q; want the oldest dropped and reruns moved last", first, last)
+	}
+
+	// The history outlives the editor
+	m.viewState = DatabaseTableListView
+	m.dbTables.file = &simulator.FileInfo{Name: "app.db", Path: "/c/app.db"}
+	got, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{':'}})
+	m = asModel(t, got)
+	m = typeQuery(t, m, "draft")
+
+	press := func(keyType tea.KeyType) {
+		t.Helper()
+		got, _ := m.handleKeyPress(tea.KeyMsg{Type: keyType})
+		m = asModel(t, got)
+	}
+	press(tea.KeyUp)
+	if m.dbQuery.input != "select 5" || m.dbQuery.cursor != len("select 5") {
+		t.Errorf("up: input = %q, cursor = %d; want the last query", m.dbQuery.input, m.dbQuery.cursor)
+	}
+	press(tea.KeyUp)
+	if m.dbQuery.input != "select 11" {
+		t.Errorf("up twice: input = %q", m.dbQuery.input)
+	}
+	press(tea.KeyDown)
+	press(tea.KeyDown)
+	if m.dbQuery.input != "" {
+		t.Errorf("down past the last query: input = %q, want a new query", m.dbQuery.input)
+	}
+}
+
+func TestDatabaseQueryView_ScrollsResults(t *testing.T) {
+	m := queryEditorModel(t, "/c/app.db")
+	rows := make([]map[string]any, 25)
+	for i := range rows {
+		rows[i] = map[string]any{"a": i, "b": i}
+	}
+	m.dbQuery.result = &simulator.QueryResult{Columns: []string{"a", "b"}, Rows: rows}
+
+	press := func(keyType tea.KeyType) {
+		t.Helper()
+		got, _ := m.handleKeyPress(tea.KeyMsg{Type: keyType})
+		m = asModel(t, got)
+	}
+	press(tea.KeyPgDown)
+	press(tea.KeyPgDown)
+	press(tea.KeyPgDown)
+	if m.dbQuery.viewport != 24 {
+		t.Errorf("viewport = %d, want the last row", m.dbQuery.viewport)
+	}
+	press(tea.KeyPgUp)
+	if m.dbQuery.viewport != 14 {
+		t.Errorf("viewport = %d, want 14", m.dbQuery.viewport)
+	}
+	press(tea.KeyShiftRight)
+	press(tea.KeyShiftRight)
+	if m.dbQuery.hScroll != 1 {
+		t.Errorf("hScroll = %d, want the last column", m.dbQuery.hScroll)
+	}
+}
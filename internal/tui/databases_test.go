@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func fakeDatabases() []simulator.FileInfo {
+	return []simulator.FileInfo{
+		{Name: "Library/Model.sqlite", Path: "/path/a/Library/Model.sqlite", Size: 300},
+		{Name: "cache.db", Path: "/path/a/cache.db", Size: 100},
+	}
+}
+
+func TestHandleFetchFiles_AddsDatabasesEntry(t *testing.T) {
+	m := Model{viewState: FileListView, fileList: fileListState{basePath: "/path/a", currentPath: "/path/a"}, height: 30}
+	gm, _ := m.handleFetchFiles(fetchFilesMsg{files: fakeFiles(), databases: fakeDatabases()})
+
+	if len(gm.fileList.files) != len(fakeFiles())+1 {
+		t.Fatalf("files = %d, want %d", len(gm.fileList.files), len(fakeFiles())+1)
+	}
+	entry := gm.fileList.files[0]
+	if !isDatabasesEntry(entry) {
+		t.Errorf("first entry = %+v, want the Databases entry", entry)
+	}
+	if entry.Size != 400 {
+		t.Errorf("entry size = %d, want 400", entry.Size)
+	}
+
+	gm, _ = m.handleFetchFiles(fetchFilesMsg{files: fakeFiles()})
+	if len(gm.fileList.files) != len(fakeFiles()) {
+		t.Errorf("no databases should add no entry, got %d files", len(gm.fileList.files))
+	}
+}
+
+func TestHandleFileListKey_DatabasesEntry(t *testing.T) {
+	m := Model{viewState: FileListView, fileList: fileListState{basePath: "/path/a", currentPath: "/path/a"}, height: 30}
+	m, _ = m.handleFetchFiles(fetchFilesMsg{files: fakeFiles(), databases: fakeDatabases()})
+
+	got, cmd := m.handleFileListKey("right")
+	gm := asModel(t, got)
+	if cmd != nil {
+		t.Error("the databases listing needs no fetch")
+	}
+	if !gm.fileList.inDatabases || len(gm.fileList.files) != 2 {
+		t.Fatalf("inDatabases = %v, files = %d; want the 2 databases", gm.fileList.inDatabases, len(gm.fileList.files))
+	}
+	if strings.Join(gm.fileList.breadcrumbs, "/") != databasesEntryName {
+		t.Errorf("breadcrumbs = %v", gm.fileList.breadcrumbs)
+	}
+
+	got, cmd = gm.handleFileListKey("left")
+	gm = asModel(t, got)
+	if gm.fileList.inDatabases || len(gm.fileList.breadcrumbs) != 0 || cmd == nil {
+		t.Errorf("left should refetch the container root, got inDatabases=%v breadcrumbs=%v", gm.fileList.inDatabases, gm.fileList.breadcrumbs)
+	}
+}
+
+func TestHandleFileListKey_OpenDatabase(t *testing.T) {
+	t.Run("database opens the table list", func(t *testing.T) {
+		m := Model{viewState: FileListView, fileList: fileListState{files: fakeDatabases(), cursor: 1}, height: 30}
+		got, cmd := m.handleFileListKey("open_database")
+		gm := asModel(t, got)
+		if gm.viewState != DatabaseTableListView {
+			t.Errorf("viewState = %v, want DatabaseTableListView", gm.viewState)
+		}
+		if gm.dbTables.file == nil || gm.dbTables.file.Name != "cache.db" {
+			t.Errorf("dbTables.file = %+v", gm.dbTables.file)
+		}
+		if cmd == nil {
+			t.Error("expected fetchDatabaseInfoCmd")
+		}
+	})
+
+	t.Run("other files flash", func(t *testing.T) {
+		m := Model{viewState: FileListView, fileList: fileListState{files: fakeFiles(), cursor: 1}, height: 30}
+		got, _ := m.handleFileListKey("open_database")
+		gm := asModel(t, got)
+		if gm.viewState != FileListView {
+			t.Errorf("viewState = %v, want FileListView", gm.viewState)
+		}
+		if !strings.Contains(gm.statusMessage, "not a SQLite database") {
+			t.Errorf("statusMessage = %q", gm.statusMessage)
+		}
+	})
+}
+
+func TestHandleFetchDatabaseInfo_RealmFallsBackToViewer(t *testing.T) {
+	file := simulator.FileInfo{Name: "default.realm", Path: "/path/a/default.realm"}
+	m := Model{viewState: DatabaseTableListView, dbTables: dbTableListState{file: &file, loading: true}, height: 30}
+
+	gm, cmd := m.handleFetchDatabaseInfo(fetchDatabaseInfoMsg{err: simulator.ErrNoRealmTooling})
+	if gm.viewState != FileViewerView || cmd == nil {
+		t.Fatalf("viewState = %v, cmd nil = %v; want the file viewer loading", gm.viewState, cmd == nil)
+	}
+	if gm.fileViewer.file == nil || gm.fileViewer.file.Path != file.Path || gm.dbTables.file != nil {
+		t.Errorf("fileViewer.file = %+v, dbTables.file = %+v", gm.fileViewer.file, gm.dbTables.file)
+	}
+}
+
+func TestIsCoreDataStore(t *testing.T) {
+	app := &simulator.App{Path: "/bundle/Example.app", Container: "file:///data/Container/"}
+	store := simulator.FileInfo{Name: "Model.sqlite", Path: "/data/Container/Library/Model.sqlite"}
+	m := Model{fileList: fileListState{selectedApp: app, basePath: app.Container}}
+
+	if !m.isCoreDataStore(store) {
+		t.Error("a .sqlite file in the app container should be a Core Data store")
+	}
+	if m.isCoreDataStore(simulator.FileInfo{Name: "cache.db", Path: "/data/Container/Library/cache.db"}) {
+		t.Error("only .sqlite files should be Core Data stores")
+	}
+	if m.isCoreDataStore(simulator.FileInfo{Name: "Model.sqlite", Path: "/elsewhere/Model.sqlite"}) {
+		t.Error("files outside the app container should not be Core Data stores")
+	}
+
+	groups := m
+	groups.fileList.fromAppGroups = true
+	if groups.isCoreDataStore(store) {
+		t.Error("app group containers should not be Core Data stores")
+	}
+	noApp := Model{fileList: fileListState{basePath: app.Container}}
+	if noApp.isCoreDataStore(store) {
+		t.Error("a store without a selected app should not be a Core Data store")
+	}
+}
@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestHandleAppListKey_TestDeepLink(t *testing.T) {
+	apps := fakeApps()
+	apps[0].URLSchemes = []string{"appa", "appa-dev"}
+	sim := fakeSims()[1]
+	m := testModelWithKeyMap()
+	m.viewState = AppListView
+	m.appList = appListState{apps: apps, selectedSim: &sim}
+
+	got, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+	m = asModel(t, got)
+	if !m.appList.deepLinkMode || m.appList.deepLinkURL != "appa://" {
+		t.Fatalf("deepLinkMode = %v, deepLinkURL = %q; want the URL bar with the first scheme", m.appList.deepLinkMode, m.appList.deepLinkURL)
+	}
+	if _, _, _, status := m.renderAppListView(); !strings.Contains(status, "Open URL: appa://") {
+		t.Errorf("status = %q", status)
+	}
+
+	// Typed keys go to the URL, including ones bound to actions
+	for _, r := range "item/q" {
+		got, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = asModel(t, got)
+	}
+	got, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyBackspace})
+	m = asModel(t, got)
+	if m.appList.deepLinkURL != "appa://item/" {
+		t.Errorf("deepLinkURL = %q, want appa://item/", m.appList.deepLinkURL)
+	}
+
+	got, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = asModel(t, got)
+	if m.appList.deepLinkMode || cmd == nil {
+		t.Error("enter should close the URL bar and open the URL")
+	}
+
+	got, _ = m.Update(openURLMsg{url: "appa://item/"})
+	if m = asModel(t, got); m.statusMessage != "Opened appa://item/" {
+		t.Errorf("statusMessage = %q", m.statusMessage)
+	}
+	got, _ = m.Update(openURLMsg{url: "appa://item/", err: errors.New("simctl failed")})
+	if m = asModel(t, got); !strings.HasPrefix(m.statusMessage, "Error") {
+		t.Errorf("statusMessage = %q", m.statusMessage)
+	}
+}
+
+func TestHandleAppListKey_TestDeepLink_Refused(t *testing.T) {
+	apps := fakeApps()
+	sim := fakeSims()[1]
+	m := Model{viewState: AppListView, appList: appListState{apps: apps, selectedSim: &sim}}
+
+	got, _ := m.handleAppListKey("test_deep_link")
+	if gm := asModel(t, got); gm.appList.deepLinkMode || !strings.Contains(gm.statusMessage, "no URL schemes") {
+		t.Errorf("app without schemes: deepLinkMode = %v, status = %q", gm.appList.deepLinkMode, gm.statusMessage)
+	}
+
+	apps[0].URLSchemes = []string{"appa"}
+	shutdown := fakeSims()[0]
+	m.appList.selectedSim = &shutdown
+	got, _ = m.handleAppListKey("test_deep_link")
+	if gm := asModel(t, got); gm.appList.deepLinkMode || !strings.Contains(gm.statusMessage, "Boot the simulator") {
+		t.Errorf("shutdown simulator: deepLinkMode = %v, status = %q", gm.appList.deepLinkMode, gm.statusMessage)
+	}
+}
@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/azizuysal/simtool/internal/config"
+)
+
+func TestHandleSimulatorListKey_DeleteSimulator(t *testing.T) {
+	m := testModelWithKeyMap()
+	m.viewState = SimulatorListView
+	m.simList.simulators = fakeSims()
+	m = m.refilterSimulators()
+
+	// Unbound by default
+	got, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlX})
+	if gm := asModel(t, got); gm.viewState != SimulatorListView {
+		t.Fatalf("viewState = %v with delete_simulator unbound, want SimulatorListView", gm.viewState)
+	}
+
+	m.config.Keys.DeleteSimulator = []string{"ctrl+x"}
+	m.keyMap = config.NewKeyMap(m.config.Keys)
+
+	// Booted simulators can't be deleted
+	m.simList.cursor = 1
+	got, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlX})
+	gm := asModel(t, got)
+	if gm.viewState != SimulatorListView || !strings.Contains(gm.statusMessage, "Shut down") {
+		t.Errorf("viewState = %v, status = %q; want the shut down hint", gm.viewState, gm.statusMessage)
+	}
+
+	m.simList.cursor = 0
+	got, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlX})
+	gm = asModel(t, got)
+	if gm.viewState != ConfirmDeleteView || gm.simList.deleteTarget == nil || gm.simList.deleteTarget.UDID != "udid-14" {
+		t.Fatalf("viewState = %v, target = %+v; want the prompt for udid-14", gm.viewState, gm.simList.deleteTarget)
+	}
+	if screen := gm.View(); !strings.Contains(screen, "Delete iPhone 14?") || !strings.Contains(screen, "y: yes • n: no") {
+		t.Errorf("the view should show the delete prompt:\n%s", screen)
+	}
+}
+
+func TestHandleSimulatorDeleteConfirm(t *testing.T) {
+	sim := fakeSims()[0]
+	m := testModelWithKeyMap()
+	m.viewState = ConfirmDeleteView
+	m.simList.simulators = fakeSims()
+	m.simList.deleteTarget = &sim
+
+	// Everything but y and n is ignored, quit and navigation included
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{'q'}},
+		{Type: tea.KeyEscape},
+		{Type: tea.KeyDown},
+		{Type: tea.KeyEnter},
+	} {
+		got, cmd := m.handleKeyPress(key)
+		gm := asModel(t, got)
+		if gm.viewState != ConfirmDeleteView || gm.simList.deleteTarget == nil || cmd != nil {
+			t.Errorf("%s should be ignored, got viewState %v, cmd %v", key, gm.viewState, cmd != nil)
+		}
+	}
+
+	got, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	gm := asModel(t, got)
+	if gm.viewState != SimulatorListView || gm.simList.deleteTarget != nil || cmd != nil {
+		t.Errorf("n should cancel, got viewState %v, target %+v", gm.viewState, gm.simList.deleteTarget)
+	}
+
+	got, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	gm = asModel(t, got)
+	if gm.viewState != SimulatorListView || !gm.simList.deleting || cmd == nil {
+		t.Errorf("y should start deleting, got viewState %v, deleting %v", gm.viewState, gm.simList.deleting)
+	}
+	if gm.statusMessage != "Deleting iPhone 14..." {
+		t.Errorf("statusMessage = %q", gm.statusMessage)
+	}
+}
+
+func TestHandleDeleteSimulator(t *testing.T) {
+	m := Model{fetcher: &mockFetcher{}}
+	m.simList.deleting = true
+
+	gm, _ := m.handleDeleteSimulator(deleteSimulatorMsg{name: "iPhone 14", err: errors.New("boom")})
+	if gm.simList.deleting || !strings.Contains(gm.statusMessage, "boom") {
+		t.Errorf("deleting = %v, status = %q; want the error", gm.simList.deleting, gm.statusMessage)
+	}
+
+	gm, cmd := m.handleDeleteSimulator(deleteSimulatorMsg{name: "iPhone 14"})
+	if gm.simList.deleting || gm.statusMessage != "Deleted iPhone 14 successfully!" {
+		t.Errorf("deleting = %v, status = %q; want the success message", gm.simList.deleting, gm.statusMessage)
+	}
+	if cmd == nil {
+		t.Error("expected a simulator refresh")
+	}
+}
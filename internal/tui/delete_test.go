@@ -0,0 +1,79 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestDeletePrompt(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := testModelWithKeyMap()
+	m.viewState = FileListView
+	m.fileList = fileListState{
+		files:       []simulator.FileInfo{{Name: "notes.txt", Path: path}},
+		currentPath: dir,
+		basePath:    dir,
+	}
+
+	got, _ := m.Update(tea.KeyMsg{Type: tea.KeyDelete})
+	m = asModel(t, got)
+	if !m.fileList.confirmDelete || !strings.Contains(m.View(), "Delete notes.txt? (y/n)") {
+		t.Fatalf("confirmDelete = %v; want the prompt shown", m.fileList.confirmDelete)
+	}
+
+	// Any key but y cancels
+	got, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if gm := asModel(t, got); gm.fileList.confirmDelete || cmd != nil {
+		t.Error("n should cancel without deleting")
+	}
+
+	got, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if gm := asModel(t, got); gm.fileList.confirmDelete || cmd == nil {
+		t.Fatal("y should close the prompt and return deleteFileCmd")
+	}
+	if msg, ok := cmd().(deleteFileMsg); !ok || msg.err != nil {
+		t.Fatalf("cmd() = %#v, want a successful delete", msg)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("the file should be gone")
+	}
+}
+
+func TestHandleDeleteFile(t *testing.T) {
+	var files []simulator.FileInfo
+	for _, name := range []string{"a.txt", "b.txt", "c.txt", "d.txt"} {
+		files = append(files, simulator.FileInfo{Name: name, Path: "/path/a/" + name})
+	}
+	m := testModelWithKeyMap()
+	m.viewState = FileListView
+	m.fileList = fileListState{files: files, cursor: 2, currentPath: "/path/a", basePath: "/path/a"}
+
+	got, cmd := m.Update(deleteFileMsg{name: "c.txt", path: "/path/a/c.txt"})
+	gm := asModel(t, got)
+	if cmd == nil || !gm.fileList.loading {
+		t.Fatal("a delete should refresh the listing")
+	}
+
+	// The refreshed listing puts the cursor on the entry above
+	remaining := []simulator.FileInfo{files[0], files[1], files[3]}
+	gm, _ = gm.handleFetchFiles(fetchFilesMsg{files: remaining})
+	if gm.fileList.cursor != 1 {
+		t.Errorf("cursor = %d, want 1 on b.txt, the entry above the deleted one", gm.fileList.cursor)
+	}
+
+	got, _ = m.Update(deleteFileMsg{name: "c.txt", path: "/path/a/c.txt", err: errors.New("operation not permitted")})
+	if gm := asModel(t, got); !strings.Contains(gm.statusMessage, "Error deleting c.txt") || gm.fileList.loading {
+		t.Errorf("status = %q, want the error flashed without a refresh", gm.statusMessage)
+	}
+}
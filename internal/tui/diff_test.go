@@ -0,0 +1,159 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestHandleFileListKey_Diff(t *testing.T) {
+	files := append(fakeFiles(), simulator.FileInfo{Name: "notes.txt", Path: "/path/a/notes.txt"})
+	m := testModelWithKeyMap()
+	m.viewState = FileListView
+	m.fileList = fileListState{files: files}
+
+	// Directories can't be diffed
+	got, _ := m.handleFileListKey("diff")
+	gm := asModel(t, got)
+	if gm.diffMark != nil || !strings.Contains(gm.statusMessage, "is a directory") {
+		t.Errorf("diffMark = %v, status = %q; want the directory refused", gm.diffMark, gm.statusMessage)
+	}
+
+	// The first file is marked, and the mark shows in the status bar
+	m.fileList.cursor = 1
+	got, _ = m.handleFileListKey("diff")
+	m = asModel(t, got)
+	if m.diffMark == nil || m.diffMark.file.Name != "readme.txt" {
+		t.Fatalf("diffMark = %v, want readme.txt", m.diffMark)
+	}
+	if !strings.Contains(m.View(), "Diff: readme.txt marked") {
+		t.Error("the view should show the marked file")
+	}
+
+	// The second file opens the diff
+	m.fileList.cursor = 2
+	got, cmd := m.handleFileListKey("diff")
+	gm = asModel(t, got)
+	if gm.viewState != DiffView || !gm.diff.loading || cmd == nil {
+		t.Fatalf("viewState = %v, loading = %v; want DiffView loading the diff", gm.viewState, gm.diff.loading)
+	}
+	if gm.diff.oldFile.Name != "readme.txt" || gm.diff.newFile.Name != "notes.txt" || gm.diffMark != nil {
+		t.Errorf("diff = %s → %s, diffMark = %v", gm.diff.oldFile.Name, gm.diff.newFile.Name, gm.diffMark)
+	}
+}
+
+func TestHandleFileListKey_Diff_Unmark(t *testing.T) {
+	files := fakeFiles()
+	m := Model{viewState: FileListView, fileList: fileListState{files: files, cursor: 1}, diffMark: &diffFile{file: files[1]}}
+	got, _ := m.handleFileListKey("diff")
+	if gm := asModel(t, got); gm.diffMark != nil || gm.viewState != FileListView {
+		t.Errorf("diff on the marked file should clear the mark: diffMark = %v", gm.diffMark)
+	}
+
+	got, _ = m.handleFileListKey("escape")
+	if gm := asModel(t, got); gm.diffMark != nil {
+		t.Error("escape should clear the mark")
+	}
+}
+
+func TestHandleFileListKey_Diff_AcrossContainers(t *testing.T) {
+	sim := &simulator.Item{Simulator: simulator.Simulator{Name: "iPhone 15"}}
+	m := testModelWithKeyMap()
+	m.viewState = FileListView
+	m.appList.selectedSim = sim
+	m.fileList = fileListState{
+		selectedApp: &simulator.App{Name: "AppA"},
+		basePath:    "/data/a",
+		files:       []simulator.FileInfo{{Name: "prefs.plist", Path: "/data/a/Library/prefs.plist"}},
+	}
+	got, _ := m.handleFileListKey("diff")
+	m = asModel(t, got)
+
+	// The mark survives opening another app's container
+	m.fileList = fileListState{
+		selectedApp: &simulator.App{Name: "AppB"},
+		basePath:    "/data/b",
+		files:       []simulator.FileInfo{{Name: "prefs.plist", Path: "/data/b/Library/prefs.plist"}},
+	}
+	if m.diffMark == nil {
+		t.Fatal("diffMark should outlive the file list")
+	}
+	if view := m.View(); !strings.Contains(view, "Diff: Library/prefs.plist in AppA marked") {
+		t.Errorf("the status should say where the mark is:\n%s", view)
+	}
+
+	got, cmd := m.handleFileListKey("diff")
+	gm := asModel(t, got)
+	if gm.viewState != DiffView || cmd == nil {
+		t.Fatalf("viewState = %v; want the diff", gm.viewState)
+	}
+	if gm.diff.oldName != "Library/prefs.plist (AppA)" || gm.diff.newName != "Library/prefs.plist (AppB)" {
+		t.Errorf("names = %q → %q", gm.diff.oldName, gm.diff.newName)
+	}
+}
+
+func TestDiffNames(t *testing.T) {
+	file := func(name, relPath, app, sim string) diffFile {
+		return diffFile{file: simulator.FileInfo{Name: name}, relPath: relPath, app: app, sim: sim}
+	}
+	tests := []struct {
+		name     string
+		old, new diffFile
+		want     string
+	}{
+		{"same container", file("a.txt", "a.txt", "App", "iPhone"), file("b.txt", "Documents/b.txt", "App", "iPhone"), "a.txt → b.txt"},
+		{"same name", file("a.txt", "a.txt", "App", "iPhone"), file("a.txt", "Documents/a.txt", "App", "iPhone"), "a.txt → Documents/a.txt"},
+		{"other app", file("a.txt", "a.txt", "AppA", "iPhone"), file("b.txt", "b.txt", "AppB", "iPhone"), "a.txt (AppA) → b.txt (AppB)"},
+		{"other simulator", file("a.txt", "a.txt", "App", "iPhone"), file("a.txt", "a.txt", "App", "iPad"), "a.txt (App on iPhone) → a.txt (App on iPad)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldName, newName := diffNames(tt.old, tt.new)
+			if got := oldName + " → " + newName; got != tt.want {
+				t.Errorf("diffNames() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleFetchDiff(t *testing.T) {
+	lines := []simulator.DiffLine{{Op: simulator.DiffRemoved, Text: "old"}, {Op: simulator.DiffAdded, Text: "new"}}
+	m := Model{viewState: DiffView, diff: diffState{loading: true, oldFile: simulator.FileInfo{Name: "a"}, newFile: simulator.FileInfo{Name: "b"}}}
+	got, _ := m.Update(fetchDiffMsg{lines: lines})
+	gm := asModel(t, got)
+	if gm.diff.loading || len(gm.diff.lines) != 2 {
+		t.Errorf("loading = %v, lines = %v", gm.diff.loading, gm.diff.lines)
+	}
+
+	got, _ = m.Update(fetchDiffMsg{err: errors.New("a is not a text file")})
+	gm = asModel(t, got)
+	if gm.viewState != FileListView || !strings.Contains(gm.statusMessage, "not a text file") {
+		t.Errorf("viewState = %v, status = %q; want the file list with the error", gm.viewState, gm.statusMessage)
+	}
+}
+
+func TestHandleDiffKey(t *testing.T) {
+	lines := make([]simulator.DiffLine, 100)
+	m := Model{viewState: DiffView, height: 30, diff: diffState{lines: lines}}
+
+	got, _ := m.handleDiffKey("end")
+	m = asModel(t, got)
+	if m.diff.viewport != m.maxDiffViewport() || m.diff.viewport == 0 {
+		t.Errorf("end: viewport = %d, want %d", m.diff.viewport, m.maxDiffViewport())
+	}
+	got, _ = m.handleDiffKey("down")
+	if gm := asModel(t, got); gm.diff.viewport != m.diff.viewport {
+		t.Error("down should stop at the last line")
+	}
+	got, _ = m.handleDiffKey("up")
+	if gm := asModel(t, got); gm.diff.viewport != m.diff.viewport-1 {
+		t.Errorf("up: viewport = %d, want %d", gm.diff.viewport, m.diff.viewport-1)
+	}
+
+	got, _ = m.handleDiffKey("left")
+	if gm := asModel(t, got); gm.viewState != FileListView || gm.diff.lines != nil {
+		t.Errorf("left: viewState = %v; want the file list with the diff cleared", gm.viewState)
+	}
+}
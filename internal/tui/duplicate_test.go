@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestHandleFileListKey_DuplicateFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(src, []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	files := []simulator.FileInfo{{Name: "notes.txt", Path: src, Size: 5}}
+	m := Model{viewState: FileListView, fileList: fileListState{files: files, currentPath: dir, basePath: dir}}
+
+	_, cmd := m.handleFileListKey("duplicate_file")
+	if cmd == nil {
+		t.Fatal("duplicate_file should return duplicateFileCmd")
+	}
+	msg, ok := cmd().(duplicateFileMsg)
+	if !ok || msg.err != nil || msg.path != filepath.Join(dir, "notes_copy.txt") {
+		t.Fatalf("cmd() = %#v, want a copy at notes_copy.txt", msg)
+	}
+
+	// Nothing to copy from the databases listing
+	m.fileList.inDatabases = true
+	if _, cmd := m.handleFileListKey("duplicate_file"); cmd != nil {
+		t.Error("duplicate_file should do nothing in the databases listing")
+	}
+}
+
+func TestHandleDuplicateFile(t *testing.T) {
+	m := testModelWithKeyMap()
+	m.viewState = FileListView
+	m.fileList = fileListState{files: fakeFiles(), currentPath: "/path/a", basePath: "/path/a"}
+
+	got, cmd := m.Update(duplicateFileMsg{name: "readme.txt", path: "/path/a/readme_copy.txt"})
+	gm := asModel(t, got)
+	if cmd == nil || !gm.fileList.loading || gm.fileList.selectPath != "/path/a/readme_copy.txt" {
+		t.Errorf("loading = %v, selectPath = %q; want a refresh selecting the copy", gm.fileList.loading, gm.fileList.selectPath)
+	}
+	if !strings.Contains(gm.statusMessage, "readme_copy.txt") {
+		t.Errorf("status = %q, want the copy's name", gm.statusMessage)
+	}
+
+	// The refreshed listing puts the cursor on the copy
+	files := append(fakeFiles(), simulator.FileInfo{Name: "readme_copy.txt", Path: "/path/a/readme_copy.txt"})
+	gm, _ = gm.handleFetchFiles(fetchFilesMsg{files: files})
+	if gm.fileList.cursor != len(files)-1 || gm.fileList.selectPath != "" {
+		t.Errorf("cursor = %d, selectPath = %q; want %d on the copy", gm.fileList.cursor, gm.fileList.selectPath, len(files)-1)
+	}
+
+	got, _ = m.Update(duplicateFileMsg{name: "readme.txt", err: errors.New("disk full")})
+	if gm := asModel(t, got); !strings.Contains(gm.statusMessage, "disk full") || gm.fileList.loading {
+		t.Errorf("status = %q, want the error flashed without a refresh", gm.statusMessage)
+	}
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestEditorCommand(t *testing.T) {
+	tests := []struct {
+		editor string
+		want   []string
+	}{
+		{"vim", []string{"vim", "/tmp/notes.txt"}},
+		{"code --wait", []string{"code", "--wait", "/tmp/notes.txt"}},
+		{"", []string{"open", "-t", "/tmp/notes.txt"}},
+		{"   ", []string{"open", "-t", "/tmp/notes.txt"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.editor, func(t *testing.T) {
+			t.Setenv("EDITOR", tt.editor)
+			cmd := editorCommand("/tmp/notes.txt")
+			if strings.Join(cmd.Args, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("editorCommand() args = %q, want %q", cmd.Args, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleFileViewerKey_Editor(t *testing.T) {
+	m := testModelWithKeyMap()
+	m.viewState = FileViewerView
+
+	if _, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}}); cmd != nil {
+		t.Error("editor without a file should do nothing")
+	}
+
+	m.fileViewer.file = &simulator.FileInfo{Name: "notes.txt", Path: "/tmp/notes.txt"}
+	if _, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}}); cmd == nil {
+		t.Error("editor should open the viewed file")
+	}
+}
+
+func TestHandleOpenInEditor(t *testing.T) {
+	m := testModelWithKeyMap()
+	m.viewState = FileViewerView
+	m.fileViewer.file = &simulator.FileInfo{Name: "notes.txt", Path: "/tmp/notes.txt"}
+	m.fileViewer.content = &simulator.FileContent{Type: simulator.FileTypeText, Lines: []string{"a"}, TotalLines: 1}
+
+	gm, _ := m.handleOpenInEditor(openInEditorMsg{err: errors.New("exec: \"nvim\": executable file not found in $PATH")})
+	if !strings.Contains(gm.statusMessage, "Error opening editor") {
+		t.Errorf("status = %q, want the editor error", gm.statusMessage)
+	}
+
+	// Text files are reloaded to show the edits
+	gm, cmd := m.handleOpenInEditor(openInEditorMsg{})
+	if !gm.fileViewer.loading || cmd == nil {
+		t.Errorf("loading = %v; want the text file reloaded", gm.fileViewer.loading)
+	}
+
+	m.fileViewer.content = &simulator.FileContent{Type: simulator.FileTypeImage}
+	if gm, cmd := m.handleOpenInEditor(openInEditorMsg{}); gm.fileViewer.loading || cmd != nil {
+		t.Error("only text files should be reloaded")
+	}
+}
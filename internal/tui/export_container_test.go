@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestHandleAppListKey_ExportContainer(t *testing.T) {
+	sim := fakeSims()[0]
+	apps := fakeApps()
+	apps[0].Container = ""
+	m := testModelWithKeyMap()
+	m.viewState = AppListView
+	m.appList = appListState{selectedSim: &sim, apps: apps}
+
+	// Apps that haven't been launched have no container to export
+	got, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Z'}})
+	gm := asModel(t, got)
+	if gm.appList.exporting != "" || !strings.Contains(gm.statusMessage, "no data container") {
+		t.Errorf("exporting = %q, status = %q; want the no container hint", gm.appList.exporting, gm.statusMessage)
+	}
+
+	m.appList.cursor = 1
+	got, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Z'}})
+	gm = asModel(t, got)
+	if gm.appList.exporting != "com.example.b" || cmd == nil {
+		t.Fatalf("exporting = %q; want an export of com.example.b", gm.appList.exporting)
+	}
+	if screen := gm.View(); !strings.Contains(screen, "⠋ Exporting com.example.b container...") {
+		t.Errorf("the status should show the spinner:\n%s", screen)
+	}
+
+	// The spinner turns until the export finishes
+	got, cmd = gm.Update(exportSpinnerMsg{})
+	gm = asModel(t, got)
+	if gm.appList.exportFrame != 1 || cmd == nil {
+		t.Errorf("exportFrame = %d; want the next frame scheduled", gm.appList.exportFrame)
+	}
+	if _, cmd := gm.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Z'}}); cmd != nil {
+		t.Error("export_container should wait for the running export")
+	}
+	gm, _ = gm.handleExportContainer(exportContainerMsg{err: errors.New("zip failed")})
+	if _, cmd := gm.Update(exportSpinnerMsg{}); cmd != nil {
+		t.Error("the spinner should stop once the export is done")
+	}
+}
+
+func TestHandleExportContainer(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	m := Model{viewState: AppListView}
+	m.appList.exporting = "com.example.app"
+
+	gm, _ := m.handleExportContainer(exportContainerMsg{path: filepath.Join(home, "Desktop", "com.example.app-2026-01-02-150405.zip")})
+	if gm.appList.exporting != "" || gm.statusMessage != "Container exported to ~/Desktop/com.example.app-2026-01-02-150405.zip" {
+		t.Errorf("exporting = %q, status = %q", gm.appList.exporting, gm.statusMessage)
+	}
+
+	gm, _ = m.handleExportContainer(exportContainerMsg{err: errors.New("zip failed")})
+	if gm.appList.exporting != "" || !strings.HasPrefix(gm.statusMessage, "Error") {
+		t.Errorf("exporting = %q, status = %q; want an error", gm.appList.exporting, gm.statusMessage)
+	}
+}
+
+func TestExportContainerCmd(t *testing.T) {
+	if _, err := exec.LookPath("zip"); err != nil {
+		t.Skip("zip not found in PATH")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.Mkdir(filepath.Join(home, "Desktop"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	container := t.TempDir()
+	if err := os.WriteFile(filepath.Join(container, "notes.txt"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	msg, ok := exportContainerCmd(simulator.App{BundleID: "com.example.app", Container: container})().(exportContainerMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("exportContainerCmd() = %+v", msg)
+	}
+	if filepath.Dir(msg.path) != filepath.Join(home, "Desktop") || !strings.HasPrefix(filepath.Base(msg.path), "com.example.app-") {
+		t.Errorf("path = %s, want a com.example.app archive on the desktop", msg.path)
+	}
+	if _, err := os.Stat(msg.path); err != nil {
+		t.Errorf("archive not written: %v", err)
+	}
+}
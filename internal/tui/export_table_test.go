@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestHandleDatabaseTableContentKey_ExportTable(t *testing.T) {
+	table := simulator.TableInfo{Name: "users"}
+	m := testModelWithKeyMap()
+	m.viewState = DatabaseTableContentView
+	m.dbTables.file = &simulator.FileInfo{Name: "app.db", Path: "/c/app.db"}
+	m.dbContent = dbTableContentState{table: &table}
+
+	got, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'E'}})
+	gm := asModel(t, got)
+	if !gm.dbContent.exporting || gm.dbContent.exportRows == nil || cmd == nil {
+		t.Fatalf("exporting = %v; want an export of users", gm.dbContent.exporting)
+	}
+	if gm.statusMessage != "Exporting users..." {
+		t.Errorf("statusMessage = %q", gm.statusMessage)
+	}
+	if _, cmd := gm.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'E'}}); cmd != nil {
+		t.Error("export_table should wait for the running export")
+	}
+
+	// Progress is polled until the export finishes
+	gm.dbContent.exportRows.Store(1500)
+	got, cmd = gm.Update(exportTableProgressMsg{})
+	gm = asModel(t, got)
+	if gm.statusMessage != "Exported 1500 rows..." || cmd == nil {
+		t.Errorf("statusMessage = %q; want the progress and another poll", gm.statusMessage)
+	}
+	gm, _ = gm.handleExportTable(exportTableMsg{err: errors.New("disk full")})
+	if _, cmd := gm.Update(exportTableProgressMsg{}); cmd != nil {
+		t.Error("progress polling should stop once the export is done")
+	}
+}
+
+func TestHandleExportTable(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	m := Model{viewState: DatabaseTableContentView}
+	m.dbContent.exporting = true
+
+	gm, _ := m.handleExportTable(exportTableMsg{path: filepath.Join(home, "Desktop", "users.csv"), rowsWritten: 42})
+	if gm.dbContent.exporting || gm.statusMessage != "Exported 42 rows to ~/Desktop/users.csv" {
+		t.Errorf("exporting = %v, status = %q", gm.dbContent.exporting, gm.statusMessage)
+	}
+
+	gm, _ = m.handleExportTable(exportTableMsg{err: errors.New("no such table: users")})
+	if gm.dbContent.exporting || !strings.HasPrefix(gm.statusMessage, "Error") {
+		t.Errorf("exporting = %v, status = %q; want an error", gm.dbContent.exporting, gm.statusMessage)
+	}
+}
+
+func TestExportTableCmd(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.Mkdir(filepath.Join(home, "Desktop"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	dbPath := filepath.Join(t.TempDir(), "app.db")
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`CREATE TABLE users (id INTEGER, name TEXT); INSERT INTO users VALUES (1, 'Ann'), (2, 'Bo')`); err != nil {
+		t.Fatal(err)
+	}
+	_ = db.Close()
+
+	msg, ok := exportTableCmd(dbPath, "users", nil)().(exportTableMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("exportTableCmd() = %+v", msg)
+	}
+	if msg.rowsWritten != 2 || msg.path != filepath.Join(home, "Desktop", "users.csv") {
+		t.Errorf("exportTableCmd() = %+v", msg)
+	}
+	data, err := os.ReadFile(msg.path)
+	if err != nil || string(data) != "id,name\n1,Ann\n2,Bo\n" {
+		t.Errorf("users.csv = %q, %v", data, err)
+	}
+}
@@ -0,0 +1,125 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+// typeKeys sends each rune of text as a key press, returning the model
+// and the command of the last one
+func typeKeys(t *testing.T, m Model, text string) (Model, tea.Cmd) {
+	t.Helper()
+	var cmd tea.Cmd
+	for _, r := range text {
+		var got tea.Model
+		got, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = asModel(t, got)
+	}
+	return m, cmd
+}
+
+func TestFileSearchFlow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	var text strings.Builder
+	for i := 1; i <= 300; i++ {
+		if i%100 == 0 {
+			text.WriteString("jump here\n")
+		} else {
+			text.WriteString("filler\n")
+		}
+	}
+	if err := os.WriteFile(path, []byte(text.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := testModelWithKeyMap()
+	m.viewState = FileViewerView
+	m.fileViewer.file = &simulator.FileInfo{Name: "notes.txt", Path: path}
+	m.fileViewer.content = &simulator.FileContent{Type: simulator.FileTypeText, Lines: []string{"filler"}, TotalLines: 300}
+
+	got, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	m = asModel(t, got)
+	if m.viewState != FileSearchView {
+		t.Fatalf("view state = %v, want FileSearchView", m.viewState)
+	}
+
+	// Letters that are bound to actions go to the query
+	m, cmd := typeKeys(t, m, "jump")
+	if m.fileSearch.query != "jump" || !m.fileSearch.loading || cmd == nil {
+		t.Fatalf("query = %q, loading = %v; want a search for jump", m.fileSearch.query, m.fileSearch.loading)
+	}
+	got, _ = m.Update(cmd())
+	m = asModel(t, got)
+	if len(m.fileSearch.matches) != 3 || m.fileSearch.matches[1].LineNum != 200 {
+		t.Fatalf("matches = %v, want lines 100, 200 and 300", m.fileSearch.matches)
+	}
+	if screen := m.View(); !strings.Contains(screen, "200  jump here") || !strings.Contains(screen, "Search: jump") {
+		t.Errorf("the search view should list the matches under the query bar:\n%s", screen)
+	}
+
+	got, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyDown})
+	m = asModel(t, got)
+	got, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = asModel(t, got)
+	if m.viewState != FileViewerView || m.fileViewer.contentOffset != 199 || cmd == nil {
+		t.Fatalf("view state = %v, offset = %d; want line 200 loaded in the viewer", m.viewState, m.fileViewer.contentOffset)
+	}
+	got, _ = m.Update(cmd())
+	m = asModel(t, got)
+	if first := m.fileViewer.content.Lines[0]; first != "jump here" {
+		t.Errorf("the viewer should start at the match, got %q", first)
+	}
+}
+
+func TestHandleFileSearchInput(t *testing.T) {
+	m := testModelWithKeyMap()
+	m.viewState = FileSearchView
+	m.fileViewer.file = &simulator.FileInfo{Name: "a.txt", Path: "/tmp/a.txt"}
+	m.fileSearch = fileSearchState{query: "ab", matches: []simulator.SearchMatch{{LineNum: 1, Snippet: "ab"}}}
+
+	got, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyBackspace})
+	m = asModel(t, got)
+	if m.fileSearch.query != "a" || cmd == nil {
+		t.Errorf("query = %q; want a search for a", m.fileSearch.query)
+	}
+
+	// Results for an older query are dropped
+	got, _ = m.Update(fetchSearchResultsMsg{query: "ab", matches: []simulator.SearchMatch{{LineNum: 9}}})
+	if gm := asModel(t, got); len(gm.fileSearch.matches) != 1 || gm.fileSearch.matches[0].LineNum != 1 {
+		t.Errorf("matches = %v; stale results should be dropped", gm.fileSearch.matches)
+	}
+	got, _ = m.Update(fetchSearchResultsMsg{query: "a", err: errors.New("grep: a.txt: Permission denied")})
+	if gm := asModel(t, got); !strings.Contains(gm.statusMessage, "Error searching file") {
+		t.Errorf("status = %q, want the search error", gm.statusMessage)
+	}
+
+	got, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyBackspace})
+	m = asModel(t, got)
+	if m.fileSearch.query != "" || m.fileSearch.matches != nil || cmd != nil {
+		t.Errorf("query = %q, matches = %v; clearing the query should clear the matches", m.fileSearch.query, m.fileSearch.matches)
+	}
+
+	got, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	if gm := asModel(t, got); gm.viewState != FileViewerView {
+		t.Errorf("view state = %v, escape should go back to the viewer", gm.viewState)
+	}
+}
+
+func TestHandleFileViewerKey_SearchTextOnly(t *testing.T) {
+	m := testModelWithKeyMap()
+	m.viewState = FileViewerView
+	m.fileViewer.file = &simulator.FileInfo{Name: "a.png", Path: "/tmp/a.png"}
+	m.fileViewer.content = &simulator.FileContent{Type: simulator.FileTypeImage}
+
+	got, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	if gm := asModel(t, got); gm.viewState != FileViewerView {
+		t.Errorf("view state = %v, only text files can be searched", gm.viewState)
+	}
+}
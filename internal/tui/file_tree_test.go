@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+// fakeTree returns Documents/{Inner/,notes.txt} and readme.txt with
+// Inner left unloaded.
+func fakeTree() []simulator.TreeNode {
+	dir := func(name, path string, depth int) simulator.TreeNode {
+		return simulator.TreeNode{FileInfo: simulator.FileInfo{Name: name, Path: path, IsDirectory: true}, Depth: depth}
+	}
+	file := func(name, path string, depth int) simulator.TreeNode {
+		return simulator.TreeNode{FileInfo: simulator.FileInfo{Name: name, Path: path}, Depth: depth}
+	}
+	docs := dir("Documents", "/path/a/Documents", 0)
+	docs.Loaded = true
+	docs.Children = []simulator.TreeNode{
+		dir("Inner", "/path/a/Documents/Inner", 1),
+		file("notes.txt", "/path/a/Documents/notes.txt", 1),
+	}
+	return []simulator.TreeNode{docs, file("readme.txt", "/path/a/readme.txt", 0)}
+}
+
+func treeModel() Model {
+	m := Model{
+		viewState: FileListView,
+		height:    40,
+		width:     100,
+		fileList: fileListState{
+			files:       fakeFiles(),
+			currentPath: "/path/a",
+			basePath:    "/path/a",
+			treeView:    true,
+			tree:        fakeTree(),
+		},
+	}
+	m.fileList.treeExpanded = map[string]bool{"/path/a/Documents": true}
+	return m
+}
+
+func TestHandleFileListKey_ToggleTree(t *testing.T) {
+	m := Model{viewState: FileListView, fileList: fileListState{files: fakeFiles(), currentPath: "/path/a"}}
+	got, cmd := m.handleFileListKey("toggle_tree")
+	gm := asModel(t, got)
+	if !gm.fileList.treeView || !gm.fileList.treeLoading || cmd == nil {
+		t.Fatalf("expected tree loading to start: %+v", gm.fileList)
+	}
+
+	got, _ = gm.handleFileListKey("toggle_tree")
+	if gm = asModel(t, got); gm.fileList.treeView {
+		t.Error("second toggle should leave tree view")
+	}
+}
+
+func TestHandleFetchTree(t *testing.T) {
+	m := Model{viewState: FileListView, fileList: fileListState{currentPath: "/path/a", treeView: true, treeLoading: true}}
+	got, _ := m.Update(fetchTreeMsg{root: "/path/a", nodes: fakeTree()})
+	gm := asModel(t, got)
+	if gm.fileList.treeLoading || !gm.fileList.treeExpanded["/path/a/Documents"] {
+		t.Errorf("loaded directories should start expanded: %+v", gm.fileList.treeExpanded)
+	}
+	if gm.fileList.treeExpanded["/path/a/Documents/Inner"] {
+		t.Error("unloaded directories should start collapsed")
+	}
+
+	got, _ = m.Update(fetchTreeMsg{root: "/elsewhere", nodes: fakeTree()})
+	if gm = asModel(t, got); gm.fileList.tree != nil {
+		t.Error("a tree for another directory should be ignored")
+	}
+}
+
+func TestHandleFileTreeKey_ExpandCollapse(t *testing.T) {
+	m := treeModel()
+
+	// Collapse Documents
+	got, _ := m.handleFileListKey("left")
+	gm := asModel(t, got)
+	if gm.fileList.treeExpanded["/path/a/Documents"] {
+		t.Fatal("left on an expanded directory should collapse it")
+	}
+	if n := len(simulator.VisibleTreeNodes(gm.fileList.tree, gm.fileList.treeExpanded)); n != 2 {
+		t.Errorf("visible nodes after collapse = %d, want 2", n)
+	}
+
+	// Expand it again
+	got, _ = gm.handleFileListKey("right")
+	if gm = asModel(t, got); !gm.fileList.treeExpanded["/path/a/Documents"] {
+		t.Error("right on a collapsed directory should expand it")
+	}
+
+	// Right on the unloaded Inner reads it
+	gm.fileList.treeCursor = 1
+	_, cmd := gm.handleFileListKey("right")
+	if cmd == nil {
+		t.Error("expected fetchTreeChildrenCmd for an unloaded directory")
+	}
+
+	// Left on a nested entry moves to its parent
+	gm.fileList.treeCursor = 2
+	got, _ = gm.handleFileListKey("left")
+	if gm = asModel(t, got); gm.fileList.treeCursor != 0 {
+		t.Errorf("treeCursor = %d, want parent at 0", gm.fileList.treeCursor)
+	}
+}
+
+func TestHandleFileTreeKey_OpenFile(t *testing.T) {
+	m := treeModel()
+	m.fileList.treeCursor = 3 // readme.txt
+	got, cmd := m.handleFileListKey("right")
+	gm := asModel(t, got)
+	if gm.viewState != FileViewerView || gm.fileViewer.file.Path != "/path/a/readme.txt" || cmd == nil {
+		t.Errorf("expected file viewer for readme.txt, got view %v", gm.viewState)
+	}
+	if !gm.fileList.treeView {
+		t.Error("tree view should be kept for when the viewer is closed")
+	}
+}
+
+func TestHandleFetchTreeChildren(t *testing.T) {
+	m := treeModel()
+	inner := []simulator.TreeNode{{FileInfo: simulator.FileInfo{Name: "x.txt", Path: "/path/a/Documents/Inner/x.txt"}, Depth: 2}}
+	got, _ := m.Update(fetchTreeChildrenMsg{path: "/path/a/Documents/Inner", children: inner})
+	gm := asModel(t, got)
+	if !gm.fileList.treeExpanded["/path/a/Documents/Inner"] {
+		t.Error("a directory read on demand should be expanded")
+	}
+	if n := len(simulator.VisibleTreeNodes(gm.fileList.tree, gm.fileList.treeExpanded)); n != 5 {
+		t.Errorf("visible nodes = %d, want 5", n)
+	}
+}
+
+func TestRenderFileListView_Tree(t *testing.T) {
+	m := treeModel()
+	m.config = testModelWithKeyMap().config
+	_, content, footer, _ := m.renderFileListView()
+	for _, want := range []string{"▾ Documents/", "▸ Inner/", "notes.txt"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("tree content missing %q", want)
+		}
+	}
+	if !strings.Contains(footer, "collapse") {
+		t.Errorf("footer = %q", footer)
+	}
+}
@@ -1,7 +1,9 @@
 package tui
 
 import (
+	"errors"
 	"os"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -139,6 +141,37 @@ func TestHandleSimulatorListKey_Filter_Toggles(t *testing.T) {
 	}
 }
 
+func TestHandleSimulatorListKey_Filter_CyclesPlatforms(t *testing.T) {
+	sims := []simulator.Item{
+		{Simulator: simulator.Simulator{UDID: "1", Name: "Apple TV"}, Platform: simulator.RuntimeTVOS},
+		{Simulator: simulator.Simulator{UDID: "2", Name: "iPhone 15"}, Platform: simulator.RuntimeIOS, AppCount: 2},
+		{Simulator: simulator.Simulator{UDID: "3", Name: "iPhone 16"}, Platform: simulator.RuntimeIOS},
+	}
+	m := Model{viewState: SimulatorListView, simList: simListState{simulators: sims}, height: 30}
+
+	// watchOS and visionOS are skipped; the list has none of them
+	want := []struct {
+		withApps bool
+		platform simulator.RuntimeFilter
+		count    int
+	}{
+		{true, 0, 1},
+		{false, simulator.RuntimeIOS, 2},
+		{false, simulator.RuntimeTVOS, 1},
+		{false, 0, 3},
+	}
+	for _, w := range want {
+		got, _ := m.handleSimulatorListKey("filter")
+		m = asModel(t, got)
+		if m.simList.filterActive != w.withApps || m.simList.platform != w.platform {
+			t.Fatalf("filter = (%v, %v), want (%v, %v)", m.simList.filterActive, m.simList.platform, w.withApps, w.platform)
+		}
+		if n := len(m.getFilteredSimulators()); n != w.count {
+			t.Errorf("%v filter shows %d simulators, want %d", w.platform, n, w.count)
+		}
+	}
+}
+
 func TestHandleSimulatorListKey_Search_EntersSearchMode(t *testing.T) {
 	m := Model{
 		viewState: SimulatorListView,
@@ -208,6 +241,48 @@ func TestHandleSimulatorListKey_Boot_AlreadyRunning(t *testing.T) {
 	}
 }
 
+func TestHandleSimulatorListKey_OpenSimulatorApp(t *testing.T) {
+	sims := fakeSims()
+
+	// A booted simulator is opened right away
+	m := Model{viewState: SimulatorListView, simList: simListState{simulators: sims, cursor: 1}, height: 30}
+	got, cmd := m.handleSimulatorListKey("open_simulator_app")
+	if gm := asModel(t, got); gm.simList.booting || cmd == nil {
+		t.Errorf("booting = %v, cmd = %v; want openSimulatorAppCmd without a boot", gm.simList.booting, cmd != nil)
+	}
+
+	// A shut down simulator is booted first
+	m.simList.cursor = 0
+	got, cmd = m.handleSimulatorListKey("open_simulator_app")
+	gm := asModel(t, got)
+	if !gm.simList.booting || gm.simList.openAfterBoot != sims[0].UDID || cmd == nil {
+		t.Errorf("booting = %v, openAfterBoot = %q; want a boot of %s", gm.simList.booting, gm.simList.openAfterBoot, sims[0].UDID)
+	}
+}
+
+func TestHandleBootSimulator_OpenAfterBoot(t *testing.T) {
+	m := Model{fetcher: &mockFetcher{}, simList: simListState{booting: true, openAfterBoot: "udid-14"}}
+	got, _ := m.Update(bootSimulatorMsg{udid: "udid-14", err: errors.New("boot failed")})
+	gm := asModel(t, got)
+	if gm.simList.openAfterBoot != "" || !strings.Contains(gm.statusMessage, "boot failed") {
+		t.Errorf("openAfterBoot = %q, status = %q; a failed boot should not open the window", gm.simList.openAfterBoot, gm.statusMessage)
+	}
+
+	got, cmd := m.Update(bootSimulatorMsg{udid: "udid-14"})
+	gm = asModel(t, got)
+	if gm.simList.openAfterBoot != "" || gm.simList.booting || cmd == nil {
+		t.Errorf("openAfterBoot = %q, booting = %v after a successful boot", gm.simList.openAfterBoot, gm.simList.booting)
+	}
+}
+
+func TestOpenSimulatorAppMsg_Error(t *testing.T) {
+	m := Model{viewState: SimulatorListView}
+	got, _ := m.Update(openSimulatorAppMsg{err: errors.New("no Simulator.app")})
+	if gm := asModel(t, got); !strings.Contains(gm.statusMessage, "no Simulator.app") {
+		t.Errorf("statusMessage = %q", gm.statusMessage)
+	}
+}
+
 // ---------- handleAppListKey ----------
 
 func fakeApps() []simulator.App {
@@ -217,6 +292,46 @@ func fakeApps() []simulator.App {
 	}
 }
 
+func TestHandleAppListKey_ViewBundleResources(t *testing.T) {
+	sim := simulator.Item{Simulator: simulator.Simulator{Name: "iPhone 15", UDID: "u"}}
+	apps := fakeApps()
+	apps[1].Path = "/bundles/AppB.app"
+	m := Model{
+		viewState: AppListView,
+		appList:   appListState{selectedSim: &sim, apps: apps},
+		height:    30,
+	}
+
+	got, _ := m.handleAppListKey("view_bundle_resources")
+	if gm := asModel(t, got); gm.viewState != AppListView || !strings.Contains(gm.statusMessage, "unknown") {
+		t.Errorf("an app without a bundle path should stay in the app list, got view %v status %q", gm.viewState, gm.statusMessage)
+	}
+
+	m.appList.cursor = 1
+	got, cmd := m.handleAppListKey("view_bundle_resources")
+	m = asModel(t, got)
+	if m.viewState != FileListView || cmd == nil {
+		t.Fatalf("viewState = %v, want FileListView with a fetch", m.viewState)
+	}
+	if !m.fileList.bundleResources || !m.fileList.treeView || m.fileList.basePath != "/bundles/AppB.app" {
+		t.Errorf("fileList = %+v, want the bundle in resource tree view", m.fileList)
+	}
+
+	// The tree drops code and expands only the localizations
+	lproj := simulator.TreeNode{FileInfo: simulator.FileInfo{Name: "en.lproj", Path: "/bundles/AppB.app/en.lproj", IsDirectory: true}, Loaded: true,
+		Children: []simulator.TreeNode{{FileInfo: simulator.FileInfo{Name: "Localizable.strings", Path: "/bundles/AppB.app/en.lproj/Localizable.strings"}, Depth: 1}}}
+	frameworks := simulator.TreeNode{FileInfo: simulator.FileInfo{Name: "Frameworks", Path: "/bundles/AppB.app/Frameworks", IsDirectory: true}, Loaded: true}
+	assets := simulator.TreeNode{FileInfo: simulator.FileInfo{Name: "Assets", Path: "/bundles/AppB.app/Assets", IsDirectory: true}, Loaded: true}
+	got, _ = m.Update(fetchTreeMsg{root: "/bundles/AppB.app", nodes: []simulator.TreeNode{assets, lproj, frameworks}})
+	m = asModel(t, got)
+	if len(m.fileList.tree) != 2 {
+		t.Errorf("tree = %+v, want Frameworks left out", m.fileList.tree)
+	}
+	if !m.fileList.treeExpanded[lproj.Path] || m.fileList.treeExpanded[assets.Path] {
+		t.Errorf("treeExpanded = %v, want only en.lproj expanded", m.fileList.treeExpanded)
+	}
+}
+
 func TestHandleAppListKey_Left_ReturnsToSimList(t *testing.T) {
 	sim := simulator.Item{Simulator: simulator.Simulator{Name: "iPhone 15", UDID: "u"}}
 	m := Model{
@@ -557,6 +672,29 @@ func TestHandleFileListKey_Right_OnDatabase_OpensDatabaseView(t *testing.T) {
 	}
 }
 
+func TestHandleFileListKey_Right_OnAssetCatalog_OpensFileViewer(t *testing.T) {
+	files := []simulator.FileInfo{
+		{Name: "Assets.xcassets", Path: "/path/a/Assets.xcassets", IsDirectory: true},
+	}
+	m := Model{
+		viewState: FileListView,
+		fileList:  fileListState{files: files, currentPath: "/path/a"},
+		height:    30,
+	}
+	got, cmd := m.handleFileListKey("right")
+	gm := asModel(t, got)
+
+	if gm.viewState != FileViewerView {
+		t.Errorf("viewState = %v, want FileViewerView", gm.viewState)
+	}
+	if gm.fileList.currentPath != "/path/a" || len(gm.fileList.breadcrumbs) != 0 {
+		t.Errorf("the file list should stay at /path/a, got %q %v", gm.fileList.currentPath, gm.fileList.breadcrumbs)
+	}
+	if cmd == nil {
+		t.Error("expected fetchFileContentCmd")
+	}
+}
+
 func TestHandleFileListKey_Navigation(t *testing.T) {
 	files := fakeFiles()
 	tests := []struct {
@@ -599,6 +737,53 @@ func TestHandleFileListKey_Open_ReturnsFinderCmd(t *testing.T) {
 	}
 }
 
+func TestHandleFileListKey_SizeFilter(t *testing.T) {
+	files := []simulator.FileInfo{
+		{Name: "Library", Path: "/c/Library", IsDirectory: true},
+		{Name: "small.txt", Path: "/c/small.txt", Size: 100},
+		{Name: "big.log", Path: "/c/big.log", Size: 5000},
+	}
+	m := Model{viewState: FileListView, height: 30}
+	m = m.setFiles(files)
+	m.fileList.cursor = 2
+
+	got, _ := m.handleFileListKey("increase_size_filter")
+	m = asModel(t, got)
+	if m.fileList.minSizeFilter != 1024 || len(m.fileList.files) != 2 {
+		t.Fatalf("minSizeFilter = %d, files = %v; want 1024 hiding small.txt", m.fileList.minSizeFilter, m.fileList.files)
+	}
+	if m.fileList.cursor != 1 {
+		t.Errorf("cursor = %d, want it kept within the list", m.fileList.cursor)
+	}
+
+	// Directories are never hidden
+	for range 5 {
+		got, _ = m.handleFileListKey("increase_size_filter")
+		m = asModel(t, got)
+	}
+	if len(m.fileList.files) != 1 || m.fileList.files[0].Name != "Library" {
+		t.Errorf("files at %d bytes = %v, want only the directory", m.fileList.minSizeFilter, m.fileList.files)
+	}
+
+	got, _ = m.handleFileListKey("reset_size_filter")
+	if m = asModel(t, got); m.fileList.minSizeFilter != 0 || len(m.fileList.files) != 3 {
+		t.Errorf("after reset: minSizeFilter = %d, %d files", m.fileList.minSizeFilter, len(m.fileList.files))
+	}
+	got, _ = m.handleFileListKey("decrease_size_filter")
+	if m = asModel(t, got); m.fileList.minSizeFilter != 0 {
+		t.Errorf("minSizeFilter = %d, want it to stay at 0", m.fileList.minSizeFilter)
+	}
+
+	// Going up a directory resets the filter
+	m.fileList.minSizeFilter = 2048
+	m.fileList.basePath = "/c"
+	m.fileList.breadcrumbs = []string{"Library"}
+	got, _ = m.handleFileListKey("left")
+	if m = asModel(t, got); m.fileList.minSizeFilter != 0 {
+		t.Errorf("minSizeFilter = %d after going up, want 0", m.fileList.minSizeFilter)
+	}
+}
+
 // ---------- handleFileViewerKey ----------
 
 func TestHandleFileViewerKey_Left_ReturnsToFileList(t *testing.T) {
@@ -818,6 +1003,135 @@ func TestHandleFileViewerKey_Down_Text_LoadsNextChunk(t *testing.T) {
 	}
 }
 
+func TestHandleFileViewerKey_BinaryChunks(t *testing.T) {
+	file := simulator.FileInfo{Path: "/x.bin"}
+	m := Model{
+		viewState: FileViewerView,
+		fileViewer: fileViewerState{
+			file:            &file,
+			content:         &simulator.FileContent{Type: simulator.FileTypeBinary, BinaryData: make([]byte, 64), TotalSize: 1 << 20},
+			contentOffset:   512,
+			contentViewport: 3,
+		},
+		height: 30,
+	}
+
+	got, cmd := m.handleFileViewerKey("binary_chunks")
+	gm := asModel(t, got)
+	if gm.binaryChunkSize != 16384 {
+		t.Errorf("binaryChunkSize = %d, want 16384 after the default 8192", gm.binaryChunkSize)
+	}
+	if gm.fileViewer.contentOffset != 515 || gm.fileViewer.contentViewport != 0 || !gm.fileViewer.loading || cmd == nil {
+		t.Errorf("contentOffset = %d, contentViewport = %d, loading = %v; want a refetch from the top visible row",
+			gm.fileViewer.contentOffset, gm.fileViewer.contentViewport, gm.fileViewer.loading)
+	}
+	if gm.statusMessage != "Chunk size: 16.0 KB" {
+		t.Errorf("statusMessage = %q", gm.statusMessage)
+	}
+
+	gm.fileViewer.content.Type = simulator.FileTypeText
+	if got, cmd := gm.handleFileViewerKey("binary_chunks"); asModel(t, got).binaryChunkSize != 16384 || cmd != nil {
+		t.Error("binary_chunks should only apply to binary files")
+	}
+}
+
+func TestFileViewerClipboardText(t *testing.T) {
+	binary := make([]byte, 64)
+	tests := []struct {
+		name     string
+		content  *simulator.FileContent
+		viewport int
+		want     string
+	}{
+		{"no content", nil, 0, ""},
+		{"text chunk", &simulator.FileContent{Type: simulator.FileTypeText, Lines: []string{"first", "second"}}, 0, "first\nsecond"},
+		{
+			"binary rows in view",
+			&simulator.FileContent{Type: simulator.FileTypeBinary, BinaryData: binary, BinaryOffset: 256},
+			2,
+			strings.Join(simulator.FormatHexDump(binary, 256)[2:], "\n"),
+		},
+		{
+			"archive tree",
+			&simulator.FileContent{Type: simulator.FileTypeArchive, ArchiveInfo: &simulator.ArchiveInfo{Entries: []simulator.ArchiveEntry{{Name: "a/b.txt"}}}},
+			0,
+			"└── a/\n    └── b.txt",
+		},
+		{"image", &simulator.FileContent{Type: simulator.FileTypeImage}, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{height: 30, fileViewer: fileViewerState{content: tt.content, contentViewport: tt.viewport}}
+			got, ok := m.fileViewerClipboardText()
+			if got != tt.want || ok != (tt.want != "") {
+				t.Errorf("fileViewerClipboardText() = %q, %v; want %q", got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleFileViewerKey_CopyFileContent(t *testing.T) {
+	m := Model{viewState: FileViewerView, height: 30}
+	got, cmd := m.handleFileViewerKey("copy_file_content")
+	if gm := asModel(t, got); cmd == nil || gm.statusMessage != "Nothing to copy" {
+		t.Errorf("status = %q; want nothing to copy", gm.statusMessage)
+	}
+
+	m.fileViewer.content = &simulator.FileContent{Type: simulator.FileTypeText, Lines: []string{"hello"}}
+	if _, cmd := m.handleFileViewerKey("copy_file_content"); cmd == nil {
+		t.Error("expected copyToClipboardCmd")
+	}
+
+	got, _ = m.Update(copyToClipboardMsg{})
+	if gm := asModel(t, got); gm.statusMessage != "Content copied to clipboard" {
+		t.Errorf("statusMessage = %q", gm.statusMessage)
+	}
+	got, _ = m.Update(copyToClipboardMsg{err: errors.New("pbcopy not found")})
+	if gm := asModel(t, got); !strings.HasPrefix(gm.statusMessage, "Error copying") {
+		t.Errorf("statusMessage = %q", gm.statusMessage)
+	}
+}
+
+func TestHandleKeys_CopyPath(t *testing.T) {
+	m := Model{viewState: FileListView, height: 30}
+	if _, cmd := m.handleFileListKey("copy"); cmd != nil {
+		t.Error("copy in an empty file list should do nothing")
+	}
+	m.fileList.files = []simulator.FileInfo{databasesEntry(nil), {Name: "a.txt", Path: "/c/a.txt"}}
+	if _, cmd := m.handleFileListKey("copy"); cmd != nil {
+		t.Error("the Databases entry has no path to copy")
+	}
+	m.fileList.cursor = 1
+	if _, cmd := m.handleFileListKey("copy"); cmd == nil {
+		t.Error("expected copyPathCmd for the selected file")
+	}
+
+	apps := fakeApps()
+	apps[1].Container = ""
+	m = Model{viewState: AppListView, height: 30, appList: appListState{apps: apps}}
+	if _, cmd := m.handleAppListKey("copy"); cmd == nil {
+		t.Error("expected copyPathCmd for the app's container")
+	}
+	m.appList.cursor = 1
+	got, _ := m.handleAppListKey("copy")
+	if gm := asModel(t, got); gm.statusMessage != "AppB has no data container yet" {
+		t.Errorf("statusMessage = %q; want the no container hint", gm.statusMessage)
+	}
+
+	got, _ = m.Update(copyToClipboardMsg{path: true})
+	if gm := asModel(t, got); gm.statusMessage != "Path copied!" {
+		t.Errorf("statusMessage = %q, want Path copied!", gm.statusMessage)
+	}
+}
+
+func TestNextBinaryChunkSize(t *testing.T) {
+	for size, want := range map[int]int{4096: 8192, 8192: 16384, 16384: 65536, 65536: 4096, 1024: 4096, 32768: 65536, 131072: 4096} {
+		if got := nextBinaryChunkSize(size); got != want {
+			t.Errorf("nextBinaryChunkSize(%d) = %d, want %d", size, got, want)
+		}
+	}
+}
+
 func TestHandleFileViewerKey_Down_Binary_LoadsNextChunk(t *testing.T) {
 	// 8 bytes → 1 hex line. With height=30, itemsPerScreen-5=2, so
 	// maxViewport clamps to 0 and the advance branch is skipped.
@@ -896,6 +1210,30 @@ func TestHandleFileViewerKey_Down_Archive_AdvancesViewport(t *testing.T) {
 	}
 }
 
+func TestHandleFileViewerKey_Cookies_Scrolls(t *testing.T) {
+	cookies := make([]simulator.Cookie, 50)
+	m := Model{
+		viewState: FileViewerView,
+		fileViewer: fileViewerState{
+			content: &simulator.FileContent{
+				Type:        simulator.FileTypeBinaryCookies,
+				CookiesInfo: &simulator.CookiesInfo{Cookies: cookies},
+			},
+		},
+		height: 30,
+	}
+	got, _ := m.handleFileViewerKey("down")
+	gm := asModel(t, got)
+	if gm.fileViewer.contentViewport != 1 {
+		t.Fatalf("after down: contentViewport = %d, want 1", gm.fileViewer.contentViewport)
+	}
+	got, _ = gm.handleFileViewerKey("up")
+	gm = asModel(t, got)
+	if gm.fileViewer.contentViewport != 0 {
+		t.Errorf("after up: contentViewport = %d, want 0", gm.fileViewer.contentViewport)
+	}
+}
+
 func TestHandleFileViewerKey_Up_Text_AtTopNoOffset_NoOp(t *testing.T) {
 	m := Model{
 		viewState: FileViewerView,
@@ -918,6 +1256,107 @@ func TestHandleFileViewerKey_Up_Text_AtTopNoOffset_NoOp(t *testing.T) {
 
 // ---------- handleDatabaseTableListKey ----------
 
+func TestHandleFileViewerKey_ScrollTop(t *testing.T) {
+	file := simulator.FileInfo{Path: "/x.txt"}
+	m := Model{
+		viewState: FileViewerView,
+		fileViewer: fileViewerState{
+			file:            &file,
+			content:         &simulator.FileContent{Type: simulator.FileTypeText, Lines: make([]string, 500), TotalLines: 2000},
+			contentOffset:   1000,
+			contentViewport: 40,
+		},
+		height: 30,
+	}
+
+	got, cmd := m.handleFileViewerKey("scroll_top")
+	gm := asModel(t, got)
+	if gm.fileViewer.contentOffset != 0 || gm.fileViewer.contentViewport != 0 || !gm.fileViewer.loading || cmd == nil {
+		t.Errorf("contentOffset = %d, contentViewport = %d, loading = %v; want the first chunk fetched",
+			gm.fileViewer.contentOffset, gm.fileViewer.contentViewport, gm.fileViewer.loading)
+	}
+
+	// The first chunk is already loaded
+	m.fileViewer.contentOffset = 0
+	got, cmd = m.handleFileViewerKey("scroll_top")
+	if gm := asModel(t, got); gm.fileViewer.contentViewport != 0 || gm.fileViewer.loading || cmd != nil {
+		t.Errorf("contentViewport = %d, loading = %v; want the viewport reset without a fetch", gm.fileViewer.contentViewport, gm.fileViewer.loading)
+	}
+}
+
+func TestHandleFileViewerKey_ScrollBottom_Text(t *testing.T) {
+	// height=30 leaves 2 text lines on screen
+	file := simulator.FileInfo{Path: "/x.txt"}
+	m := Model{
+		viewState: FileViewerView,
+		fileViewer: fileViewerState{
+			file:    &file,
+			content: &simulator.FileContent{Type: simulator.FileTypeText, Lines: make([]string, 500), TotalLines: 1234},
+		},
+		height: 30,
+	}
+
+	got, cmd := m.handleFileViewerKey("scroll_bottom")
+	gm := asModel(t, got)
+	if gm.fileViewer.contentOffset != 734 || !gm.fileViewer.scrollToEnd || !gm.fileViewer.loading || cmd == nil {
+		t.Fatalf("contentOffset = %d, scrollToEnd = %v, loading = %v; want the last chunk fetched from line 734",
+			gm.fileViewer.contentOffset, gm.fileViewer.scrollToEnd, gm.fileViewer.loading)
+	}
+
+	// The last chunk arrives with the viewport on its last screenful
+	gm, _ = gm.handleFetchFileContent(fetchFileContentMsg{content: &simulator.FileContent{
+		Type: simulator.FileTypeText, Lines: make([]string, 500), TotalLines: 1234, LineOffset: 734,
+	}})
+	if gm.fileViewer.contentViewport != 498 || gm.fileViewer.scrollToEnd {
+		t.Errorf("contentViewport = %d, scrollToEnd = %v; want 498 once loaded", gm.fileViewer.contentViewport, gm.fileViewer.scrollToEnd)
+	}
+
+	// Already at the end, so only the viewport moves
+	got, cmd = gm.handleFileViewerKey("scroll_bottom")
+	if gm := asModel(t, got); gm.fileViewer.contentViewport != 498 || cmd != nil {
+		t.Errorf("contentViewport = %d; want 498 without a fetch", gm.fileViewer.contentViewport)
+	}
+}
+
+func TestHandleFileViewerKey_ScrollBottom_Binary(t *testing.T) {
+	file := simulator.FileInfo{Path: "/x.bin"}
+	m := Model{
+		viewState: FileViewerView,
+		fileViewer: fileViewerState{
+			file:    &file,
+			content: &simulator.FileContent{Type: simulator.FileTypeBinary, BinaryData: make([]byte, 8192), TotalSize: 20000},
+		},
+		height: 30,
+	}
+
+	got, cmd := m.handleFileViewerKey("scroll_bottom")
+	gm := asModel(t, got)
+	// 1250 rows in the file, 512 per default 8 KB chunk
+	if gm.fileViewer.contentOffset != 738 || !gm.fileViewer.scrollToEnd || cmd == nil {
+		t.Errorf("contentOffset = %d, scrollToEnd = %v; want the last chunk fetched from row 738",
+			gm.fileViewer.contentOffset, gm.fileViewer.scrollToEnd)
+	}
+}
+
+func TestHandleFileViewerKey_ScrollBottom_Archive(t *testing.T) {
+	m := Model{
+		viewState: FileViewerView,
+		fileViewer: fileViewerState{
+			content: &simulator.FileContent{
+				Type:        simulator.FileTypeArchive,
+				ArchiveInfo: &simulator.ArchiveInfo{Entries: make([]simulator.ArchiveEntry, 20)},
+			},
+		},
+		height: 30,
+	}
+
+	got, cmd := m.handleFileViewerKey("scroll_bottom")
+	// 4 entries fit below the header
+	if gm := asModel(t, got); gm.fileViewer.contentViewport != 16 || cmd != nil {
+		t.Errorf("contentViewport = %d; want 16 without a fetch", gm.fileViewer.contentViewport)
+	}
+}
+
 func TestHandleDatabaseTableListKey_Left_ReturnsToFileList(t *testing.T) {
 	m := Model{
 		viewState: DatabaseTableListView,
@@ -994,6 +1433,48 @@ func TestHandleDatabaseTableListKey_Navigation(t *testing.T) {
 	}
 }
 
+func TestHandleDatabaseTableListKey_Vacuum(t *testing.T) {
+	dbFile := simulator.FileInfo{Name: "app.sqlite", Path: "/x/app.sqlite", Size: 12 << 20}
+	m := testModelWithKeyMap()
+	m.viewState = DatabaseTableListView
+	m.dbTables = dbTableListState{file: &dbFile, info: &simulator.DatabaseInfo{FileSize: 12 << 20}}
+
+	got, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlV})
+	m = asModel(t, got)
+	if !m.dbTables.confirmVacuum {
+		t.Fatal("ctrl+v should ask for confirmation")
+	}
+	if _, _, _, status := m.renderDatabaseTableListView(); !strings.Contains(status, "Vacuum app.sqlite?") {
+		t.Errorf("status = %q", status)
+	}
+
+	// Any key other than y cancels
+	got, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if cancelled := asModel(t, got); cancelled.dbTables.confirmVacuum || cmd != nil {
+		t.Error("n should cancel the prompt")
+	}
+
+	got, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = asModel(t, got)
+	if !m.dbTables.vacuuming || cmd == nil {
+		t.Fatal("y should start the vacuum")
+	}
+
+	got, _ = m.Update(vacuumDatabaseMsg{before: 12 << 20, after: 9 << 20})
+	m = asModel(t, got)
+	if m.dbTables.vacuuming || m.statusMessage != "Vacuumed: 12.0 MB → 9.0 MB" {
+		t.Errorf("vacuuming = %v, statusMessage = %q", m.dbTables.vacuuming, m.statusMessage)
+	}
+	if m.dbTables.file.Size != 9<<20 || m.dbTables.info.FileSize != 9<<20 {
+		t.Errorf("sizes = %d, %d after the vacuum, want 9 MB", m.dbTables.file.Size, m.dbTables.info.FileSize)
+	}
+
+	got, _ = m.Update(vacuumDatabaseMsg{err: simulator.ErrDatabaseInUse})
+	if m = asModel(t, got); !strings.Contains(m.statusMessage, "Error vacuuming database: database is in use") {
+		t.Errorf("statusMessage = %q", m.statusMessage)
+	}
+}
+
 // ---------- handleDatabaseTableContentKey ----------
 
 func TestHandleDatabaseTableContentKey_Left_ReturnsToTableList(t *testing.T) {
@@ -1025,6 +1506,29 @@ func TestHandleDatabaseTableContentKey_Left_ReturnsToTableList(t *testing.T) {
 	}
 }
 
+func TestHandleDatabaseTableContentKey_HorizontalScroll(t *testing.T) {
+	table := simulator.TableInfo{Name: "users", Columns: []simulator.ColumnInfo{{Name: "a"}, {Name: "b"}, {Name: "c"}}}
+	m := Model{
+		viewState: DatabaseTableContentView,
+		dbContent: dbTableContentState{table: &table},
+		height:    30,
+	}
+
+	for range 3 {
+		got, _ := m.handleDatabaseTableContentKey("right")
+		m = asModel(t, got)
+	}
+	if m.dbContent.hScroll != 2 {
+		t.Errorf("right stops at the last column: hScroll = %d, want 2", m.dbContent.hScroll)
+	}
+
+	got, _ := m.handleDatabaseTableContentKey("left")
+	m = asModel(t, got)
+	if m.viewState != DatabaseTableContentView || m.dbContent.hScroll != 1 {
+		t.Errorf("left while scrolled: viewState = %v, hScroll = %d; want to stay and scroll to 1", m.viewState, m.dbContent.hScroll)
+	}
+}
+
 func TestHandleDatabaseTableContentKey_Up_Scrolls(t *testing.T) {
 	m := Model{
 		viewState: DatabaseTableContentView,
@@ -1078,6 +1582,161 @@ func TestHandleDatabaseTableContentKey_Down_LoadsMore(t *testing.T) {
 	}
 }
 
+func TestHandleDatabaseTableContentKey_Sort(t *testing.T) {
+	dbFile := simulator.FileInfo{Path: "/x.db"}
+	table := simulator.TableInfo{Name: "users", Columns: []simulator.ColumnInfo{{Name: "id"}, {Name: "name"}}}
+	m := Model{
+		viewState: DatabaseTableContentView,
+		dbTables:  dbTableListState{file: &dbFile},
+		dbContent: dbTableContentState{table: &table, offset: 50, viewport: 4},
+		height:    30,
+	}
+
+	got, cmd := m.handleDatabaseTableContentKey("sort_next_column")
+	m = asModel(t, got)
+	if m.dbContent.sortColumnName() != "id" || cmd == nil {
+		t.Errorf("sort column = %q, want id with a reload", m.dbContent.sortColumnName())
+	}
+	if m.dbContent.offset != 0 || m.dbContent.viewport != 0 || !m.dbContent.loading {
+		t.Errorf("offset = %d, viewport = %d, loading = %v; want a reload from the first row", m.dbContent.offset, m.dbContent.viewport, m.dbContent.loading)
+	}
+
+	got, _ = m.handleDatabaseTableContentKey("toggle_sort_direction")
+	m = asModel(t, got)
+	if !m.dbContent.sortDesc {
+		t.Error("toggle_sort_direction should switch to descending")
+	}
+
+	// Cycling past the last column returns to the table's own order
+	for _, action := range []string{"sort_next_column", "sort_next_column"} {
+		got, _ = m.handleDatabaseTableContentKey(action)
+		m = asModel(t, got)
+	}
+	if m.dbContent.sortColumnName() != "" {
+		t.Errorf("sort column = %q, want none after the last column", m.dbContent.sortColumnName())
+	}
+	got, _ = m.handleDatabaseTableContentKey("sort_prev_column")
+	if m = asModel(t, got); m.dbContent.sortColumnName() != "name" {
+		t.Errorf("sort_prev_column from table order = %q, want name", m.dbContent.sortColumnName())
+	}
+
+	// Entering a table resets the sort
+	m.viewState = DatabaseTableListView
+	m.dbTables.info = &simulator.DatabaseInfo{Tables: []simulator.TableInfo{table}}
+	got, _ = m.handleDatabaseTableListKey("right")
+	if m = asModel(t, got); m.dbContent.sortColumn != 0 || m.dbContent.sortDesc {
+		t.Errorf("sortColumn = %d, sortDesc = %v after entering a table", m.dbContent.sortColumn, m.dbContent.sortDesc)
+	}
+}
+
+func TestHandleDatabaseTableContentKey_ColumnPicker(t *testing.T) {
+	dbFile := simulator.FileInfo{Path: "/x.db"}
+	table := simulator.TableInfo{Name: "users", Columns: []simulator.ColumnInfo{{Name: "id"}, {Name: "name"}}}
+	m := testModelWithKeyMap()
+	m.viewState = DatabaseTableContentView
+	m.dbTables = dbTableListState{file: &dbFile}
+	m.dbContent = dbTableContentState{table: &table, hScroll: 1}
+
+	got, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlH})
+	if m = asModel(t, got); !m.dbContent.columnPicker {
+		t.Fatal("ctrl+h should open the column picker")
+	}
+	if view := m.View(); !strings.Contains(view, "[x] name") {
+		t.Errorf("View() should show the picker:\n%s", view)
+	}
+
+	// Hiding a column keeps the scroll position on a shown one
+	got, _ = m.handleDatabaseTableContentKey("open")
+	m = asModel(t, got)
+	if !m.hiddenColumns["/x.db/users"]["id"] || m.dbContent.hScroll != 0 {
+		t.Errorf("hidden = %v, hScroll = %d; want id hidden and hScroll 0", m.hiddenColumns, m.dbContent.hScroll)
+	}
+
+	// The last shown column can't be hidden
+	got, _ = m.handleDatabaseTableContentKey("down")
+	m = asModel(t, got)
+	got, _ = m.handleDatabaseTableContentKey("open")
+	if m = asModel(t, got); m.hiddenColumns["/x.db/users"]["name"] || m.statusMessage == "" {
+		t.Errorf("hiding the last column: hidden = %v, status = %q", m.hiddenColumns, m.statusMessage)
+	}
+
+	got, _ = m.handleDatabaseTableContentKey("escape")
+	if m = asModel(t, got); m.dbContent.columnPicker {
+		t.Error("escape should close the column picker")
+	}
+
+	// The hidden columns outlive the table content state
+	m.dbContent = dbTableContentState{table: &table}
+	if hidden := m.currentHiddenColumns(); !hidden["id"] {
+		t.Errorf("currentHiddenColumns() = %v after reopening the table", hidden)
+	}
+}
+
+func TestDatabaseTableContent_Search(t *testing.T) {
+	dbFile := simulator.FileInfo{Path: "/x.db"}
+	table := simulator.TableInfo{Name: "users", RowCount: 200, Columns: []simulator.ColumnInfo{{Name: "name", Type: "TEXT"}}}
+	m := testModelWithKeyMap()
+	m.viewState = DatabaseTableContentView
+	m.dbTables = dbTableListState{file: &dbFile}
+	m.dbContent = dbTableContentState{table: &table, data: []map[string]any{{"name": "Ann"}}, offset: 50}
+
+	got, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	if m = asModel(t, got); !m.dbContent.searchMode {
+		t.Fatal("/ should open the query bar")
+	}
+
+	// Every typed character re-runs the search, including ones bound to actions
+	for _, r := range "bq" {
+		var cmd tea.Cmd
+		got, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		if m = asModel(t, got); cmd == nil {
+			t.Errorf("typing %q should start a search", r)
+		}
+	}
+	if m.dbContent.searchQuery != "bq" {
+		t.Errorf("searchQuery = %q, want bq", m.dbContent.searchQuery)
+	}
+	got, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyBackspace})
+	if m = asModel(t, got); m.dbContent.searchQuery != "b" {
+		t.Errorf("searchQuery after backspace = %q, want b", m.dbContent.searchQuery)
+	}
+
+	// Results for an outdated query are dropped
+	got, _ = m.Update(tableSearchMsg{query: "bq", data: []map[string]any{{"name": "stale"}}, total: 1})
+	if m = asModel(t, got); m.dbContent.data[0]["name"] != "Ann" {
+		t.Error("results for an edited query should be ignored")
+	}
+	got, _ = m.Update(tableSearchMsg{query: "b", data: []map[string]any{{"name": "Bob"}}, total: 1})
+	if m = asModel(t, got); len(m.dbContent.data) != 1 || m.dbContent.data[0]["name"] != "Bob" || m.dbContent.offset != 0 {
+		t.Errorf("data = %v at offset %d, want the match from offset 0", m.dbContent.data, m.dbContent.offset)
+	}
+	if footer := m.View(); !strings.Contains(footer, "1 match") {
+		t.Errorf("View() should show the match count:\n%s", footer)
+	}
+
+	// Enter keeps the matches; a late page of the full table doesn't replace them
+	got, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if m = asModel(t, got); m.dbContent.searchMode || m.dbContent.searchQuery != "b" {
+		t.Errorf("enter should close the query bar keeping the query, got mode %v query %q", m.dbContent.searchMode, m.dbContent.searchQuery)
+	}
+	got, _ = m.Update(fetchTableDataMsg{data: []map[string]any{{"name": "page"}}, offset: 100})
+	if m = asModel(t, got); m.dbContent.data[0]["name"] != "Bob" {
+		t.Error("a table page should not replace search results")
+	}
+
+	// Scrolling down doesn't page in the rest of the table
+	got, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyDown})
+	if m = asModel(t, got); cmd != nil {
+		t.Error("down past the matches should not load another page")
+	}
+
+	// Escape clears the search and reloads the table
+	got, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEscape})
+	if m = asModel(t, got); m.dbContent.searchQuery != "" || cmd == nil || !m.dbContent.loading {
+		t.Errorf("escape should clear the query and reload, got query %q", m.dbContent.searchQuery)
+	}
+}
+
 // ---------- handleKeyPress dispatcher ----------
 
 func testModelWithKeyMap() Model {
@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+// browser drives a Model through Update the way the Bubble Tea runtime
+// would, one message at a time, running the fetch commands that key
+// presses return
+type browser struct {
+	t *testing.T
+	m Model
+}
+
+// send delivers msg and returns the command it produced
+func (b *browser) send(msg tea.Msg) tea.Cmd {
+	b.t.Helper()
+	got, cmd := b.m.Update(msg)
+	b.m = asModel(b.t, got)
+	return cmd
+}
+
+// press sends a key press. If it starts a fetch, the fetch is run and
+// its result delivered, as the runtime would once the command returns.
+func (b *browser) press(key tea.KeyType) {
+	b.t.Helper()
+	cmd := b.send(tea.KeyMsg{Type: key})
+	if cmd == nil {
+		return
+	}
+	switch msg := cmd().(type) {
+	case fetchFilesMsg, fetchFileContentMsg:
+		b.send(msg)
+	}
+}
+
+// expect fails the test unless the model is in view state want and its
+// rendered screen contains every one of texts
+func (b *browser) expect(step string, want ViewState, texts ...string) {
+	b.t.Helper()
+	if b.m.viewState != want {
+		b.t.Fatalf("%s: view state = %v, want %v", step, b.m.viewState, want)
+	}
+	screen := b.m.View()
+	for _, text := range texts {
+		if !strings.Contains(screen, text) {
+			b.t.Errorf("%s: screen doesn't show %q:\n%s", step, text, screen)
+		}
+	}
+}
+
+func TestFileBrowsingFlow(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	container := t.TempDir()
+	docs := filepath.Join(container, "Documents")
+	if err := os.Mkdir(docs, 0755); err != nil {
+		t.Fatal(err)
+	}
+	var notes strings.Builder
+	for i := 1; i <= 100; i++ {
+		fmt.Fprintf(&notes, "note %03d\n", i)
+	}
+	if err := os.WriteFile(filepath.Join(docs, "notes.txt"), []byte(notes.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sim := simulator.Item{
+		Simulator: simulator.Simulator{Name: "iPhone 15", UDID: "udid-15", State: "Shutdown"},
+		Runtime:   "iOS 17.0",
+		AppCount:  1,
+	}
+	b := &browser{t: t, m: New(&mockFetcher{items: []simulator.Item{sim}}, false)}
+	b.send(tea.WindowSizeMsg{Width: 100, Height: 40})
+	b.send(fetchSimulatorsCmd(b.m.fetcher)())
+	b.expect("start", SimulatorListView, "iPhone 15")
+
+	// Right enters the simulator. Its app list comes from simctl and
+	// plutil, so the fetch is replaced by an app in the temp container.
+	if cmd := b.send(tea.KeyMsg{Type: tea.KeyRight}); cmd == nil {
+		t.Fatal("right on a simulator should fetch its apps")
+	}
+	b.send(fetchAppsMsg{apps: []simulator.App{{Name: "Notes", BundleID: "com.example.notes", Container: container}}})
+	b.expect("enter simulator", AppListView, "Notes", "com.example.notes")
+
+	b.press(tea.KeyRight)
+	b.expect("enter app", FileListView, "Documents")
+
+	b.press(tea.KeyRight)
+	b.expect("enter Documents", FileListView, "notes.txt")
+
+	b.press(tea.KeyRight)
+	b.expect("open notes.txt", FileViewerView, "note 001", "note 002")
+
+	for range 3 {
+		b.press(tea.KeyDown)
+	}
+	b.expect("scroll down", FileViewerView, "note 004")
+	if screen := b.m.View(); strings.Contains(screen, "note 003") {
+		t.Errorf("scroll down: note 003 should have scrolled off:\n%s", screen)
+	}
+	b.press(tea.KeyUp)
+	b.expect("scroll up", FileViewerView, "note 003")
+
+	b.press(tea.KeyLeft)
+	b.expect("back", FileListView, "notes.txt")
+	if b.m.fileList.currentPath != docs {
+		t.Errorf("back: current path = %s, want %s", b.m.fileList.currentPath, docs)
+	}
+}
@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestHandleAppListKey_ViewKeychain(t *testing.T) {
+	sim := fakeSims()[1]
+	m := Model{
+		viewState: AppListView,
+		appList:   appListState{selectedSim: &sim, apps: fakeApps(), cursor: 0},
+		height:    30,
+	}
+	got, cmd := m.handleAppListKey("view_keychain")
+	gm := asModel(t, got)
+
+	if gm.viewState != KeychainView {
+		t.Errorf("viewState = %v, want KeychainView", gm.viewState)
+	}
+	if gm.keychain.app == nil || gm.keychain.app.BundleID != "com.example.a" {
+		t.Errorf("keychain.app = %+v, want com.example.a", gm.keychain.app)
+	}
+	if !gm.keychain.loading || cmd == nil {
+		t.Error("expected loading state and fetchKeychainCmd")
+	}
+}
+
+func TestHandleFetchKeychain(t *testing.T) {
+	app := fakeApps()[0]
+
+	tests := []struct {
+		name       string
+		msg        fetchKeychainMsg
+		wantStatus string
+	}{
+		{"no keychain", fetchKeychainMsg{err: simulator.ErrNoKeychain}, "No keychain found"},
+		{"dump error", fetchKeychainMsg{err: errors.New("boom")}, "Error reading keychain: boom"},
+		{"no items", fetchKeychainMsg{}, "No keychain items for AppA"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{viewState: KeychainView, keychain: keychainState{app: &app, loading: true}}
+			got, _ := m.Update(tt.msg)
+			gm := asModel(t, got)
+			if gm.viewState != AppListView {
+				t.Errorf("viewState = %v, want AppListView", gm.viewState)
+			}
+			if !strings.Contains(gm.statusMessage, tt.wantStatus) {
+				t.Errorf("statusMessage = %q, want %q", gm.statusMessage, tt.wantStatus)
+			}
+		})
+	}
+
+	t.Run("items are shown", func(t *testing.T) {
+		m := Model{viewState: KeychainView, keychain: keychainState{app: &app, loading: true}}
+		got, _ := m.Update(fetchKeychainMsg{items: []simulator.KeychainItem{{Class: "generic password"}}})
+		gm := asModel(t, got)
+		if gm.keychain.loading || len(gm.keychain.items) != 1 {
+			t.Errorf("keychain = %+v", gm.keychain)
+		}
+	})
+}
+
+func TestHandleKeychainKey(t *testing.T) {
+	app := fakeApps()[0]
+	m := Model{
+		viewState: KeychainView,
+		keychain:  keychainState{app: &app, items: make([]simulator.KeychainItem, 50)},
+		height:    30,
+	}
+
+	got, _ := m.handleKeychainKey("down")
+	gm := asModel(t, got)
+	if gm.keychain.viewport != 1 {
+		t.Errorf("down: viewport = %d, want 1", gm.keychain.viewport)
+	}
+
+	got, _ = gm.handleKeychainKey("right")
+	gm = asModel(t, got)
+	if gm.keychain.hScroll != 1 {
+		t.Errorf("right: hScroll = %d, want 1", gm.keychain.hScroll)
+	}
+
+	// Left scrolls the columns back before leaving the view
+	got, _ = gm.handleKeychainKey("left")
+	gm = asModel(t, got)
+	if gm.viewState != KeychainView || gm.keychain.hScroll != 0 {
+		t.Errorf("first left: view %v hScroll %d, want KeychainView 0", gm.viewState, gm.keychain.hScroll)
+	}
+	got, _ = gm.handleKeychainKey("left")
+	if gm = asModel(t, got); gm.viewState != AppListView {
+		t.Errorf("second left: viewState = %v, want AppListView", gm.viewState)
+	}
+}
+
+func TestRenderKeychainView(t *testing.T) {
+	app := fakeApps()[0]
+	m := testModelWithKeyMap()
+	m.width = 120
+	m.viewState = KeychainView
+	m.keychain = keychainState{
+		app: &app,
+		items: []simulator.KeychainItem{{
+			Class:   "generic password",
+			Service: "com.example.a.auth",
+			Account: "user@example.com",
+			Created: time.Date(2024, 3, 4, 5, 6, 0, 0, time.UTC),
+		}},
+	}
+
+	out := m.View()
+	for _, want := range []string{"AppA Keychain", "Class", "Service", "generic password", "user@example.com", "2024-03-04 05:06"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("View() missing %q", want)
+		}
+	}
+
+	m.keychain = keychainState{app: &app, loading: true}
+	if out := m.View(); !strings.Contains(out, "Dumping keychain...") {
+		t.Error("loading view should show the dumping status")
+	}
+}
@@ -0,0 +1,230 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestHandleAppListKey_ViewLogs_BootedStartsStream(t *testing.T) {
+	sim := fakeSims()[1] // Booted
+	m := Model{
+		viewState: AppListView,
+		appList:   appListState{selectedSim: &sim, apps: fakeApps(), cursor: 1},
+		height:    30,
+	}
+	got, cmd := m.handleAppListKey("view_logs")
+	gm := asModel(t, got)
+
+	if gm.viewState != LogStreamView {
+		t.Errorf("viewState = %v, want LogStreamView", gm.viewState)
+	}
+	if gm.logStream.app == nil || gm.logStream.app.BundleID != "com.example.b" {
+		t.Errorf("logStream.app = %+v, want com.example.b", gm.logStream.app)
+	}
+	if !gm.logStream.following {
+		t.Error("a new stream should follow the newest line")
+	}
+	if cmd == nil {
+		t.Error("expected startLogStreamCmd")
+	}
+}
+
+func TestHandleAppListKey_ViewLogs_ShutdownFlashes(t *testing.T) {
+	sim := fakeSims()[0] // Shutdown
+	m := Model{
+		viewState: AppListView,
+		appList:   appListState{selectedSim: &sim, apps: fakeApps()},
+		height:    30,
+	}
+	got, cmd := m.handleAppListKey("view_logs")
+	gm := asModel(t, got)
+
+	if gm.viewState != AppListView {
+		t.Errorf("viewState = %v, want AppListView", gm.viewState)
+	}
+	if !strings.Contains(gm.statusMessage, "Boot the simulator") {
+		t.Errorf("statusMessage = %q, want boot hint", gm.statusMessage)
+	}
+	if cmd == nil {
+		t.Error("expected status clear cmd")
+	}
+}
+
+func TestHandleSimulatorListKey_ViewLogs(t *testing.T) {
+	m := Model{viewState: SimulatorListView, simList: simListState{simulators: fakeSims()}, height: 30}
+
+	got, _ := m.handleSimulatorListKey("view_logs")
+	gm := asModel(t, got)
+	if gm.viewState != SimulatorListView || !strings.Contains(gm.statusMessage, "Boot the simulator") {
+		t.Errorf("shut down simulator: viewState = %v, statusMessage = %q", gm.viewState, gm.statusMessage)
+	}
+
+	m.simList.cursor = 1 // Booted
+	got, cmd := m.handleSimulatorListKey("view_logs")
+	gm = asModel(t, got)
+	if gm.viewState != LogStreamView {
+		t.Errorf("viewState = %v, want LogStreamView", gm.viewState)
+	}
+	if gm.logStream.sim == nil || gm.logStream.sim.UDID != "udid-15" || gm.logStream.app != nil {
+		t.Errorf("logStream = %+v, want the whole of udid-15", gm.logStream)
+	}
+	if cmd == nil {
+		t.Error("expected startSimulatorLogStreamCmd")
+	}
+
+	// Stopping returns to the simulator list
+	gm = gm.stopLogStream()
+	if gm.viewState != SimulatorListView {
+		t.Errorf("after stop viewState = %v, want SimulatorListView", gm.viewState)
+	}
+}
+
+func TestHandleLogStreamStarted(t *testing.T) {
+	t.Run("error returns to app list", func(t *testing.T) {
+		m := Model{viewState: LogStreamView, logStream: logStreamState{following: true}}
+		got, _ := m.Update(logStreamStartedMsg{err: errors.New("no xcrun")})
+		gm := asModel(t, got)
+		if gm.viewState != AppListView {
+			t.Errorf("viewState = %v, want AppListView", gm.viewState)
+		}
+		if !strings.Contains(gm.statusMessage, "Error starting log stream") {
+			t.Errorf("statusMessage = %q", gm.statusMessage)
+		}
+	})
+
+	t.Run("success begins reading", func(t *testing.T) {
+		stream := &simulator.LogStream{}
+		m := Model{viewState: LogStreamView}
+		got, cmd := m.Update(logStreamStartedMsg{stream: stream})
+		gm := asModel(t, got)
+		if gm.logStream.stream != stream {
+			t.Error("stream not recorded")
+		}
+		if cmd == nil {
+			t.Error("expected waitForLogLineCmd")
+		}
+	})
+}
+
+func TestHandleLogLine(t *testing.T) {
+	stream := &simulator.LogStream{}
+
+	t.Run("following pins viewport to newest line", func(t *testing.T) {
+		m := Model{
+			viewState: LogStreamView,
+			height:    20, // 20-8-4 = 8 visible lines
+			logStream: logStreamState{stream: stream, following: true},
+		}
+		lines := make([]string, 12)
+		got, cmd := m.Update(logLineMsg{stream: stream, lines: lines})
+		gm := asModel(t, got)
+		if len(gm.logStream.lines) != 12 {
+			t.Errorf("len(lines) = %d, want 12", len(gm.logStream.lines))
+		}
+		if gm.logStream.viewport != 4 {
+			t.Errorf("viewport = %d, want 4", gm.logStream.viewport)
+		}
+		if cmd == nil {
+			t.Error("expected next waitForLogLineCmd")
+		}
+	})
+
+	t.Run("stale stream is ignored", func(t *testing.T) {
+		m := Model{viewState: LogStreamView, logStream: logStreamState{stream: stream}}
+		got, cmd := m.Update(logLineMsg{stream: &simulator.LogStream{}, lines: []string{"x"}})
+		gm := asModel(t, got)
+		if len(gm.logStream.lines) != 0 || cmd != nil {
+			t.Error("lines from a stopped stream should be dropped")
+		}
+	})
+
+	t.Run("oldest lines dropped past the cap", func(t *testing.T) {
+		m := Model{
+			viewState: LogStreamView,
+			height:    30,
+			logStream: logStreamState{stream: stream, lines: make([]string, logStreamMaxLines), viewport: 5},
+		}
+		got, _ := m.Update(logLineMsg{stream: stream, lines: []string{"a", "b", "c"}})
+		gm := asModel(t, got)
+		if len(gm.logStream.lines) != logStreamMaxLines {
+			t.Errorf("len(lines) = %d, want %d", len(gm.logStream.lines), logStreamMaxLines)
+		}
+		if last := gm.logStream.lines[len(gm.logStream.lines)-1]; last != "c" {
+			t.Errorf("newest line = %q, want c", last)
+		}
+		if gm.logStream.viewport != 2 {
+			t.Errorf("viewport = %d, want 2 (shifted with dropped lines)", gm.logStream.viewport)
+		}
+	})
+}
+
+func TestHandleLogStreamEnded(t *testing.T) {
+	stream := &simulator.LogStream{}
+	m := Model{viewState: LogStreamView, logStream: logStreamState{stream: stream}}
+	got, _ := m.Update(logStreamEndedMsg{stream: stream})
+	if !asModel(t, got).logStream.ended {
+		t.Error("ended should be set")
+	}
+}
+
+func TestHandleLogStreamKey(t *testing.T) {
+	lines := make([]string, 20)
+
+	tests := []struct {
+		name          string
+		viewport      int
+		action        string
+		wantViewport  int
+		wantFollowing bool
+	}{
+		{"up pauses following", 12, "up", 11, false},
+		{"down to bottom resumes following", 11, "down", 12, true},
+		{"home jumps to top", 12, "home", 0, false},
+		{"end jumps to bottom", 0, "end", 12, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{
+				viewState: LogStreamView,
+				height:    20, // 8 visible lines, max viewport 12
+				logStream: logStreamState{lines: lines, viewport: tt.viewport, following: !tt.wantFollowing},
+			}
+			got, _ := m.handleLogStreamKey(tt.action)
+			gm := asModel(t, got)
+			if gm.logStream.viewport != tt.wantViewport {
+				t.Errorf("viewport = %d, want %d", gm.logStream.viewport, tt.wantViewport)
+			}
+			if gm.logStream.following != tt.wantFollowing {
+				t.Errorf("following = %v, want %v", gm.logStream.following, tt.wantFollowing)
+			}
+		})
+	}
+}
+
+func TestHandleKeyPress_QuitStopsLogStream(t *testing.T) {
+	m := testModelWithKeyMap()
+	m.viewState = LogStreamView
+	m.logStream = logStreamState{lines: []string{"x"}}
+
+	got, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	gm := asModel(t, got)
+	if gm.viewState != AppListView {
+		t.Errorf("viewState = %v, want AppListView", gm.viewState)
+	}
+	if gm.logStream.lines != nil {
+		t.Error("log state should be cleared")
+	}
+	if cmd != nil {
+		t.Error("q in the log view must not quit the app")
+	}
+
+	_, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlC})
+	if cmd == nil {
+		t.Error("ctrl+c should still quit")
+	}
+}
@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestHandleFileListKey_ShowMetadata(t *testing.T) {
+	m := Model{viewState: FileListView, fileList: fileListState{files: fakeFiles(), cursor: 1}}
+
+	// Attributes not read yet are fetched first
+	got, cmd := m.handleFileListKey("show_metadata")
+	if gm := asModel(t, got); gm.fileList.metadata != nil || cmd == nil {
+		t.Errorf("metadata = %v, cmd = %v; want fetchXattrsCmd", gm.fileList.metadata, cmd != nil)
+	}
+
+	// Cached attributes open the panel right away
+	m.fileList.files[1].Xattrs = map[string]string{}
+	got, cmd = m.handleFileListKey("show_metadata")
+	if gm := asModel(t, got); gm.fileList.metadata == nil || cmd != nil {
+		t.Errorf("metadata = %v, cmd = %v; want the panel without a fetch", gm.fileList.metadata, cmd != nil)
+	}
+}
+
+func TestHandleFetchXattrs(t *testing.T) {
+	m := testModelWithKeyMap()
+	m.viewState = FileListView
+	m.fileList = fileListState{files: fakeFiles(), selectedApp: &fakeApps()[0]}
+	xattrs := map[string]string{"com.apple.quarantine": "0083;65a1b2c3;Safari;"}
+	got, _ := m.Update(fetchXattrsMsg{path: "/path/a/readme.txt", xattrs: xattrs})
+	gm := asModel(t, got)
+	if gm.fileList.metadata == nil || gm.fileList.metadata.Name != "readme.txt" {
+		t.Fatalf("metadata = %v, want readme.txt", gm.fileList.metadata)
+	}
+	if gm.fileList.files[1].Xattrs == nil {
+		t.Error("the attributes should be cached on the file")
+	}
+	if !strings.Contains(gm.View(), "com.apple.quarantine") {
+		t.Error("the view should show the metadata panel")
+	}
+
+	got, _ = m.Update(fetchXattrsMsg{path: "/path/a/readme.txt", err: errors.New("xattr failed")})
+	if gm := asModel(t, got); gm.fileList.metadata != nil || !strings.Contains(gm.statusMessage, "xattr failed") {
+		t.Errorf("metadata = %v, status = %q; want the error flashed", gm.fileList.metadata, gm.statusMessage)
+	}
+}
+
+func TestMetadataPanel_AnyKeyCloses(t *testing.T) {
+	files := fakeFiles()
+	m := Model{viewState: FileListView, fileList: fileListState{files: files, metadata: &files[1]}}
+	got, _ := m.handleFileListKey("down")
+	gm := asModel(t, got)
+	if gm.fileList.metadata != nil {
+		t.Error("panel should close")
+	}
+	if gm.fileList.cursor != 0 {
+		t.Error("the closing key should not also move the cursor")
+	}
+}
@@ -1,8 +1,13 @@
 package tui
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -16,6 +21,18 @@ import (
 // memory for very large files.
 const textLinesPerChunk = 500
 
+// logStreamMaxLines caps how many log lines the log view keeps. Older
+// lines are dropped so a chatty app can't grow memory without bound.
+const logStreamMaxLines = 2000
+
+// logLinesPerMsg caps how many buffered log lines are delivered in a
+// single logLineMsg, bounding the work done per Update.
+const logLinesPerMsg = 100
+
+// treeDefaultDepth is how many directory levels the file tree reads and
+// expands at once; deeper directories start collapsed.
+const treeDefaultDepth = 3
+
 // Receiver convention for Model: all methods in this package take
 // Model by value. Mutators return the updated Model; callers assign
 // the return value so the mutation propagates. Bubble Tea expects
@@ -35,6 +52,17 @@ const (
 	FileViewerView
 	DatabaseTableListView
 	DatabaseTableContentView
+	LogStreamView
+	CrashLogsView
+	URLCacheView
+	KeychainView
+	AppGroupsView
+	DiffView
+	SpawnResultView
+	ConfirmDeleteView
+	CreateSimulatorView
+	FileSearchView
+	DatabaseQueryView
 )
 
 // simListState holds the state for the simulator list view.
@@ -43,10 +71,57 @@ type simListState struct {
 	cursor       int
 	viewport     int
 	booting      bool
+	shuttingDown bool
 	loading      bool
 	filterActive bool
 	searchMode   bool
 	searchQuery  string
+	// UDID to open in Simulator.app once its boot succeeds
+	openAfterBoot string
+
+	// Simulator waiting for y/n in ConfirmDeleteView
+	deleteTarget *simulator.Item
+	deleting     bool
+
+	// A simulator from the new simulator form is being created
+	creating bool
+
+	// Inline prompt for the name of a clone of the selected simulator
+	cloneMode bool
+	cloneName string
+	cloning   bool
+	// A screenshot is being captured
+	capturing bool
+
+	// Inline prompt for a command to run in the selected simulator
+	spawnMode    bool
+	spawnCommand string
+	spawning     bool
+	// UDID to move the cursor to once the list is refreshed
+	selectUDID string
+	// Whether the warning about runtimes newer than Xcode was shown
+	runtimeWarned bool
+
+	// Status bar network overrides of booted simulators by UDID, kept
+	// across refreshes of the list. nil until first queried.
+	networkConditions map[string]string
+
+	// Watch and phone pairings by UDID, kept across refreshes of the
+	// list. nil until first queried.
+	pairings map[string]simulator.Pairing
+
+	// Order of the list, cycled by the sort key
+	sortOrder simulator.SimSortOrder
+
+	// Platform the filter key narrows the list to after the simulators
+	// with apps; 0 shows every platform
+	platform simulator.RuntimeFilter
+
+	// Simulators left by the filter and search query, kept so key
+	// presses and redraws don't filter the list again. Rebuilt by
+	// refilterSimulators whenever the list, filter or query changes.
+	filteredSimsCache  []simulator.Item
+	filteredSimsCached bool
 }
 
 // allAppsState holds the state for the combined "all apps" view.
@@ -57,6 +132,29 @@ type allAppsState struct {
 	loading     bool
 	searchMode  bool
 	searchQuery string
+	sortOrder   simulator.AppSortOrder
+}
+
+// appListSortOrders and allAppsSortOrders are the orders the sort keys
+// step through in a simulator's app list and the All Apps view
+var (
+	appListSortOrders = []simulator.AppSortOrder{
+		simulator.AppSortByName,
+		simulator.AppSortBySize,
+		simulator.AppSortByBundleID,
+		simulator.AppSortByModTime,
+	}
+	allAppsSortOrders = []simulator.AppSortOrder{
+		simulator.AppSortByName,
+		simulator.AppSortBySize,
+		simulator.AppSortBySimulator,
+		simulator.AppSortByModTime,
+	}
+)
+
+// nextAppSortOrder returns the order after o in orders, wrapping around
+func nextAppSortOrder(orders []simulator.AppSortOrder, o simulator.AppSortOrder) simulator.AppSortOrder {
+	return orders[(slices.Index(orders, o)+1)%len(orders)]
 }
 
 // appListState holds the state for a single simulator's app list.
@@ -68,12 +166,65 @@ type appListState struct {
 	loading     bool
 	searchMode  bool
 	searchQuery string
+	sortOrder   simulator.AppSortOrder
+
+	notifications *notificationOverlay // Non-nil while the notification panel is open
+	permissions   *permissionsOverlay  // Non-nil while the permissions panel is open
+	resources     *resourcesOverlay    // Non-nil while the resources panel is open
+	push          *pushOverlay         // Non-nil while the push payload editor is open
+	resourcesSeq  int                  // Bumped per opening so stale refreshes are dropped
+
+	// Multi-select mode for batch uninstall
+	multiSelect      bool
+	selectedApps     map[string]bool // Paths of the selected apps
+	confirmUninstall bool            // Waiting for y/n on the uninstall prompt
+	uninstalling     bool
+
+	// Deep link input: deepLinkMode while the URL bar takes input
+	deepLinkMode bool
+	deepLinkURL  string
+
+	// Bundle ID of the app whose container is being zipped, and the
+	// spinner frame shown meanwhile
+	exporting   string
+	exportFrame int
+}
+
+// notificationOverlay is the notification panel shown over the app list.
+type notificationOverlay struct {
+	appName string
+	info    *simulator.NotificationInfo // nil if the app has no settings file
+}
+
+// permissionsOverlay is the privacy permissions checklist shown over
+// the app list.
+type permissionsOverlay struct {
+	app         simulator.App
+	permissions []simulator.Permission
+	cursor      int
+	updating    bool // A grant or revoke is running
+}
+
+// pushOverlay is the push notification payload editor shown over the
+// app list.
+type pushOverlay struct {
+	app     simulator.App
+	payload string
+	sending bool
+}
+
+// resourcesOverlay is the memory usage panel shown over the app list.
+type resourcesOverlay struct {
+	simName string
+	stats   *simulator.MemoryStats // nil until the first reading arrives
+	err     error
 }
 
 // fileListState holds the state for the file browser.
 type fileListState struct {
 	selectedApp    *simulator.App
-	files          []simulator.FileInfo
+	files          []simulator.FileInfo // allFiles less those the size filter hides
+	allFiles       []simulator.FileInfo
 	cursor         int
 	viewport       int
 	loading        bool
@@ -82,6 +233,42 @@ type fileListState struct {
 	breadcrumbs    []string       // Path components from base to current
 	cursorMemory   map[string]int // Remember cursor position for each path
 	viewportMemory map[string]int // Remember viewport position for each path
+
+	// Browsing an app group container opened from the app group list
+	fromAppGroups bool
+
+	// Browsing the app bundle at selectedApp.Path, showing only its
+	// resources
+	bundleResources bool
+
+	// SQLite databases anywhere in the container, listed under a
+	// "Databases" entry at the container root
+	databases   []simulator.FileInfo
+	inDatabases bool // Showing the databases entry's listing
+
+	// Tree view of everything below currentPath
+	treeView     bool
+	treeLoading  bool
+	tree         []simulator.TreeNode
+	treeExpanded map[string]bool
+	treeCursor   int // Index into the visible tree nodes
+	treeViewport int
+
+	metadata *simulator.FileInfo // Non-nil while the metadata panel is open
+
+	// Files smaller than minSizeFilter bytes are hidden; 0 shows all
+	minSizeFilter int64
+
+	// File to put the cursor on once the listing refreshes, e.g. a
+	// new copy; cleared once found
+	selectPath string
+
+	// Inline prompt for a new name for the selected file
+	renameMode  bool
+	renameName  string
+	renameError string // Why renameName can't be used, shown in the prompt
+
+	confirmDelete bool // Waiting for y/n on the delete prompt
 }
 
 // fileViewerState holds the state for the file viewer.
@@ -91,7 +278,10 @@ type fileViewerState struct {
 	contentOffset   int // Line offset for text, byte offset for binary
 	contentViewport int // Viewport position within the loaded chunk
 	loading         bool
+	scrollToEnd     bool // Move the viewport to the end once the pending chunk loads
 	svgWarning      string
+	fromCrashLogs   bool // Opened from the crash log list rather than the file list
+	fromURLCache    bool // Opened from the URL cache list rather than the file list
 }
 
 // dbTableListState holds the state for the database table list view.
@@ -101,6 +291,13 @@ type dbTableListState struct {
 	cursor   int
 	viewport int
 	loading  bool
+
+	confirmVacuum bool // Waiting for y/n on the vacuum prompt
+	vacuuming     bool
+
+	// The database is a .sqlite file in an app container, so its tables
+	// are named after the Core Data entities in the app bundle's model
+	coreDataEnhance bool
 }
 
 // dbTableContentState holds the state for the per-table content view.
@@ -109,6 +306,193 @@ type dbTableContentState struct {
 	data     []map[string]any     // Current page of table data
 	offset   int                  // Row offset for pagination
 	viewport int                  // Viewport position within the loaded page
+	hScroll  int                  // Columns scrolled off the left edge
+	loading  bool
+
+	// Number of the sort column in table.Columns counting from 1; 0 keeps
+	// the table's own order
+	sortColumn int
+	sortDesc   bool
+
+	// Row search: searchMode while the query bar takes input; a
+	// non-empty searchQuery replaces data with the matching rows
+	searchMode  bool
+	searchQuery string
+	matchCount  int
+
+	// Column picker: columnPicker while the panel is open, with the
+	// cursor on pickerCursor in table.Columns
+	columnPicker bool
+	pickerCursor int
+
+	// CSV export in progress; exportRows counts the rows written so far
+	exporting  bool
+	exportRows *atomic.Int64
+}
+
+// queryHistorySize is how many of the last queries run the SQL query
+// editor recalls
+const queryHistorySize = 10
+
+// dbQueryState holds the SQL query editor of the open database and the
+// results of its last query. history outlives the editor, so queries
+// run against one database can be recalled in the next.
+type dbQueryState struct {
+	input    string // Query being edited
+	cursor   int    // Rune index of the cursor in input
+	running  bool
+	result   *simulator.QueryResult
+	viewport int // First result row shown
+	hScroll  int // Result columns scrolled off the left edge
+
+	history    []string // Queries run, oldest first
+	historyPos int      // Index of the recalled query; len(history) when editing a new one
+}
+
+// tableColumnsKey returns the key of the open table in hiddenColumns
+func (m Model) tableColumnsKey() string {
+	if m.dbContent.table == nil || m.dbTables.file == nil {
+		return ""
+	}
+	return m.dbTables.file.Path + "/" + m.dbContent.table.Name
+}
+
+// currentHiddenColumns returns the columns hidden in the open table, or
+// nil if none are
+func (m Model) currentHiddenColumns() map[string]bool {
+	return m.hiddenColumns[m.tableColumnsKey()]
+}
+
+// shownColumnCount returns the number of columns of the open table that
+// aren't hidden
+func (m Model) shownColumnCount() int {
+	if m.dbContent.table == nil {
+		return 0
+	}
+	hidden := m.currentHiddenColumns()
+	shown := 0
+	for _, col := range m.dbContent.table.Columns {
+		if !hidden[col.Name] {
+			shown++
+		}
+	}
+	return shown
+}
+
+// sortColumnName returns the name of the column the table is sorted by,
+// or "" if it isn't sorted
+func (s dbTableContentState) sortColumnName() string {
+	if s.table == nil || s.sortColumn < 1 || s.sortColumn > len(s.table.Columns) {
+		return ""
+	}
+	return s.table.Columns[s.sortColumn-1].Name
+}
+
+// logStreamState holds the state for the live simulator or app log view.
+type logStreamState struct {
+	app       *simulator.App
+	sim       *simulator.Item      // Set instead of app to stream every process
+	stream    *simulator.LogStream // nil until the log process has started
+	lines     []string
+	viewport  int
+	following bool // Keep the viewport pinned to the newest line
+	ended     bool // The log process exited on its own
+}
+
+// crashLogsState holds the state for an app's crash log list.
+type crashLogsState struct {
+	app      *simulator.App
+	logs     []simulator.CrashLog
+	cursor   int
+	viewport int
+	loading  bool
+}
+
+// urlCacheState holds the state for an app's URL cache list.
+type urlCacheState struct {
+	app      *simulator.App
+	entries  []simulator.URLCacheEntry
+	cursor   int
+	viewport int
+	loading  bool
+}
+
+// keychainState holds the state for an app's keychain item table.
+type keychainState struct {
+	app      *simulator.App
+	items    []simulator.KeychainItem
+	viewport int
+	hScroll  int // Index of the first column shown
+	loading  bool
+}
+
+// appGroupsState holds the state for an app's app group list.
+type appGroupsState struct {
+	app      *simulator.App
+	groups   []simulator.AppGroup
+	cursor   int
+	viewport int
+	loading  bool
+}
+
+// diffState holds the state for the diff between two files.
+type diffState struct {
+	oldFile  simulator.FileInfo
+	newFile  simulator.FileInfo
+	oldName  string // Names of the files in the title (see diffNames)
+	newName  string
+	lines    []simulator.DiffLine
+	viewport int
+	loading  bool
+}
+
+// diffFile is a file picked for a diff in the file list, with the app
+// container it was picked in
+type diffFile struct {
+	file    simulator.FileInfo
+	relPath string // Path of the file in the container
+	app     string
+	sim     string
+}
+
+// spawnState holds the output of a command run in a simulator.
+type spawnState struct {
+	simName  string
+	result   simulator.SpawnResult
+	lines    []string
+	viewport int
+}
+
+// createStep is a step of the new simulator form
+type createStep int
+
+const (
+	createStepRuntime createStep = iota
+	createStepDeviceType
+	createStepName
+)
+
+// createSimState holds the new simulator form, which asks for a
+// runtime, then a device type that runs on it, then a name.
+type createSimState struct {
+	step        createStep
+	runtimes    []simulator.Runtime
+	deviceTypes []simulator.DeviceType // Those that support runtime
+	runtime     simulator.Runtime
+	deviceType  simulator.DeviceType
+	name        string
+	cursor      int
+	viewport    int
+	loading     bool
+}
+
+// fileSearchState holds the search of the text file open in the file
+// viewer. Every edit of the query re-runs grep.
+type fileSearchState struct {
+	query    string
+	matches  []simulator.SearchMatch
+	cursor   int
+	viewport int
 	loading  bool
 }
 
@@ -133,6 +517,30 @@ type Model struct {
 	fileViewer fileViewerState
 	dbTables   dbTableListState
 	dbContent  dbTableContentState
+	logStream  logStreamState
+	crashLogs  crashLogsState
+	urlCache   urlCacheState
+	keychain   keychainState
+	appGroups  appGroupsState
+	diff       diffState
+	spawn      spawnState
+	createSim  createSimState
+	fileSearch fileSearchState
+	dbQuery    dbQueryState
+
+	// First file of a diff, marked with the diff key until a second
+	// file is chosen. It outlives the file list, so the second file can
+	// be in another app's container or another simulator.
+	diffMark *diffFile
+
+	// Columns hidden in the table content view, by table key (see
+	// tableColumnsKey); kept while the app runs so they survive going
+	// back to the table list
+	hiddenColumns map[string]map[string]bool
+
+	// Bytes read per binary file fetch; starts at the configured size
+	// and is cycled by the binary_chunks key
+	binaryChunkSize int
 
 	// Theme state
 	currentThemeMode string // Current detected theme mode ("dark" or "light")
@@ -169,7 +577,10 @@ func New(fetcher simulator.Fetcher, startWithApps bool) Model {
 		currentThemeMode: themeMode,
 		config:           cfg,
 		keyMap:           keyMap,
+		binaryChunkSize:  cfg.Viewer.BinaryChunkSize,
 	}
+	simulator.SetRowCountCacheTTL(time.Duration(cfg.Viewer.RowCountCacheSeconds) * time.Second)
+	fetcher.SetRuntimeFilter(simulator.ParseRuntimeFilter(cfg.Display.Platforms))
 
 	// Check command-line flag first, then config
 	if startWithApps || (cfg.Startup.InitialView == "all_apps") {
@@ -178,9 +589,19 @@ func New(fetcher simulator.Fetcher, startWithApps bool) Model {
 		m.allApps.loading = true
 	}
 
+	// Warn once per session; reloads don't repeat it
+	if len(cfg.LegacyKeys) > 0 {
+		m.statusMessage = fmt.Sprintf("Warning: deprecated config keys %s; run simtool --migrate-config",
+			strings.Join(cfg.LegacyKeys, ", "))
+	}
+
 	return m
 }
 
+// legacyKeyWarningDuration is how long the deprecated config key warning
+// stays in the status bar after startup
+const legacyKeyWarningDuration = 8 * time.Second
+
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
 	cmds := []tea.Cmd{
@@ -196,6 +617,10 @@ func (m Model) Init() tea.Cmd {
 		cmds = append(cmds, fetchSimulatorsCmd(m.fetcher))
 	}
 
+	if m.statusMessage != "" {
+		cmds = append(cmds, clearStatusAfter(legacyKeyWarningDuration))
+	}
+
 	return tea.Batch(cmds...)
 }
 
@@ -213,6 +638,46 @@ func fetchSimulatorsCmd(fetcher simulator.Fetcher) tea.Cmd {
 	}
 }
 
+// networkConditionsMsg is sent when the status bar network of the
+// booted simulators has been read
+type networkConditionsMsg struct {
+	conditions map[string]string // By UDID
+}
+
+// fetchNetworkConditionsCmd reads the status bar network override of
+// each booted simulator. Simulators that aren't booted are skipped.
+func fetchNetworkConditionsCmd(sims []simulator.Item) tea.Cmd {
+	return func() tea.Msg {
+		conditions := make(map[string]string)
+		for _, sim := range sims {
+			if !sim.IsRunning() {
+				continue
+			}
+			if condition, err := simulator.GetNetworkCondition(sim.UDID); err == nil && condition != "" {
+				conditions[sim.UDID] = condition
+			}
+		}
+		return networkConditionsMsg{conditions: conditions}
+	}
+}
+
+// pairingsMsg is sent when the simulator pairs have been listed
+type pairingsMsg struct {
+	pairings map[string]simulator.Pairing // By UDID
+}
+
+// fetchPairingsCmd lists the watch and phone simulator pairs. A failed
+// listing reports no pairs.
+func fetchPairingsCmd() tea.Cmd {
+	return func() tea.Msg {
+		pairings, err := simulator.GetPairings()
+		if err != nil {
+			pairings = map[string]simulator.Pairing{}
+		}
+		return pairingsMsg{pairings: pairings}
+	}
+}
+
 // bootSimulatorMsg is sent when a simulator boot is attempted
 type bootSimulatorMsg struct {
 	udid string
@@ -227,6 +692,202 @@ func (m Model) bootSimulatorCmd(udid string) tea.Cmd {
 	}
 }
 
+// shutdownSimulatorMsg is sent when a simulator shutdown is attempted
+type shutdownSimulatorMsg struct {
+	udid string
+	err  error
+}
+
+// shutdownSimulatorCmd shuts down a simulator asynchronously
+func (m Model) shutdownSimulatorCmd(udid string) tea.Cmd {
+	return func() tea.Msg {
+		err := m.fetcher.Shutdown(udid)
+		return shutdownSimulatorMsg{udid: udid, err: err}
+	}
+}
+
+// deleteSimulatorMsg is sent when a simulator deletion is attempted
+type deleteSimulatorMsg struct {
+	name string
+	err  error
+}
+
+// deleteSimulatorCmd deletes a simulator asynchronously
+func (m Model) deleteSimulatorCmd(sim simulator.Item) tea.Cmd {
+	return func() tea.Msg {
+		return deleteSimulatorMsg{name: sim.Name, err: simulator.DeleteSimulator(sim.UDID)}
+	}
+}
+
+// fetchRuntimesMsg is sent when the runtimes a new simulator can use
+// have been listed
+type fetchRuntimesMsg struct {
+	runtimes []simulator.Runtime
+	err      error
+}
+
+// fetchRuntimesCmd lists the iOS runtimes for the new simulator form
+func fetchRuntimesCmd() tea.Cmd {
+	return func() tea.Msg {
+		runtimes, err := simulator.ListIOSRuntimes()
+		return fetchRuntimesMsg{runtimes: runtimes, err: err}
+	}
+}
+
+// fetchDeviceTypesMsg is sent when the device types a new simulator can
+// use have been listed
+type fetchDeviceTypesMsg struct {
+	deviceTypes []simulator.DeviceType
+	err         error
+}
+
+// fetchDeviceTypesCmd lists the device types for the new simulator form
+func fetchDeviceTypesCmd() tea.Cmd {
+	return func() tea.Msg {
+		deviceTypes, err := simulator.ListDeviceTypes()
+		return fetchDeviceTypesMsg{deviceTypes: deviceTypes, err: err}
+	}
+}
+
+// createSimulatorMsg is sent when a simulator creation is attempted
+type createSimulatorMsg struct {
+	name string
+	udid string // UDID of the new simulator
+	err  error
+}
+
+// createSimulatorCmd creates a simulator asynchronously
+func createSimulatorCmd(name, deviceType, runtime string) tea.Cmd {
+	return func() tea.Msg {
+		udid, err := simulator.CreateSimulator(name, deviceType, runtime)
+		return createSimulatorMsg{name: name, udid: udid, err: err}
+	}
+}
+
+// openSimulatorAppMsg is sent when Simulator.app has been asked to show
+// a simulator
+type openSimulatorAppMsg struct {
+	err error
+}
+
+// openSimulatorAppCmd opens the window of simulator udid in Simulator.app
+func openSimulatorAppCmd(udid string) tea.Cmd {
+	return func() tea.Msg {
+		return openSimulatorAppMsg{err: simulator.OpenSimulatorApp(udid)}
+	}
+}
+
+// exportContainerMsg is sent when an app's data container has been
+// archived
+type exportContainerMsg struct {
+	path string
+	err  error
+}
+
+// exportContainerCmd zips the data container of app to ~/Desktop
+func exportContainerCmd(app simulator.App) tea.Cmd {
+	return func() tea.Msg {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return exportContainerMsg{err: err}
+		}
+		path, err := simulator.ExportContainer(app.Container, app.BundleID, filepath.Join(home, "Desktop"), time.Now())
+		return exportContainerMsg{path: path, err: err}
+	}
+}
+
+// exportTableMsg is sent when a database table has been written to CSV
+type exportTableMsg struct {
+	path        string
+	rowsWritten int
+	err         error
+}
+
+// exportTableCmd writes every row of table to ~/Desktop as CSV, counting
+// the rows written in progress as it goes
+func exportTableCmd(dbPath, table string, progress *atomic.Int64) tea.Cmd {
+	return func() tea.Msg {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return exportTableMsg{err: err}
+		}
+		path, written, err := simulator.ExportTableCSV(dbPath, table, filepath.Join(home, "Desktop"), progress)
+		return exportTableMsg{path: path, rowsWritten: written, err: err}
+	}
+}
+
+// exportTableProgressMsg asks for the rows a table export has written
+type exportTableProgressMsg struct{}
+
+// exportTableProgressCmd schedules the next table export progress update
+func exportTableProgressCmd() tea.Cmd {
+	return tea.Tick(250*time.Millisecond, func(time.Time) tea.Msg {
+		return exportTableProgressMsg{}
+	})
+}
+
+// spinnerFrames animate the status bar while a container is exported
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// exportSpinnerMsg advances the export spinner
+type exportSpinnerMsg struct{}
+
+// exportSpinnerCmd schedules the next export spinner frame
+func exportSpinnerCmd() tea.Cmd {
+	return tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg {
+		return exportSpinnerMsg{}
+	})
+}
+
+// captureScreenMsg is sent when a simulator screenshot has been saved
+type captureScreenMsg struct {
+	path string
+	err  error
+}
+
+// captureScreenCmd saves a screenshot of the booted simulator udid,
+// named after name, to ~/Desktop
+func captureScreenCmd(udid, name string) tea.Cmd {
+	return func() tea.Msg {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return captureScreenMsg{err: err}
+		}
+		path, err := simulator.CaptureScreenshot(udid, name, filepath.Join(home, "Desktop"), time.Now())
+		return captureScreenMsg{path: path, err: err}
+	}
+}
+
+// spawnMsg is sent when a command run in a simulator has exited
+type spawnMsg struct {
+	simName string
+	result  simulator.SpawnResult
+	err     error
+}
+
+// spawnCmd runs command in the booted simulator udid, named simName
+func spawnCmd(udid, simName, command string) tea.Cmd {
+	return func() tea.Msg {
+		result, err := simulator.SpawnCommand(udid, command)
+		return spawnMsg{simName: simName, result: result, err: err}
+	}
+}
+
+// cloneSimulatorMsg is sent when a simulator clone is attempted
+type cloneSimulatorMsg struct {
+	name string
+	udid string // UDID of the clone
+	err  error
+}
+
+// cloneSimulatorCmd clones a simulator asynchronously
+func cloneSimulatorCmd(udid, name string) tea.Cmd {
+	return func() tea.Msg {
+		newUDID, err := simulator.CloneSimulator(udid, name)
+		return cloneSimulatorMsg{name: name, udid: newUDID, err: err}
+	}
+}
+
 // fetchAppsMsg is sent when apps are fetched
 type fetchAppsMsg struct {
 	apps []simulator.App
@@ -247,6 +908,20 @@ type themeChangedMsg struct {
 	newMode string // "dark" or "light"
 }
 
+// reloadConfigMsg carries a freshly loaded configuration into the TUI,
+// typically in response to SIGHUP.
+type reloadConfigMsg struct {
+	cfg *config.Config
+	err error
+}
+
+// ReloadConfigMsg wraps the result of config.Load for delivery to a
+// running program via tea.Program.Send. On error the TUI keeps its
+// current configuration and flashes the error.
+func ReloadConfigMsg(cfg *config.Config, err error) tea.Msg {
+	return reloadConfigMsg{cfg: cfg, err: err}
+}
+
 // fetchAppsCmd fetches apps for a simulator
 func (m Model) fetchAppsCmd(sim simulator.Item) tea.Cmd {
 	return func() tea.Msg {
@@ -297,15 +972,23 @@ func (m Model) checkThemeChange() tea.Cmd {
 
 // fetchFilesMsg is sent when files are fetched
 type fetchFilesMsg struct {
-	files []simulator.FileInfo
-	err   error
+	files     []simulator.FileInfo
+	databases []simulator.FileInfo // Only searched for at the container root
+	err       error
 }
 
-// fetchFilesCmd fetches files for an app container
+// fetchFilesCmd fetches files for an app container. At the container
+// root it also searches the whole container for SQLite databases; app
+// bundles aren't searched.
 func (m Model) fetchFilesCmd(containerPath string) tea.Cmd {
+	isRoot := containerPath == m.fileList.basePath && !m.fileList.bundleResources
 	return func() tea.Msg {
 		files, err := simulator.GetFilesForContainer(containerPath)
-		return fetchFilesMsg{files: files, err: err}
+		msg := fetchFilesMsg{files: files, err: err}
+		if err == nil && isRoot {
+			msg.databases = simulator.FindDatabases(containerPath)
+		}
+		return msg
 	}
 }
 
@@ -330,30 +1013,105 @@ func (m Model) openInFinderCmd(path string) tea.Cmd {
 	}
 }
 
+// copyToClipboardMsg is sent when text has been copied to the clipboard
+type copyToClipboardMsg struct {
+	err  error
+	path bool // The text copied was a path
+}
+
+// copyToClipboardCmd copies text to the macOS clipboard through pbcopy
+func copyToClipboardCmd(text string) tea.Cmd {
+	return func() tea.Msg {
+		cmd := exec.Command("pbcopy")
+		cmd.Stdin = strings.NewReader(text)
+		return copyToClipboardMsg{err: cmd.Run()}
+	}
+}
+
+// copyPathCmd copies a file or folder path to the clipboard, without the
+// file:// prefix some paths carry
+func copyPathCmd(path string) tea.Cmd {
+	copyText := copyToClipboardCmd(strings.TrimPrefix(path, "file://"))
+	return func() tea.Msg {
+		msg := copyText().(copyToClipboardMsg)
+		msg.path = true
+		return msg
+	}
+}
+
+// fetchSearchResultsMsg is sent when a search of the viewed file completes
+type fetchSearchResultsMsg struct {
+	query   string
+	matches []simulator.SearchMatch
+	err     error
+}
+
+// searchFileCmd finds the lines of the file at path containing query
+func searchFileCmd(path, query string) tea.Cmd {
+	return func() tea.Msg {
+		matches, err := simulator.SearchFile(path, query)
+		return fetchSearchResultsMsg{query: query, matches: matches, err: err}
+	}
+}
+
+// openInEditorMsg is sent when the editor opened on a file has exited
+type openInEditorMsg struct {
+	err error
+}
+
+// editorCommand builds the command that opens path in the user's
+// editor: $EDITOR, which may carry flags such as "code --wait", or the
+// default text editor through open -t when it isn't set
+func editorCommand(path string) *exec.Cmd {
+	if editor := strings.Fields(os.Getenv("EDITOR")); len(editor) > 0 {
+		return exec.Command(editor[0], append(editor[1:], path)...)
+	}
+	return exec.Command("open", "-t", path)
+}
+
+// openInEditorCmd opens path in the user's editor. The TUI gives the
+// terminal to the editor and takes it back, alt screen included, once
+// the editor exits.
+func openInEditorCmd(path string) tea.Cmd {
+	return tea.ExecProcess(editorCommand(path), func(err error) tea.Msg {
+		return openInEditorMsg{err: err}
+	})
+}
+
+// chunkSize returns the bytes read per binary file fetch
+func (m Model) chunkSize() int {
+	if m.binaryChunkSize <= 0 {
+		return simulator.BinaryChunkSize
+	}
+	return m.binaryChunkSize
+}
+
 // fetchFileContentMsg is sent when file content is fetched
 type fetchFileContentMsg struct {
 	content *simulator.FileContent
 	err     error
 }
 
-// fetchFileContentCmd fetches the content of a file for viewing
-func (m Model) fetchFileContentCmd(path string, offset int) tea.Cmd {
+// fetchFileContentCmd fetches the content of a file for viewing. Image
+// previews are sized to fit a terminal of the given width and height.
+func (m Model) fetchFileContentCmd(path string, offset, width, height int) tea.Cmd {
+	chunkSize := m.chunkSize()
 	return func() tea.Msg {
 		// For text files, load a fixed-size chunk. For images the chunk
 		// count doubles as the preview height so it's derived from the
 		// terminal dimensions instead.
 		maxLines := textLinesPerChunk
-		maxWidth := m.width - 6 // Same as contentWidth in view.go
+		maxWidth := width - 6 // Same as contentWidth in view.go
 		fileType := simulator.DetectFileType(path)
 		if fileType == simulator.FileTypeImage {
 			// Pass terminal height minus UI overhead
 			// Account for: title (4), footer (4), border (0 - handled by contentHeight)
-			maxLines = m.height - 8
+			maxLines = height - 8
 			if maxLines < 20 {
 				maxLines = 20
 			}
 		}
-		content, err := simulator.ReadFileContent(path, offset, maxLines, maxWidth)
+		content, err := simulator.ReadFileContentChunk(path, offset, maxLines, maxWidth, chunkSize)
 		return fetchFileContentMsg{content: content, err: err}
 	}
 }
@@ -364,14 +1122,49 @@ type fetchDatabaseInfoMsg struct {
 	err    error
 }
 
-// fetchDatabaseInfoCmd fetches database information
-func (m Model) fetchDatabaseInfoCmd(path string) tea.Cmd {
+// fetchDatabaseInfoCmd fetches database information. Tables are named
+// after the Core Data entities of the model in the app bundle at
+// bundlePath, unless it is empty, and the store is checked against the
+// model's current version.
+func (m Model) fetchDatabaseInfoCmd(path, bundlePath string) tea.Cmd {
 	return func() tea.Msg {
 		dbInfo, err := simulator.ReadDatabaseContent(path)
+		if err == nil && bundlePath != "" {
+			simulator.ApplyCoreDataEntityNames(dbInfo, bundlePath, path)
+			simulator.CheckCoreDataMigration(dbInfo, bundlePath, path)
+		}
 		return fetchDatabaseInfoMsg{dbInfo: dbInfo, err: err}
 	}
 }
 
+// vacuumDatabaseMsg is sent when a database has been vacuumed
+type vacuumDatabaseMsg struct {
+	before, after int64 // File sizes in bytes
+	err           error
+}
+
+// vacuumDatabaseCmd runs VACUUM on the database at path
+func (m Model) vacuumDatabaseCmd(path string) tea.Cmd {
+	return func() tea.Msg {
+		before, after, err := simulator.VacuumDatabase(path)
+		return vacuumDatabaseMsg{before: before, after: after, err: err}
+	}
+}
+
+// runQueryMsg is sent when a SQL query typed in the query editor has run
+type runQueryMsg struct {
+	result *simulator.QueryResult
+	err    error
+}
+
+// runQueryCmd runs query against the database at path
+func runQueryCmd(path, query string) tea.Cmd {
+	return func() tea.Msg {
+		result, err := simulator.RunQuery(path, query, simulator.QueryMaxRows)
+		return runQueryMsg{result: result, err: err}
+	}
+}
+
 // fetchTableDataMsg is sent when table data is fetched
 type fetchTableDataMsg struct {
 	data   []map[string]any
@@ -381,8 +1174,454 @@ type fetchTableDataMsg struct {
 
 // fetchTableDataCmd fetches table data with pagination
 func (m Model) fetchTableDataCmd(dbPath, tableName string, offset, limit int) tea.Cmd {
+	return m.fetchSortedTableDataCmd(dbPath, tableName, "", true, offset, limit)
+}
+
+// fetchSortedTableDataCmd fetches table data ordered by sortColumn with
+// pagination. An empty sortColumn keeps the table's own order.
+func (m Model) fetchSortedTableDataCmd(dbPath, tableName, sortColumn string, ascending bool, offset, limit int) tea.Cmd {
 	return func() tea.Msg {
-		data, err := simulator.ReadTableData(dbPath, tableName, offset, limit)
+		data, err := simulator.ReadSortedTableData(dbPath, tableName, sortColumn, ascending, offset, limit)
 		return fetchTableDataMsg{data: data, offset: offset, err: err}
 	}
 }
+
+// tableSearchLimit is the most matching rows a table search loads
+const tableSearchLimit = 50
+
+// tableSearchMsg is sent when a table row search completes
+type tableSearchMsg struct {
+	query string
+	data  []map[string]any
+	total int
+	err   error
+}
+
+// tableSearchCmd searches the text columns of table for query, in the
+// current sort order
+func (m Model) tableSearchCmd(dbPath string, table *simulator.TableInfo, query, sortColumn string, ascending bool) tea.Cmd {
+	return func() tea.Msg {
+		data, total, err := simulator.SearchTableData(dbPath, table, query, sortColumn, ascending, tableSearchLimit)
+		return tableSearchMsg{query: query, data: data, total: total, err: err}
+	}
+}
+
+// logStreamStartedMsg is sent when the log stream process has started
+type logStreamStartedMsg struct {
+	stream *simulator.LogStream
+	err    error
+}
+
+// startLogStreamCmd starts streaming logs for app on a booted simulator
+func (m Model) startLogStreamCmd(udid string, app simulator.App) tea.Cmd {
+	return func() tea.Msg {
+		stream, err := simulator.StartLogStream(udid, app)
+		return logStreamStartedMsg{stream: stream, err: err}
+	}
+}
+
+// startSimulatorLogStreamCmd starts streaming the logs of every process
+// on a booted simulator
+func (m Model) startSimulatorLogStreamCmd(udid string) tea.Cmd {
+	return func() tea.Msg {
+		stream, err := simulator.StartSimulatorLogStream(udid)
+		return logStreamStartedMsg{stream: stream, err: err}
+	}
+}
+
+// logLineMsg carries log output read from a stream. Lines already
+// buffered when the first one arrives are delivered together.
+type logLineMsg struct {
+	stream *simulator.LogStream
+	lines  []string
+}
+
+// logStreamEndedMsg is sent when a log stream's process exits
+type logStreamEndedMsg struct {
+	stream *simulator.LogStream
+}
+
+// waitForLogLineCmd blocks until the stream produces output or closes
+func waitForLogLineCmd(stream *simulator.LogStream) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-stream.Lines
+		if !ok {
+			return logStreamEndedMsg{stream: stream}
+		}
+		lines := []string{line}
+		for len(lines) < logLinesPerMsg {
+			select {
+			case line, ok := <-stream.Lines:
+				if !ok {
+					// Deliver what we have; the next wait reports the end.
+					return logLineMsg{stream: stream, lines: lines}
+				}
+				lines = append(lines, line)
+			default:
+				return logLineMsg{stream: stream, lines: lines}
+			}
+		}
+		return logLineMsg{stream: stream, lines: lines}
+	}
+}
+
+// crashCountsMsg carries the number of crash reports per bundle ID
+type crashCountsMsg struct {
+	counts map[string]int
+}
+
+// countCrashLogsCmd counts the crash reports for each app in the list
+func countCrashLogsCmd(apps []simulator.App) tea.Cmd {
+	bundleIDs := make([]string, len(apps))
+	for i, app := range apps {
+		bundleIDs[i] = app.BundleID
+	}
+	return func() tea.Msg {
+		return crashCountsMsg{counts: simulator.CountCrashLogs(bundleIDs)}
+	}
+}
+
+// appSizeUpdatedMsg carries the measured size of the app bundle at path.
+// It is keyed by path rather than bundle ID because the all-apps list
+// holds the same bundle ID once per simulator.
+type appSizeUpdatedMsg struct {
+	path string
+	size int64
+}
+
+// maxSizeWorkers caps how many app bundles are measured at once
+const maxSizeWorkers = 4
+
+// sizeWorkers holds a token for each bundle being measured
+var sizeWorkers = make(chan struct{}, maxSizeWorkers)
+
+// sizeWorkerCmd measures the app bundle at path, waiting for a free
+// worker first so a long list doesn't walk every bundle at once
+func sizeWorkerCmd(path string) tea.Cmd {
+	return func() tea.Msg {
+		sizeWorkers <- struct{}{}
+		defer func() { <-sizeWorkers }()
+		return appSizeUpdatedMsg{path: path, size: simulator.AppSize(path)}
+	}
+}
+
+// appSizesCmd measures every app in the list whose size isn't known yet
+func appSizesCmd(apps []simulator.App) tea.Cmd {
+	var cmds []tea.Cmd
+	seen := make(map[string]bool)
+	for _, app := range apps {
+		if app.Size >= 0 || app.Path == "" || seen[app.Path] {
+			continue
+		}
+		seen[app.Path] = true
+		cmds = append(cmds, sizeWorkerCmd(app.Path))
+	}
+	return tea.Batch(cmds...)
+}
+
+// fetchCrashLogsMsg is sent when an app's crash logs have been listed
+type fetchCrashLogsMsg struct {
+	logs []simulator.CrashLog
+}
+
+// fetchCrashLogsCmd lists the crash logs for an app
+func (m Model) fetchCrashLogsCmd(app simulator.App) tea.Cmd {
+	return func() tea.Msg {
+		return fetchCrashLogsMsg{logs: simulator.GetCrashLogs(app.BundleID)}
+	}
+}
+
+// fetchURLCacheMsg is sent when an app's URL cache has been read
+type fetchURLCacheMsg struct {
+	entries []simulator.URLCacheEntry
+	err     error
+}
+
+// fetchURLCacheCmd reads the URL cache for an app
+func (m Model) fetchURLCacheCmd(app simulator.App) tea.Cmd {
+	return func() tea.Msg {
+		entries, err := simulator.GetURLCacheEntries(app.Container, app.BundleID)
+		return fetchURLCacheMsg{entries: entries, err: err}
+	}
+}
+
+// urlCacheBodyMsg is sent when a cached response body is ready to view
+type urlCacheBodyMsg struct {
+	file simulator.FileInfo
+	err  error
+}
+
+// urlCacheBodyCmd makes a cached response's body available as a file
+func (m Model) urlCacheBodyCmd(entry simulator.URLCacheEntry) tea.Cmd {
+	return func() tea.Msg {
+		path, err := entry.BodyFile()
+		if err != nil {
+			return urlCacheBodyMsg{err: err}
+		}
+		return urlCacheBodyMsg{file: entry.FileInfo(path)}
+	}
+}
+
+// fetchKeychainMsg is sent when a simulator's keychain has been dumped
+type fetchKeychainMsg struct {
+	items []simulator.KeychainItem
+	err   error
+}
+
+// fetchKeychainCmd dumps the keychain items of an app on simulator udid
+func (m Model) fetchKeychainCmd(udid string, app simulator.App) tea.Cmd {
+	return func() tea.Msg {
+		items, err := simulator.GetKeychainItems(udid, app.BundleID)
+		return fetchKeychainMsg{items: items, err: err}
+	}
+}
+
+// fetchAppGroupsMsg is sent when an app's app groups have been read
+type fetchAppGroupsMsg struct {
+	groups []simulator.AppGroup
+	err    error
+}
+
+// fetchAppGroupsCmd reads the app groups of an app on simulator udid
+func (m Model) fetchAppGroupsCmd(udid string, app simulator.App) tea.Cmd {
+	return func() tea.Msg {
+		groups, err := simulator.GetAppGroups(udid, app)
+		return fetchAppGroupsMsg{groups: groups, err: err}
+	}
+}
+
+// fetchDiffMsg is sent when the diff between two files is computed
+type fetchDiffMsg struct {
+	lines []simulator.DiffLine
+	err   error
+}
+
+// fetchDiffCmd computes the diff from the file at oldPath to the one at
+// newPath
+func (m Model) fetchDiffCmd(oldPath, newPath string) tea.Cmd {
+	return func() tea.Msg {
+		lines, err := simulator.ComputeDiff(oldPath, newPath)
+		return fetchDiffMsg{lines: lines, err: err}
+	}
+}
+
+// fetchXattrsMsg is sent when a file's extended attributes are read
+type fetchXattrsMsg struct {
+	path   string
+	xattrs map[string]string
+	err    error
+}
+
+// fetchXattrsCmd reads the extended attributes of the file at path
+func (m Model) fetchXattrsCmd(path string) tea.Cmd {
+	return func() tea.Msg {
+		xattrs, err := simulator.ReadXattrs(path)
+		return fetchXattrsMsg{path: path, xattrs: xattrs, err: err}
+	}
+}
+
+// duplicateFileMsg is sent when a file or directory has been copied
+type duplicateFileMsg struct {
+	name string // Name of the original
+	path string // Path of the copy
+	err  error
+}
+
+// duplicateFileCmd copies file next to it in its directory
+func (m Model) duplicateFileCmd(file simulator.FileInfo) tea.Cmd {
+	return func() tea.Msg {
+		path, err := simulator.DuplicateFile(file.Path)
+		return duplicateFileMsg{name: file.Name, path: path, err: err}
+	}
+}
+
+// renameFileMsg is sent when a file or directory has been renamed
+type renameFileMsg struct {
+	oldPath string
+	newPath string
+	err     error
+}
+
+// renameFileCmd renames the file at path to name, in the same directory
+func (m Model) renameFileCmd(path, name string) tea.Cmd {
+	return func() tea.Msg {
+		newPath, err := simulator.RenameFile(path, name)
+		return renameFileMsg{oldPath: path, newPath: newPath, err: err}
+	}
+}
+
+// deleteFileMsg is sent when a file or directory has been deleted
+type deleteFileMsg struct {
+	name string
+	path string
+	err  error
+}
+
+// deleteFileCmd deletes file, and everything in it if it's a directory
+func (m Model) deleteFileCmd(file simulator.FileInfo) tea.Cmd {
+	return func() tea.Msg {
+		err := simulator.DeleteFile(file.Path)
+		return deleteFileMsg{name: file.Name, path: file.Path, err: err}
+	}
+}
+
+// notificationInfoMsg is sent when an app's notification settings are read
+type notificationInfoMsg struct {
+	appName string
+	info    *simulator.NotificationInfo
+	err     error
+}
+
+// fetchNotificationInfoCmd reads the notification settings for an app
+func (m Model) fetchNotificationInfoCmd(app simulator.App) tea.Cmd {
+	return func() tea.Msg {
+		info, err := simulator.GetNotificationInfo(app.Container)
+		return notificationInfoMsg{appName: app.Name, info: info, err: err}
+	}
+}
+
+// openURLMsg is sent when a URL has been opened in a simulator
+type openURLMsg struct {
+	url string
+	err error
+}
+
+// openURLCmd opens url in the booted simulator with udid
+func openURLCmd(udid, url string) tea.Cmd {
+	return func() tea.Msg {
+		return openURLMsg{url: url, err: simulator.OpenURL(udid, url)}
+	}
+}
+
+// sendPushMsg is sent when a push notification has been delivered to
+// the simulator
+type sendPushMsg struct {
+	app string
+	err error
+}
+
+// sendPushCmd sends the APNs payload to app on simulator udid
+func sendPushCmd(udid string, app simulator.App, payload string) tea.Cmd {
+	return func() tea.Msg {
+		err := simulator.SendPushNotification(udid, app.BundleID, payload)
+		return sendPushMsg{app: app.Name, err: err}
+	}
+}
+
+// appPermissionsMsg is sent when an app's privacy permissions are read
+type appPermissionsMsg struct {
+	app         simulator.App
+	permissions []simulator.Permission
+	err         error
+}
+
+// fetchAppPermissionsCmd reads the privacy permissions of an app
+func (m Model) fetchAppPermissionsCmd(udid string, app simulator.App) tea.Cmd {
+	return func() tea.Msg {
+		permissions, err := simulator.GetAppPermissions(udid, app.BundleID)
+		return appPermissionsMsg{app: app, permissions: permissions, err: err}
+	}
+}
+
+// setAppPermissionMsg is sent when a permission has been granted or
+// revoked
+type setAppPermissionMsg struct {
+	service string
+	status  string // The permission's status after the change
+	err     error
+}
+
+// setAppPermissionCmd grants or revokes an app's access to service
+func (m Model) setAppPermissionCmd(udid, bundleID, service string, grant bool) tea.Cmd {
+	return func() tea.Msg {
+		action, status := "revoke", simulator.PermissionDenied
+		if grant {
+			action, status = "grant", simulator.PermissionGranted
+		}
+		err := simulator.SetAppPermission(udid, bundleID, service, action)
+		return setAppPermissionMsg{service: service, status: status, err: err}
+	}
+}
+
+// memoryStatsRefreshInterval is how often the resources panel rereads
+// the simulator's memory usage
+const memoryStatsRefreshInterval = 5 * time.Second
+
+// fetchMemoryStatsMsg is sent when a simulator's memory usage is read.
+// seq identifies the opening of the resources panel it was read for.
+type fetchMemoryStatsMsg struct {
+	udid  string
+	seq   int
+	stats *simulator.MemoryStats
+	err   error
+}
+
+// fetchMemoryStatsCmd reads the memory usage of simulator udid after
+// delay, or right away if delay is zero
+func (m Model) fetchMemoryStatsCmd(udid string, seq int, delay time.Duration) tea.Cmd {
+	fetch := func() tea.Msg {
+		stats, err := simulator.GetMemoryStats(udid)
+		return fetchMemoryStatsMsg{udid: udid, seq: seq, stats: stats, err: err}
+	}
+	if delay == 0 {
+		return fetch
+	}
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return fetch()
+	})
+}
+
+// fetchTreeMsg is sent when the file tree below a directory has been read
+type fetchTreeMsg struct {
+	root  string
+	nodes []simulator.TreeNode
+	err   error
+}
+
+// fetchTreeCmd reads the file tree below root
+func (m Model) fetchTreeCmd(root string) tea.Cmd {
+	return func() tea.Msg {
+		nodes, err := simulator.ReadContainerTree(root, treeDefaultDepth)
+		return fetchTreeMsg{root: root, nodes: nodes, err: err}
+	}
+}
+
+// fetchTreeChildrenMsg is sent when a collapsed directory past the
+// initial depth has been read
+type fetchTreeChildrenMsg struct {
+	path     string
+	children []simulator.TreeNode
+	err      error
+}
+
+// fetchTreeChildrenCmd reads the contents of a tree directory
+func (m Model) fetchTreeChildrenCmd(dir simulator.TreeNode) tea.Cmd {
+	return func() tea.Msg {
+		children, err := simulator.ReadTreeChildren(dir, treeDefaultDepth)
+		return fetchTreeChildrenMsg{path: dir.Path, children: children, err: err}
+	}
+}
+
+// uninstallAppsMsg is sent when a batch uninstall has finished
+type uninstallAppsMsg struct {
+	removed int
+	err     error // First failure, if any
+}
+
+// uninstallAppsCmd uninstalls apps from a booted simulator one by one,
+// carrying on past failures so one stuck app doesn't block the rest
+func (m Model) uninstallAppsCmd(udid string, apps []simulator.App) tea.Cmd {
+	return func() tea.Msg {
+		var msg uninstallAppsMsg
+		for _, app := range apps {
+			if err := simulator.UninstallApp(udid, app.BundleID); err != nil {
+				if msg.err == nil {
+					msg.err = err
+				}
+				continue
+			}
+			msg.removed++
+		}
+		return msg
+	}
+}
@@ -1,10 +1,17 @@
 package tui
 
 import (
+	"fmt"
+	"image"
+	"image/png"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
+
 	"github.com/azizuysal/simtool/internal/simulator"
 )
 
@@ -16,6 +23,11 @@ type mockFetcher struct {
 	bootErr    error
 	bootCalled bool
 	bootUDID   string
+
+	shutdownErr  error
+	shutdownUDID string
+
+	runtimeFilter simulator.RuntimeFilter
 }
 
 func (m *mockFetcher) Fetch() ([]simulator.Item, error) {
@@ -32,6 +44,15 @@ func (m *mockFetcher) Boot(udid string) error {
 	return m.bootErr
 }
 
+func (m *mockFetcher) Shutdown(udid string) error {
+	m.shutdownUDID = udid
+	return m.shutdownErr
+}
+
+func (m *mockFetcher) SetRuntimeFilter(filter simulator.RuntimeFilter) {
+	m.runtimeFilter = filter
+}
+
 func TestNew(t *testing.T) {
 	fetcher := &mockFetcher{}
 
@@ -66,6 +87,26 @@ func TestNew(t *testing.T) {
 			t.Error("Expected allApps.loading to be true when starting with all apps")
 		}
 	})
+
+	t.Run("warns about legacy config keys", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv("XDG_CONFIG_HOME", dir)
+		if err := os.MkdirAll(filepath.Join(dir, "simtool"), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "simtool", "config.toml"), []byte("[syntax]\ntheme = \"dracula\"\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		model := New(fetcher, false)
+
+		if !strings.Contains(model.statusMessage, "syntax.theme") || !strings.Contains(model.statusMessage, "--migrate-config") {
+			t.Errorf("statusMessage = %q, want a warning naming syntax.theme", model.statusMessage)
+		}
+		if model.config.Theme.DarkTheme != "dracula" {
+			t.Errorf("dark theme = %q, want 'dracula'", model.config.Theme.DarkTheme)
+		}
+	})
 }
 
 func TestInit(t *testing.T) {
@@ -254,7 +295,7 @@ func TestFetchFileContentCmd(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			model := Model{height: tt.height}
-			cmd := model.fetchFileContentCmd(tt.path, tt.offset)
+			cmd := model.fetchFileContentCmd(tt.path, tt.offset, model.width, model.height)
 
 			if cmd == nil {
 				t.Error("Expected fetchFileContentCmd to return a command")
@@ -263,6 +304,55 @@ func TestFetchFileContentCmd(t *testing.T) {
 	}
 }
 
+func TestFetchFileContentCmdDimensions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "square.png")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(f, image.NewRGBA(image.Rect(0, 0, 400, 400))); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+
+	previewSize := func(cmd tea.Cmd) (int, int) {
+		t.Helper()
+		msg, ok := cmd().(fetchFileContentMsg)
+		if !ok || msg.err != nil || msg.content.ImageInfo == nil || msg.content.ImageInfo.Preview == nil {
+			t.Fatalf("fetchFileContentCmd() = %+v, want an image preview", msg)
+		}
+		preview := msg.content.ImageInfo.Preview
+		return preview.Width, preview.Height
+	}
+
+	// The model's own size is ignored in favour of the one passed in
+	m := Model{width: 200, height: 60}
+	smallW, smallH := previewSize(m.fetchFileContentCmd(path, 0, 60, 40))
+	bigW, bigH := previewSize(m.fetchFileContentCmd(path, 0, 160, 60))
+	if smallW > 60 || smallH > 40 {
+		t.Errorf("preview for 60x40 is %dx%d, larger than the terminal", smallW, smallH)
+	}
+	if bigW <= smallW || bigH <= smallH {
+		t.Errorf("preview for 160x60 is %dx%d, want larger than %dx%d for 60x40", bigW, bigH, smallW, smallH)
+	}
+
+	// Resizing while an image is shown fetches it again at the new size
+	m = testModelWithKeyMap()
+	m.viewState = FileViewerView
+	m.fileViewer.file = &simulator.FileInfo{Name: "square.png", Path: path}
+	m.fileViewer.content = &simulator.FileContent{Type: simulator.FileTypeImage}
+	got, cmd := m.Update(tea.WindowSizeMsg{Width: 160, Height: 60})
+	if cmd == nil {
+		t.Fatal("resizing should fetch the image again")
+	}
+	if w, h := previewSize(cmd); w != bigW || h != bigH {
+		t.Errorf("preview after resize is %dx%d, want %dx%d", w, h, bigW, bigH)
+	}
+	if _, cmd := asModel(t, got).Update(tea.WindowSizeMsg{Width: 160, Height: 60}); cmd != nil {
+		t.Error("a size message with an unchanged size shouldn't fetch again")
+	}
+}
+
 func TestModelState(t *testing.T) {
 	model := Model{
 		viewState: SimulatorListView,
@@ -459,3 +549,178 @@ func TestNewModelThemeMode(t *testing.T) {
 		t.Errorf("Expected currentThemeMode to be 'dark' or 'light', got %q", model.currentThemeMode)
 	}
 }
+
+func TestUpdateViewport_EndShowsLastItem(t *testing.T) {
+	name := func(i int) string { return fmt.Sprintf("Item%02d", i) }
+	app := &simulator.App{Name: "App", BundleID: "com.example.app"}
+	views := []struct {
+		name  string
+		setup func(m Model, n int) Model
+	}{
+		{"simulators", func(m Model, n int) Model {
+			m.viewState = SimulatorListView
+			for i := range n {
+				m.simList.simulators = append(m.simList.simulators, simulator.Item{Simulator: simulator.Simulator{Name: name(i), UDID: name(i)}})
+			}
+			return m
+		}},
+		{"apps", func(m Model, n int) Model {
+			m.viewState = AppListView
+			m.appList.selectedSim = &simulator.Item{}
+			for i := range n {
+				m.appList.apps = append(m.appList.apps, simulator.App{Name: name(i), BundleID: name(i)})
+			}
+			return m
+		}},
+		{"all apps", func(m Model, n int) Model {
+			m.viewState = AllAppsView
+			for i := range n {
+				m.allApps.apps = append(m.allApps.apps, simulator.App{Name: name(i), BundleID: name(i)})
+			}
+			return m
+		}},
+		{"files", func(m Model, n int) Model {
+			m.viewState = FileListView
+			m.fileList.selectedApp = app
+			for i := range n {
+				m.fileList.files = append(m.fileList.files, simulator.FileInfo{Name: name(i), Path: "/" + name(i)})
+			}
+			return m
+		}},
+		{"files in a folder", func(m Model, n int) Model {
+			m.viewState = FileListView
+			m.fileList.selectedApp = app
+			m.fileList.breadcrumbs = []string{"Documents"}
+			for i := range n {
+				m.fileList.files = append(m.fileList.files, simulator.FileInfo{Name: name(i), Path: "/" + name(i)})
+			}
+			return m
+		}},
+		{"crash logs", func(m Model, n int) Model {
+			m.viewState = CrashLogsView
+			m.crashLogs.app = app
+			for i := range n {
+				m.crashLogs.logs = append(m.crashLogs.logs, simulator.CrashLog{Name: name(i)})
+			}
+			return m
+		}},
+		{"URL cache", func(m Model, n int) Model {
+			m.viewState = URLCacheView
+			m.urlCache.app = app
+			for i := range n {
+				m.urlCache.entries = append(m.urlCache.entries, simulator.URLCacheEntry{URL: "https://" + name(i)})
+			}
+			return m
+		}},
+		{"app groups", func(m Model, n int) Model {
+			m.viewState = AppGroupsView
+			m.appGroups.app = app
+			for i := range n {
+				m.appGroups.groups = append(m.appGroups.groups, simulator.AppGroup{Identifier: name(i)})
+			}
+			return m
+		}},
+	}
+
+	for _, view := range views {
+		// 18 lines is the smallest terminal that fits the file list's
+		// header and breadcrumbs above one file
+		for height := 18; height <= 48; height++ {
+			for n := 1; n <= 20; n++ {
+				m := view.setup(testModelWithKeyMap(), n)
+				m.height = height
+				m.width = 100
+				got, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnd})
+				if out := asModel(t, got).View(); !strings.Contains(out, name(n-1)) {
+					t.Errorf("%s: height %d, %d items: last item not visible after end", view.name, height, n)
+				}
+			}
+		}
+	}
+}
+
+func TestAppSizesCmd(t *testing.T) {
+	bundle := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bundle, "App"), make([]byte, 100), 0644); err != nil {
+		t.Fatal(err)
+	}
+	apps := []simulator.App{
+		{Name: "Measured", Path: "/bundles/Measured.app", Size: 42},
+		{Name: "NoBundle", Size: simulator.AppSizeUnknown},
+		{Name: "App", Path: bundle, Size: simulator.AppSizeUnknown},
+		{Name: "App on another simulator", Path: bundle, Size: simulator.AppSizeUnknown},
+	}
+
+	// A single command isn't wrapped in a batch
+	msg, ok := appSizesCmd(apps)().(appSizeUpdatedMsg)
+	if !ok {
+		t.Fatal("only the unmeasured bundle should be measured, once")
+	}
+	if msg.path != bundle || msg.size < 100 {
+		t.Errorf("msg = %+v, want %s with at least 100 bytes", msg, bundle)
+	}
+	if cmd := appSizesCmd(apps[:2]); cmd != nil {
+		t.Error("no command expected when every size is known or has no bundle")
+	}
+}
+
+func TestAppSizeUpdatedMsg(t *testing.T) {
+	apps := fakeApps()
+	for i := range apps {
+		apps[i].Path = "/bundles/" + apps[i].Name + ".app"
+		apps[i].Size = simulator.AppSizeUnknown
+	}
+	all := append([]simulator.App(nil), apps...)
+	selected := apps[1]
+	m := Model{
+		viewState: FileListView,
+		appList:   appListState{apps: apps},
+		allApps:   allAppsState{apps: all},
+		fileList:  fileListState{selectedApp: &selected},
+	}
+
+	got, _ := m.Update(appSizeUpdatedMsg{path: "/bundles/AppB.app", size: 2048})
+	gm := asModel(t, got)
+	if gm.appList.apps[0].Size != simulator.AppSizeUnknown || gm.appList.apps[1].Size != 2048 {
+		t.Errorf("app list sizes = %d, %d; want unknown, 2048", gm.appList.apps[0].Size, gm.appList.apps[1].Size)
+	}
+	if gm.allApps.apps[1].Size != 2048 {
+		t.Errorf("all apps size = %d, want 2048", gm.allApps.apps[1].Size)
+	}
+	if gm.fileList.selectedApp.Size != 2048 {
+		t.Errorf("selected app size = %d, want 2048", gm.fileList.selectedApp.Size)
+	}
+}
+
+func TestAppSizeUpdatedMsg_KeepsCursorOnApp(t *testing.T) {
+	sim := fakeSims()[1]
+	apps := fakeApps()
+	for i := range apps {
+		apps[i].Path = "/bundles/" + apps[i].Name + ".app"
+		apps[i].Size = simulator.AppSizeUnknown
+	}
+	m := testModelWithKeyMap()
+	m.viewState = AppListView
+	m.height = 30
+	m.appList = appListState{
+		selectedSim:  &sim,
+		apps:         apps,
+		cursor:       0, // AppA
+		sortOrder:    simulator.AppSortBySize,
+		multiSelect:  true,
+		selectedApps: map[string]bool{"/bundles/AppA.app": true},
+	}
+
+	// AppB is measured first and moves above AppA
+	got, _ := m.Update(appSizeUpdatedMsg{path: "/bundles/AppB.app", size: 2048})
+	gm := asModel(t, got)
+	if got := appNames(gm.getFilteredAndSearchedApps()); got != "AppB,AppA" {
+		t.Fatalf("apps = %s, want AppB first", got)
+	}
+	if app := gm.getFilteredAndSearchedApps()[gm.appList.cursor]; app.Name != "AppA" {
+		t.Errorf("cursor on %s, want it to stay on AppA", app.Name)
+	}
+	if view := gm.View(); !strings.Contains(view, "✓ AppA") || strings.Contains(view, "✓ AppB") {
+		t.Errorf("the selection should follow AppA:\n%s", view)
+	}
+}
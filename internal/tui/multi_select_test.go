@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func multiSelectModel() Model {
+	sim := fakeSims()[1] // Booted
+	m := testModelWithKeyMap()
+	m.viewState = AppListView
+	apps := fakeApps()
+	for i := range apps {
+		apps[i].Path = "/bundles/" + apps[i].Name + ".app"
+	}
+	m.appList = appListState{selectedSim: &sim, apps: apps}
+	return m
+}
+
+func TestMultiSelect_ToggleAndCancel(t *testing.T) {
+	m := multiSelectModel()
+
+	got, _ := m.handleAppListKey("multi_select")
+	gm := asModel(t, got)
+	if !gm.appList.multiSelect {
+		t.Fatal("m should enter multi-select mode")
+	}
+
+	// Space toggles without moving the cursor
+	got, _ = gm.handleAppListKey("open")
+	gm = asModel(t, got)
+	if !gm.appList.selectedApps["/bundles/AppA.app"] || gm.appList.cursor != 0 {
+		t.Errorf("selectedApps = %v, cursor = %d", gm.appList.selectedApps, gm.appList.cursor)
+	}
+	got, _ = gm.handleAppListKey("down")
+	gm = asModel(t, got)
+	got, _ = gm.handleAppListKey("open")
+	gm = asModel(t, got)
+	if len(gm.appList.selectedApps) != 2 {
+		t.Errorf("selectedApps = %v, want both", gm.appList.selectedApps)
+	}
+	got, _ = gm.handleAppListKey("open")
+	gm = asModel(t, got)
+	if gm.appList.selectedApps["/bundles/AppB.app"] {
+		t.Error("second toggle should deselect")
+	}
+
+	got, _ = gm.handleAppListKey("escape")
+	gm = asModel(t, got)
+	if gm.appList.multiSelect || gm.appList.selectedApps != nil {
+		t.Error("escape should leave multi-select and clear the selection")
+	}
+}
+
+func TestMultiSelect_DeleteRequiresSelectionAndBootedSim(t *testing.T) {
+	m := multiSelectModel()
+	m.appList.multiSelect = true
+	m.appList.selectedApps = map[string]bool{}
+
+	got, _ := m.handleAppListKey("delete_selected")
+	if gm := asModel(t, got); gm.statusMessage != "No apps selected" {
+		t.Errorf("statusMessage = %q", gm.statusMessage)
+	}
+
+	shutdown := fakeSims()[0]
+	m.appList.selectedSim = &shutdown
+	m.appList.selectedApps["/bundles/AppA.app"] = true
+	got, _ = m.handleAppListKey("delete_selected")
+	if gm := asModel(t, got); !strings.Contains(gm.statusMessage, "Boot the simulator") || gm.appList.confirmUninstall {
+		t.Errorf("statusMessage = %q, confirm = %v", gm.statusMessage, gm.appList.confirmUninstall)
+	}
+}
+
+func TestMultiSelect_ConfirmUninstall(t *testing.T) {
+	m := multiSelectModel()
+	m.appList.multiSelect = true
+	m.appList.selectedApps = map[string]bool{"/bundles/AppA.app": true, "/bundles/AppB.app": true}
+
+	got, _ := m.handleAppListKey("delete_selected")
+	gm := asModel(t, got)
+	if !gm.appList.confirmUninstall {
+		t.Fatal("D should prompt for confirmation")
+	}
+	_, _, _, status := gm.renderAppListView()
+	if !strings.Contains(status, "Uninstall 2 selected apps") {
+		t.Errorf("status = %q", status)
+	}
+
+	// Any key other than y cancels
+	got, cmd := gm.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	cancelled := asModel(t, got)
+	if cancelled.appList.confirmUninstall || cmd != nil || !cancelled.appList.multiSelect {
+		t.Error("n should cancel the prompt and stay in multi-select")
+	}
+
+	got, cmd = gm.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	gm = asModel(t, got)
+	if !gm.appList.uninstalling || cmd == nil {
+		t.Error("y should start the uninstall")
+	}
+}
+
+func TestHandleUninstallApps(t *testing.T) {
+	m := multiSelectModel()
+	m.appList.multiSelect = true
+	m.appList.selectedApps = map[string]bool{"/bundles/AppA.app": true}
+	m.appList.uninstalling = true
+
+	got, cmd := m.Update(uninstallAppsMsg{removed: 2})
+	gm := asModel(t, got)
+	if gm.appList.multiSelect || gm.appList.uninstalling {
+		t.Error("multi-select should end after uninstalling")
+	}
+	if gm.statusMessage != "Uninstalled 2 apps successfully" {
+		t.Errorf("statusMessage = %q", gm.statusMessage)
+	}
+	if !gm.appList.loading || cmd == nil {
+		t.Error("expected the app list to reload")
+	}
+}
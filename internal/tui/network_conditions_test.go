@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestHandleFetchSimulators_NetworkConditions(t *testing.T) {
+	m := Model{viewState: SimulatorListView, height: 30}
+
+	// The first load queries the status bars right away
+	got, cmd := m.Update(fetchSimulatorsMsg{simulators: fakeSims()})
+	gm := asModel(t, got)
+	if cmd == nil {
+		t.Fatal("first simulator load should query network conditions")
+	}
+
+	got, _ = gm.Update(networkConditionsMsg{conditions: map[string]string{"udid-15": "LTE"}})
+	gm = asModel(t, got)
+	if c := gm.simList.simulators[1].NetworkCondition; c != "LTE" {
+		t.Errorf("booted simulator NetworkCondition = %q, want LTE", c)
+	}
+	if c := gm.simList.simulators[0].NetworkCondition; c != "" {
+		t.Errorf("shutdown simulator NetworkCondition = %q, want empty", c)
+	}
+
+	got, _ = gm.Update(pairingsMsg{pairings: map[string]simulator.Pairing{
+		"udid-15": {PairedDevice: "Apple Watch Series 9", Active: true, Connected: true},
+	}})
+	gm = asModel(t, got)
+	if sim := gm.simList.simulators[1]; sim.PairedDevice != "Apple Watch Series 9" || !sim.IsConnected {
+		t.Errorf("paired simulator PairedDevice = %q, IsConnected = %v", sim.PairedDevice, sim.IsConnected)
+	}
+
+	// Later refreshes keep the cached condition and pairing and leave
+	// querying to the tick
+	got, cmd = gm.Update(fetchSimulatorsMsg{simulators: fakeSims()})
+	gm = asModel(t, got)
+	if cmd != nil {
+		t.Error("refresh after the first load should not query network conditions")
+	}
+	if c := gm.simList.simulators[1].NetworkCondition; c != "LTE" {
+		t.Errorf("after refresh NetworkCondition = %q, want cached LTE", c)
+	}
+	if !gm.simList.simulators[1].IsConnected {
+		t.Error("after refresh IsConnected = false, want the cached pairing")
+	}
+}
+
+func TestHandleFetchSimulators_RuntimeWarning(t *testing.T) {
+	m := Model{viewState: SimulatorListView, height: 30}
+	sims := fakeSims()
+	sims[2].RuntimeUnsupported = true
+
+	gm, _ := m.handleFetchSimulators(fetchSimulatorsMsg{simulators: sims})
+	if !strings.HasPrefix(gm.statusMessage, "Warning") {
+		t.Fatalf("statusMessage = %q, want the runtime warning", gm.statusMessage)
+	}
+
+	// The warning is only shown for the first load
+	gm.statusMessage = ""
+	gm, _ = gm.handleFetchSimulators(fetchSimulatorsMsg{simulators: sims})
+	if gm.statusMessage != "" {
+		t.Errorf("statusMessage = %q after a refresh, want none", gm.statusMessage)
+	}
+
+	gm, _ = m.handleFetchSimulators(fetchSimulatorsMsg{simulators: fakeSims()})
+	if gm.statusMessage != "" {
+		t.Errorf("statusMessage = %q without unsupported runtimes, want none", gm.statusMessage)
+	}
+}
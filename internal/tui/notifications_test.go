@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestHandleAppListKey_InspectNotifications(t *testing.T) {
+	apps := fakeApps()
+	apps[1].Container = "/containers/b"
+	m := Model{viewState: AppListView, appList: appListState{apps: apps, cursor: 1}}
+	_, cmd := m.handleAppListKey("inspect_notifications")
+	if cmd == nil {
+		t.Error("expected fetchNotificationInfoCmd")
+	}
+
+	apps[1].Container = ""
+	m.appList.apps = apps
+	got, _ := m.handleAppListKey("inspect_notifications")
+	if gm := asModel(t, got); !strings.Contains(gm.statusMessage, "no data container") {
+		t.Errorf("statusMessage = %q", gm.statusMessage)
+	}
+}
+
+func TestHandleNotificationInfo(t *testing.T) {
+	t.Run("settings shown in panel", func(t *testing.T) {
+		m := Model{viewState: AppListView}
+		info := &simulator.NotificationInfo{AuthorizationStatus: "authorized"}
+		got, _ := m.Update(notificationInfoMsg{appName: "AppA", info: info})
+		gm := asModel(t, got)
+		if gm.appList.notifications == nil || gm.appList.notifications.info != info {
+			t.Fatalf("notifications = %+v", gm.appList.notifications)
+		}
+	})
+
+	t.Run("missing settings still open the panel", func(t *testing.T) {
+		m := Model{viewState: AppListView}
+		err := fmt.Errorf("notification settings: %w", os.ErrNotExist)
+		got, _ := m.Update(notificationInfoMsg{appName: "AppA", err: err})
+		gm := asModel(t, got)
+		if gm.appList.notifications == nil || gm.appList.notifications.info != nil {
+			t.Errorf("notifications = %+v", gm.appList.notifications)
+		}
+	})
+
+	t.Run("other errors flash", func(t *testing.T) {
+		m := Model{viewState: AppListView}
+		got, _ := m.Update(notificationInfoMsg{appName: "AppA", err: errors.New("bad plist")})
+		gm := asModel(t, got)
+		if gm.appList.notifications != nil || !strings.Contains(gm.statusMessage, "Error reading notification settings") {
+			t.Errorf("notifications = %+v, status = %q", gm.appList.notifications, gm.statusMessage)
+		}
+	})
+}
+
+func TestNotificationPanel_AnyKeyCloses(t *testing.T) {
+	m := Model{
+		viewState: AppListView,
+		appList:   appListState{apps: fakeApps(), notifications: &notificationOverlay{appName: "AppA"}},
+	}
+	got, _ := m.handleAppListKey("down")
+	gm := asModel(t, got)
+	if gm.appList.notifications != nil {
+		t.Error("panel should close")
+	}
+	if gm.appList.cursor != 0 {
+		t.Error("the closing key should not also move the cursor")
+	}
+}
@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func fakePermissions() []simulator.Permission {
+	return []simulator.Permission{
+		{Service: "camera", Label: "Camera", Status: simulator.PermissionGranted},
+		{Service: "location", Label: "Location (while in use)", Status: simulator.PermissionUnknown},
+	}
+}
+
+func TestHandleAppListKey_ViewPermissions(t *testing.T) {
+	sim := fakeSims()[1]
+	m := Model{viewState: AppListView, appList: appListState{apps: fakeApps(), selectedSim: &sim}}
+	if _, cmd := m.handleAppListKey("view_permissions"); cmd == nil {
+		t.Error("expected fetchAppPermissionsCmd")
+	}
+
+	got, _ := m.Update(appPermissionsMsg{app: fakeApps()[0], permissions: fakePermissions()})
+	gm := asModel(t, got)
+	if gm.appList.permissions == nil || len(gm.appList.permissions.permissions) != 2 {
+		t.Fatalf("permissions = %+v", gm.appList.permissions)
+	}
+
+	got, _ = m.Update(appPermissionsMsg{err: errors.New("no access table")})
+	if gm := asModel(t, got); gm.appList.permissions != nil || !strings.Contains(gm.statusMessage, "Error reading permissions") {
+		t.Errorf("permissions = %+v, status = %q", gm.appList.permissions, gm.statusMessage)
+	}
+}
+
+func TestPermissionsPanel_Toggle(t *testing.T) {
+	sim := fakeSims()[1]
+	m := testModelWithKeyMap()
+	m.viewState = AppListView
+	m.appList = appListState{
+		apps:        fakeApps(),
+		selectedSim: &sim,
+		permissions: &permissionsOverlay{app: fakeApps()[0], permissions: fakePermissions()},
+	}
+	if view := m.View(); !strings.Contains(view, "[x] Camera") || !strings.Contains(view, "AppA Permissions") {
+		t.Errorf("View() should show the permissions panel:\n%s", view)
+	}
+
+	// Moving within the panel leaves the app list cursor alone
+	got, _ := m.handleAppListKey("down")
+	m = asModel(t, got)
+	if m.appList.permissions.cursor != 1 || m.appList.cursor != 0 {
+		t.Fatalf("panel cursor = %d, app cursor = %d", m.appList.permissions.cursor, m.appList.cursor)
+	}
+
+	got, cmd := m.handleAppListKey("open")
+	m = asModel(t, got)
+	if !m.appList.permissions.updating || cmd == nil {
+		t.Fatal("open should grant the permission")
+	}
+
+	// Location isn't readable, so the panel keeps the status it set
+	got, _ = m.Update(setAppPermissionMsg{service: "location", status: simulator.PermissionGranted})
+	m = asModel(t, got)
+	got, _ = m.Update(appPermissionsMsg{app: fakeApps()[0], permissions: fakePermissions()})
+	m = asModel(t, got)
+	if status := m.appList.permissions.permissions[1].Status; status != simulator.PermissionGranted {
+		t.Errorf("location status = %q after granting, want granted", status)
+	}
+	if m.appList.permissions.updating || m.appList.permissions.cursor != 1 {
+		t.Errorf("updating = %v, cursor = %d after refresh", m.appList.permissions.updating, m.appList.permissions.cursor)
+	}
+
+	got, _ = m.Update(setAppPermissionMsg{service: "camera", err: errors.New("simctl failed")})
+	if m = asModel(t, got); !strings.HasPrefix(m.statusMessage, "Error") {
+		t.Errorf("statusMessage = %q", m.statusMessage)
+	}
+
+	got, _ = m.handleAppListKey("escape")
+	if m = asModel(t, got); m.appList.permissions != nil {
+		t.Error("escape should close the panel")
+	}
+}
@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestHandleAppListKey_SendPushNotification(t *testing.T) {
+	apps := fakeApps()
+	sim := fakeSims()[1]
+	m := testModelWithKeyMap()
+	m.viewState = AppListView
+	m.appList = appListState{apps: apps, selectedSim: &sim}
+
+	got, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlP})
+	m = asModel(t, got)
+	if m.appList.push == nil || m.appList.push.payload != simulator.PushPayloadTemplate {
+		t.Fatalf("push = %+v, want the editor with the payload template", m.appList.push)
+	}
+	if _, content, _, _ := m.renderAppListView(); !strings.Contains(content, "Push Notification to "+apps[0].Name) {
+		t.Errorf("content missing the push editor:\n%s", content)
+	}
+
+	// Typed keys go to the payload, including ones bound to actions
+	m.appList.push.payload = ""
+	for _, msg := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("{q")},
+		{Type: tea.KeyEnter},
+		{Type: tea.KeySpace},
+		{Type: tea.KeyRunes, Runes: []rune("}x")},
+		{Type: tea.KeyBackspace},
+	} {
+		got, _ = m.handleKeyPress(msg)
+		m = asModel(t, got)
+	}
+	if want := "{q\n }"; m.appList.push.payload != want {
+		t.Errorf("payload = %q, want %q", m.appList.push.payload, want)
+	}
+
+	got, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlP})
+	m = asModel(t, got)
+	if cmd == nil || !m.appList.push.sending {
+		t.Fatal("ctrl+p should send the payload")
+	}
+
+	got, _ = m.Update(sendPushMsg{app: apps[0].Name, err: errors.New("invalid payload")})
+	m = asModel(t, got)
+	if m.appList.push == nil || m.appList.push.sending || !strings.HasPrefix(m.statusMessage, "Error") {
+		t.Errorf("failed send: push = %+v, status = %q; want the editor kept open", m.appList.push, m.statusMessage)
+	}
+
+	got, _ = m.Update(sendPushMsg{app: apps[0].Name})
+	m = asModel(t, got)
+	if m.appList.push != nil || m.statusMessage != "Push notification sent to "+apps[0].Name {
+		t.Errorf("sent: push = %+v, status = %q", m.appList.push, m.statusMessage)
+	}
+}
+
+func TestHandleAppListKey_SendPushNotification_Refused(t *testing.T) {
+	sim := fakeSims()[0]
+	m := Model{viewState: AppListView, appList: appListState{apps: fakeApps(), selectedSim: &sim}}
+
+	got, _ := m.handleAppListKey("send_push_notification")
+	if gm := asModel(t, got); gm.appList.push != nil || !strings.Contains(gm.statusMessage, "Boot the simulator") {
+		t.Errorf("shutdown simulator: push = %+v, status = %q", gm.appList.push, gm.statusMessage)
+	}
+}
+
+func TestHandlePushInput_Escape(t *testing.T) {
+	m := testModelWithKeyMap()
+	m.viewState = AppListView
+	m.appList = appListState{apps: fakeApps(), push: &pushOverlay{app: fakeApps()[0], payload: "{}"}}
+
+	got, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	if gm := asModel(t, got); gm.appList.push != nil {
+		t.Error("escape should close the push editor")
+	}
+}
@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestRenamePrompt(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"notes.txt", "todo.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m := testModelWithKeyMap()
+	m.viewState = FileListView
+	m.fileList = fileListState{
+		files:       []simulator.FileInfo{{Name: "notes.txt", Path: filepath.Join(dir, "notes.txt")}},
+		currentPath: dir,
+		basePath:    dir,
+	}
+
+	got, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m = asModel(t, got)
+	if !m.fileList.renameMode || m.fileList.renameName != "notes.txt" {
+		t.Fatalf("renameMode = %v, renameName = %q; want the prompt filled with the name", m.fileList.renameMode, m.fileList.renameName)
+	}
+
+	// Replace the name with one that is taken
+	for range "notes.txt" {
+		got, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+		m = asModel(t, got)
+	}
+	got, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("todo.txt")})
+	got, cmd := asModel(t, got).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = asModel(t, got)
+	if cmd != nil || !m.fileList.renameMode || !strings.Contains(m.fileList.renameError, "already exists") {
+		t.Errorf("renameMode = %v, renameError = %q; want the prompt kept open with the error", m.fileList.renameMode, m.fileList.renameError)
+	}
+	if !strings.Contains(m.View(), "already exists") {
+		t.Error("the view should show why the name can't be used")
+	}
+
+	got, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	got, cmd = asModel(t, got).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m = asModel(t, got); m.fileList.renameMode || cmd == nil {
+		t.Fatalf("renameMode = %v; want the prompt closed and renameFileCmd", m.fileList.renameMode)
+	}
+	msg, ok := cmd().(renameFileMsg)
+	if !ok || msg.err != nil || msg.newPath != filepath.Join(dir, "todo.tx") {
+		t.Errorf("cmd() = %#v, want a rename to todo.tx", msg)
+	}
+}
+
+func TestHandleRenameFile(t *testing.T) {
+	m := testModelWithKeyMap()
+	m.viewState = FileListView
+	m.fileList = fileListState{
+		files:          fakeFiles(),
+		currentPath:    "/path/a",
+		basePath:       "/path/a",
+		cursorMemory:   map[string]int{"/path/a": 1, "/path/a/Caches": 4, "/path/a/Caches/images": 2, "/path/a/CachesOld": 3},
+		viewportMemory: map[string]int{"/path/a/Caches": 1},
+	}
+
+	got, cmd := m.Update(renameFileMsg{oldPath: "/path/a/Caches", newPath: "/path/a/Old"})
+	gm := asModel(t, got)
+	if cmd == nil || gm.fileList.selectPath != "/path/a/Old" {
+		t.Errorf("selectPath = %q; want a refresh selecting the renamed directory", gm.fileList.selectPath)
+	}
+	want := map[string]int{"/path/a": 1, "/path/a/Old": 4, "/path/a/Old/images": 2, "/path/a/CachesOld": 3}
+	if len(gm.fileList.cursorMemory) != len(want) {
+		t.Errorf("cursorMemory = %v, want %v", gm.fileList.cursorMemory, want)
+	}
+	for path, cursor := range want {
+		if gm.fileList.cursorMemory[path] != cursor {
+			t.Errorf("cursorMemory = %v, want %v", gm.fileList.cursorMemory, want)
+			break
+		}
+	}
+	if gm.fileList.viewportMemory["/path/a/Old"] != 1 {
+		t.Errorf("viewportMemory = %v, want the directory's entry moved", gm.fileList.viewportMemory)
+	}
+
+	got, _ = m.Update(renameFileMsg{oldPath: "/path/a/readme.txt", err: errors.New("permission denied")})
+	if gm := asModel(t, got); !strings.Contains(gm.statusMessage, "Error renaming readme.txt") {
+		t.Errorf("status = %q, want the error flashed", gm.statusMessage)
+	}
+}
@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestHandleAppListKey_ViewResources(t *testing.T) {
+	sims := fakeSims()
+
+	t.Run("booted simulator opens the panel", func(t *testing.T) {
+		m := Model{viewState: AppListView, appList: appListState{selectedSim: &sims[1], apps: fakeApps()}}
+		got, cmd := m.handleAppListKey("view_resources")
+		gm := asModel(t, got)
+		if gm.appList.resources == nil || gm.appList.resources.simName != sims[1].Name {
+			t.Fatalf("resources = %+v", gm.appList.resources)
+		}
+		if gm.appList.resourcesSeq != 1 {
+			t.Errorf("resourcesSeq = %d, want 1", gm.appList.resourcesSeq)
+		}
+		if cmd == nil {
+			t.Error("expected fetchMemoryStatsCmd")
+		}
+	})
+
+	t.Run("shut down simulator flashes", func(t *testing.T) {
+		m := Model{viewState: AppListView, appList: appListState{selectedSim: &sims[0], apps: fakeApps()}}
+		got, _ := m.handleAppListKey("view_resources")
+		gm := asModel(t, got)
+		if gm.appList.resources != nil || !strings.Contains(gm.statusMessage, "Boot the simulator") {
+			t.Errorf("resources = %+v, status = %q", gm.appList.resources, gm.statusMessage)
+		}
+	})
+}
+
+func TestHandleFetchMemoryStats(t *testing.T) {
+	stats := &simulator.MemoryStats{UsedMB: 4096}
+
+	t.Run("reading shown and refresh scheduled", func(t *testing.T) {
+		m := Model{viewState: AppListView, appList: appListState{resources: &resourcesOverlay{simName: "iPhone"}, resourcesSeq: 2}}
+		got, cmd := m.Update(fetchMemoryStatsMsg{udid: "udid-15", seq: 2, stats: stats})
+		gm := asModel(t, got)
+		if gm.appList.resources.stats != stats {
+			t.Errorf("stats = %+v", gm.appList.resources.stats)
+		}
+		if cmd == nil {
+			t.Error("expected the next refresh to be scheduled")
+		}
+	})
+
+	t.Run("errors shown in panel", func(t *testing.T) {
+		m := Model{viewState: AppListView, appList: appListState{resources: &resourcesOverlay{simName: "iPhone"}, resourcesSeq: 1}}
+		got, _ := m.Update(fetchMemoryStatsMsg{udid: "udid-15", seq: 1, err: errors.New("vm_stat failed")})
+		if gm := asModel(t, got); gm.appList.resources.err == nil {
+			t.Error("expected the error to be kept for the panel")
+		}
+	})
+
+	t.Run("stale reading ends the refresh loop", func(t *testing.T) {
+		m := Model{viewState: AppListView, appList: appListState{resources: &resourcesOverlay{simName: "iPhone"}, resourcesSeq: 3}}
+		got, cmd := m.Update(fetchMemoryStatsMsg{udid: "udid-15", seq: 2, stats: stats})
+		if gm := asModel(t, got); gm.appList.resources.stats != nil || cmd != nil {
+			t.Errorf("stale reading applied: stats = %+v, cmd = %v", gm.appList.resources.stats, cmd != nil)
+		}
+	})
+
+	t.Run("closed panel ends the refresh loop", func(t *testing.T) {
+		m := Model{viewState: AppListView, appList: appListState{resourcesSeq: 1}}
+		if _, cmd := m.Update(fetchMemoryStatsMsg{udid: "udid-15", seq: 1, stats: stats}); cmd != nil {
+			t.Error("no refresh should be scheduled once the panel is closed")
+		}
+	})
+}
+
+func TestResourcesPanel_AnyKeyCloses(t *testing.T) {
+	m := Model{
+		viewState: AppListView,
+		appList:   appListState{apps: fakeApps(), resources: &resourcesOverlay{simName: "iPhone"}},
+	}
+	got, _ := m.handleAppListKey("down")
+	gm := asModel(t, got)
+	if gm.appList.resources != nil {
+		t.Error("panel should close")
+	}
+	if gm.appList.cursor != 0 {
+		t.Error("the closing key should not also move the cursor")
+	}
+}
@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestHandleSimulatorListKey_CaptureScreen(t *testing.T) {
+	m := testModelWithKeyMap()
+	m.viewState = SimulatorListView
+	m.simList.simulators = fakeSims()
+
+	// Shut down simulators have no screen to capture
+	got, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	gm := asModel(t, got)
+	if gm.simList.capturing || !strings.Contains(gm.statusMessage, "Boot the simulator") {
+		t.Errorf("capturing = %v, status = %q; want the boot hint", gm.simList.capturing, gm.statusMessage)
+	}
+
+	m.simList.cursor = 1
+	got, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	gm = asModel(t, got)
+	if !gm.simList.capturing || cmd == nil {
+		t.Fatalf("capturing = %v; want a screenshot of the booted simulator", gm.simList.capturing)
+	}
+
+	// A second press while capturing is ignored
+	if _, cmd := gm.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}}); cmd != nil {
+		t.Error("capture_screen should wait for the running capture")
+	}
+}
+
+func TestHandleCaptureScreen(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	m := Model{viewState: SimulatorListView}
+	m.simList.capturing = true
+
+	gm, _ := m.handleCaptureScreen(captureScreenMsg{path: filepath.Join(home, "Desktop", "iPhone-15-2026-01-02-150405.png")})
+	if gm.simList.capturing || gm.statusMessage != "Screenshot saved to ~/Desktop/iPhone-15-2026-01-02-150405.png" {
+		t.Errorf("capturing = %v, status = %q", gm.simList.capturing, gm.statusMessage)
+	}
+
+	gm, _ = m.handleCaptureScreen(captureScreenMsg{err: errors.New("simctl failed")})
+	if gm.simList.capturing || !strings.HasPrefix(gm.statusMessage, "Error") {
+		t.Errorf("capturing = %v, status = %q; want an error", gm.simList.capturing, gm.statusMessage)
+	}
+}
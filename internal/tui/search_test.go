@@ -1,8 +1,12 @@
 package tui
 
 import (
+	"fmt"
+	"strconv"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
+
 	"github.com/azizuysal/simtool/internal/simulator"
 )
 
@@ -236,3 +240,122 @@ func TestGetFilteredAndSearchedApps(t *testing.T) {
 		})
 	}
 }
+
+func TestFindNearestCursor(t *testing.T) {
+	full := []string{"a", "b", "c", "d", "e"}
+	id := func(s string) string { return s }
+
+	tests := []struct {
+		name      string
+		oldCursor int
+		filtered  []string
+		want      int
+	}{
+		{"selected item still matches", 3, []string{"b", "d"}, 1},
+		{"moves to the nearest match above", 2, []string{"a", "b", "e"}, 1},
+		{"top when nothing above matches", 1, []string{"d", "e"}, 0},
+		{"empty results", 2, nil, 0},
+		{"cursor past the end of full", 9, []string{"a", "e"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findNearestCursor(tt.oldCursor, tt.filtered, full, id); got != tt.want {
+				t.Errorf("findNearestCursor(%d, %v) = %d, want %d", tt.oldCursor, tt.filtered, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchInputKeepsSelection(t *testing.T) {
+	m := testModelWithKeyMap()
+	m.simList.simulators = fakeSims()
+	m.simList.searchMode = true
+	m.simList.cursor = 1 // iPhone 15
+
+	got, _ := m.handleSimulatorSearchInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+	m = asModel(t, got)
+	if m.simList.cursor != 1 {
+		t.Errorf("cursor after typing %q = %d, want 1 (iPhone 15)", m.simList.searchQuery, m.simList.cursor)
+	}
+	// "17" leaves out iPhone 14, so iPhone 15 moves up to the top
+	got, _ = m.handleSimulatorSearchInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("7")})
+	m = asModel(t, got)
+	if m.simList.cursor != 0 {
+		t.Errorf("cursor after typing %q = %d, want 0 (iPhone 15)", m.simList.searchQuery, m.simList.cursor)
+	}
+	got, _ = m.handleSimulatorSearchInput(tea.KeyMsg{Type: tea.KeyBackspace})
+	m = asModel(t, got)
+	if m.simList.cursor != 1 {
+		t.Errorf("cursor after backspace = %d, want 1 (iPhone 15)", m.simList.cursor)
+	}
+
+	m.appList.apps = fakeApps()
+	m.appList.searchMode = true
+	m.appList.cursor = 1 // AppB
+	got, _ = m.handleAppSearchInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	m = asModel(t, got)
+	if m.appList.cursor != 0 {
+		t.Errorf("cursor after typing %q = %d, want 0 (AppB)", m.appList.searchQuery, m.appList.cursor)
+	}
+}
+
+func TestFilteredSimulatorsCache(t *testing.T) {
+	m := testModelWithKeyMap()
+	sims := fakeSims()
+	sims[1].AppCount = 2
+	got, _ := m.Update(fetchSimulatorsMsg{simulators: sims})
+	m = asModel(t, got)
+	if n := len(m.getFilteredAndSearchedSimulators()); n != 3 {
+		t.Fatalf("%d simulators after fetch, want 3", n)
+	}
+
+	got, _ = m.handleSimulatorListKey("filter")
+	m = asModel(t, got)
+	if filtered := m.getFilteredAndSearchedSimulators(); len(filtered) != 1 || filtered[0].UDID != "udid-15" {
+		t.Errorf("filter should leave only iPhone 15, got %v", filtered)
+	}
+	got, _ = m.handleSimulatorListKey("filter")
+	m = asModel(t, got)
+
+	got, _ = m.handleSimulatorListKey("search")
+	m = asModel(t, got)
+	got, _ = m.handleSimulatorSearchInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m = asModel(t, got)
+	if filtered := m.getFilteredAndSearchedSimulators(); len(filtered) != 1 || filtered[0].UDID != "udid-ip" {
+		t.Errorf("searching for a should leave only iPad Pro, got %v", filtered)
+	}
+	got, _ = m.handleSimulatorSearchInput(tea.KeyMsg{Type: tea.KeyEscape})
+	m = asModel(t, got)
+	if n := len(m.getFilteredAndSearchedSimulators()); n != 3 {
+		t.Errorf("%d simulators after leaving search, want 3", n)
+	}
+
+	got, _ = m.Update(fetchSimulatorsMsg{simulators: sims[:1]})
+	m = asModel(t, got)
+	if n := len(m.getFilteredAndSearchedSimulators()); n != 1 {
+		t.Errorf("%d simulators after a refresh to 1, want 1", n)
+	}
+}
+
+// BenchmarkSimulatorListKeyPress measures a cursor move and redraw of a
+// long, filtered and searched simulator list
+func BenchmarkSimulatorListKeyPress(b *testing.B) {
+	m := testModelWithKeyMap()
+	var sims []simulator.Item
+	for i := range 500 {
+		sims = append(sims, simulator.Item{
+			Simulator: simulator.Simulator{Name: fmt.Sprintf("iPhone %d", i), UDID: strconv.Itoa(i)},
+			Runtime:   "iOS 17.0",
+			AppCount:  i % 3,
+		})
+	}
+	got, _ := m.Update(fetchSimulatorsMsg{simulators: sims})
+	got, _ = got.(Model).handleSimulatorListKey("filter")
+	m = got.(Model)
+	m = m.setSimulatorSearchQuery("iphone 1")
+
+	for b.Loop() {
+		got, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		_ = got.View()
+	}
+}
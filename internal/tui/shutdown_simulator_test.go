@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestHandleSimulatorListKey_Shutdown(t *testing.T) {
+	fetcher := &mockFetcher{}
+	m := testModelWithKeyMap()
+	m.fetcher = fetcher
+	m.viewState = SimulatorListView
+	m.simList.simulators = fakeSims()
+
+	// Only running simulators can be shut down
+	got, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	gm := asModel(t, got)
+	if gm.simList.shuttingDown || !strings.Contains(gm.statusMessage, "not running") {
+		t.Errorf("shuttingDown = %v, status = %q; want the not running hint", gm.simList.shuttingDown, gm.statusMessage)
+	}
+	if cmd == nil {
+		t.Error("the hint should be cleared after a while")
+	}
+
+	m.simList.cursor = 1
+	got, cmd = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	gm = asModel(t, got)
+	if !gm.simList.shuttingDown || gm.statusMessage != "Shutting down iPhone 15..." {
+		t.Fatalf("shuttingDown = %v, status = %q; want a shutdown in progress", gm.simList.shuttingDown, gm.statusMessage)
+	}
+	msg, ok := cmd().(shutdownSimulatorMsg)
+	if !ok || msg.udid != "udid-15" || fetcher.shutdownUDID != "udid-15" {
+		t.Errorf("cmd() = %+v, fetcher shut down %q; want udid-15", msg, fetcher.shutdownUDID)
+	}
+
+	// A second press waits for the first shutdown to finish
+	if _, cmd := gm.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}}); cmd != nil {
+		t.Error("no command expected while a shutdown is in progress")
+	}
+}
+
+func TestHandleShutdownSimulator(t *testing.T) {
+	m := Model{fetcher: &mockFetcher{}}
+	m.simList.shuttingDown = true
+
+	gm, _ := m.handleShutdownSimulator(shutdownSimulatorMsg{udid: "udid-15", err: errors.New("boom")})
+	if gm.simList.shuttingDown || !strings.Contains(gm.statusMessage, "boom") {
+		t.Errorf("shuttingDown = %v, status = %q; want the error", gm.simList.shuttingDown, gm.statusMessage)
+	}
+
+	gm, cmd := m.handleShutdownSimulator(shutdownSimulatorMsg{udid: "udid-15"})
+	if gm.simList.shuttingDown || gm.statusMessage != "Simulator shut down successfully!" {
+		t.Errorf("shuttingDown = %v, status = %q; want the success message", gm.simList.shuttingDown, gm.statusMessage)
+	}
+	if cmd == nil {
+		t.Error("expected a simulator refresh")
+	}
+}
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+// sortTestSims returns simulators sorted by name, as the fetcher
+// returns them
+func sortTestSims() []simulator.Item {
+	return []simulator.Item{
+		{Simulator: simulator.Simulator{Name: "Apple Watch", UDID: "w", State: "Shutdown"}, Runtime: "watchOS 10.0", AppCount: 0},
+		{Simulator: simulator.Simulator{Name: "iPad Air", UDID: "a", State: "Booted"}, Runtime: "iOS 9.3", AppCount: 2},
+		{Simulator: simulator.Simulator{Name: "iPhone 14", UDID: "14", State: "Shutdown"}, Runtime: "iOS 17.0", AppCount: 5},
+		{Simulator: simulator.Simulator{Name: "iPhone 15", UDID: "15", State: "Booted"}, Runtime: "iOS 17.2", AppCount: 2},
+	}
+}
+
+// simNames joins the names of sims with commas
+func simNames(sims []simulator.Item) string {
+	names := make([]string, len(sims))
+	for i, sim := range sims {
+		names[i] = sim.Name
+	}
+	return strings.Join(names, ",")
+}
+
+func TestHandleSimulatorListKey_Sort(t *testing.T) {
+	m := testModelWithKeyMap()
+	m.simList.simulators = sortTestSims()
+	m = m.refilterSimulators()
+	m.simList.cursor = 2 // iPhone 14
+
+	var orders []string
+	for range int(simulator.SimSortOrderCount) {
+		got, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+		m = asModel(t, got)
+		orders = append(orders, m.simList.sortOrder.String())
+		if sim := m.getFilteredAndSearchedSimulators()[m.simList.cursor]; sim.UDID != "14" {
+			t.Errorf("%s: selected %s, want iPhone 14 to stay selected", m.simList.sortOrder, sim.Name)
+		}
+	}
+	if got := strings.Join(orders, ","); got != "runtime,state,apps,name" {
+		t.Errorf("sort cycle = %s", got)
+	}
+
+	// Booting acts on the simulator shown under the cursor
+	m.simList.sortOrder = simulator.SimSortByState
+	m = m.refilterSimulators()
+	m.simList.cursor = 2
+	got, _ := m.handleSimulatorListKey("boot")
+	if gm := asModel(t, got); gm.statusMessage != "Booting Apple Watch..." {
+		t.Errorf("status = %q, want Apple Watch booted", gm.statusMessage)
+	}
+}
+
+// sortTestApps returns a simulator's apps sorted by name, as the app
+// list fetch returns them
+func sortTestApps() []simulator.App {
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []simulator.App{
+		{Name: "Alpha", BundleID: "com.z.alpha", Size: 100, ModTime: day},
+		{Name: "Beta", BundleID: "com.a.beta", Size: simulator.AppSizeUnknown, ModTime: day.Add(48 * time.Hour)},
+		{Name: "Delta", BundleID: "com.a.delta", Size: 100, ModTime: day.Add(24 * time.Hour)},
+		{Name: "Gamma", BundleID: "com.m.gamma", Size: 300, ModTime: day},
+	}
+}
+
+func TestHandleAppListKey_Sort(t *testing.T) {
+	sim := fakeSims()[0]
+	m := testModelWithKeyMap()
+	m.viewState = AppListView
+	m.appList = appListState{selectedSim: &sim, apps: sortTestApps(), cursor: 3} // Gamma
+
+	var orders []string
+	for range len(appListSortOrders) {
+		got, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+		m = asModel(t, got)
+		orders = append(orders, m.appList.sortOrder.String())
+		if app := m.getFilteredAndSearchedApps()[m.appList.cursor]; app.Name != "Gamma" {
+			t.Errorf("%s: selected %s, want Gamma to stay selected", m.appList.sortOrder, app.Name)
+		}
+	}
+	if got := strings.Join(orders, ","); got != "size,bundle ID,modified,name" {
+		t.Errorf("sort cycle = %s", got)
+	}
+	if footer := m.View(); !strings.Contains(footer, "s: sort (name)") {
+		t.Errorf("the footer should show the sort order:\n%s", footer)
+	}
+
+	// Entering an app opens the one shown under the cursor
+	m.appList.sortOrder = simulator.AppSortBySize
+	m.appList.cursor = 0
+	got, _ := m.handleAppListKey("right")
+	if gm := asModel(t, got); gm.fileList.selectedApp == nil || gm.fileList.selectedApp.Name != "Gamma" {
+		t.Errorf("selected app = %v, want Gamma", gm.fileList.selectedApp)
+	}
+}
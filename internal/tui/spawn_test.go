@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestHandleSimulatorListKey_SpawnCommand(t *testing.T) {
+	m := testModelWithKeyMap()
+	m.viewState = SimulatorListView
+	m.simList.simulators = fakeSims()
+
+	got, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'!'}})
+	if gm := asModel(t, got); gm.simList.spawnMode || !strings.Contains(gm.statusMessage, "Boot the simulator") {
+		t.Errorf("shutdown simulator: spawnMode = %v, status = %q", gm.simList.spawnMode, gm.statusMessage)
+	}
+
+	m.simList.cursor = 1
+	got, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'!'}})
+	m = asModel(t, got)
+	if !m.simList.spawnMode {
+		t.Fatal("spawn_command should open the command prompt")
+	}
+
+	// Typed keys go to the command, including ones bound to actions
+	for _, msg := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("ls")},
+		{Type: tea.KeySpace, Runes: []rune{' '}},
+		{Type: tea.KeyRunes, Runes: []rune("/tmpq")},
+		{Type: tea.KeyBackspace},
+	} {
+		got, _ = m.handleKeyPress(msg)
+		m = asModel(t, got)
+	}
+	if m.simList.spawnCommand != "ls /tmp" {
+		t.Errorf("spawnCommand = %q, want %q", m.simList.spawnCommand, "ls /tmp")
+	}
+	if _, _, _, status := m.renderSimulatorListView(); !strings.Contains(status, "Run: ls /tmp") {
+		t.Errorf("status = %q", status)
+	}
+
+	got, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = asModel(t, got)
+	if m.simList.spawnMode || !m.simList.spawning || cmd == nil {
+		t.Errorf("enter should close the prompt and run the command")
+	}
+}
+
+func TestHandleSpawn(t *testing.T) {
+	m := testModelWithKeyMap()
+	m.viewState = SimulatorListView
+	m.simList.spawning = true
+
+	got, _ := m.Update(spawnMsg{err: errors.New("no command to run")})
+	m = asModel(t, got)
+	if m.viewState != SimulatorListView || m.simList.spawning || !strings.HasPrefix(m.statusMessage, "Error") {
+		t.Errorf("failed spawn: view = %v, status = %q", m.viewState, m.statusMessage)
+	}
+
+	result := simulator.SpawnResult{Command: "ls /tmp", Output: "a.txt\nb.txt\n", ExitCode: 1}
+	got, _ = m.Update(spawnMsg{simName: "iPhone 15", result: result})
+	m = asModel(t, got)
+	if m.viewState != SpawnResultView || m.statusMessage != "ls /tmp exited with status 1" {
+		t.Fatalf("view = %v, status = %q; want the spawn result", m.viewState, m.statusMessage)
+	}
+	title, content, _, _ := m.renderSpawnResultView()
+	if title != "iPhone 15: ls /tmp" || !strings.Contains(content, "a.txt") || !strings.Contains(content, "b.txt") {
+		t.Errorf("title = %q, content:\n%s", title, content)
+	}
+
+	// The exit status stays in view once the message is cleared
+	got, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyDown})
+	m = asModel(t, got)
+	if _, _, _, status := m.renderSpawnResultView(); !strings.Contains(status, "exited with status 1") {
+		t.Errorf("status = %q", status)
+	}
+
+	got, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	if m = asModel(t, got); m.viewState != SimulatorListView {
+		t.Errorf("escape should return to the simulator list, view = %v", m.viewState)
+	}
+}
@@ -1,15 +1,21 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/azizuysal/simtool/internal/config"
 	"github.com/azizuysal/simtool/internal/simulator"
+	"github.com/azizuysal/simtool/internal/tui/components"
+	"github.com/azizuysal/simtool/internal/tui/components/file_viewer"
 	"github.com/azizuysal/simtool/internal/ui"
 )
 
@@ -52,8 +58,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		return m.handleKeyPress(msg)
 	case tea.WindowSizeMsg:
+		resized := msg.Width != m.width || msg.Height != m.height
 		m.height = msg.Height
 		m.width = msg.Width
+		// Image previews are drawn to fit the terminal, so redraw them
+		// at the new size
+		if resized && m.viewState == FileViewerView && m.fileViewer.file != nil &&
+			m.fileViewer.content != nil && m.fileViewer.content.Type == simulator.FileTypeImage {
+			return m.updateViewport(), m.fetchFileContentCmd(m.fileViewer.file.Path, 0, m.width, m.height)
+		}
 		return m.updateViewport(), nil
 	case fetchSimulatorsMsg:
 		return m.handleFetchSimulators(msg)
@@ -63,6 +76,65 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleFetchAllApps(msg)
 	case bootSimulatorMsg:
 		return m.handleBootSimulator(msg)
+	case shutdownSimulatorMsg:
+		return m.handleShutdownSimulator(msg)
+	case deleteSimulatorMsg:
+		return m.handleDeleteSimulator(msg)
+	case fetchRuntimesMsg:
+		return m.handleFetchRuntimes(msg)
+	case fetchDeviceTypesMsg:
+		return m.handleFetchDeviceTypes(msg)
+	case createSimulatorMsg:
+		return m.handleCreateSimulator(msg)
+	case openSimulatorAppMsg:
+		if msg.err != nil {
+			return m.flashStatus(fmt.Sprintf("Error: %v", msg.err), 3*time.Second)
+		}
+		return m, nil
+	case cloneSimulatorMsg:
+		return m.handleCloneSimulator(msg)
+	case fetchSearchResultsMsg:
+		return m.handleFetchSearchResults(msg)
+	case openInEditorMsg:
+		return m.handleOpenInEditor(msg)
+	case exportContainerMsg:
+		return m.handleExportContainer(msg)
+	case exportTableMsg:
+		return m.handleExportTable(msg)
+	case exportTableProgressMsg:
+		if !m.dbContent.exporting {
+			return m, nil
+		}
+		m.statusMessage = fmt.Sprintf("Exported %d rows...", m.dbContent.exportRows.Load())
+		return m, exportTableProgressCmd()
+	case exportSpinnerMsg:
+		if m.appList.exporting == "" {
+			return m, nil
+		}
+		m.appList.exportFrame++
+		return m, exportSpinnerCmd()
+	case captureScreenMsg:
+		return m.handleCaptureScreen(msg)
+	case spawnMsg:
+		return m.handleSpawn(msg)
+	case sendPushMsg:
+		return m.handleSendPush(msg)
+	case copyToClipboardMsg:
+		if msg.err != nil {
+			return m.flashStatus(fmt.Sprintf("Error copying to clipboard: %v", msg.err), 3*time.Second)
+		}
+		if msg.path {
+			return m.flashStatus("Path copied!", 2*time.Second)
+		}
+		return m.flashStatus("Content copied to clipboard", 2*time.Second)
+	case networkConditionsMsg:
+		m.simList.networkConditions = msg.conditions
+		m.simList.simulators = m.applyNetworkConditions(m.simList.simulators)
+		return m.refilterSimulators(), nil
+	case pairingsMsg:
+		m.simList.pairings = msg.pairings
+		m.simList.simulators = m.applyNetworkConditions(m.simList.simulators)
+		return m.refilterSimulators(), nil
 	case clearStatusMsg:
 		m.statusMessage = ""
 		return m, nil
@@ -75,14 +147,77 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleTick()
 	case themeChangedMsg:
 		return m.handleThemeChanged(msg)
+	case reloadConfigMsg:
+		return m.handleReloadConfig(msg)
 	case fetchFilesMsg:
 		return m.handleFetchFiles(msg)
 	case fetchDatabaseInfoMsg:
 		return m.handleFetchDatabaseInfo(msg)
 	case fetchTableDataMsg:
 		return m.handleFetchTableData(msg)
+	case tableSearchMsg:
+		return m.handleTableSearch(msg)
 	case fetchFileContentMsg:
 		return m.handleFetchFileContent(msg)
+	case fetchTreeMsg:
+		return m.handleFetchTree(msg)
+	case fetchTreeChildrenMsg:
+		return m.handleFetchTreeChildren(msg)
+	case logStreamStartedMsg:
+		return m.handleLogStreamStarted(msg)
+	case logLineMsg:
+		return m.handleLogLine(msg)
+	case crashCountsMsg:
+		for i := range m.appList.apps {
+			m.appList.apps[i].CrashCount = msg.counts[m.appList.apps[i].BundleID]
+		}
+		return m, nil
+	case appSizeUpdatedMsg:
+		return m.handleAppSizeUpdated(msg), nil
+	case uninstallAppsMsg:
+		return m.handleUninstallApps(msg)
+	case vacuumDatabaseMsg:
+		return m.handleVacuumDatabase(msg)
+	case runQueryMsg:
+		return m.handleRunQuery(msg)
+	case notificationInfoMsg:
+		return m.handleNotificationInfo(msg)
+	case appPermissionsMsg:
+		return m.handleAppPermissions(msg)
+	case openURLMsg:
+		if msg.err != nil {
+			return m.flashStatus(fmt.Sprintf("Error: %v", msg.err), 3*time.Second)
+		}
+		return m.flashStatus(fmt.Sprintf("Opened %s", msg.url), 2*time.Second)
+	case setAppPermissionMsg:
+		return m.handleSetAppPermission(msg)
+	case fetchDiffMsg:
+		return m.handleFetchDiff(msg)
+	case fetchXattrsMsg:
+		return m.handleFetchXattrs(msg)
+	case duplicateFileMsg:
+		return m.handleDuplicateFile(msg)
+	case renameFileMsg:
+		return m.handleRenameFile(msg)
+	case deleteFileMsg:
+		return m.handleDeleteFile(msg)
+	case fetchMemoryStatsMsg:
+		return m.handleFetchMemoryStats(msg)
+	case fetchCrashLogsMsg:
+		return m.handleFetchCrashLogs(msg)
+	case fetchURLCacheMsg:
+		return m.handleFetchURLCache(msg)
+	case urlCacheBodyMsg:
+		return m.handleURLCacheBody(msg)
+	case fetchKeychainMsg:
+		return m.handleFetchKeychain(msg)
+	case fetchAppGroupsMsg:
+		return m.handleFetchAppGroups(msg)
+	case logStreamEndedMsg:
+		if msg.stream == m.logStream.stream {
+			m.logStream.ended = true
+		}
+		return m, nil
 	}
 	return m, nil
 }
@@ -90,7 +225,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // handleFetchSimulators processes the result of a simulator list fetch,
 // clamping the cursor into the new range and refreshing the viewport.
 func (m Model) handleFetchSimulators(msg fetchSimulatorsMsg) (Model, tea.Cmd) {
-	m.simList.simulators = msg.simulators
+	m.simList.simulators = m.applyNetworkConditions(msg.simulators)
+	m = m.refilterSimulators()
 	m.err = msg.err
 	m.simList.loading = false
 	if m.simList.cursor >= len(m.simList.simulators) {
@@ -99,7 +235,48 @@ func (m Model) handleFetchSimulators(msg fetchSimulatorsMsg) (Model, tea.Cmd) {
 	if m.simList.cursor < 0 && len(m.simList.simulators) > 0 {
 		m.simList.cursor = 0
 	}
-	return m.updateViewport(), nil
+	if m.simList.selectUDID != "" {
+		for i, sim := range m.getFilteredAndSearchedSimulators() {
+			if sim.UDID == m.simList.selectUDID {
+				m.simList.cursor = i
+				m.simList.selectUDID = ""
+				break
+			}
+		}
+	}
+	var cmds []tea.Cmd
+	if m.simList.networkConditions == nil && msg.err == nil {
+		// Query right after the first load rather than waiting for a tick
+		cmds = append(cmds, fetchNetworkConditionsCmd(m.simList.simulators))
+	}
+	if m.simList.pairings == nil && msg.err == nil {
+		cmds = append(cmds, fetchPairingsCmd())
+	}
+	if !m.simList.runtimeWarned && msg.err == nil {
+		// Warn once, when the list first loads
+		m.simList.runtimeWarned = true
+		for _, sim := range m.simList.simulators {
+			if sim.RuntimeUnsupported {
+				var cmd tea.Cmd
+				m, cmd = m.flashStatus("Warning: some simulator runtimes (⚠) are newer than the installed Xcode supports", 5*time.Second)
+				cmds = append(cmds, cmd)
+				break
+			}
+		}
+	}
+	return m.updateViewport(), tea.Batch(cmds...)
+}
+
+// applyNetworkConditions sets the cached network condition and pairing
+// on each simulator in sims.
+func (m Model) applyNetworkConditions(sims []simulator.Item) []simulator.Item {
+	for i := range sims {
+		sims[i].NetworkCondition = m.simList.networkConditions[sims[i].UDID]
+		pairing := m.simList.pairings[sims[i].UDID]
+		sims[i].PairedDevice = pairing.PairedDevice
+		sims[i].IsConnected = pairing.Connected
+	}
+	return sims
 }
 
 // handleFetchApps processes the result of a per-simulator app list
@@ -120,7 +297,7 @@ func (m Model) handleFetchApps(msg fetchAppsMsg) (Model, tea.Cmd) {
 	}
 	m.appList.cursor = 0
 	m.appList.viewport = 0
-	return m.updateViewport(), nil
+	return m.updateViewport(), tea.Batch(countCrashLogsCmd(msg.apps), appSizesCmd(msg.apps))
 }
 
 // handleFetchAllApps processes the result of the combined all-apps
@@ -131,21 +308,172 @@ func (m Model) handleFetchAllApps(msg fetchAllAppsMsg) (Model, tea.Cmd) {
 	m.allApps.loading = false
 	if msg.err != nil {
 		m.err = msg.err
-	} else {
-		m.allApps.cursor = 0
-		m.allApps.viewport = 0
+		return m.updateViewport(), nil
 	}
-	return m.updateViewport(), nil
+	m.allApps.cursor = 0
+	m.allApps.viewport = 0
+	return m.updateViewport(), appSizesCmd(msg.apps)
+}
+
+// handleAppSizeUpdated fills in a measured bundle size wherever the app
+// is shown
+func (m Model) handleAppSizeUpdated(msg appSizeUpdatedMsg) Model {
+	// A size can reorder a list sorted by size, so keep the cursor on
+	// the app it was on
+	var appPath, allAppsPath string
+	if apps := m.getFilteredAndSearchedApps(); m.appList.cursor < len(apps) {
+		appPath = apps[m.appList.cursor].Path
+	}
+	if apps := m.getFilteredAndSearchedAllApps(); m.allApps.cursor < len(apps) {
+		allAppsPath = apps[m.allApps.cursor].Path
+	}
+
+	for _, apps := range [][]simulator.App{m.appList.apps, m.allApps.apps} {
+		for i := range apps {
+			if apps[i].Path == msg.path {
+				apps[i].Size = msg.size
+			}
+		}
+	}
+	if app := m.fileList.selectedApp; app != nil && app.Path == msg.path {
+		updated := *app
+		updated.Size = msg.size
+		m.fileList.selectedApp = &updated
+	}
+
+	if i := appIndexByPath(m.getFilteredAndSearchedApps(), appPath); i >= 0 {
+		m.appList.cursor = i
+	}
+	if i := appIndexByPath(m.getFilteredAndSearchedAllApps(), allAppsPath); i >= 0 {
+		m.allApps.cursor = i
+	}
+	return m.updateViewport()
+}
+
+// appIndexByPath returns the index of the app at path in apps, or -1
+func appIndexByPath(apps []simulator.App, path string) int {
+	if path == "" {
+		return -1
+	}
+	return slices.IndexFunc(apps, func(app simulator.App) bool { return app.Path == path })
 }
 
 // handleBootSimulator processes the result of a boot command, batching
-// a simulator refresh and status clear on success.
+// a simulator refresh and status clear on success. A boot started by
+// open_simulator_app goes on to open the simulator's window.
 func (m Model) handleBootSimulator(msg bootSimulatorMsg) (Model, tea.Cmd) {
 	m.simList.booting = false
+	openAfterBoot := m.simList.openAfterBoot == msg.udid
+	m.simList.openAfterBoot = ""
 	if msg.err != nil {
 		return m.flashStatus(fmt.Sprintf("Error: %v", msg.err), 3*time.Second)
 	}
 	m.statusMessage = "Simulator booted successfully!"
+	cmds := []tea.Cmd{
+		fetchSimulatorsCmd(m.fetcher),
+		clearStatusAfter(3 * time.Second),
+	}
+	if openAfterBoot {
+		cmds = append(cmds, openSimulatorAppCmd(msg.udid))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// handleShutdownSimulator processes the result of a shutdown command,
+// refreshing the list so the simulator shows as shut down.
+func (m Model) handleShutdownSimulator(msg shutdownSimulatorMsg) (Model, tea.Cmd) {
+	m.simList.shuttingDown = false
+	if msg.err != nil {
+		return m.flashStatus(fmt.Sprintf("Error: %v", msg.err), 3*time.Second)
+	}
+	m.statusMessage = "Simulator shut down successfully!"
+	return m, tea.Batch(
+		fetchSimulatorsCmd(m.fetcher),
+		clearStatusAfter(3*time.Second),
+	)
+}
+
+// handleDeleteSimulator processes the result of a delete command,
+// refreshing the list without the deleted simulator.
+func (m Model) handleDeleteSimulator(msg deleteSimulatorMsg) (Model, tea.Cmd) {
+	m.simList.deleting = false
+	if msg.err != nil {
+		return m.flashStatus(fmt.Sprintf("Error: %v", msg.err), 3*time.Second)
+	}
+	m.statusMessage = fmt.Sprintf("Deleted %s successfully!", msg.name)
+	return m, tea.Batch(
+		fetchSimulatorsCmd(m.fetcher),
+		clearStatusAfter(3*time.Second),
+	)
+}
+
+// handleFetchRuntimes fills the first step of the new simulator form,
+// or closes the form if there is no runtime to create a simulator on.
+func (m Model) handleFetchRuntimes(msg fetchRuntimesMsg) (Model, tea.Cmd) {
+	if m.viewState != CreateSimulatorView || m.createSim.step != createStepRuntime {
+		return m, nil
+	}
+	m.createSim.loading = false
+	if msg.err != nil || len(msg.runtimes) == 0 {
+		m.viewState = SimulatorListView
+		m.createSim = createSimState{}
+		if msg.err != nil {
+			return m.flashStatus(fmt.Sprintf("Error: %v", msg.err), 3*time.Second)
+		}
+		return m.flashStatus("No iOS runtimes installed", 2*time.Second)
+	}
+	m.createSim.runtimes = msg.runtimes
+	return m, nil
+}
+
+// handleFetchDeviceTypes fills the device type step of the new
+// simulator form with the types that run the chosen runtime.
+func (m Model) handleFetchDeviceTypes(msg fetchDeviceTypesMsg) (Model, tea.Cmd) {
+	if m.viewState != CreateSimulatorView || m.createSim.step != createStepDeviceType {
+		return m, nil
+	}
+	m.createSim.loading = false
+	var deviceTypes []simulator.DeviceType
+	for _, deviceType := range msg.deviceTypes {
+		if deviceType.Supports(m.createSim.runtime) {
+			deviceTypes = append(deviceTypes, deviceType)
+		}
+	}
+	if msg.err != nil || len(deviceTypes) == 0 {
+		m = m.createSimStep(createStepRuntime)
+		if msg.err != nil {
+			return m.flashStatus(fmt.Sprintf("Error: %v", msg.err), 3*time.Second)
+		}
+		return m.flashStatus(fmt.Sprintf("No device types run %s", m.createSim.runtime.Name), 2*time.Second)
+	}
+	m.createSim.deviceTypes = deviceTypes
+	return m, nil
+}
+
+// handleCreateSimulator processes the result of a create command,
+// refreshing the list with the cursor on the new simulator.
+func (m Model) handleCreateSimulator(msg createSimulatorMsg) (Model, tea.Cmd) {
+	m.simList.creating = false
+	if msg.err != nil {
+		return m.flashStatus(fmt.Sprintf("Error: %v", msg.err), 3*time.Second)
+	}
+	m.simList.selectUDID = msg.udid
+	m.statusMessage = fmt.Sprintf("Created %s (%s) successfully!", msg.name, msg.udid)
+	return m, tea.Batch(
+		fetchSimulatorsCmd(m.fetcher),
+		clearStatusAfter(3*time.Second),
+	)
+}
+
+// handleCloneSimulator processes the result of a clone command,
+// refreshing the list with the cursor on the new simulator.
+func (m Model) handleCloneSimulator(msg cloneSimulatorMsg) (Model, tea.Cmd) {
+	m.simList.cloning = false
+	if msg.err != nil {
+		return m.flashStatus(fmt.Sprintf("Error: %v", msg.err), 3*time.Second)
+	}
+	m.simList.selectUDID = msg.udid
+	m.statusMessage = fmt.Sprintf("Cloned simulator as %s successfully!", msg.name)
 	return m, tea.Batch(
 		fetchSimulatorsCmd(m.fetcher),
 		clearStatusAfter(3*time.Second),
@@ -153,8 +481,8 @@ func (m Model) handleBootSimulator(msg bootSimulatorMsg) (Model, tea.Cmd) {
 }
 
 // handleTick runs on the 2-second periodic tick: refreshes simulator
-// state, re-schedules the next tick, and opportunistically polls the
-// terminal theme for a live switch.
+// state, status bar network conditions and pairings, re-schedules the next tick,
+// and opportunistically polls the terminal theme for a live switch.
 func (m Model) handleTick() (Model, tea.Cmd) {
 	cmds := []tea.Cmd{
 		fetchSimulatorsCmd(m.fetcher),
@@ -162,6 +490,12 @@ func (m Model) handleTick() (Model, tea.Cmd) {
 			return tickMsg(t)
 		}),
 	}
+	if m.simList.networkConditions != nil {
+		cmds = append(cmds, fetchNetworkConditionsCmd(m.simList.simulators))
+	}
+	if m.simList.pairings != nil {
+		cmds = append(cmds, fetchPairingsCmd())
+	}
 	if cmd := m.checkThemeChange(); cmd != nil {
 		cmds = append(cmds, cmd)
 	}
@@ -175,15 +509,53 @@ func (m Model) handleThemeChanged(msg themeChangedMsg) (Model, tea.Cmd) {
 	if err := ui.ReloadStyles(); err != nil {
 		return m.flashStatus(fmt.Sprintf("Failed to reload theme: %v", err), 2*time.Second)
 	}
+	// In auto mode the syntax theme follows the terminal too
+	simulator.ResetSyntaxStyle()
 	return m, nil
 }
 
+// handleReloadConfig swaps in a reloaded configuration: key bindings are
+// rebuilt, UI styles regenerated and the cached chroma style dropped so
+// text files pick up a new syntax theme on the next render.
+func (m Model) handleReloadConfig(msg reloadConfigMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.flashStatus(fmt.Sprintf("Error reloading config: %v", msg.err), 3*time.Second)
+	}
+	prevFilter := simulator.ParseRuntimeFilter(m.config.Display.Platforms)
+	m.config = msg.cfg
+	m.keyMap = config.NewKeyMap(msg.cfg.Keys)
+	m.binaryChunkSize = msg.cfg.Viewer.BinaryChunkSize
+	simulator.SetRowCountCacheTTL(time.Duration(msg.cfg.Viewer.RowCountCacheSeconds) * time.Second)
+	if err := ui.ReloadStyles(); err != nil {
+		return m.flashStatus(fmt.Sprintf("Error reloading styles: %v", err), 3*time.Second)
+	}
+	simulator.ResetSyntaxStyle()
+	m, cmd := m.flashStatus("Configuration reloaded successfully", 2*time.Second)
+	// Fetch the list again when it should show other platforms
+	if filter := simulator.ParseRuntimeFilter(msg.cfg.Display.Platforms); filter != prevFilter {
+		m.fetcher.SetRuntimeFilter(filter)
+		if !filter.Includes(m.simList.platform) {
+			m.simList.platform = 0
+		}
+		cmd = tea.Batch(cmd, fetchSimulatorsCmd(m.fetcher))
+	}
+	return m, cmd
+}
+
 // handleFetchFiles processes the result of a directory listing fetch,
 // restoring cursor/viewport positions saved when the user drilled into
 // the directory so going back to a parent lands on the previous entry.
 func (m Model) handleFetchFiles(msg fetchFilesMsg) (Model, tea.Cmd) {
-	m.fileList.files = msg.files
+	files := msg.files
+	if m.fileList.bundleResources {
+		files = simulator.FilterBundleResources(msg.files)
+	}
 	m.fileList.loading = false
+	m.fileList.databases = msg.databases
+	if len(msg.databases) > 0 {
+		files = append([]simulator.FileInfo{databasesEntry(msg.databases)}, files...)
+	}
+	m = m.setFiles(files)
 	if msg.err != nil {
 		m.viewState = AppListView
 		m.fileList.selectedApp = nil
@@ -191,7 +563,8 @@ func (m Model) handleFetchFiles(msg fetchFilesMsg) (Model, tea.Cmd) {
 		return m.flashStatus(fmt.Sprintf("Error loading files: %v", msg.err), 3*time.Second)
 	}
 	if m.fileList.cursorMemory != nil {
-		if cursor, ok := m.fileList.cursorMemory[m.fileList.currentPath]; ok {
+		key := filepath.Clean(m.fileList.currentPath)
+		if cursor, ok := m.fileList.cursorMemory[key]; ok {
 			m.fileList.cursor = cursor
 			if m.fileList.cursor >= len(m.fileList.files) {
 				m.fileList.cursor = len(m.fileList.files) - 1
@@ -202,7 +575,7 @@ func (m Model) handleFetchFiles(msg fetchFilesMsg) (Model, tea.Cmd) {
 		} else {
 			m.fileList.cursor = 0
 		}
-		if viewport, ok := m.fileList.viewportMemory[m.fileList.currentPath]; ok {
+		if viewport, ok := m.fileList.viewportMemory[key]; ok {
 			m.fileList.viewport = viewport
 		} else {
 			m.fileList.viewport = 0
@@ -211,12 +584,29 @@ func (m Model) handleFetchFiles(msg fetchFilesMsg) (Model, tea.Cmd) {
 		m.fileList.cursor = 0
 		m.fileList.viewport = 0
 	}
+	if m.fileList.selectPath != "" {
+		for i, file := range m.fileList.files {
+			if file.Path == m.fileList.selectPath {
+				m.fileList.cursor = i
+				break
+			}
+		}
+		m.fileList.selectPath = ""
+	}
 	return m.updateViewport(), nil
 }
 
 // handleFetchDatabaseInfo processes the result of a SQLite schema read.
 func (m Model) handleFetchDatabaseInfo(msg fetchDatabaseInfoMsg) (Model, tea.Cmd) {
 	m.dbTables.loading = false
+	if errors.Is(msg.err, simulator.ErrNoRealmTooling) && m.dbTables.file != nil {
+		// Show the Realm file as hex instead; the viewer explains why
+		file := *m.dbTables.file
+		m.dbTables.file = nil
+		m.fileViewer = fileViewerState{file: &file, loading: true}
+		m.viewState = FileViewerView
+		return m, m.fetchFileContentCmd(file.Path, 0, m.width, m.height)
+	}
 	if msg.err != nil {
 		m.viewState = FileListView
 		m.dbTables.file = nil
@@ -228,6 +618,10 @@ func (m Model) handleFetchDatabaseInfo(msg fetchDatabaseInfoMsg) (Model, tea.Cmd
 
 // handleFetchTableData processes a page of SQLite row data.
 func (m Model) handleFetchTableData(msg fetchTableDataMsg) (Model, tea.Cmd) {
+	if m.dbContent.searchQuery != "" {
+		// A page requested before the search started; the matches win
+		return m, nil
+	}
 	m.dbContent.loading = false
 	if msg.err != nil {
 		return m.flashStatus(fmt.Sprintf("Error loading table data: %v", msg.err), 3*time.Second)
@@ -237,122 +631,711 @@ func (m Model) handleFetchTableData(msg fetchTableDataMsg) (Model, tea.Cmd) {
 	return m.updateViewport(), nil
 }
 
+// handleTableSearch shows the rows matching a table search. Results for
+// a query that has since been edited are dropped.
+func (m Model) handleTableSearch(msg tableSearchMsg) (Model, tea.Cmd) {
+	if m.viewState != DatabaseTableContentView || msg.query != m.dbContent.searchQuery {
+		return m, nil
+	}
+	m.dbContent.loading = false
+	if msg.err != nil {
+		return m.flashStatus(fmt.Sprintf("Error searching table: %v", msg.err), 3*time.Second)
+	}
+	m.dbContent.data = msg.data
+	m.dbContent.matchCount = msg.total
+	m.dbContent.offset = 0
+	m.dbContent.viewport = 0
+	return m, nil
+}
+
 // handleFetchFileContent processes a chunk of file content for the
 // viewer: re-syncs the hex-dump offset for binary files and scans SVG
 // source for features the rasterizer can't render.
 func (m Model) handleFetchFileContent(msg fetchFileContentMsg) (Model, tea.Cmd) {
 	m.fileViewer.loading = false
 	if msg.err != nil {
-		m.viewState = FileListView
+		m.viewState = m.fileViewerParent()
 		m.fileViewer.file = nil
 		return m.flashStatus(fmt.Sprintf("Error loading file: %v", msg.err), 3*time.Second)
 	}
 	m.fileViewer.content = msg.content
-	if m.fileViewer.content.Type == simulator.FileTypeBinary {
+	// Number lines from where the chunk that arrived starts, which may
+	// not be the last one asked for if keys were pressed while loading
+	switch m.fileViewer.content.Type {
+	case simulator.FileTypeText:
+		m.fileViewer.contentOffset = m.fileViewer.content.LineOffset
+	case simulator.FileTypeBinary:
 		m.fileViewer.contentOffset = int(m.fileViewer.content.BinaryOffset / simulator.HexBytesPerLine)
 	}
+	if m.fileViewer.scrollToEnd {
+		m.fileViewer.scrollToEnd = false
+		m.fileViewer.contentViewport = m.fileViewerMaxViewport()
+	}
 	m.fileViewer.svgWarning = detectSVGWarning(m.fileViewer.file)
 	return m.updateViewport(), nil
 }
 
-// detectSVGWarning returns a non-empty warning string if the given file
-// is an SVG whose source references features (embedded raster images,
-// filters, foreign objects) that the rasterizer cannot render. Returns
-// "" for non-SVG files, missing files, or SVGs with no such features.
-func detectSVGWarning(file *simulator.FileInfo) string {
-	if file == nil || strings.ToLower(filepath.Ext(file.Path)) != ".svg" {
-		return ""
+// handleFetchTree shows a freshly read file tree with every loaded
+// directory expanded. Trees for a directory the user has since left
+// are dropped.
+func (m Model) handleFetchTree(msg fetchTreeMsg) (Model, tea.Cmd) {
+	if !m.fileList.treeView || msg.root != m.fileList.currentPath {
+		return m, nil
 	}
-	data, err := os.ReadFile(file.Path)
-	if err != nil {
-		return ""
+	m.fileList.treeLoading = false
+	if msg.err != nil {
+		m.fileList.treeView = false
+		return m.flashStatus(fmt.Sprintf("Error loading tree: %v", msg.err), 3*time.Second)
 	}
-	svg := string(data)
-	var features []string
-	if strings.Contains(svg, "data:image/") ||
-		strings.Contains(svg, "xlink:href=\"data:") ||
-		strings.Contains(svg, "xlink:href=\"http") {
-		features = append(features, "embedded images")
+	m.fileList.tree = msg.nodes
+	m.fileList.treeExpanded = make(map[string]bool)
+	if m.fileList.bundleResources {
+		m.fileList.tree = simulator.FilterBundleResourceTree(msg.nodes)
+		simulator.ExpandLocalizations(m.fileList.tree, m.fileList.treeExpanded)
+	} else {
+		simulator.ExpandLoadedDirs(msg.nodes, m.fileList.treeExpanded)
 	}
-	if strings.Contains(svg, "<filter") || strings.Contains(svg, "filter=") {
-		features = append(features, "filters")
+	m.fileList.treeCursor = 0
+	m.fileList.treeViewport = 0
+	return m, nil
+}
+
+// handleFetchTreeChildren attaches and expands a directory read on demand.
+func (m Model) handleFetchTreeChildren(msg fetchTreeChildrenMsg) (Model, tea.Cmd) {
+	if !m.fileList.treeView {
+		return m, nil
 	}
-	if strings.Contains(svg, "<foreignObject") {
-		features = append(features, "foreign objects")
+	if msg.err != nil {
+		return m.flashStatus(fmt.Sprintf("Error loading folder: %v", msg.err), 3*time.Second)
 	}
-	if len(features) == 0 {
-		return ""
+	if m.fileList.bundleResources {
+		msg.children = simulator.FilterBundleResourceTree(msg.children)
 	}
-	return fmt.Sprintf("Warning: SVG contains unsupported features (%s). Preview may be incomplete.",
-		strings.Join(features, ", "))
+	if simulator.AttachTreeChildren(m.fileList.tree, msg.path, msg.children) {
+		m.fileList.treeExpanded[msg.path] = true
+		simulator.ExpandLoadedDirs(msg.children, m.fileList.treeExpanded)
+	}
+	return m, nil
 }
 
-// handleKeyPress processes keyboard input
-func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Handle search mode input first
-	if m.simList.searchMode && m.viewState == SimulatorListView {
-		return m.handleSimulatorSearchInput(msg)
+// handleLogStreamStarted records a newly started log stream and begins
+// reading from it. A stream that starts after the user already left the
+// log view is stopped straight away.
+func (m Model) handleLogStreamStarted(msg logStreamStartedMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		if m.viewState == LogStreamView {
+			m.viewState = m.logStreamParent()
+		}
+		m.logStream = logStreamState{}
+		return m.flashStatus(fmt.Sprintf("Error starting log stream: %v", msg.err), 3*time.Second)
 	}
-	if m.appList.searchMode && m.viewState == AppListView {
-		return m.handleAppSearchInput(msg)
+	if m.viewState != LogStreamView || m.logStream.stream != nil {
+		msg.stream.Stop()
+		return m, nil
 	}
-	if m.allApps.searchMode && m.viewState == AllAppsView {
-		return m.handleAllAppsSearchInput(msg)
+	m.logStream.stream = msg.stream
+	return m, waitForLogLineCmd(msg.stream)
+}
+
+// handleLogLine appends streamed log output, dropping the oldest lines
+// past logStreamMaxLines, and keeps the viewport on the newest line
+// while following.
+func (m Model) handleLogLine(msg logLineMsg) (Model, tea.Cmd) {
+	if msg.stream != m.logStream.stream {
+		// Output from a stream that has since been stopped.
+		return m, nil
+	}
+	m.logStream.lines = append(m.logStream.lines, msg.lines...)
+	if excess := len(m.logStream.lines) - logStreamMaxLines; excess > 0 {
+		m.logStream.lines = m.logStream.lines[excess:]
+		m.logStream.viewport = max(m.logStream.viewport-excess, 0)
 	}
+	if m.logStream.following {
+		m.logStream.viewport = m.maxLogStreamViewport()
+	}
+	return m, waitForLogLineCmd(msg.stream)
+}
 
-	action := m.keyMap.GetAction(msg.String())
+// handleUninstallApps leaves multi-select mode after a batch uninstall
+// and reloads the app list.
+func (m Model) handleUninstallApps(msg uninstallAppsMsg) (Model, tea.Cmd) {
+	m.appList.uninstalling = false
+	m.appList.multiSelect = false
+	m.appList.selectedApps = nil
 
-	// Global quit (ignored in search mode)
-	if action == "quit" {
-		if m.simList.searchMode || m.appList.searchMode || m.allApps.searchMode {
-			return m, nil
-		}
-		return m, tea.Quit
+	noun := "apps"
+	if msg.removed == 1 {
+		noun = "app"
+	}
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error: uninstalled %d %s; %v", msg.removed, noun, msg.err)
+	} else {
+		m.statusMessage = fmt.Sprintf("Uninstalled %d %s successfully", msg.removed, noun)
 	}
 
-	// Navigation actions clear any pending status message before dispatch.
-	if action == "up" || action == "down" || action == "home" || action == "end" {
-		m.statusMessage = ""
+	cmds := []tea.Cmd{clearStatusAfter(3 * time.Second)}
+	if m.appList.selectedSim != nil && m.viewState == AppListView {
+		m.appList.loading = true
+		cmds = append(cmds, m.fetchAppsCmd(*m.appList.selectedSim))
 	}
+	return m, tea.Batch(cmds...)
+}
 
-	switch m.viewState {
-	case SimulatorListView:
-		return m.handleSimulatorListKey(action)
-	case AppListView:
-		return m.handleAppListKey(action)
-	case AllAppsView:
-		return m.handleAllAppsKey(action)
-	case FileListView:
-		return m.handleFileListKey(action)
-	case FileViewerView:
-		return m.handleFileViewerKey(action)
-	case DatabaseTableListView:
-		return m.handleDatabaseTableListKey(action)
-	case DatabaseTableContentView:
-		return m.handleDatabaseTableContentKey(action)
+// handleVacuumDatabase reports the database's size before and after a
+// vacuum
+func (m Model) handleVacuumDatabase(msg vacuumDatabaseMsg) (Model, tea.Cmd) {
+	m.dbTables.vacuuming = false
+	if msg.err != nil {
+		return m.flashStatus(fmt.Sprintf("Error vacuuming database: %v", msg.err), 3*time.Second)
+	}
+	if m.dbTables.file != nil {
+		file := *m.dbTables.file
+		file.Size = msg.after
+		m.dbTables.file = &file
+	}
+	if m.dbTables.info != nil {
+		info := *m.dbTables.info
+		info.FileSize = msg.after
+		m.dbTables.info = &info
+	}
+	return m.flashStatus(fmt.Sprintf("Vacuumed: %s → %s", simulator.FormatSize(msg.before), simulator.FormatSize(msg.after)), 3*time.Second)
+}
+
+// handleRunQuery shows the results of the query run from the query
+// editor. On failure the previous results stay up with the error in the
+// status bar.
+func (m Model) handleRunQuery(msg runQueryMsg) (Model, tea.Cmd) {
+	if m.viewState != DatabaseQueryView || !m.dbQuery.running {
+		return m, nil
 	}
+	m.dbQuery.running = false
+	if msg.err != nil {
+		return m.flashStatus(fmt.Sprintf("Error: %v", msg.err), 5*time.Second)
+	}
+	m.dbQuery.result = msg.result
+	m.dbQuery.viewport = 0
+	m.dbQuery.hScroll = 0
 	return m, nil
 }
 
-// handleSimulatorListKey handles key actions in the simulator list view.
-func (m Model) handleSimulatorListKey(action string) (tea.Model, tea.Cmd) {
-	switch action {
-	case "right":
-		filteredSims := m.getFilteredSimulators()
-		if len(filteredSims) > 0 && m.simList.cursor < len(filteredSims) {
-			sim := filteredSims[m.simList.cursor]
-			m.appList.selectedSim = &sim
-			m.viewState = AppListView
-			m.appList.loading = true
-			return m, m.fetchAppsCmd(sim)
-		}
-	case "up":
-		if m.simList.cursor > 0 {
-			m.simList.cursor--
-			m = m.updateViewport()
+// handleNotificationInfo opens the notification panel over the app
+// list. A missing settings file is shown in the panel rather than as an
+// error, since it just means the app never asked for permission.
+func (m Model) handleNotificationInfo(msg notificationInfoMsg) (Model, tea.Cmd) {
+	if m.viewState != AppListView {
+		return m, nil
+	}
+	if msg.err != nil && !errors.Is(msg.err, os.ErrNotExist) {
+		return m.flashStatus(fmt.Sprintf("Error reading notification settings: %v", msg.err), 3*time.Second)
+	}
+	m.appList.notifications = &notificationOverlay{appName: msg.appName, info: msg.info}
+	return m, nil
+}
+
+// handleAppPermissions opens the permissions panel over the app list,
+// or refreshes it after a change. Statuses simtool can't read keep the
+// value last set from the panel.
+func (m Model) handleAppPermissions(msg appPermissionsMsg) (Model, tea.Cmd) {
+	if m.viewState != AppListView {
+		return m, nil
+	}
+	if msg.err != nil {
+		m.appList.permissions = nil
+		return m.flashStatus(fmt.Sprintf("Error reading permissions: %v", msg.err), 3*time.Second)
+	}
+
+	overlay := &permissionsOverlay{app: msg.app, permissions: msg.permissions}
+	if old := m.appList.permissions; old != nil && old.app.BundleID == msg.app.BundleID {
+		overlay.cursor = old.cursor
+		for i, p := range overlay.permissions {
+			if p.Status == simulator.PermissionUnknown && i < len(old.permissions) {
+				overlay.permissions[i].Status = old.permissions[i].Status
+			}
 		}
-	case "down":
-		filteredSims := m.getFilteredAndSearchedSimulators()
+	}
+	m.appList.permissions = overlay
+	return m, nil
+}
+
+// handleSetAppPermission records a granted or revoked permission and
+// rereads the app's permissions
+func (m Model) handleSetAppPermission(msg setAppPermissionMsg) (Model, tea.Cmd) {
+	overlay := m.appList.permissions
+	if m.viewState != AppListView || overlay == nil {
+		return m, nil
+	}
+	updated := *overlay
+	updated.updating = false
+	m.appList.permissions = &updated
+	if msg.err != nil {
+		return m.flashStatus(fmt.Sprintf("Error: %v", msg.err), 3*time.Second)
+	}
+
+	updated.permissions = append([]simulator.Permission(nil), overlay.permissions...)
+	for i := range updated.permissions {
+		if updated.permissions[i].Service == msg.service {
+			updated.permissions[i].Status = msg.status
+		}
+	}
+	if m.appList.selectedSim == nil {
+		return m, nil
+	}
+	return m, m.fetchAppPermissionsCmd(m.appList.selectedSim.UDID, updated.app)
+}
+
+// handlePermissionsKey handles key actions while the permissions panel
+// is open. Open or enter grants the permission under the cursor, or
+// revokes it if it's granted.
+func (m Model) handlePermissionsKey(action string) (tea.Model, tea.Cmd) {
+	overlay := *m.appList.permissions
+	switch action {
+	case "up":
+		if overlay.cursor > 0 {
+			overlay.cursor--
+		}
+	case "down":
+		if overlay.cursor < len(overlay.permissions)-1 {
+			overlay.cursor++
+		}
+	case "open", "enter":
+		if overlay.updating || overlay.cursor >= len(overlay.permissions) || m.appList.selectedSim == nil {
+			return m, nil
+		}
+		permission := overlay.permissions[overlay.cursor]
+		grant := permission.Status != simulator.PermissionGranted && permission.Status != simulator.PermissionLimited
+		overlay.updating = true
+		m.appList.permissions = &overlay
+		return m, m.setAppPermissionCmd(m.appList.selectedSim.UDID, overlay.app.BundleID, permission.Service, grant)
+	case "view_permissions", "escape", "left":
+		m.appList.permissions = nil
+		return m, nil
+	}
+	m.appList.permissions = &overlay
+	return m, nil
+}
+
+// handleFetchMemoryStats shows a memory usage reading in the resources
+// panel and schedules the next one. Readings for a panel that has since
+// been closed or reopened end the refresh loop.
+func (m Model) handleFetchMemoryStats(msg fetchMemoryStatsMsg) (Model, tea.Cmd) {
+	if m.viewState != AppListView || m.appList.resources == nil || msg.seq != m.appList.resourcesSeq {
+		return m, nil
+	}
+	m.appList.resources.stats = msg.stats
+	m.appList.resources.err = msg.err
+	return m, m.fetchMemoryStatsCmd(msg.udid, msg.seq, memoryStatsRefreshInterval)
+}
+
+// handleFetchDiff shows a computed diff. Files that can't be diffed
+// return to the file list with the error.
+func (m Model) handleFetchDiff(msg fetchDiffMsg) (Model, tea.Cmd) {
+	if m.viewState != DiffView {
+		return m, nil
+	}
+	if msg.err != nil {
+		m.viewState = FileListView
+		m.diff = diffState{}
+		return m.flashStatus(fmt.Sprintf("Error computing diff: %v", msg.err), 3*time.Second)
+	}
+	m.diff.lines = msg.lines
+	m.diff.loading = false
+	m.diff.viewport = 0
+	return m, nil
+}
+
+// handleFetchXattrs caches a file's extended attributes in the file
+// list and opens the metadata panel for it
+func (m Model) handleFetchXattrs(msg fetchXattrsMsg) (Model, tea.Cmd) {
+	if m.viewState != FileListView {
+		return m, nil
+	}
+	if msg.err != nil {
+		return m.flashStatus(fmt.Sprintf("Error: %v", msg.err), 3*time.Second)
+	}
+	for i := range m.fileList.files {
+		if m.fileList.files[i].Path == msg.path {
+			m.fileList.files[i].Xattrs = msg.xattrs
+			file := m.fileList.files[i]
+			m.fileList.metadata = &file
+			break
+		}
+	}
+	return m, nil
+}
+
+// handleDuplicateFile refreshes the file list after a copy, with the
+// cursor on the copy
+func (m Model) handleDuplicateFile(msg duplicateFileMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.flashStatus(fmt.Sprintf("Error duplicating %s: %v", msg.name, msg.err), 3*time.Second)
+	}
+	m, flash := m.flashStatus(fmt.Sprintf("Duplicated %s as %s", msg.name, filepath.Base(msg.path)), 2*time.Second)
+	if m.viewState != FileListView || filepath.Dir(msg.path) != filepath.Clean(m.fileList.currentPath) {
+		return m, flash
+	}
+	m.fileList.selectPath = msg.path
+	m.fileList.loading = true
+	return m, tea.Batch(flash, m.fetchFilesCmd(m.fileList.currentPath))
+}
+
+// handleRenameFile refreshes the file list after a rename, with the
+// cursor on the renamed file. The remembered cursor positions of a
+// renamed directory and the directories in it move to the new paths.
+func (m Model) handleRenameFile(msg renameFileMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.flashStatus(fmt.Sprintf("Error renaming %s: %v", filepath.Base(msg.oldPath), msg.err), 3*time.Second)
+	}
+	m.fileList.cursorMemory = renamePathKeys(m.fileList.cursorMemory, msg.oldPath, msg.newPath)
+	m.fileList.viewportMemory = renamePathKeys(m.fileList.viewportMemory, msg.oldPath, msg.newPath)
+	m, flash := m.flashStatus(fmt.Sprintf("Renamed %s to %s", filepath.Base(msg.oldPath), filepath.Base(msg.newPath)), 2*time.Second)
+	if m.viewState != FileListView || filepath.Dir(msg.newPath) != filepath.Clean(m.fileList.currentPath) {
+		return m, flash
+	}
+	m.fileList.selectPath = msg.newPath
+	m.fileList.loading = true
+	return m, tea.Batch(flash, m.fetchFilesCmd(m.fileList.currentPath))
+}
+
+// handleDeleteFile refreshes the file list after a delete, with the
+// cursor on the entry above the deleted one
+func (m Model) handleDeleteFile(msg deleteFileMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.flashStatus(fmt.Sprintf("Error deleting %s: %v", msg.name, msg.err), 3*time.Second)
+	}
+	if m.diffMark != nil && m.diffMark.file.Path == msg.path {
+		m.diffMark = nil
+	}
+	m, flash := m.flashStatus(fmt.Sprintf("Deleted %s", msg.name), 2*time.Second)
+	if m.viewState != FileListView || filepath.Dir(msg.path) != filepath.Clean(m.fileList.currentPath) {
+		return m, flash
+	}
+	for i, file := range m.fileList.files {
+		if file.Path == msg.path {
+			// The refresh restores the cursor from cursorMemory
+			m = m.rememberFilePosition(m.fileList.currentPath, max(i-1, 0), m.fileList.viewport)
+			break
+		}
+	}
+	m.fileList.loading = true
+	return m, tea.Batch(flash, m.fetchFilesCmd(m.fileList.currentPath))
+}
+
+// rememberFilePosition records cursor and viewport as the position to
+// restore when the directory at path is listed again. Paths are cleaned
+// so "/a/b/" and "/a/b" share one position.
+func (m Model) rememberFilePosition(path string, cursor, viewport int) Model {
+	if m.fileList.cursorMemory == nil {
+		m.fileList.cursorMemory = make(map[string]int)
+		m.fileList.viewportMemory = make(map[string]int)
+	}
+	key := filepath.Clean(path)
+	m.fileList.cursorMemory[key] = cursor
+	m.fileList.viewportMemory[key] = viewport
+	return m
+}
+
+// renamePathKeys returns memory with the entries for oldPath, and the
+// paths below it, moved to newPath
+func renamePathKeys(memory map[string]int, oldPath, newPath string) map[string]int {
+	moved := make(map[string]int)
+	for path, value := range memory {
+		rel, err := filepath.Rel(oldPath, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		delete(memory, path)
+		moved[filepath.Join(newPath, rel)] = value
+	}
+	for path, value := range moved {
+		memory[path] = value
+	}
+	return memory
+}
+
+// handleFetchCrashLogs processes the crash log listing for an app. An
+// app with no crash logs returns to the app list with a flash message.
+func (m Model) handleFetchCrashLogs(msg fetchCrashLogsMsg) (Model, tea.Cmd) {
+	if m.viewState != CrashLogsView {
+		return m, nil
+	}
+	if len(msg.logs) == 0 {
+		name := ""
+		if m.crashLogs.app != nil {
+			name = m.crashLogs.app.Name
+		}
+		m.viewState = AppListView
+		m.crashLogs = crashLogsState{}
+		return m.flashStatus(fmt.Sprintf("No crash logs found for %s", name), 2*time.Second)
+	}
+	m.crashLogs.logs = msg.logs
+	m.crashLogs.loading = false
+	m.crashLogs.cursor = 0
+	m.crashLogs.viewport = 0
+	return m.updateViewport(), nil
+}
+
+// handleFetchURLCache processes the URL cache listing for an app. An
+// app without a cache, or with an empty one, returns to the app list
+// with a flash message.
+func (m Model) handleFetchURLCache(msg fetchURLCacheMsg) (Model, tea.Cmd) {
+	if m.viewState != URLCacheView {
+		return m, nil
+	}
+	name := ""
+	if m.urlCache.app != nil {
+		name = m.urlCache.app.Name
+	}
+	if msg.err != nil || len(msg.entries) == 0 {
+		m.viewState = AppListView
+		m.urlCache = urlCacheState{}
+		switch {
+		case errors.Is(msg.err, simulator.ErrNoURLCache):
+			return m.flashStatus(fmt.Sprintf("No URL cache found for %s", name), 2*time.Second)
+		case msg.err != nil:
+			return m.flashStatus(fmt.Sprintf("Error reading URL cache: %v", msg.err), 3*time.Second)
+		}
+		return m.flashStatus(fmt.Sprintf("URL cache for %s is empty", name), 2*time.Second)
+	}
+	m.urlCache.entries = msg.entries
+	m.urlCache.loading = false
+	m.urlCache.cursor = 0
+	m.urlCache.viewport = 0
+	return m.updateViewport(), nil
+}
+
+// handleFetchKeychain processes the keychain dump for an app. If the
+// dump fails or the app has no items, it returns to the app list with a
+// flash message.
+func (m Model) handleFetchKeychain(msg fetchKeychainMsg) (Model, tea.Cmd) {
+	if m.viewState != KeychainView {
+		return m, nil
+	}
+	name := ""
+	if m.keychain.app != nil {
+		name = m.keychain.app.Name
+	}
+	if msg.err != nil || len(msg.items) == 0 {
+		m.viewState = AppListView
+		m.keychain = keychainState{}
+		switch {
+		case errors.Is(msg.err, simulator.ErrNoKeychain):
+			return m.flashStatus("No keychain found for this simulator", 2*time.Second)
+		case msg.err != nil:
+			return m.flashStatus(fmt.Sprintf("Error reading keychain: %v", msg.err), 3*time.Second)
+		}
+		return m.flashStatus(fmt.Sprintf("No keychain items for %s", name), 2*time.Second)
+	}
+	m.keychain.items = msg.items
+	m.keychain.loading = false
+	m.keychain.viewport = 0
+	m.keychain.hScroll = 0
+	return m, nil
+}
+
+// handleFetchAppGroups processes the app group listing for an app. An
+// app that uses no app groups returns to the app list with a flash
+// message.
+func (m Model) handleFetchAppGroups(msg fetchAppGroupsMsg) (Model, tea.Cmd) {
+	if m.viewState != AppGroupsView {
+		return m, nil
+	}
+	name := ""
+	if m.appGroups.app != nil {
+		name = m.appGroups.app.Name
+	}
+	if msg.err != nil || len(msg.groups) == 0 {
+		m.viewState = AppListView
+		m.appGroups = appGroupsState{}
+		if msg.err != nil && !errors.Is(msg.err, simulator.ErrNoAppGroups) {
+			return m.flashStatus(fmt.Sprintf("Error reading app groups: %v", msg.err), 3*time.Second)
+		}
+		return m.flashStatus(fmt.Sprintf("%s doesn't use any app groups", name), 2*time.Second)
+	}
+	m.appGroups.groups = msg.groups
+	m.appGroups.loading = false
+	m.appGroups.cursor = 0
+	m.appGroups.viewport = 0
+	return m.updateViewport(), nil
+}
+
+// handleURLCacheBody opens a cached response body in the file viewer
+func (m Model) handleURLCacheBody(msg urlCacheBodyMsg) (Model, tea.Cmd) {
+	if m.viewState != URLCacheView {
+		return m, nil
+	}
+	if msg.err != nil {
+		return m.flashStatus(fmt.Sprintf("Error opening response body: %v", msg.err), 3*time.Second)
+	}
+	file := msg.file
+	m.fileViewer = fileViewerState{
+		file:         &file,
+		loading:      true,
+		fromURLCache: true,
+	}
+	m.viewState = FileViewerView
+	return m, m.fetchFileContentCmd(file.Path, 0, m.width, m.height)
+}
+
+// detectSVGWarning returns a non-empty warning string if the given file
+// is an SVG whose source references features (embedded raster images,
+// filters, foreign objects) that the rasterizer cannot render. Returns
+// "" for non-SVG files, missing files, or SVGs with no such features.
+func detectSVGWarning(file *simulator.FileInfo) string {
+	if file == nil || strings.ToLower(filepath.Ext(file.Path)) != ".svg" {
+		return ""
+	}
+	data, err := os.ReadFile(file.Path)
+	if err != nil {
+		return ""
+	}
+	svg := string(data)
+	var features []string
+	if strings.Contains(svg, "data:image/") ||
+		strings.Contains(svg, "xlink:href=\"data:") ||
+		strings.Contains(svg, "xlink:href=\"http") {
+		features = append(features, "embedded images")
+	}
+	if strings.Contains(svg, "<filter") || strings.Contains(svg, "filter=") {
+		features = append(features, "filters")
+	}
+	if strings.Contains(svg, "<foreignObject") {
+		features = append(features, "foreign objects")
+	}
+	if len(features) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Warning: SVG contains unsupported features (%s). Preview may be incomplete.",
+		strings.Join(features, ", "))
+}
+
+// handleKeyPress processes keyboard input
+func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The delete confirmation takes every key, quit included
+	if m.viewState == ConfirmDeleteView {
+		return m.handleSimulatorDeleteConfirm(msg)
+	}
+	if m.viewState == FileSearchView {
+		return m.handleFileSearchInput(msg)
+	}
+	if m.viewState == DatabaseQueryView {
+		return m.handleDatabaseQueryInput(msg)
+	}
+	// Handle search mode input first
+	if m.simList.searchMode && m.viewState == SimulatorListView {
+		return m.handleSimulatorSearchInput(msg)
+	}
+	if m.simList.cloneMode && m.viewState == SimulatorListView {
+		return m.handleCloneNameInput(msg)
+	}
+	if m.simList.spawnMode && m.viewState == SimulatorListView {
+		return m.handleSpawnInput(msg)
+	}
+	if m.fileList.renameMode && m.viewState == FileListView {
+		return m.handleRenameInput(msg)
+	}
+	if m.createSim.step == createStepName && m.viewState == CreateSimulatorView {
+		return m.handleCreateNameInput(msg)
+	}
+	if m.fileList.confirmDelete && m.viewState == FileListView {
+		return m.handleDeleteConfirm(msg)
+	}
+	if m.appList.searchMode && m.viewState == AppListView {
+		return m.handleAppSearchInput(msg)
+	}
+	if m.allApps.searchMode && m.viewState == AllAppsView {
+		return m.handleAllAppsSearchInput(msg)
+	}
+	if m.appList.confirmUninstall && m.viewState == AppListView {
+		return m.handleUninstallConfirm(msg)
+	}
+	if m.appList.deepLinkMode && m.viewState == AppListView {
+		return m.handleDeepLinkInput(msg)
+	}
+	if m.appList.push != nil && m.viewState == AppListView {
+		return m.handlePushInput(msg)
+	}
+	if m.dbContent.searchMode && m.viewState == DatabaseTableContentView {
+		return m.handleTableSearchInput(msg)
+	}
+	if m.dbTables.confirmVacuum && m.viewState == DatabaseTableListView {
+		return m.handleVacuumConfirm(msg)
+	}
+
+	action := m.keyMap.GetAction(msg.String())
+
+	// Global quit (ignored in search mode)
+	if action == "quit" {
+		if m.simList.searchMode || m.appList.searchMode || m.allApps.searchMode || m.dbContent.searchMode {
+			return m, nil
+		}
+		// In the log view, quit keys stop the stream instead; ctrl+c
+		// still exits.
+		if m.viewState == LogStreamView && msg.String() != "ctrl+c" {
+			return m.stopLogStream(), nil
+		}
+		m.logStream.stream.Stop()
+		return m, tea.Quit
+	}
+
+	// Navigation actions clear any pending status message before dispatch.
+	if action == "up" || action == "down" || action == "home" || action == "end" {
+		m.statusMessage = ""
+	}
+
+	switch m.viewState {
+	case SimulatorListView:
+		return m.handleSimulatorListKey(action)
+	case AppListView:
+		return m.handleAppListKey(action)
+	case AllAppsView:
+		return m.handleAllAppsKey(action)
+	case FileListView:
+		return m.handleFileListKey(action)
+	case FileViewerView:
+		return m.handleFileViewerKey(action)
+	case DatabaseTableListView:
+		return m.handleDatabaseTableListKey(action)
+	case DatabaseTableContentView:
+		return m.handleDatabaseTableContentKey(action)
+	case LogStreamView:
+		return m.handleLogStreamKey(action)
+	case CrashLogsView:
+		return m.handleCrashLogsKey(action)
+	case URLCacheView:
+		return m.handleURLCacheKey(action)
+	case KeychainView:
+		return m.handleKeychainKey(action)
+	case AppGroupsView:
+		return m.handleAppGroupsKey(action)
+	case DiffView:
+		return m.handleDiffKey(action)
+	case SpawnResultView:
+		return m.handleSpawnResultKey(action)
+	case CreateSimulatorView:
+		return m.handleCreateSimulatorKey(action)
+	}
+	return m, nil
+}
+
+// handleSimulatorListKey handles key actions in the simulator list view.
+func (m Model) handleSimulatorListKey(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case "right":
+		filteredSims := m.getFilteredSimulators()
+		if len(filteredSims) > 0 && m.simList.cursor < len(filteredSims) {
+			sim := filteredSims[m.simList.cursor]
+			m.appList.selectedSim = &sim
+			m.viewState = AppListView
+			m.appList.loading = true
+			return m, m.fetchAppsCmd(sim)
+		}
+	case "up":
+		if m.simList.cursor > 0 {
+			m.simList.cursor--
+			m = m.updateViewport()
+		}
+	case "down":
+		filteredSims := m.getFilteredAndSearchedSimulators()
 		if m.simList.cursor < len(filteredSims)-1 {
 			m.simList.cursor++
 			m = m.updateViewport()
@@ -368,11 +1351,28 @@ func (m Model) handleSimulatorListKey(action string) (tea.Model, tea.Cmd) {
 		}
 		m = m.updateViewport()
 	case "filter":
-		m.simList.filterActive = !m.simList.filterActive
-		// Reset cursor when toggling filter
+		m.simList.filterActive, m.simList.platform = nextSimFilter(
+			m.simList.filterActive, m.simList.platform, m.simList.simulators)
+		m = m.refilterSimulators()
+		// Reset cursor when changing the filter
 		m.simList.cursor = 0
 		m.simList.viewport = 0
 		m = m.updateViewport()
+	case "sort", "cycle_sort":
+		// Keep the selected simulator selected in the new order
+		var udid string
+		if sims := m.getFilteredAndSearchedSimulators(); m.simList.cursor < len(sims) {
+			udid = sims[m.simList.cursor].UDID
+		}
+		m.simList.sortOrder = m.simList.sortOrder.Next()
+		m = m.refilterSimulators()
+		for i, sim := range m.getFilteredAndSearchedSimulators() {
+			if sim.UDID == udid {
+				m.simList.cursor = i
+				break
+			}
+		}
+		m = m.updateViewport()
 	case "boot", "open":
 		filteredSims := m.getFilteredSimulators()
 		if len(filteredSims) > 0 && m.simList.cursor < len(filteredSims) {
@@ -385,27 +1385,595 @@ func (m Model) handleSimulatorListKey(action string) (tea.Model, tea.Cmd) {
 				return m.flashStatus("Simulator is already running", 2*time.Second)
 			}
 		}
+	case "create_simulator":
+		if m.simList.creating {
+			return m, nil
+		}
+		m.createSim = createSimState{loading: true}
+		m.viewState = CreateSimulatorView
+		m.statusMessage = ""
+		return m, fetchRuntimesCmd()
+	case "delete_simulator":
+		filteredSims := m.getFilteredAndSearchedSimulators()
+		if len(filteredSims) == 0 || m.simList.cursor >= len(filteredSims) || m.simList.deleting {
+			return m, nil
+		}
+		sim := filteredSims[m.simList.cursor]
+		if sim.IsRunning() {
+			// simctl refuses to delete booted simulators
+			return m.flashStatus("Shut down the simulator to delete it", 2*time.Second)
+		}
+		m.simList.deleteTarget = &sim
+		m.viewState = ConfirmDeleteView
+		m.statusMessage = ""
+	case "shutdown":
+		filteredSims := m.getFilteredAndSearchedSimulators()
+		if len(filteredSims) == 0 || m.simList.cursor >= len(filteredSims) || m.simList.shuttingDown {
+			return m, nil
+		}
+		sim := filteredSims[m.simList.cursor]
+		if !sim.IsRunning() {
+			return m.flashStatus("Simulator is not running", 2*time.Second)
+		}
+		m.simList.shuttingDown = true
+		m.statusMessage = fmt.Sprintf("Shutting down %s...", sim.Name)
+		return m, m.shutdownSimulatorCmd(sim.UDID)
+	case "open_simulator_app":
+		filteredSims := m.getFilteredSimulators()
+		if len(filteredSims) == 0 || m.simList.cursor >= len(filteredSims) {
+			return m, nil
+		}
+		sim := filteredSims[m.simList.cursor]
+		if sim.IsRunning() {
+			return m, openSimulatorAppCmd(sim.UDID)
+		}
+		if !m.simList.booting {
+			m.simList.booting = true
+			m.simList.openAfterBoot = sim.UDID
+			m.statusMessage = fmt.Sprintf("Booting %s...", sim.Name)
+			return m, m.bootSimulatorCmd(sim.UDID)
+		}
 	case "search":
 		m.simList.searchMode = true
 		m.simList.searchQuery = ""
+		m = m.refilterSimulators()
 		// Reset cursor to 0 when starting search
 		m.simList.cursor = 0
 		m.simList.viewport = 0
 		m = m.updateViewport()
-	}
-	return m, nil
-}
-
-// handleAppListKey handles key actions in the app list view.
-func (m Model) handleAppListKey(action string) (tea.Model, tea.Cmd) {
+	case "clone_simulator":
+		filteredSims := m.getFilteredAndSearchedSimulators()
+		if len(filteredSims) == 0 || m.simList.cursor >= len(filteredSims) || m.simList.cloning {
+			return m, nil
+		}
+		sim := filteredSims[m.simList.cursor]
+		if sim.IsRunning() {
+			// simctl can only clone shut down simulators
+			return m.flashStatus("Shut down the simulator to clone it", 2*time.Second)
+		}
+		m.simList.cloneMode = true
+		m.simList.cloneName = sim.Name + " Copy"
+		m.statusMessage = ""
+	case "capture_screen":
+		filteredSims := m.getFilteredAndSearchedSimulators()
+		if len(filteredSims) == 0 || m.simList.cursor >= len(filteredSims) || m.simList.capturing {
+			return m, nil
+		}
+		sim := filteredSims[m.simList.cursor]
+		if !sim.IsRunning() {
+			return m.flashStatus("Boot the simulator to take a screenshot", 2*time.Second)
+		}
+		m.simList.capturing = true
+		m.statusMessage = fmt.Sprintf("Capturing %s...", sim.Name)
+		return m, captureScreenCmd(sim.UDID, sim.Name)
+	case "spawn_command":
+		filteredSims := m.getFilteredAndSearchedSimulators()
+		if len(filteredSims) == 0 || m.simList.cursor >= len(filteredSims) || m.simList.spawning {
+			return m, nil
+		}
+		if !filteredSims[m.simList.cursor].IsRunning() {
+			return m.flashStatus("Boot the simulator to run commands in it", 2*time.Second)
+		}
+		m.simList.spawnMode = true
+		m.statusMessage = ""
+	case "view_logs":
+		filteredSims := m.getFilteredAndSearchedSimulators()
+		if len(filteredSims) == 0 || m.simList.cursor >= len(filteredSims) {
+			return m, nil
+		}
+		sim := filteredSims[m.simList.cursor]
+		if !sim.IsRunning() {
+			return m.flashStatus("Boot the simulator to stream its logs", 2*time.Second)
+		}
+		m.viewState = LogStreamView
+		m.logStream = logStreamState{sim: &sim, following: true}
+		return m, m.startSimulatorLogStreamCmd(sim.UDID)
+	}
+	return m, nil
+}
+
+// handleSpawnInput edits the command in the spawn prompt. Enter runs it
+// in the selected simulator, escape cancels; the last command is kept
+// for the next prompt.
+func (m Model) handleSpawnInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keyMap.GetAction(msg.String()) {
+	case "escape":
+		m.simList.spawnMode = false
+		return m, nil
+	case "backspace":
+		if command := []rune(m.simList.spawnCommand); len(command) > 0 {
+			m.simList.spawnCommand = string(command[:len(command)-1])
+		}
+		return m, nil
+	case "enter":
+		command := strings.TrimSpace(m.simList.spawnCommand)
+		filteredSims := m.getFilteredAndSearchedSimulators()
+		if command == "" || m.simList.cursor >= len(filteredSims) {
+			return m, nil
+		}
+		sim := filteredSims[m.simList.cursor]
+		m.simList.spawnMode = false
+		m.simList.spawning = true
+		m.statusMessage = fmt.Sprintf("Running %s in %s...", command, sim.Name)
+		return m, spawnCmd(sim.UDID, sim.Name, command)
+	}
+	if (msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace) && !msg.Alt {
+		m.simList.spawnCommand += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// handleSpawn shows the output of a command run in a simulator, with its
+// exit status in the status bar
+func (m Model) handleSpawn(msg spawnMsg) (Model, tea.Cmd) {
+	m.simList.spawning = false
+	if msg.err != nil {
+		return m.flashStatus(fmt.Sprintf("Error: %v", msg.err), 3*time.Second)
+	}
+	if m.viewState != SimulatorListView {
+		return m, nil
+	}
+	output := strings.TrimRight(msg.result.Output, "\n")
+	var lines []string
+	if output != "" {
+		lines = strings.Split(output, "\n")
+	}
+	m.spawn = spawnState{simName: msg.simName, result: msg.result, lines: lines}
+	m.viewState = SpawnResultView
+	m.statusMessage = spawnExitStatus(msg.result)
+	return m, nil
+}
+
+// spawnExitStatus describes how a spawned command exited
+func spawnExitStatus(result simulator.SpawnResult) string {
+	return fmt.Sprintf("%s exited with status %d", result.Command, result.ExitCode)
+}
+
+// handleCaptureScreen reports where a screenshot was saved, with the
+// home directory shortened to ~
+func (m Model) handleCaptureScreen(msg captureScreenMsg) (Model, tea.Cmd) {
+	m.simList.capturing = false
+	if msg.err != nil {
+		return m.flashStatus(fmt.Sprintf("Error: %v", msg.err), 3*time.Second)
+	}
+	return m.flashStatus("Screenshot saved to "+homeRelative(msg.path), 3*time.Second)
+}
+
+// handleExportContainer reports where a container archive was saved
+func (m Model) handleExportContainer(msg exportContainerMsg) (Model, tea.Cmd) {
+	m.appList.exporting = ""
+	if msg.err != nil {
+		return m.flashStatus(fmt.Sprintf("Error: %v", msg.err), 3*time.Second)
+	}
+	return m.flashStatus("Container exported to "+homeRelative(msg.path), 5*time.Second)
+}
+
+// handleExportTable reports how many rows a table export wrote and
+// where. It reports even after the user has left the table.
+func (m Model) handleExportTable(msg exportTableMsg) (Model, tea.Cmd) {
+	m.dbContent.exporting = false
+	m.dbContent.exportRows = nil
+	if msg.err != nil {
+		return m.flashStatus(fmt.Sprintf("Error: %v", msg.err), 3*time.Second)
+	}
+	noun := "rows"
+	if msg.rowsWritten == 1 {
+		noun = "row"
+	}
+	return m.flashStatus(fmt.Sprintf("Exported %d %s to %s", msg.rowsWritten, noun, homeRelative(msg.path)), 5*time.Second)
+}
+
+// handleFetchSearchResults lists the lines matching a file search.
+// Results for a query that has since been edited are dropped.
+func (m Model) handleFetchSearchResults(msg fetchSearchResultsMsg) (Model, tea.Cmd) {
+	if m.viewState != FileSearchView || msg.query != m.fileSearch.query {
+		return m, nil
+	}
+	m.fileSearch.loading = false
+	if msg.err != nil {
+		m.fileSearch.matches = nil
+		return m.flashStatus(fmt.Sprintf("Error searching file: %v", msg.err), 3*time.Second)
+	}
+	m.fileSearch.matches = msg.matches
+	m.fileSearch.cursor = 0
+	m.fileSearch.viewport = 0
+	return m, nil
+}
+
+// handleOpenInEditor reloads the viewed text file once its editor
+// exits, to show any changes made there
+func (m Model) handleOpenInEditor(msg openInEditorMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.flashStatus(fmt.Sprintf("Error opening editor: %v", msg.err), 3*time.Second)
+	}
+	if m.viewState != FileViewerView || m.fileViewer.file == nil ||
+		m.fileViewer.content == nil || m.fileViewer.content.Type != simulator.FileTypeText {
+		return m, nil
+	}
+	m.fileViewer.loading = true
+	return m, m.fetchFileContentCmd(m.fileViewer.file.Path, m.fileViewer.contentOffset, m.width, m.height)
+}
+
+// homeRelative shortens a path in the home directory to start with ~
+func homeRelative(path string) string {
+	if home, err := os.UserHomeDir(); err == nil {
+		if rel, ok := strings.CutPrefix(path, home+string(filepath.Separator)); ok {
+			return "~/" + rel
+		}
+	}
+	return path
+}
+
+// handleCloneNameInput edits the name in the clone prompt. Enter clones
+// the selected simulator under that name, escape cancels.
+func (m Model) handleCloneNameInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keyMap.GetAction(msg.String()) {
+	case "escape":
+		m.simList.cloneMode = false
+		m.simList.cloneName = ""
+		return m, nil
+	case "backspace":
+		if name := []rune(m.simList.cloneName); len(name) > 0 {
+			m.simList.cloneName = string(name[:len(name)-1])
+		}
+		return m, nil
+	case "enter":
+		name := strings.TrimSpace(m.simList.cloneName)
+		filteredSims := m.getFilteredAndSearchedSimulators()
+		if name == "" || m.simList.cursor >= len(filteredSims) {
+			return m, nil
+		}
+		sim := filteredSims[m.simList.cursor]
+		m.simList.cloneMode = false
+		m.simList.cloneName = ""
+		m.simList.cloning = true
+		m.statusMessage = fmt.Sprintf("Cloning %s...", sim.Name)
+		return m, cloneSimulatorCmd(sim.UDID, name)
+	}
+	if (msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace) && !msg.Alt {
+		m.simList.cloneName += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// handleCreateSimulatorKey handles key actions in the runtime and
+// device type steps of the new simulator form. Right picks the entry
+// under the cursor and moves on; left goes back a step.
+func (m Model) handleCreateSimulatorKey(action string) (tea.Model, tea.Cmd) {
+	count := m.createSimChoiceCount()
+	switch action {
+	case "left", "escape":
+		if m.createSim.step == createStepRuntime {
+			m.viewState = SimulatorListView
+			m.createSim = createSimState{}
+			return m, nil
+		}
+		return m.createSimStep(m.createSim.step - 1), nil
+	case "right", "enter":
+		if m.createSim.loading || m.createSim.cursor >= count {
+			return m, nil
+		}
+		if m.createSim.step == createStepRuntime {
+			m.createSim.runtime = m.createSim.runtimes[m.createSim.cursor]
+			m = m.createSimStep(createStepDeviceType)
+			m.createSim.loading = true
+			return m, fetchDeviceTypesCmd()
+		}
+		m.createSim.deviceType = m.createSim.deviceTypes[m.createSim.cursor]
+		m = m.createSimStep(createStepName)
+		m.createSim.name = m.createSim.deviceType.Name
+	case "up":
+		if m.createSim.cursor > 0 {
+			m.createSim.cursor--
+			m = m.updateViewport()
+		}
+	case "down":
+		if m.createSim.cursor < count-1 {
+			m.createSim.cursor++
+			m = m.updateViewport()
+		}
+	case "home":
+		m.createSim.cursor = 0
+		m.createSim.viewport = 0
+	case "end":
+		m.createSim.cursor = max(count-1, 0)
+		m = m.updateViewport()
+	}
+	return m, nil
+}
+
+// handleCreateNameInput edits the name in the last step of the new
+// simulator form. Enter creates the simulator, escape goes back to the
+// device types.
+func (m Model) handleCreateNameInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keyMap.GetAction(msg.String()) {
+	case "escape":
+		return m.createSimStep(createStepDeviceType), nil
+	case "backspace":
+		if name := []rune(m.createSim.name); len(name) > 0 {
+			m.createSim.name = string(name[:len(name)-1])
+		}
+		return m, nil
+	case "enter":
+		name := strings.TrimSpace(m.createSim.name)
+		if name == "" {
+			return m, nil
+		}
+		form := m.createSim
+		m.viewState = SimulatorListView
+		m.createSim = createSimState{}
+		m.simList.creating = true
+		m.statusMessage = fmt.Sprintf("Creating %s...", name)
+		return m, createSimulatorCmd(name, form.deviceType.Identifier, form.runtime.Identifier)
+	}
+	if (msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace) && !msg.Alt {
+		m.createSim.name += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// createSimStep moves the new simulator form to step, with the cursor on
+// the choice made there before if going back
+func (m Model) createSimStep(step createStep) Model {
+	m.createSim.step = step
+	m.createSim.loading = false
+	m.createSim.cursor = 0
+	m.createSim.viewport = 0
+	switch step {
+	case createStepRuntime:
+		m.createSim.deviceTypes = nil
+		for i, runtime := range m.createSim.runtimes {
+			if runtime.Identifier == m.createSim.runtime.Identifier {
+				m.createSim.cursor = i
+			}
+		}
+	case createStepDeviceType:
+		for i, deviceType := range m.createSim.deviceTypes {
+			if deviceType.Identifier == m.createSim.deviceType.Identifier {
+				m.createSim.cursor = i
+			}
+		}
+	}
+	return m.updateViewport()
+}
+
+// createSimChoiceCount returns how many entries the current step of the
+// new simulator form lists
+func (m Model) createSimChoiceCount() int {
+	switch m.createSim.step {
+	case createStepRuntime:
+		return len(m.createSim.runtimes)
+	case createStepDeviceType:
+		return len(m.createSim.deviceTypes)
+	}
+	return 0
+}
+
+// handleRenameInput edits the new name in the rename prompt. Enter
+// renames the selected file if the name can be used, escape cancels.
+func (m Model) handleRenameInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keyMap.GetAction(msg.String()) {
+	case "escape":
+		m.fileList.renameMode = false
+		return m, nil
+	case "backspace":
+		if name := []rune(m.fileList.renameName); len(name) > 0 {
+			m.fileList.renameName = string(name[:len(name)-1])
+		}
+		m.fileList.renameError = ""
+		return m, nil
+	case "enter":
+		if m.fileList.cursor >= len(m.fileList.files) {
+			return m, nil
+		}
+		file := m.fileList.files[m.fileList.cursor]
+		name := strings.TrimSpace(m.fileList.renameName)
+		if err := simulator.CheckRename(file.Path, name); err != nil {
+			// Keep the prompt open so the name can be fixed
+			m.fileList.renameError = err.Error()
+			return m, nil
+		}
+		m.fileList.renameMode = false
+		if name == file.Name {
+			return m, nil
+		}
+		return m, m.renameFileCmd(file.Path, name)
+	}
+	if (msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace) && !msg.Alt {
+		m.fileList.renameName += string(msg.Runes)
+		m.fileList.renameError = ""
+	}
+	return m, nil
+}
+
+// handleSimulatorDeleteConfirm answers the simulator delete prompt: y
+// deletes the simulator and n goes back to the list. Other keys are
+// ignored so a stray key press can't dismiss or confirm it.
+func (m Model) handleSimulatorDeleteConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		sim := m.simList.deleteTarget
+		m.simList.deleteTarget = nil
+		m.viewState = SimulatorListView
+		if sim == nil {
+			return m, nil
+		}
+		m.simList.deleting = true
+		m.statusMessage = fmt.Sprintf("Deleting %s...", sim.Name)
+		return m, m.deleteSimulatorCmd(*sim)
+	case "n", "N":
+		m.simList.deleteTarget = nil
+		m.viewState = SimulatorListView
+	}
+	return m, nil
+}
+
+// handleDeleteConfirm answers the delete prompt: y deletes the selected
+// file, any other key cancels.
+func (m Model) handleDeleteConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.fileList.confirmDelete = false
+	if key := msg.String(); key != "y" && key != "Y" {
+		return m, nil
+	}
+	if m.fileList.cursor >= len(m.fileList.files) {
+		return m, nil
+	}
+	return m, m.deleteFileCmd(m.fileList.files[m.fileList.cursor])
+}
+
+// handlePushInput edits the payload in the push notification editor.
+// Enter starts a new line; the send_push_notification key sends the
+// payload and escape closes the editor.
+func (m Model) handlePushInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	push := *m.appList.push
+	if push.sending {
+		return m, nil
+	}
+	switch m.keyMap.GetAction(msg.String()) {
+	case "escape":
+		m.appList.push = nil
+		return m, nil
+	case "send_push_notification":
+		if m.appList.selectedSim == nil {
+			return m, nil
+		}
+		push.sending = true
+		m.appList.push = &push
+		m.statusMessage = fmt.Sprintf("Sending push notification to %s...", push.app.Name)
+		return m, sendPushCmd(m.appList.selectedSim.UDID, push.app, push.payload)
+	case "backspace":
+		if payload := []rune(push.payload); len(payload) > 0 {
+			push.payload = string(payload[:len(payload)-1])
+		}
+	case "enter":
+		push.payload += "\n"
+	default:
+		switch {
+		case msg.Type == tea.KeyTab:
+			push.payload += "  "
+		case msg.Type == tea.KeySpace:
+			push.payload += " "
+		case msg.Type == tea.KeyRunes && !msg.Alt:
+			push.payload += string(msg.Runes)
+		}
+	}
+	m.appList.push = &push
+	return m, nil
+}
+
+// handleSendPush closes the push editor once the notification is
+// delivered. On failure the editor stays open to fix the payload.
+func (m Model) handleSendPush(msg sendPushMsg) (Model, tea.Cmd) {
+	if m.appList.push != nil {
+		push := *m.appList.push
+		push.sending = false
+		m.appList.push = &push
+	}
+	if msg.err != nil {
+		return m.flashStatus(fmt.Sprintf("Error: %v", msg.err), 5*time.Second)
+	}
+	m.appList.push = nil
+	return m.flashStatus(fmt.Sprintf("Push notification sent to %s", msg.app), 2*time.Second)
+}
+
+// handleDeepLinkInput edits the deep link URL; enter opens it in the
+// simulator and escape cancels.
+func (m Model) handleDeepLinkInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keyMap.GetAction(msg.String()) {
+	case "escape":
+		m.appList.deepLinkMode = false
+		m.appList.deepLinkURL = ""
+		return m, nil
+	case "backspace":
+		if url := []rune(m.appList.deepLinkURL); len(url) > 0 {
+			m.appList.deepLinkURL = string(url[:len(url)-1])
+		}
+		return m, nil
+	case "enter":
+		url := strings.TrimSpace(m.appList.deepLinkURL)
+		if url == "" || m.appList.selectedSim == nil {
+			return m, nil
+		}
+		m.appList.deepLinkMode = false
+		m.appList.deepLinkURL = ""
+		return m, openURLCmd(m.appList.selectedSim.UDID, url)
+	}
+	if msg.Type == tea.KeyRunes && !msg.Alt {
+		m.appList.deepLinkURL += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// handleAppListKey handles key actions in the app list view.
+func (m Model) handleAppListKey(action string) (tea.Model, tea.Cmd) {
+	// Any key closes the notification panel
+	if m.appList.notifications != nil {
+		m.appList.notifications = nil
+		return m, nil
+	}
+	// Any key closes the resources panel too
+	if m.appList.resources != nil {
+		m.appList.resources = nil
+		return m, nil
+	}
+	if m.appList.permissions != nil {
+		return m.handlePermissionsKey(action)
+	}
+
+	// Multi-select mode keeps navigation but replaces the other actions
+	if m.appList.multiSelect {
+		switch action {
+		case "up", "down", "home", "end":
+			// Handled below
+		case "boot", "open":
+			if apps := m.getFilteredAndSearchedApps(); m.appList.cursor < len(apps) {
+				path := apps[m.appList.cursor].Path
+				if m.appList.selectedApps[path] {
+					delete(m.appList.selectedApps, path)
+				} else {
+					m.appList.selectedApps[path] = true
+				}
+			}
+			return m, nil
+		case "delete_selected":
+			return m.promptUninstall()
+		case "escape", "multi_select", "left":
+			m.appList.multiSelect = false
+			m.appList.selectedApps = nil
+			return m, nil
+		default:
+			return m, nil
+		}
+	}
+
 	switch action {
 	case "left":
 		m.viewState = SimulatorListView
 		m.appList = appListState{}
 		m = m.updateViewport()
 	case "right":
-		if len(m.appList.apps) > 0 {
-			app := m.appList.apps[m.appList.cursor]
+		if filteredApps := m.getFilteredAndSearchedApps(); m.appList.cursor < len(filteredApps) {
+			app := filteredApps[m.appList.cursor]
 			m.fileList.selectedApp = &app
 			m.viewState = FileListView
 			m.fileList.loading = true
@@ -438,61 +2006,295 @@ func (m Model) handleAppListKey(action string) (tea.Model, tea.Cmd) {
 		}
 		m = m.updateViewport()
 	case "boot", "open":
-		if len(m.appList.apps) > 0 {
-			app := m.appList.apps[m.appList.cursor]
+		if filteredApps := m.getFilteredAndSearchedApps(); m.appList.cursor < len(filteredApps) {
+			app := filteredApps[m.appList.cursor]
 			if app.Container != "" {
 				// Open the app's container in Finder
 				return m, m.openInFinderCmd(app.Container)
 			}
 		}
-	case "search":
-		m.appList.searchMode = true
-		m.appList.searchQuery = ""
-		// Reset cursor to 0 when starting search
-		m.appList.cursor = 0
-		m.appList.viewport = 0
-		m = m.updateViewport()
-	}
-	return m, nil
-}
-
-// handleAllAppsKey handles key actions in the combined all-apps view.
-func (m Model) handleAllAppsKey(action string) (tea.Model, tea.Cmd) {
-	switch action {
-	case "right":
-		filteredApps := m.getFilteredAndSearchedAllApps()
-		if len(filteredApps) > 0 && m.allApps.cursor < len(filteredApps) {
-			app := filteredApps[m.allApps.cursor]
-			m.fileList.selectedApp = &app
-			m.viewState = FileListView
-			m.fileList.loading = true
-			m.fileList.currentPath = app.Container
-			m.fileList.basePath = app.Container
-			m.fileList.breadcrumbs = []string{}
-			m.fileList.cursorMemory = make(map[string]int)
-			m.fileList.viewportMemory = make(map[string]int)
-			return m, m.fetchFilesCmd(app.Container)
+	case "copy":
+		filteredApps := m.getFilteredAndSearchedApps()
+		if m.appList.cursor >= len(filteredApps) {
+			return m, nil
 		}
-	case "up":
-		if m.allApps.cursor > 0 {
-			m.allApps.cursor--
-			m = m.updateViewport()
+		app := filteredApps[m.appList.cursor]
+		if app.Container == "" {
+			return m.flashStatus(fmt.Sprintf("%s has no data container yet", app.Name), 2*time.Second)
 		}
-	case "down":
-		filteredApps := m.getFilteredAndSearchedAllApps()
-		if m.allApps.cursor < len(filteredApps)-1 {
-			m.allApps.cursor++
-			m = m.updateViewport()
+		return m, copyPathCmd(app.Container)
+	case "view_logs":
+		filteredApps := m.getFilteredAndSearchedApps()
+		if m.appList.selectedSim == nil || m.appList.cursor >= len(filteredApps) {
+			return m, nil
 		}
-	case "boot", "open":
-		filteredApps := m.getFilteredAndSearchedAllApps()
-		if len(filteredApps) > 0 && m.allApps.cursor < len(filteredApps) {
-			app := filteredApps[m.allApps.cursor]
+		if !m.appList.selectedSim.IsRunning() {
+			return m.flashStatus("Boot the simulator to stream its logs", 2*time.Second)
+		}
+		app := filteredApps[m.appList.cursor]
+		m.viewState = LogStreamView
+		m.logStream = logStreamState{app: &app, following: true}
+		return m, m.startLogStreamCmd(m.appList.selectedSim.UDID, app)
+	case "view_url_cache":
+		filteredApps := m.getFilteredAndSearchedApps()
+		if m.appList.cursor >= len(filteredApps) {
+			return m, nil
+		}
+		app := filteredApps[m.appList.cursor]
+		m.viewState = URLCacheView
+		m.urlCache = urlCacheState{app: &app, loading: true}
+		return m, m.fetchURLCacheCmd(app)
+	case "view_keychain":
+		filteredApps := m.getFilteredAndSearchedApps()
+		if m.appList.selectedSim == nil || m.appList.cursor >= len(filteredApps) {
+			return m, nil
+		}
+		app := filteredApps[m.appList.cursor]
+		m.viewState = KeychainView
+		m.keychain = keychainState{app: &app, loading: true}
+		return m, m.fetchKeychainCmd(m.appList.selectedSim.UDID, app)
+	case "view_app_groups":
+		filteredApps := m.getFilteredAndSearchedApps()
+		if m.appList.selectedSim == nil || m.appList.cursor >= len(filteredApps) {
+			return m, nil
+		}
+		app := filteredApps[m.appList.cursor]
+		m.viewState = AppGroupsView
+		m.appGroups = appGroupsState{app: &app, loading: true}
+		return m, m.fetchAppGroupsCmd(m.appList.selectedSim.UDID, app)
+	case "view_crash_logs":
+		filteredApps := m.getFilteredAndSearchedApps()
+		if m.appList.cursor >= len(filteredApps) {
+			return m, nil
+		}
+		app := filteredApps[m.appList.cursor]
+		m.viewState = CrashLogsView
+		m.crashLogs = crashLogsState{app: &app, loading: true}
+		return m, m.fetchCrashLogsCmd(app)
+	case "inspect_notifications":
+		filteredApps := m.getFilteredAndSearchedApps()
+		if m.appList.cursor >= len(filteredApps) {
+			return m, nil
+		}
+		app := filteredApps[m.appList.cursor]
+		if app.Container == "" {
+			return m.flashStatus("App has no data container", 2*time.Second)
+		}
+		return m, m.fetchNotificationInfoCmd(app)
+	case "export_container":
+		filteredApps := m.getFilteredAndSearchedApps()
+		if m.appList.cursor >= len(filteredApps) || m.appList.exporting != "" {
+			return m, nil
+		}
+		app := filteredApps[m.appList.cursor]
+		if app.Container == "" {
+			return m.flashStatus(fmt.Sprintf("%s has no data container yet", app.Name), 2*time.Second)
+		}
+		m.appList.exporting = app.BundleID
+		m.appList.exportFrame = 0
+		m.statusMessage = ""
+		return m, tea.Batch(exportContainerCmd(app), exportSpinnerCmd())
+	case "test_deep_link":
+		filteredApps := m.getFilteredAndSearchedApps()
+		if m.appList.cursor >= len(filteredApps) {
+			return m, nil
+		}
+		app := filteredApps[m.appList.cursor]
+		if len(app.URLSchemes) == 0 {
+			return m.flashStatus(fmt.Sprintf("%s registers no URL schemes", app.Name), 2*time.Second)
+		}
+		if m.appList.selectedSim == nil || !m.appList.selectedSim.IsRunning() {
+			return m.flashStatus("Boot the simulator to open URLs", 2*time.Second)
+		}
+		m.appList.deepLinkMode = true
+		m.appList.deepLinkURL = app.URLSchemes[0] + "://"
+	case "send_push_notification":
+		filteredApps := m.getFilteredAndSearchedApps()
+		if m.appList.cursor >= len(filteredApps) {
+			return m, nil
+		}
+		if m.appList.selectedSim == nil || !m.appList.selectedSim.IsRunning() {
+			return m.flashStatus("Boot the simulator to send push notifications", 2*time.Second)
+		}
+		m.appList.push = &pushOverlay{app: filteredApps[m.appList.cursor], payload: simulator.PushPayloadTemplate}
+		m.statusMessage = ""
+	case "view_permissions":
+		filteredApps := m.getFilteredAndSearchedApps()
+		if m.appList.cursor >= len(filteredApps) || m.appList.selectedSim == nil {
+			return m, nil
+		}
+		return m, m.fetchAppPermissionsCmd(m.appList.selectedSim.UDID, filteredApps[m.appList.cursor])
+	case "view_bundle_resources":
+		filteredApps := m.getFilteredAndSearchedApps()
+		if m.appList.cursor >= len(filteredApps) {
+			return m, nil
+		}
+		app := filteredApps[m.appList.cursor]
+		if app.Path == "" {
+			return m.flashStatus("App bundle location unknown", 2*time.Second)
+		}
+		// Open the bundle in tree view with the localizations expanded;
+		// the plain list behind it is loaded for when the tree is closed
+		m.fileList = fileListState{
+			selectedApp:     &app,
+			loading:         true,
+			currentPath:     app.Path,
+			basePath:        app.Path,
+			breadcrumbs:     []string{},
+			cursorMemory:    make(map[string]int),
+			viewportMemory:  make(map[string]int),
+			bundleResources: true,
+			treeView:        true,
+			treeLoading:     true,
+		}
+		m.viewState = FileListView
+		return m, tea.Batch(m.fetchFilesCmd(app.Path), m.fetchTreeCmd(app.Path))
+	case "view_resources":
+		sim := m.appList.selectedSim
+		if sim == nil {
+			return m, nil
+		}
+		if !sim.IsRunning() {
+			return m.flashStatus("Boot the simulator to see its resources", 2*time.Second)
+		}
+		m.appList.resourcesSeq++
+		m.appList.resources = &resourcesOverlay{simName: sim.Name}
+		return m, m.fetchMemoryStatsCmd(sim.UDID, m.appList.resourcesSeq, 0)
+	case "sort", "cycle_sort":
+		// Keep the selected app selected in the new order
+		var bundleID string
+		if apps := m.getFilteredAndSearchedApps(); m.appList.cursor < len(apps) {
+			bundleID = apps[m.appList.cursor].BundleID
+		}
+		m.appList.sortOrder = nextAppSortOrder(appListSortOrders, m.appList.sortOrder)
+		for i, app := range m.getFilteredAndSearchedApps() {
+			if app.BundleID == bundleID {
+				m.appList.cursor = i
+				break
+			}
+		}
+		m = m.updateViewport()
+	case "multi_select":
+		if len(m.getFilteredAndSearchedApps()) > 0 {
+			m.appList.multiSelect = true
+			m.appList.selectedApps = make(map[string]bool)
+		}
+	case "search":
+		m.appList.searchMode = true
+		m.appList.searchQuery = ""
+		// Reset cursor to 0 when starting search
+		m.appList.cursor = 0
+		m.appList.viewport = 0
+		m = m.updateViewport()
+	}
+	return m, nil
+}
+
+// promptUninstall asks for confirmation before uninstalling the selected
+// apps. simctl can only uninstall from a booted simulator.
+func (m Model) promptUninstall() (Model, tea.Cmd) {
+	if m.appList.uninstalling {
+		return m, nil
+	}
+	if len(m.appList.selectedApps) == 0 {
+		return m.flashStatus("No apps selected", 2*time.Second)
+	}
+	if m.appList.selectedSim == nil || !m.appList.selectedSim.IsRunning() {
+		return m.flashStatus("Boot the simulator to uninstall apps", 2*time.Second)
+	}
+	m.appList.confirmUninstall = true
+	return m, nil
+}
+
+// handleUninstallConfirm answers the uninstall prompt: y uninstalls the
+// selected apps, any other key cancels.
+func (m Model) handleUninstallConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.appList.confirmUninstall = false
+	if key := msg.String(); key != "y" && key != "Y" {
+		return m, nil
+	}
+
+	var apps []simulator.App
+	for _, app := range m.getFilteredAndSearchedApps() {
+		if m.appList.selectedApps[app.Path] {
+			apps = append(apps, app)
+		}
+	}
+	m.appList.uninstalling = true
+	m.statusMessage = fmt.Sprintf("Uninstalling %d apps...", len(apps))
+	return m, m.uninstallAppsCmd(m.appList.selectedSim.UDID, apps)
+}
+
+// handleAllAppsKey handles key actions in the combined all-apps view.
+func (m Model) handleAllAppsKey(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case "right":
+		filteredApps := m.getFilteredAndSearchedAllApps()
+		if len(filteredApps) > 0 && m.allApps.cursor < len(filteredApps) {
+			app := filteredApps[m.allApps.cursor]
+			m.fileList.selectedApp = &app
+			m.viewState = FileListView
+			m.fileList.loading = true
+			m.fileList.currentPath = app.Container
+			m.fileList.basePath = app.Container
+			m.fileList.breadcrumbs = []string{}
+			m.fileList.cursorMemory = make(map[string]int)
+			m.fileList.viewportMemory = make(map[string]int)
+			return m, m.fetchFilesCmd(app.Container)
+		}
+	case "up":
+		if m.allApps.cursor > 0 {
+			m.allApps.cursor--
+			m = m.updateViewport()
+		}
+	case "down":
+		filteredApps := m.getFilteredAndSearchedAllApps()
+		if m.allApps.cursor < len(filteredApps)-1 {
+			m.allApps.cursor++
+			m = m.updateViewport()
+		}
+	case "home":
+		m.allApps.cursor = 0
+		m.allApps.viewport = 0
+	case "end":
+		m.allApps.cursor = max(len(m.getFilteredAndSearchedAllApps())-1, 0)
+		m = m.updateViewport()
+	case "boot", "open":
+		filteredApps := m.getFilteredAndSearchedAllApps()
+		if len(filteredApps) > 0 && m.allApps.cursor < len(filteredApps) {
+			app := filteredApps[m.allApps.cursor]
 			if app.Container != "" {
 				// Open the app's container in Finder
 				return m, m.openInFinderCmd(app.Container)
 			}
 		}
+	case "copy":
+		filteredApps := m.getFilteredAndSearchedAllApps()
+		if m.allApps.cursor >= len(filteredApps) {
+			return m, nil
+		}
+		app := filteredApps[m.allApps.cursor]
+		if app.Container == "" {
+			return m.flashStatus(fmt.Sprintf("%s has no data container yet", app.Name), 2*time.Second)
+		}
+		return m, copyPathCmd(app.Container)
+	case "view_logs":
+		filteredApps := m.getFilteredAndSearchedAllApps()
+		if m.allApps.cursor >= len(filteredApps) {
+			return m, nil
+		}
+		app := filteredApps[m.allApps.cursor]
+		sim := m.findSimulator(app.SimulatorUDID)
+		if sim == nil || !sim.IsRunning() {
+			return m.flashStatus(fmt.Sprintf("Boot %s to stream its logs", app.SimulatorName), 2*time.Second)
+		}
+		m.viewState = LogStreamView
+		m.logStream = logStreamState{app: &app, following: true}
+		return m, m.startLogStreamCmd(sim.UDID, app)
+	case "cycle_sort", "sort":
+		m.allApps.sortOrder = nextAppSortOrder(allAppsSortOrders, m.allApps.sortOrder)
+		m.allApps.cursor = 0
+		m.allApps.viewport = 0
 	case "search":
 		m.allApps.searchMode = true
 		m.allApps.searchQuery = ""
@@ -504,299 +2306,1394 @@ func (m Model) handleAllAppsKey(action string) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// handleFileListKey handles key actions in the file list view.
-func (m Model) handleFileListKey(action string) (tea.Model, tea.Cmd) {
+// handleFileListKey handles key actions in the file list view.
+func (m Model) handleFileListKey(action string) (tea.Model, tea.Cmd) {
+	// Any key closes the metadata panel
+	if m.fileList.metadata != nil {
+		m.fileList.metadata = nil
+		return m, nil
+	}
+	if m.fileList.treeView {
+		return m.handleFileTreeKey(action)
+	}
+
+	switch action {
+	case "left":
+		if len(m.fileList.breadcrumbs) > 0 {
+			// Go up one directory level, showing files of every size
+			m.fileList.inDatabases = false
+			m.fileList.minSizeFilter = 0
+			m.fileList.breadcrumbs = m.fileList.breadcrumbs[:len(m.fileList.breadcrumbs)-1]
+			newPath := m.fileList.basePath
+			if len(m.fileList.breadcrumbs) > 0 {
+				newPath = filepath.Join(append([]string{m.fileList.basePath}, m.fileList.breadcrumbs...)...)
+			}
+			m.fileList.currentPath = newPath
+			m.fileList.loading = true
+			return m, m.fetchFilesCmd(newPath)
+		}
+		// At root level, go back to the view the container was opened
+		// from. Reading fileList before the clear is deliberate: it
+		// decides which view we return to.
+		nextView := AppListView
+		switch {
+		case m.fileList.fromAppGroups:
+			nextView = AppGroupsView
+		case m.fileList.selectedApp != nil && m.fileList.selectedApp.SimulatorUDID != "":
+			nextView = AllAppsView
+		}
+		m.viewState = nextView
+		m.fileList = fileListState{}
+		m = m.updateViewport()
+	case "right":
+		if len(m.fileList.files) > 0 {
+			file := m.fileList.files[m.fileList.cursor]
+			if isDatabasesEntry(file) {
+				return m.openDatabasesEntry(), nil
+			}
+			if file.IsDirectory && simulator.DetectFileType(file.Path) == simulator.FileTypeAssetCatalog {
+				// Asset catalogs open as a whole in the file viewer
+				return m.openFile(file)
+			}
+			if file.IsDirectory {
+				// Save current cursor position before drilling in
+				m = m.rememberFilePosition(m.fileList.currentPath, m.fileList.cursor, m.fileList.viewport)
+
+				// Drill into the directory
+				m.fileList.breadcrumbs = append(m.fileList.breadcrumbs, file.Name)
+				m.fileList.currentPath = file.Path
+				m.fileList.loading = true
+				return m, m.fetchFilesCmd(file.Path)
+			}
+			return m.openFile(file)
+		}
+	case "up":
+		if m.fileList.cursor > 0 {
+			m.fileList.cursor--
+			m = m.updateViewport()
+		}
+	case "down":
+		if m.fileList.cursor < len(m.fileList.files)-1 {
+			m.fileList.cursor++
+			m = m.updateViewport()
+		}
+	case "home":
+		m.fileList.cursor = 0
+		m.fileList.viewport = 0
+	case "end":
+		m.fileList.cursor = len(m.fileList.files) - 1
+		m = m.updateViewport()
+	case "boot", "open":
+		if len(m.fileList.files) > 0 {
+			file := m.fileList.files[m.fileList.cursor]
+			if isDatabasesEntry(file) {
+				return m, nil
+			}
+			// Open in Finder - for files, this will reveal them in their containing folder
+			return m, m.openInFinderCmd(file.Path)
+		}
+	case "copy":
+		if len(m.fileList.files) == 0 {
+			return m, nil
+		}
+		if file := m.fileList.files[m.fileList.cursor]; !isDatabasesEntry(file) {
+			return m, copyPathCmd(file.Path)
+		}
+	case "diff":
+		if len(m.fileList.files) == 0 {
+			return m, nil
+		}
+		file := m.fileList.files[m.fileList.cursor]
+		if file.IsDirectory {
+			return m.flashStatus(fmt.Sprintf("%s is a directory", file.Name), 2*time.Second)
+		}
+		picked := m.diffFile(file)
+		if m.diffMark == nil {
+			m.diffMark = &picked
+			return m, nil
+		}
+		if m.diffMark.file.Path == file.Path {
+			// Pressing it again on the marked file clears the mark
+			m.diffMark = nil
+			return m, nil
+		}
+		marked := *m.diffMark
+		m.diffMark = nil
+		m.viewState = DiffView
+		m.diff = diffState{oldFile: marked.file, newFile: file, loading: true}
+		m.diff.oldName, m.diff.newName = diffNames(marked, picked)
+		return m, m.fetchDiffCmd(marked.file.Path, file.Path)
+	case "escape":
+		m.diffMark = nil
+	case "show_metadata":
+		if len(m.fileList.files) == 0 {
+			return m, nil
+		}
+		file := m.fileList.files[m.fileList.cursor]
+		if isDatabasesEntry(file) {
+			return m, nil
+		}
+		if file.Xattrs != nil {
+			m.fileList.metadata = &file
+			return m, nil
+		}
+		return m, m.fetchXattrsCmd(file.Path)
+	case "open_database":
+		if len(m.fileList.files) == 0 {
+			return m, nil
+		}
+		file := m.fileList.files[m.fileList.cursor]
+		if file.IsDirectory || simulator.DetectFileType(file.Path) != simulator.FileTypeDatabase {
+			return m.flashStatus(fmt.Sprintf("%s is not a SQLite database", file.Name), 2*time.Second)
+		}
+		return m.openFile(file)
+	case "toggle_tree":
+		if m.fileList.inDatabases {
+			// The databases listing has no directory of its own to show
+			return m, nil
+		}
+		m.fileList.treeView = true
+		m.fileList.treeLoading = true
+		m.fileList.tree = nil
+		return m, m.fetchTreeCmd(m.fileList.currentPath)
+	case "increase_size_filter":
+		m.fileList.minSizeFilter += sizeFilterStep
+		return m.applySizeFilter(), nil
+	case "decrease_size_filter":
+		m.fileList.minSizeFilter = max(m.fileList.minSizeFilter-sizeFilterStep, 0)
+		return m.applySizeFilter(), nil
+	case "reset_size_filter":
+		m.fileList.minSizeFilter = 0
+		return m.applySizeFilter(), nil
+	case "rename":
+		if len(m.fileList.files) == 0 || m.fileList.inDatabases || m.fileList.bundleResources {
+			return m, nil
+		}
+		file := m.fileList.files[m.fileList.cursor]
+		if isDatabasesEntry(file) {
+			return m, nil
+		}
+		m.fileList.renameMode = true
+		m.fileList.renameName = file.Name
+		m.fileList.renameError = ""
+		m.statusMessage = ""
+	case "delete":
+		if len(m.fileList.files) == 0 || m.fileList.inDatabases || m.fileList.bundleResources {
+			return m, nil
+		}
+		if !isDatabasesEntry(m.fileList.files[m.fileList.cursor]) {
+			m.fileList.confirmDelete = true
+			m.statusMessage = ""
+		}
+	case "duplicate_file":
+		if len(m.fileList.files) == 0 || m.fileList.inDatabases || m.fileList.bundleResources {
+			// Copies would land elsewhere, or inside the signed app bundle
+			return m, nil
+		}
+		file := m.fileList.files[m.fileList.cursor]
+		if isDatabasesEntry(file) {
+			return m, nil
+		}
+		if !simulator.CanDuplicate(file.Path) {
+			return m.flashStatus(fmt.Sprintf("Can't duplicate %s: no write permission", file.Name), 2*time.Second)
+		}
+		return m, m.duplicateFileCmd(file)
+	}
+	return m, nil
+}
+
+// sizeFilterStep is how much the size filter keys change the minimum
+// file size, in bytes
+const sizeFilterStep = 1024
+
+// setFiles lists files in the file list, less those the size filter hides
+func (m Model) setFiles(files []simulator.FileInfo) Model {
+	m.fileList.allFiles = files
+	return m.applySizeFilter()
+}
+
+// applySizeFilter lists the files at least minSizeFilter bytes in size.
+// Directories are listed whatever their size. The cursor stays within
+// the list.
+func (m Model) applySizeFilter() Model {
+	if m.fileList.minSizeFilter == 0 {
+		m.fileList.files = m.fileList.allFiles
+	} else {
+		files := make([]simulator.FileInfo, 0, len(m.fileList.allFiles))
+		for _, file := range m.fileList.allFiles {
+			if file.IsDirectory || file.Size >= m.fileList.minSizeFilter {
+				files = append(files, file)
+			}
+		}
+		m.fileList.files = files
+	}
+	m.fileList.cursor = max(min(m.fileList.cursor, len(m.fileList.files)-1), 0)
+	return m.updateViewport()
+}
+
+// databasesEntryName names the entry at the container root that lists
+// every database in the container.
+const databasesEntryName = "Databases"
+
+// databasesEntry returns the container-root entry that collects the
+// given databases. It has no path since it isn't a real directory.
+func databasesEntry(databases []simulator.FileInfo) simulator.FileInfo {
+	entry := simulator.FileInfo{Name: databasesEntryName, IsDirectory: true}
+	for _, db := range databases {
+		entry.Size += db.Size
+		if db.ModifiedAt.After(entry.ModifiedAt) {
+			entry.CreatedAt = db.ModifiedAt
+			entry.ModifiedAt = db.ModifiedAt
+		}
+	}
+	return entry
+}
+
+// isDatabasesEntry reports whether file is the entry made by databasesEntry
+func isDatabasesEntry(file simulator.FileInfo) bool {
+	return file.IsDirectory && file.Path == "" && file.Name == databasesEntryName
+}
+
+// openDatabasesEntry lists the container's databases as if they were a
+// folder. Going back refetches the container root.
+func (m Model) openDatabasesEntry() Model {
+	m = m.rememberFilePosition(m.fileList.currentPath, m.fileList.cursor, m.fileList.viewport)
+
+	m.fileList.breadcrumbs = append(m.fileList.breadcrumbs, databasesEntryName)
+	m = m.setFiles(m.fileList.databases)
+	m.fileList.inDatabases = true
+	m.fileList.cursor = 0
+	m.fileList.viewport = 0
+	return m
+}
+
+// isCoreDataStore reports whether file may be a Core Data store: a
+// .sqlite file in the selected app's data container, whose model would
+// be in the app bundle
+func (m Model) isCoreDataStore(file simulator.FileInfo) bool {
+	app := m.fileList.selectedApp
+	if app == nil || app.Path == "" || m.fileList.bundleResources || m.fileList.fromAppGroups {
+		return false
+	}
+	if !strings.EqualFold(filepath.Ext(file.Path), ".sqlite") {
+		return false
+	}
+	base := strings.TrimPrefix(m.fileList.basePath, "file://")
+	path := strings.TrimPrefix(file.Path, "file://")
+	return base != "" && strings.HasPrefix(path, strings.TrimSuffix(base, "/")+"/")
+}
+
+// openFile opens a file from the file list: SQLite databases go to the
+// table list, everything else to the file viewer.
+func (m Model) openFile(file simulator.FileInfo) (Model, tea.Cmd) {
+	// Check if it's a database file
+	fileType := simulator.DetectFileType(file.Path)
+	if fileType == simulator.FileTypeDatabase {
+		// View database tables
+		m.dbTables.file = &file
+		m.viewState = DatabaseTableListView
+		m.dbTables.loading = true
+		m.dbTables.cursor = 0
+		m.dbTables.viewport = 0
+		m.dbTables.coreDataEnhance = m.isCoreDataStore(file)
+		bundlePath := ""
+		if m.dbTables.coreDataEnhance {
+			bundlePath = m.fileList.selectedApp.Path
+		}
+		return m, m.fetchDatabaseInfoCmd(file.Path, bundlePath)
+	}
+	// View the file
+	m.fileViewer.file = &file
+	m.viewState = FileViewerView
+	m.fileViewer.loading = true
+	m.fileViewer.contentOffset = 0
+	m.fileViewer.contentViewport = 0
+	return m, m.fetchFileContentCmd(file.Path, 0, m.width, m.height)
+}
+
+// handleFileTreeKey handles key actions in the file list's tree view.
+// Right expands a directory (reading it first if it is past the initial
+// depth) or opens a file; left collapses a directory or moves to the
+// parent, and leaves the tree from a top-level entry.
+func (m Model) handleFileTreeKey(action string) (tea.Model, tea.Cmd) {
+	if action == "toggle_tree" || (action == "left" && m.fileList.treeLoading) {
+		return m.closeFileTree(), nil
+	}
+	visible := simulator.VisibleTreeNodes(m.fileList.tree, m.fileList.treeExpanded)
+	if m.fileList.treeLoading || m.fileList.treeCursor >= len(visible) {
+		return m, nil
+	}
+	node := visible[m.fileList.treeCursor]
+
+	switch action {
+	case "right":
+		if !node.IsDirectory {
+			return m.openFile(node.FileInfo)
+		}
+		if !node.Loaded {
+			return m, m.fetchTreeChildrenCmd(node)
+		}
+		m.fileList.treeExpanded[node.Path] = !m.fileList.treeExpanded[node.Path]
+	case "left":
+		switch {
+		case node.IsDirectory && m.fileList.treeExpanded[node.Path]:
+			m.fileList.treeExpanded[node.Path] = false
+		case node.Depth > 0:
+			// Move to the parent directory's line
+			for i := m.fileList.treeCursor - 1; i >= 0; i-- {
+				if visible[i].Depth == node.Depth-1 {
+					m.fileList.treeCursor = i
+					break
+				}
+			}
+		default:
+			return m.closeFileTree(), nil
+		}
+	case "up":
+		if m.fileList.treeCursor > 0 {
+			m.fileList.treeCursor--
+		}
+	case "down":
+		if m.fileList.treeCursor < len(visible)-1 {
+			m.fileList.treeCursor++
+		}
+	case "home":
+		m.fileList.treeCursor = 0
+	case "end":
+		m.fileList.treeCursor = len(visible) - 1
+	case "boot", "open":
+		return m, m.openInFinderCmd(node.Path)
+	case "copy":
+		return m, copyPathCmd(node.Path)
+	}
+	return m.updateFileTreeViewport(), nil
+}
+
+// closeFileTree leaves tree view, returning to the plain file list.
+func (m Model) closeFileTree() Model {
+	m.fileList.treeView = false
+	m.fileList.treeLoading = false
+	m.fileList.tree = nil
+	m.fileList.treeExpanded = nil
+	m.fileList.treeCursor = 0
+	m.fileList.treeViewport = 0
+	return m
+}
+
+// updateFileTreeViewport keeps the tree cursor on screen, also clamping
+// it after a collapse shrank the visible tree.
+func (m Model) updateFileTreeViewport() Model {
+	visible := simulator.VisibleTreeNodes(m.fileList.tree, m.fileList.treeExpanded)
+	m.fileList.treeCursor = min(m.fileList.treeCursor, max(len(visible)-1, 0))
+	updateViewportForList(&m.fileList.treeCursor, &m.fileList.treeViewport, len(visible), m.fileTreeRowsPerScreen())
+	return m
+}
+
+// fileTreeRowsPerScreen is how many tree lines the file list shows.
+func (m Model) fileTreeRowsPerScreen() int {
+	fileList := components.NewFileList(m.width-6, m.height-8)
+	fileList.Update(nil, 0, 0, m.fileList.selectedApp, m.fileList.breadcrumbs, nil)
+	return fileList.TreeRowsPerScreen()
+}
+
+// handleFileViewerKey handles key actions in the file viewer view.
+func (m Model) handleFileViewerKey(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case "left":
+		m.viewState = m.fileViewerParent()
+		m.fileViewer = fileViewerState{}
+		m = m.updateViewport()
+	case "up":
+		if m.fileViewer.content == nil {
+			return m, nil
+		}
+		switch m.fileViewer.content.Type {
+		case simulator.FileTypeText:
+			if m.fileViewer.contentViewport > 0 {
+				m.fileViewer.contentViewport--
+			} else if m.fileViewer.contentOffset > 0 {
+				// Need to load previous chunk
+				newOffset := m.fileViewer.contentOffset - textChunkBackStep
+				if newOffset < 0 {
+					newOffset = 0
+				}
+				m.fileViewer.contentOffset = newOffset
+				m.fileViewer.loading = true
+				return m, m.fetchFileContentCmd(m.fileViewer.file.Path, newOffset, m.width, m.height)
+			}
+		case simulator.FileTypeImage:
+			if m.fileViewer.contentViewport > 0 {
+				m.fileViewer.contentViewport--
+			}
+		case simulator.FileTypeBinary:
+			if m.fileViewer.contentViewport > 0 {
+				m.fileViewer.contentViewport--
+			} else if m.fileViewer.contentOffset > 0 {
+				// Need to load previous chunk
+				newOffset := m.fileViewer.contentOffset - binaryChunkBackStep
+				if newOffset < 0 {
+					newOffset = 0
+				}
+				m.fileViewer.contentOffset = newOffset
+				m.fileViewer.loading = true
+				// Convert line offset to hex-dump row offset
+				return m, m.fetchFileContentCmd(m.fileViewer.file.Path, newOffset/simulator.HexBytesPerLine, m.width, m.height)
+			}
+		case simulator.FileTypeArchive:
+			// Allow scrolling through archive entries
+			if m.fileViewer.content.ArchiveInfo != nil && m.fileViewer.contentViewport > 0 {
+				m.fileViewer.contentViewport--
+			}
+		case simulator.FileTypeBinaryCookies, simulator.FileTypeAssetCatalog:
+			if m.fileViewer.contentViewport > 0 {
+				m.fileViewer.contentViewport--
+			}
+		}
+	case "down":
+		if m.fileViewer.content == nil {
+			return m, nil
+		}
+		if m.fileViewer.contentViewport < m.fileViewerMaxViewport() {
+			m.fileViewer.contentViewport++
+			return m, nil
+		}
+		switch m.fileViewer.content.Type {
+		case simulator.FileTypeText:
+			if m.fileViewer.contentOffset+len(m.fileViewer.content.Lines) < m.fileViewer.content.TotalLines {
+				// Need to load more content
+				newOffset := m.fileViewer.contentOffset + len(m.fileViewer.content.Lines)
+				m.fileViewer.contentOffset = newOffset
+				m.fileViewer.contentViewport = 0 // Reset viewport for new chunk
+				m.fileViewer.loading = true
+				return m, m.fetchFileContentCmd(m.fileViewer.file.Path, newOffset, m.width, m.height)
+			}
+		case simulator.FileTypeBinary:
+			// Check if we need to load more data
+			currentEndByte := m.fileViewer.content.BinaryOffset + int64(len(m.fileViewer.content.BinaryData))
+			if currentEndByte < m.fileViewer.content.TotalSize {
+				// Load next chunk
+				hexLines := simulator.FormatHexDump(m.fileViewer.content.BinaryData, m.fileViewer.content.BinaryOffset)
+				newOffset := m.fileViewer.contentOffset + len(hexLines)
+				m.fileViewer.contentOffset = newOffset
+				m.fileViewer.contentViewport = 0 // Reset viewport for new chunk
+				m.fileViewer.loading = true
+				// Load with line offset (total lines from start)
+				return m, m.fetchFileContentCmd(m.fileViewer.file.Path, newOffset, m.width, m.height)
+			}
+		}
+	case "scroll_top":
+		if m.fileViewer.content == nil {
+			return m, nil
+		}
+		m.fileViewer.contentViewport = 0
+		if m.fileViewer.contentOffset > 0 {
+			// Text and binary files load in chunks; go back to the first
+			m.fileViewer.contentOffset = 0
+			m.fileViewer.scrollToEnd = false
+			m.fileViewer.loading = true
+			return m, m.fetchFileContentCmd(m.fileViewer.file.Path, 0, m.width, m.height)
+		}
+	case "scroll_bottom":
+		if m.fileViewer.content == nil {
+			return m, nil
+		}
+		if offset, ok := m.fileViewerLastChunk(); ok {
+			m.fileViewer.contentOffset = offset
+			m.fileViewer.contentViewport = 0
+			m.fileViewer.scrollToEnd = true
+			m.fileViewer.loading = true
+			return m, m.fetchFileContentCmd(m.fileViewer.file.Path, offset, m.width, m.height)
+		}
+		m.fileViewer.contentViewport = m.fileViewerMaxViewport()
+	case "binary_chunks":
+		if m.fileViewer.content == nil || m.fileViewer.content.Type != simulator.FileTypeBinary {
+			return m, nil
+		}
+		m.binaryChunkSize = nextBinaryChunkSize(m.chunkSize())
+		// Refetch from the top visible row so the view doesn't move
+		top := m.fileViewer.contentOffset + m.fileViewer.contentViewport
+		m.fileViewer.contentOffset = top
+		m.fileViewer.contentViewport = 0
+		m.fileViewer.loading = true
+		fetch := m.fetchFileContentCmd(m.fileViewer.file.Path, top, m.width, m.height)
+		var flash tea.Cmd
+		m, flash = m.flashStatus("Chunk size: "+simulator.FormatSize(int64(m.binaryChunkSize)), 2*time.Second)
+		return m, tea.Batch(fetch, flash)
+	case "copy_file_content":
+		text, ok := m.fileViewerClipboardText()
+		if !ok {
+			return m.flashStatus("Nothing to copy", 2*time.Second)
+		}
+		return m, copyToClipboardCmd(text)
+	case "editor":
+		if m.fileViewer.file == nil {
+			return m, nil
+		}
+		return m, openInEditorCmd(m.fileViewer.file.Path)
+	case "search":
+		// Line numbers only mean something for text
+		if m.fileViewer.file == nil || m.fileViewer.content == nil || m.fileViewer.content.Type != simulator.FileTypeText {
+			return m, nil
+		}
+		m.fileSearch = fileSearchState{}
+		m.viewState = FileSearchView
+	}
+	return m, nil
+}
+
+// handleFileSearchInput edits the query of the file search; every edit
+// re-runs the search. The arrow keys move through the matches, since
+// letters go to the query. Enter shows the selected line in the file
+// viewer and escape goes back to where the viewer was.
+func (m Model) handleFileSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keyMap.GetAction(msg.String()) {
+	case "escape":
+		m.viewState = FileViewerView
+		return m, nil
+	case "enter":
+		if m.fileSearch.cursor >= len(m.fileSearch.matches) {
+			return m, nil
+		}
+		line := m.fileSearch.matches[m.fileSearch.cursor].LineNum
+		m.viewState = FileViewerView
+		m.fileViewer.contentOffset = line - 1
+		m.fileViewer.contentViewport = 0
+		m.fileViewer.scrollToEnd = false
+		m.fileViewer.loading = true
+		return m, m.fetchFileContentCmd(m.fileViewer.file.Path, line-1, m.width, m.height)
+	case "backspace":
+		if m.fileSearch.query == "" {
+			return m, nil
+		}
+		query := []rune(m.fileSearch.query)
+		m.fileSearch.query = string(query[:len(query)-1])
+		return m.runFileSearch()
+	}
+
+	switch key := msg.String(); {
+	case key == "up":
+		if m.fileSearch.cursor > 0 {
+			m.fileSearch.cursor--
+		}
+		return m.updateViewport(), nil
+	case key == "down":
+		if m.fileSearch.cursor < len(m.fileSearch.matches)-1 {
+			m.fileSearch.cursor++
+		}
+		return m.updateViewport(), nil
+	case len([]rune(key)) == 1:
+		m.fileSearch.query += key
+		return m.runFileSearch()
+	}
+	return m, nil
+}
+
+// runFileSearch searches the viewed file for the current query, from
+// the first match. An empty query clears the matches.
+func (m Model) runFileSearch() (tea.Model, tea.Cmd) {
+	m.fileSearch.cursor = 0
+	m.fileSearch.viewport = 0
+	if m.fileSearch.query == "" {
+		m.fileSearch.matches = nil
+		m.fileSearch.loading = false
+		return m, nil
+	}
+	m.fileSearch.loading = true
+	return m, searchFileCmd(m.fileViewer.file.Path, m.fileSearch.query)
+}
+
+// fileViewerMaxViewport returns the furthest the file viewer can scroll
+// within the loaded content, leaving the last screenful in view
+func (m Model) fileViewerMaxViewport() int {
+	content := m.fileViewer.content
+	if content == nil {
+		return 0
+	}
+	itemsPerScreen := CalculateItemsPerScreen(m.height)
+	var total int
+	switch content.Type {
+	case simulator.FileTypeText:
+		total = len(content.Lines)
+		itemsPerScreen -= 5 // Account for header
+	case simulator.FileTypeImage:
+		if content.ImageInfo == nil || content.ImageInfo.Preview == nil {
+			return 0
+		}
+		total = 8 + len(content.ImageInfo.Preview.Rows) // ~8 lines for metadata
+		itemsPerScreen -= 5
+	case simulator.FileTypeBinary:
+		total = len(simulator.FormatHexDump(content.BinaryData, content.BinaryOffset))
+		itemsPerScreen -= 5 // Account for header
+	case simulator.FileTypeArchive:
+		// One line per archive entry
+		if content.ArchiveInfo == nil {
+			return 0
+		}
+		total = len(content.ArchiveInfo.Entries)
+		itemsPerScreen -= 3 // Header takes 3 lines
+	case simulator.FileTypeAssetCatalog:
+		// One tree line per folder or asset
+		if content.AssetCatalog == nil {
+			return 0
+		}
+		total = len(content.AssetCatalog.Assets)
+		itemsPerScreen -= 3 // Header takes 3 lines
+	case simulator.FileTypeBinaryCookies:
+		// One row per cookie, below the same headers as a table view
+		if content.CookiesInfo == nil {
+			return 0
+		}
+		total = len(content.CookiesInfo.Cookies)
+		itemsPerScreen -= 8
+	}
+	return max(total-itemsPerScreen, 0)
+}
+
+// fileViewerLastChunk returns the offset to fetch the last chunk of a
+// text or binary file from, in lines or hex dump rows, or false if the
+// end of the file is already loaded
+func (m Model) fileViewerLastChunk() (int, bool) {
+	content := m.fileViewer.content
+	switch content.Type {
+	case simulator.FileTypeText:
+		if m.fileViewer.contentOffset+len(content.Lines) >= content.TotalLines {
+			return 0, false
+		}
+		return max(content.TotalLines-textLinesPerChunk, 0), true
+	case simulator.FileTypeBinary:
+		if content.BinaryOffset+int64(len(content.BinaryData)) >= content.TotalSize {
+			return 0, false
+		}
+		totalRows := int((content.TotalSize + simulator.HexBytesPerLine - 1) / simulator.HexBytesPerLine)
+		return max(totalRows-m.chunkSize()/simulator.HexBytesPerLine, 0), true
+	}
+	return 0, false
+}
+
+// fileViewerClipboardText returns the file viewer content to copy: the
+// loaded chunk of a text file, the hex dump rows in view for a binary
+// file, or an archive's file tree. Other file types have nothing to
+// copy.
+func (m Model) fileViewerClipboardText() (string, bool) {
+	content := m.fileViewer.content
+	if content == nil {
+		return "", false
+	}
+	var lines []string
+	switch content.Type {
+	case simulator.FileTypeText:
+		lines = content.Lines
+	case simulator.FileTypeBinary:
+		hexLines := simulator.FormatHexDump(content.BinaryData, content.BinaryOffset)
+		start := min(m.fileViewer.contentViewport, len(hexLines))
+		end := min(start+max(CalculateItemsPerScreen(m.height)-5, 1), len(hexLines))
+		lines = hexLines[start:end]
+	case simulator.FileTypeArchive:
+		lines = file_viewer.ArchiveTreeLines(content.ArchiveInfo)
+	}
+	if len(lines) == 0 {
+		return "", false
+	}
+	return strings.Join(lines, "\n"), true
+}
+
+// binaryChunkSizes are the chunk sizes the binary_chunks key cycles
+// through, in bytes
+var binaryChunkSizes = []int{4096, 8192, 16384, 65536}
+
+// nextBinaryChunkSize returns the chunk size after size in
+// binaryChunkSizes, wrapping around. A size not in the list, e.g. one
+// from the config, moves to the next larger one.
+func nextBinaryChunkSize(size int) int {
+	for _, s := range binaryChunkSizes {
+		if s > size {
+			return s
+		}
+	}
+	return binaryChunkSizes[0]
+}
+
+// handleDatabaseTableListKey handles key actions in the database table list view.
+func (m Model) handleDatabaseTableListKey(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case "left":
+		m.viewState = FileListView
+		m.dbTables = dbTableListState{}
+		m = m.updateViewport()
+	case "right":
+		if m.dbTables.info != nil && len(m.dbTables.info.Tables) > 0 && m.dbTables.cursor < len(m.dbTables.info.Tables) {
+			table := m.dbTables.info.Tables[m.dbTables.cursor]
+			m.viewState = DatabaseTableContentView
+			// A new table starts unscrolled and unsorted
+			m.dbContent = dbTableContentState{table: &table, loading: true}
+			// Load first page of table data
+			return m, m.fetchTableDataCmd(m.dbTables.file.Path, table.Name, 0, simulator.MaxDisplayRows)
+		}
+	case "up":
+		if m.dbTables.cursor > 0 {
+			m.dbTables.cursor--
+			m = m.updateViewport()
+		}
+	case "down":
+		if m.dbTables.info != nil && m.dbTables.cursor < len(m.dbTables.info.Tables)-1 {
+			m.dbTables.cursor++
+			m = m.updateViewport()
+		}
+	case "vacuum_database":
+		if m.dbTables.file != nil && !m.dbTables.loading && !m.dbTables.vacuuming {
+			m.dbTables.confirmVacuum = true
+		}
+	case "query_database":
+		if m.dbTables.file != nil && !m.dbTables.loading && !m.dbTables.vacuuming {
+			history := m.dbQuery.history
+			m.dbQuery = dbQueryState{history: history, historyPos: len(history)}
+			m.viewState = DatabaseQueryView
+		}
+	}
+	return m, nil
+}
+
+// queryScrollRows is how many result rows the page keys scroll in the
+// query editor
+const queryScrollRows = 10
+
+// handleDatabaseQueryInput edits the query in the SQL query editor.
+// Enter starts a new line and the run_query key runs the query. The
+// arrow keys move the cursor and recall earlier queries, since letters
+// go to the query; the page keys and shift+arrows scroll the results.
+// Escape goes back to the table list.
+func (m Model) handleDatabaseQueryInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	q := m.dbQuery
+	input := []rune(q.input)
+	switch m.keyMap.GetAction(msg.String()) {
+	case "escape":
+		m.viewState = DatabaseTableListView
+		return m, nil
+	case "run_query":
+		query := strings.TrimSpace(q.input)
+		if query == "" || q.running {
+			return m, nil
+		}
+		q.history = appendQueryHistory(q.history, query)
+		q.historyPos = len(q.history)
+		q.running = true
+		m.dbQuery = q
+		m.statusMessage = ""
+		return m, runQueryCmd(m.dbTables.file.Path, query)
+	case "backspace":
+		if q.cursor > 0 {
+			q.input = string(input[:q.cursor-1]) + string(input[q.cursor:])
+			q.cursor--
+		}
+	case "enter":
+		q = q.insert("\n")
+	default:
+		switch msg.Type {
+		case tea.KeyLeft:
+			q.cursor = max(q.cursor-1, 0)
+		case tea.KeyRight:
+			q.cursor = min(q.cursor+1, len(input))
+		case tea.KeyHome:
+			for q.cursor > 0 && input[q.cursor-1] != '\n' {
+				q.cursor--
+			}
+		case tea.KeyEnd:
+			for q.cursor < len(input) && input[q.cursor] != '\n' {
+				q.cursor++
+			}
+		case tea.KeyUp:
+			if q.historyPos > 0 {
+				q.historyPos--
+				q = q.recall()
+			}
+		case tea.KeyDown:
+			if q.historyPos < len(q.history) {
+				q.historyPos++
+				q = q.recall()
+			}
+		case tea.KeyPgUp:
+			q.viewport = max(q.viewport-queryScrollRows, 0)
+		case tea.KeyPgDown:
+			if q.result != nil {
+				q.viewport = max(min(q.viewport+queryScrollRows, len(q.result.Rows)-1), 0)
+			}
+		case tea.KeyShiftLeft:
+			q.hScroll = max(q.hScroll-1, 0)
+		case tea.KeyShiftRight:
+			if q.result != nil && q.hScroll < len(q.result.Columns)-1 {
+				q.hScroll++
+			}
+		case tea.KeyTab:
+			q = q.insert("  ")
+		case tea.KeySpace:
+			q = q.insert(" ")
+		case tea.KeyRunes:
+			if !msg.Alt {
+				q = q.insert(string(msg.Runes))
+			}
+		}
+	}
+	m.dbQuery = q
+	return m, nil
+}
+
+// insert types text into the query at the cursor
+func (q dbQueryState) insert(text string) dbQueryState {
+	input := []rune(q.input)
+	inserted := []rune(text)
+	q.input = string(input[:q.cursor]) + text + string(input[q.cursor:])
+	q.cursor += len(inserted)
+	return q
+}
+
+// recall puts the query at historyPos in the editor, or clears it past
+// the last query
+func (q dbQueryState) recall() dbQueryState {
+	q.input = ""
+	if q.historyPos < len(q.history) {
+		q.input = q.history[q.historyPos]
+	}
+	q.cursor = len([]rune(q.input))
+	return q
+}
+
+// appendQueryHistory adds query to the end of history, moving it there
+// if it was run before, and keeps the last queryHistorySize queries
+func appendQueryHistory(history []string, query string) []string {
+	history = slices.DeleteFunc(slices.Clone(history), func(h string) bool { return h == query })
+	history = append(history, query)
+	if len(history) > queryHistorySize {
+		history = history[len(history)-queryHistorySize:]
+	}
+	return history
+}
+
+// handleVacuumConfirm answers the vacuum prompt: y vacuums the database,
+// any other key cancels.
+func (m Model) handleVacuumConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.dbTables.confirmVacuum = false
+	if key := msg.String(); key != "y" && key != "Y" {
+		return m, nil
+	}
+	m.dbTables.vacuuming = true
+	m.statusMessage = fmt.Sprintf("Vacuuming %s...", m.dbTables.file.Name)
+	return m, m.vacuumDatabaseCmd(m.dbTables.file.Path)
+}
+
+// handleDatabaseTableContentKey handles key actions in the table content view.
+// Right scrolls the columns; left scrolls them back and only returns to
+// the table list once the first column is showing.
+func (m Model) handleDatabaseTableContentKey(action string) (tea.Model, tea.Cmd) {
+	if m.dbContent.columnPicker {
+		return m.handleColumnPickerKey(action)
+	}
+	switch action {
+	case "left":
+		if m.dbContent.hScroll > 0 {
+			m.dbContent.hScroll--
+			return m, nil
+		}
+		m.viewState = DatabaseTableListView
+		m.dbContent = dbTableContentState{}
+		m = m.updateViewport()
+	case "right":
+		if m.dbContent.hScroll < m.shownColumnCount()-1 {
+			m.dbContent.hScroll++
+		}
+	case "up":
+		if m.dbContent.viewport > 0 {
+			m.dbContent.viewport--
+		}
+	case "down":
+		// Allow scrolling through table data with lazy loading
+		itemsPerScreen := CalculateItemsPerScreen(m.height) - 8 // Account for header and table headers
+		maxViewport := len(m.dbContent.data) - itemsPerScreen
+		if maxViewport < 0 {
+			maxViewport = 0
+		}
+
+		if m.dbContent.viewport < maxViewport {
+			m.dbContent.viewport++
+		} else if m.dbContent.table != nil && m.dbContent.searchQuery == "" && m.dbContent.offset+len(m.dbContent.data) < int(m.dbContent.table.RowCount) {
+			// Need to load more data
+			newOffset := m.dbContent.offset + len(m.dbContent.data)
+			m.dbContent.offset = newOffset
+			m.dbContent.viewport = 0 // Reset viewport for new chunk
+			m.dbContent.loading = true
+			return m, m.fetchSortedTableDataCmd(m.dbTables.file.Path, m.dbContent.table.Name, m.dbContent.sortColumnName(), !m.dbContent.sortDesc, newOffset, simulator.MaxDisplayRows)
+		}
+	case "sort_next_column", "sort_prev_column":
+		if m.dbContent.table == nil || len(m.dbContent.table.Columns) == 0 {
+			return m, nil
+		}
+		// Cycle through the columns and back to the table's own order
+		step := 1
+		if action == "sort_prev_column" {
+			step = -1
+		}
+		choices := len(m.dbContent.table.Columns) + 1
+		m.dbContent.sortColumn = (m.dbContent.sortColumn + step + choices) % choices
+		return m.reloadSortedTable()
+	case "toggle_sort_direction":
+		if m.dbContent.table == nil || len(m.dbContent.table.Columns) == 0 {
+			return m, nil
+		}
+		if m.dbContent.sortColumn == 0 {
+			m.dbContent.sortColumn = 1
+		} else {
+			m.dbContent.sortDesc = !m.dbContent.sortDesc
+		}
+		return m.reloadSortedTable()
+	case "search":
+		if m.dbContent.table != nil {
+			m.dbContent.searchMode = true
+		}
+	case "toggle_columns":
+		if m.dbContent.table != nil && len(m.dbContent.table.Columns) > 0 {
+			m.dbContent.columnPicker = true
+			m.dbContent.pickerCursor = 0
+		}
+	case "export_table":
+		if m.dbContent.table == nil || m.dbTables.file == nil || m.dbContent.exporting {
+			return m, nil
+		}
+		m.dbContent.exporting = true
+		m.dbContent.exportRows = new(atomic.Int64)
+		m.statusMessage = fmt.Sprintf("Exporting %s...", m.dbContent.table.Name)
+		return m, tea.Batch(
+			exportTableCmd(m.dbTables.file.Path, m.dbContent.table.Name, m.dbContent.exportRows),
+			exportTableProgressCmd())
+	case "escape":
+		if m.dbContent.searchQuery != "" {
+			m.dbContent.searchQuery = ""
+			return m.reloadSortedTable()
+		}
+	}
+	return m, nil
+}
+
+// handleColumnPickerKey handles key actions while the column picker is
+// open. Open or enter shows or hides the column under the cursor; at
+// least one column always stays shown.
+func (m Model) handleColumnPickerKey(action string) (tea.Model, tea.Cmd) {
+	columns := m.dbContent.table.Columns
+	switch action {
+	case "up":
+		if m.dbContent.pickerCursor > 0 {
+			m.dbContent.pickerCursor--
+		}
+	case "down":
+		if m.dbContent.pickerCursor < len(columns)-1 {
+			m.dbContent.pickerCursor++
+		}
+	case "open", "enter":
+		name := columns[m.dbContent.pickerCursor].Name
+		key := m.tableColumnsKey()
+		hide := !m.hiddenColumns[key][name]
+		if hide && m.shownColumnCount() == 1 {
+			return m.flashStatus("At least one column must be shown", 2*time.Second)
+		}
+
+		// Copy the maps so earlier Model values don't see the change
+		updated := make(map[string]map[string]bool, len(m.hiddenColumns)+1)
+		for k, v := range m.hiddenColumns {
+			updated[k] = v
+		}
+		hidden := make(map[string]bool, len(updated[key])+1)
+		for k := range updated[key] {
+			hidden[k] = true
+		}
+		if hide {
+			hidden[name] = true
+		} else {
+			delete(hidden, name)
+		}
+		updated[key] = hidden
+		m.hiddenColumns = updated
+		m.dbContent.hScroll = min(m.dbContent.hScroll, m.shownColumnCount()-1)
+	case "toggle_columns", "escape", "left":
+		m.dbContent.columnPicker = false
+	}
+	return m, nil
+}
+
+// handleTableSearchInput edits the table search query. Every edit
+// re-runs the search; enter closes the query bar keeping the matches and
+// escape clears the search.
+func (m Model) handleTableSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keyMap.GetAction(msg.String()) {
+	case "escape":
+		m.dbContent.searchMode = false
+		m.dbContent.searchQuery = ""
+		return m.reloadSortedTable()
+	case "enter":
+		m.dbContent.searchMode = false
+		return m, nil
+	case "backspace":
+		if m.dbContent.searchQuery == "" {
+			return m, nil
+		}
+		query := []rune(m.dbContent.searchQuery)
+		m.dbContent.searchQuery = string(query[:len(query)-1])
+		return m.reloadSortedTable()
+	}
+	if len([]rune(msg.String())) == 1 {
+		m.dbContent.searchQuery += msg.String()
+		return m.reloadSortedTable()
+	}
+	return m, nil
+}
+
+// reloadSortedTable reloads the table content from its first row in the
+// current sort order, limited to the search matches if there is a query
+func (m Model) reloadSortedTable() (tea.Model, tea.Cmd) {
+	if m.dbContent.searchQuery != "" {
+		m.dbContent.viewport = 0
+		return m, m.tableSearchCmd(m.dbTables.file.Path, m.dbContent.table, m.dbContent.searchQuery, m.dbContent.sortColumnName(), !m.dbContent.sortDesc)
+	}
+	m.dbContent.offset = 0
+	m.dbContent.viewport = 0
+	m.dbContent.loading = true
+	return m, m.fetchSortedTableDataCmd(m.dbTables.file.Path, m.dbContent.table.Name, m.dbContent.sortColumnName(), !m.dbContent.sortDesc, 0, simulator.MaxDisplayRows)
+}
+
+// handleLogStreamKey handles key actions in the log stream view.
+// Scrolling up pauses following; scrolling back to the bottom or
+// pressing end resumes it.
+func (m Model) handleLogStreamKey(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case "left", "escape":
+		return m.stopLogStream(), nil
+	case "up":
+		if m.logStream.viewport > 0 {
+			m.logStream.viewport--
+			m.logStream.following = false
+		}
+	case "down":
+		maxViewport := m.maxLogStreamViewport()
+		if m.logStream.viewport < maxViewport {
+			m.logStream.viewport++
+		}
+		m.logStream.following = m.logStream.viewport >= maxViewport
+	case "home":
+		m.logStream.viewport = 0
+		m.logStream.following = false
+	case "end":
+		m.logStream.viewport = m.maxLogStreamViewport()
+		m.logStream.following = true
+	}
+	return m, nil
+}
+
+// handleDiffKey handles key actions in the diff view.
+func (m Model) handleDiffKey(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case "left", "escape":
+		m.viewState = FileListView
+		m.diff = diffState{}
+	case "up":
+		if m.diff.viewport > 0 {
+			m.diff.viewport--
+		}
+	case "down":
+		if m.diff.viewport < m.maxDiffViewport() {
+			m.diff.viewport++
+		}
+	case "home":
+		m.diff.viewport = 0
+	case "end":
+		m.diff.viewport = m.maxDiffViewport()
+	}
+	return m, nil
+}
+
+// handleSpawnResultKey handles key actions in the spawn result view.
+func (m Model) handleSpawnResultKey(action string) (tea.Model, tea.Cmd) {
 	switch action {
-	case "left":
-		if len(m.fileList.breadcrumbs) > 0 {
-			// Go up one directory level
-			m.fileList.breadcrumbs = m.fileList.breadcrumbs[:len(m.fileList.breadcrumbs)-1]
-			newPath := m.fileList.basePath
-			if len(m.fileList.breadcrumbs) > 0 {
-				newPath = filepath.Join(append([]string{m.fileList.basePath}, m.fileList.breadcrumbs...)...)
-			}
-			m.fileList.currentPath = newPath
-			m.fileList.loading = true
-			return m, m.fetchFilesCmd(newPath)
+	case "left", "escape":
+		m.viewState = SimulatorListView
+		m.spawn = spawnState{}
+		m.statusMessage = ""
+	case "up":
+		if m.spawn.viewport > 0 {
+			m.spawn.viewport--
 		}
-		// At root level, go back to app list or all apps view.
-		// Reading selectedApp before the fileList clear is deliberate:
-		// it decides which view we return to.
-		nextView := AppListView
-		if m.fileList.selectedApp != nil && m.fileList.selectedApp.SimulatorUDID != "" {
-			nextView = AllAppsView
+	case "down":
+		if m.spawn.viewport < m.maxSpawnViewport() {
+			m.spawn.viewport++
 		}
-		m.viewState = nextView
-		m.fileList = fileListState{}
+	case "home":
+		m.spawn.viewport = 0
+	case "end":
+		m.spawn.viewport = m.maxSpawnViewport()
+	case "copy_file_content":
+		if len(m.spawn.lines) == 0 {
+			return m.flashStatus("Nothing to copy", 2*time.Second)
+		}
+		return m, copyToClipboardCmd(strings.Join(m.spawn.lines, "\n"))
+	}
+	return m, nil
+}
+
+// handleCrashLogsKey handles key actions in the crash log list view.
+func (m Model) handleCrashLogsKey(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case "left":
+		m.viewState = AppListView
+		m.crashLogs = crashLogsState{}
 		m = m.updateViewport()
 	case "right":
-		if len(m.fileList.files) > 0 {
-			file := m.fileList.files[m.fileList.cursor]
-			if file.IsDirectory {
-				// Save current cursor position before drilling in
-				if m.fileList.cursorMemory == nil {
-					m.fileList.cursorMemory = make(map[string]int)
-					m.fileList.viewportMemory = make(map[string]int)
-				}
-				m.fileList.cursorMemory[m.fileList.currentPath] = m.fileList.cursor
-				m.fileList.viewportMemory[m.fileList.currentPath] = m.fileList.viewport
-
-				// Drill into the directory
-				m.fileList.breadcrumbs = append(m.fileList.breadcrumbs, file.Name)
-				m.fileList.currentPath = file.Path
-				m.fileList.loading = true
-				return m, m.fetchFilesCmd(file.Path)
+		if m.crashLogs.cursor < len(m.crashLogs.logs) {
+			file := m.crashLogs.logs[m.crashLogs.cursor].FileInfo()
+			m.fileViewer = fileViewerState{
+				file:          &file,
+				loading:       true,
+				fromCrashLogs: true,
 			}
-			// Check if it's a database file
-			fileType := simulator.DetectFileType(file.Path)
-			if fileType == simulator.FileTypeDatabase {
-				// View database tables
-				m.dbTables.file = &file
-				m.viewState = DatabaseTableListView
-				m.dbTables.loading = true
-				m.dbTables.cursor = 0
-				m.dbTables.viewport = 0
-				return m, m.fetchDatabaseInfoCmd(file.Path)
-			}
-			// View the file
-			m.fileViewer.file = &file
 			m.viewState = FileViewerView
-			m.fileViewer.loading = true
-			m.fileViewer.contentOffset = 0
-			m.fileViewer.contentViewport = 0
-			return m, m.fetchFileContentCmd(file.Path, 0)
+			return m, m.fetchFileContentCmd(file.Path, 0, m.width, m.height)
 		}
 	case "up":
-		if m.fileList.cursor > 0 {
-			m.fileList.cursor--
+		if m.crashLogs.cursor > 0 {
+			m.crashLogs.cursor--
 			m = m.updateViewport()
 		}
 	case "down":
-		if m.fileList.cursor < len(m.fileList.files)-1 {
-			m.fileList.cursor++
+		if m.crashLogs.cursor < len(m.crashLogs.logs)-1 {
+			m.crashLogs.cursor++
 			m = m.updateViewport()
 		}
 	case "home":
-		m.fileList.cursor = 0
-		m.fileList.viewport = 0
+		m.crashLogs.cursor = 0
+		m.crashLogs.viewport = 0
 	case "end":
-		m.fileList.cursor = len(m.fileList.files) - 1
+		m.crashLogs.cursor = max(len(m.crashLogs.logs)-1, 0)
 		m = m.updateViewport()
 	case "boot", "open":
-		if len(m.fileList.files) > 0 {
-			file := m.fileList.files[m.fileList.cursor]
-			// Open in Finder - for files, this will reveal them in their containing folder
-			return m, m.openInFinderCmd(file.Path)
+		if m.crashLogs.cursor < len(m.crashLogs.logs) {
+			return m, m.openInFinderCmd(m.crashLogs.logs[m.crashLogs.cursor].Path)
 		}
 	}
 	return m, nil
 }
 
-// handleFileViewerKey handles key actions in the file viewer view.
-func (m Model) handleFileViewerKey(action string) (tea.Model, tea.Cmd) {
+// handleAppGroupsKey handles key actions in the app group list view.
+// Right browses the selected group's container in the file list.
+func (m Model) handleAppGroupsKey(action string) (tea.Model, tea.Cmd) {
 	switch action {
 	case "left":
-		m.viewState = FileListView
-		m.fileViewer = fileViewerState{}
+		m.viewState = AppListView
+		m.appGroups = appGroupsState{}
 		m = m.updateViewport()
-	case "up":
-		if m.fileViewer.content == nil {
+	case "right":
+		if m.appGroups.cursor >= len(m.appGroups.groups) {
 			return m, nil
 		}
-		switch m.fileViewer.content.Type {
-		case simulator.FileTypeText:
-			if m.fileViewer.contentViewport > 0 {
-				m.fileViewer.contentViewport--
-			} else if m.fileViewer.contentOffset > 0 {
-				// Need to load previous chunk
-				newOffset := m.fileViewer.contentOffset - textChunkBackStep
-				if newOffset < 0 {
-					newOffset = 0
-				}
-				m.fileViewer.contentOffset = newOffset
-				m.fileViewer.loading = true
-				return m, m.fetchFileContentCmd(m.fileViewer.file.Path, newOffset)
-			}
-		case simulator.FileTypeImage:
-			if m.fileViewer.contentViewport > 0 {
-				m.fileViewer.contentViewport--
-			}
-		case simulator.FileTypeBinary:
-			if m.fileViewer.contentViewport > 0 {
-				m.fileViewer.contentViewport--
-			} else if m.fileViewer.contentOffset > 0 {
-				// Need to load previous chunk
-				newOffset := m.fileViewer.contentOffset - binaryChunkBackStep
-				if newOffset < 0 {
-					newOffset = 0
-				}
-				m.fileViewer.contentOffset = newOffset
-				m.fileViewer.loading = true
-				// Convert line offset to hex-dump row offset
-				return m, m.fetchFileContentCmd(m.fileViewer.file.Path, newOffset/simulator.HexBytesPerLine)
-			}
-		case simulator.FileTypeArchive:
-			// Allow scrolling through archive entries
-			if m.fileViewer.content.ArchiveInfo != nil && m.fileViewer.contentViewport > 0 {
-				m.fileViewer.contentViewport--
-			}
+		group := m.appGroups.groups[m.appGroups.cursor]
+		if group.Path == "" {
+			return m.flashStatus(fmt.Sprintf("%s has no container yet", group.Identifier), 2*time.Second)
+		}
+		m.fileList = fileListState{
+			selectedApp:    m.appGroups.app,
+			loading:        true,
+			currentPath:    group.Path,
+			basePath:       group.Path,
+			breadcrumbs:    []string{},
+			cursorMemory:   make(map[string]int),
+			viewportMemory: make(map[string]int),
+			fromAppGroups:  true,
+		}
+		m.viewState = FileListView
+		return m, m.fetchFilesCmd(group.Path)
+	case "up":
+		if m.appGroups.cursor > 0 {
+			m.appGroups.cursor--
+			m = m.updateViewport()
 		}
 	case "down":
-		if m.fileViewer.content == nil {
-			return m, nil
+		if m.appGroups.cursor < len(m.appGroups.groups)-1 {
+			m.appGroups.cursor++
+			m = m.updateViewport()
 		}
-		switch m.fileViewer.content.Type {
-		case simulator.FileTypeText:
-			itemsPerScreen := CalculateItemsPerScreen(m.height) - 5 // Account for header
-			maxViewport := len(m.fileViewer.content.Lines) - itemsPerScreen
-			if maxViewport < 0 {
-				maxViewport = 0
-			}
-
-			if m.fileViewer.contentViewport < maxViewport {
-				m.fileViewer.contentViewport++
-			} else if m.fileViewer.contentOffset+len(m.fileViewer.content.Lines) < m.fileViewer.content.TotalLines {
-				// Need to load more content
-				newOffset := m.fileViewer.contentOffset + len(m.fileViewer.content.Lines)
-				m.fileViewer.contentOffset = newOffset
-				m.fileViewer.contentViewport = 0 // Reset viewport for new chunk
-				m.fileViewer.loading = true
-				return m, m.fetchFileContentCmd(m.fileViewer.file.Path, newOffset)
-			}
-		case simulator.FileTypeImage:
-			// For images, calculate based on total content lines
-			if m.fileViewer.content.ImageInfo != nil && m.fileViewer.content.ImageInfo.Preview != nil {
-				// Calculate total lines (metadata + preview)
-				totalLines := 8 + len(m.fileViewer.content.ImageInfo.Preview.Rows) // ~8 lines for metadata
-				itemsPerScreen := CalculateItemsPerScreen(m.height) - 5
-				maxViewport := totalLines - itemsPerScreen
-				if maxViewport < 0 {
-					maxViewport = 0
-				}
-				if m.fileViewer.contentViewport < maxViewport {
-					m.fileViewer.contentViewport++
-				}
-			}
-		case simulator.FileTypeBinary:
-			// Allow scrolling through binary files with lazy loading
-			hexLines := simulator.FormatHexDump(m.fileViewer.content.BinaryData, m.fileViewer.content.BinaryOffset)
-			itemsPerScreen := CalculateItemsPerScreen(m.height) - 5 // Account for header
-			maxViewport := len(hexLines) - itemsPerScreen
-			if maxViewport < 0 {
-				maxViewport = 0
-			}
-
-			if m.fileViewer.contentViewport < maxViewport {
-				m.fileViewer.contentViewport++
-			} else {
-				// Check if we need to load more data
-				currentEndByte := m.fileViewer.content.BinaryOffset + int64(len(m.fileViewer.content.BinaryData))
-				if currentEndByte < m.fileViewer.content.TotalSize {
-					// Load next chunk
-					newOffset := m.fileViewer.contentOffset + len(hexLines)
-					m.fileViewer.contentOffset = newOffset
-					m.fileViewer.contentViewport = 0 // Reset viewport for new chunk
-					m.fileViewer.loading = true
-					// Load with line offset (total lines from start)
-					return m, m.fetchFileContentCmd(m.fileViewer.file.Path, newOffset)
-				}
-			}
-		case simulator.FileTypeArchive:
-			// Allow scrolling through archive entries (now 1 line per entry)
-			if m.fileViewer.content.ArchiveInfo != nil {
-				itemsPerScreen := CalculateItemsPerScreen(m.height) - 3 // Header takes 3 lines
-				maxViewport := len(m.fileViewer.content.ArchiveInfo.Entries) - itemsPerScreen
-				if maxViewport < 0 {
-					maxViewport = 0
-				}
-				if m.fileViewer.contentViewport < maxViewport {
-					m.fileViewer.contentViewport++
-				}
-			}
+	case "home":
+		m.appGroups.cursor = 0
+		m.appGroups.viewport = 0
+	case "end":
+		m.appGroups.cursor = max(len(m.appGroups.groups)-1, 0)
+		m = m.updateViewport()
+	case "boot", "open":
+		if m.appGroups.cursor < len(m.appGroups.groups) && m.appGroups.groups[m.appGroups.cursor].Path != "" {
+			return m, m.openInFinderCmd(m.appGroups.groups[m.appGroups.cursor].Path)
 		}
 	}
 	return m, nil
 }
 
-// handleDatabaseTableListKey handles key actions in the database table list view.
-func (m Model) handleDatabaseTableListKey(action string) (tea.Model, tea.Cmd) {
+// handleURLCacheKey handles key actions in the URL cache list view.
+func (m Model) handleURLCacheKey(action string) (tea.Model, tea.Cmd) {
 	switch action {
 	case "left":
-		m.viewState = FileListView
-		m.dbTables = dbTableListState{}
+		m.viewState = AppListView
+		m.urlCache = urlCacheState{}
 		m = m.updateViewport()
 	case "right":
-		if m.dbTables.info != nil && len(m.dbTables.info.Tables) > 0 && m.dbTables.cursor < len(m.dbTables.info.Tables) {
-			table := m.dbTables.info.Tables[m.dbTables.cursor]
-			m.dbContent.table = &table
-			m.viewState = DatabaseTableContentView
-			m.dbContent.loading = true
-			m.dbContent.offset = 0
-			m.dbContent.viewport = 0
-			// Load first page of table data (50 rows)
-			return m, m.fetchTableDataCmd(m.dbTables.file.Path, table.Name, 0, 50)
+		if m.urlCache.cursor < len(m.urlCache.entries) {
+			return m, m.urlCacheBodyCmd(m.urlCache.entries[m.urlCache.cursor])
 		}
 	case "up":
-		if m.dbTables.cursor > 0 {
-			m.dbTables.cursor--
+		if m.urlCache.cursor > 0 {
+			m.urlCache.cursor--
 			m = m.updateViewport()
 		}
 	case "down":
-		if m.dbTables.info != nil && m.dbTables.cursor < len(m.dbTables.info.Tables)-1 {
-			m.dbTables.cursor++
+		if m.urlCache.cursor < len(m.urlCache.entries)-1 {
+			m.urlCache.cursor++
 			m = m.updateViewport()
 		}
+	case "home":
+		m.urlCache.cursor = 0
+		m.urlCache.viewport = 0
+	case "end":
+		m.urlCache.cursor = max(len(m.urlCache.entries)-1, 0)
+		m = m.updateViewport()
 	}
 	return m, nil
 }
 
-// handleDatabaseTableContentKey handles key actions in the table content view.
-func (m Model) handleDatabaseTableContentKey(action string) (tea.Model, tea.Cmd) {
-	switch action {
-	case "left":
-		m.viewState = DatabaseTableListView
-		m.dbContent = dbTableContentState{}
-		m = m.updateViewport()
-	case "up":
-		if m.dbContent.viewport > 0 {
-			m.dbContent.viewport--
-		}
-	case "down":
-		// Allow scrolling through table data with lazy loading
-		itemsPerScreen := CalculateItemsPerScreen(m.height) - 8 // Account for header and table headers
-		maxViewport := len(m.dbContent.data) - itemsPerScreen
-		if maxViewport < 0 {
-			maxViewport = 0
+// fileViewerParent returns the view the file viewer goes back to.
+func (m Model) fileViewerParent() ViewState {
+	if m.fileViewer.fromCrashLogs {
+		return CrashLogsView
+	}
+	if m.fileViewer.fromURLCache {
+		return URLCacheView
+	}
+	return FileListView
+}
+
+// stopLogStream kills the log process and returns to the list it was
+// started from.
+func (m Model) stopLogStream() Model {
+	m.logStream.stream.Stop()
+	m.viewState = m.logStreamParent()
+	m.logStream = logStreamState{}
+	return m.updateViewport()
+}
+
+// logStreamParent returns the view the log stream goes back to. Apps
+// opened from the all-apps view carry their simulator's UDID.
+func (m Model) logStreamParent() ViewState {
+	if m.logStream.sim != nil {
+		return SimulatorListView
+	}
+	if m.logStream.app != nil && m.logStream.app.SimulatorUDID != "" {
+		return AllAppsView
+	}
+	return AppListView
+}
+
+// findSimulator returns the simulator with the given UDID, or nil if it
+// isn't in the simulator list.
+func (m Model) findSimulator(udid string) *simulator.Item {
+	for i := range m.simList.simulators {
+		if m.simList.simulators[i].UDID == udid {
+			return &m.simList.simulators[i]
 		}
+	}
+	return nil
+}
 
-		if m.dbContent.viewport < maxViewport {
-			m.dbContent.viewport++
-		} else if m.dbContent.table != nil && m.dbContent.offset+len(m.dbContent.data) < int(m.dbContent.table.RowCount) {
-			// Need to load more data
-			newOffset := m.dbContent.offset + len(m.dbContent.data)
-			m.dbContent.offset = newOffset
-			m.dbContent.viewport = 0 // Reset viewport for new chunk
-			m.dbContent.loading = true
-			return m, m.fetchTableDataCmd(m.dbTables.file.Path, m.dbContent.table.Name, newOffset, 50)
+// maxLogStreamViewport is the viewport that shows the newest log line
+// at the bottom of the screen.
+func (m Model) maxLogStreamViewport() int {
+	visible := max(m.height-8-components.LogStreamHeaderLines, 1)
+	return max(len(m.logStream.lines)-visible, 0)
+}
+
+// maxSpawnViewport returns the last viewport position of the spawn
+// result view, whose text viewer header takes 4 lines
+func (m Model) maxSpawnViewport() int {
+	visible := max(m.height-8-4, 1)
+	return max(len(m.spawn.lines)-visible, 0)
+}
+
+// diffFile returns file, in the file list, as a file picked for a diff
+func (m Model) diffFile(file simulator.FileInfo) diffFile {
+	picked := diffFile{file: file, relPath: file.Name}
+	if rel, err := filepath.Rel(m.fileList.basePath, file.Path); err == nil && m.fileList.basePath != "" {
+		picked.relPath = rel
+	}
+	if m.fileList.selectedApp != nil {
+		picked.app = m.fileList.selectedApp.Name
+	}
+	if m.appList.selectedSim != nil {
+		picked.sim = m.appList.selectedSim.Name
+	}
+	return picked
+}
+
+// diffNames returns the names the diff view's title gives the files of
+// a diff: their file names, or their paths in their containers when the
+// names match. Files in different containers are followed by their app,
+// and their simulator when that differs too.
+func diffNames(oldFile, newFile diffFile) (string, string) {
+	oldName, newName := oldFile.file.Name, newFile.file.Name
+	if oldName == newName {
+		oldName, newName = oldFile.relPath, newFile.relPath
+	}
+	where := func(f diffFile) string {
+		if oldFile.sim != newFile.sim {
+			return f.app + " on " + f.sim
 		}
+		return f.app
 	}
-	return m, nil
+	if where(oldFile) != where(newFile) {
+		oldName += " (" + where(oldFile) + ")"
+		newName += " (" + where(newFile) + ")"
+	}
+	return oldName, newName
+}
+
+// maxDiffViewport returns the last viewport position of the diff view
+func (m Model) maxDiffViewport() int {
+	visible := max(m.height-8-components.DiffViewHeaderLines, 1)
+	return max(len(m.diff.lines)-visible, 0)
 }
 
 // getFilteredSimulators returns simulators based on the current filter state
 func (m Model) getFilteredSimulators() []simulator.Item {
-	if !m.simList.filterActive {
-		return m.simList.simulators
+	if !m.simList.filterActive && m.simList.platform == 0 {
+		return simulator.SortSimulators(m.simList.simulators, m.simList.sortOrder)
 	}
 
-	// Filter to show only simulators with apps
+	// Filter to show only simulators with apps, or of one platform
 	var filtered []simulator.Item
 	for _, sim := range m.simList.simulators {
-		if sim.AppCount > 0 {
+		if m.simList.filterActive && sim.AppCount > 0 ||
+			m.simList.platform != 0 && sim.Platform == m.simList.platform {
 			filtered = append(filtered, sim)
 		}
 	}
-	return filtered
+	return simulator.SortSimulators(filtered, m.simList.sortOrder)
+}
+
+// nextSimFilter returns the simulator list filter after the current
+// one. The filter key steps from every simulator to those with apps,
+// then through the platforms in the list, then back to every simulator.
+func nextSimFilter(withApps bool, platform simulator.RuntimeFilter, sims []simulator.Item) (bool, simulator.RuntimeFilter) {
+	if !withApps && platform == 0 {
+		return true, 0
+	}
+	next := simulator.Platforms
+	if i := slices.Index(next, platform); i >= 0 {
+		next = next[i+1:]
+	}
+	for _, p := range next {
+		if slices.ContainsFunc(sims, func(sim simulator.Item) bool { return sim.Platform == p }) {
+			return false, p
+		}
+	}
+	return false, 0
 }
 
 // handleSimulatorSearchInput handles keyboard input when in simulator search mode
@@ -809,6 +3706,7 @@ func (m Model) handleSimulatorSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Exit search mode
 		m.simList.searchMode = false
 		m.simList.searchQuery = ""
+		m = m.refilterSimulators()
 		m.simList.cursor = 0
 		m.simList.viewport = 0
 		m.statusMessage = ""
@@ -818,10 +3716,7 @@ func (m Model) handleSimulatorSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "backspace":
 		// Remove last character from search query
 		if len(m.simList.searchQuery) > 0 {
-			m.simList.searchQuery = m.simList.searchQuery[:len(m.simList.searchQuery)-1]
-			m.simList.cursor = 0
-			m.simList.viewport = 0
-			m = m.updateViewport()
+			m = m.setSimulatorSearchQuery(m.simList.searchQuery[:len(m.simList.searchQuery)-1])
 		}
 		return m, nil
 
@@ -853,6 +3748,7 @@ func (m Model) handleSimulatorSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			// Exit search mode
 			m.simList.searchMode = false
 			m.simList.searchQuery = ""
+			m = m.refilterSimulators()
 			m.statusMessage = ""
 			return m, m.fetchAppsCmd(sim)
 		}
@@ -860,19 +3756,13 @@ func (m Model) handleSimulatorSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "boot", "open":
 		// Space is allowed in search
-		m.simList.searchQuery += " "
-		m.simList.cursor = 0
-		m.simList.viewport = 0
-		m = m.updateViewport()
+		m = m.setSimulatorSearchQuery(m.simList.searchQuery + " ")
 		return m, nil
 
 	default:
 		// Add any single character to search query (including h, j, k, l, q, etc.)
 		if len(msg.String()) == 1 {
-			m.simList.searchQuery += msg.String()
-			m.simList.cursor = 0
-			m.simList.viewport = 0
-			m = m.updateViewport()
+			m = m.setSimulatorSearchQuery(m.simList.searchQuery + msg.String())
 		}
 		return m, nil
 	}
@@ -897,10 +3787,7 @@ func (m Model) handleAppSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "backspace":
 		// Remove last character from search query
 		if len(m.appList.searchQuery) > 0 {
-			m.appList.searchQuery = m.appList.searchQuery[:len(m.appList.searchQuery)-1]
-			m.appList.cursor = 0
-			m.appList.viewport = 0
-			m = m.updateViewport()
+			m = m.setAppSearchQuery(m.appList.searchQuery[:len(m.appList.searchQuery)-1])
 		}
 		return m, nil
 
@@ -944,26 +3831,78 @@ func (m Model) handleAppSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "boot", "open":
 		// Space is allowed in search
-		m.appList.searchQuery += " "
-		m.appList.cursor = 0
-		m.appList.viewport = 0
-		m = m.updateViewport()
+		m = m.setAppSearchQuery(m.appList.searchQuery + " ")
 		return m, nil
 
 	default:
 		// Add any single character to search query (including h, j, k, l, q, etc.)
 		if len(msg.String()) == 1 {
-			m.appList.searchQuery += msg.String()
-			m.appList.cursor = 0
-			m.appList.viewport = 0
-			m = m.updateViewport()
+			m = m.setAppSearchQuery(m.appList.searchQuery + msg.String())
 		}
 		return m, nil
 	}
 }
 
+// setSimulatorSearchQuery changes the simulator search query, keeping
+// the cursor on the selected simulator while it still matches
+func (m Model) setSimulatorSearchQuery(query string) Model {
+	before := m.getFilteredAndSearchedSimulators()
+	m.simList.searchQuery = query
+	m = m.refilterSimulators()
+	after := m.getFilteredAndSearchedSimulators()
+	m.simList.cursor = findNearestCursor(m.simList.cursor, after, before, func(sim simulator.Item) string { return sim.UDID })
+	m.simList.viewport = min(m.simList.viewport, m.simList.cursor)
+	return m.updateViewport()
+}
+
+// setAppSearchQuery changes the app search query, keeping the cursor on
+// the selected app while it still matches
+func (m Model) setAppSearchQuery(query string) Model {
+	before := m.getFilteredAndSearchedApps()
+	m.appList.searchQuery = query
+	after := m.getFilteredAndSearchedApps()
+	m.appList.cursor = findNearestCursor(m.appList.cursor, after, before, func(app simulator.App) string { return app.BundleID })
+	m.appList.viewport = min(m.appList.viewport, m.appList.cursor)
+	return m.updateViewport()
+}
+
+// findNearestCursor returns the cursor position in filtered for the item
+// at oldCursor in full, the list the cursor pointed into before the query
+// changed. If that item no longer matches, the cursor goes to the first
+// item above it that does, or to the top when none does. key identifies
+// an item in both lists.
+func findNearestCursor[T any](oldCursor int, filtered, full []T, key func(T) string) int {
+	if len(filtered) == 0 {
+		return 0
+	}
+	index := make(map[string]int, len(filtered))
+	for i, item := range filtered {
+		index[key(item)] = i
+	}
+	for i := min(oldCursor, len(full)-1); i >= 0; i-- {
+		if pos, ok := index[key(full[i])]; ok {
+			return pos
+		}
+	}
+	return 0
+}
+
+// refilterSimulators rebuilds the cache of filtered and searched
+// simulators. Call it after changing the simulator list, the filter or
+// the search query.
+func (m Model) refilterSimulators() Model {
+	m.simList.filteredSimsCached = false
+	m.simList.filteredSimsCache = m.getFilteredAndSearchedSimulators()
+	m.simList.filteredSimsCached = true
+	return m
+}
+
 // getFilteredAndSearchedSimulators returns simulators based on both filter and search
 func (m Model) getFilteredAndSearchedSimulators() []simulator.Item {
+	if m.simList.filteredSimsCached {
+		return m.simList.filteredSimsCache
+	}
+
 	// First apply the app filter
 	filtered := m.getFilteredSimulators()
 
@@ -988,11 +3927,12 @@ func (m Model) getFilteredAndSearchedSimulators() []simulator.Item {
 	return searched
 }
 
-// getFilteredAndSearchedApps returns apps based on search query
+// getFilteredAndSearchedApps returns apps based on search query, in
+// the current sort order
 func (m Model) getFilteredAndSearchedApps() []simulator.App {
 	// If no search query, return all apps
 	if m.appList.searchQuery == "" {
-		return m.appList.apps
+		return simulator.SortApps(m.appList.apps, m.appList.sortOrder)
 	}
 
 	// Apply search filter
@@ -1008,7 +3948,7 @@ func (m Model) getFilteredAndSearchedApps() []simulator.App {
 		}
 	}
 
-	return searched
+	return simulator.SortApps(searched, m.appList.sortOrder)
 }
 
 // handleAllAppsSearchInput handles keyboard input when in all apps search mode
@@ -1087,11 +4027,12 @@ func (m Model) handleAllAppsSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 }
 
-// getFilteredAndSearchedAllApps returns all apps based on search query
+// getFilteredAndSearchedAllApps returns all apps based on search query,
+// in the current sort order
 func (m Model) getFilteredAndSearchedAllApps() []simulator.App {
 	// If no search query, return all apps
 	if m.allApps.searchQuery == "" {
-		return m.allApps.apps
+		return simulator.SortApps(m.allApps.apps, m.allApps.sortOrder)
 	}
 
 	// Apply search filter
@@ -1108,5 +4049,36 @@ func (m Model) getFilteredAndSearchedAllApps() []simulator.App {
 		}
 	}
 
-	return searched
+	return simulator.SortApps(searched, m.allApps.sortOrder)
+}
+
+// handleKeychainKey handles key actions in the keychain table view.
+// Like the table content view, left scrolls the columns back before
+// returning to the app list.
+func (m Model) handleKeychainKey(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case "left":
+		if m.keychain.hScroll > 0 {
+			m.keychain.hScroll--
+			return m, nil
+		}
+		m.viewState = AppListView
+		m.keychain = keychainState{}
+		m = m.updateViewport()
+	case "right":
+		if m.keychain.hScroll < len(keychainColumns)-1 {
+			m.keychain.hScroll++
+		}
+	case "up":
+		if m.keychain.viewport > 0 {
+			m.keychain.viewport--
+		}
+	case "down":
+		itemsPerScreen := CalculateItemsPerScreen(m.height) - 8 // Account for header and table headers
+		maxViewport := max(len(m.keychain.items)-itemsPerScreen, 0)
+		if m.keychain.viewport < maxViewport {
+			m.keychain.viewport++
+		}
+	}
+	return m, nil
 }
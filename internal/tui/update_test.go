@@ -1,12 +1,16 @@
 package tui
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/azizuysal/simtool/internal/config"
 	"github.com/azizuysal/simtool/internal/simulator"
 )
 
@@ -101,3 +105,383 @@ func TestUpdateWindowSizeMsg(t *testing.T) {
 		t.Error("Expected no command for window resize")
 	}
 }
+
+func TestUpdateReloadConfigMsg(t *testing.T) {
+	t.Run("success swaps config and key map", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		cfg := config.Default()
+		cfg.Keys.Quit = []string{"Q"}
+
+		model := Model{config: config.Default(), keyMap: config.NewKeyMap(config.DefaultKeys())}
+		updated, cmd := model.Update(ReloadConfigMsg(cfg, nil))
+		m := updated.(Model)
+
+		if m.config != cfg {
+			t.Error("config not replaced")
+		}
+		if got := m.keyMap.GetAction("Q"); got != "quit" {
+			t.Errorf("GetAction(Q) = %q, want quit", got)
+		}
+		if !strings.Contains(m.statusMessage, "successfully") {
+			t.Errorf("statusMessage = %q, want success message", m.statusMessage)
+		}
+		if cmd == nil {
+			t.Error("expected a status-clear command")
+		}
+	})
+
+	t.Run("platform change fetches the list again", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		cfg := config.Default()
+		cfg.Display.Platforms = []string{"iOS"}
+
+		fetcher := &mockFetcher{}
+		model := Model{
+			fetcher: fetcher,
+			config:  config.Default(),
+			keyMap:  config.NewKeyMap(config.DefaultKeys()),
+			simList: simListState{platform: simulator.RuntimeTVOS},
+		}
+		updated, cmd := model.Update(ReloadConfigMsg(cfg, nil))
+		m := updated.(Model)
+
+		if fetcher.runtimeFilter != simulator.RuntimeIOS {
+			t.Errorf("runtime filter = %v, want iOS only", fetcher.runtimeFilter)
+		}
+		if m.simList.platform != 0 {
+			t.Errorf("platform filter = %v, want it cleared", m.simList.platform)
+		}
+		if cmd == nil {
+			t.Error("expected a fetch command")
+		}
+	})
+
+	t.Run("error keeps current config", func(t *testing.T) {
+		orig := config.Default()
+		model := Model{config: orig, keyMap: config.NewKeyMap(orig.Keys)}
+		updated, _ := model.Update(ReloadConfigMsg(config.Default(), errors.New("bad toml")))
+		m := updated.(Model)
+
+		if m.config != orig {
+			t.Error("config replaced despite load error")
+		}
+		if !strings.Contains(m.statusMessage, "Error reloading config") {
+			t.Errorf("statusMessage = %q, want reload error", m.statusMessage)
+		}
+	})
+}
+
+func TestModel_Update(t *testing.T) {
+	// Keep tickMsg from probing the terminal's theme
+	t.Setenv("SIMTOOL_THEME_MODE", "dark")
+
+	sims := []simulator.Item{
+		{Simulator: simulator.Simulator{UDID: "A", Name: "iPhone 15", State: "Booted"}},
+		{Simulator: simulator.Simulator{UDID: "B", Name: "iPad Pro", State: "Shutdown"}},
+	}
+	base := func() Model {
+		return Model{
+			fetcher:          &mockFetcher{items: sims},
+			currentThemeMode: "dark",
+			height:           30,
+			width:            80,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		setup   func(m Model) Model
+		msg     tea.Msg
+		wantCmd bool
+		check   func(t *testing.T, m Model)
+	}{
+		{
+			name: "fetchSimulatorsMsg success",
+			setup: func(m Model) Model {
+				m.simList.loading = true
+				m.simList.cursor = 5
+				return m
+			},
+			msg:     fetchSimulatorsMsg{simulators: sims},
+			wantCmd: true, // Network conditions and pairs are queried after the first load
+			check: func(t *testing.T, m Model) {
+				if len(m.simList.simulators) != 2 || m.simList.loading || m.err != nil {
+					t.Errorf("simulators = %d, loading = %v, err = %v; want 2 loaded", len(m.simList.simulators), m.simList.loading, m.err)
+				}
+				if m.simList.cursor != 1 {
+					t.Errorf("cursor = %d, want it clamped to 1", m.simList.cursor)
+				}
+			},
+		},
+		{
+			name:  "fetchSimulatorsMsg error",
+			setup: func(m Model) Model { m.simList.loading = true; return m },
+			msg:   fetchSimulatorsMsg{err: errors.New("simctl failed")},
+			check: func(t *testing.T, m Model) {
+				if m.err == nil || m.simList.loading {
+					t.Errorf("err = %v, loading = %v; want the error kept and loading done", m.err, m.simList.loading)
+				}
+			},
+		},
+		{
+			name:    "bootSimulatorMsg success",
+			setup:   func(m Model) Model { m.simList.booting = true; return m },
+			msg:     bootSimulatorMsg{udid: "B"},
+			wantCmd: true, // Refresh and clear the status
+			check: func(t *testing.T, m Model) {
+				if m.simList.booting || m.statusMessage != "Simulator booted successfully!" {
+					t.Errorf("booting = %v, status = %q", m.simList.booting, m.statusMessage)
+				}
+			},
+		},
+		{
+			name:    "bootSimulatorMsg error",
+			setup:   func(m Model) Model { m.simList.booting = true; return m },
+			msg:     bootSimulatorMsg{udid: "B", err: errors.New("unable to boot")},
+			wantCmd: true,
+			check: func(t *testing.T, m Model) {
+				if m.simList.booting || !strings.Contains(m.statusMessage, "unable to boot") {
+					t.Errorf("booting = %v, status = %q; want the error shown", m.simList.booting, m.statusMessage)
+				}
+			},
+		},
+		{
+			name: "fetchAppsMsg empty",
+			setup: func(m Model) Model {
+				m.viewState = AppListView
+				m.appList.selectedSim = &sims[0]
+				m.appList.loading = true
+				return m
+			},
+			msg:     fetchAppsMsg{},
+			wantCmd: true,
+			check: func(t *testing.T, m Model) {
+				if m.viewState != SimulatorListView || m.appList.selectedSim != nil || m.appList.loading {
+					t.Errorf("viewState = %v, selectedSim = %v; want back on the simulator list", m.viewState, m.appList.selectedSim)
+				}
+				if m.statusMessage != "No apps installed on this simulator" {
+					t.Errorf("status = %q", m.statusMessage)
+				}
+			},
+		},
+		{
+			name: "fetchAppsMsg error",
+			setup: func(m Model) Model {
+				m.viewState = AppListView
+				m.appList.selectedSim = &sims[0]
+				return m
+			},
+			msg:     fetchAppsMsg{err: errors.New("listapps failed")},
+			wantCmd: true,
+			check: func(t *testing.T, m Model) {
+				if m.viewState != SimulatorListView || !strings.Contains(m.statusMessage, "Error loading apps: listapps failed") {
+					t.Errorf("viewState = %v, status = %q; want the error on the simulator list", m.viewState, m.statusMessage)
+				}
+			},
+		},
+		{
+			name:  "clearStatusMsg",
+			setup: func(m Model) Model { m.statusMessage = "Copied"; return m },
+			msg:   clearStatusMsg{},
+			check: func(t *testing.T, m Model) {
+				if m.statusMessage != "" {
+					t.Errorf("status = %q, want it cleared", m.statusMessage)
+				}
+			},
+		},
+		{
+			name:    "tickMsg",
+			msg:     tickMsg(time.Now()),
+			wantCmd: true, // Refresh and schedule the next tick
+			check: func(t *testing.T, m Model) {
+				if m.currentThemeMode != "dark" {
+					t.Errorf("currentThemeMode = %q, want dark", m.currentThemeMode)
+				}
+			},
+		},
+		{
+			name: "tea.WindowSizeMsg",
+			msg:  tea.WindowSizeMsg{Width: 120, Height: 50},
+			check: func(t *testing.T, m Model) {
+				if m.width != 120 || m.height != 50 {
+					t.Errorf("size = %dx%d, want 120x50", m.width, m.height)
+				}
+			},
+		},
+		{
+			name: "fetchFileContentMsg",
+			setup: func(m Model) Model {
+				m.viewState = FileViewerView
+				m.fileViewer = fileViewerState{file: &simulator.FileInfo{Path: "/a.bin"}, loading: true}
+				return m
+			},
+			msg: fetchFileContentMsg{content: &simulator.FileContent{Type: simulator.FileTypeBinary, BinaryOffset: 160}},
+			check: func(t *testing.T, m Model) {
+				if m.fileViewer.content == nil || m.fileViewer.loading {
+					t.Fatalf("content = %v, loading = %v; want the content shown", m.fileViewer.content, m.fileViewer.loading)
+				}
+				if m.fileViewer.contentOffset != 10 {
+					t.Errorf("contentOffset = %d, want 10 hex rows", m.fileViewer.contentOffset)
+				}
+			},
+		},
+		{
+			name: "fetchFileContentMsg error",
+			setup: func(m Model) Model {
+				m.viewState = FileViewerView
+				m.fileViewer = fileViewerState{file: &simulator.FileInfo{Path: "/a.bin"}, loading: true}
+				return m
+			},
+			msg:     fetchFileContentMsg{err: errors.New("permission denied")},
+			wantCmd: true,
+			check: func(t *testing.T, m Model) {
+				if m.viewState != FileListView || m.fileViewer.file != nil {
+					t.Errorf("viewState = %v; want back on the file list", m.viewState)
+				}
+				if !strings.Contains(m.statusMessage, "Error loading file: permission denied") {
+					t.Errorf("status = %q", m.statusMessage)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := base()
+			if tt.setup != nil {
+				m = tt.setup(m)
+			}
+			updated, cmd := m.Update(tt.msg)
+			if (cmd != nil) != tt.wantCmd {
+				t.Errorf("cmd = %v, want a command: %v", cmd != nil, tt.wantCmd)
+			}
+			tt.check(t, asModel(t, updated))
+		})
+	}
+}
+
+func TestFileListPositionRestoredGoingUp(t *testing.T) {
+	// Directory paths may end in a slash, while going up rebuilds the
+	// parent's path with filepath.Join
+	listing := func(dir string) []simulator.FileInfo {
+		var files []simulator.FileInfo
+		for i := range 20 {
+			name := fmt.Sprintf("dir%02d", i)
+			files = append(files, simulator.FileInfo{Name: name, Path: dir + name + "/", IsDirectory: true})
+		}
+		return files
+	}
+	m := testModelWithKeyMap()
+	m.viewState = FileListView
+	m.fileList = fileListState{basePath: "/data/App/", currentPath: "/data/App/"}
+	m, _ = m.handleFetchFiles(fetchFilesMsg{files: listing("/data/App/")})
+
+	// Down to dir12 in the container, then dir03 in it, then one deeper
+	var positions [][2]int
+	for _, steps := range []int{12, 3} {
+		for range steps {
+			got, _ := m.handleFileListKey("down")
+			m = asModel(t, got)
+		}
+		positions = append(positions, [2]int{m.fileList.cursor, m.fileList.viewport})
+		dir := m.fileList.files[m.fileList.cursor].Path
+		got, _ := m.handleFileListKey("right")
+		m = asModel(t, got)
+		m, _ = m.handleFetchFiles(fetchFilesMsg{files: listing(dir)})
+	}
+
+	for i := len(positions) - 1; i >= 0; i-- {
+		got, _ := m.handleFileListKey("left")
+		m = asModel(t, got)
+		m, _ = m.handleFetchFiles(fetchFilesMsg{files: listing(m.fileList.currentPath + "/")})
+		if got := [2]int{m.fileList.cursor, m.fileList.viewport}; got != positions[i] {
+			t.Errorf("level %d: cursor, viewport = %v, want %v restored", i, got, positions[i])
+		}
+	}
+	if positions[0][1] == 0 {
+		t.Error("the container listing should have scrolled for the test to cover the viewport")
+	}
+}
+
+// TestAllAppsViewKeyHandling sends key presses through Update to check
+// that the all-apps view gets them rather than only handleAllAppsKey
+func TestAllAppsViewKeyHandling(t *testing.T) {
+	m := testModelWithKeyMap()
+	m.viewState = AllAppsView
+	m.allApps.apps = fakeApps()
+	press := func(key string) tea.Cmd {
+		t.Helper()
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		switch key {
+		case "right":
+			msg = tea.KeyMsg{Type: tea.KeyRight}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEscape}
+		}
+		got, cmd := m.Update(msg)
+		m = asModel(t, got)
+		return cmd
+	}
+
+	press("j")
+	if m.allApps.cursor != 1 {
+		t.Errorf("j: cursor = %d, want 1", m.allApps.cursor)
+	}
+	press("k")
+	if m.allApps.cursor != 0 {
+		t.Errorf("k: cursor = %d, want 0", m.allApps.cursor)
+	}
+
+	press("/")
+	if !m.allApps.searchMode {
+		t.Fatal("/ should start a search")
+	}
+	if cmd := press("q"); cmd != nil || m.allApps.searchQuery != "q" {
+		t.Errorf("q in search should be typed, got query %q", m.allApps.searchQuery)
+	}
+	press("esc")
+	if m.allApps.searchMode || m.viewState != AllAppsView {
+		t.Fatalf("escape should leave search in the all-apps view, got search %v view %v", m.allApps.searchMode, m.viewState)
+	}
+
+	if cmd := press("q"); cmd == nil {
+		t.Error("q should quit")
+	} else if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Errorf("q returned %T, want tea.QuitMsg", cmd())
+	}
+
+	press("j")
+	if cmd := press("right"); cmd == nil || m.viewState != FileListView || m.fileList.selectedApp.BundleID != "com.example.b" {
+		t.Errorf("right should open AppB's files, got view %v", m.viewState)
+	}
+}
+
+func TestFileOpsRefreshContainerRoot(t *testing.T) {
+	// The container path may end in a slash, which filepath.Dir of a
+	// file in it doesn't
+	tests := []struct {
+		name string
+		msg  tea.Msg
+	}{
+		{"duplicate", duplicateFileMsg{name: "a.txt", path: "/data/App/a_copy.txt"}},
+		{"rename", renameFileMsg{oldPath: "/data/App/a.txt", newPath: "/data/App/b.txt"}},
+		{"delete", deleteFileMsg{name: "a.txt", path: "/data/App/a.txt"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testModelWithKeyMap()
+			m.viewState = FileListView
+			m.fileList = fileListState{
+				basePath:       "/data/App/",
+				currentPath:    "/data/App/",
+				files:          []simulator.FileInfo{{Name: "a.txt", Path: "/data/App/a.txt"}},
+				cursorMemory:   make(map[string]int),
+				viewportMemory: make(map[string]int),
+			}
+			got, _ := m.Update(tt.msg)
+			if gm := asModel(t, got); !gm.fileList.loading {
+				t.Error("the file list should reload after the change")
+			}
+		})
+	}
+}
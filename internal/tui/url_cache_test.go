@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/azizuysal/simtool/internal/simulator"
+)
+
+func TestHandleAppListKey_ViewURLCache(t *testing.T) {
+	sim := fakeSims()[0]
+	m := Model{
+		viewState: AppListView,
+		appList:   appListState{selectedSim: &sim, apps: fakeApps(), cursor: 1},
+		height:    30,
+	}
+	got, cmd := m.handleAppListKey("view_url_cache")
+	gm := asModel(t, got)
+
+	if gm.viewState != URLCacheView {
+		t.Errorf("viewState = %v, want URLCacheView", gm.viewState)
+	}
+	if gm.urlCache.app == nil || gm.urlCache.app.BundleID != "com.example.b" {
+		t.Errorf("urlCache.app = %+v, want com.example.b", gm.urlCache.app)
+	}
+	if !gm.urlCache.loading || cmd == nil {
+		t.Error("expected loading state and fetchURLCacheCmd")
+	}
+}
+
+func TestHandleFetchURLCache(t *testing.T) {
+	app := fakeApps()[0]
+
+	tests := []struct {
+		name       string
+		msg        fetchURLCacheMsg
+		wantStatus string
+	}{
+		{"no cache", fetchURLCacheMsg{err: simulator.ErrNoURLCache}, "No URL cache found for AppA"},
+		{"read error", fetchURLCacheMsg{err: errors.New("boom")}, "Error reading URL cache: boom"},
+		{"empty cache", fetchURLCacheMsg{}, "URL cache for AppA is empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{viewState: URLCacheView, urlCache: urlCacheState{app: &app, loading: true}}
+			got, _ := m.Update(tt.msg)
+			gm := asModel(t, got)
+			if gm.viewState != AppListView {
+				t.Errorf("viewState = %v, want AppListView", gm.viewState)
+			}
+			if !strings.Contains(gm.statusMessage, tt.wantStatus) {
+				t.Errorf("statusMessage = %q, want %q", gm.statusMessage, tt.wantStatus)
+			}
+		})
+	}
+
+	t.Run("entries are listed", func(t *testing.T) {
+		m := Model{viewState: URLCacheView, urlCache: urlCacheState{app: &app, loading: true}, height: 30}
+		entries := []simulator.URLCacheEntry{{URL: "https://example.com/"}}
+		got, _ := m.Update(fetchURLCacheMsg{entries: entries})
+		gm := asModel(t, got)
+		if gm.urlCache.loading || len(gm.urlCache.entries) != 1 {
+			t.Errorf("urlCache = %+v", gm.urlCache)
+		}
+	})
+}
+
+func TestURLCacheView_OpenAndReturn(t *testing.T) {
+	app := fakeApps()[0]
+	m := Model{
+		viewState: URLCacheView,
+		urlCache:  urlCacheState{app: &app, entries: []simulator.URLCacheEntry{{URL: "https://example.com/a.json", BodyPath: "/cache/fsCachedData/A"}}},
+		height:    30,
+		width:     100,
+	}
+	_, cmd := m.handleURLCacheKey("right")
+	if cmd == nil {
+		t.Fatal("expected urlCacheBodyCmd")
+	}
+
+	got, cmd := m.Update(urlCacheBodyMsg{file: simulator.FileInfo{Name: "https://example.com/a.json", Path: "/cache/fsCachedData/A"}})
+	gm := asModel(t, got)
+	if gm.viewState != FileViewerView || gm.fileViewer.file == nil || gm.fileViewer.file.Path != "/cache/fsCachedData/A" {
+		t.Fatalf("expected file viewer on the body, got view %v file %+v", gm.viewState, gm.fileViewer.file)
+	}
+	if cmd == nil {
+		t.Error("expected fetchFileContentCmd")
+	}
+
+	got, _ = gm.handleFileViewerKey("left")
+	gm = asModel(t, got)
+	if gm.viewState != URLCacheView {
+		t.Errorf("left from body viewer: viewState = %v, want URLCacheView", gm.viewState)
+	}
+
+	got, _ = gm.handleURLCacheKey("left")
+	if gm = asModel(t, got); gm.viewState != AppListView {
+		t.Errorf("left from URL cache: viewState = %v, want AppListView", gm.viewState)
+	}
+}
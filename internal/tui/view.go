@@ -1,8 +1,11 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/azizuysal/simtool/internal/config"
+	"github.com/azizuysal/simtool/internal/simulator"
 	"github.com/azizuysal/simtool/internal/tui/components"
 	"github.com/azizuysal/simtool/internal/tui/components/file_viewer"
 	"github.com/azizuysal/simtool/internal/ui"
@@ -15,22 +18,6 @@ func (m Model) View() string {
 		return ui.ErrorStyle().Render("Error: " + m.err.Error())
 	}
 
-	// Special handling for AllAppsView which returns complete layout
-	if m.viewState == AllAppsView {
-		return components.AllAppsListView(
-			m.allApps.apps,
-			m.allApps.cursor,
-			m.allApps.viewport,
-			m.width,
-			m.height,
-			m.allApps.searchMode,
-			m.allApps.searchQuery,
-			m.allApps.loading,
-			m.err,
-			&m.config.Keys,
-		)
-	}
-
 	// Create layout
 	layout := components.NewLayout(m.width, m.height)
 
@@ -42,6 +29,8 @@ func (m Model) View() string {
 		title, content, footer, status = m.renderSimulatorListView()
 	case AppListView:
 		title, content, footer, status = m.renderAppListView()
+	case AllAppsView:
+		title, content, footer, status = m.renderAllAppsView()
 	case FileListView:
 		title, content, footer, status = m.renderFileListView()
 	case FileViewerView:
@@ -50,6 +39,28 @@ func (m Model) View() string {
 		title, content, footer, status = m.renderDatabaseTableListView()
 	case DatabaseTableContentView:
 		title, content, footer, status = m.renderDatabaseTableContentView()
+	case DatabaseQueryView:
+		title, content, footer, status = m.renderDatabaseQueryView()
+	case LogStreamView:
+		title, content, footer, status = m.renderLogStreamView()
+	case CrashLogsView:
+		title, content, footer, status = m.renderCrashLogsView()
+	case URLCacheView:
+		title, content, footer, status = m.renderURLCacheView()
+	case KeychainView:
+		title, content, footer, status = m.renderKeychainView()
+	case AppGroupsView:
+		title, content, footer, status = m.renderAppGroupsView()
+	case DiffView:
+		title, content, footer, status = m.renderDiffView()
+	case SpawnResultView:
+		title, content, footer, status = m.renderSpawnResultView()
+	case ConfirmDeleteView:
+		title, content, footer, status = m.renderConfirmDeleteView()
+	case CreateSimulatorView:
+		title, content, footer, status = m.renderCreateSimulatorView()
+	case FileSearchView:
+		title, content, footer, status = m.renderFileSearchView()
 	default:
 		title, content, footer, status = m.renderSimulatorListView()
 	}
@@ -72,9 +83,18 @@ func (m Model) renderSimulatorListView() (title, content, footer, status string)
 	// Create simulator list component
 	simList := components.NewSimulatorList(contentWidth, contentHeight)
 	simList.Update(filteredSims, m.simList.cursor, m.simList.viewport, m.simList.filterActive, m.simList.searchMode, m.simList.searchQuery, &m.config.Keys)
+	simList.SetShowSystemAppCount(m.config.Display.ShowSystemAppCount)
+	simList.SetSortLabel(m.simList.sortOrder.String())
+	simList.SetPlatform(m.simList.platform)
 
 	// Get title
-	title = simList.GetTitle(len(m.simList.simulators))
+	running := 0
+	for _, sim := range m.simList.simulators {
+		if sim.IsRunning() {
+			running++
+		}
+	}
+	title = simList.GetTitle(len(m.simList.simulators), running)
 
 	// Get content
 	// Create content box
@@ -93,12 +113,18 @@ func (m Model) renderSimulatorListView() (title, content, footer, status string)
 	switch {
 	case m.simList.loading:
 		status = ui.LoadingStyle().Render("Loading simulators...")
+	case m.simList.cloneMode:
+		status = ui.SearchStyle().Render(fmt.Sprintf("Clone as: %s▏ (enter to clone, esc to cancel)", m.simList.cloneName))
+	case m.simList.spawnMode:
+		status = ui.SearchStyle().Render(fmt.Sprintf("Run: %s▏ (enter to run, esc to cancel)", m.simList.spawnCommand))
 	case m.statusMessage != "":
 		switch {
 		case strings.Contains(m.statusMessage, "Error") || strings.Contains(m.statusMessage, "No apps installed"):
 			status = ui.ErrorStyle().Render(m.statusMessage)
 		case strings.Contains(m.statusMessage, "successfully"):
 			status = ui.FooterStyle().Foreground(ui.SuccessColor()).Render(m.statusMessage)
+		case strings.HasPrefix(m.statusMessage, "Warning"):
+			status = ui.WarningStyle().Render(m.statusMessage)
 		default:
 			status = ui.FooterStyle().Render(m.statusMessage)
 		}
@@ -125,27 +151,100 @@ func (m Model) renderAppListView() (title, content, footer, status string) {
 		simName = m.appList.selectedSim.Name
 	}
 	appList.Update(filteredApps, m.appList.cursor, m.appList.viewport, m.appList.searchMode, m.appList.searchQuery, simName, &m.config.Keys)
+	appList.SetSelection(m.appList.multiSelect, m.appList.selectedApps)
+	appList.SetSortLabel(m.appList.sortOrder.String())
+	appList.SetDateFormat(simulator.DateFormat(m.config.Display.DateFormat))
 
 	// Get title
 	title = appList.GetTitle(len(m.appList.apps))
 
-	// Get content
+	// Get content and footer
 	// Create content box
 	contentBox := components.NewContentBox(contentWidth, contentHeight)
-	if m.appList.loading {
+	footer = appList.GetFooter()
+	switch {
+	case m.appList.loading:
 		// Show empty content while loading
 		content = contentBox.Render("", "", false)
-	} else {
+	case m.appList.notifications != nil:
+		// The notification panel replaces the list until dismissed
+		panel := components.NewNotificationPanel(contentWidth, contentHeight)
+		panel.Update(m.appList.notifications.appName, m.appList.notifications.info)
+		content = contentBox.Render("", panel.Render(), false)
+		footer = panel.GetFooter()
+	case m.appList.permissions != nil:
+		panel := components.NewPermissionsPanel(contentWidth, contentHeight)
+		panel.Update(m.appList.permissions.app.Name, m.appList.permissions.permissions, m.appList.permissions.cursor, &m.config.Keys)
+		content = contentBox.Render("", panel.Render(), false)
+		footer = panel.GetFooter()
+	case m.appList.push != nil:
+		panel := components.NewPushEditor(contentWidth, contentHeight)
+		panel.Update(m.appList.push.app.Name, m.appList.push.payload, m.appList.push.sending, &m.config.Keys)
+		content = contentBox.Render("", panel.Render(), false)
+		footer = panel.GetFooter()
+	case m.appList.resources != nil:
+		panel := components.NewResourcesPanel(contentWidth, contentHeight)
+		panel.Update(m.appList.resources.simName, m.appList.resources.stats, m.appList.resources.err)
+		content = contentBox.Render("", panel.Render(), false)
+		footer = panel.GetFooter()
+	default:
 		content = contentBox.Render("", appList.Render(), false)
 	}
 
-	// Get footer
-	footer = appList.GetFooter()
-
 	// Get status
 	switch {
 	case m.appList.loading:
 		status = ui.LoadingStyle().Render("Loading apps...")
+	case m.appList.deepLinkMode:
+		status = ui.SearchStyle().Render(fmt.Sprintf("Open URL: %s▏ (enter to open, esc to cancel)", m.appList.deepLinkURL))
+	case m.appList.confirmUninstall:
+		status = ui.WarningStyle().Render(fmt.Sprintf("Uninstall %d selected apps from %s? (y/n)", len(m.appList.selectedApps), simName))
+	case m.appList.exporting != "":
+		frame := spinnerFrames[m.appList.exportFrame%len(spinnerFrames)]
+		status = ui.LoadingStyle().Render(fmt.Sprintf("%s Exporting %s container...", frame, m.appList.exporting))
+	case m.statusMessage != "":
+		if strings.Contains(m.statusMessage, "Error") {
+			status = ui.ErrorStyle().Render(m.statusMessage)
+		} else {
+			status = ui.FooterStyle().Render(m.statusMessage)
+		}
+	default:
+		status = appList.GetStatus()
+	}
+
+	return
+}
+
+// renderAllAppsView renders the apps of every simulator using the app
+// list component in all-apps mode. Load errors are shown in place of
+// the list, since there is no parent view to fall back to.
+func (m Model) renderAllAppsView() (title, content, footer, status string) {
+	filteredApps := m.getFilteredAndSearchedAllApps()
+
+	contentHeight := m.height - 8
+	contentWidth := m.width - 6
+
+	appList := components.NewAppList(contentWidth, contentHeight)
+	appList.Update(filteredApps, m.allApps.cursor, m.allApps.viewport, m.allApps.searchMode, m.allApps.searchQuery, "", &m.config.Keys)
+	appList.SetShowAllSims(true, m.allApps.sortOrder.String())
+	appList.SetDateFormat(simulator.DateFormat(m.config.Display.DateFormat))
+
+	title = appList.GetTitle(len(m.allApps.apps))
+	footer = appList.GetFooter()
+
+	contentBox := components.NewContentBox(contentWidth, contentHeight)
+	switch {
+	case m.allApps.loading:
+		content = contentBox.Render("", "", false)
+	case m.err != nil:
+		content = contentBox.Render("", ui.ErrorStyle().Render(fmt.Sprintf("Error loading apps: %v", m.err)), false)
+	default:
+		content = contentBox.Render("", appList.Render(), false)
+	}
+
+	switch {
+	case m.allApps.loading:
+		status = ui.LoadingStyle().Render("Loading all apps...")
 	case m.statusMessage != "":
 		if strings.Contains(m.statusMessage, "Error") {
 			status = ui.ErrorStyle().Render(m.statusMessage)
@@ -168,37 +267,216 @@ func (m Model) renderFileListView() (title, content, footer, status string) {
 	// Create file list component
 	fileList := components.NewFileList(contentWidth, contentHeight)
 	fileList.Update(m.fileList.files, m.fileList.cursor, m.fileList.viewport, m.fileList.selectedApp, m.fileList.breadcrumbs, &m.config.Keys)
+	fileList.SetNerdFontIcons(m.config.Display.UseNerdFontIcons)
+	fileList.SetDateFormat(simulator.DateFormat(m.config.Display.DateFormat))
+	fileList.SetMinSizeFilter(m.fileList.minSizeFilter)
+	if m.fileList.treeView {
+		visible := simulator.VisibleTreeNodes(m.fileList.tree, m.fileList.treeExpanded)
+		fileList.UpdateTree(visible, m.fileList.treeExpanded, m.fileList.treeCursor, m.fileList.treeViewport)
+	}
 
 	// Get title
 	title = fileList.GetTitle()
+	if m.fileList.bundleResources && m.fileList.selectedApp != nil {
+		title = m.fileList.selectedApp.Name + " Bundle Resources"
+	}
 
 	// Get content
 	// Create content box
 	contentBox := components.NewContentBox(contentWidth, contentHeight)
-	if m.fileList.loading {
+	footer = fileList.GetFooter()
+	switch {
+	case m.fileList.loading || m.fileList.treeLoading:
 		// Show empty content while loading
 		content = contentBox.Render("", "", false)
-	} else {
+	case m.fileList.metadata != nil:
+		// The metadata panel replaces the list until dismissed
+		panel := components.NewMetadataPanel(contentWidth, contentHeight)
+		panel.Update(*m.fileList.metadata)
+		content = contentBox.Render("", panel.Render(), false)
+		footer = panel.GetFooter()
+	default:
 		content = contentBox.Render("", fileList.Render(), false)
 	}
 
-	// Get footer
-	footer = fileList.GetFooter()
-
 	// Get status
 	if m.fileList.loading {
 		status = ui.LoadingStyle().Render("Loading files...")
+	} else if m.fileList.treeLoading {
+		status = ui.LoadingStyle().Render("Loading tree...")
+	} else if m.fileList.confirmDelete && m.fileList.cursor < len(m.fileList.files) {
+		status = ui.WarningStyle().Render(fmt.Sprintf("Delete %s? (y/n)", m.fileList.files[m.fileList.cursor].Name))
+	} else if m.fileList.renameMode {
+		prompt := fmt.Sprintf("Rename to: %s▏ (enter to rename, esc to cancel)", m.fileList.renameName)
+		if m.fileList.renameError != "" {
+			status = ui.ErrorStyle().Render(prompt + " — " + m.fileList.renameError)
+		} else {
+			status = ui.SearchStyle().Render(prompt)
+		}
 	} else if m.statusMessage != "" {
 		if strings.Contains(m.statusMessage, "Error") {
 			status = ui.ErrorStyle().Render(m.statusMessage)
 		} else {
 			status = ui.FooterStyle().Render(m.statusMessage)
 		}
+	} else if m.diffMark != nil {
+		diffKey := config.FormatKeys(m.config.Keys.DiffFiles)
+		marked := m.diffMark.file.Name
+		// Once the list is on another container, say where the mark is
+		if here := m.diffFile(simulator.FileInfo{}); here.sim != m.diffMark.sim {
+			marked = fmt.Sprintf("%s in %s on %s", m.diffMark.relPath, m.diffMark.app, m.diffMark.sim)
+		} else if here.app != m.diffMark.app {
+			marked = fmt.Sprintf("%s in %s", m.diffMark.relPath, m.diffMark.app)
+		}
+		status = ui.SearchStyle().Render(fmt.Sprintf("Diff: %s marked — press %s on another file to compare", marked, diffKey))
 	}
 
 	return
 }
 
+// renderDiffView renders the diff between two files using components
+func (m Model) renderDiffView() (title, content, footer, status string) {
+	contentHeight := m.height - 8
+	contentWidth := m.width - 6
+
+	diffView := components.NewDiffView(contentWidth, contentHeight)
+	diffView.Update(m.diff.oldName, m.diff.newName, m.diff.lines, m.diff.viewport, &m.config.Keys)
+
+	title = diffView.GetTitle()
+
+	contentBox := components.NewContentBox(contentWidth, contentHeight)
+	if m.diff.loading {
+		content = contentBox.Render("", "", false)
+	} else {
+		content = contentBox.Render("", diffView.Render(), false)
+	}
+
+	footer = diffView.GetFooter()
+
+	if m.diff.loading {
+		status = ui.LoadingStyle().Render("Computing diff...")
+	} else if m.statusMessage != "" {
+		status = ui.FooterStyle().Render(m.statusMessage)
+	}
+
+	return
+}
+
+// renderSpawnResultView renders the output of a command run in a
+// simulator with the text file viewer
+func (m Model) renderSpawnResultView() (title, content, footer, status string) {
+	contentHeight := m.height - 8
+	contentWidth := m.width - 6
+
+	output := &simulator.FileContent{
+		Type:       simulator.FileTypeText,
+		Lines:      m.spawn.lines,
+		TotalLines: len(m.spawn.lines),
+	}
+	file := &simulator.FileInfo{Name: m.spawn.result.Command, Size: int64(len(m.spawn.result.Output))}
+	viewer := file_viewer.NewFileViewer(contentWidth, contentHeight)
+	viewer.Update(file, output, m.spawn.viewport, 0, "", &m.config.Keys)
+
+	title = fmt.Sprintf("%s: %s", m.spawn.simName, m.spawn.result.Command)
+
+	contentBox := components.NewContentBox(contentWidth, contentHeight)
+	if len(m.spawn.lines) == 0 {
+		content = contentBox.Render("", ui.DetailStyle().Render("No output"), false)
+	} else {
+		content = contentBox.Render("", viewer.Render(), false)
+	}
+
+	footer = viewer.GetFooter()
+
+	switch {
+	case strings.Contains(m.statusMessage, "Error"):
+		status = ui.ErrorStyle().Render(m.statusMessage)
+	case m.statusMessage != "":
+		status = ui.FooterStyle().Render(m.statusMessage)
+	case m.spawn.result.ExitCode != 0:
+		status = ui.WarningStyle().Render(spawnExitStatus(m.spawn.result))
+	default:
+		status = ui.FooterStyle().Render(spawnExitStatus(m.spawn.result))
+	}
+
+	return
+}
+
+// renderConfirmDeleteView renders the simulator delete prompt over the
+// simulator list's title
+func (m Model) renderConfirmDeleteView() (title, content, footer, status string) {
+	contentHeight := m.height - 8
+	contentWidth := m.width - 6
+
+	title, _, _, _ = m.renderSimulatorListView()
+
+	panel := components.NewConfirmPanel(contentWidth, contentHeight)
+	if sim := m.simList.deleteTarget; sim != nil {
+		panel.Update(fmt.Sprintf("Delete %s?", sim.Name), []string{
+			fmt.Sprintf("%s • %s", sim.Runtime, sim.UDID),
+			"Its apps and data are deleted with it.",
+		})
+	}
+
+	contentBox := components.NewContentBox(contentWidth, contentHeight)
+	content = contentBox.Render("", panel.Render(), false)
+	footer = panel.GetFooter()
+	return
+}
+
+// renderCreateSimulatorView renders the current step of the new
+// simulator form: a list of runtimes or device types, or the name prompt
+func (m Model) renderCreateSimulatorView() (title, content, footer, status string) {
+	contentHeight := m.height - 8
+	contentWidth := m.width - 6
+	contentBox := components.NewContentBox(contentWidth, contentHeight)
+
+	if m.createSim.step == createStepName {
+		title = "New Simulator"
+		summary := fmt.Sprintf("%s\n%s", m.createSim.deviceType.Name, m.createSim.runtime.Name)
+		content = contentBox.Render("", ui.DetailStyle().Render(summary), false)
+		footer = "Enter: create • ESC: back"
+		status = ui.SearchStyle().Render(fmt.Sprintf("Name: %s▏ (enter to create, esc to go back)", m.createSim.name))
+		return
+	}
+
+	var listTitle, loading string
+	var choices []components.Choice
+	switch m.createSim.step {
+	case createStepRuntime:
+		listTitle = "New Simulator: Runtime"
+		loading = "Loading runtimes..."
+		for _, runtime := range m.createSim.runtimes {
+			choices = append(choices, components.Choice{Name: runtime.Name, Detail: "Build " + runtime.BuildVersion})
+		}
+	case createStepDeviceType:
+		listTitle = fmt.Sprintf("New %s Simulator: Device", m.createSim.runtime.Name)
+		loading = "Loading device types..."
+		for _, deviceType := range m.createSim.deviceTypes {
+			choices = append(choices, components.Choice{Name: deviceType.Name, Detail: deviceType.ProductFamily})
+		}
+	}
+
+	choiceList := components.NewChoiceList(contentWidth, contentHeight)
+	choiceList.Update(listTitle, choices, m.createSim.cursor, m.createSim.viewport, &m.config.Keys)
+
+	title = choiceList.GetTitle()
+	footer = choiceList.GetFooter()
+	if m.createSim.loading {
+		content = contentBox.Render("", "", false)
+		status = ui.LoadingStyle().Render(loading)
+		return
+	}
+	content = contentBox.Render("", choiceList.Render(), false)
+	switch {
+	case strings.Contains(m.statusMessage, "Error"):
+		status = ui.ErrorStyle().Render(m.statusMessage)
+	case m.statusMessage != "":
+		status = ui.FooterStyle().Render(m.statusMessage)
+	}
+	return
+}
+
 // renderFileViewerView renders the file viewer using components
 func (m Model) renderFileViewerView() (title, content, footer, status string) {
 	// Calculate available space for content
@@ -242,6 +520,29 @@ func (m Model) renderFileViewerView() (title, content, footer, status string) {
 	return
 }
 
+// renderFileSearchView renders the matches of a search of the viewed
+// file, with the query bar in the status line
+func (m Model) renderFileSearchView() (title, content, footer, status string) {
+	contentHeight := m.height - 8
+	contentWidth := m.width - 6
+
+	searchList := components.NewFileSearchList(contentWidth, contentHeight)
+	searchList.Update(m.fileViewer.file, m.fileSearch.query, m.fileSearch.matches, m.fileSearch.cursor, m.fileSearch.viewport, m.fileSearch.loading, &m.config.Keys)
+
+	title = searchList.GetTitle()
+	contentBox := components.NewContentBox(contentWidth, contentHeight)
+	content = contentBox.Render("", searchList.Render(), false)
+	footer = searchList.GetFooter()
+
+	if strings.Contains(m.statusMessage, "Error") {
+		status = ui.ErrorStyle().Render(m.statusMessage)
+	} else {
+		status = searchList.GetStatus()
+	}
+
+	return
+}
+
 // renderDatabaseTableListView renders the database table list using components
 func (m Model) renderDatabaseTableListView() (title, content, footer, status string) {
 	// Calculate available space
@@ -271,6 +572,8 @@ func (m Model) renderDatabaseTableListView() (title, content, footer, status str
 	// Get status
 	if m.dbTables.loading {
 		status = ui.LoadingStyle().Render("Loading database...")
+	} else if m.dbTables.confirmVacuum {
+		status = ui.WarningStyle().Render(fmt.Sprintf("Vacuum %s? This rewrites the file. (y/n)", m.dbTables.file.Name))
 	} else if m.statusMessage != "" {
 		if strings.Contains(m.statusMessage, "Error") {
 			status = ui.ErrorStyle().Render(m.statusMessage)
@@ -291,6 +594,10 @@ func (m Model) renderDatabaseTableContentView() (title, content, footer, status
 	// Create database table content component
 	tableContent := components.NewDatabaseTableContent(contentWidth, contentHeight)
 	tableContent.Update(m.dbContent.table, m.dbContent.data, m.dbTables.file, m.dbContent.viewport, m.dbContent.offset, &m.config.Keys)
+	tableContent.SetHScroll(m.dbContent.hScroll)
+	tableContent.SetSort(m.dbContent.sortColumnName(), m.dbContent.sortDesc)
+	tableContent.SetSearch(m.dbContent.searchMode, m.dbContent.searchQuery, m.dbContent.matchCount)
+	tableContent.SetHiddenColumns(m.currentHiddenColumns())
 
 	// Get title
 	title = tableContent.GetTitle()
@@ -298,19 +605,274 @@ func (m Model) renderDatabaseTableContentView() (title, content, footer, status
 	// Get content
 	// Create content box
 	contentBox := components.NewContentBox(contentWidth, contentHeight)
-	if m.dbContent.loading {
+	footer = tableContent.GetFooter()
+	switch {
+	case m.dbContent.loading:
+		// Show empty content while loading
+		content = contentBox.Render("", "", false)
+	case m.dbContent.columnPicker:
+		// The column picker replaces the rows until closed
+		picker := components.NewColumnPicker(contentWidth, contentHeight)
+		picker.Update(m.dbContent.table, m.currentHiddenColumns(), m.dbContent.pickerCursor, &m.config.Keys)
+		content = contentBox.Render("", picker.Render(), false)
+		footer = picker.GetFooter()
+	default:
+		content = contentBox.Render("", tableContent.Render(), false)
+	}
+
+	// Get status; the query bar stays up while the rows reload
+	if searchStatus := tableContent.GetStatus(); searchStatus != "" && m.statusMessage == "" {
+		status = searchStatus
+	} else if m.dbContent.loading {
+		status = ui.LoadingStyle().Render("Loading table data...")
+	} else if m.statusMessage != "" {
+		if strings.Contains(m.statusMessage, "Error") {
+			status = ui.ErrorStyle().Render(m.statusMessage)
+		} else {
+			status = ui.FooterStyle().Render(m.statusMessage)
+		}
+	}
+
+	return
+}
+
+// renderDatabaseQueryView renders the SQL query editor and the results
+// of its last query using components
+func (m Model) renderDatabaseQueryView() (title, content, footer, status string) {
+	// Calculate available space
+	contentHeight := m.height - 8
+	contentWidth := m.width - 6
+
+	queryView := components.NewDatabaseQuery(contentWidth, contentHeight)
+	queryView.Update(m.dbTables.file, m.dbQuery.input, m.dbQuery.cursor, &m.config.Keys)
+	queryView.SetResult(m.dbQuery.result, m.dbQuery.viewport, m.dbQuery.hScroll)
+
+	title = queryView.GetTitle()
+
+	contentBox := components.NewContentBox(contentWidth, contentHeight)
+	content = contentBox.Render("", queryView.Render(), false)
+
+	footer = queryView.GetFooter()
+
+	// Get status
+	if m.dbQuery.running {
+		status = ui.LoadingStyle().Render("Running query...")
+	} else if m.statusMessage != "" {
+		if strings.Contains(m.statusMessage, "Error") {
+			status = ui.ErrorStyle().Render(m.statusMessage)
+		} else {
+			status = ui.FooterStyle().Render(m.statusMessage)
+		}
+	} else {
+		status = queryView.GetStatus()
+	}
+
+	return
+}
+
+// renderLogStreamView renders the live simulator or app log view using
+// components
+func (m Model) renderLogStreamView() (title, content, footer, status string) {
+	// Calculate available space
+	contentHeight := m.height - 8
+	contentWidth := m.width - 6
+
+	// Create log stream component
+	logView := components.NewLogStream(contentWidth, contentHeight)
+	logView.Update(m.logStream.app, m.logStream.lines, m.logStream.viewport, m.logStream.following, m.logStream.ended, &m.config.Keys)
+	logView.SetSimulator(m.logStream.sim)
+
+	title = logView.GetTitle()
+
+	contentBox := components.NewContentBox(contentWidth, contentHeight)
+	content = contentBox.Render("", logView.Render(), false)
+
+	footer = logView.GetFooter()
+
+	// Get status
+	if m.statusMessage != "" {
+		if strings.Contains(m.statusMessage, "Error") {
+			status = ui.ErrorStyle().Render(m.statusMessage)
+		} else {
+			status = ui.FooterStyle().Render(m.statusMessage)
+		}
+	} else if m.logStream.stream == nil && !m.logStream.ended {
+		status = ui.LoadingStyle().Render("Starting log stream...")
+	}
+
+	return
+}
+
+// renderCrashLogsView renders an app's crash log list using components
+func (m Model) renderCrashLogsView() (title, content, footer, status string) {
+	// Calculate available space
+	contentHeight := m.height - 8
+	contentWidth := m.width - 6
+
+	// Create crash log list component
+	crashList := components.NewCrashLogList(contentWidth, contentHeight)
+	crashList.Update(m.crashLogs.app, m.crashLogs.logs, m.crashLogs.cursor, m.crashLogs.viewport, &m.config.Keys)
+	crashList.SetDateFormat(simulator.DateFormat(m.config.Display.DateFormat))
+
+	title = crashList.GetTitle()
+
+	contentBox := components.NewContentBox(contentWidth, contentHeight)
+	if m.crashLogs.loading {
+		// Show empty content while loading
+		content = contentBox.Render("", "", false)
+	} else {
+		content = contentBox.Render("", crashList.Render(), false)
+	}
+
+	footer = crashList.GetFooter()
+
+	// Get status
+	if m.crashLogs.loading {
+		status = ui.LoadingStyle().Render("Searching for crash logs...")
+	} else if m.statusMessage != "" {
+		if strings.Contains(m.statusMessage, "Error") {
+			status = ui.ErrorStyle().Render(m.statusMessage)
+		} else {
+			status = ui.FooterStyle().Render(m.statusMessage)
+		}
+	}
+
+	return
+}
+
+// renderURLCacheView renders an app's cached responses using components
+func (m Model) renderURLCacheView() (title, content, footer, status string) {
+	// Calculate available space
+	contentHeight := m.height - 8
+	contentWidth := m.width - 6
+
+	// Create URL cache list component
+	cacheList := components.NewURLCacheList(contentWidth, contentHeight)
+	cacheList.Update(m.urlCache.app, m.urlCache.entries, m.urlCache.cursor, m.urlCache.viewport, &m.config.Keys)
+
+	title = cacheList.GetTitle()
+
+	contentBox := components.NewContentBox(contentWidth, contentHeight)
+	if m.urlCache.loading {
+		// Show empty content while loading
+		content = contentBox.Render("", "", false)
+	} else {
+		content = contentBox.Render("", cacheList.Render(), false)
+	}
+
+	footer = cacheList.GetFooter()
+
+	// Get status
+	if m.urlCache.loading {
+		status = ui.LoadingStyle().Render("Reading URL cache...")
+	} else if m.statusMessage != "" {
+		if strings.Contains(m.statusMessage, "Error") {
+			status = ui.ErrorStyle().Render(m.statusMessage)
+		} else {
+			status = ui.FooterStyle().Render(m.statusMessage)
+		}
+	}
+
+	return
+}
+
+// renderAppGroupsView renders an app's app groups using components
+func (m Model) renderAppGroupsView() (title, content, footer, status string) {
+	// Calculate available space
+	contentHeight := m.height - 8
+	contentWidth := m.width - 6
+
+	// Create app group list component
+	groupList := components.NewAppGroupList(contentWidth, contentHeight)
+	groupList.Update(m.appGroups.app, m.appGroups.groups, m.appGroups.cursor, m.appGroups.viewport, &m.config.Keys)
+
+	title = groupList.GetTitle()
+
+	contentBox := components.NewContentBox(contentWidth, contentHeight)
+	if m.appGroups.loading {
+		// Show empty content while loading
+		content = contentBox.Render("", "", false)
+	} else {
+		content = contentBox.Render("", groupList.Render(), false)
+	}
+
+	footer = groupList.GetFooter()
+
+	// Get status
+	if m.appGroups.loading {
+		status = ui.LoadingStyle().Render("Reading app groups...")
+	} else if m.statusMessage != "" {
+		if strings.Contains(m.statusMessage, "Error") {
+			status = ui.ErrorStyle().Render(m.statusMessage)
+		} else {
+			status = ui.FooterStyle().Render(m.statusMessage)
+		}
+	}
+
+	return
+}
+
+// keychainColumns are the columns of the keychain table
+var keychainColumns = []simulator.ColumnInfo{
+	{Name: "Class"},
+	{Name: "Service"},
+	{Name: "Account"},
+	{Name: "Created"},
+}
+
+// keychainTable converts keychain items into a table and its rows for
+// the database table renderer
+func keychainTable(items []simulator.KeychainItem) (*simulator.TableInfo, []map[string]any) {
+	table := &simulator.TableInfo{
+		Name:     "Keychain",
+		RowCount: int64(len(items)),
+		Columns:  keychainColumns,
+	}
+	rows := make([]map[string]any, len(items))
+	for i, item := range items {
+		created := ""
+		if !item.Created.IsZero() {
+			created = item.Created.Format("2006-01-02 15:04")
+		}
+		rows[i] = map[string]any{
+			"Class":   item.Class,
+			"Service": item.Service,
+			"Account": item.Account,
+			"Created": created,
+		}
+	}
+	return table, rows
+}
+
+// renderKeychainView renders an app's keychain items as a table
+func (m Model) renderKeychainView() (title, content, footer, status string) {
+	// Calculate available space
+	contentHeight := m.height - 8
+	contentWidth := m.width - 6
+
+	table, rows := keychainTable(m.keychain.items)
+	tableContent := components.NewDatabaseTableContent(contentWidth, contentHeight)
+	tableContent.Update(table, rows, nil, m.keychain.viewport, 0, &m.config.Keys)
+	tableContent.SetHScroll(m.keychain.hScroll)
+
+	title = "Keychain"
+	if m.keychain.app != nil {
+		title = fmt.Sprintf("%s Keychain", m.keychain.app.Name)
+	}
+
+	contentBox := components.NewContentBox(contentWidth, contentHeight)
+	if m.keychain.loading {
 		// Show empty content while loading
 		content = contentBox.Render("", "", false)
 	} else {
 		content = contentBox.Render("", tableContent.Render(), false)
 	}
 
-	// Get footer
 	footer = tableContent.GetFooter()
 
 	// Get status
-	if m.dbContent.loading {
-		status = ui.LoadingStyle().Render("Loading table data...")
+	if m.keychain.loading {
+		status = ui.LoadingStyle().Render("Dumping keychain...")
 	} else if m.statusMessage != "" {
 		if strings.Contains(m.statusMessage, "Error") {
 			status = ui.ErrorStyle().Render(m.statusMessage)
@@ -1,9 +1,16 @@
 package tui
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
+
 	"github.com/azizuysal/simtool/internal/config"
 	"github.com/azizuysal/simtool/internal/simulator"
 )
@@ -46,7 +53,7 @@ func TestView(t *testing.T) {
 				width:  80,
 				config: defaultConfig,
 			},
-			contains: []string{"iOS Simulators"},
+			contains: []string{"Simulators"},
 		},
 		{
 			name: "app list view",
@@ -129,8 +136,8 @@ func TestViewStateIntegration(t *testing.T) {
 	// Test SimulatorListView
 	model.viewState = SimulatorListView
 	view := model.View()
-	if !strings.Contains(view, "iOS Simulators") {
-		t.Error("SimulatorListView should show iOS Simulators")
+	if !strings.Contains(view, "Simulators") {
+		t.Error("SimulatorListView should show Simulators")
 	}
 
 	// Test AppListView
@@ -182,6 +189,14 @@ func TestViewLoadingStates(t *testing.T) {
 		t.Error("Should show loading message when loading apps")
 	}
 
+	// Test loading all apps
+	model.viewState = AllAppsView
+	model.allApps.loading = true
+	view = model.View()
+	if !strings.Contains(view, "Loading all apps...") {
+		t.Error("Should show loading message when loading all apps")
+	}
+
 	// Test loading files
 	model.viewState = FileListView
 	model.fileList.loading = true
@@ -237,3 +252,82 @@ func TestViewFilterMode(t *testing.T) {
 		t.Error("Should show filter status when filter is active")
 	}
 }
+
+func TestLineNumberConsistency(t *testing.T) {
+	// Each line holds its own 1-based number, so the viewer's line
+	// numbers can be checked against the text next to them
+	var text strings.Builder
+	for i := 1; i <= 1200; i++ {
+		fmt.Fprintf(&text, "line %d\n", i)
+	}
+	path := filepath.Join(t.TempDir(), "numbers.txt")
+	if err := os.WriteFile(path, []byte(text.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	numbered := regexp.MustCompile(`(\d+) │ line (\d+)`)
+
+	// checkLines fails unless every line on screen is numbered after its
+	// text and the first is contentOffset+contentViewport+1
+	checkLines := func(t *testing.T, m Model) {
+		t.Helper()
+		shown := numbered.FindAllStringSubmatch(m.View(), -1)
+		if len(shown) == 0 {
+			t.Fatal("no numbered lines on screen")
+		}
+		for _, match := range shown {
+			if match[1] != match[2] {
+				t.Errorf("line %s shows the text of line %s", match[1], match[2])
+			}
+		}
+		if want := strconv.Itoa(m.fileViewer.contentOffset + m.fileViewer.contentViewport + 1); shown[0][1] != want {
+			t.Errorf("first line on screen = %s, want %s", shown[0][1], want)
+		}
+	}
+	// fetch runs cmd, a fetchFileContentCmd, and delivers its chunk
+	fetch := func(t *testing.T, m Model, cmd tea.Cmd) Model {
+		t.Helper()
+		msg, ok := cmd().(fetchFileContentMsg)
+		if !ok || msg.err != nil {
+			t.Fatalf("cmd() = %#v, want a loaded chunk", msg)
+		}
+		got, _ := m.Update(msg)
+		return asModel(t, got)
+	}
+
+	m := testModelWithKeyMap()
+	m.viewState = FileViewerView
+	m.fileViewer = fileViewerState{file: &simulator.FileInfo{Name: "numbers.txt", Path: path}, loading: true}
+	m = fetch(t, m, m.fetchFileContentCmd(path, 0, m.width, m.height))
+	if len(m.fileViewer.content.Lines) != textLinesPerChunk || m.fileViewer.contentOffset != 0 {
+		t.Fatalf("first chunk has %d lines at offset %d, want %d at 0", len(m.fileViewer.content.Lines), m.fileViewer.contentOffset, textLinesPerChunk)
+	}
+	checkLines(t, m)
+
+	// Scroll to the bottom of the chunk; the next down loads the next one
+	var cmd tea.Cmd
+	for cmd == nil {
+		var got tea.Model
+		got, cmd = m.handleFileViewerKey("down")
+		m = asModel(t, got)
+	}
+	checkLines(t, fetch(t, m, cmd))
+	m = fetch(t, m, cmd)
+	if m.fileViewer.contentOffset != textLinesPerChunk || m.fileViewer.contentViewport != 0 {
+		t.Errorf("contentOffset = %d, contentViewport = %d; want the next chunk from the top", m.fileViewer.contentOffset, m.fileViewer.contentViewport)
+	}
+
+	// Jumping to the end and straight back asks for two chunks; if the
+	// last chunk arrives late, it is numbered from where it starts
+	got, bottom := m.handleFileViewerKey("scroll_bottom")
+	m = asModel(t, got)
+	got, top := m.handleFileViewerKey("scroll_top")
+	m = asModel(t, got)
+	if bottom == nil || top == nil {
+		t.Fatal("expected a fetch for each jump")
+	}
+	m = fetch(t, fetch(t, m, top), bottom)
+	if m.fileViewer.contentOffset != 1200-textLinesPerChunk {
+		t.Errorf("contentOffset = %d, want %d from the late chunk", m.fileViewer.contentOffset, 1200-textLinesPerChunk)
+	}
+	checkLines(t, m)
+}
@@ -16,27 +16,28 @@ func CalculateItemsPerScreen(height int) int {
 // same "m = m.foo()" pattern as the rest of the package. See the note
 // in model.go about receiver conventions.
 func (m Model) updateViewport() Model {
-	itemsPerScreen := CalculateItemsPerScreen(m.height)
+	// The list components draw 3-line items inside a content box whose
+	// border takes 2 of its lines
+	listItems := listItemsPerScreen(m.height)
 
 	switch m.viewState {
 	case SimulatorListView:
-		// Calculate items per screen the same way SimulatorList does
-		contentHeight := m.height - 8                // Same calculation as in view.go
-		simItemsPerScreen := (contentHeight - 2) / 3 // Same as SimulatorList.calculateItemsPerScreen
-		if simItemsPerScreen < 1 {
-			simItemsPerScreen = 1
-		}
-		updateViewportForList(&m.simList.cursor, &m.simList.viewport, len(m.simList.simulators), simItemsPerScreen)
+		updateViewportForList(&m.simList.cursor, &m.simList.viewport, len(m.simList.simulators), listItems)
 	case AllAppsView:
-		// Each app entry takes 3 lines (name, bundle ID, simulator name)
-		contentHeight := m.height - 8
-		appItemsPerScreen := contentHeight / 3
-		if appItemsPerScreen < 1 {
-			appItemsPerScreen = 1
-		}
-		updateViewportForList(&m.allApps.cursor, &m.allApps.viewport, len(m.allApps.apps), appItemsPerScreen)
+		updateViewportForList(&m.allApps.cursor, &m.allApps.viewport, len(m.allApps.apps), listItems)
 	case AppListView:
-		updateViewportForList(&m.appList.cursor, &m.appList.viewport, len(m.appList.apps), itemsPerScreen)
+		updateViewportForList(&m.appList.cursor, &m.appList.viewport, len(m.appList.apps), listItems)
+	case URLCacheView:
+		updateViewportForList(&m.urlCache.cursor, &m.urlCache.viewport, len(m.urlCache.entries), listItems)
+	case AppGroupsView:
+		updateViewportForList(&m.appGroups.cursor, &m.appGroups.viewport, len(m.appGroups.groups), listItems)
+	case CrashLogsView:
+		updateViewportForList(&m.crashLogs.cursor, &m.crashLogs.viewport, len(m.crashLogs.logs), listItems)
+	case CreateSimulatorView:
+		updateViewportForList(&m.createSim.cursor, &m.createSim.viewport, m.createSimChoiceCount(), listItems)
+	case FileSearchView:
+		// One match per line inside the content box border
+		updateViewportForList(&m.fileSearch.cursor, &m.fileSearch.viewport, len(m.fileSearch.matches), max(m.height-10, 1))
 	case FileListView:
 		// Calculate available height for content box
 		contentHeight := m.height - 8 // Title (4) + Footer (4)
@@ -62,6 +63,15 @@ func (m Model) updateViewport() Model {
 	return m
 }
 
+// listItemsPerScreen returns how many items the list components show in
+// a terminal of the given height, counted the same way as
+// SimulatorList.calculateItemsPerScreen: the content box is height-8
+// lines tall, less 2 for its border
+func listItemsPerScreen(height int) int {
+	contentHeight := height - 8 // Same calculation as in view.go
+	return max((contentHeight-2)/3, 1)
+}
+
 // updateViewportForList updates viewport for any list
 func updateViewportForList(cursor, viewport *int, totalItems, itemsPerScreen int) {
 	// Adjust viewport to keep cursor visible
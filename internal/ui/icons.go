@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Nerd Font glyphs for the file list. They are in the fonts' private use
+// area, so they only render with a patched font installed.
+const (
+	folderIcon   = "\uf07b" // nf-fa-folder
+	fileIcon     = "\uf016" // nf-fa-file_o
+	textIcon     = "\uf0f6" // nf-fa-file_text_o
+	imageIcon    = "\uf1c5" // nf-fa-file_image_o
+	videoIcon    = "\uf1c8" // nf-fa-file_video_o
+	databaseIcon = "\uf1c0" // nf-fa-database
+	archiveIcon  = "\uf1c6" // nf-fa-file_archive_o
+	binaryIcon   = "\uf471" // nf-oct-file_binary
+	jsonIcon     = "\ue60b" // nf-seti-json
+	swiftIcon    = "\ue755" // nf-dev-swift
+	goIcon       = "\ue627" // nf-seti-go
+)
+
+// nerdFontIcons maps lowercase file extensions to their icon
+var nerdFontIcons = map[string]string{
+	// Text
+	".txt": textIcon, ".md": textIcon, ".log": textIcon, ".csv": textIcon,
+	".strings": textIcon, ".plist": textIcon, ".xml": textIcon,
+	".yaml": textIcon, ".yml": textIcon, ".html": textIcon, ".css": textIcon,
+	".ips": textIcon,
+
+	// Images
+	".jpg": imageIcon, ".jpeg": imageIcon, ".png": imageIcon, ".gif": imageIcon,
+	".bmp": imageIcon, ".webp": imageIcon, ".ico": imageIcon, ".svg": imageIcon,
+	".heic": imageIcon,
+
+	// Video
+	".mp4": videoIcon, ".mov": videoIcon, ".m4v": videoIcon, ".avi": videoIcon,
+
+	// Databases
+	".db": databaseIcon, ".sqlite": databaseIcon, ".sqlite3": databaseIcon,
+	".db3": databaseIcon, ".realm": databaseIcon,
+
+	// Archives
+	".zip": archiveIcon, ".jar": archiveIcon, ".ipa": archiveIcon,
+	".apk": archiveIcon, ".aar": archiveIcon, ".gz": archiveIcon,
+	".tar": archiveIcon,
+
+	// Binaries
+	".bin": binaryIcon, ".dat": binaryIcon, ".dylib": binaryIcon,
+	".so": binaryIcon, ".a": binaryIcon, ".o": binaryIcon, ".car": binaryIcon,
+	".wasm": binaryIcon,
+
+	// Source and data formats with their own icon
+	".json":  jsonIcon,
+	".swift": swiftIcon,
+	".go":    goIcon,
+}
+
+// FileIcon returns the Nerd Font icon for a file named name, or the
+// folder icon for a directory. Files of an unknown type get a plain
+// file icon.
+func FileIcon(name string, isDir bool) string {
+	if isDir {
+		return folderIcon
+	}
+	if icon, ok := nerdFontIcons[strings.ToLower(filepath.Ext(name))]; ok {
+		return icon
+	}
+	return fileIcon
+}
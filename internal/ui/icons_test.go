@@ -0,0 +1,28 @@
+package ui
+
+import "testing"
+
+func TestFileIcon(t *testing.T) {
+	tests := []struct {
+		name  string
+		isDir bool
+		want  string
+	}{
+		{"Documents", true, folderIcon},
+		{"photo.PNG", false, imageIcon},
+		{"store.sqlite", false, databaseIcon},
+		{"config.json", false, jsonIcon},
+		{"AppDelegate.swift", false, swiftIcon},
+		{"main.go", false, goIcon},
+		{"notes.txt", false, textIcon},
+		{"backup.zip", false, archiveIcon},
+		{"clip.mov", false, videoIcon},
+		{"libfoo.dylib", false, binaryIcon},
+		{"README", false, fileIcon},
+	}
+	for _, tt := range tests {
+		if got := FileIcon(tt.name, tt.isDir); got != tt.want {
+			t.Errorf("FileIcon(%q, %v) = %q, want %q", tt.name, tt.isDir, got, tt.want)
+		}
+	}
+}
@@ -61,6 +61,20 @@ func ErrorStyle() lipgloss.Style {
 	return lipgloss.NewStyle()
 }
 
+func WarningStyle() lipgloss.Style {
+	if styles != nil {
+		return styles.Warning
+	}
+	return lipgloss.NewStyle()
+}
+
+func SuccessStyle() lipgloss.Style {
+	if styles != nil {
+		return styles.Success
+	}
+	return lipgloss.NewStyle()
+}
+
 func SearchStyle() lipgloss.Style {
 	if styles != nil {
 		return styles.Search
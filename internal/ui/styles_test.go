@@ -18,6 +18,8 @@ func TestStyleFunctions(t *testing.T) {
 		{"ShutdownStyle", ShutdownStyle},
 		{"HeaderStyle", HeaderStyle},
 		{"ErrorStyle", ErrorStyle},
+		{"WarningStyle", WarningStyle},
+		{"SuccessStyle", SuccessStyle},
 		{"SearchStyle", SearchStyle},
 		{"NameStyle", NameStyle},
 		{"DetailStyle", DetailStyle},